@@ -14,6 +14,7 @@ const (
 var DefaultRoles = map[string]string{
 	atc.SaveConfig:                     MemberRole,
 	atc.GetConfig:                      ViewerRole,
+	atc.PlanPipelineConfig:             MemberRole,
 	atc.GetCC:                          ViewerRole,
 	atc.GetBuild:                       ViewerRole,
 	atc.GetBuildPlan:                   ViewerRole,
@@ -27,6 +28,9 @@ var DefaultRoles = map[string]string{
 	atc.CreateJobBuild:                 OperatorRole,
 	atc.RerunJobBuild:                  OperatorRole,
 	atc.SetBuildComment:                OperatorRole,
+	atc.ListBuildApprovals:             ViewerRole,
+	atc.ApproveBuildStep:               OperatorRole,
+	atc.RejectBuildStep:                OperatorRole,
 	atc.ListAllJobs:                    ViewerRole,
 	atc.ListJobs:                       ViewerRole,
 	atc.ListJobBuilds:                  ViewerRole,
@@ -36,6 +40,11 @@ var DefaultRoles = map[string]string{
 	atc.UnpauseJob:                     OperatorRole,
 	atc.ScheduleJob:                    OperatorRole,
 	atc.GetVersionsDB:                  ViewerRole,
+	atc.ListNotificationDeliveries:     ViewerRole,
+	atc.SearchPipelineBuildLogs:        ViewerRole,
+	atc.ListPipelineConfigRevisions:    ViewerRole,
+	atc.GetPipelineConfigRevision:      ViewerRole,
+	atc.RestorePipelineConfigRevision:  MemberRole,
 	atc.JobBadge:                       ViewerRole,
 	atc.MainJobBadge:                   ViewerRole,
 	atc.ClearTaskCache:                 OperatorRole,
@@ -47,6 +56,7 @@ var DefaultRoles = map[string]string{
 	atc.SetPinCommentOnResource:        OperatorRole,
 	atc.CheckResource:                  OperatorRole,
 	atc.CheckResourceWebHook:           OperatorRole,
+	atc.CheckResourcesWebHook:          OperatorRole,
 	atc.CheckResourceType:              OperatorRole,
 	atc.CheckPrototype:                 OperatorRole,
 	atc.ListResourceVersions:           ViewerRole,
@@ -100,6 +110,10 @@ var DefaultRoles = map[string]string{
 	atc.RenameTeam:                     OwnerRole,
 	atc.DestroyTeam:                    OwnerRole,
 	atc.ListTeamBuilds:                 ViewerRole,
+	atc.ListSecrets:                    MemberRole,
+	atc.SetSecret:                      MemberRole,
+	atc.DeleteSecret:                   MemberRole,
+	atc.ListSecretUsages:               ViewerRole,
 	atc.CreateArtifact:                 MemberRole,
 	atc.GetArtifact:                    MemberRole,
 	atc.ListBuildArtifacts:             ViewerRole,
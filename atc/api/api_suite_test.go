@@ -44,40 +44,42 @@ var (
 	"resource_causality": false
 }`
 
-	fakeWorkerPool          *apifakes.FakePool
-	fakeVolumeRepository    *dbfakes.FakeVolumeRepository
-	fakeContainerRepository *dbfakes.FakeContainerRepository
-	fakeDestroyer           *gcfakes.FakeDestroyer
-	dbTeamFactory           *dbfakes.FakeTeamFactory
-	dbPipelineFactory       *dbfakes.FakePipelineFactory
-	dbJobFactory            *dbfakes.FakeJobFactory
-	dbResourceFactory       *dbfakes.FakeResourceFactory
-	dbResourceConfigFactory *dbfakes.FakeResourceConfigFactory
-	fakePipeline            *dbfakes.FakePipeline
-	fakeAccess              *accessorfakes.FakeAccess
-	fakeAccessor            *accessorfakes.FakeAccessFactory
-	dbWorkerFactory         *dbfakes.FakeWorkerFactory
-	dbWorkerTeamFactory     *dbfakes.FakeTeamFactory
-	dbWorkerLifecycle       *dbfakes.FakeWorkerLifecycle
-	fakeDbConn              *dbfakes.FakeDbConn
-	build                   *dbfakes.FakeBuild
-	dbBuildFactory          *dbfakes.FakeBuildFactory
-	dbUserFactory           *dbfakes.FakeUserFactory
-	dbCheckFactory          *dbfakes.FakeCheckFactory
-	dbTeam                  *dbfakes.FakeTeam
-	dbWall                  *dbfakes.FakeWall
-	dbComponentFactory      *dbfakes.FakeComponentFactory
-	fakeSecretManager       *credsfakes.FakeSecrets
-	fakeVarSourcePool       *credsfakes.FakeVarSourcePool
-	fakePolicyChecker       *policycheckerfakes.FakePolicyChecker
-	credsManagers           creds.Managers
-	interceptTimeoutFactory *containerserverfakes.FakeInterceptTimeoutFactory
-	interceptTimeout        *containerserverfakes.FakeInterceptTimeout
-	isTLSEnabled            bool
-	cliDownloadsDir         string
-	logger                  *lagertest.TestLogger
-	fakeClock               *fakeclock.FakeClock
-	dbSigningKeyFactory     *dbfakes.FakeSigningKeyFactory
+	fakeWorkerPool           *apifakes.FakePool
+	fakeVolumeRepository     *dbfakes.FakeVolumeRepository
+	fakeContainerRepository  *dbfakes.FakeContainerRepository
+	fakeDestroyer            *gcfakes.FakeDestroyer
+	dbTeamFactory            *dbfakes.FakeTeamFactory
+	dbPipelineFactory        *dbfakes.FakePipelineFactory
+	dbJobFactory             *dbfakes.FakeJobFactory
+	dbResourceFactory        *dbfakes.FakeResourceFactory
+	dbResourceConfigFactory  *dbfakes.FakeResourceConfigFactory
+	fakePipeline             *dbfakes.FakePipeline
+	fakeAccess               *accessorfakes.FakeAccess
+	fakeAccessor             *accessorfakes.FakeAccessFactory
+	dbWorkerFactory          *dbfakes.FakeWorkerFactory
+	dbWorkerTeamFactory      *dbfakes.FakeTeamFactory
+	dbWorkerLifecycle        *dbfakes.FakeWorkerLifecycle
+	fakeDbConn               *dbfakes.FakeDbConn
+	build                    *dbfakes.FakeBuild
+	dbBuildFactory           *dbfakes.FakeBuildFactory
+	dbUserFactory            *dbfakes.FakeUserFactory
+	dbCheckFactory           *dbfakes.FakeCheckFactory
+	dbTeam                   *dbfakes.FakeTeam
+	dbWall                   *dbfakes.FakeWall
+	dbComponentFactory       *dbfakes.FakeComponentFactory
+	dbSecretUsageFactory     *dbfakes.FakeSecretUsageFactory
+	dbSecretCacheInvalidator *dbfakes.FakeSecretCacheInvalidator
+	fakeSecretManager        *credsfakes.FakeSecrets
+	fakeVarSourcePool        *credsfakes.FakeVarSourcePool
+	fakePolicyChecker        *policycheckerfakes.FakePolicyChecker
+	credsManagers            creds.Managers
+	interceptTimeoutFactory  *containerserverfakes.FakeInterceptTimeoutFactory
+	interceptTimeout         *containerserverfakes.FakeInterceptTimeout
+	isTLSEnabled             bool
+	cliDownloadsDir          string
+	logger                   *lagertest.TestLogger
+	fakeClock                *fakeclock.FakeClock
+	dbSigningKeyFactory      *dbfakes.FakeSigningKeyFactory
 
 	constructedEventHandler *fakeEventHandlerFactory
 
@@ -122,6 +124,8 @@ var _ = BeforeEach(func() {
 	dbCheckFactory = new(dbfakes.FakeCheckFactory)
 	dbWall = new(dbfakes.FakeWall)
 	dbComponentFactory = new(dbfakes.FakeComponentFactory)
+	dbSecretUsageFactory = new(dbfakes.FakeSecretUsageFactory)
+	dbSecretCacheInvalidator = new(dbfakes.FakeSecretCacheInvalidator)
 	dbSigningKeyFactory = new(dbfakes.FakeSigningKeyFactory)
 
 	interceptTimeoutFactory = new(containerserverfakes.FakeInterceptTimeoutFactory)
@@ -238,6 +242,8 @@ func newApiTestServer(opts ...apiConfigOpt) *httptest.Server {
 		dbResourceConfigFactory,
 		dbUserFactory,
 		dbComponentFactory,
+		dbSecretUsageFactory,
+		dbSecretCacheInvalidator,
 		fakeDbConn,
 		testServerConfig.workerCount,
 		testServerConfig.componentStaleMultiplier,
@@ -261,6 +267,7 @@ func newApiTestServer(opts ...apiConfigOpt) *httptest.Server {
 		dbWall,
 		fakeClock,
 		dbSigningKeyFactory,
+		fakePolicyChecker,
 	)
 
 	Expect(err).NotTo(HaveOccurred())
@@ -1181,6 +1181,332 @@ var _ = Describe("Builds API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/builds/:build_id/approvals", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = http.Get(server.URL + "/api/v1/builds/42/approvals")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+
+				dbBuildFactory.BuildForAPIReturns(build, true, nil)
+				build.TeamNameReturns("some-team")
+				build.AllAssociatedTeamNamesReturns([]string{"some-team"})
+				build.JobIDReturns(42)
+				build.JobNameReturns("job1")
+				build.PipelineIDReturns(42)
+			})
+
+			Context("when getting the approvals succeeds", func() {
+				BeforeEach(func() {
+					build.ApprovalsReturns([]db.BuildApproval{
+						{
+							PlanID:    "some-plan-id",
+							Name:      "ship-it",
+							Status:    atc.ApprovalApproved,
+							Users:     []string{"some-user"},
+							DecidedBy: "some-user",
+							CreatedAt: time.Unix(100, 0),
+							DecidedAt: time.Unix(200, 0),
+						},
+					}, nil)
+				})
+
+				It("returns 200 with the approvals", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+					body, err := io.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(body).To(MatchJSON(`[
+						{
+							"plan_id": "some-plan-id",
+							"name": "ship-it",
+							"status": "approved",
+							"users": ["some-user"],
+							"decided_by": "some-user",
+							"created_at": 100,
+							"decided_at": 200
+						}
+					]`))
+				})
+			})
+
+			Context("when getting the approvals fails", func() {
+				BeforeEach(func() {
+					build.ApprovalsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/builds/:build_id/approvals/:plan_id/approve", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/builds/128/approvals/some-plan-id/approve", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				fakeAccess.UserInfoReturns(atc.UserInfo{
+					UserId:        "1234",
+					UserName:      "some-user",
+					Email:         "some-user@example.com",
+					Connector:     "github",
+					DisplayUserId: "some-display-user",
+				})
+
+				build.TeamNameReturns("some-team")
+				build.AllAssociatedTeamNamesReturns([]string{"some-team"})
+				build.IsRunningReturns(true)
+				dbBuildFactory.BuildForAPIReturns(build, true, nil)
+			})
+
+			Context("when the approval does not exist", func() {
+				BeforeEach(func() {
+					build.ApprovalReturns(db.BuildApproval{}, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when the approval is pending", func() {
+				var approval db.BuildApproval
+
+				BeforeEach(func() {
+					approval = db.BuildApproval{
+						PlanID: "some-plan-id",
+						Name:   "ship-it",
+						Status: atc.ApprovalPending,
+					}
+				})
+
+				Context("when it is not restricted", func() {
+					BeforeEach(func() {
+						build.ApprovalReturns(approval, true, nil)
+						build.DecideApprovalReturns(true, nil)
+					})
+
+					It("returns 204", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+					})
+
+					It("approves it as the requester", func() {
+						Expect(build.ApprovalArgsForCall(0)).To(Equal(atc.PlanID("some-plan-id")))
+
+						Expect(build.DecideApprovalCallCount()).To(Equal(1))
+						planID, status, decidedBy := build.DecideApprovalArgsForCall(0)
+						Expect(planID).To(Equal(atc.PlanID("some-plan-id")))
+						Expect(status).To(Equal(atc.ApprovalApproved))
+						Expect(decidedBy).To(Equal("some-display-user"))
+					})
+
+					Context("when it was decided concurrently", func() {
+						BeforeEach(func() {
+							build.DecideApprovalReturns(false, nil)
+						})
+
+						It("returns 409", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusConflict))
+						})
+					})
+
+					Context("when the build is no longer running", func() {
+						BeforeEach(func() {
+							build.IsRunningReturns(false)
+						})
+
+						It("returns 409", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusConflict))
+							Expect(build.DecideApprovalCallCount()).To(Equal(0))
+						})
+					})
+				})
+
+				Context("when it is restricted to users", func() {
+					BeforeEach(func() {
+						approval.Users = []string{"github:some-user"}
+						build.ApprovalReturns(approval, true, nil)
+						build.DecideApprovalReturns(true, nil)
+					})
+
+					It("allows a listed user", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+					})
+
+					Context("when the requester is listed by user ID", func() {
+						BeforeEach(func() {
+							approval.Users = []string{"github:1234"}
+							build.ApprovalReturns(approval, true, nil)
+						})
+
+						It("returns 204", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+						})
+					})
+
+					Context("when a user of the same name on another connector is listed", func() {
+						BeforeEach(func() {
+							approval.Users = []string{"local:some-user"}
+							build.ApprovalReturns(approval, true, nil)
+						})
+
+						It("returns 403", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+							Expect(build.DecideApprovalCallCount()).To(Equal(0))
+						})
+					})
+
+					Context("when only the requester's name or email is listed", func() {
+						BeforeEach(func() {
+							approval.Users = []string{"some-user", "some-user@example.com", "some-display-user"}
+							build.ApprovalReturns(approval, true, nil)
+						})
+
+						It("returns 403", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+							Expect(build.DecideApprovalCallCount()).To(Equal(0))
+						})
+					})
+
+					Context("when the requester is not listed", func() {
+						BeforeEach(func() {
+							approval.Users = []string{"some-other-user"}
+							build.ApprovalReturns(approval, true, nil)
+						})
+
+						It("returns 403", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+							Expect(build.DecideApprovalCallCount()).To(Equal(0))
+						})
+					})
+				})
+
+				Context("when it is restricted to roles", func() {
+					BeforeEach(func() {
+						approval.Roles = []string{"owner"}
+						build.ApprovalReturns(approval, true, nil)
+						build.DecideApprovalReturns(true, nil)
+					})
+
+					Context("when the requester has the role on the build's team", func() {
+						BeforeEach(func() {
+							fakeAccess.TeamRolesReturns(map[string][]string{
+								"some-team": {"owner"},
+							})
+						})
+
+						It("returns 204", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+						})
+					})
+
+					Context("when the requester does not have the role", func() {
+						BeforeEach(func() {
+							fakeAccess.TeamRolesReturns(map[string][]string{
+								"some-team":  {"member"},
+								"other-team": {"owner"},
+							})
+						})
+
+						It("returns 403", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+						})
+					})
+				})
+			})
+
+			Context("when the approval was already decided", func() {
+				BeforeEach(func() {
+					build.ApprovalReturns(db.BuildApproval{
+						PlanID: "some-plan-id",
+						Status: atc.ApprovalRejected,
+					}, true, nil)
+				})
+
+				It("returns 409", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusConflict))
+				})
+			})
+
+			Context("when getting the approval fails", func() {
+				BeforeEach(func() {
+					build.ApprovalReturns(db.BuildApproval{}, false, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/builds/:build_id/approvals/:plan_id/reject", func() {
+		var response *http.Response
+
+		BeforeEach(func() {
+			fakeAccess.IsAuthenticatedReturns(true)
+			fakeAccess.IsAuthorizedReturns(true)
+			fakeAccess.UserInfoReturns(atc.UserInfo{DisplayUserId: "some-display-user"})
+
+			build.TeamNameReturns("some-team")
+			build.AllAssociatedTeamNamesReturns([]string{"some-team"})
+			build.IsRunningReturns(true)
+			build.ApprovalReturns(db.BuildApproval{
+				PlanID: "some-plan-id",
+				Status: atc.ApprovalPending,
+			}, true, nil)
+			build.DecideApprovalReturns(true, nil)
+			dbBuildFactory.BuildForAPIReturns(build, true, nil)
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/builds/128/approvals/some-plan-id/reject", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("rejects the approval", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+
+			Expect(build.DecideApprovalCallCount()).To(Equal(1))
+			_, status, decidedBy := build.DecideApprovalArgsForCall(0)
+			Expect(status).To(Equal(atc.ApprovalRejected))
+			Expect(decidedBy).To(Equal("some-display-user"))
+		})
+	})
+
 	Describe("GET /api/v1/builds/:build_id/preparation", func() {
 		var response *http.Response
 
@@ -1199,6 +1525,7 @@ var _ = Describe("Builds API", func() {
 					PausedPipeline:   db.BuildPreparationStatusNotBlocking,
 					PausedJob:        db.BuildPreparationStatusNotBlocking,
 					MaxRunningBuilds: db.BuildPreparationStatusBlocking,
+					ConcurrencyGroup: db.BuildPreparationStatusNotBlocking,
 					Inputs: map[string]db.BuildPreparationStatus{
 						"foo": db.BuildPreparationStatusNotBlocking,
 						"bar": db.BuildPreparationStatusBlocking,
@@ -1340,6 +1667,7 @@ var _ = Describe("Builds API", func() {
 					"paused_pipeline": "not_blocking",
 					"paused_job": "not_blocking",
 					"max_running_builds": "blocking",
+					"concurrency_group": "not_blocking",
 					"inputs": {
 						"foo": "not_blocking",
 						"bar": "blocking"
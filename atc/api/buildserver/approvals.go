@@ -0,0 +1,156 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+
+	"code.cloudfoundry.org/lager/v3"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ListBuildApprovals(build db.BuildForAPI) http.Handler {
+	logger := s.logger.Session("list-build-approvals", build.LagerData())
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		approvals, err := build.Approvals()
+		if err != nil {
+			logger.Error("failed-to-get-approvals", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		presented := []atc.BuildApproval{}
+		for _, approval := range approvals {
+			presented = append(presented, present.BuildApproval(approval))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(presented)
+		if err != nil {
+			logger.Error("failed-to-encode-approvals", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+func (s *Server) ApproveBuildStep(build db.BuildForAPI) http.Handler {
+	return s.decideApproval(build, atc.ApprovalApproved)
+}
+
+func (s *Server) RejectBuildStep(build db.BuildForAPI) http.Handler {
+	return s.decideApproval(build, atc.ApprovalRejected)
+}
+
+func (s *Server) decideApproval(build db.BuildForAPI, status atc.ApprovalStatus) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		planID := atc.PlanID(r.FormValue(":plan_id"))
+
+		logger := s.logger.Session("decide-approval", lager.Data{
+			"build":   build.ID(),
+			"plan-id": planID,
+			"status":  status,
+		})
+
+		approval, found, err := build.Approval(planID)
+		if err != nil {
+			logger.Error("failed-to-get-approval", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		acc := accessor.GetAccessor(r)
+		if !canDecideApproval(acc, build.TeamName(), approval) {
+			logger.Info("not-an-approver")
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		if approval.Status != atc.ApprovalPending || !build.IsRunning() {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
+		decided, err := build.DecideApproval(planID, status, acc.UserInfo().DisplayUserId)
+		if err != nil {
+			logger.Error("failed-to-decide-approval", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !decided {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// canDecideApproval returns whether the requester is one of the approval's
+// users or holds one of its roles on the build's team. Any requester may
+// decide an approval which restricts neither.
+//
+// Users are given in the same form as in team auth, qualified by the
+// connector they log in with, e.g. "github:some-user" or "local:some-user".
+// They match the requester's user ID or username from that connector only, so
+// that someone with the same name on another connector cannot approve.
+func canDecideApproval(acc accessor.Access, teamName string, approval db.BuildApproval) bool {
+	if len(approval.Users) == 0 && len(approval.Roles) == 0 {
+		return true
+	}
+
+	approverIDs := connectorUserIDs(acc.UserInfo())
+	for _, user := range approval.Users {
+		for _, id := range approverIDs {
+			if strings.EqualFold(user, id) {
+				return true
+			}
+		}
+	}
+
+	teamRoles := acc.TeamRoles()[teamName]
+	for _, role := range approval.Roles {
+		if slices.Contains(teamRoles, role) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// connectorUserIDs returns the connector-qualified IDs of the user, the same
+// as those matched against the users of team auth.
+func connectorUserIDs(userInfo atc.UserInfo) []string {
+	connector := userInfo.Connector
+	if connector == "" {
+		return nil
+	}
+
+	if strings.EqualFold(connector, "cloudfoundry") {
+		connector = "cf"
+	}
+
+	userName := userInfo.UserName
+	if userName == "" {
+		userName = userInfo.Name
+	}
+
+	var ids []string
+	for _, id := range []string{userInfo.UserId, userName} {
+		if id != "" {
+			ids = append(ids, connector+":"+id)
+		}
+	}
+
+	return ids
+}
@@ -513,13 +513,26 @@ var _ = Describe("Config API", func() {
 						It("saves it initially paused", func() {
 							Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
 
-							ref, savedConfig, id, initiallyPaused := dbTeam.SavePipelineArgsForCall(0)
+							ref, savedConfig, id, initiallyPaused, _ := dbTeam.SavePipelineArgsForCall(0)
 							Expect(ref.Name).To(Equal("a-pipeline"))
 							Expect(savedConfig).To(Equal(pipelineConfig))
 							Expect(id).To(Equal(db.ConfigVersion(42)))
 							Expect(initiallyPaused).To(BeTrue())
 						})
 
+						Context("when the user is known", func() {
+							BeforeEach(func() {
+								fakeAccess.UserInfoReturns(atc.UserInfo{DisplayUserId: "some-user"})
+							})
+
+							It("records who saved it", func() {
+								Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+
+								_, _, _, _, savedBy := dbTeam.SavePipelineArgsForCall(0)
+								Expect(savedBy).To(Equal("some-user"))
+							})
+						})
+
 						Context("and saving it fails", func() {
 							BeforeEach(func() {
 								dbTeam.SavePipelineReturns(nil, false, errors.New("oh no!"))
@@ -534,6 +547,24 @@ var _ = Describe("Config API", func() {
 							})
 						})
 
+						Context("and a passed constraint names an unknown job in another pipeline", func() {
+							BeforeEach(func() {
+								dbTeam.SavePipelineReturns(nil, false, db.PassedJobNotFoundError{Passed: "other-pipeline/some-job"})
+							})
+
+							It("returns 400", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+							})
+
+							It("returns the error in the response body", func() {
+								Expect(io.ReadAll(response.Body)).To(MatchJSON(`{
+									"errors": [
+										"no matching job(s) for passed constraint 'other-pipeline/some-job'"
+									]
+								}`))
+							})
+						})
+
 						Context("when it's the first time the pipeline has been created", func() {
 							BeforeEach(func() {
 								returnedPipeline := new(dbfakes.FakePipeline)
@@ -611,7 +642,7 @@ var _ = Describe("Config API", func() {
 						It("saves it initially paused", func() {
 							Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
 
-							ref, savedConfig, id, initiallyPaused := dbTeam.SavePipelineArgsForCall(0)
+							ref, savedConfig, id, initiallyPaused, _ := dbTeam.SavePipelineArgsForCall(0)
 							Expect(ref.Name).To(Equal("a-pipeline"))
 							Expect(savedConfig).To(Equal(pipelineConfig))
 							Expect(id).To(Equal(db.ConfigVersion(42)))
@@ -658,7 +689,7 @@ jobs:
 							It("saves it", func() {
 								Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
 
-								ref, savedConfig, id, initiallyPaused := dbTeam.SavePipelineArgsForCall(0)
+								ref, savedConfig, id, initiallyPaused, _ := dbTeam.SavePipelineArgsForCall(0)
 								Expect(ref.Name).To(Equal("a-pipeline"))
 								Expect(savedConfig).To(Equal(atc.Config{
 									Resources: []atc.ResourceConfig{
@@ -967,7 +998,7 @@ jobs:
 									It("passes validation and saves it un-interpolated", func() {
 										Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
 
-										ref, savedConfig, id, initiallyPaused := dbTeam.SavePipelineArgsForCall(0)
+										ref, savedConfig, id, initiallyPaused, _ := dbTeam.SavePipelineArgsForCall(0)
 										Expect(ref.Name).To(Equal("a-pipeline"))
 										Expect(savedConfig).To(Equal(payloadAsConfig))
 										Expect(id).To(Equal(db.ConfigVersion(42)))
@@ -1117,7 +1148,7 @@ jobs:
 								It("saves an instanced pipeline", func() {
 									Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
 
-									ref, _, _, _ := dbTeam.SavePipelineArgsForCall(0)
+									ref, _, _, _, _ := dbTeam.SavePipelineArgsForCall(0)
 									Expect(ref).To(Equal(atc.PipelineRef{
 										Name:         "a-pipeline",
 										InstanceVars: atc.InstanceVars{"branch": "feature"},
@@ -1217,7 +1248,7 @@ jobs:
 					It("saves it", func() {
 						Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
 
-						ref, savedConfig, id, initiallyPaused := dbTeam.SavePipelineArgsForCall(0)
+						ref, savedConfig, id, initiallyPaused, _ := dbTeam.SavePipelineArgsForCall(0)
 						Expect(ref.Name).To(Equal("a-pipeline"))
 						Expect(savedConfig).To(Equal(atc.Config{
 							Jobs: atc.JobConfigs{
@@ -1319,4 +1350,148 @@ jobs:
 			})
 		})
 	})
+
+	Describe("POST /api/v1/teams/:team_name/pipelines/:name/config/plan", func() {
+		var (
+			request  *http.Request
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			var err error
+			request, err = requestGenerator.CreateRequest(atc.PlanPipelineConfig, rata.Params{
+				"team_name":     "a-team",
+				"pipeline_name": "a-pipeline",
+			}, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			request.Header.Set("Content-Type", "application/json")
+
+			payload, err := json.Marshal(pipelineConfig)
+			Expect(err).NotTo(HaveOccurred())
+
+			request.Body = gbytes.BufferWithBytes(payload)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when the pipeline exists", func() {
+				BeforeEach(func() {
+					fakePipeline.PlanConfigReturns(atc.ConfigPlan{
+						RecheckedResources: []atc.ConfigPlanResource{
+							{Name: "some-resource", Reason: "source changed"},
+						},
+						RemovedJobs: []atc.ConfigPlanJob{
+							{Name: "some-old-job", Builds: 12},
+						},
+						OrphanedPins: []atc.ConfigPlanVersion{
+							{Resource: "some-resource", Version: atc.Version{"ref": "v1"}, Reason: "source changed"},
+						},
+						OrphanedDisabledVersions: []atc.ConfigPlanVersion{},
+						PassedConstraints: []atc.ConfigPlanPassedChange{
+							{Job: "some-job", Input: "some-input", Before: []string{"some-old-job"}, After: []string{}},
+						},
+					}, nil)
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns Content-Type 'application/json'", func() {
+					Expect(response).Should(IncludeHeaderEntries(map[string]string{
+						"Content-Type": "application/json",
+					}))
+				})
+
+				It("plans the config against the pipeline", func() {
+					Expect(dbTeam.PipelineCallCount()).To(Equal(1))
+					Expect(dbTeam.PipelineArgsForCall(0)).To(Equal(atc.PipelineRef{Name: "a-pipeline"}))
+
+					Expect(fakePipeline.PlanConfigCallCount()).To(Equal(1))
+					Expect(fakePipeline.PlanConfigArgsForCall(0)).To(Equal(pipelineConfig))
+				})
+
+				It("returns the plan", func() {
+					Expect(io.ReadAll(response.Body)).To(MatchJSON(`{
+						"rechecked_resources": [{"name": "some-resource", "reason": "source changed"}],
+						"removed_jobs": [{"name": "some-old-job", "builds": 12}],
+						"orphaned_pins": [{"resource": "some-resource", "version": {"ref": "v1"}, "reason": "source changed"}],
+						"orphaned_disabled_versions": [],
+						"passed_constraints": [{"job": "some-job", "input": "some-input", "before": ["some-old-job"], "after": []}]
+					}`))
+				})
+
+				It("does not save the config", func() {
+					Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+				})
+
+				Context("when planning fails", func() {
+					BeforeEach(func() {
+						fakePipeline.PlanConfigReturns(atc.ConfigPlan{}, errors.New("oh no"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when the pipeline does not exist yet", func() {
+				BeforeEach(func() {
+					dbTeam.PipelineReturns(nil, false, nil)
+				})
+
+				It("returns an empty plan", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(io.ReadAll(response.Body)).To(MatchJSON(`{
+						"rechecked_resources": [],
+						"removed_jobs": [],
+						"orphaned_pins": [],
+						"orphaned_disabled_versions": [],
+						"passed_constraints": []
+					}`))
+				})
+			})
+
+			Context("when the config is invalid", func() {
+				BeforeEach(func() {
+					pipelineConfig.Jobs = append(pipelineConfig.Jobs, pipelineConfig.Jobs[0])
+
+					payload, err := json.Marshal(pipelineConfig)
+					Expect(err).NotTo(HaveOccurred())
+
+					request.Body = gbytes.BufferWithBytes(payload)
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+
+				It("does not plan it", func() {
+					Expect(fakePipeline.PlanConfigCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
 })
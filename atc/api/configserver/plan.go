@@ -0,0 +1,103 @@
+package configserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"code.cloudfoundry.org/lager/v3"
+	"github.com/concourse/concourse/atc"
+	. "github.com/concourse/concourse/atc/api/helpers"
+	"github.com/concourse/concourse/atc/configvalidate"
+	"github.com/tedsuo/rata"
+)
+
+// PlanPipelineConfig reports what saving the config would do to the existing
+// pipeline, without saving it.
+func (s *Server) PlanPipelineConfig(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("plan-config")
+
+	var config atc.Config
+	switch r.Header.Get("Content-type") {
+	case "application/json", "application/x-yaml":
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			HandleBadRequest(w, fmt.Sprintf("read failed: %s", err))
+			return
+		}
+
+		err = atc.UnmarshalConfig(body, &config)
+		if err != nil {
+			logger.Info("malformed-request-payload", lager.Data{"error": err.Error()})
+			HandleBadRequest(w, fmt.Sprintf("malformed config: %s", err))
+			return
+		}
+	default:
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+		return
+	}
+
+	_, errorMessages := configvalidate.Validate(config)
+	if len(errorMessages) > 0 {
+		HandleBadRequest(w, errorMessages...)
+		return
+	}
+
+	teamName := rata.Param(r, "team_name")
+	pipelineName := rata.Param(r, "pipeline_name")
+	pipelineRef := atc.PipelineRef{Name: pipelineName}
+	var err error
+	pipelineRef.InstanceVars, err = atc.InstanceVarsFromQueryParams(r.URL.Query())
+	if err != nil {
+		logger.Error("malformed-instance-vars", err)
+		HandleBadRequest(w, fmt.Sprintf("instance vars are malformed: %v", err))
+		return
+	}
+
+	team, found, err := s.teamFactory.FindTeam(teamName)
+	if err != nil {
+		logger.Error("failed-to-find-team", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		logger.Debug("team-not-found", lager.Data{"team": teamName})
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	pipeline, found, err := team.Pipeline(pipelineRef)
+	if err != nil {
+		logger.Error("failed-to-find-pipeline", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	// a new pipeline has no existing state for the config to affect
+	plan := atc.ConfigPlan{
+		RecheckedResources:       []atc.ConfigPlanResource{},
+		RemovedJobs:              []atc.ConfigPlanJob{},
+		OrphanedPins:             []atc.ConfigPlanVersion{},
+		OrphanedDisabledVersions: []atc.ConfigPlanVersion{},
+		PassedConstraints:        []atc.ConfigPlanPassedChange{},
+	}
+
+	if found {
+		plan, err = pipeline.PlanConfig(config)
+		if err != nil {
+			logger.Error("failed-to-plan-config", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(plan)
+	if err != nil {
+		logger.Error("failed-to-encode-plan", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,144 @@
+package configserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager/v3"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+	. "github.com/concourse/concourse/atc/api/helpers"
+	"github.com/concourse/concourse/atc/configvalidate"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/policy"
+	"github.com/tedsuo/rata"
+)
+
+// RestoreConfigRevision saves the config of one of the pipeline's previous
+// revisions as a new revision. The config goes through the same validation,
+// credential check and policy check as configs saved by SaveConfig, as it may
+// no longer be acceptable.
+func (s *Server) RestoreConfigRevision(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("restore-config-revision")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		revisionNumber, err := strconv.Atoi(rata.Param(r, "revision"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintln(w, "revision must be an integer")
+			return
+		}
+
+		revision, found, err := pipeline.ConfigRevision(revisionNumber)
+		if err != nil {
+			logger.Error("failed-to-get-config-revision", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_, errorMessages := configvalidate.Validate(revision.Config)
+		if len(errorMessages) > 0 {
+			logger.Info("ignoring-invalid-config", lager.Data{"errors": errorMessages})
+			HandleBadRequest(w, errorMessages...)
+			return
+		}
+
+		if _, exists := r.URL.Query()[atc.SaveConfigCheckCreds]; exists {
+			variables := creds.NewVariables(s.secretManager, creds.SecretLookupParams{Team: pipeline.TeamName(), Pipeline: pipeline.Name()}, false)
+
+			errs := validateCredParams(variables, revision.Config, logger)
+			if errs != nil {
+				HandleBadRequest(w, fmt.Sprintf("credential validation failed\n\n%s", errs))
+				return
+			}
+		}
+
+		if !s.checkConfigPolicy(logger, w, r, revision.Config) {
+			return
+		}
+
+		acc := accessor.GetAccessor(r)
+		found, err = pipeline.RestoreConfigRevision(revisionNumber, acc.UserInfo().DisplayUserId)
+		if err != nil {
+			if errors.Is(err, db.ErrConfigComparisonFailed) {
+				w.WriteHeader(http.StatusConflict)
+				fmt.Fprintln(w, "pipeline was configured while restoring the revision, try again")
+				return
+			}
+
+			var passedErr db.PassedJobNotFoundError
+			if errors.As(err, &passedErr) {
+				logger.Info("rejecting-unknown-passed-job", lager.Data{"error": err.Error()})
+				HandleBadRequest(w, err.Error())
+				return
+			}
+
+			logger.Error("failed-to-restore-config-revision", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		err = s.teamFactory.NotifyResourceScanner()
+		if err != nil {
+			logger.Error("failed-to-notify-resource-scanner", err)
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+// checkConfigPolicy runs the policy check that saving the config with
+// SaveConfig would have run, responding and returning false if the config is
+// not allowed.
+func (s *Server) checkConfigPolicy(logger lager.Logger, w http.ResponseWriter, r *http.Request, config atc.Config) bool {
+	payload, err := json.Marshal(config)
+	if err != nil {
+		logger.Error("failed-to-marshal-config", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return false
+	}
+
+	checkRequest := r.Clone(r.Context())
+	checkRequest.Header.Set("Content-Type", "application/json")
+	checkRequest.Body = io.NopCloser(bytes.NewReader(payload))
+
+	result, err := s.policyChecker.Check(atc.SaveConfig, accessor.GetAccessor(r), checkRequest)
+	if err != nil {
+		logger.Error("policy-checker", err)
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, "policy-checker: unreachable or misconfigured")
+		return false
+	}
+
+	if !result.Allowed() {
+		policyCheckErr := policy.PolicyCheckNotPass{
+			Messages: result.Messages(),
+		}
+
+		if result.ShouldBlock() {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, policyCheckErr.Error())
+			return false
+		}
+
+		w.Header().Add("X-Concourse-Policy-Check-Warning", policyCheckErr.Error())
+	}
+
+	return true
+}
@@ -2,12 +2,14 @@ package configserver
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 
 	"code.cloudfoundry.org/lager/v3"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
 	. "github.com/concourse/concourse/atc/api/helpers"
 	"github.com/concourse/concourse/atc/configvalidate"
 	"github.com/concourse/concourse/atc/creds"
@@ -123,8 +125,16 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	_, created, err := team.SavePipeline(pipelineRef, config, version, true)
+	acc := accessor.GetAccessor(r)
+	_, created, err := team.SavePipeline(pipelineRef, config, version, true, acc.UserInfo().DisplayUserId)
 	if err != nil {
+		var passedErr db.PassedJobNotFoundError
+		if errors.As(err, &passedErr) {
+			session.Info("rejecting-unknown-passed-job", lager.Data{"error": err.Error()})
+			HandleBadRequest(w, err.Error())
+			return
+		}
+
 		session.Error("failed-to-save-config", err)
 		w.WriteHeader(http.StatusInternalServerError)
 		fmt.Fprintf(w, "failed to save config: %s", err)
@@ -171,6 +181,18 @@ func validateCredParams(credMgrVars vars.Variables, config atc.Config, session l
 		if err != nil {
 			errs = multierror.Append(errs, err)
 		}
+
+		if resource.Webhook != nil {
+			_, err = creds.NewString(credMgrVars, resource.Webhook.Secret).Evaluate()
+			if err != nil {
+				errs = multierror.Append(errs, err)
+			}
+
+			_, err = creds.NewString(credMgrVars, resource.Webhook.Token).Evaluate()
+			if err != nil {
+				errs = multierror.Append(errs, err)
+			}
+		}
 	}
 
 	for _, job := range config.Jobs {
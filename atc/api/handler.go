@@ -21,8 +21,10 @@ import (
 	"github.com/concourse/concourse/atc/api/jobserver"
 	"github.com/concourse/concourse/atc/api/loglevelserver"
 	"github.com/concourse/concourse/atc/api/pipelineserver"
+	"github.com/concourse/concourse/atc/api/policychecker"
 	"github.com/concourse/concourse/atc/api/resourceserver"
 	"github.com/concourse/concourse/atc/api/resourceserver/versionserver"
+	"github.com/concourse/concourse/atc/api/secretserver"
 	"github.com/concourse/concourse/atc/api/teamserver"
 	"github.com/concourse/concourse/atc/api/usersserver"
 	"github.com/concourse/concourse/atc/api/volumeserver"
@@ -67,6 +69,8 @@ func NewHandler(
 	dbResourceConfigFactory db.ResourceConfigFactory,
 	dbUserFactory db.UserFactory,
 	dbComponentFactory db.ComponentFactory,
+	dbSecretUsageFactory db.SecretUsageFactory,
+	dbSecretCacheInvalidator db.SecretCacheInvalidator,
 	dbConn db.DbConn,
 	minWorkerCount int,
 	componentStaleMultiplier float64,
@@ -90,6 +94,7 @@ func NewHandler(
 	dbWall db.Wall,
 	clock clock.Clock,
 	dbSigningKeyFactory db.SigningKeyFactory,
+	policyChecker policychecker.PolicyChecker,
 ) (http.Handler, error) {
 
 	absCLIDownloadsDir, err := filepath.Abs(cliDownloadsDir)
@@ -107,7 +112,7 @@ func NewHandler(
 
 	versionServer := versionserver.NewServer(logger, externalURL)
 	pipelineServer := pipelineserver.NewServer(logger, dbTeamFactory, dbPipelineFactory, externalURL)
-	configServer := configserver.NewServer(logger, dbTeamFactory, secretManager)
+	configServer := configserver.NewServer(logger, dbTeamFactory, secretManager, policyChecker)
 	ccServer := ccserver.NewServer(logger, dbTeamFactory, externalURL)
 	workerServer := workerserver.NewServer(logger, workerTeamFactory, dbWorkerFactory)
 	logLevelServer := loglevelserver.NewServer(logger, sink)
@@ -121,14 +126,16 @@ func NewHandler(
 	usersServer := usersserver.NewServer(logger, dbUserFactory)
 	wallServer := wallserver.NewServer(dbWall, logger)
 	componentServer := componentsserver.NewServer(logger, dbComponentFactory)
+	secretServer := secretserver.NewServer(logger, dbSecretUsageFactory, dbSecretCacheInvalidator)
 	if oidcIssuer == "" {
 		oidcIssuer = externalURL
 	}
 	idTokenServer := idtokenserver.NewServer(logger, oidcIssuer, dbSigningKeyFactory)
 
 	handlers := map[string]http.Handler{
-		atc.GetConfig:  http.HandlerFunc(configServer.GetConfig),
-		atc.SaveConfig: http.HandlerFunc(configServer.SaveConfig),
+		atc.GetConfig:          http.HandlerFunc(configServer.GetConfig),
+		atc.SaveConfig:         http.HandlerFunc(configServer.SaveConfig),
+		atc.PlanPipelineConfig: http.HandlerFunc(configServer.PlanPipelineConfig),
 
 		atc.GetCC: http.HandlerFunc(ccServer.GetCC),
 
@@ -142,6 +149,9 @@ func NewHandler(
 		atc.BuildEvents:         buildHandlerFactory.HandlerFor(buildServer.BuildEvents),
 		atc.ListBuildArtifacts:  buildHandlerFactory.HandlerFor(buildServer.GetBuildArtifacts),
 		atc.SetBuildComment:     buildHandlerFactory.HandlerFor(buildServer.SetBuildComment),
+		atc.ListBuildApprovals:  buildHandlerFactory.HandlerFor(buildServer.ListBuildApprovals),
+		atc.ApproveBuildStep:    buildHandlerFactory.HandlerFor(buildServer.ApproveBuildStep),
+		atc.RejectBuildStep:     buildHandlerFactory.HandlerFor(buildServer.RejectBuildStep),
 
 		atc.ListAllJobs:    http.HandlerFunc(jobServer.ListAllJobs),
 		atc.ListJobs:       pipelineHandlerFactory.HandlerFor(jobServer.ListJobs),
@@ -179,6 +189,13 @@ func NewHandler(
 		atc.CreatePipelineBuild:       pipelineHandlerFactory.HandlerFor(pipelineServer.CreateBuild),
 		atc.PipelineBadge:             pipelineHandlerFactory.HandlerFor(pipelineServer.PipelineBadge),
 
+		atc.ListNotificationDeliveries: pipelineHandlerFactory.HandlerFor(pipelineServer.ListNotificationDeliveries),
+		atc.SearchPipelineBuildLogs:    pipelineHandlerFactory.HandlerFor(pipelineServer.SearchBuildLogs),
+
+		atc.ListPipelineConfigRevisions:   pipelineHandlerFactory.HandlerFor(pipelineServer.ListConfigRevisions),
+		atc.GetPipelineConfigRevision:     pipelineHandlerFactory.HandlerFor(pipelineServer.GetConfigRevision),
+		atc.RestorePipelineConfigRevision: pipelineHandlerFactory.HandlerFor(configServer.RestoreConfigRevision),
+
 		atc.ListAllResources:          http.HandlerFunc(resourceServer.ListAllResources),
 		atc.ListSharedForResource:     pipelineHandlerFactory.HandlerFor(resourceServer.ListSharedForResource),
 		atc.ListSharedForResourceType: pipelineHandlerFactory.HandlerFor(resourceServer.ListSharedForResourceType),
@@ -189,6 +206,7 @@ func NewHandler(
 		atc.SetPinCommentOnResource:   pipelineHandlerFactory.HandlerFor(resourceServer.SetPinCommentOnResource),
 		atc.CheckResource:             pipelineHandlerFactory.HandlerFor(resourceServer.CheckResource),
 		atc.CheckResourceWebHook:      pipelineHandlerFactory.HandlerFor(resourceServer.CheckResourceWebHook),
+		atc.CheckResourcesWebHook:     teamHandlerFactory.HandlerFor(resourceServer.CheckResourcesWebHook),
 		atc.CheckResourceType:         pipelineHandlerFactory.HandlerFor(resourceServer.CheckResourceType),
 		atc.CheckPrototype:            pipelineHandlerFactory.HandlerFor(resourceServer.CheckPrototype),
 		atc.ClearResourceCache:        pipelineHandlerFactory.HandlerFor(resourceServer.ClearResourceCache),
@@ -241,6 +259,12 @@ func NewHandler(
 		atc.DestroyTeam:    teamHandlerFactory.HandlerFor(teamServer.DestroyTeam),
 		atc.ListTeamBuilds: teamHandlerFactory.HandlerFor(teamServer.ListTeamBuilds),
 
+		atc.ListSecrets:      teamHandlerFactory.HandlerFor(secretServer.ListSecrets),
+		atc.SetSecret:        teamHandlerFactory.HandlerFor(secretServer.SetSecret),
+		atc.DeleteSecret:     teamHandlerFactory.HandlerFor(secretServer.DeleteSecret),
+		atc.ListSecretUsages: http.HandlerFunc(secretServer.ListSecretUsages),
+		atc.ClearSecretCache: http.HandlerFunc(secretServer.ClearSecretCache),
+
 		atc.CreateArtifact: teamHandlerFactory.HandlerFor(artifactServer.CreateArtifact),
 		atc.GetArtifact:    teamHandlerFactory.HandlerFor(artifactServer.GetArtifact),
 
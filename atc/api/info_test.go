@@ -10,7 +10,10 @@ import (
 	"code.cloudfoundry.org/lager/v3"
 	secretsmanagertypes "github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
 	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/creds/credhub"
+	"github.com/concourse/concourse/atc/creds/dummy"
+	"github.com/concourse/concourse/atc/creds/postgres"
 	"github.com/concourse/concourse/atc/creds/secretsmanager"
 	"github.com/concourse/concourse/atc/creds/secretsmanager/secretsmanagerfakes"
 	"github.com/concourse/concourse/atc/creds/ssm"
@@ -457,5 +460,35 @@ var _ = Describe("Pipelines API", func() {
 			})
 
 		})
+
+		Context("when several credential managers are chained", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(true)
+
+				credsManagers["dummy"] = creds.ChainedManager{
+					Manager:  &dummy.Manager{Vars: []dummy.VarFlag{{Name: "foo", Value: "bar"}}},
+					Position: 2,
+				}
+
+				credsManagers["postgres"] = creds.ChainedManager{
+					Manager:  &postgres.Manager{Enabled: true},
+					Position: 1,
+				}
+			})
+
+			It("reports the health and position of each manager", func() {
+				Expect(body).To(MatchJSON(`{
+					"dummy": {
+						"health": {"method": "noop"},
+						"position": 2
+					},
+					"postgres": {
+						"health": {"method": "postgres"},
+						"position": 1
+					}
+				}`))
+			})
+		})
 	})
 })
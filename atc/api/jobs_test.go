@@ -503,6 +503,20 @@ var _ = Describe("Jobs API", func() {
 								})
 							})
 
+							Context("when the job has a schedule", func() {
+								BeforeEach(func() {
+									fakeJob.NextScheduledTriggerReturns(time.Unix(1709690400, 0))
+								})
+
+								It("returns when the job is next triggered", func() {
+									var job atc.Job
+									err := json.NewDecoder(response.Body).Decode(&job)
+									Expect(err).NotTo(HaveOccurred())
+
+									Expect(job.NextScheduledTrigger).To(Equal(int64(1709690400)))
+								})
+							})
+
 							Context("when getting the job's builds fails", func() {
 								BeforeEach(func() {
 									fakeJob.FinishedAndNextBuildReturns(nil, nil, errors.New("oh no!"))
@@ -1297,6 +1311,41 @@ var _ = Describe("Jobs API", func() {
 				]`))
 					})
 
+					Context("when a build is a cell of a matrix build", func() {
+						BeforeEach(func() {
+							cell := new(dbfakes.FakeBuildForAPI)
+							cell.IDReturns(5)
+							cell.NameReturns("2-1")
+							cell.JobNameReturns("some-job")
+							cell.PipelineNameReturns("some-pipeline")
+							cell.TeamNameReturns("some-team")
+							cell.StatusReturns(db.BuildStatusPending)
+							cell.ParentBuildIDReturns(4)
+							cell.MatrixValuesReturns(atc.MatrixValues{"go": "1.22"})
+
+							fakeJob.BuildsReturns([]db.BuildForAPI{cell}, db.Pagination{}, nil)
+						})
+
+						It("returns its parent and matrix values", func() {
+							body, err := io.ReadAll(response.Body)
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(body).To(MatchJSON(`[
+					{
+						"id": 5,
+						"name": "2-1",
+						"job_name": "some-job",
+						"status": "pending",
+						"api_url": "/api/v1/builds/5",
+						"pipeline_name": "some-pipeline",
+						"team_name": "some-team",
+						"parent_build_id": 4,
+						"matrix_values": {"go": "1.22"}
+					}
+				]`))
+						})
+					})
+
 					Context("when next/previous pages are available", func() {
 						BeforeEach(func() {
 							fakeJob.BuildsReturns(returnedBuilds, db.Pagination{
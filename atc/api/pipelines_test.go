@@ -10,8 +10,11 @@ import (
 	"time"
 
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/policy"
+	"github.com/concourse/concourse/atc/policy/policyfakes"
 	. "github.com/concourse/concourse/atc/testhelpers"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -855,6 +858,23 @@ var _ = Describe("Pipelines API", func() {
 						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
 					})
 				})
+
+				Context("when other pipelines pass through the pipeline's jobs", func() {
+					BeforeEach(func() {
+						fakeTeam.PipelineReturns(dbPipeline, true, nil)
+						dbPipeline.DestroyReturns(db.PipelineReferencedError{
+							Pipelines: []atc.PipelineRef{{Name: "downstream-pipeline"}},
+						})
+					})
+
+					It("returns 409 naming them", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusConflict))
+
+						body, err := io.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(string(body)).To(ContainSubstring("downstream-pipeline"))
+					})
+				})
 			})
 
 			Context("when requester does not belong to the team", func() {
@@ -1531,6 +1551,15 @@ var _ = Describe("Pipelines API", func() {
 									ScopeID: &scopeID,
 								},
 							},
+							Dependencies: []atc.DebugDependency{
+								{
+									JobID:        13,
+									InputName:    "some-input-name",
+									ResourceID:   77,
+									PassedJobID:  42,
+									PassedJobRef: "other-pipeline/other-job",
+								},
+							},
 						},
 						nil,
 					)
@@ -1610,6 +1639,15 @@ var _ = Describe("Pipelines API", func() {
 						"Name": "resource-128",
 						"ScopeID": 789
 					}
+				],
+				"Dependencies": [
+					{
+						"JobID": 13,
+						"InputName": "some-input-name",
+						"ResourceID": 77,
+						"PassedJobID": 42,
+						"PassedJobRef": "other-pipeline/other-job"
+					}
 				]
 				}`))
 				})
@@ -1649,6 +1687,331 @@ var _ = Describe("Pipelines API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/notification-deliveries", func() {
+		var response *http.Response
+		var query string
+
+		BeforeEach(func() {
+			query = ""
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("GET", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/notification-deliveries"+query, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+				fakeTeam.PipelineReturns(dbPipeline, true, nil)
+			})
+
+			Context("when getting the deliveries works", func() {
+				BeforeEach(func() {
+					query = "?status=failed&limit=5"
+
+					fakeDelivery := new(dbfakes.FakeNotificationDelivery)
+					fakeDelivery.IDReturns(3)
+					fakeDelivery.NameReturns("slack")
+					fakeDelivery.StatusReturns(atc.NotificationDeliveryFailed)
+					fakeDelivery.BuildIDReturns(42)
+					fakeDelivery.BuildNameReturns("7")
+					fakeDelivery.JobNameReturns("some-job")
+					fakeDelivery.BuildStatusReturns(db.BuildStatusFailed)
+					fakeDelivery.PreviousStatusReturns(db.BuildStatusSucceeded)
+					fakeDelivery.AttemptsReturns(5)
+					fakeDelivery.ResponseCodeReturns(502)
+					fakeDelivery.LastErrorReturns("unexpected response: 502 Bad Gateway")
+					fakeDelivery.CreatedAtReturns(time.Unix(100, 0))
+					fakeDelivery.UpdatedAtReturns(time.Unix(200, 0))
+
+					dbPipeline.NotificationDeliveriesReturns([]db.NotificationDelivery{fakeDelivery}, nil)
+				})
+
+				It("filters by the given statuses and limit", func() {
+					Expect(dbPipeline.NotificationDeliveriesCallCount()).To(Equal(1))
+					statuses, limit := dbPipeline.NotificationDeliveriesArgsForCall(0)
+					Expect(statuses).To(Equal([]atc.NotificationDeliveryStatus{atc.NotificationDeliveryFailed}))
+					Expect(limit).To(Equal(5))
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns the deliveries", func() {
+					body, err := io.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"id": 3,
+							"name": "slack",
+							"status": "failed",
+							"build_id": 42,
+							"build_name": "7",
+							"job_name": "some-job",
+							"build_status": "failed",
+							"previous_status": "succeeded",
+							"attempts": 5,
+							"response_code": 502,
+							"last_error": "unexpected response: 502 Bad Gateway",
+							"created_at": 100,
+							"updated_at": 200
+						}
+					]`))
+				})
+			})
+
+			Context("when getting the deliveries fails", func() {
+				BeforeEach(func() {
+					dbPipeline.NotificationDeliveriesReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/logs/search", func() {
+		var response *http.Response
+		var query string
+
+		BeforeEach(func() {
+			query = "?pattern=FAIL"
+
+			fakePipeline.SearchBuildLogsReturns(db.BuildLogSearchResult{
+				Matches: []db.BuildLogMatch{
+					{
+						BuildID:   42,
+						BuildName: "7",
+						JobName:   "some-job",
+						PlanID:    "some-plan",
+						StepName:  "unit",
+						Line:      12,
+						Text:      "FAIL: some-test",
+						Time:      time.Unix(100, 0),
+					},
+				},
+				Truncated:      true,
+				ArchivedBuilds: 3,
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/logs/search" + query)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			It("returns 200", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+
+			It("returns the matches", func() {
+				body, err := io.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`{
+					"matches": [
+						{
+							"build_id": 42,
+							"build_name": "7",
+							"job_name": "some-job",
+							"plan_id": "some-plan",
+							"step_name": "unit",
+							"line": 12,
+							"text": "FAIL: some-test",
+							"time": 100
+						}
+					],
+					"truncated": true,
+					"archived_builds": 3
+				}`))
+			})
+
+			It("searches for the literal pattern with the default limit", func() {
+				Expect(fakePipeline.SearchBuildLogsCallCount()).To(Equal(1))
+				search := fakePipeline.SearchBuildLogsArgsForCall(0)
+				Expect(search.Pattern.String()).To(Equal("FAIL"))
+				Expect(search.Jobs).To(BeEmpty())
+				Expect(search.From.IsZero()).To(BeTrue())
+				Expect(search.To.IsZero()).To(BeTrue())
+				Expect(search.Limit).To(Equal(100))
+			})
+
+			Context("when the pattern has regex characters", func() {
+				BeforeEach(func() {
+					query = "?pattern=a.b"
+				})
+
+				It("escapes them", func() {
+					search := fakePipeline.SearchBuildLogsArgsForCall(0)
+					Expect(search.Pattern.MatchString("a.b")).To(BeTrue())
+					Expect(search.Pattern.MatchString("axb")).To(BeFalse())
+				})
+
+				Context("when regex is enabled", func() {
+					BeforeEach(func() {
+						query += "&regex=true"
+					})
+
+					It("uses the pattern as a regex", func() {
+						search := fakePipeline.SearchBuildLogsArgsForCall(0)
+						Expect(search.Pattern.MatchString("axb")).To(BeTrue())
+					})
+				})
+			})
+
+			Context("when all the params are passed", func() {
+				BeforeEach(func() {
+					query = "?pattern=FAIL&job=some-job&job=other-job&from=10&to=20&limit=5"
+				})
+
+				It("passes them through", func() {
+					search := fakePipeline.SearchBuildLogsArgsForCall(0)
+					Expect(search.Jobs).To(Equal([]string{"some-job", "other-job"}))
+					Expect(search.From).To(Equal(time.Unix(10, 0)))
+					Expect(search.To).To(Equal(time.Unix(20, 0)))
+					Expect(search.Limit).To(Equal(5))
+				})
+			})
+
+			Context("when the limit is too large", func() {
+				BeforeEach(func() {
+					query = "?pattern=FAIL&limit=100000"
+				})
+
+				It("caps it", func() {
+					search := fakePipeline.SearchBuildLogsArgsForCall(0)
+					Expect(search.Limit).To(Equal(1000))
+				})
+			})
+
+			Context("when the pattern is missing", func() {
+				BeforeEach(func() {
+					query = ""
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(fakePipeline.SearchBuildLogsCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the regex is invalid", func() {
+				BeforeEach(func() {
+					query = "?pattern=(&regex=true"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+
+					body, err := io.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(body)).To(ContainSubstring("invalid pattern"))
+				})
+			})
+
+			Context("when a timestamp is invalid", func() {
+				BeforeEach(func() {
+					query = "?pattern=FAIL&from=yesterday"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when searching fails", func() {
+				BeforeEach(func() {
+					fakePipeline.SearchBuildLogsReturns(db.BuildLogSearchResult{}, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			Context("and the pipeline is private", func() {
+				BeforeEach(func() {
+					fakePipeline.PublicReturns(false)
+				})
+
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+			})
+
+			Context("and the pipeline is public", func() {
+				BeforeEach(func() {
+					fakePipeline.PublicReturns(true)
+
+					publicJob := new(dbfakes.FakeJob)
+					publicJob.NameReturns("public-job")
+					publicJob.PublicReturns(true)
+
+					privateJob := new(dbfakes.FakeJob)
+					privateJob.NameReturns("private-job")
+
+					fakePipeline.JobsReturns(db.Jobs{publicJob, privateJob}, nil)
+				})
+
+				It("only searches the public jobs", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					search := fakePipeline.SearchBuildLogsArgsForCall(0)
+					Expect(search.Jobs).To(Equal([]string{"public-job"}))
+				})
+
+				Context("when only private jobs are requested", func() {
+					BeforeEach(func() {
+						query += "&job=private-job"
+					})
+
+					It("returns no matches without searching", func() {
+						Expect(fakePipeline.SearchBuildLogsCallCount()).To(Equal(0))
+
+						body, err := io.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(body).To(MatchJSON(`{"matches":[],"truncated":false}`))
+					})
+				})
+			})
+		})
+	})
+
 	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/rename", func() {
 		var response *http.Response
 		var requestBody string
@@ -2085,4 +2448,348 @@ var _ = Describe("Pipelines API", func() {
 			})
 		})
 	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/config/revisions", func() {
+		var response *http.Response
+
+		BeforeEach(func() {
+			fakePipeline.ConfigRevisionsReturns([]db.PipelineConfigRevision{
+				{
+					Revision:          2,
+					ConfigVersion:     42,
+					CreatedAt:         time.Unix(200, 0),
+					BuildID:           12,
+					BuildName:         "3",
+					BuildJobName:      "set-self",
+					BuildPipelineName: "some-pipeline",
+				},
+				{
+					Revision:      1,
+					ConfigVersion: 41,
+					CreatedBy:     "some-user",
+					CreatedAt:     time.Unix(100, 0),
+				},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/config/revisions")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			It("returns the revisions", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				body, err := io.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`[
+					{
+						"revision": 2,
+						"config_version": 42,
+						"created_at": 200,
+						"build_id": 12,
+						"build_name": "3",
+						"build_job_name": "set-self",
+						"build_pipeline_name": "some-pipeline"
+					},
+					{
+						"revision": 1,
+						"config_version": 41,
+						"created_by": "some-user",
+						"created_at": 100
+					}
+				]`))
+			})
+
+			Context("when getting the revisions fails", func() {
+				BeforeEach(func() {
+					fakePipeline.ConfigRevisionsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/config/revisions/:revision", func() {
+		var (
+			response *http.Response
+			revision string
+		)
+
+		BeforeEach(func() {
+			revision = "1"
+
+			fakePipeline.ConfigRevisionReturns(db.PipelineConfigRevision{
+				Revision:      1,
+				ConfigVersion: 41,
+				CreatedBy:     "some-user",
+				CreatedAt:     time.Unix(100, 0),
+				Config: atc.Config{
+					Jobs: atc.JobConfigs{{Name: "some-job"}},
+				},
+			}, true, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/config/revisions/" + revision)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			It("returns the revision with its config", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(fakePipeline.ConfigRevisionArgsForCall(0)).To(Equal(1))
+
+				var presented atc.PipelineConfigRevision
+				err := json.NewDecoder(response.Body).Decode(&presented)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(presented.Revision).To(Equal(1))
+				Expect(presented.CreatedBy).To(Equal("some-user"))
+				Expect(presented.Config).ToNot(BeNil())
+				Expect(presented.Config.Jobs[0].Name).To(Equal("some-job"))
+			})
+
+			Context("when the revision is not found", func() {
+				BeforeEach(func() {
+					fakePipeline.ConfigRevisionReturns(db.PipelineConfigRevision{}, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when the revision is not a number", func() {
+				BeforeEach(func() {
+					revision = "latest"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/config/revisions/:revision/restore", func() {
+		var (
+			response *http.Response
+			query    string
+		)
+
+		BeforeEach(func() {
+			query = ""
+
+			fakePipeline.ConfigRevisionReturns(db.PipelineConfigRevision{
+				Revision: 3,
+				Config: atc.Config{
+					Jobs: atc.JobConfigs{
+						{
+							Name:         "some-job",
+							PlanSequence: []atc.Step{{Config: &atc.TaskStep{Name: "some-task", ConfigPath: "some/task.yml"}}},
+						},
+					},
+				},
+			}, true, nil)
+			fakePipeline.RestoreConfigRevisionReturns(true, nil)
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/config/revisions/3/restore"+query, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				fakeAccess.UserInfoReturns(atc.UserInfo{DisplayUserId: "some-user"})
+			})
+
+			It("restores the revision as the user", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				Expect(fakePipeline.RestoreConfigRevisionCallCount()).To(Equal(1))
+				revision, restoredBy := fakePipeline.RestoreConfigRevisionArgsForCall(0)
+				Expect(revision).To(Equal(3))
+				Expect(restoredBy).To(Equal("some-user"))
+			})
+
+			It("notifies the resource scanner", func() {
+				Expect(dbTeamFactory.NotifyResourceScannerCallCount()).To(Equal(1))
+			})
+
+			It("looks up the revision", func() {
+				Expect(fakePipeline.ConfigRevisionCallCount()).To(Equal(1))
+				Expect(fakePipeline.ConfigRevisionArgsForCall(0)).To(Equal(3))
+			})
+
+			Context("when the revision is not found", func() {
+				BeforeEach(func() {
+					fakePipeline.ConfigRevisionReturns(db.PipelineConfigRevision{}, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					Expect(fakePipeline.RestoreConfigRevisionCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the revision's config is no longer valid", func() {
+				BeforeEach(func() {
+					fakePipeline.ConfigRevisionReturns(db.PipelineConfigRevision{
+						Revision: 3,
+						Config: atc.Config{
+							Jobs: atc.JobConfigs{{Name: "some-job"}, {Name: "some-job"}},
+						},
+					}, true, nil)
+				})
+
+				It("returns 400 without restoring it", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(fakePipeline.RestoreConfigRevisionCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when credentials are checked", func() {
+				BeforeEach(func() {
+					query = "?check_creds"
+
+					fakePipeline.ConfigRevisionReturns(db.PipelineConfigRevision{
+						Revision: 3,
+						Config: atc.Config{
+							Resources: atc.ResourceConfigs{
+								{Name: "some-resource", Type: "git", Source: atc.Source{"uri": "((uri))"}},
+							},
+							Jobs: atc.JobConfigs{
+								{
+									Name:         "some-job",
+									PlanSequence: []atc.Step{{Config: &atc.GetStep{Name: "some-resource"}}},
+								},
+							},
+						},
+					}, true, nil)
+				})
+
+				Context("when they are missing", func() {
+					BeforeEach(func() {
+						fakeSecretManager.GetReturns(nil, nil, false, nil)
+					})
+
+					It("returns 400 without restoring it", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						Expect(fakePipeline.RestoreConfigRevisionCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when they exist", func() {
+					BeforeEach(func() {
+						fakeSecretManager.GetReturns("some-uri", nil, true, nil)
+					})
+
+					It("restores it", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(fakePipeline.RestoreConfigRevisionCallCount()).To(Equal(1))
+					})
+				})
+			})
+
+			Context("when the config does not pass the policy check for saving it", func() {
+				BeforeEach(func() {
+					fakeResult := new(policyfakes.FakePolicyCheckResult)
+					fakeResult.AllowedReturns(false)
+					fakeResult.ShouldBlockReturns(true)
+					fakeResult.MessagesReturns([]string{"no tasks allowed"})
+
+					fakePolicyChecker.CheckStub = func(action string, _ accessor.Access, req *http.Request) (policy.PolicyCheckResult, error) {
+						if action != atc.SaveConfig {
+							return policy.PassedPolicyCheck(), nil
+						}
+
+						var config map[string]any
+						err := json.NewDecoder(req.Body).Decode(&config)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(config).To(HaveKey("jobs"))
+
+						return fakeResult, nil
+					}
+				})
+
+				It("returns 403 without restoring it", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					Expect(io.ReadAll(response.Body)).To(ContainSubstring("no tasks allowed"))
+					Expect(fakePipeline.RestoreConfigRevisionCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the pipeline was configured in the meantime", func() {
+				BeforeEach(func() {
+					fakePipeline.RestoreConfigRevisionReturns(false, db.ErrConfigComparisonFailed)
+				})
+
+				It("returns 409", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusConflict))
+				})
+			})
+
+			Context("when a passed constraint names a job which no longer exists", func() {
+				BeforeEach(func() {
+					fakePipeline.RestoreConfigRevisionReturns(false, db.PassedJobNotFoundError{Passed: "other-pipeline/some-job"})
+				})
+
+				It("returns 400 with the error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+
+					body, err := io.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(body)).To(ContainSubstring("no matching job(s) for passed constraint 'other-pipeline/some-job'"))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				Expect(fakePipeline.RestoreConfigRevisionCallCount()).To(BeZero())
+			})
+		})
+	})
 })
@@ -0,0 +1,75 @@
+package pipelineserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/tedsuo/rata"
+)
+
+func (s *Server) ListConfigRevisions(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("list-config-revisions")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		revisions, err := pipeline.ConfigRevisions()
+		if err != nil {
+			logger.Error("failed-to-get-config-revisions", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		presented := make([]atc.PipelineConfigRevision, len(revisions))
+		for i, revision := range revisions {
+			presented[i] = present.PipelineConfigRevision(revision)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		err = json.NewEncoder(w).Encode(presented)
+		if err != nil {
+			logger.Error("failed-to-encode-config-revisions", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+func (s *Server) GetConfigRevision(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("get-config-revision")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		revisionNumber, err := strconv.Atoi(rata.Param(r, "revision"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintln(w, "revision must be an integer")
+			return
+		}
+
+		revision, found, err := pipeline.ConfigRevision(revisionNumber)
+		if err != nil {
+			logger.Error("failed-to-get-config-revision", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		presented := present.PipelineConfigRevision(revision)
+		presented.Config = &revision.Config
+
+		w.Header().Set("Content-Type", "application/json")
+
+		err = json.NewEncoder(w).Encode(presented)
+		if err != nil {
+			logger.Error("failed-to-encode-config-revision", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
@@ -1,6 +1,8 @@
 package pipelineserver
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 
 	"code.cloudfoundry.org/lager/v3"
@@ -17,6 +19,14 @@ func (s *Server) DeletePipeline(pipelineDB db.Pipeline) http.Handler {
 
 		err := pipelineDB.Destroy()
 		if err != nil {
+			var referencedErr db.PipelineReferencedError
+			if errors.As(err, &referencedErr) {
+				logger.Info("pipeline-is-referenced", lager.Data{"error": err.Error()})
+				w.WriteHeader(http.StatusConflict)
+				fmt.Fprintln(w, err.Error())
+				return
+			}
+
 			logger.Error("failed", err)
 
 			w.WriteHeader(http.StatusInternalServerError)
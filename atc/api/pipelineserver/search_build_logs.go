@@ -0,0 +1,142 @@
+package pipelineserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+)
+
+const (
+	buildLogSearchDefaultLimit = 100
+	buildLogSearchMaxLimit     = 1000
+)
+
+func (s *Server) SearchBuildLogs(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("search-build-logs")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pattern := r.FormValue("pattern")
+		if pattern == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintln(w, "pattern must be specified")
+			return
+		}
+
+		if r.FormValue("regex") != "true" {
+			pattern = regexp.QuoteMeta(pattern)
+		}
+
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "invalid pattern: %s\n", err)
+			return
+		}
+
+		search := db.BuildLogSearch{
+			Pattern: re,
+			Jobs:    r.Form["job"],
+			Limit:   buildLogSearchDefaultLimit,
+		}
+
+		if urlLimit := r.FormValue(atc.PaginationQueryLimit); urlLimit != "" {
+			search.Limit, err = strconv.Atoi(urlLimit)
+			if err != nil || search.Limit <= 0 {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprintln(w, "limit must be a positive integer")
+				return
+			}
+
+			search.Limit = min(search.Limit, buildLogSearchMaxLimit)
+		}
+
+		for param, t := range map[string]*time.Time{
+			atc.PaginationQueryFrom: &search.From,
+			atc.PaginationQueryTo:   &search.To,
+		} {
+			value := r.FormValue(param)
+			if value == "" {
+				continue
+			}
+
+			unix, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprintf(w, "%s must be a unix timestamp\n", param)
+				return
+			}
+
+			*t = time.Unix(unix, 0)
+		}
+
+		// the pipeline may be public while the requester is not a member of its
+		// team, in which case only the logs of its public jobs are visible
+		acc := accessor.GetAccessor(r)
+		if !acc.IsAuthorized(pipeline.TeamName()) {
+			jobs, err := pipeline.Jobs()
+			if err != nil {
+				logger.Error("failed-to-get-jobs", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			var publicJobs []string
+			for _, job := range jobs {
+				if !job.Public() {
+					continue
+				}
+
+				if len(search.Jobs) > 0 && !slices.Contains(search.Jobs, job.Name()) {
+					continue
+				}
+
+				publicJobs = append(publicJobs, job.Name())
+			}
+
+			if len(publicJobs) == 0 {
+				s.writeBuildLogSearchResults(w, atc.BuildLogSearchResults{Matches: []atc.BuildLogMatch{}})
+				return
+			}
+
+			search.Jobs = publicJobs
+		}
+
+		result, err := pipeline.SearchBuildLogs(search)
+		if err != nil {
+			logger.Error("failed-to-search-build-logs", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		results := atc.BuildLogSearchResults{
+			Matches:        []atc.BuildLogMatch{},
+			Truncated:      result.Truncated,
+			ArchivedBuilds: result.ArchivedBuilds,
+		}
+
+		for _, match := range result.Matches {
+			results.Matches = append(results.Matches, present.BuildLogMatch(match))
+		}
+
+		s.writeBuildLogSearchResults(w, results)
+	})
+}
+
+func (s *Server) writeBuildLogSearchResults(w http.ResponseWriter, results atc.BuildLogSearchResults) {
+	w.Header().Set("Content-Type", "application/json")
+
+	err := json.NewEncoder(w).Encode(results)
+	if err != nil {
+		s.logger.Error("failed-to-encode-build-log-search-results", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
@@ -30,6 +30,8 @@ func Build(build db.BuildForAPI, job db.Job, access accessor.Access) atc.Build {
 		TeamName:             build.TeamName(),
 		Status:               atc.BuildStatus(build.Status()),
 		APIURL:               apiURL,
+		ParentBuildID:        build.ParentBuildID(),
+		MatrixValues:         build.MatrixValues(),
 		CreatedBy:            build.CreatedBy(),
 	}
 
@@ -0,0 +1,24 @@
+package present
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func BuildApproval(approval db.BuildApproval) atc.BuildApproval {
+	atcApproval := atc.BuildApproval{
+		PlanID:    approval.PlanID,
+		Name:      approval.Name,
+		Status:    approval.Status,
+		Users:     approval.Users,
+		Roles:     approval.Roles,
+		DecidedBy: approval.DecidedBy,
+		CreatedAt: approval.CreatedAt.Unix(),
+	}
+
+	if !approval.DecidedAt.IsZero() {
+		atcApproval.DecidedAt = approval.DecidedAt.Unix()
+	}
+
+	return atcApproval
+}
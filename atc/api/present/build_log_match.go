@@ -0,0 +1,19 @@
+package present
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func BuildLogMatch(match db.BuildLogMatch) atc.BuildLogMatch {
+	return atc.BuildLogMatch{
+		BuildID:   match.BuildID,
+		BuildName: match.BuildName,
+		JobName:   match.JobName,
+		PlanID:    match.PlanID,
+		StepName:  match.StepName,
+		Line:      match.Line,
+		Text:      match.Text,
+		Time:      match.Time.Unix(),
+	}
+}
@@ -78,5 +78,9 @@ func Job(
 		atcJob.PausedAt = job.PausedAt().Unix()
 	}
 
+	if !job.NextScheduledTrigger().IsZero() {
+		atcJob.NextScheduledTrigger = job.NextScheduledTrigger().Unix()
+	}
+
 	return atcJob
 }
@@ -0,0 +1,24 @@
+package present
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func NotificationDelivery(delivery db.NotificationDelivery) atc.NotificationDelivery {
+	return atc.NotificationDelivery{
+		ID:             delivery.ID(),
+		Name:           delivery.Name(),
+		Status:         delivery.Status(),
+		BuildID:        delivery.BuildID(),
+		BuildName:      delivery.BuildName(),
+		JobName:        delivery.JobName(),
+		BuildStatus:    atc.BuildStatus(delivery.BuildStatus()),
+		PreviousStatus: atc.BuildStatus(delivery.PreviousStatus()),
+		Attempts:       delivery.Attempts(),
+		ResponseCode:   delivery.ResponseCode(),
+		LastError:      delivery.LastError(),
+		CreatedAt:      delivery.CreatedAt().Unix(),
+		UpdatedAt:      delivery.UpdatedAt().Unix(),
+	}
+}
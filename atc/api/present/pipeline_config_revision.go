@@ -0,0 +1,19 @@
+package present
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func PipelineConfigRevision(revision db.PipelineConfigRevision) atc.PipelineConfigRevision {
+	return atc.PipelineConfigRevision{
+		Revision:          revision.Revision,
+		ConfigVersion:     int(revision.ConfigVersion),
+		CreatedBy:         revision.CreatedBy,
+		CreatedAt:         revision.CreatedAt.Unix(),
+		BuildID:           revision.BuildID,
+		BuildName:         revision.BuildName,
+		BuildJobName:      revision.BuildJobName,
+		BuildPipelineName: revision.BuildPipelineName,
+	}
+}
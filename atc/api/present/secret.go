@@ -0,0 +1,15 @@
+package present
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func Secret(secret db.Secret) atc.Secret {
+	return atc.Secret{
+		Name:      secret.Name,
+		Pipeline:  secret.PipelineName,
+		UpdatedBy: secret.UpdatedBy,
+		UpdatedAt: secret.UpdatedAt.Unix(),
+	}
+}
@@ -2,6 +2,9 @@ package api_test
 
 import (
 	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -17,6 +20,7 @@ import (
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	. "github.com/concourse/concourse/atc/testhelpers"
+	"github.com/concourse/concourse/atc/webhook"
 	"github.com/concourse/concourse/vars"
 )
 
@@ -1645,12 +1649,18 @@ var _ = Describe("Resources API", func() {
 	Describe("POST /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check/webhook", func() {
 		var (
 			checkRequestBody atc.CheckRequestBody
+			requestBody      []byte
+			webhookURL       string
+			requestHeader    http.Header
 			response         *http.Response
 			fakeResource     *dbfakes.FakeResource
 		)
 
 		BeforeEach(func() {
 			checkRequestBody = atc.CheckRequestBody{}
+			requestBody = nil
+			webhookURL = server.URL + "/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/check/webhook?webhook_token=fake-token"
+			requestHeader = http.Header{}
 
 			fakeResource = new(dbfakes.FakeResource)
 			fakeResource.NameReturns("resource-name")
@@ -1661,8 +1671,13 @@ var _ = Describe("Resources API", func() {
 			reqPayload, err := json.Marshal(checkRequestBody)
 			Expect(err).NotTo(HaveOccurred())
 
-			request, err := http.NewRequest("POST", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/check/webhook?webhook_token=fake-token", bytes.NewBuffer(reqPayload))
+			if requestBody != nil {
+				reqPayload = requestBody
+			}
+
+			request, err := http.NewRequest("POST", webhookURL, bytes.NewBuffer(reqPayload))
 			Expect(err).NotTo(HaveOccurred())
+			request.Header = requestHeader
 			request.Header.Set("Content-Type", "application/json")
 
 			response, err = client.Do(request)
@@ -1808,6 +1823,408 @@ var _ = Describe("Resources API", func() {
 				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
 			})
 		})
+
+		Context("when the body is too large", func() {
+			BeforeEach(func() {
+				requestBody = bytes.Repeat([]byte("x"), 6*1024*1024)
+				fakePipeline.ResourceReturns(fakeResource, true, nil)
+			})
+
+			It("returns 413 without looking up credentials", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusRequestEntityTooLarge))
+				Expect(fakePipeline.VariablesCallCount()).To(BeZero())
+				Expect(dbCheckFactory.TryCreateCheckCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the resource has a signed webhook", func() {
+			var (
+				payload   []byte
+				signature string
+			)
+
+			BeforeEach(func() {
+				webhookURL = server.URL + "/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/check/webhook"
+
+				fakePipeline.VariablesReturns(vars.StaticVariables{
+					"webhook-secret": "some-secret",
+				}, nil)
+
+				var err error
+				payload, err = json.Marshal(checkRequestBody)
+				Expect(err).NotTo(HaveOccurred())
+
+				mac := hmac.New(sha256.New, []byte("some-secret"))
+				mac.Write(payload)
+				signature = "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+				fakeResource.ConfigReturns(atc.ResourceConfig{
+					Name: "resource-name",
+					Webhook: &atc.ResourceWebhook{
+						Provider: atc.WebhookProviderGitHub,
+						Secret:   "((webhook-secret))",
+					},
+				})
+				fakeResource.RecordWebhookDeliveryReturns(true, nil)
+				fakePipeline.ResourceReturns(fakeResource, true, nil)
+				dbCheckFactory.TryCreateCheckReturns(new(dbfakes.FakeBuild), true, nil)
+			})
+
+			Context("when the body is signed with the secret", func() {
+				BeforeEach(func() {
+					requestHeader.Set("X-Hub-Signature-256", signature)
+					requestHeader.Set("X-GitHub-Delivery", "some-delivery")
+				})
+
+				It("creates a check", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusCreated))
+					Expect(dbCheckFactory.TryCreateCheckCallCount()).To(Equal(1))
+				})
+
+				It("records the delivery by the digest of its body, which the signature covers", func() {
+					Expect(fakeResource.RecordWebhookDeliveryCallCount()).To(Equal(1))
+					deliveryID, window := fakeResource.RecordWebhookDeliveryArgsForCall(0)
+					Expect(deliveryID).To(Equal(webhook.PayloadDigest(payload)))
+					Expect(window).To(Equal(atc.DefaultWebhookReplayWindow))
+				})
+
+				Context("when the delivery header is missing", func() {
+					BeforeEach(func() {
+						requestHeader.Del("X-GitHub-Delivery")
+					})
+
+					It("returns 400 without creating a check", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						Expect(fakeResource.RecordWebhookDeliveryCallCount()).To(BeZero())
+						Expect(dbCheckFactory.TryCreateCheckCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when the delivery was already seen", func() {
+					BeforeEach(func() {
+						fakeResource.RecordWebhookDeliveryReturns(false, nil)
+					})
+
+					It("returns 409 without creating a check", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusConflict))
+						Expect(dbCheckFactory.TryCreateCheckCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when the payload does not match the branch filter", func() {
+					BeforeEach(func() {
+						fakeResource.ConfigReturns(atc.ResourceConfig{
+							Name: "resource-name",
+							Webhook: &atc.ResourceWebhook{
+								Provider: atc.WebhookProviderGitHub,
+								Secret:   "((webhook-secret))",
+								Branches: []string{"main"},
+							},
+						})
+					})
+
+					It("returns 204 without creating a check", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+						Expect(dbCheckFactory.TryCreateCheckCallCount()).To(BeZero())
+					})
+				})
+			})
+
+			Context("when the signature is invalid", func() {
+				BeforeEach(func() {
+					requestHeader.Set("X-Hub-Signature-256", "sha256=0000")
+				})
+
+				It("returns 401 without creating a check", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+					Expect(fakeResource.RecordWebhookDeliveryCallCount()).To(BeZero())
+					Expect(dbCheckFactory.TryCreateCheckCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when there is no signature", func() {
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+			})
+
+			Context("when the secret interpolates to nothing", func() {
+				BeforeEach(func() {
+					fakePipeline.VariablesReturns(vars.StaticVariables{
+						"webhook-secret": "",
+					}, nil)
+				})
+
+				It("returns 401 without creating a check", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+					Expect(dbCheckFactory.TryCreateCheckCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when the resource has a webhook authenticated by a token", func() {
+			BeforeEach(func() {
+				webhookURL = server.URL + "/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/check/webhook"
+
+				fakePipeline.VariablesReturns(vars.StaticVariables{
+					"webhook-token": "some-token",
+				}, nil)
+
+				fakeResource.ConfigReturns(atc.ResourceConfig{
+					Name: "resource-name",
+					Webhook: &atc.ResourceWebhook{
+						Provider: atc.WebhookProviderGitLab,
+						Token:    "((webhook-token))",
+					},
+				})
+				fakeResource.RecordWebhookDeliveryReturns(true, nil)
+				fakePipeline.ResourceReturns(fakeResource, true, nil)
+				dbCheckFactory.TryCreateCheckReturns(new(dbfakes.FakeBuild), true, nil)
+
+				requestHeader.Set("X-Gitlab-Token", "some-token")
+				requestHeader.Set("X-Gitlab-Event-UUID", "some-delivery")
+			})
+
+			It("records the delivery by its ID", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusCreated))
+				Expect(fakeResource.RecordWebhookDeliveryCallCount()).To(Equal(1))
+				deliveryID, _ := fakeResource.RecordWebhookDeliveryArgsForCall(0)
+				Expect(deliveryID).To(Equal("some-delivery"))
+			})
+		})
+	})
+
+	Describe("POST /api/v1/teams/:team_name/resources/check/webhook", func() {
+		var (
+			query    string
+			response *http.Response
+
+			resource1 *dbfakes.FakeResource
+			resource2 *dbfakes.FakeResource
+			resource3 *dbfakes.FakeResource
+		)
+
+		newResource := func(id int, scopeID int, uri string) *dbfakes.FakeResource {
+			resource := new(dbfakes.FakeResource)
+			resource.IDReturns(id)
+			resource.NameReturns("some-repo")
+			resource.PipelineIDReturns(1)
+			resource.PipelineReturns(fakePipeline, true, nil)
+			resource.HasWebhookReturns(true)
+			resource.WebhookTokenReturns("((webhook-token))")
+			resource.SourceReturns(atc.Source{"uri": uri, "branch": "main"})
+			resource.ResourceConfigScopeIDReturns(scopeID)
+			return resource
+		}
+
+		BeforeEach(func() {
+			query = `?type=git&webhook_token=some-token&source={"uri":"some-uri"}`
+
+			fakePipeline.VariablesReturns(vars.StaticVariables{"webhook-token": "some-token"}, nil)
+
+			resource1 = newResource(1, 10, "some-uri")
+			resource2 = newResource(2, 10, "some-uri")
+			resource3 = newResource(3, 11, "other-uri")
+			dbTeam.ResourcesOfTypeReturns(db.Resources{resource1, resource2, resource3}, nil)
+
+			fakeBuild := new(dbfakes.FakeBuild)
+			fakeBuild.IDReturns(42)
+			fakeBuild.NameReturns("some-name")
+			fakeBuild.TeamNameReturns("some-team")
+			fakeBuild.StatusReturns("started")
+			dbCheckFactory.TryCreateCheckReturns(fakeBuild, true, nil)
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("POST", server.URL+"/api/v1/teams/a-team/resources/check/webhook"+query, bytes.NewBufferString("{}"))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("looks up the team's resources of the given type", func() {
+			Expect(dbTeam.ResourcesOfTypeCallCount()).To(Equal(1))
+			Expect(dbTeam.ResourcesOfTypeArgsForCall(0)).To(Equal("git"))
+		})
+
+		It("checks each resource config scope matching the source once", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusCreated))
+			Expect(dbCheckFactory.TryCreateCheckCallCount()).To(Equal(1))
+
+			_, actualResource, _, _, manuallyTriggered, _, toDb := dbCheckFactory.TryCreateCheckArgsForCall(0)
+			Expect(actualResource).To(Equal(resource1))
+			Expect(manuallyTriggered).To(BeTrue())
+			Expect(toDb).To(BeTrue())
+
+			Expect(io.ReadAll(response.Body)).To(MatchJSON(`[{
+				"id": 42,
+				"name": "some-name",
+				"team_name": "some-team",
+				"status": "started",
+				"api_url": "/api/v1/builds/42"
+			}]`))
+		})
+
+		Context("when the resources do not share a scope", func() {
+			BeforeEach(func() {
+				resource2.ResourceConfigScopeIDReturns(12)
+			})
+
+			It("checks each of them", func() {
+				Expect(dbCheckFactory.TryCreateCheckCallCount()).To(Equal(2))
+			})
+		})
+
+		Context("when no source filter is given", func() {
+			BeforeEach(func() {
+				query = "?type=git&webhook_token=some-token"
+			})
+
+			It("returns 400 without looking up resources", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				Expect(dbTeam.ResourcesOfTypeCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the source filter is empty", func() {
+			BeforeEach(func() {
+				query = "?type=git&webhook_token=some-token&source={}"
+			})
+
+			It("returns 400 without looking up resources", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				Expect(dbTeam.ResourcesOfTypeCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when resources in too many pipelines match", func() {
+			BeforeEach(func() {
+				resources := db.Resources{}
+				for i := 0; i < 21; i++ {
+					resource := newResource(i+1, 0, "some-uri")
+					resource.PipelineIDReturns(i + 1)
+					resources = append(resources, resource)
+				}
+
+				dbTeam.ResourcesOfTypeReturns(resources, nil)
+			})
+
+			It("returns 400 without looking up credentials", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				Expect(fakePipeline.VariablesCallCount()).To(BeZero())
+				Expect(dbCheckFactory.TryCreateCheckCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when a resource does not accept the token", func() {
+			BeforeEach(func() {
+				resource1.WebhookTokenReturns("other-token")
+			})
+
+			It("only checks the resources which do", func() {
+				Expect(dbCheckFactory.TryCreateCheckCallCount()).To(Equal(1))
+				_, actualResource, _, _, _, _, _ := dbCheckFactory.TryCreateCheckArgsForCall(0)
+				Expect(actualResource).To(Equal(resource2))
+			})
+		})
+
+		Context("when no resource accepts the token", func() {
+			BeforeEach(func() {
+				query = `?type=git&webhook_token=wrong-token&source={"uri":"some-uri"}`
+			})
+
+			It("returns 404, the same as when no resource matches", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				Expect(dbCheckFactory.TryCreateCheckCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when no resource matches the source", func() {
+			BeforeEach(func() {
+				query = `?type=git&webhook_token=some-token&source={"uri":"bogus-uri"}`
+			})
+
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("when the source filter matches an interpolated value", func() {
+			BeforeEach(func() {
+				resource1.SourceReturns(atc.Source{"uri": "some-uri", "password": "((password))"})
+				fakePipeline.VariablesReturns(vars.StaticVariables{
+					"webhook-token": "some-token",
+					"password":      "hunter2",
+				}, nil)
+				query = `?type=git&webhook_token=wrong-token&source={"password":"hunter2"}`
+			})
+
+			It("does not match against the credential", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				Expect(fakePipeline.VariablesCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when a pipeline's vars cannot be loaded", func() {
+			var otherPipeline *dbfakes.FakePipeline
+
+			BeforeEach(func() {
+				otherPipeline = new(dbfakes.FakePipeline)
+				otherPipeline.VariablesReturns(nil, errors.New("nope"))
+
+				resource1.PipelineIDReturns(2)
+				resource1.PipelineReturns(otherPipeline, true, nil)
+			})
+
+			It("skips its resources and checks the rest", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusCreated))
+				Expect(dbCheckFactory.TryCreateCheckCallCount()).To(Equal(1))
+				_, actualResource, _, _, _, _, _ := dbCheckFactory.TryCreateCheckArgsForCall(0)
+				Expect(actualResource).To(Equal(resource2))
+			})
+		})
+
+		Context("when the resources have no webhook", func() {
+			BeforeEach(func() {
+				resource1.HasWebhookReturns(false)
+				resource2.HasWebhookReturns(false)
+			})
+
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("when no type is given", func() {
+			BeforeEach(func() {
+				query = "?webhook_token=some-token"
+			})
+
+			It("returns 400", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("when the source filter is malformed", func() {
+			BeforeEach(func() {
+				query = "?type=git&source=nope"
+			})
+
+			It("returns 400", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("when getting the resources fails", func() {
+			BeforeEach(func() {
+				dbTeam.ResourcesOfTypeReturns(nil, errors.New("nope"))
+			})
+
+			It("returns 500", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
 	})
 
 	Describe("DELETE /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/cache", func() {
@@ -0,0 +1,253 @@
+package resourceserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager/v3"
+	"code.cloudfoundry.org/lager/v3/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/vars"
+)
+
+// maxWebhookPipelines limits how many pipelines a single team webhook can
+// load credentials for.
+const maxWebhookPipelines = 20
+
+// CheckResourcesWebHook defines a handler which accepts a single webhook for
+// a team and checks every resource across its pipelines whose type and source
+// match the request. Each resource authenticates the request using its own
+// webhook config, and resources which share a resource config scope are only
+// checked once.
+//
+// The source filter is matched against the resources' sources as configured,
+// before any vars are interpolated, so that the response never depends on
+// the value of a credential. For the same reason, the handler responds with
+// 404 both when no resource matches and when none accepts the request.
+//
+// As the request is not authenticated until credentials have been looked up
+// for each pipeline, a non-empty source filter is required, and requests
+// matching resources in more than maxWebhookPipelines pipelines are rejected.
+func (s *Server) CheckResourcesWebHook(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resourceType := r.URL.Query().Get("type")
+		webhookToken := r.URL.Query().Get("webhook_token")
+
+		logger := s.logger.Session("check-resources-webhook", lager.Data{
+			"team": team.Name(),
+			"type": resourceType,
+		})
+
+		if resourceType == "" {
+			logger.Info("no-resource-type")
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		filter := r.URL.Query().Get("source")
+		if filter == "" {
+			logger.Info("no-source-filter")
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		var sourceFilter atc.Source
+		err := json.Unmarshal([]byte(filter), &sourceFilter)
+		if err != nil {
+			logger.Info("malformed-source-filter", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if len(sourceFilter) == 0 {
+			logger.Info("no-source-filter")
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		body, ok := readWebhookBody(logger, w, r)
+		if !ok {
+			return
+		}
+
+		allResources, err := team.ResourcesOfType(resourceType)
+		if err != nil {
+			logger.Error("failed-to-get-resources", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		dbResources := []db.Resource{}
+		matchedPipelines := map[int]bool{}
+		for _, dbResource := range allResources {
+			if dbResource.HasWebhook() && sourceMatches(dbResource.Source(), sourceFilter) {
+				dbResources = append(dbResources, dbResource)
+				matchedPipelines[dbResource.PipelineID()] = true
+			}
+		}
+
+		if len(matchedPipelines) > maxWebhookPipelines {
+			logger.Info("too-many-matching-pipelines", lager.Data{"pipelines": len(matchedPipelines)})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		pipelines := map[int]*webhookPipeline{}
+		checkedScopes := map[int]bool{}
+
+		authorized := 0
+		builds := []atc.Build{}
+
+		for _, dbResource := range dbResources {
+			rLogger := logger.Session("resource", lager.Data{
+				"pipeline": dbResource.PipelineRef().String(),
+				"resource": dbResource.Name(),
+			})
+
+			pipeline, cached := pipelines[dbResource.PipelineID()]
+			if !cached {
+				pipeline = s.webhookPipeline(rLogger, dbResource)
+				pipelines[dbResource.PipelineID()] = pipeline
+			}
+
+			if pipeline == nil {
+				continue
+			}
+
+			status := s.authorizeWebhook(rLogger, dbResource, pipeline.variables, webhookToken, r.Header, body)
+			if status == http.StatusUnauthorized || status == http.StatusInternalServerError {
+				continue
+			}
+
+			authorized++
+
+			if status != 0 {
+				continue
+			}
+
+			scopeID := dbResource.ResourceConfigScopeID()
+			if scopeID != 0 {
+				if checkedScopes[scopeID] {
+					rLogger.Debug("scope-already-checked", lager.Data{"scope": scopeID})
+					continue
+				}
+
+				checkedScopes[scopeID] = true
+			}
+
+			build, created, err := s.checkFactory.TryCreateCheck(
+				lagerctx.NewContext(context.Background(), rLogger),
+				dbResource,
+				pipeline.resourceTypes,
+				nil,
+				true,  // manually triggered
+				false, // skip interval recursively
+				true,  // to database
+			)
+			if err != nil {
+				rLogger.Error("failed-to-create-check", err)
+				continue
+			}
+
+			if !created {
+				rLogger.Info("check-not-created")
+				continue
+			}
+
+			builds = append(builds, present.Build(build, nil, nil))
+		}
+
+		if authorized == 0 {
+			logger.Info("no-matching-resources")
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if len(builds) == 0 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+
+		err = json.NewEncoder(w).Encode(builds)
+		if err != nil {
+			logger.Error("failed-to-encode-checks", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+type webhookPipeline struct {
+	variables     vars.Variables
+	resourceTypes db.ResourceTypes
+}
+
+// webhookPipeline loads what is needed to authorize and check the resources
+// of the given resource's pipeline. It returns nil if the pipeline no longer
+// exists or cannot be loaded, in which case its resources are skipped rather
+// than failing the webhook for every other pipeline.
+func (s *Server) webhookPipeline(logger lager.Logger, dbResource db.Resource) *webhookPipeline {
+	dbPipeline, found, err := dbResource.Pipeline()
+	if err != nil {
+		logger.Error("failed-to-get-pipeline", err)
+		return nil
+	}
+
+	if !found {
+		return nil
+	}
+
+	variables, err := dbPipeline.Variables(logger, s.secretManager, s.varSourcePool, creds.SecretLookupParams{
+		Team:         dbPipeline.TeamName(),
+		Pipeline:     dbPipeline.Name(),
+		InstanceVars: dbPipeline.InstanceVars(),
+	})
+	if err != nil {
+		logger.Error("failed-to-create-var-sources", err)
+		return nil
+	}
+
+	resourceTypes, err := dbPipeline.ResourceTypes()
+	if err != nil {
+		logger.Error("failed-to-get-resource-types", err)
+		return nil
+	}
+
+	return &webhookPipeline{
+		variables:     variables,
+		resourceTypes: resourceTypes,
+	}
+}
+
+// sourceMatches returns whether every field of the filter is present in the
+// source with an equal value.
+func sourceMatches(source atc.Source, filter atc.Source) bool {
+	for key, expected := range filter {
+		actual, found := source[key]
+		if !found {
+			return false
+		}
+
+		expectedJSON, err := json.Marshal(expected)
+		if err != nil {
+			return false
+		}
+
+		actualJSON, err := json.Marshal(actual)
+		if err != nil {
+			return false
+		}
+
+		if string(expectedJSON) != string(actualJSON) {
+			return false
+		}
+	}
+
+	return true
+}
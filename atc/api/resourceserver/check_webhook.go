@@ -3,6 +3,8 @@ package resourceserver
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 
 	"code.cloudfoundry.org/lager/v3"
@@ -10,10 +12,37 @@ import (
 	"github.com/concourse/concourse/atc/api/present"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/webhook"
+	"github.com/concourse/concourse/vars"
 	"github.com/tedsuo/rata"
 )
 
-// CheckResourceWebHook defines a handler for process a check resource request via an access token.
+// maxWebhookBodySize limits how much of a webhook request is read. Webhooks
+// are not authenticated until the body has been read, so it must be bounded.
+const maxWebhookBodySize = 5 * 1024 * 1024
+
+// readWebhookBody reads the body of a webhook request, responding and
+// returning false if it cannot be read or is too large.
+func readWebhookBody(logger lager.Logger, w http.ResponseWriter, r *http.Request) ([]byte, bool) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBodySize))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			logger.Info("webhook-body-too-large", lager.Data{"limit": tooLarge.Limit})
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			return nil, false
+		}
+
+		logger.Error("failed-to-read-body", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return nil, false
+	}
+
+	return body, true
+}
+
+// CheckResourceWebHook defines a handler for process a check resource request via an access token,
+// or via the signed webhook configured on the resource.
 func (s *Server) CheckResourceWebHook(dbPipeline db.Pipeline) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		resourceName := rata.Param(r, "resource_name")
@@ -23,12 +52,6 @@ func (s *Server) CheckResourceWebHook(dbPipeline db.Pipeline) http.Handler {
 			"resource": resourceName,
 		})
 
-		if webhookToken == "" {
-			logger.Info("no-webhook-token", lager.Data{"error": "missing webhook_token"})
-			w.WriteHeader(http.StatusBadRequest)
-			return
-		}
-
 		dbResource, found, err := dbPipeline.Resource(resourceName)
 		if err != nil {
 			logger.Error("failed-to-get-resource", err)
@@ -42,6 +65,18 @@ func (s *Server) CheckResourceWebHook(dbPipeline db.Pipeline) http.Handler {
 			return
 		}
 
+		webhookConfig := dbResource.Config().Webhook
+		if webhookConfig == nil && webhookToken == "" {
+			logger.Info("no-webhook-token", lager.Data{"error": "missing webhook_token"})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		body, ok := readWebhookBody(logger, w, r)
+		if !ok {
+			return
+		}
+
 		secretsParams := creds.SecretLookupParams{
 			Team:         dbPipeline.TeamName(),
 			Pipeline:     dbPipeline.Name(),
@@ -54,15 +89,10 @@ func (s *Server) CheckResourceWebHook(dbPipeline db.Pipeline) http.Handler {
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
-		token, err := creds.NewString(variables, dbResource.WebhookToken()).Evaluate()
-		if err != nil {
-			logger.Error("failed-to-evaluate-webhook-token", err)
-			w.WriteHeader(http.StatusInternalServerError)
-			return
-		}
-		if token != webhookToken {
-			logger.Info("invalid-token", lager.Data{"token": webhookToken})
-			w.WriteHeader(http.StatusUnauthorized)
+
+		status := s.authorizeWebhook(logger, dbResource, variables, webhookToken, r.Header, body)
+		if status != 0 {
+			w.WriteHeader(status)
 			return
 		}
 
@@ -104,3 +134,93 @@ func (s *Server) CheckResourceWebHook(dbPipeline db.Pipeline) http.Handler {
 		}
 	})
 }
+
+// authorizeWebhook authenticates a request against the resource's webhook
+// config or, if it has none, its webhook_token. For webhooks configured on
+// the resource it also rejects replayed deliveries and applies the payload
+// filters. It returns the
+// status to respond with if a check should not be created, or 0.
+func (s *Server) authorizeWebhook(
+	logger lager.Logger,
+	dbResource db.Resource,
+	variables vars.Variables,
+	webhookToken string,
+	header http.Header,
+	body []byte,
+) int {
+	config := dbResource.Config().Webhook
+	if config == nil {
+		token, err := creds.NewString(variables, dbResource.WebhookToken()).Evaluate()
+		if err != nil {
+			logger.Error("failed-to-evaluate-webhook-token", err)
+			return http.StatusInternalServerError
+		}
+
+		if token == "" || token != webhookToken {
+			logger.Info("invalid-token", lager.Data{"token": webhookToken})
+			return http.StatusUnauthorized
+		}
+
+		return 0
+	}
+
+	secret, err := creds.NewString(variables, config.Secret).Evaluate()
+	if err != nil {
+		logger.Error("failed-to-evaluate-webhook-secret", err)
+		return http.StatusInternalServerError
+	}
+
+	token, err := creds.NewString(variables, config.Token).Evaluate()
+	if err != nil {
+		logger.Error("failed-to-evaluate-webhook-token", err)
+		return http.StatusInternalServerError
+	}
+
+	err = webhook.Verify(*config, secret, token, header, body)
+	if err != nil {
+		logger.Info("invalid-webhook", lager.Data{"error": err.Error()})
+		return http.StatusUnauthorized
+	}
+
+	var deliveryID string
+	if name := config.DeliveryHeaderName(); name != "" {
+		deliveryID = header.Get(name)
+		if deliveryID == "" {
+			logger.Info("missing-delivery-header", lager.Data{"header": name})
+			return http.StatusBadRequest
+		}
+	}
+
+	// the delivery ID is not covered by the signature, so signed deliveries
+	// are told apart by their body instead
+	replayKey := deliveryID
+	if secret != "" {
+		replayKey = webhook.PayloadDigest(body)
+	}
+
+	if replayKey != "" {
+		window, err := config.ReplayWindowDuration()
+		if err != nil {
+			logger.Error("failed-to-parse-replay-window", err)
+			return http.StatusInternalServerError
+		}
+
+		recorded, err := dbResource.RecordWebhookDelivery(replayKey, window)
+		if err != nil {
+			logger.Error("failed-to-record-webhook-delivery", err)
+			return http.StatusInternalServerError
+		}
+
+		if !recorded {
+			logger.Info("replayed-webhook-delivery", lager.Data{"delivery": deliveryID})
+			return http.StatusConflict
+		}
+	}
+
+	if !webhook.Matches(*config, webhook.ParseEvent(config.ProviderName(), body)) {
+		logger.Debug("webhook-filtered")
+		return http.StatusNoContent
+	}
+
+	return 0
+}
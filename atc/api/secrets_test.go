@@ -0,0 +1,459 @@
+package api_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Secrets API", func() {
+	var (
+		fakeTeam *dbfakes.FakeTeam
+		response *http.Response
+	)
+
+	BeforeEach(func() {
+		fakeTeam = new(dbfakes.FakeTeam)
+		fakeTeam.IDReturns(1)
+		fakeTeam.NameReturns("some-team")
+		dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+	})
+
+	Describe("GET /api/v1/teams/:team_name/secrets", func() {
+		var query string
+
+		BeforeEach(func() {
+			query = ""
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/secrets" + query)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				Expect(fakeTeam.SecretsCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(fakeTeam.SecretsCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+
+				fakeTeam.SecretsReturns([]db.Secret{
+					{
+						Name:      "some-secret",
+						UpdatedBy: "some-user",
+						UpdatedAt: time.Unix(1000, 0),
+					},
+					{
+						Name:         "some-pipeline-secret",
+						PipelineName: "some-pipeline",
+						UpdatedAt:    time.Unix(2000, 0),
+					},
+				}, nil)
+			})
+
+			It("returns the secrets without their values", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+				body, err := io.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`[
+					{"name": "some-secret", "updated_by": "some-user", "updated_at": 1000},
+					{"name": "some-pipeline-secret", "pipeline": "some-pipeline", "updated_at": 2000}
+				]`))
+			})
+
+			Context("when filtering by pipeline", func() {
+				BeforeEach(func() {
+					query = "?pipeline=some-pipeline"
+				})
+
+				It("only returns the pipeline's secrets", func() {
+					body, err := io.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{"name": "some-pipeline-secret", "pipeline": "some-pipeline", "updated_at": 2000}
+					]`))
+				})
+			})
+
+			Context("when getting the secrets fails", func() {
+				BeforeEach(func() {
+					fakeTeam.SecretsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/secrets/:secret_name", func() {
+		var (
+			query   string
+			request atc.SetSecretRequest
+		)
+
+		BeforeEach(func() {
+			query = ""
+			request = atc.SetSecretRequest{Value: "some-value"}
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/some-team/secrets/some-secret"+query, jsonEncode(request))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(fakeTeam.SetSecretCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				fakeAccess.UserInfoReturns(atc.UserInfo{DisplayUserId: "some-user"})
+			})
+
+			It("sets a team-scoped secret", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+
+				Expect(fakeTeam.SetSecretCallCount()).To(Equal(1))
+				pipelineName, name, value, setBy := fakeTeam.SetSecretArgsForCall(0)
+				Expect(pipelineName).To(BeEmpty())
+				Expect(name).To(Equal("some-secret"))
+				Expect(value).To(Equal("some-value"))
+				Expect(setBy).To(Equal("some-user"))
+			})
+
+			It("does not return the value", func() {
+				body, err := io.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(body).To(BeEmpty())
+			})
+
+			Context("when a pipeline is given", func() {
+				BeforeEach(func() {
+					query = "?pipeline=some-pipeline"
+				})
+
+				It("sets a pipeline-scoped secret", func() {
+					Expect(fakeTeam.SetSecretCallCount()).To(Equal(1))
+					pipelineName, _, _, _ := fakeTeam.SetSecretArgsForCall(0)
+					Expect(pipelineName).To(Equal("some-pipeline"))
+				})
+			})
+
+			Context("when the value is empty", func() {
+				BeforeEach(func() {
+					request.Value = ""
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(fakeTeam.SetSecretCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when setting the secret fails", func() {
+				BeforeEach(func() {
+					fakeTeam.SetSecretReturns(errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("DELETE /api/v1/teams/:team_name/secrets/:secret_name", func() {
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("DELETE", server.URL+"/api/v1/teams/some-team/secrets/some-secret?pipeline=some-pipeline", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(fakeTeam.DeleteSecretCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when the secret exists", func() {
+				BeforeEach(func() {
+					fakeTeam.DeleteSecretReturns(true, nil)
+				})
+
+				It("deletes it", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+
+					Expect(fakeTeam.DeleteSecretCallCount()).To(Equal(1))
+					pipelineName, name := fakeTeam.DeleteSecretArgsForCall(0)
+					Expect(pipelineName).To(Equal("some-pipeline"))
+					Expect(name).To(Equal("some-secret"))
+				})
+			})
+
+			Context("when the secret does not exist", func() {
+				BeforeEach(func() {
+					fakeTeam.DeleteSecretReturns(false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+	})
+})
+
+var _ = Describe("Secret Usages API", func() {
+	var (
+		query    string
+		response *http.Response
+	)
+
+	BeforeEach(func() {
+		query = "?var=github.token"
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		response, err = client.Get(server.URL + "/api/v1/secret_usages" + query)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Context("when not authenticated", func() {
+		BeforeEach(func() {
+			fakeAccess.IsAuthenticatedReturns(false)
+		})
+
+		It("returns 401", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+		})
+	})
+
+	Context("when authenticated", func() {
+		BeforeEach(func() {
+			fakeAccess.IsAuthenticatedReturns(true)
+			fakeAccess.TeamNamesReturns([]string{"some-team"})
+
+			usages := []atc.SecretUsage{
+				{
+					Var:          "github.token",
+					TeamName:     "some-team",
+					PipelineID:   1,
+					PipelineName: "some-pipeline",
+					Jobs:         []string{"some-job"},
+					Resources:    []string{"some-resource"},
+					LastBuild: &atc.SecretUsageBuild{
+						ID:         42,
+						Name:       "7",
+						JobName:    "some-job",
+						ResolvedAt: 1000,
+					},
+				},
+			}
+
+			dbSecretUsageFactory.AllSecretUsagesReturns(usages, nil)
+			dbSecretUsageFactory.VisibleSecretUsagesReturns(usages, nil)
+		})
+
+		It("returns the usages of the teams the user can see", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+			Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+			Expect(dbSecretUsageFactory.VisibleSecretUsagesCallCount()).To(Equal(1))
+			varName, teamNames := dbSecretUsageFactory.VisibleSecretUsagesArgsForCall(0)
+			Expect(varName).To(Equal("github.token"))
+			Expect(teamNames).To(Equal([]string{"some-team"}))
+
+			body, err := io.ReadAll(response.Body)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(body).To(MatchJSON(`[
+				{
+					"var": "github.token",
+					"team_name": "some-team",
+					"pipeline_id": 1,
+					"pipeline_name": "some-pipeline",
+					"jobs": ["some-job"],
+					"resources": ["some-resource"],
+					"last_build": {"id": 42, "name": "7", "job_name": "some-job", "resolved_at": 1000}
+				}
+			]`))
+		})
+
+		Context("when the user is an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAdminReturns(true)
+			})
+
+			It("returns the usages of every team", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(dbSecretUsageFactory.AllSecretUsagesCallCount()).To(Equal(1))
+				Expect(dbSecretUsageFactory.AllSecretUsagesArgsForCall(0)).To(Equal("github.token"))
+			})
+		})
+
+		Context("when no var is given", func() {
+			BeforeEach(func() {
+				query = ""
+			})
+
+			It("returns 400", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("when getting the usages fails", func() {
+			BeforeEach(func() {
+				dbSecretUsageFactory.VisibleSecretUsagesReturns(nil, errors.New("nope"))
+			})
+
+			It("returns 500", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+})
+
+var _ = Describe("Clear Secret Cache API", func() {
+	var (
+		query    string
+		response *http.Response
+	)
+
+	BeforeEach(func() {
+		query = "?team=some-team&var=github.token"
+	})
+
+	JustBeforeEach(func() {
+		request, err := http.NewRequest("DELETE", server.URL+"/api/v1/secret_cache"+query, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		response, err = client.Do(request)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Context("when not authenticated", func() {
+		BeforeEach(func() {
+			fakeAccess.IsAuthenticatedReturns(false)
+		})
+
+		It("returns 401", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			Expect(dbSecretCacheInvalidator.InvalidateCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when authenticated as a non-admin", func() {
+		BeforeEach(func() {
+			fakeAccess.IsAuthenticatedReturns(true)
+			fakeAccess.IsAdminReturns(false)
+		})
+
+		It("returns 403", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			Expect(dbSecretCacheInvalidator.InvalidateCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when authenticated as an admin", func() {
+		BeforeEach(func() {
+			fakeAccess.IsAuthenticatedReturns(true)
+			fakeAccess.IsAdminReturns(true)
+		})
+
+		It("invalidates the matching secrets on every web node", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+
+			Expect(dbSecretCacheInvalidator.InvalidateCallCount()).To(Equal(1))
+			Expect(dbSecretCacheInvalidator.InvalidateArgsForCall(0)).To(Equal(creds.SecretCacheInvalidation{
+				Team: "some-team",
+				Var:  "github.token",
+			}))
+		})
+
+		Context("when no team or var is given", func() {
+			BeforeEach(func() {
+				query = ""
+			})
+
+			It("invalidates every secret", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+				Expect(dbSecretCacheInvalidator.InvalidateArgsForCall(0)).To(Equal(creds.SecretCacheInvalidation{}))
+			})
+		})
+
+		Context("when invalidating fails", func() {
+			BeforeEach(func() {
+				dbSecretCacheInvalidator.InvalidateReturns(errors.New("nope"))
+			})
+
+			It("returns 500", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+})
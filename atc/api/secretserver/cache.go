@@ -0,0 +1,34 @@
+package secretserver
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/lager/v3"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+)
+
+// ClearSecretCache removes the matching secrets from the caches of every web
+// node, so that e.g. a rotated credential is used straight away.
+func (s *Server) ClearSecretCache(w http.ResponseWriter, r *http.Request) {
+	invalidation := creds.SecretCacheInvalidation{
+		Team: r.URL.Query().Get(atc.ClearSecretCacheTeamQuery),
+		Var:  r.URL.Query().Get(atc.ClearSecretCacheVarQuery),
+	}
+
+	logger := s.logger.Session("clear-secret-cache", lager.Data{
+		"team": invalidation.Team,
+		"var":  invalidation.Var,
+	})
+
+	err := s.secretCacheInvalidator.Invalidate(invalidation)
+	if err != nil {
+		logger.Error("failed-to-invalidate-secret-cache", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	logger.Info("invalidated")
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,102 @@
+package secretserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager/v3"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+	. "github.com/concourse/concourse/atc/api/helpers"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/tedsuo/rata"
+)
+
+func (s *Server) ListSecrets(team db.Team) http.Handler {
+	logger := s.logger.Session("list-secrets")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		secrets, err := team.Secrets()
+		if err != nil {
+			logger.Error("failed-to-get-secrets", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		pipelineName := r.URL.Query().Get(atc.SecretPipelineQuery)
+
+		presented := []atc.Secret{}
+		for _, secret := range secrets {
+			if pipelineName != "" && secret.PipelineName != pipelineName {
+				continue
+			}
+
+			presented = append(presented, present.Secret(secret))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		err = json.NewEncoder(w).Encode(presented)
+		if err != nil {
+			logger.Error("failed-to-encode-secrets", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+func (s *Server) SetSecret(team db.Team) http.Handler {
+	logger := s.logger.Session("set-secret")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request atc.SetSecretRequest
+		err := json.NewDecoder(r.Body).Decode(&request)
+		if err != nil {
+			logger.Info("malformed-request", lager.Data{"error": err.Error()})
+			HandleBadRequest(w, "malformed request")
+			return
+		}
+
+		if request.Value == "" {
+			HandleBadRequest(w, "secret value cannot be empty")
+			return
+		}
+
+		name := rata.Param(r, "secret_name")
+		pipelineName := r.URL.Query().Get(atc.SecretPipelineQuery)
+
+		acc := accessor.GetAccessor(r)
+
+		err = team.SetSecret(pipelineName, name, request.Value, acc.UserInfo().DisplayUserId)
+		if err != nil {
+			logger.Error("failed-to-set-secret", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+func (s *Server) DeleteSecret(team db.Team) http.Handler {
+	logger := s.logger.Session("delete-secret")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := rata.Param(r, "secret_name")
+		pipelineName := r.URL.Query().Get(atc.SecretPipelineQuery)
+
+		found, err := team.DeleteSecret(pipelineName, name)
+		if err != nil {
+			logger.Error("failed-to-delete-secret", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
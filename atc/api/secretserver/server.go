@@ -0,0 +1,20 @@
+package secretserver
+
+import (
+	"code.cloudfoundry.org/lager/v3"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type Server struct {
+	logger                 lager.Logger
+	secretUsageFactory     db.SecretUsageFactory
+	secretCacheInvalidator db.SecretCacheInvalidator
+}
+
+func NewServer(logger lager.Logger, secretUsageFactory db.SecretUsageFactory, secretCacheInvalidator db.SecretCacheInvalidator) *Server {
+	return &Server{
+		logger:                 logger,
+		secretUsageFactory:     secretUsageFactory,
+		secretCacheInvalidator: secretCacheInvalidator,
+	}
+}
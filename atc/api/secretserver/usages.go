@@ -0,0 +1,49 @@
+package secretserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+	. "github.com/concourse/concourse/atc/api/helpers"
+)
+
+// ListSecretUsages reports where a var is used across the teams which the
+// requester can see. Only var names are recorded, never their values.
+func (s *Server) ListSecretUsages(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("list-secret-usages")
+
+	varName := r.URL.Query().Get(atc.SecretUsageVarQuery)
+	if varName == "" {
+		HandleBadRequest(w, "var must be specified")
+		return
+	}
+
+	acc := accessor.GetAccessor(r)
+
+	var (
+		usages []atc.SecretUsage
+		err    error
+	)
+
+	if acc.IsAdmin() {
+		usages, err = s.secretUsageFactory.AllSecretUsages(varName)
+	} else {
+		usages, err = s.secretUsageFactory.VisibleSecretUsages(varName, acc.TeamNames())
+	}
+
+	if err != nil {
+		logger.Error("failed-to-get-secret-usages", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(usages)
+	if err != nil {
+		logger.Error("failed-to-encode-secret-usages", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/teamserver"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	. "github.com/concourse/concourse/atc/testhelpers"
@@ -263,6 +264,69 @@ var _ = Describe("Teams API", func() {
 					Expect(updatedProviderAuth).To(Equal(atcTeam.Auth))
 				})
 
+				It("leaves the var sources alone", func() {
+					Expect(fakeTeam.UpdateVarSourcesCallCount()).To(BeZero())
+					Expect(fakeTeam.UpdateProviderAuthAndVarSourcesCallCount()).To(BeZero())
+				})
+
+				Context("when var sources are given", func() {
+					BeforeEach(func() {
+						atcTeam.VarSources = &atc.VarSourceConfigs{
+							{
+								Name:   "some-vault",
+								Type:   "dummy",
+								Config: map[string]any{"vars": map[string]any{"foo": "((global-token))"}},
+							},
+						}
+					})
+
+					It("updates them together with provider auth", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(fakeTeam.UpdateProviderAuthAndVarSourcesCallCount()).To(Equal(1))
+
+						updatedProviderAuth, updatedVarSources := fakeTeam.UpdateProviderAuthAndVarSourcesArgsForCall(0)
+						Expect(updatedProviderAuth).To(Equal(atcTeam.Auth))
+						Expect(updatedVarSources).To(Equal(*atcTeam.VarSources))
+
+						Expect(fakeTeam.UpdateProviderAuthCallCount()).To(BeZero())
+						Expect(fakeTeam.UpdateVarSourcesCallCount()).To(BeZero())
+					})
+
+					It("does not return them", func() {
+						body, err := io.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(string(body)).ToNot(ContainSubstring("some-vault"))
+					})
+
+					Context("when a var source uses another var source", func() {
+						BeforeEach(func() {
+							(*atcTeam.VarSources)[0].Config = map[string]any{"vars": map[string]any{"foo": "((other:token))"}}
+						})
+
+						It("returns 400 Bad Request with the errors", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+
+							var setTeamResponse teamserver.SetTeamResponse
+							err := json.NewDecoder(response.Body).Decode(&setTeamResponse)
+							Expect(err).NotTo(HaveOccurred())
+							Expect(setTeamResponse.Errors).To(ContainElement("var_sources.some-vault: team var sources can only use vars from the global credential manager"))
+
+							Expect(fakeTeam.UpdateProviderAuthCallCount()).To(BeZero())
+							Expect(fakeTeam.UpdateProviderAuthAndVarSourcesCallCount()).To(BeZero())
+						})
+					})
+
+					Context("when updating the team fails", func() {
+						BeforeEach(func() {
+							fakeTeam.UpdateProviderAuthAndVarSourcesReturns(errors.New("nope"))
+						})
+
+						It("returns 500 Internal Server error", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+				})
+
 				Context("when updating provider auth fails", func() {
 					BeforeEach(func() {
 						fakeTeam.UpdateProviderAuthReturns(errors.New("stop trying to make fetch happen"))
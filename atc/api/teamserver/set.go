@@ -9,6 +9,7 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api/accessor"
 	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/configvalidate"
 )
 
 type SetTeamResponse struct {
@@ -40,6 +41,20 @@ func (s *Server) SetTeam(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	response := SetTeamResponse{}
+	if atcTeam.VarSources != nil {
+		warnings, errorMessages := configvalidate.ValidateTeamVarSources(*atcTeam.VarSources)
+		if len(errorMessages) > 0 {
+			hLog.Info("invalid-var-sources", lager.Data{"errors": errorMessages})
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(SetTeamResponse{Errors: errorMessages, Warnings: warnings})
+			return
+		}
+
+		response.Warnings = append(response.Warnings, warnings...)
+	}
+
 	atcTeam.Name = teamName
 
 	team, found, err := s.teamFactory.FindTeam(teamName)
@@ -49,10 +64,14 @@ func (s *Server) SetTeam(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response := SetTeamResponse{}
 	if found {
-		hLog.Debug("updating-credentials")
-		err = team.UpdateProviderAuth(atcTeam.Auth)
+		if atcTeam.VarSources != nil {
+			hLog.Debug("updating-credentials-and-var-sources")
+			err = team.UpdateProviderAuthAndVarSources(atcTeam.Auth, *atcTeam.VarSources)
+		} else {
+			hLog.Debug("updating-credentials")
+			err = team.UpdateProviderAuth(atcTeam.Auth)
+		}
 		if err != nil {
 			hLog.Error("failed-to-update-team", err, lager.Data{"teamName": teamName})
 			w.WriteHeader(http.StatusInternalServerError)
@@ -41,9 +41,12 @@ import (
 	"github.com/concourse/concourse/atc/db/lock"
 	"github.com/concourse/concourse/atc/db/migration"
 	"github.com/concourse/concourse/atc/engine"
+	"github.com/concourse/concourse/atc/fairshare"
 	"github.com/concourse/concourse/atc/gc"
 	"github.com/concourse/concourse/atc/lidar"
+	"github.com/concourse/concourse/atc/logarchive"
 	"github.com/concourse/concourse/atc/metric"
+	"github.com/concourse/concourse/atc/notifications"
 	"github.com/concourse/concourse/atc/pauser"
 	"github.com/concourse/concourse/atc/policy"
 	"github.com/concourse/concourse/atc/scheduler"
@@ -83,12 +86,18 @@ import (
 	// dynamically registered policy checkers
 	_ "github.com/concourse/concourse/atc/policy/opa"
 
+	// dynamically registered build log archives
+	_ "github.com/concourse/concourse/atc/logarchive/filesystem"
+	_ "github.com/concourse/concourse/atc/logarchive/s3"
+
 	// dynamically registered credential managers
 	_ "github.com/concourse/concourse/atc/creds/conjur"
 	_ "github.com/concourse/concourse/atc/creds/credhub"
 	_ "github.com/concourse/concourse/atc/creds/dummy"
+	_ "github.com/concourse/concourse/atc/creds/file"
 	"github.com/concourse/concourse/atc/creds/idtoken"
 	_ "github.com/concourse/concourse/atc/creds/kubernetes"
+	"github.com/concourse/concourse/atc/creds/postgres"
 	_ "github.com/concourse/concourse/atc/creds/secretsmanager"
 	_ "github.com/concourse/concourse/atc/creds/ssm"
 	_ "github.com/concourse/concourse/atc/creds/vault"
@@ -116,6 +125,8 @@ type RunCommand struct {
 
 	varSourcePool creds.VarSourcePool
 
+	buildLogArchive logarchive.Archive
+
 	BindIP   flag.IP `long:"bind-ip"   default:"0.0.0.0" description:"IP address on which to listen for web traffic."`
 	BindPort uint16  `long:"bind-port" default:"8080"    description:"Port on which to listen for HTTP traffic."`
 
@@ -239,6 +250,8 @@ type RunCommand struct {
 
 	JobSchedulingMaxInFlight uint64 `long:"job-scheduling-max-in-flight" default:"32" description:"Maximum number of jobs to be scheduling at the same time"`
 
+	FairShareTeamWeights map[string]float64 `long:"fair-share-team-weight" description:"Weight of a team's share of worker placement, relative to other teams which default to 1. Also orders job scheduling when more jobs are pending than can be scheduled at once. Only applies within each ATC. Can be specified multiple times." value-name:"TEAM:WEIGHT"`
+
 	DefaultCpuLimit    *int    `long:"default-task-cpu-limit" description:"Default max number of cpu shares per task, 0 means unlimited"`
 	DefaultMemoryLimit *string `long:"default-task-memory-limit" description:"Default maximum memory per task, 0 means unlimited"`
 
@@ -262,6 +275,19 @@ type RunCommand struct {
 		CACerts       []string      `long:"syslog-ca-cert"              description:"Paths to PEM-encoded CA cert files to use to verify the Syslog server SSL cert."`
 	} ` group:"Syslog Drainer Configuration"`
 
+	Notifications struct {
+		Interval     time.Duration `long:"notifications-interval" default:"10s" description:"Interval on which to queue and deliver build status notifications."`
+		Timeout      time.Duration `long:"notifications-timeout" default:"10s" description:"Timeout for a single notification webhook request."`
+		MaxAttempts  int           `long:"notifications-max-attempts" default:"5" description:"Maximum number of attempts to deliver a notification before marking it as failed."`
+		RetryBackoff time.Duration `long:"notifications-retry-backoff" default:"30s" description:"Delay before retrying a failed notification delivery. Doubled after every attempt."`
+	} `group:"Build Notifications"`
+
+	BuildLogArchive struct {
+		ArchiveAfter time.Duration `long:"build-log-archive-after" default:"24h" description:"How long after a build finishes to move its events out of the database and into the build log archive."`
+		Interval     time.Duration `long:"build-log-archive-interval" default:"1m" description:"Interval on which to archive the events of finished builds."`
+		BatchSize    int           `long:"build-log-archive-batch-size" default:"100" description:"Maximum number of builds to archive on each interval."`
+	} `group:"Build Log Archiving"`
+
 	Auth struct {
 		AuthFlags     skycmd.AuthFlags
 		MainTeamFlags skycmd.AuthTeamFlags `group:"Authentication (Main Team)" namespace:"main-team"`
@@ -300,6 +326,8 @@ type RunCommand struct {
 	DBNotificationBusQueueSize int `long:"db-notification-bus-queue-size" default:"10000" description:"DB notification bus queue size, default is 10000. If UI often misses loading running build logs, then consider to increase the queue size."`
 
 	DisableRedactSecrets bool `long:"disable-redact-secrets" description:"Disables secret redaction in build logs."`
+
+	RejectConfigSecrets bool `long:"reject-config-secrets" description:"Reject pipeline configs containing what look like literal secrets (e.g. access keys, tokens, private keys), instead of only warning about them."`
 }
 
 type Migration struct {
@@ -478,6 +506,7 @@ func (cmd *ATCCommand) WireDynamicFlags(commandFlags *flags.Command) {
 func (cmd *RunCommand) WireDynamicFlags(commandFlags *flags.Command) {
 	var (
 		metricsGroup      *flags.Group
+		archiveGroup      *flags.Group
 		policyChecksGroup *flags.Group
 		credsGroup        *flags.Group
 		authGroup         *flags.Group
@@ -499,11 +528,15 @@ func (cmd *RunCommand) WireDynamicFlags(commandFlags *flags.Command) {
 			policyChecksGroup = group
 		}
 
+		if archiveGroup == nil && group.ShortDescription == "Build Log Archiving" {
+			archiveGroup = group
+		}
+
 		if authGroup == nil && group.ShortDescription == "Authentication" {
 			authGroup = group
 		}
 
-		if metricsGroup != nil && credsGroup != nil && authGroup != nil && policyChecksGroup != nil {
+		if metricsGroup != nil && credsGroup != nil && authGroup != nil && policyChecksGroup != nil && archiveGroup != nil {
 			break
 		}
 
@@ -522,6 +555,10 @@ func (cmd *RunCommand) WireDynamicFlags(commandFlags *flags.Command) {
 		panic("could not find Credential Management group for registering managers")
 	}
 
+	if archiveGroup == nil {
+		panic("could not find Build Log Archiving group for registering archives")
+	}
+
 	if authGroup == nil {
 		panic("could not find Authentication group for registering connectors")
 	}
@@ -537,6 +574,8 @@ func (cmd *RunCommand) WireDynamicFlags(commandFlags *flags.Command) {
 
 	policy.WireCheckers(policyChecksGroup)
 
+	logarchive.WireArchives(archiveGroup)
+
 	skycmd.WireConnectors(authGroup)
 	skycmd.WireTeamConnectors(authGroup.Find("Authentication (Main Team)"))
 }
@@ -589,6 +628,7 @@ func (cmd *RunCommand) Runner(positionalArguments []string) (ifrit.Runner, error
 	atc.DefaultWebhookInterval = cmd.ResourceWithWebhookCheckingInterval
 	atc.DefaultResourceTypeInterval = cmd.ResourceTypeCheckingInterval
 	atc.DisableRedactSecrets = cmd.DisableRedactSecrets
+	atc.RejectConfigSecrets = cmd.RejectConfigSecrets
 
 	if cmd.FeatureFlags.EnablePipelineInstances {
 		commandSession.Info("deprecated", lager.Data{
@@ -628,6 +668,11 @@ func (cmd *RunCommand) Runner(positionalArguments []string) (ifrit.Runner, error
 		return nil, err
 	}
 
+	cmd.buildLogArchive, err = logarchive.Initialize(logger)
+	if err != nil {
+		return nil, err
+	}
+
 	db.SetupConnectionRetryingDriver(
 		defaultDriverName,
 		cmd.Postgres.ConnectionString(),
@@ -702,13 +747,12 @@ func (cmd *RunCommand) Runner(positionalArguments []string) (ifrit.Runner, error
 		return nil, err
 	}
 
-	issuer := cmd.ExternalURL.String()
-	if cmd.OIDCIssuerURL.String() != "" {
-		issuer = cmd.OIDCIssuerURL.String()
-	}
-
 	idtoken.UpdateGlobalManagerFactory(func(f *idtoken.ManagerFactory) {
-		f.SetIssuer(issuer)
+		f.SetIssuer(cmd.oidcIssuer())
+	})
+
+	postgres.UpdateGlobalManagerFactory(func(f *postgres.ManagerFactory) {
+		f.SetSecretFactory(db.NewSecretFactory(backendConn))
 	})
 
 	secretManager, err := cmd.secretManager(logger)
@@ -724,6 +768,20 @@ func (cmd *RunCommand) Runner(positionalArguments []string) (ifrit.Runner, error
 		clock.NewClock(),
 	)
 
+	cacheLogger := logger.Session("secret-cache")
+	err = db.ListenForSecretCacheInvalidations(cacheLogger, backendConn, func(invalidation creds.SecretCacheInvalidation) {
+		invalidated := creds.InvalidateSecretCache(secretManager, invalidation) + cmd.varSourcePool.InvalidateCache(invalidation)
+
+		cacheLogger.Info("invalidated", lager.Data{
+			"team":        invalidation.Team,
+			"var":         invalidation.Var,
+			"invalidated": invalidated,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	members, err := cmd.constructMembers(logger, reconfigurableSink, apiConn, workerConn, backendConn, gcConn, storage, lockFactory, secretManager)
 	if err != nil {
 		return nil, err
@@ -909,7 +967,7 @@ func (cmd *RunCommand) constructAPIMembers(
 	dbContainerRepository := db.NewContainerRepository(dbConn)
 	dbVolumeRepository := db.NewVolumeRepository(dbConn)
 	gcContainerDestroyer := gc.NewDestroyer(logger, dbContainerRepository, dbVolumeRepository)
-	dbBuildFactory := db.NewBuildFactory(dbConn, lockFactory, cmd.GC.OneOffBuildGracePeriod, cmd.GC.FailedGracePeriod)
+	dbBuildFactory := db.NewBuildFactory(dbConn, lockFactory, cmd.GC.OneOffBuildGracePeriod, cmd.GC.FailedGracePeriod, cmd.buildLogArchive)
 	dbCheckFactory := db.NewCheckFactory(dbConn, lockFactory, secretManager, cmd.varSourcePool, checkBuildsChan, nil)
 	dbAccessTokenFactory := db.NewAccessTokenFactory(dbConn)
 	dbSigningKeyFactory := db.NewSigningKeyFactory(dbConn)
@@ -961,6 +1019,8 @@ func (cmd *RunCommand) constructAPIMembers(
 		dbResourceConfigFactory,
 		userFactory,
 		dbComponentFactory,
+		db.NewSecretUsageFactory(dbConn),
+		db.NewSecretCacheInvalidator(dbConn),
 		dbConn,
 		pool,
 		secretManager,
@@ -1119,13 +1179,14 @@ func (cmd *RunCommand) backendComponents(
 	dbResourceCacheFactory := db.NewResourceCacheFactory(dbConn, lockFactory)
 	dbResourceConfigFactory := db.NewResourceConfigFactory(dbConn, lockFactory)
 
-	dbBuildFactory := db.NewBuildFactory(dbConn, lockFactory, cmd.GC.OneOffBuildGracePeriod, cmd.GC.FailedGracePeriod)
+	dbBuildFactory := db.NewBuildFactory(dbConn, lockFactory, cmd.GC.OneOffBuildGracePeriod, cmd.GC.FailedGracePeriod, cmd.buildLogArchive)
 	dbCheckFactory := db.NewCheckFactory(dbConn, lockFactory, secretManager, cmd.varSourcePool, checkBuildsChan, util.NewSequenceGenerator(1))
 	dbPipelineFactory := db.NewPipelineFactory(dbConn, lockFactory)
 	dbJobFactory := db.NewJobFactory(dbConn, lockFactory)
 	dbPipelineLifecycle := db.NewPipelineLifecycle(dbConn, lockFactory)
 	dbPipelinePauser := db.NewPipelinePauser(dbConn, lockFactory)
 	dbSigningKeyFactory := db.NewSigningKeyFactory(dbConn)
+	dbNotificationDeliveryFactory := db.NewNotificationDeliveryFactory(dbConn, lockFactory)
 
 	dbWorkerFactory := db.NewWorkerFactory(dbConn, workerCache)
 
@@ -1170,6 +1231,7 @@ func (cmd *RunCommand) backendComponents(
 		lockFactory,
 		rateLimiter,
 		policyChecker,
+		dbSigningKeyFactory,
 	)
 
 	buildEventWatcher, err := db.NewBuildBeingWatchedMarker(logger, dbConn, db.DefaultBuildBeingWatchedMarkDuration, clock.NewClock())
@@ -1238,6 +1300,17 @@ func (cmd *RunCommand) backendComponents(
 						dbCheckFactory),
 				},
 				cmd.JobSchedulingMaxInFlight,
+				cmd.fairSharePolicy(),
+			),
+		},
+		{
+			Component: atc.Component{
+				Name:     atc.ComponentTriggerer,
+				Interval: 10 * time.Second,
+			},
+			Runnable: scheduler.NewTriggerer(
+				dbJobFactory,
+				clock.NewClock(),
 			),
 		},
 		{
@@ -1284,6 +1357,25 @@ func (cmd *RunCommand) backendComponents(
 				KeyGracePeriod:      cmd.SigningKey.GracePeriod,
 			},
 		},
+		{
+			Component: atc.Component{
+				Name:     atc.ComponentNotifier,
+				Interval: cmd.Notifications.Interval,
+			},
+			Runnable: notifications.NewNotifier(
+				notifications.Config{
+					ExternalURL:  cmd.ExternalURL.String(),
+					Timeout:      cmd.Notifications.Timeout,
+					MaxAttempts:  cmd.Notifications.MaxAttempts,
+					RetryBackoff: cmd.Notifications.RetryBackoff,
+				},
+				dbNotificationDeliveryFactory,
+				dbBuildFactory,
+				secretManager,
+				cmd.varSourcePool,
+				clock.NewClock(),
+			),
+		},
 	}
 
 	idtoken.UpdateGlobalManagerFactory(func(f *idtoken.ManagerFactory) {
@@ -1306,6 +1398,22 @@ func (cmd *RunCommand) backendComponents(
 		})
 	}
 
+	if cmd.buildLogArchive != nil {
+		components = append(components, RunnableComponent{
+			Component: atc.Component{
+				Name:     atc.ComponentBuildLogArchiver,
+				Interval: cmd.BuildLogArchive.Interval,
+			},
+			Runnable: gc.NewBuildLogArchiver(
+				dbBuildFactory,
+				clock.NewClock(),
+				cmd.BuildLogArchive.ArchiveAfter,
+				cmd.BuildLogArchive.BatchSize,
+				syslogDrainConfigured,
+			),
+		})
+	}
+
 	return components, err
 }
 
@@ -1333,6 +1441,10 @@ func (cmd *RunCommand) streamer(cacheFactory db.ResourceCacheFactory) worker.Str
 	)
 }
 
+func (cmd *RunCommand) fairSharePolicy() fairshare.Policy {
+	return fairshare.Policy{Weights: cmd.FairShareTeamWeights}
+}
+
 func (cmd *RunCommand) constructPool(dbConn db.DbConn, lockFactory lock.LockFactory, workerCache *db.WorkerCache) (worker.Pool, error) {
 	dbResourceCacheFactory := db.NewResourceCacheFactory(dbConn, lockFactory)
 	dbWorkerBaseResourceTypeFactory := db.NewWorkerBaseResourceTypeFactory(dbConn)
@@ -1368,6 +1480,7 @@ func (cmd *RunCommand) constructPool(dbConn db.DbConn, lockFactory lock.LockFact
 		},
 		db,
 		workerVersion,
+		cmd.fairSharePolicy(),
 	), nil
 }
 
@@ -1383,7 +1496,7 @@ func (cmd *RunCommand) gcComponents(
 	dbArtifactLifecycle := db.NewArtifactLifecycle(gcConn)
 	dbAccessTokenLifecycle := db.NewAccessTokenLifecycle(gcConn)
 	resourceConfigCheckSessionLifecycle := db.NewResourceConfigCheckSessionLifecycle(gcConn)
-	dbBuildFactory := db.NewBuildFactory(gcConn, lockFactory, cmd.GC.OneOffBuildGracePeriod, cmd.GC.FailedGracePeriod)
+	dbBuildFactory := db.NewBuildFactory(gcConn, lockFactory, cmd.GC.OneOffBuildGracePeriod, cmd.GC.FailedGracePeriod, cmd.buildLogArchive)
 	dbResourceConfigFactory := db.NewResourceConfigFactory(gcConn, lockFactory)
 	dbPipelineLifecycle := db.NewPipelineLifecycle(gcConn, lockFactory)
 	dbCheckLifecycle := db.NewCheckLifecycle(gcConn)
@@ -1497,10 +1610,25 @@ func workerVersion() (version.Version, error) {
 }
 
 func (cmd *RunCommand) secretManager(logger lager.Logger) (creds.Secrets, error) {
-	var secretsFactory creds.SecretsFactory = noop.NewNoopFactory()
-	for name, manager := range cmd.CredentialManagers {
-		if !manager.IsConfigured() {
-			continue
+	names, err := cmd.CredentialManagement.ConfiguredManagers(cmd.CredentialManagers)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(names) == 0 {
+		return cmd.CredentialManagement.NewSecrets(noop.NewNoopFactory()), nil
+	}
+
+	var factories []creds.NamedSecretsFactory
+	for i, name := range names {
+		manager := cmd.CredentialManagers[name]
+
+		if len(names) > 1 {
+			// reported by the API so that the order can be seen
+			cmd.CredentialManagers[name] = creds.ChainedManager{
+				Manager:  manager,
+				Position: i + 1,
+			}
 		}
 
 		credsLogger := logger.Session("credential-manager", lager.Data{
@@ -1519,14 +1647,22 @@ func (cmd *RunCommand) secretManager(logger lager.Logger) (creds.Secrets, error)
 			return nil, fmt.Errorf("credential manager '%s' misconfigured: %s", name, err)
 		}
 
-		secretsFactory, err = manager.NewSecretsFactory(credsLogger)
+		secretsFactory, err := manager.NewSecretsFactory(credsLogger)
 		if err != nil {
 			return nil, err
 		}
 
-		break
+		factories = append(factories, creds.NamedSecretsFactory{
+			Name:    name,
+			Factory: secretsFactory,
+		})
 	}
 
+	credsLogger := logger.Session("credential-managers")
+	secretsFactory := creds.NewChainedSecretsFactory(factories, func(manager string) {
+		metric.CredentialLookupServed{Manager: manager}.Emit(credsLogger)
+	})
+
 	return cmd.CredentialManagement.NewSecrets(secretsFactory), nil
 }
 
@@ -1751,6 +1887,10 @@ func (cmd *RunCommand) validate() error {
 		errs = multierror.Append(errs, err)
 	}
 
+	if err := cmd.fairSharePolicy().Validate(); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+
 	return errs.ErrorOrNil()
 }
 
@@ -1857,6 +1997,15 @@ func (cmd *RunCommand) configureAuthForDefaultTeam(teamFactory db.TeamFactory) e
 	return nil
 }
 
+// oidcIssuer is the issuer of the ID tokens minted for var sources and steps.
+func (cmd *RunCommand) oidcIssuer() string {
+	if cmd.OIDCIssuerURL.String() != "" {
+		return cmd.OIDCIssuerURL.String()
+	}
+
+	return cmd.ExternalURL.String()
+}
+
 func (cmd *RunCommand) constructEngine(
 	workerPool worker.Pool,
 	workerFactory db.WorkerFactory,
@@ -1872,6 +2021,7 @@ func (cmd *RunCommand) constructEngine(
 	lockFactory lock.LockFactory,
 	rateLimiter engine.RateLimiter,
 	policyChecker policy.Checker,
+	signingKeyFactory db.SigningKeyFactory,
 ) engine.Engine {
 	return engine.NewEngine(
 		engine.NewStepperFactory(
@@ -1892,6 +2042,7 @@ func (cmd *RunCommand) constructEngine(
 				cmd.DefaultPutTimeout,
 				cmd.DefaultTaskTimeout,
 				cmd.DefaultTaskCacheTTL,
+				idtoken.NewStepTokenGenerator(cmd.oidcIssuer(), signingKeyFactory),
 			),
 			cmd.ExternalURL.String(),
 			rateLimiter,
@@ -2084,6 +2235,8 @@ func (cmd *RunCommand) constructAPIHandler(
 	resourceConfigFactory db.ResourceConfigFactory,
 	dbUserFactory db.UserFactory,
 	dbComponentFactory db.ComponentFactory,
+	dbSecretUsageFactory db.SecretUsageFactory,
+	dbSecretCacheInvalidator db.SecretCacheInvalidator,
 	dbConn db.DbConn,
 	workerPool worker.Pool,
 	secretManager creds.Secrets,
@@ -2119,13 +2272,15 @@ func (cmd *RunCommand) constructAPIHandler(
 		return nil, err
 	}
 
+	apiPolicyChecker := policychecker.NewApiPolicyChecker(policyChecker)
+
 	apiWrapper := wrappa.MultiWrappa{
 		wrappa.NewConcurrentRequestLimitsWrappa(
 			logger,
 			wrappa.NewConcurrentRequestPolicy(cmd.ConcurrentRequestLimits),
 		),
 		wrappa.NewAPIMetricsWrappa(logger),
-		wrappa.NewPolicyCheckWrappa(logger, policychecker.NewApiPolicyChecker(policyChecker)),
+		wrappa.NewPolicyCheckWrappa(logger, apiPolicyChecker),
 		wrappa.NewAPIAuthWrappa(
 			checkPipelineAccessHandlerFactory,
 			checkBuildReadAccessHandlerFactory,
@@ -2164,6 +2319,8 @@ func (cmd *RunCommand) constructAPIHandler(
 		resourceConfigFactory,
 		dbUserFactory,
 		dbComponentFactory,
+		dbSecretUsageFactory,
+		dbSecretCacheInvalidator,
 		dbConn,
 		cmd.Health.MinWorkerCount,
 		cmd.Health.ComponentStaleMultiplier,
@@ -2187,6 +2344,7 @@ func (cmd *RunCommand) constructAPIHandler(
 		dbWall,
 		clock.NewClock(),
 		dbSigningKeyFactory,
+		apiPolicyChecker,
 	)
 }
 
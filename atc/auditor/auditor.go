@@ -66,6 +66,9 @@ func (a *auditor) ValidateAction(action string) bool {
 		atc.BuildResources,
 		atc.AbortBuild,
 		atc.GetBuildPreparation,
+		atc.ListBuildApprovals,
+		atc.ApproveBuildStep,
+		atc.RejectBuildStep,
 		atc.ListBuildsWithVersionAsInput,
 		atc.ListBuildsWithVersionAsOutput,
 		atc.CreateArtifact,
@@ -105,6 +108,11 @@ func (a *auditor) ValidateAction(action string) bool {
 		atc.RenamePipeline,
 		atc.ListPipelineBuilds,
 		atc.CreatePipelineBuild,
+		atc.ListNotificationDeliveries,
+		atc.SearchPipelineBuildLogs,
+		atc.ListPipelineConfigRevisions,
+		atc.GetPipelineConfigRevision,
+		atc.RestorePipelineConfigRevision,
 		atc.PipelineBadge:
 		return a.EnablePipelineAuditLog
 	case atc.ListAllResources,
@@ -115,6 +123,7 @@ func (a *auditor) ValidateAction(action string) bool {
 		atc.SetPinCommentOnResource,
 		atc.CheckResource,
 		atc.CheckResourceWebHook,
+		atc.CheckResourcesWebHook,
 		atc.CheckResourceType,
 		atc.CheckPrototype,
 		atc.ListResourceVersions,
@@ -133,6 +142,7 @@ func (a *auditor) ValidateAction(action string) bool {
 	case
 		atc.SaveConfig,
 		atc.GetConfig,
+		atc.PlanPipelineConfig,
 		atc.GetCC,
 		atc.GetVersionsDB,
 		atc.ClearTaskCache,
@@ -151,13 +161,18 @@ func (a *auditor) ValidateAction(action string) bool {
 		atc.PauseAllComponents,
 		atc.UnpauseAllComponents,
 		atc.PauseComponent,
-		atc.UnpauseComponent:
+		atc.UnpauseComponent,
+		atc.ClearSecretCache:
 		return a.EnableSystemAuditLog
 	case atc.ListTeams,
 		atc.SetTeam,
 		atc.RenameTeam,
 		atc.DestroyTeam,
 		atc.ListTeamBuilds,
+		atc.ListSecrets,
+		atc.SetSecret,
+		atc.DeleteSecret,
+		atc.ListSecretUsages,
 		atc.GetTeam:
 		return a.EnableTeamAuditLog
 	case atc.RegisterWorker,
@@ -15,6 +15,10 @@ func (status BuildStatus) String() string {
 	return string(status)
 }
 
+// ScheduledBuildCreator is recorded as the creator of builds triggered by a
+// job's schedule.
+const ScheduledBuildCreator = "scheduled"
+
 type Build struct {
 	ID                   int           `json:"id"`
 	TeamName             string        `json:"team_name"`
@@ -32,6 +36,8 @@ type Build struct {
 	ReapTime             int64         `json:"reap_time,omitempty"`
 	RerunNumber          int           `json:"rerun_number,omitempty"`
 	RerunOf              *RerunOfBuild `json:"rerun_of,omitempty"`
+	ParentBuildID        int           `json:"parent_build_id,omitempty"`
+	MatrixValues         MatrixValues  `json:"matrix_values,omitempty"`
 	CreatedBy            *string       `json:"created_by,omitempty"`
 }
 
@@ -72,6 +78,7 @@ type BuildPreparation struct {
 	PausedPipeline      BuildPreparationStatus            `json:"paused_pipeline"`
 	PausedJob           BuildPreparationStatus            `json:"paused_job"`
 	MaxRunningBuilds    BuildPreparationStatus            `json:"max_running_builds"`
+	ConcurrencyGroup    BuildPreparationStatus            `json:"concurrency_group"`
 	Inputs              map[string]BuildPreparationStatus `json:"inputs"`
 	InputsSatisfied     BuildPreparationStatus            `json:"inputs_satisfied"`
 	MissingInputReasons MissingInputReasons               `json:"missing_input_reasons"`
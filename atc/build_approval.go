@@ -0,0 +1,22 @@
+package atc
+
+type ApprovalStatus string
+
+const (
+	ApprovalPending  ApprovalStatus = "pending"
+	ApprovalApproved ApprovalStatus = "approved"
+	ApprovalRejected ApprovalStatus = "rejected"
+	ApprovalTimedOut ApprovalStatus = "timed-out"
+)
+
+// BuildApproval is the state of an approve step's gate within a build.
+type BuildApproval struct {
+	PlanID    PlanID         `json:"plan_id"`
+	Name      string         `json:"name"`
+	Status    ApprovalStatus `json:"status"`
+	Users     []string       `json:"users,omitempty"`
+	Roles     []string       `json:"roles,omitempty"`
+	DecidedBy string         `json:"decided_by,omitempty"`
+	CreatedAt int64          `json:"created_at"`
+	DecidedAt int64          `json:"decided_at,omitempty"`
+}
@@ -0,0 +1,23 @@
+package atc
+
+// BuildLogMatch is a line of a build's log which matched a log search.
+type BuildLogMatch struct {
+	BuildID   int    `json:"build_id"`
+	BuildName string `json:"build_name"`
+	JobName   string `json:"job_name,omitempty"`
+	PlanID    PlanID `json:"plan_id"`
+	StepName  string `json:"step_name,omitempty"`
+	Line      int    `json:"line"`
+	Text      string `json:"text"`
+	Time      int64  `json:"time"`
+}
+
+// BuildLogSearchResults is the response to a log search. Truncated is set if
+// the search stopped at its match limit, or if there were more builds in
+// range than a single search will scan. ArchivedBuilds counts the builds in
+// range which were not searched because their logs have been archived.
+type BuildLogSearchResults struct {
+	Matches        []BuildLogMatch `json:"matches"`
+	Truncated      bool            `json:"truncated"`
+	ArchivedBuilds int             `json:"archived_builds,omitempty"`
+}
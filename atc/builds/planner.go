@@ -83,6 +83,7 @@ func (visitor *planVisitor) VisitTask(step *atc.TaskStep) error {
 		OutputMapping:     step.OutputMapping,
 		ImageArtifactName: step.ImageArtifactName,
 		Timeout:           step.Timeout,
+		IDToken:           step.IDToken,
 
 		ResourceTypes:     visitor.resourceTypes,
 		CheckSkipInterval: visitor.manuallyTriggered,
@@ -107,6 +108,7 @@ func (visitor *planVisitor) VisitRun(step *atc.RunStep) error {
 		Tags:       visitor.effectiveTags(step.Tags),
 		Limits:     step.Limits,
 		Timeout:    step.Timeout,
+		IDToken:    step.IDToken,
 	})
 
 	return nil
@@ -315,6 +317,17 @@ func (visitor *planVisitor) VisitLoadVar(step *atc.LoadVarStep) error {
 	return nil
 }
 
+func (visitor *planVisitor) VisitApprove(step *atc.ApproveStep) error {
+	visitor.plan = visitor.planFactory.NewPlan(atc.ApprovePlan{
+		Name:    step.Name,
+		Users:   step.Users,
+		Roles:   step.Roles,
+		Timeout: step.Timeout,
+	})
+
+	return nil
+}
+
 func (visitor *planVisitor) VisitTry(step *atc.TryStep) error {
 	err := step.Step.Config.Visit(visitor)
 	if err != nil {
@@ -342,6 +355,20 @@ func (visitor *planVisitor) VisitTimeout(step *atc.TimeoutStep) error {
 	return nil
 }
 
+func (visitor *planVisitor) VisitIf(step *atc.IfStep) error {
+	err := step.Step.Visit(visitor)
+	if err != nil {
+		return err
+	}
+
+	visitor.plan = visitor.planFactory.NewPlan(atc.IfPlan{
+		Condition: step.Condition,
+		Step:      visitor.plan,
+	})
+
+	return nil
+}
+
 func (visitor *planVisitor) VisitRetry(step *atc.RetryStep) error {
 	retryStep := make(atc.RetryPlan, step.Attempts)
 
@@ -1397,6 +1397,48 @@ var factoryTests = []PlannerTest{
 			}
 		}`,
 	},
+	{
+		Title: "task step with an id token",
+
+		Config: &atc.TaskStep{
+			Name: "some-task",
+			Config: &atc.TaskConfig{
+				Platform: "linux",
+				Run:      atc.TaskRunConfig{Path: "hello"},
+			},
+			IDToken: &atc.IDTokenConfig{
+				Audience:  []string{"sts.amazonaws.com"},
+				Env:       "AWS_WEB_IDENTITY_TOKEN",
+				ExpiresIn: "15m",
+			},
+		},
+
+		PlanJSON: `{
+			"id": "(unique)",
+			"task": {
+				"name": "some-task",
+				"privileged": false,
+				"hermetic": false,
+				"config": {
+					"platform": "linux",
+					"run": {"path": "hello"}
+				},
+				"id_token": {
+					"audience": ["sts.amazonaws.com"],
+					"env": "AWS_WEB_IDENTITY_TOKEN",
+					"expires_in": "15m"
+				},
+				"resource_types": [
+					{
+						"name": "some-resource-type",
+						"type": "some-base-resource-type",
+						"source": {"some": "type-source"},
+						"defaults": {"default-key":"default-value"}
+					}
+				]
+			}
+		}`,
+	},
 	{
 		Title: "run step",
 
@@ -1414,6 +1456,7 @@ var factoryTests = []PlannerTest{
 				Memory: newMemoryLimit(2048),
 			},
 			Timeout: "1h",
+			IDToken: &atc.IDTokenConfig{Audience: []string{"some-audience"}},
 		},
 
 		PlanJSON: `{
@@ -1429,7 +1472,8 @@ var factoryTests = []PlannerTest{
 				"privileged": true,
 				"tags": ["tag-1", "tag-2"],
 				"container_limits": {"cpu": 456, "memory": 2048},
-				"timeout": "1h"
+				"timeout": "1h",
+				"id_token": {"audience": ["some-audience"]}
 			}
 		}`,
 	},
@@ -1475,6 +1519,26 @@ var factoryTests = []PlannerTest{
 			}
 		}`,
 	},
+	{
+		Title: "approve step",
+
+		Config: &atc.ApproveStep{
+			Name:    "ship-it",
+			Users:   []string{"some-user"},
+			Roles:   []string{"owner"},
+			Timeout: "1h",
+		},
+
+		PlanJSON: `{
+			"id": "(unique)",
+			"approve": {
+				"name": "ship-it",
+				"users": ["some-user"],
+				"roles": ["owner"],
+				"timeout": "1h"
+			}
+		}`,
+	},
 	{
 		Title: "try step",
 
@@ -1883,6 +1947,31 @@ var factoryTests = []PlannerTest{
 			}
 		}`,
 	},
+	{
+		Title: "if modifier",
+
+		Config: &atc.IfStep{
+			Step: &atc.LoadVarStep{
+				Name: "some-var",
+				File: "some-file",
+			},
+			Condition: "steps.unit.succeeded",
+		},
+
+		PlanJSON: `{
+			"id": "(unique)",
+			"if": {
+				"step": {
+					"id": "(unique)",
+					"load_var": {
+						"name": "some-var",
+						"file": "some-file"
+					}
+				},
+				"condition": "steps.unit.succeeded"
+			}
+		}`,
+	},
 	{
 		Title: "attempts modifier",
 
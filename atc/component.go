@@ -24,6 +24,9 @@ const (
 	ComponentPipelinePauser             = "pipeline_pauser"
 	ComponentBeingWatchedBuildMarker    = "being_watched_build_marker"
 	ComponentSigningKeyLifecycler       = "signing_key_lifecycler"
+	ComponentNotifier                   = "notifier"
+	ComponentBuildLogArchiver           = "build_log_archiver"
+	ComponentTriggerer                  = "triggerer"
 )
 
 var (
@@ -32,6 +35,7 @@ var (
 		ComponentBuildTracker,
 		ComponentLidarScanner,
 		ComponentScheduler,
+		ComponentTriggerer,
 	}
 
 	// These components GC data in the database, and artifacts (containers, volumes) on Workers.
@@ -18,14 +18,15 @@ const ConfigVersionHeader = "X-Concourse-Config-Version"
 const DefaultTeamName = "main"
 
 type Config struct {
-	Groups        GroupConfigs     `json:"groups,omitempty"`
-	VarSources    VarSourceConfigs `json:"var_sources,omitempty"`
-	Resources     ResourceConfigs  `json:"resources,omitempty"`
-	ResourceTypes ResourceTypes    `json:"resource_types,omitempty"`
-	Prototypes    Prototypes       `json:"prototypes,omitempty"`
-	Jobs          JobConfigs       `json:"jobs,omitempty"`
-	Display       *DisplayConfig   `json:"display,omitempty"`
-	UserData      any              `json:"user_data,omitempty"`
+	Groups        GroupConfigs        `json:"groups,omitempty"`
+	VarSources    VarSourceConfigs    `json:"var_sources,omitempty"`
+	Resources     ResourceConfigs     `json:"resources,omitempty"`
+	ResourceTypes ResourceTypes       `json:"resource_types,omitempty"`
+	Prototypes    Prototypes          `json:"prototypes,omitempty"`
+	Jobs          JobConfigs          `json:"jobs,omitempty"`
+	Notifications NotificationConfigs `json:"notifications,omitempty"`
+	Display       *DisplayConfig      `json:"display,omitempty"`
+	UserData      any                 `json:"user_data,omitempty"`
 }
 
 func UnmarshalConfig(payload []byte, config any) error {
@@ -37,6 +38,7 @@ func UnmarshalConfig(payload []byte, config any) error {
 		ResourceTypes any `json:"resource_types,omitempty"`
 		Prototypes    any `json:"prototypes,omitempty"`
 		Jobs          any `json:"jobs,omitempty"`
+		Notifications any `json:"notifications,omitempty"`
 		Display       any `json:"display,omitempty"`
 		UserData      any `json:"user_data,omitempty"`
 	}
@@ -178,18 +180,19 @@ func (c VarSourceConfigs) OrderByDependency() (VarSourceConfigs, error) {
 }
 
 type ResourceConfig struct {
-	Name                 string      `json:"name"`
-	OldName              string      `json:"old_name,omitempty"`
-	Public               bool        `json:"public,omitempty"`
-	WebhookToken         string      `json:"webhook_token,omitempty"`
-	Type                 string      `json:"type"`
-	Source               Source      `json:"source"`
-	CheckEvery           *CheckEvery `json:"check_every,omitempty"`
-	CheckTimeout         string      `json:"check_timeout,omitempty"`
-	Tags                 Tags        `json:"tags,omitempty"`
-	Version              Version     `json:"version,omitempty"`
-	Icon                 string      `json:"icon,omitempty"`
-	ExposeBuildCreatedBy bool        `json:"expose_build_created_by,omitempty"`
+	Name                 string           `json:"name"`
+	OldName              string           `json:"old_name,omitempty"`
+	Public               bool             `json:"public,omitempty"`
+	WebhookToken         string           `json:"webhook_token,omitempty"`
+	Webhook              *ResourceWebhook `json:"webhook,omitempty"`
+	Type                 string           `json:"type"`
+	Source               Source           `json:"source"`
+	CheckEvery           *CheckEvery      `json:"check_every,omitempty"`
+	CheckTimeout         string           `json:"check_timeout,omitempty"`
+	Tags                 Tags             `json:"tags,omitempty"`
+	Version              Version          `json:"version,omitempty"`
+	Icon                 string           `json:"icon,omitempty"`
+	ExposeBuildCreatedBy bool             `json:"expose_build_created_by,omitempty"`
 }
 
 type ResourceType struct {
@@ -151,6 +151,21 @@ func (index ResourceTypeIndex) FindEquivalent(obj any) (any, bool) {
 	return ResourceTypes(index).Lookup(name(obj))
 }
 
+type NotificationIndex NotificationConfigs
+
+func (index NotificationIndex) Slice() []any {
+	slice := make([]any, len(index))
+	for i, object := range index {
+		slice[i] = object
+	}
+
+	return slice
+}
+
+func (index NotificationIndex) FindEquivalent(obj any) (any, bool) {
+	return NotificationConfigs(index).Lookup(name(obj))
+}
+
 func groupDiffIndices(oldIndex GroupIndex, newIndex GroupIndex) Diffs {
 	diffs := Diffs{}
 
@@ -367,6 +382,16 @@ func (c Config) Diff(out io.Writer, newConfig Config) bool {
 		}
 	}
 
+	notificationDiffs := diffIndices(NotificationIndex(c.Notifications), NotificationIndex(newConfig.Notifications))
+	if len(notificationDiffs) > 0 {
+		diffExists = true
+		fmt.Fprintln(out, "notifications:")
+
+		for _, diff := range notificationDiffs {
+			diff.Render(indent, "notification")
+		}
+	}
+
 	displayDiff, diff := diffDisplay(c.Display, newConfig.Display)
 	if diff {
 		diffExists = true
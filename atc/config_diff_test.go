@@ -299,4 +299,50 @@ var _ = Describe("Config diff", func() {
 			})
 		})
 	})
+
+	Describe("notifications", func() {
+		var notification NotificationConfig
+		BeforeEach(func() {
+			notification = NotificationConfig{
+				Name: "some-notification",
+				Webhook: NotificationWebhook{
+					URL: "https://example.com/hook",
+				},
+				Statuses: []BuildStatus{StatusFailed},
+			}
+		})
+
+		Context("when a notification is added", func() {
+			It("says the notification has been added", func() {
+				buffer := NewBuffer()
+				diff := Config{}.Diff(buffer, Config{
+					Notifications: NotificationConfigs{notification},
+				})
+				Expect(diff).To(BeTrue())
+				Eventually(buffer).Should(Say("notifications:"))
+				Eventually(buffer).Should(Say("notification some-notification has been added:"))
+				Eventually(buffer).Should(Say(`\+.*url: https://example.com/hook`))
+			})
+		})
+
+		Context("when a notification changes", func() {
+			It("shows the diff", func() {
+				oldConfig := Config{
+					Notifications: NotificationConfigs{notification},
+				}
+
+				notification.Statuses = []BuildStatus{StatusErrored}
+				newConfig := Config{
+					Notifications: NotificationConfigs{notification},
+				}
+
+				buffer := NewBuffer()
+				diff := oldConfig.Diff(buffer, newConfig)
+				Expect(diff).To(BeTrue())
+				Eventually(buffer).Should(Say("notification some-notification has changed:"))
+				Eventually(buffer).Should(Say(`-.*- failed`))
+				Eventually(buffer).Should(Say(`\+.*- errored`))
+			})
+		})
+	})
 })
@@ -0,0 +1,56 @@
+package atc
+
+// ConfigPlan describes what saving a config would do to the state the
+// pipeline has already built up, beyond the changes to the config itself.
+type ConfigPlan struct {
+	// RecheckedResources will get a new resource config scope, and so start
+	// over with an empty version history.
+	RecheckedResources []ConfigPlanResource `json:"rechecked_resources"`
+
+	// RemovedJobs are jobs with builds which are no longer in the config, and
+	// which no job claims as its old_name.
+	RemovedJobs []ConfigPlanJob `json:"removed_jobs"`
+
+	// OrphanedPins and OrphanedDisabledVersions refer to versions which will
+	// no longer be part of their resource's version history.
+	OrphanedPins             []ConfigPlanVersion `json:"orphaned_pins"`
+	OrphanedDisabledVersions []ConfigPlanVersion `json:"orphaned_disabled_versions"`
+
+	PassedConstraints []ConfigPlanPassedChange `json:"passed_constraints"`
+}
+
+type ConfigPlanResource struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+type ConfigPlanJob struct {
+	Name   string `json:"name"`
+	Builds int    `json:"builds"`
+}
+
+type ConfigPlanVersion struct {
+	Resource string  `json:"resource"`
+	Version  Version `json:"version"`
+	Reason   string  `json:"reason"`
+}
+
+// ConfigPlanPassedChange is a get step whose passed constraints differ
+// between the current and the new config. Before is empty for a new get step,
+// After is empty for a removed one.
+type ConfigPlanPassedChange struct {
+	Job    string   `json:"job"`
+	Input  string   `json:"input"`
+	Before []string `json:"before"`
+	After  []string `json:"after"`
+}
+
+// IsEmpty returns true if saving the config has no consequences beyond the
+// config itself.
+func (plan ConfigPlan) IsEmpty() bool {
+	return len(plan.RecheckedResources) == 0 &&
+		len(plan.RemovedJobs) == 0 &&
+		len(plan.OrphanedPins) == 0 &&
+		len(plan.OrphanedDisabledVersions) == 0 &&
+		len(plan.PassedConstraints) == 0
+}
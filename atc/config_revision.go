@@ -0,0 +1,18 @@
+package atc
+
+// PipelineConfigRevision is a config which was saved for a pipeline. The
+// Build fields are set if it was saved by a set_pipeline step, and Config is
+// only set when a single revision is requested.
+type PipelineConfigRevision struct {
+	Revision      int    `json:"revision"`
+	ConfigVersion int    `json:"config_version"`
+	CreatedBy     string `json:"created_by,omitempty"`
+	CreatedAt     int64  `json:"created_at"`
+
+	BuildID           int    `json:"build_id,omitempty"`
+	BuildName         string `json:"build_name,omitempty"`
+	BuildJobName      string `json:"build_job_name,omitempty"`
+	BuildPipelineName string `json:"build_pipeline_name,omitempty"`
+
+	Config *Config `json:"config,omitempty"`
+}
@@ -40,6 +40,56 @@ var _ = Describe("Config", func() {
 				})
 			})
 		})
+
+		Context("when unmarshaling a version filter from JSON", func() {
+			It("produces a version config with the filter", func() {
+				var versionConfig VersionConfig
+				bs := []byte(`{ "filter": { "semver": "~1.2", "match": { "ref": "^v" } } }`)
+				err := json.Unmarshal(bs, &versionConfig)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(versionConfig).To(Equal(VersionConfig{
+					Filter: &VersionFilter{
+						Semver: "~1.2",
+						Match:  map[string]string{"ref": "^v"},
+					},
+				}))
+			})
+
+			It("round-trips through JSON", func() {
+				versionConfig := VersionConfig{
+					Filter: &VersionFilter{Metadata: map[string]string{"channel": "stable"}},
+				}
+
+				bs, err := json.Marshal(&versionConfig)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(bs).To(MatchJSON(`{"filter":{"metadata":{"channel":"stable"}}}`))
+
+				var unmarshaled VersionConfig
+				err = json.Unmarshal(bs, &unmarshaled)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(unmarshaled).To(Equal(versionConfig))
+			})
+
+			It("treats a version with a string filter field as pinned", func() {
+				var versionConfig VersionConfig
+				err := json.Unmarshal([]byte(`{ "filter": "abc" }`), &versionConfig)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(versionConfig).To(Equal(VersionConfig{Pinned: Version{"filter": "abc"}}))
+			})
+
+			It("errors when the filter has unknown fields", func() {
+				var versionConfig VersionConfig
+				err := json.Unmarshal([]byte(`{ "filter": { "regex": "^v" } }`), &versionConfig)
+				Expect(err).To(MatchError(ContainSubstring("invalid version filter")))
+			})
+
+			It("errors when the filter is combined with other fields", func() {
+				var versionConfig VersionConfig
+				err := json.Unmarshal([]byte(`{ "filter": { "semver": "1.x" }, "ref": "abc" }`), &versionConfig)
+				Expect(err).To(MatchError("a version filter cannot be combined with other version fields"))
+			})
+		})
 	})
 
 	Describe("VarSourceConfigs.OrderByDependency", func() {
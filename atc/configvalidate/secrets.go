@@ -0,0 +1,211 @@
+package configvalidate
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+)
+
+// SecretDetector looks for a kind of secret which has been pasted into a
+// config value literally instead of being referenced with a ((var)).
+type SecretDetector interface {
+	// Name describes the kind of secret, e.g. "AWS access key".
+	Name() string
+
+	// Detect reports whether the value contains the secret.
+	Detect(value string) bool
+}
+
+// PatternDetector detects secrets which have a recognizable format.
+type PatternDetector struct {
+	Description string
+	Pattern     *regexp.Regexp
+}
+
+func (detector PatternDetector) Name() string { return detector.Description }
+
+func (detector PatternDetector) Detect(value string) bool {
+	return detector.Pattern.MatchString(value)
+}
+
+// EntropyDetector detects long random-looking tokens, which are likely to be
+// secrets even when their format isn't recognized. Only tokens mixing upper
+// case letters, lower case letters and digits are considered, which rules out
+// paths and identifiers.
+type EntropyDetector struct {
+	// MinLength is the length a token must have to be considered.
+	MinLength int
+
+	// MinEntropy is the Shannon entropy, in bits per character, above which
+	// a token is considered random. Hex strings such as commit SHAs have at
+	// most 4 bits per character.
+	MinEntropy float64
+}
+
+var entropyToken = regexp.MustCompile(`[A-Za-z0-9+/=_-]+`)
+
+func (detector EntropyDetector) Name() string { return "high-entropy string" }
+
+func (detector EntropyDetector) Detect(value string) bool {
+	for _, token := range entropyToken.FindAllString(value, -1) {
+		if len(token) >= detector.MinLength && mixedCase(token) && shannonEntropy(token) > detector.MinEntropy {
+			return true
+		}
+	}
+
+	return false
+}
+
+func mixedCase(token string) bool {
+	return strings.ContainsAny(token, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") &&
+		strings.ContainsAny(token, "abcdefghijklmnopqrstuvwxyz") &&
+		strings.ContainsAny(token, "0123456789")
+}
+
+func shannonEntropy(token string) float64 {
+	counts := map[rune]int{}
+	for _, r := range token {
+		counts[r]++
+	}
+
+	var entropy float64
+	for _, count := range counts {
+		p := float64(count) / float64(len(token))
+		entropy -= p * math.Log2(p)
+	}
+
+	return entropy
+}
+
+var secretDetectors = []SecretDetector{
+	PatternDetector{
+		Description: "AWS access key",
+		Pattern:     regexp.MustCompile(`\b(AKIA|ASIA)[0-9A-Z]{16}\b`),
+	},
+	PatternDetector{
+		Description: "GitHub token",
+		Pattern:     regexp.MustCompile(`\b(gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{40,})\b`),
+	},
+	PatternDetector{
+		Description: "private key",
+		Pattern:     regexp.MustCompile(`-----BEGIN ([A-Z]+ )*PRIVATE KEY( BLOCK)?-----`),
+	},
+	EntropyDetector{
+		MinLength:  32,
+		MinEntropy: 4.5,
+	},
+}
+
+// RegisterSecretDetector adds a detector to the ones run over every config.
+func RegisterSecretDetector(detector SecretDetector) {
+	secretDetectors = append(secretDetectors, detector)
+}
+
+// ValidateVarFileSecrets looks for literal secrets in the vars loaded from a
+// var file. They are reported as warnings or errors in the same way as
+// secrets found in the config itself.
+func ValidateVarFileSecrets(path string, vars map[string]any) ([]atc.ConfigWarning, []string) {
+	warnings, err := secretFindings(detectSecrets("var file "+path, vars))
+	if err != nil {
+		return warnings, []string{formatErr("var files", err)}
+	}
+
+	return warnings, nil
+}
+
+func validateSecrets(c atc.Config) ([]atc.ConfigWarning, error) {
+	var findings []string
+
+	for _, resource := range c.Resources {
+		findings = append(findings, detectSecrets("resources."+resource.Name+".source", resource.Source)...)
+	}
+
+	for _, resourceType := range c.ResourceTypes {
+		identifier := "resource_types." + resourceType.Name
+		findings = append(findings, detectSecrets(identifier+".source", resourceType.Source)...)
+		findings = append(findings, detectSecrets(identifier+".defaults", resourceType.Defaults)...)
+	}
+
+	for _, job := range c.Jobs {
+		_ = job.StepConfig().Visit(atc.StepRecursor{
+			OnTask: func(step *atc.TaskStep) error {
+				identifier := "jobs." + job.Name + ".task(" + step.Name + ")"
+				findings = append(findings, detectSecrets(identifier+".params", taskEnv(step.Params))...)
+				if step.Config != nil {
+					findings = append(findings, detectSecrets(identifier+".config.params", taskEnv(step.Config.Params))...)
+				}
+				return nil
+			},
+		})
+	}
+
+	return secretFindings(findings)
+}
+
+// secretFindings reports the findings as errors if the ATC rejects configs
+// containing secrets, and as warnings otherwise.
+func secretFindings(findings []string) ([]atc.ConfigWarning, error) {
+	if atc.RejectConfigSecrets {
+		return nil, compositeErr(findings)
+	}
+
+	var warnings []atc.ConfigWarning
+	for _, finding := range findings {
+		warnings = append(warnings, atc.ConfigWarning{
+			Type:    "literal_secret",
+			Message: finding,
+		})
+	}
+
+	return warnings, nil
+}
+
+func taskEnv(env atc.TaskEnv) map[string]any {
+	values := map[string]any{}
+	for k, v := range env {
+		values[k] = v
+	}
+
+	return values
+}
+
+// detectSecrets walks the value, returning a message for each string which
+// looks like a secret. Map keys are visited in order so that the messages are
+// stable.
+func detectSecrets(identifier string, value any) []string {
+	switch v := value.(type) {
+	case atc.Source:
+		return detectSecrets(identifier, map[string]any(v))
+	case map[string]any:
+		var findings []string
+		for _, key := range slices.Sorted(maps.Keys(v)) {
+			findings = append(findings, detectSecrets(identifier+"."+key, v[key])...)
+		}
+		return findings
+	case map[any]any:
+		converted := map[string]any{}
+		for key, val := range v {
+			converted[fmt.Sprint(key)] = val
+		}
+		return detectSecrets(identifier, converted)
+	case []any:
+		var findings []string
+		for i, val := range v {
+			findings = append(findings, detectSecrets(fmt.Sprintf("%s[%d]", identifier, i), val)...)
+		}
+		return findings
+	case string:
+		for _, detector := range secretDetectors {
+			if detector.Detect(v) {
+				return []string{fmt.Sprintf("%s looks like a literal %s; use a ((var)) instead", identifier, detector.Name())}
+			}
+		}
+	}
+
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/creds"
@@ -81,12 +82,24 @@ func Validate(c atc.Config) ([]atc.ConfigWarning, []string) {
 	}
 	warnings = append(warnings, jobWarnings...)
 
+	notificationsWarnings, notificationsErr := validateNotifications(c)
+	if notificationsErr != nil {
+		errorMessages = append(errorMessages, formatErr("notifications", notificationsErr))
+	}
+	warnings = append(warnings, notificationsWarnings...)
+
 	displayWarnings, displayErr := validateDisplay(c)
 	if displayErr != nil {
 		errorMessages = append(errorMessages, formatErr("display config", displayErr))
 	}
 	warnings = append(warnings, displayWarnings...)
 
+	secretsWarnings, secretsErr := validateSecrets(c)
+	if secretsErr != nil {
+		errorMessages = append(errorMessages, formatErr("secrets", secretsErr))
+	}
+	warnings = append(warnings, secretsWarnings...)
+
 	cycleErr := validateCycle(c)
 
 	if cycleErr != nil {
@@ -207,6 +220,10 @@ func validateResources(c atc.Config) ([]atc.ConfigWarning, error) {
 		if resource.Type == "" {
 			errorMessages = append(errorMessages, identifier+" has no type")
 		}
+
+		if resource.Webhook != nil {
+			errorMessages = append(errorMessages, validateResourceWebhook(identifier, resource)...)
+		}
 	}
 
 	errorMessages = append(errorMessages, validateResourcesUnused(c)...)
@@ -214,6 +231,46 @@ func validateResources(c atc.Config) ([]atc.ConfigWarning, error) {
 	return warnings, compositeErr(errorMessages)
 }
 
+func validateResourceWebhook(identifier string, resource atc.ResourceConfig) []string {
+	var errorMessages []string
+
+	webhook := resource.Webhook
+
+	if resource.WebhookToken != "" {
+		errorMessages = append(errorMessages, identifier+" can't use both webhook_token and webhook")
+	}
+
+	validProvider := false
+	for _, provider := range atc.WebhookProviders {
+		if webhook.ProviderName() == provider {
+			validProvider = true
+			break
+		}
+	}
+
+	if !validProvider {
+		errorMessages = append(errorMessages, fmt.Sprintf("%s.webhook has unknown provider '%s'", identifier, webhook.Provider))
+	}
+
+	if webhook.Secret == "" && webhook.Token == "" {
+		errorMessages = append(errorMessages, identifier+".webhook must specify a secret or a token")
+	}
+
+	if webhook.ReplayWindow != "" {
+		if _, err := webhook.ReplayWindowDuration(); err != nil {
+			errorMessages = append(errorMessages, fmt.Sprintf("%s.webhook has invalid replay_window: %s", identifier, err))
+		}
+	}
+
+	for _, expr := range append(append([]string{}, webhook.Branches...), webhook.Paths...) {
+		if _, err := glob.Compile(expr, '/'); err != nil {
+			errorMessages = append(errorMessages, fmt.Sprintf("%s.webhook has invalid glob expression '%s'", identifier, expr))
+		}
+	}
+
+	return errorMessages
+}
+
 func validateResourceTypes(c atc.Config, seenTypes map[string]location) ([]atc.ConfigWarning, error) {
 	var warnings []atc.ConfigWarning
 	var errorMessages []string
@@ -403,6 +460,29 @@ func validateJobs(c atc.Config) ([]atc.ConfigWarning, error) {
 			}
 		}
 
+		if job.ConcurrencyGroup != nil {
+			if job.ConcurrencyGroup.Name == "" {
+				errorMessages = append(errorMessages, identifier+" has a concurrency_group with no name")
+			}
+
+			scope := job.ConcurrencyGroup.ScopeName()
+			if scope != atc.ConcurrencyGroupScopeTeam && scope != atc.ConcurrencyGroupScopePipeline {
+				errorMessages = append(
+					errorMessages,
+					identifier+fmt.Sprintf(" has unknown concurrency_group.scope: '%s'", scope),
+				)
+			}
+		}
+
+		if job.Schedule != nil {
+			_, err := job.Schedule.NextTrigger(time.Now())
+			if err != nil {
+				errorMessages = append(errorMessages, identifier+" has an invalid schedule: "+err.Error())
+			}
+		}
+
+		errorMessages = append(errorMessages, validateJobMatrix(identifier, job.Matrix)...)
+
 		step := job.Step()
 
 		validator := atc.NewStepValidator(c, []string{identifier, ".plan"})
@@ -417,6 +497,29 @@ func validateJobs(c atc.Config) ([]atc.ConfigWarning, error) {
 	return warnings, compositeErr(errorMessages)
 }
 
+func validateJobMatrix(identifier string, matrix []atc.MatrixVarConfig) []string {
+	var errorMessages []string
+
+	vars := map[string]bool{}
+	for i, dimension := range matrix {
+		dimensionIdentifier := fmt.Sprintf("%s.matrix[%d]", identifier, i)
+
+		if dimension.Var == "" {
+			errorMessages = append(errorMessages, dimensionIdentifier+" has no var")
+		} else if vars[dimension.Var] {
+			errorMessages = append(errorMessages, fmt.Sprintf("%s repeats var '%s'", dimensionIdentifier, dimension.Var))
+		}
+
+		vars[dimension.Var] = true
+
+		if len(dimension.Values) == 0 {
+			errorMessages = append(errorMessages, dimensionIdentifier+" has no values")
+		}
+	}
+
+	return errorMessages
+}
+
 func compositeErr(errorMessages []string) error {
 	if len(errorMessages) == 0 {
 		return nil
@@ -426,12 +529,36 @@ func compositeErr(errorMessages []string) error {
 }
 
 func validateVarSources(c atc.Config) ([]atc.ConfigWarning, error) {
+	warnings, errorMessages := validateVarSourceConfigs(c.VarSources)
+	return warnings, compositeErr(errorMessages)
+}
+
+// ValidateTeamVarSources validates the var sources of a team. Their config can
+// only use vars from the global credential manager, as they are set up before
+// any other var source.
+func ValidateTeamVarSources(varSources atc.VarSourceConfigs) ([]atc.ConfigWarning, []string) {
+	warnings, errorMessages := validateVarSourceConfigs(varSources)
+
+	for i, varSource := range varSources {
+		identifier := location{section: "var_sources", index: i}.Identifier(varSource.Name)
+
+		// on its own, a var source can only be ordered if it has no
+		// dependencies on other var sources
+		if _, err := (atc.VarSourceConfigs{varSource}).OrderByDependency(); err != nil {
+			errorMessages = append(errorMessages, fmt.Sprintf("%s: team var sources can only use vars from the global credential manager", identifier))
+		}
+	}
+
+	return warnings, errorMessages
+}
+
+func validateVarSourceConfigs(varSources atc.VarSourceConfigs) ([]atc.ConfigWarning, []string) {
 	var warnings []atc.ConfigWarning
 	var errorMessages []string
 
 	names := map[string]location{}
 
-	for i, varSource := range c.VarSources {
+	for i, varSource := range varSources {
 		location := location{section: "var_sources", index: i}
 		identifier := location.Identifier(varSource.Name)
 
@@ -473,13 +600,101 @@ func validateVarSources(c atc.Config) ([]atc.ConfigWarning, error) {
 		}
 	}
 
-	if _, err := c.VarSources.OrderByDependency(); err != nil {
+	if _, err := varSources.OrderByDependency(); err != nil {
 		errorMessages = append(errorMessages, fmt.Sprintf("failed to order by dependency: %s", err.Error()))
 	}
 
+	return warnings, errorMessages
+}
+
+func validateNotifications(c atc.Config) ([]atc.ConfigWarning, error) {
+	var warnings []atc.ConfigWarning
+	var errorMessages []string
+
+	names := map[string]location{}
+
+	for i, notification := range c.Notifications {
+		location := location{section: "notifications", index: i}
+		identifier := location.Identifier(notification.Name)
+
+		warning, err := atc.ValidateIdentifier(notification.Name, identifier)
+		if err != nil {
+			errorMessages = append(errorMessages, err.Error())
+		}
+		if warning != nil {
+			warnings = append(warnings, *warning)
+		}
+
+		if other, ok := names[notification.Name]; ok {
+			errorMessages = append(errorMessages,
+				fmt.Sprintf(
+					"%s and %s have the same name ('%s')",
+					other, location, notification.Name))
+		}
+		names[notification.Name] = location
+
+		if notification.Webhook.URL == "" {
+			errorMessages = append(errorMessages, identifier+" has no webhook url")
+		} else if !strings.Contains(notification.Webhook.URL, "((") {
+			// urls containing vars can only be checked once they are
+			// interpolated, at delivery time
+			u, err := url.Parse(notification.Webhook.URL)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+				errorMessages = append(errorMessages, fmt.Sprintf("%s has an invalid webhook url: %s", identifier, notification.Webhook.URL))
+			}
+		}
+
+		for _, jobGlob := range notification.Jobs {
+			g, err := glob.Compile(jobGlob)
+			if err != nil {
+				errorMessages = append(errorMessages, fmt.Sprintf("%s has invalid glob expression '%s'", identifier, jobGlob))
+				continue
+			}
+
+			matchingJob := false
+			for _, job := range c.Jobs {
+				if g.Match(job.Name) {
+					matchingJob = true
+					break
+				}
+			}
+
+			if !matchingJob {
+				errorMessages = append(errorMessages, fmt.Sprintf("%s has job glob '%s' which does not match any job", identifier, jobGlob))
+			}
+		}
+
+		for _, status := range notification.Statuses {
+			if !validNotificationStatus(status) {
+				errorMessages = append(errorMessages, fmt.Sprintf("%s has unknown status '%s'", identifier, status))
+			}
+		}
+
+		for j, transition := range notification.Transitions {
+			if transition.From == "" && transition.To == "" {
+				errorMessages = append(errorMessages, fmt.Sprintf("%s.transitions[%d] must specify from or to", identifier, j))
+			}
+
+			for _, status := range []atc.BuildStatus{transition.From, transition.To} {
+				if status != "" && !validNotificationStatus(status) {
+					errorMessages = append(errorMessages, fmt.Sprintf("%s.transitions[%d] has unknown status '%s'", identifier, j, status))
+				}
+			}
+		}
+	}
+
 	return warnings, compositeErr(errorMessages)
 }
 
+func validNotificationStatus(status atc.BuildStatus) bool {
+	switch status {
+	case atc.StatusSucceeded, atc.StatusFailed, atc.StatusErrored, atc.StatusAborted:
+		return true
+	default:
+		return false
+	}
+}
+
 func validateDisplay(c atc.Config) ([]atc.ConfigWarning, error) {
 	var warnings []atc.ConfigWarning
 
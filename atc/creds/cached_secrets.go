@@ -1,8 +1,12 @@
 package creds
 
 import (
+	"strings"
+	"sync"
 	"time"
 
+	"code.cloudfoundry.org/lager/v3"
+	"github.com/concourse/concourse/vars"
 	"github.com/patrickmn/go-cache"
 )
 
@@ -11,12 +15,18 @@ type SecretCacheConfig struct {
 	Duration         time.Duration `long:"secret-cache-duration" default:"1m" description:"If the cache is enabled, secret values will be cached for not longer than this duration (it can be less, if underlying secret lease time is smaller)"`
 	DurationNotFound time.Duration `long:"secret-cache-duration-notfound" default:"10s" description:"If the cache is enabled, secret not found responses will be cached for this duration"`
 	PurgeInterval    time.Duration `long:"secret-cache-purge-interval" default:"10m" description:"If the cache is enabled, expired items will be removed on this interval"`
+	MaxDuration      time.Duration `long:"secret-cache-max-duration" description:"If set, secrets which report when they expire, e.g. Vault leases, are cached until they expire for up to this duration, rather than for no longer than --secret-cache-duration"`
 }
 
 type CachedSecrets struct {
 	secrets     Secrets
 	cacheConfig SecretCacheConfig
 	cache       *cache.Cache
+
+	// teams records which teams looked up each cached secret path, so that
+	// a team's secrets can be invalidated
+	teamsLock sync.Mutex
+	teams     map[string]map[string]bool
 }
 
 type CacheEntry struct {
@@ -29,11 +39,20 @@ func NewCachedSecrets(secrets Secrets, cacheConfig SecretCacheConfig) *CachedSec
 	// Create a cache with:
 	// - default expiration time for entries set to 'cacheConfig.Duration'
 	// - purges expired items regularly, on every `cacheConfig.PurgeInterval` after creation
-	return &CachedSecrets{
+	cs := &CachedSecrets{
 		secrets:     secrets,
 		cacheConfig: cacheConfig,
 		cache:       cache.New(cacheConfig.Duration, cacheConfig.PurgeInterval),
+		teams:       map[string]map[string]bool{},
 	}
+
+	cs.cache.OnEvicted(func(secretPath string, _ any) {
+		cs.teamsLock.Lock()
+		delete(cs.teams, secretPath)
+		cs.teamsLock.Unlock()
+	})
+
+	return cs
 }
 
 func (cs *CachedSecrets) Get(secretPath string) (any, *time.Time, bool, error) {
@@ -45,6 +64,8 @@ func (cs *CachedSecrets) NewSecretLookupPaths(teamName string, pipelineName stri
 }
 
 func (cs *CachedSecrets) GetWithParams(secretPath string, context SecretLookupParams) (any, *time.Time, bool, error) {
+	cs.recordTeam(secretPath, context.Team)
+
 	// if there is a corresponding entry in the cache, return it
 	entry, found := cs.cache.Get(secretPath)
 	if found {
@@ -60,6 +81,11 @@ func (cs *CachedSecrets) GetWithParams(secretPath string, context SecretLookupPa
 		return nil, nil, false, err
 	}
 
+	// leased secrets belong to the build they were looked up for
+	if found && IsLeased(cs.secrets, secretPath) {
+		return value, expiration, found, nil
+	}
+
 	// here we want to cache secret value, expiration, and found flag too
 	// meaning that "secret not found" responses will be cached too!
 	entry = CacheEntry{value: value, expiration: expiration, found: found}
@@ -74,6 +100,11 @@ func (cs *CachedSecrets) GetWithParams(secretPath string, context SecretLookupPa
 			if itemDuration < duration && itemDuration > 0 {
 				duration = itemDuration
 			}
+
+			// secrets may be cached for longer when they say they can be
+			if itemDuration > duration && cs.cacheConfig.MaxDuration > 0 {
+				duration = min(itemDuration, cs.cacheConfig.MaxDuration)
+			}
 		}
 		cs.cache.Set(secretPath, entry, duration)
 	} else {
@@ -86,3 +117,83 @@ func (cs *CachedSecrets) GetWithParams(secretPath string, context SecretLookupPa
 func (cs *CachedSecrets) NewSecretLookupPathsWithParams(context SecretLookupParams, allowRootPath bool) []SecretLookupPath {
 	return NewSecretLookupPathsWithParams(cs.secrets, context, allowRootPath)
 }
+
+func (cs *CachedSecrets) IsLeased(secretPath string) bool {
+	return IsLeased(cs.secrets, secretPath)
+}
+
+func (cs *CachedSecrets) RevokeLeases(logger lager.Logger, runStateID string) error {
+	return RevokeLeases(logger, cs.secrets, runStateID)
+}
+
+func (cs *CachedSecrets) recordTeam(secretPath string, team string) {
+	if team == "" {
+		return
+	}
+
+	cs.teamsLock.Lock()
+	defer cs.teamsLock.Unlock()
+
+	if cs.teams[secretPath] == nil {
+		cs.teams[secretPath] = map[string]bool{}
+	}
+
+	cs.teams[secretPath][team] = true
+}
+
+// Invalidate removes the cached secrets which match the invalidation, so that
+// they are looked up again, and returns how many were removed.
+func (cs *CachedSecrets) Invalidate(invalidation SecretCacheInvalidation) int {
+	var matched []string
+	for secretPath := range cs.cache.Items() {
+		if cs.matches(invalidation, secretPath) {
+			matched = append(matched, secretPath)
+		}
+	}
+
+	for _, secretPath := range matched {
+		cs.cache.Delete(secretPath)
+	}
+
+	return len(matched)
+}
+
+func (cs *CachedSecrets) matches(invalidation SecretCacheInvalidation, secretPath string) bool {
+	if invalidation.Team != "" {
+		cs.teamsLock.Lock()
+		lookedUp := cs.teams[secretPath][invalidation.Team]
+		cs.teamsLock.Unlock()
+
+		if !lookedUp {
+			return false
+		}
+	}
+
+	if invalidation.Var != "" {
+		return secretPathMatchesVar(secretPath, invalidation.Var)
+	}
+
+	return true
+}
+
+// secretPathMatchesVar reports whether the secret path may have been looked
+// up for the var. Managers map vars to paths in their own way, e.g.
+// /concourse/team/var or namespace/pipeline.var, but the var always comes
+// last, so the match errs on the side of invalidating too much.
+func secretPathMatchesVar(secretPath string, varName string) bool {
+	if ref, err := vars.ParseReference(varName); err == nil {
+		varName = ref.Path
+	}
+
+	if secretPath == varName {
+		return true
+	}
+
+	for _, separator := range []string{"/", ".", ":"} {
+		if strings.HasSuffix(secretPath, separator+varName) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"time"
 
+	"code.cloudfoundry.org/lager/v3/lagertest"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/creds/credsfakes"
 
@@ -191,4 +192,98 @@ var _ = Describe("Caching of secrets", func() {
 		Expect(underlyingMisses).To(BeIdenticalTo(4))
 	})
 
+	Context("when the underlying secrets are leased", func() {
+		var leasedSecrets *credsfakes.FakeLeasedSecrets
+
+		BeforeEach(func() {
+			leasedSecrets = new(credsfakes.FakeLeasedSecrets)
+			leasedSecrets.GetStub = makeGetStub("foo", "value", nil, true, nil, &underlyingReads, &underlyingMisses)
+			leasedSecrets.IsLeasedStub = func(secretPath string) bool {
+				return secretPath == "foo"
+			}
+
+			cachedSecretManager = creds.NewCachedSecrets(leasedSecrets, cacheConfig)
+		})
+
+		It("should not cache leased secrets", func() {
+			_, _, _, _ = cachedSecretManager.Get("foo")
+			_, _, _, _ = cachedSecretManager.Get("foo")
+			Expect(underlyingReads).To(BeIdenticalTo(2))
+		})
+
+		It("should revoke leases through the underlying secrets", func() {
+			err := cachedSecretManager.RevokeLeases(lagertest.NewTestLogger("test"), "build:1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(leasedSecrets.RevokeLeasesCallCount()).To(Equal(1))
+		})
+	})
+
+	It("should cache secrets until they expire, up to the max duration", func() {
+		cacheConfig.MaxDuration = 600 * time.Millisecond
+		cachedSecretManager = creds.NewCachedSecrets(secretManager, cacheConfig)
+
+		expiration := time.Now().Add(time.Hour)
+		secretManager.GetStub = makeGetStub("foo", "value", &expiration, true, nil, &underlyingReads, &underlyingMisses)
+
+		_, _, _, _ = cachedSecretManager.Get("foo")
+		Expect(underlyingReads).To(BeIdenticalTo(1))
+
+		// still cached past the default duration
+		time.Sleep(cacheConfig.Duration + time.Millisecond)
+		_, _, _, _ = cachedSecretManager.Get("foo")
+		Expect(underlyingReads).To(BeIdenticalTo(1))
+
+		// but not past the max duration
+		time.Sleep(cacheConfig.MaxDuration - cacheConfig.Duration)
+		_, _, _, _ = cachedSecretManager.Get("foo")
+		Expect(underlyingReads).To(BeIdenticalTo(2))
+	})
+
+	Describe("Invalidate", func() {
+		BeforeEach(func() {
+			secretManager.GetStub = func(secretPath string) (any, *time.Time, bool, error) {
+				underlyingReads++
+				return "value", nil, true, nil
+			}
+
+			for _, lookup := range []struct{ team, path string }{
+				{"main", "/concourse/main/pipeline/github"},
+				{"main", "/concourse/main/github-token"},
+				{"other", "/concourse/other/github"},
+				{"other", "/concourse/shared/github"},
+				{"main", "/concourse/shared/github"},
+			} {
+				_, _, _, err := cachedSecretManager.GetWithParams(lookup.path, creds.SecretLookupParams{Team: lookup.team})
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			underlyingReads = 0
+		})
+
+		It("should invalidate every secret", func() {
+			Expect(creds.InvalidateSecretCache(cachedSecretManager, creds.SecretCacheInvalidation{})).To(Equal(4))
+
+			_, _, _, _ = cachedSecretManager.Get("/concourse/main/github-token")
+			Expect(underlyingReads).To(BeIdenticalTo(1))
+		})
+
+		It("should invalidate the secrets looked up for a team", func() {
+			Expect(cachedSecretManager.Invalidate(creds.SecretCacheInvalidation{Team: "other"})).To(Equal(2))
+
+			_, _, _, _ = cachedSecretManager.Get("/concourse/shared/github")
+			_, _, _, _ = cachedSecretManager.Get("/concourse/main/pipeline/github")
+			Expect(underlyingReads).To(BeIdenticalTo(1))
+		})
+
+		It("should invalidate the secrets looked up for a var", func() {
+			Expect(cachedSecretManager.Invalidate(creds.SecretCacheInvalidation{Var: "github.token"})).To(Equal(3))
+
+			_, _, _, _ = cachedSecretManager.Get("/concourse/main/github-token")
+			Expect(underlyingReads).To(BeIdenticalTo(0))
+		})
+
+		It("should invalidate the secrets looked up for a team's var", func() {
+			Expect(cachedSecretManager.Invalidate(creds.SecretCacheInvalidation{Team: "main", Var: "github"})).To(Equal(2))
+		})
+	})
 })
@@ -0,0 +1,175 @@
+package creds
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager/v3"
+)
+
+// NamedSecretsFactory is the secrets factory of a configured credential
+// manager, along with the name the manager was registered under.
+type NamedSecretsFactory struct {
+	Name    string
+	Factory SecretsFactory
+}
+
+type chainedSecretsFactory struct {
+	factories []NamedSecretsFactory
+	served    func(manager string)
+}
+
+// NewChainedSecretsFactory returns a SecretsFactory whose secrets are looked
+// up from each of the given factories in turn. served, if given, is called
+// with the name of the manager which a secret was found in.
+func NewChainedSecretsFactory(factories []NamedSecretsFactory, served func(manager string)) SecretsFactory {
+	return chainedSecretsFactory{
+		factories: factories,
+		served:    served,
+	}
+}
+
+func (factory chainedSecretsFactory) NewSecrets() Secrets {
+	links := make([]chainLink, len(factory.factories))
+	for i, f := range factory.factories {
+		links[i] = chainLink{
+			name:    f.Name,
+			secrets: f.Factory.NewSecrets(),
+		}
+	}
+
+	return &ChainedSecrets{
+		links:  links,
+		served: factory.served,
+	}
+}
+
+type chainLink struct {
+	name    string
+	secrets Secrets
+}
+
+// ChainedSecrets resolves vars from several credential managers. Each manager
+// keeps its own lookup paths, and the lookup paths of earlier managers are
+// tried first, so a var is resolved from the first manager which has it.
+type ChainedSecrets struct {
+	links  []chainLink
+	served func(manager string)
+}
+
+func (cs *ChainedSecrets) Get(secretPath string) (any, *time.Time, bool, error) {
+	return cs.GetWithParams(secretPath, SecretLookupParams{})
+}
+
+func (cs *ChainedSecrets) NewSecretLookupPaths(teamName string, pipelineName string, allowRootPath bool) []SecretLookupPath {
+	return cs.NewSecretLookupPathsWithParams(SecretLookupParams{Team: teamName, Pipeline: pipelineName}, allowRootPath)
+}
+
+// GetWithParams looks the secret up in the manager named by the path's
+// prefix. Paths without a known prefix are looked up in every manager.
+func (cs *ChainedSecrets) GetWithParams(secretPath string, params SecretLookupParams) (any, *time.Time, bool, error) {
+	name, path, found := strings.Cut(secretPath, ":")
+	if found {
+		for _, link := range cs.links {
+			if link.name == name {
+				return cs.get(link, path, params)
+			}
+		}
+	}
+
+	for _, link := range cs.links {
+		result, expiration, found, err := cs.get(link, secretPath, params)
+		if err != nil || found {
+			return result, expiration, found, err
+		}
+	}
+
+	return nil, nil, false, nil
+}
+
+func (cs *ChainedSecrets) get(link chainLink, path string, params SecretLookupParams) (any, *time.Time, bool, error) {
+	result, expiration, found, err := GetWithParams(link.secrets, path, params)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	if found && cs.served != nil {
+		cs.served(link.name)
+	}
+
+	return result, expiration, found, nil
+}
+
+// IsLeased asks the manager named by the path's prefix whether the secret is
+// leased.
+func (cs *ChainedSecrets) IsLeased(secretPath string) bool {
+	name, path, found := strings.Cut(secretPath, ":")
+	if found {
+		for _, link := range cs.links {
+			if link.name == name {
+				return IsLeased(link.secrets, path)
+			}
+		}
+	}
+
+	for _, link := range cs.links {
+		if IsLeased(link.secrets, secretPath) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RevokeLeases revokes the leases obtained from every manager, carrying on
+// past managers which fail so that as many leases as possible are revoked.
+func (cs *ChainedSecrets) RevokeLeases(logger lager.Logger, runStateID string) error {
+	var errs []error
+	for _, link := range cs.links {
+		err := RevokeLeases(logger.Session(link.name), link.secrets, runStateID)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// NewSecretLookupPathsWithParams returns the lookup paths of every manager in
+// order, prefixed with the manager's name so that Get knows where to look.
+func (cs *ChainedSecrets) NewSecretLookupPathsWithParams(params SecretLookupParams, allowRootPath bool) []SecretLookupPath {
+	var lookupPaths []SecretLookupPath
+	for _, link := range cs.links {
+		paths := NewSecretLookupPathsWithParams(link.secrets, params, allowRootPath)
+		if len(paths) == 0 {
+			// managers without lookup paths map vars to secrets 1-to-1
+			lookupPaths = append(lookupPaths, chainedLookupPath{manager: link.name})
+			continue
+		}
+
+		for _, path := range paths {
+			lookupPaths = append(lookupPaths, chainedLookupPath{manager: link.name, inner: path})
+		}
+	}
+
+	return lookupPaths
+}
+
+type chainedLookupPath struct {
+	manager string
+	inner   SecretLookupPath
+}
+
+func (lookup chainedLookupPath) VariableToSecretPath(varName string) (string, error) {
+	secretPath := varName
+	if lookup.inner != nil {
+		var err error
+		secretPath, err = lookup.inner.VariableToSecretPath(varName)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return lookup.manager + ":" + secretPath, nil
+}
@@ -0,0 +1,162 @@
+package creds_test
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/lager/v3/lagertest"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/credsfakes"
+	"github.com/concourse/concourse/vars"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ChainedSecrets", func() {
+	var (
+		firstSecrets  *credsfakes.FakeSecrets
+		secondSecrets *credsfakes.FakeSecrets
+		served        []string
+
+		variables vars.Variables
+	)
+
+	secretsFactory := func(secrets creds.Secrets) creds.SecretsFactory {
+		factory := new(credsfakes.FakeSecretsFactory)
+		factory.NewSecretsReturns(secrets)
+		return factory
+	}
+
+	storeStub := func(store map[string]any) func(string) (any, *time.Time, bool, error) {
+		return func(path string) (any, *time.Time, bool, error) {
+			value, found := store[path]
+			return value, nil, found, nil
+		}
+	}
+
+	BeforeEach(func() {
+		firstSecrets = new(credsfakes.FakeSecrets)
+		firstSecrets.NewSecretLookupPathsReturns([]creds.SecretLookupPath{
+			creds.NewSecretLookupWithPrefix("/first/"),
+		})
+		firstSecrets.GetStub = storeStub(map[string]any{
+			"/first/both":  "from-first",
+			"/first/first": "only-first",
+		})
+
+		// no lookup paths, like the dummy manager
+		secondSecrets = new(credsfakes.FakeSecrets)
+		secondSecrets.GetStub = storeStub(map[string]any{
+			"both":   "from-second",
+			"second": "only-second",
+		})
+
+		served = nil
+	})
+
+	JustBeforeEach(func() {
+		secrets := creds.NewChainedSecretsFactory([]creds.NamedSecretsFactory{
+			{Name: "first", Factory: secretsFactory(firstSecrets)},
+			{Name: "second", Factory: secretsFactory(secondSecrets)},
+		}, func(manager string) {
+			served = append(served, manager)
+		}).NewSecrets()
+
+		variables = creds.NewVariables(secrets, creds.SecretLookupParams{Team: "team", Pipeline: "pipeline"}, false)
+	})
+
+	It("implements the SecretsWithParams interface", func() {
+		var _ creds.SecretsWithParams = &creds.ChainedSecrets{}
+	})
+
+	It("resolves a var from the first manager which has it", func() {
+		value, found, err := variables.Get(vars.Reference{Path: "both"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(value).To(Equal("from-first"))
+
+		Expect(served).To(Equal([]string{"first"}))
+		Expect(secondSecrets.GetCallCount()).To(BeZero())
+	})
+
+	It("falls back to later managers using their own lookup paths", func() {
+		value, found, err := variables.Get(vars.Reference{Path: "second"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(value).To(Equal("only-second"))
+
+		Expect(firstSecrets.GetArgsForCall(0)).To(Equal("/first/second"))
+		Expect(secondSecrets.GetArgsForCall(0)).To(Equal("second"))
+		Expect(served).To(Equal([]string{"second"}))
+	})
+
+	It("does not report a manager when no manager has the var", func() {
+		_, found, err := variables.Get(vars.Reference{Path: "missing"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeFalse())
+		Expect(served).To(BeEmpty())
+	})
+
+	It("reports the manager and secret path which resolved the var", func() {
+		_, resolution, found, err := vars.Resolve(variables, vars.Reference{Path: "both"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(resolution).To(Equal(vars.Resolution{Manager: "first", SecretPath: "/first/both"}))
+
+		_, resolution, found, err = vars.Resolve(variables, vars.Reference{Path: "second"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(resolution).To(Equal(vars.Resolution{Manager: "second", SecretPath: "second"}))
+	})
+
+	Context("when a manager fails", func() {
+		BeforeEach(func() {
+			firstSecrets.GetReturns(nil, nil, false, errors.New("nope"))
+		})
+
+		It("does not fall back to later managers", func() {
+			_, _, err := variables.Get(vars.Reference{Path: "second"})
+			Expect(err).To(MatchError("nope"))
+			Expect(secondSecrets.GetCallCount()).To(BeZero())
+		})
+	})
+	Describe("leases", func() {
+		var leasedSecrets *credsfakes.FakeLeasedSecrets
+
+		BeforeEach(func() {
+			leasedSecrets = new(credsfakes.FakeLeasedSecrets)
+			leasedSecrets.IsLeasedStub = func(secretPath string) bool {
+				return secretPath == "db"
+			}
+		})
+
+		It("asks the manager named by the path whether a secret is leased", func() {
+			secrets := creds.NewChainedSecretsFactory([]creds.NamedSecretsFactory{
+				{Name: "first", Factory: secretsFactory(firstSecrets)},
+				{Name: "leased", Factory: secretsFactory(leasedSecrets)},
+			}, nil).NewSecrets()
+
+			Expect(creds.IsLeased(secrets, "leased:db")).To(BeTrue())
+			Expect(creds.IsLeased(secrets, "first:db")).To(BeFalse())
+		})
+
+		It("revokes leases from every manager, even when one fails", func() {
+			otherLeasedSecrets := new(credsfakes.FakeLeasedSecrets)
+			leasedSecrets.RevokeLeasesReturns(errors.New("nope"))
+
+			secrets := creds.NewChainedSecretsFactory([]creds.NamedSecretsFactory{
+				{Name: "leased", Factory: secretsFactory(leasedSecrets)},
+				{Name: "first", Factory: secretsFactory(firstSecrets)},
+				{Name: "other", Factory: secretsFactory(otherLeasedSecrets)},
+			}, nil).NewSecrets()
+
+			err := creds.RevokeLeases(lagertest.NewTestLogger("test"), secrets, "build:1")
+			Expect(err).To(MatchError("nope"))
+
+			Expect(otherLeasedSecrets.RevokeLeasesCallCount()).To(Equal(1))
+			_, runStateID := otherLeasedSecrets.RevokeLeasesArgsForCall(0)
+			Expect(runStateID).To(Equal("build:1"))
+		})
+	})
+})
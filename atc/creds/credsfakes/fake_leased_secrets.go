@@ -0,0 +1,348 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package credsfakes
+
+import (
+	"sync"
+	"time"
+
+	lager "code.cloudfoundry.org/lager/v3"
+	"github.com/concourse/concourse/atc/creds"
+)
+
+type FakeLeasedSecrets struct {
+	GetStub        func(string) (any, *time.Time, bool, error)
+	getMutex       sync.RWMutex
+	getArgsForCall []struct {
+		arg1 string
+	}
+	getReturns struct {
+		result1 any
+		result2 *time.Time
+		result3 bool
+		result4 error
+	}
+	getReturnsOnCall map[int]struct {
+		result1 any
+		result2 *time.Time
+		result3 bool
+		result4 error
+	}
+	IsLeasedStub        func(string) bool
+	isLeasedMutex       sync.RWMutex
+	isLeasedArgsForCall []struct {
+		arg1 string
+	}
+	isLeasedReturns struct {
+		result1 bool
+	}
+	isLeasedReturnsOnCall map[int]struct {
+		result1 bool
+	}
+	NewSecretLookupPathsStub        func(string, string, bool) []creds.SecretLookupPath
+	newSecretLookupPathsMutex       sync.RWMutex
+	newSecretLookupPathsArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 bool
+	}
+	newSecretLookupPathsReturns struct {
+		result1 []creds.SecretLookupPath
+	}
+	newSecretLookupPathsReturnsOnCall map[int]struct {
+		result1 []creds.SecretLookupPath
+	}
+	RevokeLeasesStub        func(lager.Logger, string) error
+	revokeLeasesMutex       sync.RWMutex
+	revokeLeasesArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+	}
+	revokeLeasesReturns struct {
+		result1 error
+	}
+	revokeLeasesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeLeasedSecrets) Get(arg1 string) (any, *time.Time, bool, error) {
+	fake.getMutex.Lock()
+	ret, specificReturn := fake.getReturnsOnCall[len(fake.getArgsForCall)]
+	fake.getArgsForCall = append(fake.getArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.GetStub
+	fakeReturns := fake.getReturns
+	fake.recordInvocation("Get", []interface{}{arg1})
+	fake.getMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3, ret.result4
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3, fakeReturns.result4
+}
+
+func (fake *FakeLeasedSecrets) GetCallCount() int {
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	return len(fake.getArgsForCall)
+}
+
+func (fake *FakeLeasedSecrets) GetCalls(stub func(string) (any, *time.Time, bool, error)) {
+	fake.getMutex.Lock()
+	defer fake.getMutex.Unlock()
+	fake.GetStub = stub
+}
+
+func (fake *FakeLeasedSecrets) GetArgsForCall(i int) string {
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	argsForCall := fake.getArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeLeasedSecrets) GetReturns(result1 any, result2 *time.Time, result3 bool, result4 error) {
+	fake.getMutex.Lock()
+	defer fake.getMutex.Unlock()
+	fake.GetStub = nil
+	fake.getReturns = struct {
+		result1 any
+		result2 *time.Time
+		result3 bool
+		result4 error
+	}{result1, result2, result3, result4}
+}
+
+func (fake *FakeLeasedSecrets) GetReturnsOnCall(i int, result1 any, result2 *time.Time, result3 bool, result4 error) {
+	fake.getMutex.Lock()
+	defer fake.getMutex.Unlock()
+	fake.GetStub = nil
+	if fake.getReturnsOnCall == nil {
+		fake.getReturnsOnCall = make(map[int]struct {
+			result1 any
+			result2 *time.Time
+			result3 bool
+			result4 error
+		})
+	}
+	fake.getReturnsOnCall[i] = struct {
+		result1 any
+		result2 *time.Time
+		result3 bool
+		result4 error
+	}{result1, result2, result3, result4}
+}
+
+func (fake *FakeLeasedSecrets) IsLeased(arg1 string) bool {
+	fake.isLeasedMutex.Lock()
+	ret, specificReturn := fake.isLeasedReturnsOnCall[len(fake.isLeasedArgsForCall)]
+	fake.isLeasedArgsForCall = append(fake.isLeasedArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.IsLeasedStub
+	fakeReturns := fake.isLeasedReturns
+	fake.recordInvocation("IsLeased", []interface{}{arg1})
+	fake.isLeasedMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeLeasedSecrets) IsLeasedCallCount() int {
+	fake.isLeasedMutex.RLock()
+	defer fake.isLeasedMutex.RUnlock()
+	return len(fake.isLeasedArgsForCall)
+}
+
+func (fake *FakeLeasedSecrets) IsLeasedCalls(stub func(string) bool) {
+	fake.isLeasedMutex.Lock()
+	defer fake.isLeasedMutex.Unlock()
+	fake.IsLeasedStub = stub
+}
+
+func (fake *FakeLeasedSecrets) IsLeasedArgsForCall(i int) string {
+	fake.isLeasedMutex.RLock()
+	defer fake.isLeasedMutex.RUnlock()
+	argsForCall := fake.isLeasedArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeLeasedSecrets) IsLeasedReturns(result1 bool) {
+	fake.isLeasedMutex.Lock()
+	defer fake.isLeasedMutex.Unlock()
+	fake.IsLeasedStub = nil
+	fake.isLeasedReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeLeasedSecrets) IsLeasedReturnsOnCall(i int, result1 bool) {
+	fake.isLeasedMutex.Lock()
+	defer fake.isLeasedMutex.Unlock()
+	fake.IsLeasedStub = nil
+	if fake.isLeasedReturnsOnCall == nil {
+		fake.isLeasedReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.isLeasedReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeLeasedSecrets) NewSecretLookupPaths(arg1 string, arg2 string, arg3 bool) []creds.SecretLookupPath {
+	fake.newSecretLookupPathsMutex.Lock()
+	ret, specificReturn := fake.newSecretLookupPathsReturnsOnCall[len(fake.newSecretLookupPathsArgsForCall)]
+	fake.newSecretLookupPathsArgsForCall = append(fake.newSecretLookupPathsArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 bool
+	}{arg1, arg2, arg3})
+	stub := fake.NewSecretLookupPathsStub
+	fakeReturns := fake.newSecretLookupPathsReturns
+	fake.recordInvocation("NewSecretLookupPaths", []interface{}{arg1, arg2, arg3})
+	fake.newSecretLookupPathsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeLeasedSecrets) NewSecretLookupPathsCallCount() int {
+	fake.newSecretLookupPathsMutex.RLock()
+	defer fake.newSecretLookupPathsMutex.RUnlock()
+	return len(fake.newSecretLookupPathsArgsForCall)
+}
+
+func (fake *FakeLeasedSecrets) NewSecretLookupPathsCalls(stub func(string, string, bool) []creds.SecretLookupPath) {
+	fake.newSecretLookupPathsMutex.Lock()
+	defer fake.newSecretLookupPathsMutex.Unlock()
+	fake.NewSecretLookupPathsStub = stub
+}
+
+func (fake *FakeLeasedSecrets) NewSecretLookupPathsArgsForCall(i int) (string, string, bool) {
+	fake.newSecretLookupPathsMutex.RLock()
+	defer fake.newSecretLookupPathsMutex.RUnlock()
+	argsForCall := fake.newSecretLookupPathsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeLeasedSecrets) NewSecretLookupPathsReturns(result1 []creds.SecretLookupPath) {
+	fake.newSecretLookupPathsMutex.Lock()
+	defer fake.newSecretLookupPathsMutex.Unlock()
+	fake.NewSecretLookupPathsStub = nil
+	fake.newSecretLookupPathsReturns = struct {
+		result1 []creds.SecretLookupPath
+	}{result1}
+}
+
+func (fake *FakeLeasedSecrets) NewSecretLookupPathsReturnsOnCall(i int, result1 []creds.SecretLookupPath) {
+	fake.newSecretLookupPathsMutex.Lock()
+	defer fake.newSecretLookupPathsMutex.Unlock()
+	fake.NewSecretLookupPathsStub = nil
+	if fake.newSecretLookupPathsReturnsOnCall == nil {
+		fake.newSecretLookupPathsReturnsOnCall = make(map[int]struct {
+			result1 []creds.SecretLookupPath
+		})
+	}
+	fake.newSecretLookupPathsReturnsOnCall[i] = struct {
+		result1 []creds.SecretLookupPath
+	}{result1}
+}
+
+func (fake *FakeLeasedSecrets) RevokeLeases(arg1 lager.Logger, arg2 string) error {
+	fake.revokeLeasesMutex.Lock()
+	ret, specificReturn := fake.revokeLeasesReturnsOnCall[len(fake.revokeLeasesArgsForCall)]
+	fake.revokeLeasesArgsForCall = append(fake.revokeLeasesArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.RevokeLeasesStub
+	fakeReturns := fake.revokeLeasesReturns
+	fake.recordInvocation("RevokeLeases", []interface{}{arg1, arg2})
+	fake.revokeLeasesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeLeasedSecrets) RevokeLeasesCallCount() int {
+	fake.revokeLeasesMutex.RLock()
+	defer fake.revokeLeasesMutex.RUnlock()
+	return len(fake.revokeLeasesArgsForCall)
+}
+
+func (fake *FakeLeasedSecrets) RevokeLeasesCalls(stub func(lager.Logger, string) error) {
+	fake.revokeLeasesMutex.Lock()
+	defer fake.revokeLeasesMutex.Unlock()
+	fake.RevokeLeasesStub = stub
+}
+
+func (fake *FakeLeasedSecrets) RevokeLeasesArgsForCall(i int) (lager.Logger, string) {
+	fake.revokeLeasesMutex.RLock()
+	defer fake.revokeLeasesMutex.RUnlock()
+	argsForCall := fake.revokeLeasesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeLeasedSecrets) RevokeLeasesReturns(result1 error) {
+	fake.revokeLeasesMutex.Lock()
+	defer fake.revokeLeasesMutex.Unlock()
+	fake.RevokeLeasesStub = nil
+	fake.revokeLeasesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLeasedSecrets) RevokeLeasesReturnsOnCall(i int, result1 error) {
+	fake.revokeLeasesMutex.Lock()
+	defer fake.revokeLeasesMutex.Unlock()
+	fake.RevokeLeasesStub = nil
+	if fake.revokeLeasesReturnsOnCall == nil {
+		fake.revokeLeasesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.revokeLeasesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLeasedSecrets) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeLeasedSecrets) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ creds.LeasedSecrets = new(FakeLeasedSecrets)
@@ -28,6 +28,29 @@ type FakeVarSourcePool struct {
 		result1 creds.Secrets
 		result2 error
 	}
+	InvalidateCacheStub        func(creds.SecretCacheInvalidation) int
+	invalidateCacheMutex       sync.RWMutex
+	invalidateCacheArgsForCall []struct {
+		arg1 creds.SecretCacheInvalidation
+	}
+	invalidateCacheReturns struct {
+		result1 int
+	}
+	invalidateCacheReturnsOnCall map[int]struct {
+		result1 int
+	}
+	RevokeLeasesStub        func(lager.Logger, string) error
+	revokeLeasesMutex       sync.RWMutex
+	revokeLeasesArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+	}
+	revokeLeasesReturns struct {
+		result1 error
+	}
+	revokeLeasesReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SizeStub        func() int
 	sizeMutex       sync.RWMutex
 	sizeArgsForCall []struct {
@@ -132,6 +155,129 @@ func (fake *FakeVarSourcePool) FindOrCreateReturnsOnCall(i int, result1 creds.Se
 	}{result1, result2}
 }
 
+func (fake *FakeVarSourcePool) InvalidateCache(arg1 creds.SecretCacheInvalidation) int {
+	fake.invalidateCacheMutex.Lock()
+	ret, specificReturn := fake.invalidateCacheReturnsOnCall[len(fake.invalidateCacheArgsForCall)]
+	fake.invalidateCacheArgsForCall = append(fake.invalidateCacheArgsForCall, struct {
+		arg1 creds.SecretCacheInvalidation
+	}{arg1})
+	stub := fake.InvalidateCacheStub
+	fakeReturns := fake.invalidateCacheReturns
+	fake.recordInvocation("InvalidateCache", []interface{}{arg1})
+	fake.invalidateCacheMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeVarSourcePool) InvalidateCacheCallCount() int {
+	fake.invalidateCacheMutex.RLock()
+	defer fake.invalidateCacheMutex.RUnlock()
+	return len(fake.invalidateCacheArgsForCall)
+}
+
+func (fake *FakeVarSourcePool) InvalidateCacheCalls(stub func(creds.SecretCacheInvalidation) int) {
+	fake.invalidateCacheMutex.Lock()
+	defer fake.invalidateCacheMutex.Unlock()
+	fake.InvalidateCacheStub = stub
+}
+
+func (fake *FakeVarSourcePool) InvalidateCacheArgsForCall(i int) creds.SecretCacheInvalidation {
+	fake.invalidateCacheMutex.RLock()
+	defer fake.invalidateCacheMutex.RUnlock()
+	argsForCall := fake.invalidateCacheArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeVarSourcePool) InvalidateCacheReturns(result1 int) {
+	fake.invalidateCacheMutex.Lock()
+	defer fake.invalidateCacheMutex.Unlock()
+	fake.InvalidateCacheStub = nil
+	fake.invalidateCacheReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeVarSourcePool) InvalidateCacheReturnsOnCall(i int, result1 int) {
+	fake.invalidateCacheMutex.Lock()
+	defer fake.invalidateCacheMutex.Unlock()
+	fake.InvalidateCacheStub = nil
+	if fake.invalidateCacheReturnsOnCall == nil {
+		fake.invalidateCacheReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.invalidateCacheReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeVarSourcePool) RevokeLeases(arg1 lager.Logger, arg2 string) error {
+	fake.revokeLeasesMutex.Lock()
+	ret, specificReturn := fake.revokeLeasesReturnsOnCall[len(fake.revokeLeasesArgsForCall)]
+	fake.revokeLeasesArgsForCall = append(fake.revokeLeasesArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.RevokeLeasesStub
+	fakeReturns := fake.revokeLeasesReturns
+	fake.recordInvocation("RevokeLeases", []interface{}{arg1, arg2})
+	fake.revokeLeasesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeVarSourcePool) RevokeLeasesCallCount() int {
+	fake.revokeLeasesMutex.RLock()
+	defer fake.revokeLeasesMutex.RUnlock()
+	return len(fake.revokeLeasesArgsForCall)
+}
+
+func (fake *FakeVarSourcePool) RevokeLeasesCalls(stub func(lager.Logger, string) error) {
+	fake.revokeLeasesMutex.Lock()
+	defer fake.revokeLeasesMutex.Unlock()
+	fake.RevokeLeasesStub = stub
+}
+
+func (fake *FakeVarSourcePool) RevokeLeasesArgsForCall(i int) (lager.Logger, string) {
+	fake.revokeLeasesMutex.RLock()
+	defer fake.revokeLeasesMutex.RUnlock()
+	argsForCall := fake.revokeLeasesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeVarSourcePool) RevokeLeasesReturns(result1 error) {
+	fake.revokeLeasesMutex.Lock()
+	defer fake.revokeLeasesMutex.Unlock()
+	fake.RevokeLeasesStub = nil
+	fake.revokeLeasesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeVarSourcePool) RevokeLeasesReturnsOnCall(i int, result1 error) {
+	fake.revokeLeasesMutex.Lock()
+	defer fake.revokeLeasesMutex.Unlock()
+	fake.RevokeLeasesStub = nil
+	if fake.revokeLeasesReturnsOnCall == nil {
+		fake.revokeLeasesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.revokeLeasesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeVarSourcePool) Size() int {
 	fake.sizeMutex.Lock()
 	ret, specificReturn := fake.sizeReturnsOnCall[len(fake.sizeArgsForCall)]
@@ -0,0 +1,101 @@
+package file
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"code.cloudfoundry.org/lager/v3"
+
+	"github.com/concourse/concourse/atc/creds"
+)
+
+type Manager struct {
+	Dir            string        `long:"dir" description:"Directory of YAML or JSON files to look up credentials in. TEAM.yml holds a team's vars and TEAM/PIPELINE.yml a pipeline's."`
+	EnvPrefix      string        `long:"env-prefix" description:"Expose environment variables with this prefix as vars shared by every team, e.g. with a prefix of CONCOURSE_SECRET_, CONCOURSE_SECRET_GITHUB_TOKEN is ((github_token))."`
+	ReloadInterval time.Duration `long:"reload-interval" default:"10s" description:"Interval on which to check the directory for changed files and reload them."`
+
+	store *Store
+}
+
+func (manager *Manager) Init(log lager.Logger) error {
+	return nil
+}
+
+func (manager *Manager) MarshalJSON() ([]byte, error) {
+	health, err := manager.Health()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(&map[string]any{
+		"dir":             manager.Dir,
+		"env_prefix":      manager.EnvPrefix,
+		"reload_interval": manager.ReloadInterval.String(),
+		"health":          health,
+	})
+}
+
+func (manager *Manager) IsConfigured() bool {
+	return manager.Dir != "" || manager.EnvPrefix != ""
+}
+
+func (manager *Manager) Validate() error {
+	if manager.Dir != "" {
+		info, err := os.Stat(manager.Dir)
+		if err != nil {
+			return err
+		}
+
+		if !info.IsDir() {
+			return fmt.Errorf("%s is not a directory", manager.Dir)
+		}
+	}
+
+	if manager.ReloadInterval <= 0 {
+		return errors.New("reload interval must be positive")
+	}
+
+	return nil
+}
+
+func (manager *Manager) Health() (*creds.HealthResponse, error) {
+	health := &creds.HealthResponse{
+		Method: "file",
+	}
+
+	if manager.store == nil {
+		return health, nil
+	}
+
+	status := manager.store.Status()
+	health.Response = status
+	health.Error = status.LastError
+
+	return health, nil
+}
+
+func (manager *Manager) Close(logger lager.Logger) {
+	if manager.store != nil {
+		manager.store.Close()
+	}
+}
+
+func (manager *Manager) NewSecretsFactory(logger lager.Logger) (creds.SecretsFactory, error) {
+	if manager.store == nil {
+		store, err := NewStore(logger.Session("store"), manager.Dir, manager.EnvPrefix, os.Environ())
+		if err != nil {
+			return nil, err
+		}
+
+		if manager.Dir != "" {
+			go store.ReloadLoop(manager.ReloadInterval)
+		}
+
+		manager.store = store
+	}
+
+	return NewSecretsFactory(manager.store), nil
+}
@@ -0,0 +1,37 @@
+package file
+
+import (
+	"errors"
+
+	"github.com/concourse/concourse/atc/creds"
+	flags "github.com/jessevdk/go-flags"
+)
+
+type managerFactory struct{}
+
+func init() {
+	creds.Register("file", NewManagerFactory())
+}
+
+func NewManagerFactory() creds.ManagerFactory {
+	return &managerFactory{}
+}
+
+func (factory *managerFactory) AddConfig(group *flags.Group) creds.Manager {
+	manager := &Manager{}
+
+	subGroup, err := group.AddGroup("File Credential Management", "", manager)
+	if err != nil {
+		panic(err)
+	}
+
+	subGroup.Namespace = "file-creds"
+
+	return manager
+}
+
+// NewInstance refuses to create a var_source, as it would let pipelines read
+// the web node's files and environment.
+func (factory *managerFactory) NewInstance(config any) (creds.Manager, error) {
+	return nil, errors.New("the file credential manager cannot be used as a var_source")
+}
@@ -0,0 +1,127 @@
+package file_test
+
+import (
+	"os"
+	"path/filepath"
+
+	"code.cloudfoundry.org/lager/v3/lagertest"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/file"
+	"github.com/jessevdk/go-flags"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Manager", func() {
+	var (
+		factory creds.ManagerFactory
+		manager creds.Manager
+		dir     string
+		args    []string
+	)
+
+	BeforeEach(func() {
+		factory = file.NewManagerFactory()
+		dir = GinkgoT().TempDir()
+		args = []string{"--file-creds-dir", dir}
+	})
+
+	JustBeforeEach(func() {
+		parser := flags.NewParser(nil, flags.Default)
+		parser.NamespaceDelimiter = "-"
+		group, err := parser.AddGroup("Credential Management", "", &struct{}{})
+		Expect(err).ToNot(HaveOccurred())
+
+		manager = factory.AddConfig(group)
+
+		_, err = parser.ParseArgs(args)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		manager.Close(lagertest.NewTestLogger("test"))
+	})
+
+	It("is registered", func() {
+		Expect(creds.ManagerFactories()).To(HaveKey("file"))
+	})
+
+	It("is configured by the dir", func() {
+		Expect(manager.IsConfigured()).To(BeTrue())
+		Expect(manager.Validate()).To(Succeed())
+	})
+
+	Context("when only the env prefix is given", func() {
+		BeforeEach(func() {
+			args = []string{"--file-creds-env-prefix", "CONCOURSE_SECRET_"}
+		})
+
+		It("is configured", func() {
+			Expect(manager.IsConfigured()).To(BeTrue())
+			Expect(manager.Validate()).To(Succeed())
+		})
+	})
+
+	Context("when nothing is given", func() {
+		BeforeEach(func() {
+			args = []string{}
+		})
+
+		It("is not configured", func() {
+			Expect(manager.IsConfigured()).To(BeFalse())
+		})
+	})
+
+	Context("when the dir does not exist", func() {
+		BeforeEach(func() {
+			args = []string{"--file-creds-dir", filepath.Join(dir, "bogus")}
+		})
+
+		It("is invalid", func() {
+			Expect(manager.Validate()).ToNot(Succeed())
+		})
+	})
+
+	Context("when the dir is a file", func() {
+		BeforeEach(func() {
+			path := filepath.Join(dir, "main.yml")
+			Expect(os.WriteFile(path, []byte("foo: bar"), 0644)).To(Succeed())
+			args = []string{"--file-creds-dir", path}
+		})
+
+		It("is invalid", func() {
+			Expect(manager.Validate()).To(MatchError(ContainSubstring("is not a directory")))
+		})
+	})
+
+	Describe("Health", func() {
+		It("reports the loaded files", func() {
+			Expect(os.WriteFile(filepath.Join(dir, "main.yml"), []byte("foo: bar"), 0644)).To(Succeed())
+
+			_, err := manager.NewSecretsFactory(lagertest.NewTestLogger("test"))
+			Expect(err).ToNot(HaveOccurred())
+
+			health, err := manager.Health()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(health.Method).To(Equal("file"))
+			Expect(health.Error).To(BeEmpty())
+			Expect(health.Response).To(HaveField("Files", 1))
+		})
+	})
+
+	Describe("NewSecretsFactory", func() {
+		It("fails when a file cannot be parsed", func() {
+			Expect(os.WriteFile(filepath.Join(dir, "main.yml"), []byte("{"), 0644)).To(Succeed())
+
+			_, err := manager.NewSecretsFactory(lagertest.NewTestLogger("test"))
+			Expect(err).To(MatchError(ContainSubstring("failed to parse main.yml")))
+		})
+	})
+
+	Describe("NewInstance", func() {
+		It("cannot be used as a var_source", func() {
+			_, err := factory.NewInstance(map[string]any{"dir": dir})
+			Expect(err).To(MatchError("the file credential manager cannot be used as a var_source"))
+		})
+	})
+})
@@ -0,0 +1,74 @@
+package file
+
+import (
+	"path"
+	"strings"
+	"time"
+
+	"github.com/concourse/concourse/atc/creds"
+)
+
+type secretsFactory struct {
+	store *Store
+}
+
+func NewSecretsFactory(store *Store) creds.SecretsFactory {
+	return &secretsFactory{
+		store: store,
+	}
+}
+
+func (factory *secretsFactory) NewSecrets() creds.Secrets {
+	return &Secrets{
+		store: factory.store,
+	}
+}
+
+// sharedPrefix marks the secret paths of shared vars. Team names cannot be
+// empty, so no TEAM/... path starts with it.
+const sharedPrefix = "/"
+
+// Secrets looks vars up in the files and environment variables loaded by the
+// store, using the same paths as the other credential managers:
+// TEAM/PIPELINE/VAR, then TEAM/VAR, then the shared VAR.
+type Secrets struct {
+	store *Store
+}
+
+// NewSecretLookupPaths only ever looks up shared vars outside of the team's
+// own paths. Every other var in the store belongs to a team, and as var names
+// may contain '/', looking them up from the root would let a pipeline read
+// another team's vars with ((other-team/var)). The root path is therefore
+// restricted to the shared vars, which are looked up regardless of
+// allowRootPath.
+func (secrets *Secrets) NewSecretLookupPaths(teamName string, pipelineName string, allowRootPath bool) []creds.SecretLookupPath {
+	lookupPaths := []creds.SecretLookupPath{}
+
+	if teamName != "" {
+		if len(pipelineName) > 0 {
+			lookupPaths = append(lookupPaths, creds.NewSecretLookupWithPrefix(path.Join(teamName, pipelineName)+"/"))
+		}
+
+		lookupPaths = append(lookupPaths, creds.NewSecretLookupWithPrefix(teamName+"/"))
+	}
+
+	lookupPaths = append(lookupPaths, creds.NewSecretLookupWithPrefix(sharedPrefix))
+
+	return lookupPaths
+}
+
+func (secrets *Secrets) Get(secretPath string) (any, *time.Time, bool, error) {
+	var value any
+	var found bool
+	if name, shared := strings.CutPrefix(secretPath, sharedPrefix); shared {
+		value, found = secrets.store.Shared(name)
+	} else {
+		value, found = secrets.store.Get(secretPath)
+	}
+
+	if !found {
+		return nil, nil, false, nil
+	}
+
+	return value, nil, true, nil
+}
@@ -0,0 +1,290 @@
+package file
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager/v3"
+	"sigs.k8s.io/yaml"
+)
+
+var extensions = []string{".yml", ".yaml", ".json"}
+
+// StoreStatus is reported as the manager's health.
+type StoreStatus struct {
+	Files     int       `json:"files"`
+	LoadedAt  time.Time `json:"loaded_at"`
+	LastError string    `json:"last_error,omitempty"`
+}
+
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// Store holds the vars loaded from a directory of files, keyed by their
+// secret path, along with the shared vars taken from the environment. The
+// directory is reloaded whenever its files change.
+type Store struct {
+	logger lager.Logger
+	dir    string
+	shared map[string]any
+
+	lock   sync.RWMutex
+	vars   map[string]any
+	stamps map[string]fileStamp
+	status StoreStatus
+
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+// NewStore loads the files in dir, failing if any of them cannot be parsed,
+// and exposes the environment variables in environ which start with
+// envPrefix as shared vars.
+func NewStore(logger lager.Logger, dir string, envPrefix string, environ []string) (*Store, error) {
+	store := &Store{
+		logger: logger,
+		dir:    dir,
+		shared: map[string]any{},
+		vars:   map[string]any{},
+		stamps: map[string]fileStamp{},
+		closed: make(chan struct{}),
+	}
+
+	if envPrefix != "" {
+		for _, env := range environ {
+			name, value, found := strings.Cut(env, "=")
+			if !found || !strings.HasPrefix(name, envPrefix) || name == envPrefix {
+				continue
+			}
+
+			store.shared[strings.ToLower(strings.TrimPrefix(name, envPrefix))] = value
+		}
+	}
+
+	_, err := store.Reload()
+	if err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
+
+// Get returns the team or pipeline var at the secret path, i.e.
+// TEAM/PIPELINE/VAR or TEAM/VAR.
+func (store *Store) Get(secretPath string) (any, bool) {
+	store.lock.RLock()
+	defer store.lock.RUnlock()
+
+	value, found := store.vars[secretPath]
+	return value, found
+}
+
+// Shared returns the shared var taken from the environment with the given
+// name. Environment variable names cannot contain '/', so neither can the
+// names of shared vars.
+func (store *Store) Shared(name string) (any, bool) {
+	if strings.Contains(name, "/") {
+		return nil, false
+	}
+
+	value, found := store.shared[name]
+	return value, found
+}
+
+func (store *Store) Status() StoreStatus {
+	store.lock.RLock()
+	defer store.lock.RUnlock()
+
+	return store.status
+}
+
+// Reload loads the directory's files again if any of them have been added,
+// removed or modified since they were last loaded. The previously loaded vars
+// are kept if any file fails to load.
+func (store *Store) Reload() (bool, error) {
+	if store.dir == "" {
+		return false, nil
+	}
+
+	files, err := store.files()
+	if err == nil && !store.changed(files) {
+		return false, nil
+	}
+
+	var vars map[string]any
+	if err == nil {
+		vars, err = store.load(files)
+	}
+
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	if err != nil {
+		store.status.LastError = err.Error()
+		return false, err
+	}
+
+	store.vars = vars
+	store.stamps = files
+	store.status = StoreStatus{
+		Files:    len(files),
+		LoadedAt: time.Now(),
+	}
+
+	return true, nil
+}
+
+// ReloadLoop reloads the directory on the interval until the store is
+// closed.
+func (store *Store) ReloadLoop(interval time.Duration) {
+	logger := store.logger.Session("reload")
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-store.closed:
+			return
+		case <-ticker.C:
+			reloaded, err := store.Reload()
+			if err != nil {
+				logger.Error("failed-to-reload", err)
+				continue
+			}
+
+			if reloaded {
+				logger.Info("reloaded", lager.Data{"files": store.Status().Files})
+			}
+		}
+	}
+}
+
+func (store *Store) Close() {
+	store.closeOnce.Do(func() {
+		close(store.closed)
+	})
+}
+
+func (store *Store) changed(files map[string]fileStamp) bool {
+	store.lock.RLock()
+	defer store.lock.RUnlock()
+
+	if len(files) != len(store.stamps) {
+		return true
+	}
+
+	for path, stamp := range files {
+		loaded, found := store.stamps[path]
+		if !found || !loaded.modTime.Equal(stamp.modTime) || loaded.size != stamp.size {
+			return true
+		}
+	}
+
+	return false
+}
+
+// files finds the team files at the top of the directory and the pipeline
+// files within each team's directory. Hidden files, such as the ..data
+// directory of a mounted Kubernetes ConfigMap, are skipped.
+func (store *Store) files() (map[string]fileStamp, error) {
+	files := map[string]fileStamp{}
+
+	add := func(dir string) ([]os.DirEntry, error) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return nil, err
+		}
+
+		var dirs []os.DirEntry
+		for _, entry := range entries {
+			if strings.HasPrefix(entry.Name(), ".") {
+				continue
+			}
+
+			path := filepath.Join(dir, entry.Name())
+
+			// follow symlinks
+			info, err := os.Stat(path)
+			if err != nil {
+				return nil, err
+			}
+
+			if info.IsDir() {
+				dirs = append(dirs, entry)
+				continue
+			}
+
+			if info.Mode().IsRegular() && slices.Contains(extensions, filepath.Ext(path)) {
+				files[path] = fileStamp{modTime: info.ModTime(), size: info.Size()}
+			}
+		}
+
+		return dirs, nil
+	}
+
+	teamDirs, err := add(store.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, teamDir := range teamDirs {
+		_, err := add(filepath.Join(store.dir, teamDir.Name()))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return files, nil
+}
+
+func (store *Store) load(files map[string]fileStamp) (map[string]any, error) {
+	vars := map[string]any{}
+	definedIn := map[string]string{}
+
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+
+	slices.Sort(paths)
+
+	for _, path := range paths {
+		rel, err := filepath.Rel(store.dir, path)
+		if err != nil {
+			return nil, err
+		}
+
+		prefix := filepath.ToSlash(strings.TrimSuffix(rel, filepath.Ext(rel))) + "/"
+
+		payload, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		var fileVars map[string]any
+		err = yaml.Unmarshal(payload, &fileVars)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", rel, err)
+		}
+
+		for name, value := range fileVars {
+			secretPath := prefix + name
+			if other, found := definedIn[secretPath]; found {
+				return nil, fmt.Errorf("var '%s' is defined in both %s and %s", secretPath, other, rel)
+			}
+
+			definedIn[secretPath] = rel
+			vars[secretPath] = value
+		}
+	}
+
+	return vars, nil
+}
@@ -0,0 +1,172 @@
+package file_test
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"code.cloudfoundry.org/lager/v3/lagertest"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/file"
+	"github.com/concourse/concourse/vars"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Store", func() {
+	var (
+		dir     string
+		environ []string
+		store   *file.Store
+		mtime   time.Time
+	)
+
+	writeFile := func(name string, contents string) {
+		path := filepath.Join(dir, name)
+		Expect(os.MkdirAll(filepath.Dir(path), 0755)).To(Succeed())
+		Expect(os.WriteFile(path, []byte(contents), 0644)).To(Succeed())
+
+		// make sure the change is noticed on file systems with coarse mtimes
+		mtime = mtime.Add(time.Second)
+		Expect(os.Chtimes(path, mtime, mtime)).To(Succeed())
+	}
+
+	lookupWithRoot := func(team string, pipeline string, allowRootPath bool, ref vars.Reference) (any, bool) {
+		secrets := file.NewSecretsFactory(store).NewSecrets()
+		variables := creds.NewVariables(secrets, creds.SecretLookupParams{Team: team, Pipeline: pipeline}, allowRootPath)
+
+		value, found, err := variables.Get(ref)
+		Expect(err).ToNot(HaveOccurred())
+		return value, found
+	}
+
+	lookup := func(team string, pipeline string, ref vars.Reference) (any, bool) {
+		return lookupWithRoot(team, pipeline, false, ref)
+	}
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		mtime = time.Now()
+		environ = []string{
+			"CONCOURSE_SECRET_GITHUB_TOKEN=from-env",
+			"CONCOURSE_SECRET_SHADOWED=from-env",
+			"HOME=/root",
+		}
+
+		writeFile("main.yml", "shadowed: from-team\nteam-var: team-value\n")
+		writeFile("main/some-pipeline.json", `{"pipeline-var": {"username": "admin"}, "team-var": "pipeline-value"}`)
+		writeFile("main/.hidden.yml", "hidden: value")
+		writeFile("main/README.md", "not: vars")
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		store, err = file.NewStore(lagertest.NewTestLogger("test"), dir, "CONCOURSE_SECRET_", environ)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("looks vars up for the pipeline, then the team", func() {
+		value, found := lookup("main", "some-pipeline", vars.Reference{Path: "team-var"})
+		Expect(found).To(BeTrue())
+		Expect(value).To(Equal("pipeline-value"))
+
+		value, found = lookup("main", "other-pipeline", vars.Reference{Path: "team-var"})
+		Expect(found).To(BeTrue())
+		Expect(value).To(Equal("team-value"))
+
+		value, found = lookup("main", "some-pipeline", vars.Reference{Path: "pipeline-var", Fields: []string{"username"}})
+		Expect(found).To(BeTrue())
+		Expect(value).To(Equal("admin"))
+	})
+
+	It("does not look vars up for other teams", func() {
+		_, found := lookup("other-team", "some-pipeline", vars.Reference{Path: "team-var"})
+		Expect(found).To(BeFalse())
+	})
+
+	Context("when another team has vars", func() {
+		BeforeEach(func() {
+			writeFile("other-team.yml", "secret: other-value\n")
+			writeFile("other-team/some-pipeline.yml", "secret: other-pipeline-value\n")
+		})
+
+		It("does not let a team read them by their full path", func() {
+			for _, allowRootPath := range []bool{false, true} {
+				_, found := lookupWithRoot("main", "some-pipeline", allowRootPath, vars.Reference{Path: "other-team/secret"})
+				Expect(found).To(BeFalse())
+
+				_, found = lookupWithRoot("main", "some-pipeline", allowRootPath, vars.Reference{Path: "other-team/some-pipeline/secret"})
+				Expect(found).To(BeFalse())
+			}
+
+			value, found := lookup("other-team", "", vars.Reference{Path: "secret"})
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("other-value"))
+		})
+	})
+
+	It("exposes prefixed environment variables to every team", func() {
+		value, found := lookup("other-team", "", vars.Reference{Path: "github_token"})
+		Expect(found).To(BeTrue())
+		Expect(value).To(Equal("from-env"))
+
+		_, found = lookup("other-team", "", vars.Reference{Path: "home"})
+		Expect(found).To(BeFalse())
+	})
+
+	It("prefers the team's files over the environment", func() {
+		value, found := lookup("main", "", vars.Reference{Path: "shadowed"})
+		Expect(found).To(BeTrue())
+		Expect(value).To(Equal("from-team"))
+	})
+
+	It("skips hidden files and files which are not YAML or JSON", func() {
+		Expect(store.Status().Files).To(Equal(2))
+
+		_, found := lookup("main", "", vars.Reference{Path: "hidden"})
+		Expect(found).To(BeFalse())
+	})
+
+	Describe("Reload", func() {
+		It("does nothing when no file has changed", func() {
+			reloaded, err := store.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(reloaded).To(BeFalse())
+		})
+
+		It("picks up changed, added and removed files", func() {
+			writeFile("main.yml", "team-var: new-value\n")
+			writeFile("other-team.yml", "team-var: other-value\n")
+			Expect(os.Remove(filepath.Join(dir, "main", "some-pipeline.json"))).To(Succeed())
+
+			reloaded, err := store.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(reloaded).To(BeTrue())
+
+			value, _ := lookup("main", "some-pipeline", vars.Reference{Path: "team-var"})
+			Expect(value).To(Equal("new-value"))
+
+			value, _ = lookup("other-team", "", vars.Reference{Path: "team-var"})
+			Expect(value).To(Equal("other-value"))
+		})
+
+		It("keeps the loaded vars when a file fails to load", func() {
+			writeFile("main.yml", "{")
+
+			_, err := store.Reload()
+			Expect(err).To(MatchError(ContainSubstring("failed to parse main.yml")))
+			Expect(store.Status().LastError).To(ContainSubstring("failed to parse main.yml"))
+
+			value, found := lookup("main", "", vars.Reference{Path: "team-var"})
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("team-value"))
+		})
+
+		It("rejects vars defined in two files", func() {
+			writeFile("main.json", `{"team-var": "again"}`)
+
+			_, err := store.Reload()
+			Expect(err).To(MatchError("var 'main/team-var' is defined in both main.json and main.yml"))
+		})
+	})
+})
@@ -0,0 +1,59 @@
+package idtoken
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/go-jose/go-jose/v4"
+)
+
+// StepTokenGenerator mints the tokens requested with the id_token option of
+// task and run steps.
+type StepTokenGenerator struct {
+	Issuer            string
+	SigningKeyFactory db.SigningKeyFactory
+}
+
+func NewStepTokenGenerator(issuer string, signingKeyFactory db.SigningKeyFactory) *StepTokenGenerator {
+	return &StepTokenGenerator{
+		Issuer:            issuer,
+		SigningKeyFactory: signingKeyFactory,
+	}
+}
+
+func (g *StepTokenGenerator) GenerateIDToken(config atc.IDTokenConfig, metadata exec.StepMetadata, stepName string) (string, time.Time, error) {
+	tokenGenerator := TokenGenerator{
+		Issuer:            g.Issuer,
+		SigningKeyFactory: g.SigningKeyFactory,
+		SubjectScope:      SubjectScope(config.SubjectScope),
+		Audience:          config.Audience,
+		ExpiresIn:         DefaultExpiresIn,
+		Algorithm:         jose.SignatureAlgorithm(config.Algorithm),
+	}
+
+	if config.ExpiresIn != "" {
+		expiresIn, err := time.ParseDuration(config.ExpiresIn)
+		if err != nil {
+			return "", time.Time{}, err
+		}
+
+		tokenGenerator.ExpiresIn = expiresIn
+	}
+
+	return tokenGenerator.GenerateStepToken(
+		creds.SecretLookupParams{
+			Team:         metadata.TeamName,
+			Pipeline:     metadata.PipelineName,
+			InstanceVars: metadata.PipelineInstanceVars,
+			Job:          metadata.JobName,
+		},
+		StepClaims{
+			BuildID:   metadata.BuildID,
+			BuildName: metadata.BuildName,
+			Step:      stepName,
+		},
+	)
+}
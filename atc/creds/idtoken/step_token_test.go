@@ -0,0 +1,123 @@
+package idtoken_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds/idtoken"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StepTokenGenerator", func() {
+	var (
+		generator *idtoken.StepTokenGenerator
+		config    atc.IDTokenConfig
+		metadata  exec.StepMetadata
+
+		token      string
+		validUntil time.Time
+		err        error
+	)
+
+	type stepClaims struct {
+		jwt.Claims
+		Team      string `json:"team"`
+		Pipeline  string `json:"pipeline"`
+		Job       string `json:"job"`
+		BuildID   int    `json:"build_id"`
+		BuildName string `json:"build_name"`
+		Step      string `json:"step"`
+	}
+
+	BeforeEach(func() {
+		signingKeyFactory := new(dbfakes.FakeSigningKeyFactory)
+		signingKeyFactory.GetNewestKeyStub = func(keyType db.SigningKeyType) (db.SigningKey, error) {
+			if keyType == db.SigningKeyTypeEC {
+				return createFakeSigningKey(*ecJWK, time.Now()), nil
+			}
+			return createFakeSigningKey(*rsaJWK, time.Now()), nil
+		}
+
+		generator = idtoken.NewStepTokenGenerator(testIssuer, signingKeyFactory)
+
+		config = atc.IDTokenConfig{
+			Audience: []string{"sts.amazonaws.com"},
+		}
+
+		metadata = exec.StepMetadata{
+			BuildID:      42,
+			BuildName:    "7",
+			TeamName:     "main",
+			JobName:      "some-job",
+			PipelineName: "some-pipeline",
+		}
+	})
+
+	JustBeforeEach(func() {
+		token, validUntil, err = generator.GenerateIDToken(config, metadata, "some-task")
+	})
+
+	parse := func(key jose.JSONWebKey, alg jose.SignatureAlgorithm) stepClaims {
+		parsed, err := jwt.ParseSigned(token, []jose.SignatureAlgorithm{alg})
+		Expect(err).ToNot(HaveOccurred())
+
+		var claims stepClaims
+		err = parsed.Claims(key, &claims)
+		Expect(err).ToNot(HaveOccurred())
+
+		return claims
+	}
+
+	It("mints a token scoped to the build step", func() {
+		Expect(err).ToNot(HaveOccurred())
+
+		claims := parse(rsaJWK.Public(), jose.RS256)
+		Expect(claims.Issuer).To(Equal(testIssuer))
+		Expect(claims.Audience).To(ConsistOf("sts.amazonaws.com"))
+		Expect(claims.Subject).To(Equal("main/some-pipeline"))
+		Expect(claims.Team).To(Equal("main"))
+		Expect(claims.Pipeline).To(Equal("some-pipeline"))
+		Expect(claims.Job).To(Equal("some-job"))
+		Expect(claims.BuildID).To(Equal(42))
+		Expect(claims.BuildName).To(Equal("7"))
+		Expect(claims.Step).To(Equal("some-task"))
+	})
+
+	It("defaults to the default lifetime", func() {
+		Expect(err).ToNot(HaveOccurred())
+		Expect(validUntil).To(BeTemporally("~", time.Now().Add(idtoken.DefaultExpiresIn), 10*time.Second))
+	})
+
+	Context("when the step configures the token", func() {
+		BeforeEach(func() {
+			config.SubjectScope = "job"
+			config.ExpiresIn = "15m"
+			config.Algorithm = "ES256"
+		})
+
+		It("respects the configuration", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(validUntil).To(BeTemporally("~", time.Now().Add(15*time.Minute), 10*time.Second))
+
+			claims := parse(ecJWK.Public(), jose.ES256)
+			Expect(claims.Subject).To(Equal("main/some-pipeline//some-job"))
+		})
+	})
+
+	Context("when expires_in is invalid", func() {
+		BeforeEach(func() {
+			config.ExpiresIn = "bogus"
+		})
+
+		It("errors", func() {
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -42,7 +42,20 @@ type TokenGenerator struct {
 	Algorithm    jose.SignatureAlgorithm
 }
 
+// StepClaims identify the build step which a token was minted for.
+type StepClaims struct {
+	BuildID   int
+	BuildName string
+	Step      string
+}
+
 func (g TokenGenerator) GenerateToken(params creds.SecretLookupParams) (token string, validUntil time.Time, err error) {
+	return g.GenerateStepToken(params, StepClaims{})
+}
+
+// GenerateStepToken generates a token which, in addition to the claims of
+// GenerateToken, names the build and step it was minted for.
+func (g TokenGenerator) GenerateStepToken(params creds.SecretLookupParams, step StepClaims) (token string, validUntil time.Time, err error) {
 	now := time.Now()
 	validUntil = now.Add(g.ExpiresIn)
 
@@ -69,11 +82,17 @@ func (g TokenGenerator) GenerateToken(params creds.SecretLookupParams) (token st
 		Pipeline     string         `json:"pipeline"`
 		Job          string         `json:"job"`
 		InstanceVars map[string]any `json:"instance_vars,omitempty"`
+		BuildID      int            `json:"build_id,omitempty"`
+		BuildName    string         `json:"build_name,omitempty"`
+		Step         string         `json:"step,omitempty"`
 	}{
 		Team:         params.Team,
 		Pipeline:     params.Pipeline,
 		Job:          params.Job,
 		InstanceVars: params.InstanceVars,
+		BuildID:      step.BuildID,
+		BuildName:    step.BuildName,
+		Step:         step.Step,
 	}
 
 	signed, err := jwt.Signed(signer).Claims(claims).Claims(customClaims).Serialize()
@@ -181,6 +181,32 @@ var _ = Describe("IDToken TokenGenerator", func() {
 		Expect(parsed.Headers[0].Algorithm).To(Equal("ES256"))
 	})
 
+	It("adds the build and step claims to step tokens", func() {
+		token, _, err := tokenGenerator.GenerateStepToken(params, idtoken.StepClaims{
+			BuildID:   42,
+			BuildName: "7",
+			Step:      "some-task",
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		parsed, err := jwt.ParseSigned(token, []jose.SignatureAlgorithm{idtoken.DefaultAlgorithm})
+		Expect(err).ToNot(HaveOccurred())
+
+		var claims struct {
+			Job       string `json:"job"`
+			BuildID   int    `json:"build_id"`
+			BuildName string `json:"build_name"`
+			Step      string `json:"step"`
+		}
+		err = parsed.Claims(rsaVerificationKey, &claims)
+		Expect(err).To(Succeed())
+
+		Expect(claims.Job).To(Equal(params.Job))
+		Expect(claims.BuildID).To(Equal(42))
+		Expect(claims.BuildName).To(Equal("7"))
+		Expect(claims.Step).To(Equal("some-task"))
+	})
+
 	Context("Generated Token", func() {
 		type claimStruct struct {
 			jwt.Claims
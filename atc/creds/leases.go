@@ -0,0 +1,41 @@
+package creds
+
+import (
+	"code.cloudfoundry.org/lager/v3"
+)
+
+// LeasedSecrets is implemented by secrets which can hand out dynamic secrets
+// under a lease, e.g. credentials from Vault's database secrets engine. The
+// leases obtained for a build are tracked by the build's run state ID, which
+// is passed in SecretLookupParams, and revoked once the build completes.
+//
+//counterfeiter:generate . LeasedSecrets
+type LeasedSecrets interface {
+	Secrets
+
+	// IsLeased reports whether the secret at the path was handed out under a
+	// lease. Leased secrets belong to a single build, so they must not be
+	// cached.
+	IsLeased(secretPath string) bool
+
+	// RevokeLeases revokes every lease obtained for the run state.
+	RevokeLeases(logger lager.Logger, runStateID string) error
+}
+
+// IsLeased calls IsLeased on the provided secrets if they implement
+// LeasedSecrets, otherwise no secret is leased.
+func IsLeased(secrets Secrets, secretPath string) bool {
+	if leased, ok := secrets.(LeasedSecrets); ok {
+		return leased.IsLeased(secretPath)
+	}
+	return false
+}
+
+// RevokeLeases calls RevokeLeases on the provided secrets if they implement
+// LeasedSecrets, otherwise there is nothing to revoke.
+func RevokeLeases(logger lager.Logger, secrets Secrets, runStateID string) error {
+	if leased, ok := secrets.(LeasedSecrets); ok {
+		return leased.RevokeLeases(logger, runStateID)
+	}
+	return nil
+}
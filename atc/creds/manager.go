@@ -1,6 +1,12 @@
 package creds
 
 import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
 	"code.cloudfoundry.org/lager/v3"
 	"github.com/jessevdk/go-flags"
 )
@@ -23,10 +29,56 @@ type ManagerFactory interface {
 type Managers map[string]Manager
 
 type CredentialManagementConfig struct {
+	ManagerOrder []string `long:"credential-manager-order" description:"Names of the configured credential managers, in the order in which they are consulted for a var. Required when more than one is configured."`
+
 	RetryConfig SecretRetryConfig
 	CacheConfig SecretCacheConfig
 }
 
+// ConfiguredManagers returns the names of the configured managers in the
+// order in which their secrets should be consulted.
+func (c CredentialManagementConfig) ConfiguredManagers(managers Managers) ([]string, error) {
+	var configured []string
+	for name, manager := range managers {
+		if manager.IsConfigured() {
+			configured = append(configured, name)
+		}
+	}
+
+	sort.Strings(configured)
+
+	if len(c.ManagerOrder) == 0 {
+		if len(configured) > 1 {
+			return nil, fmt.Errorf("multiple credential managers configured (%s): their order must be set with --credential-manager-order", strings.Join(configured, ", "))
+		}
+
+		return configured, nil
+	}
+
+	for i, name := range c.ManagerOrder {
+		manager, found := managers[name]
+		if !found {
+			return nil, fmt.Errorf("unknown credential manager '%s' in --credential-manager-order", name)
+		}
+
+		if !manager.IsConfigured() {
+			return nil, fmt.Errorf("credential manager '%s' in --credential-manager-order is not configured", name)
+		}
+
+		if slices.Contains(c.ManagerOrder[:i], name) {
+			return nil, fmt.Errorf("credential manager '%s' is listed more than once in --credential-manager-order", name)
+		}
+	}
+
+	for _, name := range configured {
+		if !slices.Contains(c.ManagerOrder, name) {
+			return nil, fmt.Errorf("credential manager '%s' is configured but missing from --credential-manager-order", name)
+		}
+	}
+
+	return c.ManagerOrder, nil
+}
+
 // NewSecrets creates a Secrets object from secretsFactory based on configs.
 func (c CredentialManagementConfig) NewSecrets(secretsFactory SecretsFactory) Secrets {
 	result := secretsFactory.NewSecrets()
@@ -52,3 +104,32 @@ func Register(name string, managerFactory ManagerFactory) {
 func ManagerFactories() map[string]ManagerFactory {
 	return managerFactories
 }
+
+// ChainedManager is a configured credential manager along with its position in
+// the order in which managers are consulted.
+type ChainedManager struct {
+	Manager
+
+	Position int
+}
+
+func (manager ChainedManager) MarshalJSON() ([]byte, error) {
+	payload, err := json.Marshal(manager.Manager)
+	if err != nil {
+		return nil, err
+	}
+
+	var info map[string]any
+	err = json.Unmarshal(payload, &info)
+	if err != nil {
+		return nil, err
+	}
+
+	if info == nil {
+		info = map[string]any{}
+	}
+
+	info["position"] = manager.Position
+
+	return json.Marshal(info)
+}
@@ -0,0 +1,80 @@
+package creds_test
+
+import (
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/dummy"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CredentialManagementConfig", func() {
+	Describe("ConfiguredManagers", func() {
+		var (
+			config   creds.CredentialManagementConfig
+			managers creds.Managers
+		)
+
+		configured := func() creds.Manager {
+			return &dummy.Manager{Vars: []dummy.VarFlag{{Name: "foo", Value: "bar"}}}
+		}
+
+		BeforeEach(func() {
+			config = creds.CredentialManagementConfig{}
+			managers = creds.Managers{
+				"first":      configured(),
+				"second":     configured(),
+				"unexpected": &dummy.Manager{},
+			}
+		})
+
+		It("returns the managers in the configured order", func() {
+			config.ManagerOrder = []string{"second", "first"}
+
+			names, err := config.ConfiguredManagers(managers)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(names).To(Equal([]string{"second", "first"}))
+		})
+
+		It("requires an order when more than one manager is configured", func() {
+			_, err := config.ConfiguredManagers(managers)
+			Expect(err).To(MatchError("multiple credential managers configured (first, second): their order must be set with --credential-manager-order"))
+		})
+
+		It("does not require an order for a single manager", func() {
+			delete(managers, "second")
+
+			names, err := config.ConfiguredManagers(managers)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(names).To(Equal([]string{"first"}))
+		})
+
+		It("rejects unknown managers", func() {
+			config.ManagerOrder = []string{"first", "second", "bogus"}
+
+			_, err := config.ConfiguredManagers(managers)
+			Expect(err).To(MatchError("unknown credential manager 'bogus' in --credential-manager-order"))
+		})
+
+		It("rejects managers which are not configured", func() {
+			config.ManagerOrder = []string{"first", "second", "unexpected"}
+
+			_, err := config.ConfiguredManagers(managers)
+			Expect(err).To(MatchError("credential manager 'unexpected' in --credential-manager-order is not configured"))
+		})
+
+		It("rejects managers listed twice", func() {
+			config.ManagerOrder = []string{"first", "second", "first"}
+
+			_, err := config.ConfiguredManagers(managers)
+			Expect(err).To(MatchError("credential manager 'first' is listed more than once in --credential-manager-order"))
+		})
+
+		It("rejects configured managers missing from the order", func() {
+			config.ManagerOrder = []string{"second"}
+
+			_, err := config.ConfiguredManagers(managers)
+			Expect(err).To(MatchError("credential manager 'first' is configured but missing from --credential-manager-order"))
+		})
+	})
+})
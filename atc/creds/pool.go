@@ -1,6 +1,7 @@
 package creds
 
 import (
+	"errors"
 	"sync"
 	"time"
 
@@ -15,6 +16,8 @@ import (
 //counterfeiter:generate . VarSourcePool
 type VarSourcePool interface {
 	FindOrCreate(lager.Logger, map[string]any, ManagerFactory) (Secrets, error)
+	RevokeLeases(lager.Logger, string) error
+	InvalidateCache(SecretCacheInvalidation) int
 	Size() int
 	Close()
 }
@@ -116,6 +119,42 @@ func (pool *varSourcePool) FindOrCreate(logger lager.Logger, config map[string]a
 	return pool.pool[key].getSecrets(), nil
 }
 
+// RevokeLeases revokes the leases obtained for the run state from every
+// pooled var source. Leases held by var sources which have since been
+// collected are left to expire.
+func (pool *varSourcePool) RevokeLeases(logger lager.Logger, runStateID string) error {
+	pool.lock.Lock()
+	secrets := make([]Secrets, 0, len(pool.pool))
+	for _, manager := range pool.pool {
+		secrets = append(secrets, manager.secrets)
+	}
+	pool.lock.Unlock()
+
+	var errs []error
+	for _, s := range secrets {
+		err := RevokeLeases(logger, s, runStateID)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// InvalidateCache removes the matching secrets cached by every pooled var
+// source.
+func (pool *varSourcePool) InvalidateCache(invalidation SecretCacheInvalidation) int {
+	pool.lock.Lock()
+	defer pool.lock.Unlock()
+
+	invalidated := 0
+	for _, manager := range pool.pool {
+		invalidated += InvalidateSecretCache(manager.secrets, invalidation)
+	}
+
+	return invalidated
+}
+
 func (pool *varSourcePool) Close() {
 	pool.closeOnce.Do(func() {
 		close(pool.closed)
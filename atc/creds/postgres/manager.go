@@ -0,0 +1,61 @@
+package postgres
+
+import (
+	"encoding/json"
+	"errors"
+
+	"code.cloudfoundry.org/lager/v3"
+
+	"github.com/concourse/concourse/atc/creds"
+)
+
+type Manager struct {
+	Enabled bool `long:"enabled" description:"Look up credentials in the secrets set through the API or 'fly set-secret', which are stored in the database encrypted with the encryption key."`
+
+	factory *ManagerFactory
+}
+
+func (manager *Manager) Init(log lager.Logger) error {
+	return nil
+}
+
+func (manager *Manager) MarshalJSON() ([]byte, error) {
+	health, err := manager.Health()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(&map[string]any{
+		"health": health,
+	})
+}
+
+func (manager *Manager) IsConfigured() bool {
+	return manager.Enabled
+}
+
+func (manager *Manager) Validate() error {
+	if manager.factory == nil || manager.factory.secretFactory == nil {
+		return errors.New("database access has not been configured")
+	}
+
+	return nil
+}
+
+func (manager *Manager) Health() (*creds.HealthResponse, error) {
+	return &creds.HealthResponse{
+		Method: "postgres",
+	}, nil
+}
+
+func (manager *Manager) Close(logger lager.Logger) {
+}
+
+func (manager *Manager) NewSecretsFactory(logger lager.Logger) (creds.SecretsFactory, error) {
+	err := manager.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSecretsFactory(logger, manager.factory.secretFactory), nil
+}
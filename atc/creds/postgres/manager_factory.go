@@ -0,0 +1,60 @@
+package postgres
+
+import (
+	"fmt"
+
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/db"
+	flags "github.com/jessevdk/go-flags"
+)
+
+type ManagerFactory struct {
+	secretFactory db.SecretFactory
+}
+
+func init() {
+	creds.Register("postgres", NewManagerFactory())
+}
+
+func NewManagerFactory() creds.ManagerFactory {
+	return &ManagerFactory{}
+}
+
+func (factory *ManagerFactory) AddConfig(group *flags.Group) creds.Manager {
+	manager := &Manager{factory: factory}
+
+	subGroup, err := group.AddGroup("Postgres Credential Management", "", manager)
+	if err != nil {
+		panic(err)
+	}
+
+	subGroup.Namespace = "postgres-creds"
+
+	return manager
+}
+
+func (factory *ManagerFactory) SetSecretFactory(secretFactory db.SecretFactory) {
+	factory.secretFactory = secretFactory
+}
+
+// NewInstance allows the secrets stored in the database to be used as a
+// var_source, e.g. by pipelines on a cluster which uses another credential
+// manager. It takes no config.
+func (factory *ManagerFactory) NewInstance(config any) (creds.Manager, error) {
+	if config != nil {
+		configMap, ok := config.(map[string]any)
+		if !ok || len(configMap) > 0 {
+			return nil, fmt.Errorf("invalid postgres credential manager config: takes no config")
+		}
+	}
+
+	return &Manager{Enabled: true, factory: factory}, nil
+}
+
+// UpdateGlobalManagerFactory provides a way to give the global
+// postgres.ManagerFactory its database access once it has been established.
+func UpdateGlobalManagerFactory(update func(*ManagerFactory)) {
+	if factory, is := creds.ManagerFactories()["postgres"].(*ManagerFactory); is {
+		update(factory)
+	}
+}
@@ -0,0 +1,65 @@
+package postgres_test
+
+import (
+	"code.cloudfoundry.org/lager/v3/lagertest"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/postgres"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/jessevdk/go-flags"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Manager", func() {
+	var (
+		factory *postgres.ManagerFactory
+		manager creds.Manager
+	)
+
+	BeforeEach(func() {
+		factory = postgres.NewManagerFactory().(*postgres.ManagerFactory)
+	})
+
+	Describe("AddConfig", func() {
+		BeforeEach(func() {
+			parser := flags.NewParser(nil, flags.Default)
+			parser.NamespaceDelimiter = "-"
+			group, err := parser.AddGroup("Credential Management", "", &struct{}{})
+			Expect(err).ToNot(HaveOccurred())
+
+			manager = factory.AddConfig(group)
+
+			_, err = parser.ParseArgs([]string{"--postgres-creds-enabled"})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("is configured by the enabled flag", func() {
+			Expect(manager.IsConfigured()).To(BeTrue())
+		})
+
+		It("is invalid until it has database access", func() {
+			Expect(manager.Validate()).ToNot(Succeed())
+
+			factory.SetSecretFactory(new(dbfakes.FakeSecretFactory))
+			Expect(manager.Validate()).To(Succeed())
+
+			_, err := manager.NewSecretsFactory(lagertest.NewTestLogger("test"))
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	Describe("NewInstance", func() {
+		BeforeEach(func() {
+			factory.SetSecretFactory(new(dbfakes.FakeSecretFactory))
+		})
+
+		It("takes no config", func() {
+			manager, err := factory.NewInstance(map[string]any{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(manager.Validate()).To(Succeed())
+
+			_, err = factory.NewInstance(map[string]any{"some": "config"})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,93 @@
+package postgres
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager/v3"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type Secrets struct {
+	log           lager.Logger
+	secretFactory db.SecretFactory
+}
+
+func NewSecrets(log lager.Logger, secretFactory db.SecretFactory) *Secrets {
+	return &Secrets{
+		log:           log,
+		secretFactory: secretFactory,
+	}
+}
+
+// NewSecretLookupPaths looks up pipeline-scoped secrets before team-scoped
+// ones. Secrets are never shared between teams, so there is no root path.
+func (s *Secrets) NewSecretLookupPaths(teamName string, pipelineName string, allowRootPath bool) []creds.SecretLookupPath {
+	lookupPaths := []creds.SecretLookupPath{}
+	if len(pipelineName) > 0 {
+		lookupPaths = append(lookupPaths, secretLookup{Team: teamName, Pipeline: pipelineName})
+	}
+
+	lookupPaths = append(lookupPaths, secretLookup{Team: teamName})
+
+	return lookupPaths
+}
+
+// Get retrieves a secret by a path of the form team/secret or
+// team/pipeline/secret, as constructed by its lookup paths.
+func (s *Secrets) Get(secretPath string) (any, *time.Time, bool, error) {
+	segments := strings.Split(secretPath, "/")
+
+	var teamName, pipelineName, name string
+	switch len(segments) {
+	case 2:
+		teamName, name = segments[0], segments[1]
+	case 3:
+		teamName, pipelineName, name = segments[0], segments[1], segments[2]
+	default:
+		return nil, nil, false, nil
+	}
+
+	for _, segment := range []*string{&teamName, &pipelineName, &name} {
+		unescaped, err := url.PathUnescape(*segment)
+		if err != nil {
+			return nil, nil, false, nil
+		}
+
+		*segment = unescaped
+	}
+
+	value, found, err := s.secretFactory.Secret(teamName, pipelineName, name)
+	if err != nil {
+		s.log.Error("failed-to-get-secret", err, lager.Data{
+			"secret-path": secretPath,
+		})
+		return nil, nil, false, err
+	}
+
+	if !found {
+		return nil, nil, false, nil
+	}
+
+	return value, nil, true, nil
+}
+
+// secretLookup escapes each part of the path so that a var containing a '/'
+// can not be mistaken for another pipeline's secret.
+type secretLookup struct {
+	Team     string
+	Pipeline string
+}
+
+func (lookup secretLookup) VariableToSecretPath(name string) (string, error) {
+	segments := []string{url.PathEscape(lookup.Team)}
+	if lookup.Pipeline != "" {
+		segments = append(segments, url.PathEscape(lookup.Pipeline))
+	}
+
+	segments = append(segments, url.PathEscape(name))
+
+	return strings.Join(segments, "/"), nil
+}
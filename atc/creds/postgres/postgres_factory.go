@@ -0,0 +1,23 @@
+package postgres
+
+import (
+	"code.cloudfoundry.org/lager/v3"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type secretsFactory struct {
+	log           lager.Logger
+	secretFactory db.SecretFactory
+}
+
+func NewSecretsFactory(log lager.Logger, secretFactory db.SecretFactory) creds.SecretsFactory {
+	return &secretsFactory{
+		log:           log,
+		secretFactory: secretFactory,
+	}
+}
+
+func (factory *secretsFactory) NewSecrets() creds.Secrets {
+	return NewSecrets(factory.log, factory.secretFactory)
+}
@@ -0,0 +1,106 @@
+package postgres_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/lager/v3/lagertest"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/postgres"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/vars"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Postgres", func() {
+	var (
+		fakeSecretFactory *dbfakes.FakeSecretFactory
+		secrets           *postgres.Secrets
+		variables         vars.Variables
+	)
+
+	BeforeEach(func() {
+		fakeSecretFactory = new(dbfakes.FakeSecretFactory)
+		secrets = postgres.NewSecrets(lagertest.NewTestLogger("test"), fakeSecretFactory)
+
+		variables = creds.NewVariables(secrets, creds.SecretLookupParams{
+			Team:     "some-team",
+			Pipeline: "some-pipeline",
+		}, false)
+	})
+
+	Describe("Get", func() {
+		It("prefers a pipeline-scoped secret", func() {
+			fakeSecretFactory.SecretReturns("some-value", true, nil)
+
+			value, found, err := variables.Get(vars.Reference{Path: "some-secret"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("some-value"))
+
+			Expect(fakeSecretFactory.SecretCallCount()).To(Equal(1))
+			teamName, pipelineName, name := fakeSecretFactory.SecretArgsForCall(0)
+			Expect(teamName).To(Equal("some-team"))
+			Expect(pipelineName).To(Equal("some-pipeline"))
+			Expect(name).To(Equal("some-secret"))
+		})
+
+		It("falls back to a team-scoped secret", func() {
+			fakeSecretFactory.SecretReturnsOnCall(0, "", false, nil)
+			fakeSecretFactory.SecretReturnsOnCall(1, "some-team-value", true, nil)
+
+			value, found, err := variables.Get(vars.Reference{Path: "some-secret"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("some-team-value"))
+
+			teamName, pipelineName, name := fakeSecretFactory.SecretArgsForCall(1)
+			Expect(teamName).To(Equal("some-team"))
+			Expect(pipelineName).To(BeEmpty())
+			Expect(name).To(Equal("some-secret"))
+		})
+
+		It("does not find a secret which is not set", func() {
+			_, found, err := variables.Get(vars.Reference{Path: "some-secret"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("does not mistake a var containing a '/' for another pipeline's secret", func() {
+			_, found, err := variables.Get(vars.Reference{Path: "other-pipeline/some-secret"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+
+			Expect(fakeSecretFactory.SecretCallCount()).To(Equal(2))
+			teamName, pipelineName, name := fakeSecretFactory.SecretArgsForCall(1)
+			Expect(teamName).To(Equal("some-team"))
+			Expect(pipelineName).To(BeEmpty())
+			Expect(name).To(Equal("other-pipeline/some-secret"))
+		})
+
+		It("returns an error if the secret can not be looked up", func() {
+			disaster := errors.New("nope")
+			fakeSecretFactory.SecretReturns("", false, disaster)
+
+			_, _, err := variables.Get(vars.Reference{Path: "some-secret"})
+			Expect(err).To(Equal(disaster))
+		})
+
+		Context("without a pipeline", func() {
+			BeforeEach(func() {
+				variables = creds.NewVariables(secrets, creds.SecretLookupParams{
+					Team: "some-team",
+				}, false)
+			})
+
+			It("only looks up team-scoped secrets", func() {
+				_, _, err := variables.Get(vars.Reference{Path: "some-secret"})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeSecretFactory.SecretCallCount()).To(Equal(1))
+				_, pipelineName, _ := fakeSecretFactory.SecretArgsForCall(0)
+				Expect(pipelineName).To(BeEmpty())
+			})
+		})
+	})
+})
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"time"
 
+	"code.cloudfoundry.org/lager/v3"
 	"github.com/concourse/retryhttp"
 )
 
@@ -53,3 +54,11 @@ func (rs RetryableSecrets) GetWithParams(secretPath string, context SecretLookup
 func (rs RetryableSecrets) NewSecretLookupPathsWithParams(context SecretLookupParams, allowRootPath bool) []SecretLookupPath {
 	return NewSecretLookupPathsWithParams(rs.secrets, context, allowRootPath)
 }
+
+func (rs RetryableSecrets) IsLeased(secretPath string) bool {
+	return IsLeased(rs.secrets, secretPath)
+}
+
+func (rs RetryableSecrets) RevokeLeases(logger lager.Logger, runStateID string) error {
+	return RevokeLeases(logger, rs.secrets, runStateID)
+}
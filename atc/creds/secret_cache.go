@@ -0,0 +1,23 @@
+package creds
+
+// SecretCacheInvalidation selects the cached secrets to remove so that they
+// are looked up again, e.g. after a leaked credential has been rotated. An
+// empty Team or Var matches every team or var.
+type SecretCacheInvalidation struct {
+	Team string `json:"team,omitempty"`
+	Var  string `json:"var,omitempty"`
+}
+
+// SecretCache is implemented by secrets which cache what they look up.
+type SecretCache interface {
+	Invalidate(SecretCacheInvalidation) int
+}
+
+// InvalidateSecretCache calls Invalidate on the provided secrets if they
+// implement SecretCache, otherwise there is nothing to invalidate.
+func InvalidateSecretCache(secrets Secrets, invalidation SecretCacheInvalidation) int {
+	if cache, ok := secrets.(SecretCache); ok {
+		return cache.Invalidate(invalidation)
+	}
+	return 0
+}
@@ -1,6 +1,8 @@
 package creds
 
 import (
+	"strings"
+
 	"github.com/concourse/concourse/vars"
 )
 
@@ -19,43 +21,63 @@ func NewVariables(secrets Secrets, secretsLookupParams SecretLookupParams, allow
 }
 
 func (sl VariableLookupFromSecrets) Get(ref vars.Reference) (any, bool, error) {
-	val, found, err := sl.get(ref.Path)
+	val, _, found, err := sl.Resolve(ref)
+	return val, found, err
+}
+
+// Resolve is like Get, also reporting the path of the secret which the var
+// was found in.
+func (sl VariableLookupFromSecrets) Resolve(ref vars.Reference) (any, vars.Resolution, bool, error) {
+	val, resolution, found, err := sl.get(ref.Path)
 	if err != nil {
-		return nil, false, err
+		return nil, vars.Resolution{}, false, err
 	}
 	if !found {
-		return nil, false, nil
+		return nil, vars.Resolution{}, false, nil
 	}
 	result, err := vars.Traverse(val, ref.String(), ref.Fields)
 	if err != nil {
-		return nil, false, err
+		return nil, vars.Resolution{}, false, err
 	}
-	return result, true, nil
+	return result, resolution, true, nil
 }
 
-func (sl VariableLookupFromSecrets) get(path string) (any, bool, error) {
+func (sl VariableLookupFromSecrets) get(path string) (any, vars.Resolution, bool, error) {
 	if len(sl.LookupPaths) == 0 {
 		// if no paths are specified (i.e. for fake & noop secret managers), then try 1-to-1 var->secret mapping
 		result, _, found, err := GetWithParams(sl.Secrets, path, sl.Context)
-		return result, found, err
+		return result, vars.Resolution{SecretPath: path}, found, err
 	}
 	// try to find a secret according to our var->secret lookup paths
 	for _, rule := range sl.LookupPaths {
 		// prepends any additional prefix paths to front of the path
 		secretPath, err := rule.VariableToSecretPath(path)
 		if err != nil {
-			return nil, false, err
+			return nil, vars.Resolution{}, false, err
 		}
 		result, _, found, err := GetWithParams(sl.Secrets, secretPath, sl.Context)
 		if err != nil {
-			return nil, false, err
+			return nil, vars.Resolution{}, false, err
 		}
 		if !found {
 			continue
 		}
-		return result, true, nil
+		return result, lookupResolution(rule, secretPath), true, nil
+	}
+	return nil, vars.Resolution{}, false, nil
+}
+
+// lookupResolution names the manager which served the secret when the lookup
+// path belongs to one of several chained managers.
+func lookupResolution(rule SecretLookupPath, secretPath string) vars.Resolution {
+	if chained, ok := rule.(chainedLookupPath); ok {
+		return vars.Resolution{
+			Manager:    chained.manager,
+			SecretPath: strings.TrimPrefix(secretPath, chained.manager+":"),
+		}
 	}
-	return nil, false, nil
+
+	return vars.Resolution{SecretPath: secretPath}
 }
 
 func (sl VariableLookupFromSecrets) List() ([]vars.Reference, error) {
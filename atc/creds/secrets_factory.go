@@ -26,6 +26,11 @@ type SecretLookupParams struct {
 	Pipeline     string
 	InstanceVars atc.InstanceVars
 	Job          string
+
+	// RunStateID identifies the build the lookup is made for, so that leased
+	// secrets can be revoked once it completes. It is empty for lookups made
+	// outside of builds.
+	RunStateID string
 }
 
 func (s SecretLookupParams) IsEmpty() bool {
@@ -104,6 +104,12 @@ func (ac *APIClient) Read(path string) (*vaultapi.Secret, error) {
 	return secret, err
 }
 
+// Revoke the lease of a dynamic secret, invalidating the credentials it
+// handed out.
+func (ac *APIClient) Revoke(leaseID string) error {
+	return ac.client().Sys().Revoke(leaseID)
+}
+
 type kvMountInfo struct {
 	mountPath string
 	isV2      bool
@@ -0,0 +1,124 @@
+package vault
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager/v3"
+)
+
+// A LeaseRevoker revokes the leases of dynamic secrets. It should be thread
+// safe!
+type LeaseRevoker interface {
+	Revoke(leaseID string) error
+}
+
+// Leases tracks the leases of the dynamic secrets read for each build, e.g.
+// from the database or AWS secrets engines, so that they can be revoked
+// rather than left to expire once the build completes.
+//
+// Leases are only tracked in memory, and only until they expire, so revoking
+// them is best effort: the leases of builds which were running when the ATC
+// restarted, or which never complete, are left to expire in Vault.
+type Leases struct {
+	revoker LeaseRevoker
+	clock   clock.Clock
+
+	lock        sync.Mutex
+	byRunState  map[string][]lease
+	leasedPaths map[string]time.Time
+}
+
+type lease struct {
+	id        string
+	expiresAt time.Time
+}
+
+func NewLeases(revoker LeaseRevoker, clock clock.Clock) *Leases {
+	return &Leases{
+		revoker:     revoker,
+		clock:       clock,
+		byRunState:  map[string][]lease{},
+		leasedPaths: map[string]time.Time{},
+	}
+}
+
+func (l *Leases) track(secretPath string, runStateID string, leaseID string, duration time.Duration) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := l.clock.Now()
+	l.forgetExpired(now)
+
+	expiresAt := now.Add(duration)
+	if expiresAt.After(l.leasedPaths[secretPath]) {
+		l.leasedPaths[secretPath] = expiresAt
+	}
+
+	// leases read outside of a build are left to expire
+	if runStateID != "" {
+		l.byRunState[runStateID] = append(l.byRunState[runStateID], lease{id: leaseID, expiresAt: expiresAt})
+	}
+}
+
+// forgetExpired drops the leases which Vault has already revoked, so that
+// the leases of builds which never complete do not pile up.
+func (l *Leases) forgetExpired(now time.Time) {
+	for secretPath, expiresAt := range l.leasedPaths {
+		if !expiresAt.After(now) {
+			delete(l.leasedPaths, secretPath)
+		}
+	}
+
+	for runStateID, leases := range l.byRunState {
+		live := leases[:0]
+		for _, lease := range leases {
+			if lease.expiresAt.After(now) {
+				live = append(live, lease)
+			}
+		}
+
+		if len(live) == 0 {
+			delete(l.byRunState, runStateID)
+		} else {
+			l.byRunState[runStateID] = live
+		}
+	}
+}
+
+func (l *Leases) isLeased(secretPath string) bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	expiresAt, found := l.leasedPaths[secretPath]
+	return found && expiresAt.After(l.clock.Now())
+}
+
+func (l *Leases) revoke(logger lager.Logger, runStateID string) error {
+	l.lock.Lock()
+	leases := l.byRunState[runStateID]
+	delete(l.byRunState, runStateID)
+	l.lock.Unlock()
+
+	now := l.clock.Now()
+
+	var errs []error
+	for _, lease := range leases {
+		if !lease.expiresAt.After(now) {
+			continue
+		}
+
+		err := l.revoker.Revoke(lease.id)
+		if err != nil {
+			logger.Error("failed-to-revoke-lease", err, lager.Data{"lease-id": lease.id})
+			errs = append(errs, err)
+			continue
+		}
+
+		logger.Debug("revoked-lease", lager.Data{"lease-id": lease.id})
+	}
+
+	return errors.Join(errs...)
+}
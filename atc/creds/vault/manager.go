@@ -8,6 +8,7 @@ import (
 	"path"
 	"time"
 
+	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager/v3"
 
 	"github.com/concourse/concourse/atc/creds"
@@ -224,6 +225,7 @@ func (manager *VaultManager) NewSecretsFactory(logger lager.Logger) (creds.Secre
 			manager.PathPrefix,
 			templates,
 			manager.SharedPath,
+			NewLeases(manager.Client, clock.NewClock()),
 		)
 	}
 
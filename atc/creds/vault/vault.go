@@ -4,6 +4,7 @@ import (
 	"path"
 	"time"
 
+	"code.cloudfoundry.org/lager/v3"
 	"github.com/concourse/concourse/atc/creds"
 
 	vaultapi "github.com/hashicorp/vault/api"
@@ -30,6 +31,10 @@ type Vault struct {
 	SharedPath      string
 	LoggedIn        <-chan struct{}
 	LoginTimeout    time.Duration
+
+	// Leases tracks the leases of dynamic secrets. Leases are neither
+	// tracked nor revoked when it is nil.
+	Leases *Leases
 }
 
 // NewSecretLookupPaths defines how variables will be searched in the underlying secret manager
@@ -49,8 +54,19 @@ func (v Vault) NewSecretLookupPaths(teamName string, pipelineName string, allowR
 	return lookupPaths
 }
 
+func (v Vault) NewSecretLookupPathsWithParams(params creds.SecretLookupParams, allowRootPath bool) []creds.SecretLookupPath {
+	return v.NewSecretLookupPaths(params.Team, params.Pipeline, allowRootPath)
+}
+
 // Get retrieves the value and expiration of an individual secret
 func (v Vault) Get(secretPath string) (any, *time.Time, bool, error) {
+	return v.GetWithParams(secretPath, creds.SecretLookupParams{})
+}
+
+// GetWithParams retrieves the value and expiration of an individual secret,
+// tracking its lease against the build it was looked up for if it is a
+// dynamic secret.
+func (v Vault) GetWithParams(secretPath string, params creds.SecretLookupParams) (any, *time.Time, bool, error) {
 	if v.LoggedIn != nil {
 		select {
 		case <-v.LoggedIn:
@@ -67,6 +83,10 @@ func (v Vault) Get(secretPath string) (any, *time.Time, bool, error) {
 		return nil, nil, false, nil
 	}
 
+	if secret.LeaseID != "" && v.Leases != nil {
+		v.Leases.track(secretPath, params.RunStateID, secret.LeaseID, time.Duration(secret.LeaseDuration)*time.Second)
+	}
+
 	val, found := secret.Data["value"]
 	if found {
 		return val, expiration, true, nil
@@ -95,3 +115,22 @@ func (v Vault) findSecret(path string) (*vaultapi.Secret, *time.Time, bool, erro
 
 	return nil, nil, false, nil
 }
+
+// IsLeased reports whether the secret at the path has been read with a lease,
+// i.e. it is a dynamic secret.
+func (v Vault) IsLeased(secretPath string) bool {
+	if v.Leases == nil {
+		return false
+	}
+
+	return v.Leases.isLeased(secretPath)
+}
+
+// RevokeLeases revokes the leases of the dynamic secrets read for the build.
+func (v Vault) RevokeLeases(logger lager.Logger, runStateID string) error {
+	if v.Leases == nil {
+		return nil
+	}
+
+	return v.Leases.revoke(logger, runStateID)
+}
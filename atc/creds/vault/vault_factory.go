@@ -14,9 +14,10 @@ type vaultFactory struct {
 	lookupTemplates []*creds.SecretTemplate
 	loggedIn        <-chan struct{}
 	loginTimeout    time.Duration
+	leases          *Leases
 }
 
-func NewVaultFactory(sr SecretReader, loginTimeout time.Duration, loggedIn <-chan struct{}, prefix string, lookupTemplates []*creds.SecretTemplate, sharedPath string) *vaultFactory {
+func NewVaultFactory(sr SecretReader, loginTimeout time.Duration, loggedIn <-chan struct{}, prefix string, lookupTemplates []*creds.SecretTemplate, sharedPath string, leases *Leases) *vaultFactory {
 	factory := &vaultFactory{
 		sr:              sr,
 		prefix:          prefix,
@@ -24,6 +25,7 @@ func NewVaultFactory(sr SecretReader, loginTimeout time.Duration, loggedIn <-cha
 		sharedPath:      sharedPath,
 		loggedIn:        loggedIn,
 		loginTimeout:    loginTimeout,
+		leases:          leases,
 	}
 
 	return factory
@@ -37,5 +39,6 @@ func (factory *vaultFactory) NewSecrets() creds.Secrets {
 		SharedPath:      factory.sharedPath,
 		LoginTimeout:    factory.loginTimeout,
 		LoggedIn:        factory.loggedIn,
+		Leases:          factory.leases,
 	}
 }
@@ -2,9 +2,11 @@ package vault_test
 
 import (
 	"encoding/json"
+	"errors"
 	"regexp"
 	"time"
 
+	"code.cloudfoundry.org/clock/fakeclock"
 	"code.cloudfoundry.org/lager/v3/lagertest"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/creds/vault"
@@ -37,6 +39,20 @@ func (msr *MockSecretReader) Read(lookupPath string) (*vaultapi.Secret, error) {
 	return nil, nil
 }
 
+type MockLeaseRevoker struct {
+	revoked []string
+	err     error
+}
+
+func (mlr *MockLeaseRevoker) Revoke(leaseID string) error {
+	if mlr.err != nil {
+		return mlr.err
+	}
+
+	mlr.revoked = append(mlr.revoked, leaseID)
+	return nil
+}
+
 func createMockV2Secret(value string) *vaultapi.Secret {
 	return &vaultapi.Secret{
 		Data: map[string]any{
@@ -317,6 +333,135 @@ var _ = Describe("Vault", func() {
 			})
 		})
 	})
+
+	Describe("leases", func() {
+		var (
+			revoker   *MockLeaseRevoker
+			fakeClock *fakeclock.FakeClock
+		)
+
+		BeforeEach(func() {
+			close(loggedInCh)
+
+			revoker = &MockLeaseRevoker{}
+			fakeClock = fakeclock.NewFakeClock(time.Now())
+			v.Leases = vault.NewLeases(revoker, fakeClock)
+			v.SecretReader = &MockSecretReader{&[]MockSecret{
+				{
+					path: "/concourse/team/pipeline/db",
+					secret: &vaultapi.Secret{
+						LeaseID:       "database/creds/some-role/some-lease",
+						LeaseDuration: 3600,
+						Data:          map[string]any{"username": "v-some-user", "password": "some-password"},
+					},
+				},
+				{
+					path:   "/concourse/team/pipeline/foo",
+					secret: createMockV1Secret("bar"),
+				},
+			}}
+
+			variables = creds.NewVariables(v, creds.SecretLookupParams{Team: "team", Pipeline: "pipeline", RunStateID: "build:1"}, false)
+		})
+
+		It("implements the LeasedSecrets interface", func() {
+			var _ creds.LeasedSecrets = v
+		})
+
+		It("reports dynamic secrets as leased once they have been read", func() {
+			Expect(v.IsLeased("/concourse/team/pipeline/db")).To(BeFalse())
+
+			_, found, err := variables.Get(vars.Reference{Path: "db", Fields: []string{"username"}})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			_, found, err = variables.Get(varFoo)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			Expect(v.IsLeased("/concourse/team/pipeline/db")).To(BeTrue())
+			Expect(v.IsLeased("/concourse/team/pipeline/foo")).To(BeFalse())
+		})
+
+		It("revokes the leases read for the build", func() {
+			_, _, err := variables.Get(vars.Reference{Path: "db"})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = v.RevokeLeases(lagertest.NewTestLogger("test"), "build:2")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(revoker.revoked).To(BeEmpty())
+
+			err = v.RevokeLeases(lagertest.NewTestLogger("test"), "build:1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(revoker.revoked).To(Equal([]string{"database/creds/some-role/some-lease"}))
+
+			By("forgetting the leases once they are revoked")
+			err = v.RevokeLeases(lagertest.NewTestLogger("test"), "build:1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(revoker.revoked).To(HaveLen(1))
+		})
+
+		It("does not track leases read outside of a build", func() {
+			_, _, _, err := v.Get("/concourse/team/pipeline/db")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(v.IsLeased("/concourse/team/pipeline/db")).To(BeTrue())
+
+			err = v.RevokeLeases(lagertest.NewTestLogger("test"), "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(revoker.revoked).To(BeEmpty())
+		})
+
+		Context("when the lease has expired", func() {
+			BeforeEach(func() {
+				_, _, err := variables.Get(vars.Reference{Path: "db"})
+				Expect(err).ToNot(HaveOccurred())
+
+				fakeClock.Increment(time.Hour)
+			})
+
+			It("no longer reports the secret as leased", func() {
+				Expect(v.IsLeased("/concourse/team/pipeline/db")).To(BeFalse())
+			})
+
+			It("does not revoke it", func() {
+				err := v.RevokeLeases(lagertest.NewTestLogger("test"), "build:1")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(revoker.revoked).To(BeEmpty())
+			})
+
+			It("forgets it once other leases are tracked", func() {
+				otherBuild := creds.NewVariables(v, creds.SecretLookupParams{Team: "team", Pipeline: "pipeline", RunStateID: "build:2"}, false)
+				_, _, err := otherBuild.Get(vars.Reference{Path: "db"})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(v.IsLeased("/concourse/team/pipeline/db")).To(BeTrue())
+
+				fakeClock.Increment(time.Minute)
+
+				err = v.RevokeLeases(lagertest.NewTestLogger("test"), "build:1")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(revoker.revoked).To(BeEmpty())
+
+				err = v.RevokeLeases(lagertest.NewTestLogger("test"), "build:2")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(revoker.revoked).To(Equal([]string{"database/creds/some-role/some-lease"}))
+			})
+		})
+
+		Context("when revoking fails", func() {
+			BeforeEach(func() {
+				revoker.err = errors.New("permission denied")
+			})
+
+			It("returns the error", func() {
+				_, _, err := variables.Get(vars.Reference{Path: "db"})
+				Expect(err).ToNot(HaveOccurred())
+
+				err = v.RevokeLeases(lagertest.NewTestLogger("test"), "build:1")
+				Expect(err).To(MatchError("permission denied"))
+			})
+		})
+	})
 })
 
 // The below tests use ghttp handlers to mock a real vault API to the api_client.
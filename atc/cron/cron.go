@@ -0,0 +1,228 @@
+// Package cron parses cron expressions, which describe the times at which a
+// job is triggered by its schedule.
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a parsed cron expression.
+type Schedule struct {
+	minute, hour, dom, month, dow uint64
+
+	// When only one of the day fields is restricted the other is ignored,
+	// otherwise a day matches if it matches either field.
+	domRestricted, dowRestricted bool
+}
+
+type field struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	minuteField = field{name: "minute", min: 0, max: 59}
+	hourField   = field{name: "hour", min: 0, max: 23}
+	domField    = field{name: "day of month", min: 1, max: 31}
+	monthField  = field{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// 7 is accepted as well as 0 for Sunday.
+	dowField = field{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// Parse parses a standard five field cron expression (minute, hour, day of
+// month, month and day of week) or one of the descriptors such as @daily.
+func Parse(expr string) (Schedule, error) {
+	spec := strings.TrimSpace(expr)
+	if strings.HasPrefix(spec, "@") {
+		var found bool
+		spec, found = descriptors[strings.ToLower(spec)]
+		if !found {
+			return Schedule{}, fmt.Errorf("invalid cron expression %q: unknown descriptor", expr)
+		}
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return Schedule{}, fmt.Errorf("invalid cron expression %q: expected 5 fields, got %d", expr, len(fields))
+	}
+
+	var schedule Schedule
+	var err error
+
+	for i, parse := range []struct {
+		field field
+		bits  *uint64
+	}{
+		{minuteField, &schedule.minute},
+		{hourField, &schedule.hour},
+		{domField, &schedule.dom},
+		{monthField, &schedule.month},
+		{dowField, &schedule.dow},
+	} {
+		*parse.bits, err = parse.field.parse(fields[i])
+		if err != nil {
+			return Schedule{}, fmt.Errorf("invalid cron expression %q: %w", expr, err)
+		}
+	}
+
+	if schedule.dow&(1<<7) != 0 {
+		schedule.dow |= 1 << 0
+	}
+
+	schedule.domRestricted = !wildcard(fields[2])
+	schedule.dowRestricted = !wildcard(fields[4])
+
+	return schedule, nil
+}
+
+func wildcard(value string) bool {
+	return value == "*" || value == "?"
+}
+
+func (f field) parse(value string) (uint64, error) {
+	var bits uint64
+	for part := range strings.SplitSeq(value, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepPart)
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step %q in %s field", stepPart, f.name)
+			}
+		}
+
+		var low, high int
+		switch {
+		case wildcard(rangePart):
+			low, high = f.min, f.max
+		case strings.Contains(rangePart, "-"):
+			lowPart, highPart, _ := strings.Cut(rangePart, "-")
+
+			var err error
+			low, err = f.value(lowPart)
+			if err != nil {
+				return 0, err
+			}
+
+			high, err = f.value(highPart)
+			if err != nil {
+				return 0, err
+			}
+
+			if low > high {
+				return 0, fmt.Errorf("invalid range %q in %s field", rangePart, f.name)
+			}
+		default:
+			var err error
+			low, err = f.value(rangePart)
+			if err != nil {
+				return 0, err
+			}
+
+			high = low
+			if hasStep {
+				high = f.max
+			}
+		}
+
+		for i := low; i <= high; i += step {
+			bits |= 1 << uint(i)
+		}
+	}
+
+	return bits, nil
+}
+
+func (f field) value(value string) (int, error) {
+	if n, found := f.names[strings.ToLower(value)]; found {
+		return n, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q in %s field", value, f.name)
+	}
+
+	if n < f.min || n > f.max {
+		return 0, fmt.Errorf("value %d out of range [%d-%d] in %s field", n, f.min, f.max, f.name)
+	}
+
+	return n, nil
+}
+
+// searchYears bounds how far ahead Next looks for a matching time, so that
+// expressions which can never match (e.g. 30th of February) terminate.
+const searchYears = 5
+
+// Next returns the first time after the given time which matches the
+// schedule, in the location of the given time. Times are matched against the
+// wall clock, so a time skipped by a daylight saving transition does not
+// match and a time repeated by one only matches once. The zero time is
+// returned if nothing matches.
+func (s Schedule) Next(after time.Time) time.Time {
+	loc := after.Location()
+
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Year() + searchYears
+
+	for t.Year() <= limit {
+		year, month, day := t.Date()
+		hour, minute := t.Hour(), t.Minute()
+
+		var next time.Time
+		switch {
+		case s.month&(1<<uint(month)) == 0:
+			next = time.Date(year, month+1, 1, 0, 0, 0, 0, loc)
+		case !s.dayMatches(t):
+			next = time.Date(year, month, day+1, 0, 0, 0, 0, loc)
+		case s.hour&(1<<uint(hour)) == 0:
+			next = time.Date(year, month, day, hour+1, 0, 0, 0, loc)
+		case s.minute&(1<<uint(minute)) == 0:
+			next = time.Date(year, month, day, hour, minute+1, 0, 0, loc)
+		default:
+			return t
+		}
+
+		// time.Date moves backwards when given a time which is skipped or
+		// repeated by a daylight saving transition
+		if !next.After(t) {
+			next = t.Add(time.Minute)
+		}
+
+		t = next
+	}
+
+	return time.Time{}
+}
+
+func (s Schedule) dayMatches(t time.Time) bool {
+	domMatches := s.dom&(1<<uint(t.Day())) != 0
+	dowMatches := s.dow&(1<<uint(t.Weekday())) != 0
+
+	if s.domRestricted && s.dowRestricted {
+		return domMatches || dowMatches
+	}
+
+	return domMatches && dowMatches
+}
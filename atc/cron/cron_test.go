@@ -0,0 +1,89 @@
+package cron_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc/cron"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cron", func() {
+	Describe("Parse", func() {
+		DescribeTable("rejects invalid expressions",
+			func(expr string, message string) {
+				_, err := cron.Parse(expr)
+				Expect(err).To(MatchError(ContainSubstring(message)))
+			},
+			Entry("too few fields", "0 0 * *", "expected 5 fields, got 4"),
+			Entry("unknown descriptor", "@fortnightly", "unknown descriptor"),
+			Entry("out of range", "60 * * * *", "value 60 out of range [0-59] in minute field"),
+			Entry("bad value", "0 x * * *", `invalid value "x" in hour field`),
+			Entry("backwards range", "0 0 * * 5-1", `invalid range "5-1" in day of week field`),
+			Entry("bad step", "*/0 * * * *", `invalid step "0" in minute field`),
+		)
+	})
+
+	Describe("Next", func() {
+		at := func(value string) time.Time {
+			t, err := time.Parse(time.RFC3339, value)
+			Expect(err).ToNot(HaveOccurred())
+			return t
+		}
+
+		DescribeTable("finds the next matching time",
+			func(expr string, after string, next string) {
+				schedule, err := cron.Parse(expr)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(schedule.Next(at(after))).To(BeTemporally("==", at(next)))
+			},
+			Entry("every minute", "* * * * *", "2024-03-05T10:15:30Z", "2024-03-05T10:16:00Z"),
+			Entry("exactly on a match", "15 10 * * *", "2024-03-05T10:15:00Z", "2024-03-06T10:15:00Z"),
+			Entry("steps", "*/20 * * * *", "2024-03-05T10:41:00Z", "2024-03-05T11:00:00Z"),
+			Entry("ranges with steps", "0 9-17/4 * * *", "2024-03-05T13:00:00Z", "2024-03-05T17:00:00Z"),
+			Entry("lists", "0 0 1,15 * *", "2024-03-02T00:00:00Z", "2024-03-15T00:00:00Z"),
+			Entry("month names", "0 0 1 jun *", "2024-03-05T00:00:00Z", "2024-06-01T00:00:00Z"),
+			Entry("day names", "30 2 * * MON-FRI", "2024-03-09T00:00:00Z", "2024-03-11T02:30:00Z"),
+			Entry("sunday as 7", "0 0 * * 7", "2024-03-05T00:00:00Z", "2024-03-10T00:00:00Z"),
+			Entry("either restricted day", "0 0 13 * fri", "2024-09-01T00:00:00Z", "2024-09-06T00:00:00Z"),
+			Entry("descriptors", "@monthly", "2024-12-05T10:00:00Z", "2025-01-01T00:00:00Z"),
+			Entry("leap days", "0 0 29 2 *", "2024-03-01T00:00:00Z", "2028-02-29T00:00:00Z"),
+		)
+
+		It("returns the zero time when nothing matches", func() {
+			schedule, err := cron.Parse("0 0 30 2 *")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(schedule.Next(at("2024-01-01T00:00:00Z"))).To(BeZero())
+		})
+
+		Context("in a location with daylight saving time", func() {
+			var loc *time.Location
+
+			BeforeEach(func() {
+				var err error
+				loc, err = time.LoadLocation("America/New_York")
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("matches against the wall clock", func() {
+				schedule, err := cron.Parse("0 9 * * *")
+				Expect(err).ToNot(HaveOccurred())
+
+				next := schedule.Next(time.Date(2024, 3, 9, 12, 0, 0, 0, loc))
+				Expect(next).To(Equal(time.Date(2024, 3, 10, 9, 0, 0, 0, loc)))
+				Expect(next.UTC().Hour()).To(Equal(13))
+			})
+
+			It("only matches a repeated time once", func() {
+				schedule, err := cron.Parse("30 1 * * *")
+				Expect(err).ToNot(HaveOccurred())
+
+				first := schedule.Next(time.Date(2024, 11, 3, 0, 0, 0, 0, loc))
+				Expect(first).To(Equal(time.Date(2024, 11, 3, 1, 30, 0, 0, loc)))
+
+				second := schedule.Next(first.Add(time.Hour))
+				Expect(second).To(Equal(time.Date(2024, 11, 4, 1, 30, 0, 0, loc)))
+			})
+		})
+	})
+})
@@ -1,6 +1,7 @@
 package db
 
 import (
+	"context"
 	"crypto/sha256"
 	"database/sql"
 	"encoding/json"
@@ -25,6 +26,7 @@ import (
 	"github.com/concourse/concourse/atc/db/encryption"
 	"github.com/concourse/concourse/atc/db/lock"
 	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/atc/logarchive"
 	"github.com/concourse/concourse/atc/util"
 	"github.com/concourse/concourse/tracing"
 )
@@ -95,14 +97,18 @@ var buildsQuery = psql.Select(`
 		t.name,
 		b.nonce,
 		b.drained,
+		b.logs_archived,
 		b.aborted,
 		b.completed,
 		b.inputs_ready,
 		COALESCE(b.rerun_of, b.rerun_of_old) AS rerun_of,
 		rb.name,
 		b.rerun_number,
+		b.parent_build_id,
+		b.matrix_values,
 		b.span_context,
-		COALESCE(bc.comment, '')
+		COALESCE(bc.comment, ''),
+		COALESCE(j.priority, 0)
 	`).
 	From("builds b").
 	JoinClause("LEFT OUTER JOIN jobs j ON b.job_id = j.id").
@@ -139,6 +145,7 @@ type Build interface {
 
 	JobID() int
 	JobName() string
+	JobPriority() int
 
 	ResourceID() int
 	ResourceName() string
@@ -163,6 +170,8 @@ type Build interface {
 	RerunOf() int
 	RerunOfName() string
 	RerunNumber() int
+	ParentBuildID() int
+	MatrixValues() atc.MatrixValues
 	CreatedBy() *string
 
 	LagerData() lager.Data
@@ -191,22 +200,36 @@ type Build interface {
 	Preparation() (BuildPreparation, bool, error)
 
 	Start(atc.Plan) (bool, error)
+	StartMatrix([]atc.MatrixValues) (bool, error)
 	Finish(BuildStatus) error
 
 	Variables(lager.Logger, creds.Secrets, creds.VarSourcePool) (vars.Variables, error)
+	SaveResolvedVars([]vars.Reference) error
 
 	SetComment(string) error
 	SetInterceptible(bool) error
 
+	RequestApproval(planID atc.PlanID, name string, users []string, roles []string) error
+	Approval(atc.PlanID) (BuildApproval, bool, error)
+	Approvals() ([]BuildApproval, error)
+	DecideApproval(planID atc.PlanID, status atc.ApprovalStatus, decidedBy string) (bool, error)
+
 	Events(uint) (EventSource, error)
 	SaveEvent(event atc.Event) error
 
+	// ArchiveEvents moves the build's events out of the database and into the
+	// build log archive of the BuildFactory the build came from. Events
+	// continues to return them.
+	ArchiveEvents(context.Context) error
+	LogsArchived() bool
+
 	Artifacts() ([]WorkerArtifact, error)
 	Artifact(artifactID int) (WorkerArtifact, error)
 
 	SaveOutput(string, ResourceCache, atc.Source, atc.Version, ResourceConfigMetadataFields, string, string) error
 	AdoptInputsAndPipes() ([]BuildInput, bool, error)
 	AdoptRerunInputsAndPipes() ([]BuildInput, bool, error)
+	AdoptMatrixInputsAndPipes() ([]BuildInput, bool, error)
 
 	Resources() ([]BuildInput, []BuildOutput, error)
 	SaveImageResourceVersion(ResourceCache) error
@@ -250,8 +273,9 @@ type build struct {
 	teamName string
 	comment  string
 
-	jobID   int
-	jobName string
+	jobID       int
+	jobName     string
+	jobPriority int
 
 	resourceID   int
 	resourceName string
@@ -266,6 +290,9 @@ type build struct {
 	rerunOfName string
 	rerunNumber int
 
+	parentBuildID int
+	matrixValues  atc.MatrixValues
+
 	schema      string
 	privatePlan atc.Plan
 	publicPlan  *json.RawMessage
@@ -275,13 +302,18 @@ type build struct {
 	endTime    time.Time
 	reapTime   time.Time
 
-	drained   bool
-	aborted   bool
-	completed bool
+	drained      bool
+	logsArchived bool
+	aborted      bool
+	completed    bool
 
 	spanContext SpanContext
 
 	eventIdSeq util.SequenceGenerator
+
+	// archive is where the build's events are read from once they have been
+	// archived. It is only set on builds from a BuildFactory.
+	archive logarchive.Archive
 }
 
 func newEmptyBuild(conn DbConn, lockFactory lock.LockFactory) *build {
@@ -377,6 +409,7 @@ func (b *build) Name() string                     { return b.name }
 func (b *build) RunStateID() string               { return fmt.Sprintf("build:%v", b.id) }
 func (b *build) JobID() int                       { return b.jobID }
 func (b *build) JobName() string                  { return b.jobName }
+func (b *build) JobPriority() int                 { return b.jobPriority }
 func (b *build) ResourceID() int                  { return b.resourceID }
 func (b *build) ResourceName() string             { return b.resourceName }
 func (b *build) ResourceTypeID() int              { return b.resourceTypeID }
@@ -396,6 +429,7 @@ func (b *build) Comment() string                  { return b.comment }
 func (b *build) Status() BuildStatus              { return b.status }
 func (b *build) IsScheduled() bool                { return b.scheduled }
 func (b *build) IsDrained() bool                  { return b.drained }
+func (b *build) LogsArchived() bool               { return b.logsArchived }
 func (b *build) IsRunning() bool                  { return !b.completed }
 func (b *build) IsAborted() bool                  { return b.aborted }
 func (b *build) IsCompleted() bool                { return b.completed }
@@ -403,6 +437,8 @@ func (b *build) InputsReady() bool                { return b.inputsReady }
 func (b *build) RerunOf() int                     { return b.rerunOf }
 func (b *build) RerunOfName() string              { return b.rerunOfName }
 func (b *build) RerunNumber() int                 { return b.rerunNumber }
+func (b *build) ParentBuildID() int               { return b.parentBuildID }
+func (b *build) MatrixValues() atc.MatrixValues   { return b.matrixValues }
 func (b *build) CreatedBy() *string               { return b.createdBy }
 
 func (b *build) isNewerThanLastCheckOf(input Resource) bool {
@@ -645,6 +681,88 @@ func (b *build) start(tx Tx, plan atc.Plan) (bool, error) {
 	return true, nil
 }
 
+// StartMatrix starts the build as the parent of a pending child build for
+// each of the given combinations of its job's matrix. The parent has no plan
+// of its own and is finished with the aggregated status of its children once
+// they have all completed.
+func (b *build) StartMatrix(combinations []atc.MatrixValues) (bool, error) {
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	defer Rollback(tx)
+
+	var startTime time.Time
+	err = psql.Update("builds").
+		Set("status", BuildStatusStarted).
+		Set("start_time", sq.Expr("now()")).
+		Where(sq.Eq{
+			"id":      b.id,
+			"status":  "pending",
+			"aborted": false,
+		}).
+		Suffix("RETURNING start_time").
+		RunWith(tx).
+		QueryRow().
+		Scan(&startTime)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, err
+	}
+
+	err = b.saveEvent(tx, event.Status{
+		Status: atc.StatusStarted,
+		Time:   startTime.Unix(),
+	})
+	if err != nil {
+		return false, err
+	}
+
+	for i, values := range combinations {
+		matrixValues, err := json.Marshal(values)
+		if err != nil {
+			return false, err
+		}
+
+		child := newEmptyBuild(b.conn, b.lockFactory)
+		err = createBuild(tx, child, map[string]any{
+			"name":            fmt.Sprintf("%s-%d", b.name, i+1),
+			"job_id":          b.jobID,
+			"pipeline_id":     b.pipelineID,
+			"team_id":         b.teamID,
+			"status":          BuildStatusPending,
+			"parent_build_id": b.id,
+			"matrix_values":   matrixValues,
+			"created_by":      b.createdBy,
+			// the matrix notifies once, through its parent build
+			"notified": true,
+		})
+		if err != nil {
+			return false, err
+		}
+	}
+
+	err = requestSchedule(tx, b.jobID)
+	if err != nil {
+		return false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	err = b.conn.Bus().Notify(buildEventsChannel(b.id))
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
 func (b *build) Finish(status BuildStatus) error {
 	tx, err := b.conn.Begin()
 	if err != nil {
@@ -653,9 +771,35 @@ func (b *build) Finish(status BuildStatus) error {
 
 	defer Rollback(tx)
 
+	err = b.finish(tx, status)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	err = b.conn.Bus().Notify(buildEventsChannel(b.id))
+	if err != nil {
+		return err
+	}
+
+	if b.parentBuildID != 0 {
+		err = b.conn.Bus().Notify(buildEventsChannel(b.parentBuildID))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (b *build) finish(tx Tx, status BuildStatus) error {
 	var endTime time.Time
 
-	err = psql.Update("builds").
+	err := psql.Update("builds").
 		Set("status", status).
 		Set("end_time", sq.Expr("now()")).
 		Set("completed", true).
@@ -678,7 +822,9 @@ func (b *build) Finish(status BuildStatus) error {
 		return err
 	}
 
-	if b.jobID != 0 && status == BuildStatusSucceeded {
+	// the parent of a matrix build records the outputs of its cells and
+	// updates the job once they have all finished
+	if b.jobID != 0 && b.parentBuildID == 0 && status == BuildStatusSucceeded {
 		_, err = psql.Delete("build_image_resource_caches").
 			Where(sq.And{
 				sq.Eq{
@@ -696,8 +842,9 @@ func (b *build) Finish(status BuildStatus) error {
 
 		rows, err := psql.Select("o.resource_id", "o.version_digest").
 			From("build_resource_config_version_outputs o").
-			Where(sq.Eq{
-				"o.build_id": b.id,
+			Where(sq.Or{
+				sq.Eq{"o.build_id": b.id},
+				sq.Expr("o.build_id IN (SELECT id FROM builds WHERE parent_build_id = ?)", b.id),
 			}).
 			RunWith(tx).
 			Query()
@@ -812,7 +959,7 @@ WITH RECURSIVE pipelines_to_archive AS (
 		}
 	}
 
-	if b.jobID != 0 {
+	if b.jobID != 0 && b.parentBuildID == 0 {
 		err = requestScheduleOnDownstreamJobs(tx, b.jobID)
 		if err != nil {
 			return err
@@ -839,17 +986,120 @@ WITH RECURSIVE pipelines_to_archive AS (
 		}
 	}
 
-	err = tx.Commit()
+	if b.parentBuildID != 0 {
+		return b.finishMatrixParent(tx)
+	}
+
+	return nil
+}
+
+// matrixStatusPrecedence orders the statuses of the cells of a matrix build
+// when aggregating them into the status of the parent.
+var matrixStatusPrecedence = map[BuildStatus]int{
+	BuildStatusSucceeded: 0,
+	BuildStatusFailed:    1,
+	BuildStatusErrored:   2,
+	BuildStatusAborted:   3,
+}
+
+// finishMatrixParent finishes the parent of a matrix build once the latest
+// attempt at each of its cells has completed. The parent is locked so that
+// cells finishing concurrently don't each see the other as still running.
+func (b *build) finishMatrixParent(tx Tx) error {
+	parent := newEmptyBuild(b.conn, b.lockFactory)
+	err := scanBuild(parent, buildsQuery.
+		Where(sq.Eq{"b.id": b.parentBuildID}).
+		Suffix("FOR UPDATE OF b").
+		RunWith(tx).
+		QueryRow(),
+		b.conn.EncryptionStrategy(),
+	)
 	if err != nil {
 		return err
 	}
 
-	err = b.conn.Bus().Notify(buildEventsChannel(b.id))
+	if parent.completed {
+		return nil
+	}
+
+	rows, err := tx.Query(`
+		SELECT DISTINCT ON (COALESCE(rerun_of, id)) status, completed
+		FROM builds
+		WHERE parent_build_id = $1
+		ORDER BY COALESCE(rerun_of, id), id DESC
+	`, b.parentBuildID)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	status := BuildStatusSucceeded
+	allCompleted := true
+	for rows.Next() {
+		var cellStatus string
+		var completed bool
+		err = rows.Scan(&cellStatus, &completed)
+		if err != nil {
+			Close(rows)
+			return err
+		}
+
+		if !completed {
+			allCompleted = false
+		}
+
+		if matrixStatusPrecedence[BuildStatus(cellStatus)] > matrixStatusPrecedence[status] {
+			status = BuildStatus(cellStatus)
+		}
+	}
+
+	err = rows.Close()
+	if err != nil {
+		return err
+	}
+
+	if !allCompleted {
+		return nil
+	}
+
+	return parent.finish(tx, status)
+}
+
+// reopenMatrix returns a finished matrix build to started when one of its
+// cells is rerun, so that it is finished again once the rerun completes.
+func (b *build) reopenMatrix(tx Tx) error {
+	if !b.completed {
+		return nil
+	}
+
+	var startTime time.Time
+	err := psql.Update("builds").
+		Set("status", BuildStatusStarted).
+		Set("completed", false).
+		Set("aborted", false).
+		Set("end_time", nil).
+		// notify again of the status the matrix ends up with
+		Set("notified", false).
+		Where(sq.Eq{"id": b.id}).
+		Suffix("RETURNING now()").
+		RunWith(tx).
+		QueryRow().
+		Scan(&startTime)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Delete("successful_build_outputs").
+		Where(sq.Eq{"build_id": b.id}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	return b.saveEvent(tx, event.Status{
+		Status: atc.StatusStarted,
+		Time:   startTime.Unix(),
+	})
 }
 
 // Variables creates variables for this build. If the build is a one-off build, it
@@ -861,6 +1111,7 @@ func (b *build) Variables(logger lager.Logger, globalSecrets creds.Secrets, varS
 		Pipeline:     b.pipelineName,
 		InstanceVars: b.pipelineInstanceVars,
 		Job:          b.jobName,
+		RunStateID:   b.RunStateID(),
 	}
 
 	// "fly execute" generated build will have no pipeline.
@@ -928,16 +1179,38 @@ func (b *build) MarkAsAborted() error {
 
 	defer Rollback(tx)
 
-	_, err = psql.Update("builds").
+	rows, err := psql.Update("builds").
 		Set("aborted", true).
-		Where(sq.Eq{"id": b.id}).
+		Where(sq.Or{
+			sq.Eq{"id": b.id},
+			// aborting a matrix build aborts each of its unfinished cells
+			sq.Eq{"parent_build_id": b.id, "completed": false},
+		}).
+		Suffix("RETURNING id").
 		RunWith(tx).
-		Exec()
+		Query()
+	if err != nil {
+		return err
+	}
+
+	var abortedIDs []int
+	for rows.Next() {
+		var id int
+		err = rows.Scan(&id)
+		if err != nil {
+			Close(rows)
+			return err
+		}
+
+		abortedIDs = append(abortedIDs, id)
+	}
+
+	err = rows.Close()
 	if err != nil {
 		return err
 	}
 
-	if b.status == BuildStatusPending {
+	if b.status == BuildStatusPending || len(abortedIDs) > 1 {
 		err = requestSchedule(tx, b.jobID)
 		if err != nil {
 			return err
@@ -949,7 +1222,14 @@ func (b *build) MarkAsAborted() error {
 		return err
 	}
 
-	return b.conn.Bus().Notify(buildAbortChannel(b.id))
+	for _, id := range abortedIDs {
+		err = b.conn.Bus().Notify(buildAbortChannel(id))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 // AbortNotifier returns a Notifier that can be watched for when the build
@@ -1036,6 +1316,7 @@ func (b *build) Preparation() (BuildPreparation, bool, error) {
 			PausedPipeline:      BuildPreparationStatusNotBlocking,
 			PausedJob:           BuildPreparationStatusNotBlocking,
 			MaxRunningBuilds:    BuildPreparationStatusNotBlocking,
+			ConcurrencyGroup:    BuildPreparationStatusNotBlocking,
 			Inputs:              map[string]BuildPreparationStatus{},
 			InputsSatisfied:     BuildPreparationStatusNotBlocking,
 			MissingInputReasons: MissingInputReasons{},
@@ -1043,20 +1324,21 @@ func (b *build) Preparation() (BuildPreparation, bool, error) {
 	}
 
 	var (
-		pausedPipeline     bool
-		pausedJob          bool
-		maxInFlightReached bool
-		pipelineID         int
-		jobName            string
+		pausedPipeline          bool
+		pausedJob               bool
+		maxInFlightReached      bool
+		concurrencyGroupReached bool
+		pipelineID              int
+		jobName                 string
 	)
-	err := psql.Select("p.paused, j.paused, j.max_in_flight_reached, j.pipeline_id, j.name").
+	err := psql.Select("p.paused, j.paused, j.max_in_flight_reached, j.concurrency_group_reached, j.pipeline_id, j.name").
 		From("builds b").
 		Join("jobs j ON b.job_id = j.id").
 		Join("pipelines p ON j.pipeline_id = p.id").
 		Where(sq.Eq{"b.id": b.id}).
 		RunWith(b.conn).
 		QueryRow().
-		Scan(&pausedPipeline, &pausedJob, &maxInFlightReached, &pipelineID, &jobName)
+		Scan(&pausedPipeline, &pausedJob, &maxInFlightReached, &concurrencyGroupReached, &pipelineID, &jobName)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return BuildPreparation{}, false, nil
@@ -1079,6 +1361,11 @@ func (b *build) Preparation() (BuildPreparation, bool, error) {
 		maxInFlightReachedStatus = BuildPreparationStatusBlocking
 	}
 
+	concurrencyGroupStatus := BuildPreparationStatusNotBlocking
+	if concurrencyGroupReached {
+		concurrencyGroupStatus = BuildPreparationStatusBlocking
+	}
+
 	tf := NewTeamFactory(b.conn, b.lockFactory)
 	t, found, err := tf.FindTeam(b.teamName)
 	if err != nil {
@@ -1180,6 +1467,7 @@ func (b *build) Preparation() (BuildPreparation, bool, error) {
 		PausedPipeline:      pausedPipelineStatus,
 		PausedJob:           pausedJobStatus,
 		MaxRunningBuilds:    maxInFlightReachedStatus,
+		ConcurrencyGroup:    concurrencyGroupStatus,
 		Inputs:              inputs,
 		InputsSatisfied:     inputsSatisfiedStatus,
 		MissingInputReasons: missingInputReasons,
@@ -1189,6 +1477,21 @@ func (b *build) Preparation() (BuildPreparation, bool, error) {
 }
 
 func (b *build) Events(from uint) (EventSource, error) {
+	var archived bool
+	err := psql.Select("logs_archived").
+		From("builds").
+		Where(sq.Eq{"id": b.id}).
+		RunWith(b.conn).
+		QueryRow().
+		Scan(&archived)
+	if err != nil {
+		return nil, err
+	}
+
+	if archived {
+		return newArchivedBuildEventSource(b.archive, b.id, from)
+	}
+
 	return newBuildEventSource(
 		b.id,
 		b.eventsTable(),
@@ -1552,6 +1855,16 @@ func (b *build) AdoptInputsAndPipes() ([]BuildInput, bool, error) {
 }
 
 func (b *build) AdoptRerunInputsAndPipes() ([]BuildInput, bool, error) {
+	return b.adoptInputsAndPipesOf(b.rerunOf)
+}
+
+// AdoptMatrixInputsAndPipes adopts the inputs and pipes of the parent of a
+// cell of a matrix build, so that every cell runs with the same versions.
+func (b *build) AdoptMatrixInputsAndPipes() ([]BuildInput, bool, error) {
+	return b.adoptInputsAndPipesOf(b.parentBuildID)
+}
+
+func (b *build) adoptInputsAndPipesOf(buildID int) ([]BuildInput, bool, error) {
 	tx, err := b.conn.Begin()
 	if err != nil {
 		return nil, false, err
@@ -1563,7 +1876,7 @@ func (b *build) AdoptRerunInputsAndPipes() ([]BuildInput, bool, error) {
 	err = psql.Select("inputs_ready").
 		From("builds").
 		Where(sq.Eq{
-			"id": b.rerunOf,
+			"id": buildID,
 		}).
 		RunWith(tx).
 		QueryRow().
@@ -1589,7 +1902,7 @@ func (b *build) AdoptRerunInputsAndPipes() ([]BuildInput, bool, error) {
 		Select(psql.Select("i.resource_id", "i.version_digest", "i.name", "false").
 			Column("?", b.id).
 			From("build_resource_config_version_inputs i").
-			Where(sq.Eq{"i.build_id": b.rerunOf})).
+			Where(sq.Eq{"i.build_id": buildID})).
 		Suffix("ON CONFLICT (build_id, resource_id, version_digest, name) DO NOTHING").
 		Suffix("RETURNING name, resource_id, version_digest, first_occurrence").
 		RunWith(tx).
@@ -1693,7 +2006,7 @@ func (b *build) AdoptRerunInputsAndPipes() ([]BuildInput, bool, error) {
 		Select(psql.Select("bp.from_build_id").
 			Column("?", b.id).
 			From("build_pipes bp").
-			Where(sq.Eq{"bp.to_build_id": b.rerunOf})).
+			Where(sq.Eq{"bp.to_build_id": buildID})).
 		Suffix("ON CONFLICT DO NOTHING").
 		RunWith(tx).
 		Exec()
@@ -1857,7 +2170,12 @@ func (b *build) SavePipeline(
 
 	jobID := newNullInt64(b.jobID)
 	buildID := newNullInt64(b.id)
-	pipelineID, isNewPipeline, err := savePipeline(tx, pipelineRef, config, from, initiallyPaused, teamID, jobID, buildID)
+	var savedBy sql.NullString
+	if b.createdBy != nil {
+		savedBy = sql.NullString{String: *b.createdBy, Valid: true}
+	}
+
+	pipelineID, isNewPipeline, err := savePipeline(tx, pipelineRef, config, from, initiallyPaused, teamID, jobID, buildID, savedBy)
 	if err != nil {
 		return nil, false, err
 	}
@@ -1905,13 +2223,13 @@ func newNullInt64(i int) sql.NullInt64 {
 
 func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy) error {
 	var (
-		jobID, resourceID, resourceTypeID, pipelineID, rerunOf, rerunNumber               sql.NullInt64
-		schema, privatePlan, jobName, resourceName, pipelineName, publicPlan, rerunOfName sql.NullString
-		createTime, startTime, endTime, reapTime                                          sql.NullTime
-		nonce, spanContext, createdBy                                                     sql.NullString
-		drained, aborted, completed                                                       bool
-		status                                                                            string
-		pipelineInstanceVars, comment                                                     sql.NullString
+		jobID, resourceID, resourceTypeID, pipelineID, rerunOf, rerunNumber, parentBuildID sql.NullInt64
+		schema, privatePlan, jobName, resourceName, pipelineName, publicPlan, rerunOfName  sql.NullString
+		createTime, startTime, endTime, reapTime                                           sql.NullTime
+		nonce, spanContext, createdBy, matrixValues                                        sql.NullString
+		drained, logsArchived, aborted, completed                                          bool
+		status                                                                             string
+		pipelineInstanceVars, comment                                                      sql.NullString
 	)
 
 	err := row.Scan(
@@ -1940,14 +2258,18 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 		&b.teamName,
 		&nonce,
 		&drained,
+		&logsArchived,
 		&aborted,
 		&completed,
 		&b.inputsReady,
 		&rerunOf,
 		&rerunOfName,
 		&rerunNumber,
+		&parentBuildID,
+		&matrixValues,
 		&spanContext,
 		&comment,
+		&b.jobPriority,
 	)
 	if err != nil {
 		return err
@@ -1967,11 +2289,13 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 	b.endTime = endTime.Time
 	b.reapTime = reapTime.Time
 	b.drained = drained
+	b.logsArchived = logsArchived
 	b.aborted = aborted
 	b.completed = completed
 	b.rerunOf = int(rerunOf.Int64)
 	b.rerunOfName = rerunOfName.String
 	b.rerunNumber = int(rerunNumber.Int64)
+	b.parentBuildID = int(parentBuildID.Int64)
 	b.comment = comment.String
 
 	var (
@@ -2017,6 +2341,13 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 		}
 	}
 
+	if matrixValues.Valid {
+		err = json.Unmarshal([]byte(matrixValues.String), &b.matrixValues)
+		if err != nil {
+			return err
+		}
+	}
+
 	if createdBy.Valid {
 		b.createdBy = &createdBy.String
 	}
@@ -2165,6 +2496,7 @@ func latestCompletedNonRerunBuild(tx Tx, jobID int) (int, error) {
 		Where(sq.And{
 			sq.Eq{"rerun_of": nil},
 			sq.Eq{"rerun_of_old": nil},
+			sq.Eq{"parent_build_id": nil},
 		}).
 		RunWith(tx).
 		QueryRow().
@@ -2185,6 +2517,7 @@ func updateNextBuildForJob(tx Tx, jobID int, latestNonRerunId int) error {
 			INNER JOIN jobs j ON j.id = b.job_id
 			WHERE b.job_id = $1
 			AND b.status IN ('pending', 'started')
+			AND b.parent_build_id IS NULL
 			AND (
 				(b.rerun_of IS NULL AND b.rerun_of_old IS NULL) OR
 				(b.rerun_of = $2 OR b.rerun_of_old = $2)
@@ -0,0 +1,185 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+)
+
+type BuildApproval struct {
+	BuildID   int
+	PlanID    atc.PlanID
+	Name      string
+	Status    atc.ApprovalStatus
+	Users     []string
+	Roles     []string
+	DecidedBy string
+	CreatedAt time.Time
+	DecidedAt time.Time
+}
+
+var buildApprovalsQuery = psql.Select(
+	"a.build_id",
+	"a.plan_id",
+	"a.name",
+	"a.status",
+	"a.users",
+	"a.roles",
+	"a.decided_by",
+	"a.created_at",
+	"a.decided_at",
+).
+	From("build_approvals a")
+
+// RequestApproval records a pending approval for the approve step with the
+// given plan ID. Requesting an approval which already exists, e.g. when the
+// build is resumed after a restart, leaves the existing approval untouched.
+func (b *build) RequestApproval(planID atc.PlanID, name string, users []string, roles []string) error {
+	if users == nil {
+		users = []string{}
+	}
+
+	if roles == nil {
+		roles = []string{}
+	}
+
+	usersJSON, err := json.Marshal(users)
+	if err != nil {
+		return err
+	}
+
+	rolesJSON, err := json.Marshal(roles)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Insert("build_approvals").
+		Columns("build_id", "plan_id", "name", "users", "roles").
+		Values(b.id, string(planID), name, usersJSON, rolesJSON).
+		Suffix("ON CONFLICT (build_id, plan_id) DO NOTHING").
+		RunWith(b.conn).
+		Exec()
+	return err
+}
+
+func (b *build) Approval(planID atc.PlanID) (BuildApproval, bool, error) {
+	row := buildApprovalsQuery.
+		Where(sq.Eq{
+			"a.build_id": b.id,
+			"a.plan_id":  string(planID),
+		}).
+		RunWith(b.conn).
+		QueryRow()
+
+	approval, err := scanBuildApproval(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return BuildApproval{}, false, nil
+		}
+
+		return BuildApproval{}, false, err
+	}
+
+	return approval, true, nil
+}
+
+func (b *build) Approvals() ([]BuildApproval, error) {
+	rows, err := buildApprovalsQuery.
+		Where(sq.Eq{"a.build_id": b.id}).
+		OrderBy("a.created_at ASC").
+		RunWith(b.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	approvals := []BuildApproval{}
+	for rows.Next() {
+		approval, err := scanBuildApproval(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		approvals = append(approvals, approval)
+	}
+
+	return approvals, nil
+}
+
+// DecideApproval moves a pending approval to the given status. It returns
+// false if the approval does not exist or has already been decided.
+func (b *build) DecideApproval(planID atc.PlanID, status atc.ApprovalStatus, decidedBy string) (bool, error) {
+	var decider sql.NullString
+	if decidedBy != "" {
+		decider = sql.NullString{String: decidedBy, Valid: true}
+	}
+
+	result, err := psql.Update("build_approvals").
+		Set("status", status).
+		Set("decided_by", decider).
+		Set("decided_at", sq.Expr("now()")).
+		Where(sq.Eq{
+			"build_id": b.id,
+			"plan_id":  string(planID),
+			"status":   atc.ApprovalPending,
+		}).
+		RunWith(b.conn).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected == 1, nil
+}
+
+func scanBuildApproval(row scannable) (BuildApproval, error) {
+	var (
+		approval  BuildApproval
+		planID    string
+		usersJSON []byte
+		rolesJSON []byte
+		decidedBy sql.NullString
+		decidedAt sql.NullTime
+	)
+
+	err := row.Scan(
+		&approval.BuildID,
+		&planID,
+		&approval.Name,
+		&approval.Status,
+		&usersJSON,
+		&rolesJSON,
+		&decidedBy,
+		&approval.CreatedAt,
+		&decidedAt,
+	)
+	if err != nil {
+		return BuildApproval{}, err
+	}
+
+	err = json.Unmarshal(usersJSON, &approval.Users)
+	if err != nil {
+		return BuildApproval{}, err
+	}
+
+	err = json.Unmarshal(rolesJSON, &approval.Roles)
+	if err != nil {
+		return BuildApproval{}, err
+	}
+
+	approval.PlanID = atc.PlanID(planID)
+	approval.DecidedBy = decidedBy.String
+	approval.DecidedAt = decidedAt.Time
+
+	return approval, nil
+}
@@ -1,6 +1,7 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
@@ -9,7 +10,9 @@ import (
 	"code.cloudfoundry.org/lager/v3"
 
 	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db/lock"
+	"github.com/concourse/concourse/atc/logarchive"
 )
 
 //counterfeiter:generate . BuildForAPI
@@ -51,6 +54,8 @@ type BuildForAPI interface {
 	RerunOf() int
 	RerunOfName() string
 	RerunNumber() int
+	ParentBuildID() int
+	MatrixValues() atc.MatrixValues
 	CreatedBy() *string
 
 	IsDrained() bool
@@ -64,6 +69,10 @@ type BuildForAPI interface {
 	MarkAsAborted() error
 	Finish(BuildStatus) error
 	SetComment(string) error
+
+	Approval(atc.PlanID) (BuildApproval, bool, error)
+	Approvals() ([]BuildApproval, error)
+	DecideApproval(planID atc.PlanID, status atc.ApprovalStatus, decidedBy string) (bool, error)
 }
 
 //counterfeiter:generate . BuildFactory
@@ -77,6 +86,16 @@ type BuildFactory interface {
 	GetAllStartedBuilds() ([]Build, error)
 	GetDrainableBuilds() ([]Build, error)
 
+	// GetArchivableBuilds returns the oldest completed builds which ended
+	// before the given time and whose events have neither been archived nor
+	// reaped, optionally only those which have been drained.
+	GetArchivableBuilds(endedBefore time.Time, onlyDrained bool, limit int) ([]Build, error)
+
+	// DeleteUnusedArchivedBuildEvents removes the archived events of builds
+	// which have since been reaped or deleted, and returns how many it
+	// removed.
+	DeleteUnusedArchivedBuildEvents(ctx context.Context) (int, error)
+
 	// TODO: move to BuildLifecycle, new interface (see WorkerLifecycle)
 	MarkNonInterceptibleBuilds() error
 }
@@ -86,19 +105,30 @@ type buildFactory struct {
 	lockFactory       lock.LockFactory
 	oneOffGracePeriod time.Duration
 	failedGracePeriod time.Duration
+	archive           logarchive.Archive
 }
 
-func NewBuildFactory(conn DbConn, lockFactory lock.LockFactory, oneOffGracePeriod time.Duration, failedGracePeriod time.Duration) BuildFactory {
+// NewBuildFactory returns a BuildFactory whose builds archive their events to,
+// and read archived events back from, the given archive. The archive may be
+// nil if build logs are not archived.
+func NewBuildFactory(conn DbConn, lockFactory lock.LockFactory, oneOffGracePeriod time.Duration, failedGracePeriod time.Duration, archive logarchive.Archive) BuildFactory {
 	return &buildFactory{
 		conn:              conn,
 		lockFactory:       lockFactory,
 		oneOffGracePeriod: oneOffGracePeriod,
 		failedGracePeriod: failedGracePeriod,
+		archive:           archive,
 	}
 }
 
-func (f *buildFactory) BuildForAPI(buildID int) (BuildForAPI, bool, error) {
+func (f *buildFactory) newBuild() *build {
 	build := newEmptyBuild(f.conn, f.lockFactory)
+	build.archive = f.archive
+	return build
+}
+
+func (f *buildFactory) BuildForAPI(buildID int) (BuildForAPI, bool, error) {
+	build := f.newBuild()
 	row := buildsQuery.
 		Where(sq.Eq{"b.id": buildID}).
 		RunWith(f.conn).
@@ -134,7 +164,7 @@ func (f *buildFactory) BuildForAPI(buildID int) (BuildForAPI, bool, error) {
 }
 
 func (f *buildFactory) Build(buildID int) (Build, bool, error) {
-	build := newEmptyBuild(f.conn, f.lockFactory)
+	build := f.newBuild()
 	row := buildsQuery.
 		Where(sq.Eq{"b.id": buildID}).
 		RunWith(f.conn).
@@ -216,15 +246,38 @@ func (f *buildFactory) GetDrainableBuilds() ([]Build, error) {
 			"b.resource_type_id": nil,
 		})
 
-	return getBuilds(query, f.conn, f.lockFactory)
+	return getBuilds(query, f.conn, f.lockFactory, f.archive)
+}
+
+func (f *buildFactory) GetArchivableBuilds(endedBefore time.Time, onlyDrained bool, limit int) ([]Build, error) {
+	query := buildsQuery.Where(
+		sq.Eq{
+			"b.completed":        true,
+			"b.logs_archived":    false,
+			"b.reap_time":        nil,
+			"b.resource_id":      nil,
+			"b.resource_type_id": nil,
+		}).
+		Where(sq.Lt{"b.end_time": endedBefore}).
+		OrderBy("b.end_time ASC").
+		Limit(uint64(limit))
+
+	if onlyDrained {
+		query = query.Where(sq.Eq{"b.drained": true})
+	}
+
+	return getBuilds(query, f.conn, f.lockFactory, f.archive)
 }
 
 func (f *buildFactory) GetAllStartedBuilds() ([]Build, error) {
 	query := buildsQuery.Where(sq.Eq{
 		"b.status": BuildStatusStarted,
-	})
+	}).
+		// the parent of a matrix build has no plan to run; it is finished by
+		// its cells
+		Where(sq.Expr("NOT EXISTS (SELECT 1 FROM builds c WHERE c.parent_build_id = b.id)"))
 
-	return getBuilds(query, f.conn, f.lockFactory)
+	return getBuilds(query, f.conn, f.lockFactory, f.archive)
 }
 
 func (f *buildFactory) findResourceOfInMemoryCheckBuild(buildId int) (Resource, bool, error) {
@@ -247,7 +300,7 @@ func (f *buildFactory) findResourceOfInMemoryCheckBuild(buildId int) (Resource,
 	return resource, true, nil
 }
 
-func getBuilds(buildsQuery sq.SelectBuilder, conn DbConn, lockFactory lock.LockFactory) ([]Build, error) {
+func getBuilds(buildsQuery sq.SelectBuilder, conn DbConn, lockFactory lock.LockFactory, archive logarchive.Archive) ([]Build, error) {
 	rows, err := buildsQuery.RunWith(conn).Query()
 	if err != nil {
 		return nil, err
@@ -259,6 +312,7 @@ func getBuilds(buildsQuery sq.SelectBuilder, conn DbConn, lockFactory lock.LockF
 
 	for rows.Next() {
 		b := newEmptyBuild(conn, lockFactory)
+		b.archive = archive
 		err := scanBuild(b, rows, conn.EncryptionStrategy())
 		if err != nil {
 			return nil, err
@@ -1,6 +1,7 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"errors"
@@ -83,6 +84,9 @@ func (b *inMemoryCheckBuildForApi) JobID() int { return 0 }
 // JobName returns an empty string because check build doesn't belong to any job.
 func (b *inMemoryCheckBuildForApi) JobName() string { return "" }
 
+// JobPriority returns 0 because check build doesn't belong to any job.
+func (b *inMemoryCheckBuildForApi) JobPriority() int { return 0 }
+
 func (b *inMemoryCheckBuildForApi) LagerData() lager.Data {
 	data := lager.Data{
 		"build":    b.Name(),
@@ -227,7 +231,11 @@ func (b *inMemoryCheckBuildForApi) Events(from uint) (EventSource, error) {
 func (b *inMemoryCheckBuildForApi) RerunOf() int        { return 0 }
 func (b *inMemoryCheckBuildForApi) RerunOfName() string { return "" }
 func (b *inMemoryCheckBuildForApi) RerunNumber() int    { return 0 }
+func (b *inMemoryCheckBuildForApi) ParentBuildID() int  { return 0 }
 func (b *inMemoryCheckBuildForApi) ReapTime() time.Time { return time.Time{} }
+func (b *inMemoryCheckBuildForApi) MatrixValues() atc.MatrixValues {
+	return nil
+}
 func (b *inMemoryCheckBuildForApi) Job() (Job, bool, error) {
 	return nil, false, errors.New("not implemented for in memory build")
 }
@@ -253,6 +261,18 @@ func (b *inMemoryCheckBuildForApi) SetComment(string) error {
 	return errors.New("not implemented for in memory build")
 }
 
+func (b *inMemoryCheckBuildForApi) Approval(atc.PlanID) (BuildApproval, bool, error) {
+	return BuildApproval{}, false, nil
+}
+
+func (b *inMemoryCheckBuildForApi) Approvals() ([]BuildApproval, error) {
+	return []BuildApproval{}, nil
+}
+
+func (b *inMemoryCheckBuildForApi) DecideApproval(atc.PlanID, atc.ApprovalStatus, string) (bool, error) {
+	return false, errors.New("not implemented for in memory build")
+}
+
 var _ Build = (*inMemoryCheckBuild)(nil)
 
 // inMemoryCheckBuild implements db.Build. It handles in-memory check builds
@@ -519,11 +539,17 @@ func (b *inMemoryCheckBuild) Variables(logger lager.Logger, secrets creds.Secret
 		Pipeline:     b.PipelineName(),
 		InstanceVars: b.PipelineInstanceVars(),
 		Job:          b.JobName(),
+		RunStateID:   b.RunStateID(),
 	}
 
 	return pipeline.Variables(logger, secrets, varSourcePool, context)
 }
 
+// vars resolved by checks are recorded through their resources' configs
+func (b *inMemoryCheckBuild) SaveResolvedVars([]vars.Reference) error {
+	return nil
+}
+
 func (b *inMemoryCheckBuild) SaveEvent(ev atc.Event) error {
 	if !b.runningInContainer {
 		b.cacheEvents = append(b.cacheEvents, ev)
@@ -647,6 +673,10 @@ func (b *inMemoryCheckBuild) InputsReady() bool     { return false }
 func (b *inMemoryCheckBuild) SetDrained(bool) error {
 	return errors.New("not implemented for in memory build")
 }
+func (b *inMemoryCheckBuild) LogsArchived() bool { return false }
+func (b *inMemoryCheckBuild) ArchiveEvents(context.Context) error {
+	return errors.New("not implemented for in memory build")
+}
 func (b *inMemoryCheckBuild) Delete() (bool, error) {
 	return false, errors.New("not implemented for in memory build")
 }
@@ -658,12 +688,31 @@ func (b *inMemoryCheckBuild) SetInterceptible(bool) error {
 	return errors.New("not implemented for in memory build")
 }
 
+func (b *inMemoryCheckBuild) RequestApproval(atc.PlanID, string, []string, []string) error {
+	return errors.New("not implemented for in memory build")
+}
+
+func (b *inMemoryCheckBuild) Approval(atc.PlanID) (BuildApproval, bool, error) {
+	return BuildApproval{}, false, nil
+}
+
+func (b *inMemoryCheckBuild) Approvals() ([]BuildApproval, error) {
+	return []BuildApproval{}, nil
+}
+
+func (b *inMemoryCheckBuild) DecideApproval(atc.PlanID, atc.ApprovalStatus, string) (bool, error) {
+	return false, errors.New("not implemented for in memory build")
+}
+
 func (b *inMemoryCheckBuild) Artifact(int) (WorkerArtifact, error) {
 	return nil, errors.New("not implemented for in memory build")
 }
 func (b *inMemoryCheckBuild) Start(atc.Plan) (bool, error) {
 	return false, errors.New("not implemented for in memory build")
 }
+func (b *inMemoryCheckBuild) StartMatrix([]atc.MatrixValues) (bool, error) {
+	return false, errors.New("not implemented for in memory build")
+}
 func (b *inMemoryCheckBuild) ResourcesChecked() (bool, error) {
 	return false, errors.New("not implemented for in memory build")
 }
@@ -679,6 +728,9 @@ func (b *inMemoryCheckBuild) AdoptInputsAndPipes() ([]BuildInput, bool, error) {
 func (b *inMemoryCheckBuild) AdoptRerunInputsAndPipes() ([]BuildInput, bool, error) {
 	return nil, false, errors.New("not implemented for in memory build")
 }
+func (b *inMemoryCheckBuild) AdoptMatrixInputsAndPipes() ([]BuildInput, bool, error) {
+	return nil, false, errors.New("not implemented for in memory build")
+}
 func (b *inMemoryCheckBuild) SaveOutput(string, ResourceCache, atc.Source, atc.Version, ResourceConfigMetadataFields, string, string) error {
 	return errors.New("not implemented for in memory build")
 }
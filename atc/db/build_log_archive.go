@@ -0,0 +1,318 @@
+package db
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"sync"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/atc/logarchive"
+)
+
+var ErrNoBuildLogArchive = errors.New("no build log archive configured")
+
+func buildLogArchiveKey(buildID int) string {
+	return fmt.Sprintf("builds/%d.json.gz", buildID)
+}
+
+// ArchiveEvents writes the build's events to the archive as gzipped JSON
+// envelopes, one per line, and only then removes them from the database. The
+// events are streamed into the archive as they are read, so that a build with
+// a lot of output isn't held in memory.
+func (b *build) ArchiveEvents(ctx context.Context) error {
+	if b.archive == nil {
+		return ErrNoBuildLogArchive
+	}
+
+	reader, writer := io.Pipe()
+	go func() {
+		writer.CloseWithError(b.writeArchivedEvents(writer))
+	}()
+
+	err := b.archive.Store(ctx, buildLogArchiveKey(b.id), reader)
+
+	// unblock the writer if the archive gave up before reading everything
+	_ = reader.CloseWithError(io.ErrClosedPipe)
+
+	if err != nil {
+		return fmt.Errorf("store events: %w", err)
+	}
+
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	_, err = psql.Update("builds").
+		Set("logs_archived", true).
+		Where(sq.Eq{"id": b.id}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Delete(b.eventsTable()).
+		Where(b.eventsWhere()).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	b.logsArchived = true
+
+	return nil
+}
+
+func (b *build) writeArchivedEvents(w io.Writer) error {
+	rows, err := psql.Select("event_id", "type", "version", "payload").
+		From(b.eventsTable()).
+		Where(b.eventsWhere()).
+		OrderBy("event_id ASC").
+		RunWith(b.conn).
+		Query()
+	if err != nil {
+		return err
+	}
+
+	defer Close(rows)
+
+	gz := gzip.NewWriter(w)
+	encoder := json.NewEncoder(gz)
+
+	for rows.Next() {
+		var (
+			eventID int
+			t, v, p string
+		)
+
+		err = rows.Scan(&eventID, &t, &v, &p)
+		if err != nil {
+			return err
+		}
+
+		data := json.RawMessage(p)
+		err = encoder.Encode(event.Envelope{
+			Data:    &data,
+			Event:   atc.EventType(t),
+			Version: atc.EventVersion(v),
+			EventID: strconv.Itoa(eventID),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return err
+	}
+
+	return gz.Close()
+}
+
+// builds created before the switch to bigint ids may have their events
+// stored under build_id_old
+func (b *build) eventsWhere() sq.Sqlizer {
+	if b.id > math.MaxInt32 {
+		return sq.Eq{"build_id": b.id}
+	}
+
+	return sq.Or{
+		sq.Eq{"build_id": b.id},
+		sq.Eq{"build_id_old": b.id},
+	}
+}
+
+// queueArchivedBuildEventsForDeletion records that the archived events of
+// whichever of the given builds have been archived are no longer needed, so
+// that they are removed from the archive by DeleteUnusedArchivedBuildEvents.
+// The builds are no longer considered archived. Builds which are deleted
+// outright are queued by the deleted_archived_build_events_insert_trigger.
+func queueArchivedBuildEventsForDeletion(tx Tx, buildIDs []int) error {
+	_, err := psql.Insert("deleted_archived_build_events").
+		Columns("build_id").
+		Select(psql.Select("id").
+			From("builds").
+			Where(sq.Eq{
+				"id":            buildIDs,
+				"logs_archived": true,
+			})).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Update("builds").
+		Set("logs_archived", false).
+		Where(sq.Eq{
+			"id":            buildIDs,
+			"logs_archived": true,
+		}).
+		RunWith(tx).
+		Exec()
+	return err
+}
+
+// DeleteUnusedArchivedBuildEvents removes the archived events of builds which
+// have since been reaped or deleted, along with their pipeline or team.
+func (f *buildFactory) DeleteUnusedArchivedBuildEvents(ctx context.Context) (int, error) {
+	if f.archive == nil {
+		return 0, ErrNoBuildLogArchive
+	}
+
+	rows, err := psql.Select("build_id").
+		From("deleted_archived_build_events").
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return 0, err
+	}
+
+	defer Close(rows)
+
+	var buildIDs []int
+	for rows.Next() {
+		var id int
+		err = rows.Scan(&id)
+		if err != nil {
+			return 0, err
+		}
+
+		buildIDs = append(buildIDs, id)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	for _, id := range buildIDs {
+		err = f.archive.Delete(ctx, buildLogArchiveKey(id))
+		if err != nil {
+			return deleted, fmt.Errorf("delete archived events of build %d: %w", id, err)
+		}
+
+		_, err = psql.Delete("deleted_archived_build_events").
+			Where(sq.Eq{"build_id": id}).
+			RunWith(f.conn).
+			Exec()
+		if err != nil {
+			return deleted, err
+		}
+
+		deleted++
+	}
+
+	return deleted, nil
+}
+
+type archivedBuildEventSource struct {
+	from uint
+
+	reader  io.ReadCloser
+	gz      *gzip.Reader
+	decoder *json.Decoder
+
+	closed bool
+	lock   sync.Mutex
+}
+
+func newArchivedBuildEventSource(archive logarchive.Archive, buildID int, from uint) (EventSource, error) {
+	if archive == nil {
+		return nil, ErrNoBuildLogArchive
+	}
+
+	reader, err := archive.Retrieve(context.TODO(), buildLogArchiveKey(buildID))
+	if err != nil {
+		if errors.Is(err, logarchive.ErrNotFound) {
+			// the archived events have been reaped, just like the events of a
+			// reaped build in the database
+			return &archivedBuildEventSource{}, nil
+		}
+
+		return nil, err
+	}
+
+	gz, err := gzip.NewReader(reader)
+	if err != nil {
+		_ = reader.Close()
+		return nil, err
+	}
+
+	return &archivedBuildEventSource{
+		from:    from,
+		reader:  reader,
+		gz:      gz,
+		decoder: json.NewDecoder(gz),
+	}, nil
+}
+
+func (source *archivedBuildEventSource) Next() (event.Envelope, error) {
+	source.lock.Lock()
+	defer source.lock.Unlock()
+
+	if source.closed {
+		return event.Envelope{}, ErrBuildEventStreamClosed
+	}
+
+	if source.decoder == nil {
+		return event.Envelope{}, ErrEndOfBuildEventStream
+	}
+
+	for {
+		var envelope event.Envelope
+		err := source.decoder.Decode(&envelope)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return event.Envelope{}, ErrEndOfBuildEventStream
+			}
+
+			return event.Envelope{}, err
+		}
+
+		eventID, err := strconv.Atoi(envelope.EventID)
+		if err != nil {
+			return event.Envelope{}, err
+		}
+
+		if eventID < int(source.from) {
+			continue
+		}
+
+		return envelope, nil
+	}
+}
+
+func (source *archivedBuildEventSource) Close() error {
+	source.lock.Lock()
+	defer source.lock.Unlock()
+
+	if source.closed || source.reader == nil {
+		source.closed = true
+		return nil
+	}
+
+	source.closed = true
+
+	_ = source.gz.Close()
+	return source.reader.Close()
+}
@@ -0,0 +1,319 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/event"
+)
+
+// MaxBuildLogSearchBuilds bounds the number of builds whose logs a single
+// search will scan, so that a broad search can't read through the whole of a
+// pipeline's history in one go.
+var MaxBuildLogSearchBuilds = 100
+
+// BuildLogSearch describes a search through the logs of a pipeline's builds.
+type BuildLogSearch struct {
+	Pattern *regexp.Regexp
+
+	// Jobs restricts the search to the builds of the given jobs. The builds of
+	// every job are searched if it is empty.
+	Jobs []string
+
+	// From and To restrict the search to builds which started within the
+	// range. Either may be zero to leave that end of the range open.
+	From time.Time
+	To   time.Time
+
+	// Limit is the maximum number of matches to return.
+	Limit int
+}
+
+// BuildLogSearchResult is the outcome of a BuildLogSearch.
+type BuildLogSearchResult struct {
+	Matches []BuildLogMatch
+
+	// Truncated is set if the search stopped at its limit, or if there were
+	// more builds in range than a single search will scan.
+	Truncated bool
+
+	// ArchivedBuilds is the number of builds in range which were not
+	// searched because their logs have been moved to the build log archive.
+	ArchivedBuilds int
+}
+
+type BuildLogMatch struct {
+	BuildID   int
+	BuildName string
+	JobName   string
+	PlanID    atc.PlanID
+	StepName  string
+	Line      int
+	Text      string
+	Time      time.Time
+}
+
+// the step types whose public plans carry the name of the step
+var namedStepTypes = []string{
+	"get",
+	"put",
+	"task",
+	"run",
+	"check",
+	"set_pipeline",
+	"load_var",
+	"approve",
+}
+
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-9;]*[a-zA-Z]`)
+
+type searchedBuild struct {
+	id           int
+	name         string
+	jobName      string
+	publicPlan   sql.NullString
+	logsArchived bool
+}
+
+// SearchBuildLogs scans the log events of the pipeline's most recent job
+// builds, oldest first, and returns the lines matching the search. Lines are
+// numbered per step, as they are shown in the UI. At most
+// MaxBuildLogSearchBuilds builds are scanned, each through the events table's
+// (build_id, event_id) index; the result is truncated if the search was cut
+// short by this or by the search's limit.
+//
+// Only the stored log events are searched, so any credentials which were
+// redacted from a build's output can't be found. Builds whose logs have been
+// archived are not searched, but are counted in the result so that a search
+// which found nothing in them isn't mistaken for one which found nothing at
+// all.
+func (p *pipeline) SearchBuildLogs(search BuildLogSearch) (BuildLogSearchResult, error) {
+	query := psql.Select("b.id", "b.name", "j.name", "b.public_plan", "b.logs_archived").
+		From("builds b").
+		Join("jobs j ON j.id = b.job_id").
+		Where(sq.Eq{"b.pipeline_id": p.id}).
+		Where(sq.NotEq{"b.start_time": nil}).
+		OrderBy("b.id DESC").
+		Limit(uint64(MaxBuildLogSearchBuilds + 1))
+
+	if len(search.Jobs) > 0 {
+		query = query.Where(sq.Eq{"j.name": search.Jobs})
+	}
+
+	if !search.From.IsZero() {
+		query = query.Where(sq.GtOrEq{"b.start_time": search.From})
+	}
+
+	if !search.To.IsZero() {
+		query = query.Where(sq.LtOrEq{"b.start_time": search.To})
+	}
+
+	rows, err := query.RunWith(p.conn).Query()
+	if err != nil {
+		return BuildLogSearchResult{}, err
+	}
+
+	defer Close(rows)
+
+	var builds []searchedBuild
+	for rows.Next() {
+		var build searchedBuild
+		err = rows.Scan(&build.id, &build.name, &build.jobName, &build.publicPlan, &build.logsArchived)
+		if err != nil {
+			return BuildLogSearchResult{}, err
+		}
+
+		builds = append(builds, build)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return BuildLogSearchResult{}, err
+	}
+
+	result := BuildLogSearchResult{
+		Matches: []BuildLogMatch{},
+	}
+
+	if len(builds) > MaxBuildLogSearchBuilds {
+		builds = builds[:MaxBuildLogSearchBuilds]
+		result.Truncated = true
+	}
+
+	slices.Reverse(builds)
+
+	for _, build := range builds {
+		if build.logsArchived {
+			result.ArchivedBuilds++
+			continue
+		}
+
+		var limitReached bool
+		result.Matches, limitReached, err = p.searchBuildLogs(build, search, result.Matches)
+		if err != nil {
+			return BuildLogSearchResult{}, err
+		}
+
+		if limitReached {
+			result.Truncated = true
+			return result, nil
+		}
+	}
+
+	return result, nil
+}
+
+type logStream struct {
+	partial string
+	line    int
+	time    time.Time
+}
+
+func (p *pipeline) searchBuildLogs(build searchedBuild, search BuildLogSearch, matches []BuildLogMatch) ([]BuildLogMatch, bool, error) {
+	rows, err := psql.Select("payload").
+		From(p.eventsTable()).
+		Where(sq.Eq{
+			"build_id": build.id,
+			"type":     string(event.EventTypeLog),
+		}).
+		OrderBy("event_id ASC").
+		RunWith(p.conn).
+		Query()
+	if err != nil {
+		return nil, false, err
+	}
+
+	defer Close(rows)
+
+	var stepNames map[atc.PlanID]string
+	if build.publicPlan.Valid {
+		stepNames = publicPlanStepNames(json.RawMessage(build.publicPlan.String))
+	}
+
+	streams := map[event.OriginID]*logStream{}
+
+	match := func(origin event.OriginID, stream *logStream, text string) bool {
+		stream.line++
+
+		text = ansiEscape.ReplaceAllString(strings.TrimSuffix(text, "\r"), "")
+		if !search.Pattern.MatchString(text) {
+			return false
+		}
+
+		matches = append(matches, BuildLogMatch{
+			BuildID:   build.id,
+			BuildName: build.name,
+			JobName:   build.jobName,
+			PlanID:    atc.PlanID(origin),
+			StepName:  stepNames[atc.PlanID(origin)],
+			Line:      stream.line,
+			Text:      text,
+			Time:      stream.time,
+		})
+
+		return search.Limit > 0 && len(matches) >= search.Limit
+	}
+
+	// streams are tracked in the order they first logged so that the trailing
+	// lines flushed at the end come out in a stable order
+	var origins []event.OriginID
+
+	for rows.Next() {
+		var payload string
+		err = rows.Scan(&payload)
+		if err != nil {
+			return nil, false, err
+		}
+
+		var log event.Log
+		err = json.Unmarshal([]byte(payload), &log)
+		if err != nil {
+			return nil, false, fmt.Errorf("unmarshal log event: %w", err)
+		}
+
+		stream, found := streams[log.Origin.ID]
+		if !found {
+			stream = &logStream{}
+			streams[log.Origin.ID] = stream
+			origins = append(origins, log.Origin.ID)
+		}
+
+		stream.time = time.Unix(log.Time, 0)
+
+		lines := strings.Split(stream.partial+log.Payload, "\n")
+		stream.partial = lines[len(lines)-1]
+
+		for _, line := range lines[:len(lines)-1] {
+			if match(log.Origin.ID, stream, line) {
+				return matches, true, nil
+			}
+		}
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, false, err
+	}
+
+	for _, origin := range origins {
+		stream := streams[origin]
+		if stream.partial == "" {
+			continue
+		}
+
+		if match(origin, stream, stream.partial) {
+			return matches, true, nil
+		}
+	}
+
+	return matches, false, nil
+}
+
+// publicPlanStepNames walks a build's public plan and returns the names of
+// its steps by plan ID.
+func publicPlanStepNames(publicPlan json.RawMessage) map[atc.PlanID]string {
+	var plan any
+	err := json.Unmarshal(publicPlan, &plan)
+	if err != nil {
+		return nil
+	}
+
+	names := map[atc.PlanID]string{}
+	collectStepNames(plan, names)
+
+	return names
+}
+
+func collectStepNames(node any, names map[atc.PlanID]string) {
+	switch node := node.(type) {
+	case map[string]any:
+		if id, ok := node["id"].(string); ok {
+			for _, stepType := range namedStepTypes {
+				step, ok := node[stepType].(map[string]any)
+				if !ok {
+					continue
+				}
+
+				if name, ok := step["name"].(string); ok {
+					names[atc.PlanID(id)] = name
+				}
+			}
+		}
+
+		for _, child := range node {
+			collectStepNames(child, names)
+		}
+
+	case []any:
+		for _, child := range node {
+			collectStepNames(child, names)
+		}
+	}
+}
@@ -20,6 +20,8 @@ import (
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbtest"
 	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/atc/logarchive"
+	"github.com/concourse/concourse/atc/logarchive/filesystem"
 	"github.com/concourse/concourse/tracing"
 	"github.com/concourse/concourse/vars"
 	. "github.com/onsi/ginkgo/v2"
@@ -55,7 +57,7 @@ var _ = Describe("Build", func() {
 			},
 		}
 
-		pipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "some-build-pipeline"}, pipelineConfig, db.ConfigVersion(1), false)
+		pipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "some-build-pipeline"}, pipelineConfig, db.ConfigVersion(1), false, "some-user")
 		Expect(err).ToNot(HaveOccurred())
 
 		job, found, err = pipeline.Job("some-job")
@@ -318,6 +320,69 @@ var _ = Describe("Build", func() {
 		})
 	})
 
+	Describe("Approvals", func() {
+		BeforeEach(func() {
+			err := build.RequestApproval("some-plan-id", "ship-it", []string{"some-user"}, nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("creates a pending approval", func() {
+			approval, found, err := build.Approval("some-plan-id")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(approval.Name).To(Equal("ship-it"))
+			Expect(approval.Status).To(Equal(atc.ApprovalPending))
+			Expect(approval.Users).To(Equal([]string{"some-user"}))
+			Expect(approval.Roles).To(BeEmpty())
+			Expect(approval.DecidedAt).To(BeZero())
+
+			approvals, err := build.Approvals()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(approvals).To(Equal([]db.BuildApproval{approval}))
+		})
+
+		It("does not find approvals for other plans", func() {
+			_, found, err := build.Approval("some-other-plan-id")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		Context("when the approval is decided", func() {
+			BeforeEach(func() {
+				decided, err := build.DecideApproval("some-plan-id", atc.ApprovalApproved, "some-user")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(decided).To(BeTrue())
+			})
+
+			It("records the decision", func() {
+				approval, _, err := build.Approval("some-plan-id")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(approval.Status).To(Equal(atc.ApprovalApproved))
+				Expect(approval.DecidedBy).To(Equal("some-user"))
+				Expect(approval.DecidedAt).NotTo(BeZero())
+			})
+
+			It("cannot be decided again", func() {
+				decided, err := build.DecideApproval("some-plan-id", atc.ApprovalTimedOut, "")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(decided).To(BeFalse())
+
+				approval, _, err := build.Approval("some-plan-id")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(approval.Status).To(Equal(atc.ApprovalApproved))
+			})
+
+			It("is not reset by requesting it again", func() {
+				err := build.RequestApproval("some-plan-id", "ship-it", nil, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				approval, _, err := build.Approval("some-plan-id")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(approval.Status).To(Equal(atc.ApprovalApproved))
+			})
+		})
+	})
+
 	Describe("Drain", func() {
 		It("defaults drain to false in the beginning", func() {
 			Expect(build.IsDrained()).To(BeFalse())
@@ -419,6 +484,150 @@ var _ = Describe("Build", func() {
 		})
 	})
 
+	Describe("StartMatrix", func() {
+		var started bool
+		var cells []db.Build
+
+		JustBeforeEach(func() {
+			var err error
+			started, err = build.StartMatrix([]atc.MatrixValues{
+				{"go": "1.21"},
+				{"go": "1.22"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			cells, err = job.GetPendingBuilds()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("starts the build without a plan", func() {
+			Expect(started).To(BeTrue())
+
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.Status()).To(Equal(db.BuildStatusStarted))
+			Expect(build.HasPlan()).To(BeFalse())
+		})
+
+		It("creates a pending cell for each combination", func() {
+			Expect(cells).To(HaveLen(2))
+
+			Expect(cells[0].Name()).To(Equal(build.Name() + "-1"))
+			Expect(cells[0].ParentBuildID()).To(Equal(build.ID()))
+			Expect(cells[0].MatrixValues()).To(Equal(atc.MatrixValues{"go": "1.21"}))
+
+			Expect(cells[1].Name()).To(Equal(build.Name() + "-2"))
+			Expect(cells[1].ParentBuildID()).To(Equal(build.ID()))
+			Expect(cells[1].MatrixValues()).To(Equal(atc.MatrixValues{"go": "1.22"}))
+		})
+
+		It("is not tracked as a started build", func() {
+			startedBuilds, err := buildFactory.GetAllStartedBuilds()
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, startedBuild := range startedBuilds {
+				Expect(startedBuild.ID()).NotTo(Equal(build.ID()))
+			}
+		})
+
+		Context("when the build has been aborted", func() {
+			BeforeEach(func() {
+				err := build.MarkAsAborted()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("does not start the build or create cells", func() {
+				Expect(started).To(BeFalse())
+				Expect(cells).To(HaveLen(1))
+				Expect(cells[0].ID()).To(Equal(build.ID()))
+			})
+		})
+
+		Context("when the build is aborted after it started", func() {
+			JustBeforeEach(func() {
+				err := build.MarkAsAborted()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("aborts each of its cells", func() {
+				for _, cell := range cells {
+					found, err := cell.Reload()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(cell.IsAborted()).To(BeTrue())
+				}
+			})
+		})
+
+		Context("when every cell finishes", func() {
+			JustBeforeEach(func() {
+				err := cells[0].Finish(db.BuildStatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+
+				found, err := build.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(build.IsCompleted()).To(BeFalse())
+
+				err = cells[1].Finish(db.BuildStatusFailed)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("finishes the build with the aggregated status", func() {
+				found, err := build.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(build.IsCompleted()).To(BeTrue())
+				Expect(build.Status()).To(Equal(db.BuildStatusFailed))
+			})
+
+			It("updates the job's latest completed build to the parent", func() {
+				found, err := job.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				finished, _, err := job.FinishedAndNextBuild()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(finished.ID()).To(Equal(build.ID()))
+			})
+
+			Context("when a failed cell is rerun", func() {
+				var rerun db.Build
+
+				JustBeforeEach(func() {
+					var err error
+					rerun, err = job.RerunBuild(cells[1], defaultBuildCreatedBy)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("creates the rerun as a cell of the same build", func() {
+					Expect(rerun.Name()).To(Equal(cells[1].Name() + ".1"))
+					Expect(rerun.ParentBuildID()).To(Equal(build.ID()))
+					Expect(rerun.MatrixValues()).To(Equal(atc.MatrixValues{"go": "1.22"}))
+				})
+
+				It("reopens the build", func() {
+					found, err := build.Reload()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(build.Status()).To(Equal(db.BuildStatusStarted))
+					Expect(build.IsCompleted()).To(BeFalse())
+				})
+
+				It("aggregates the status of the rerun once it finishes", func() {
+					err := rerun.Finish(db.BuildStatusSucceeded)
+					Expect(err).NotTo(HaveOccurred())
+
+					found, err := build.Reload()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(build.Status()).To(Equal(db.BuildStatusSucceeded))
+				})
+			})
+		})
+	})
+
 	Describe("Finish", func() {
 		var scenario *dbtest.Scenario
 		var build db.Build
@@ -855,7 +1064,7 @@ var _ = Describe("Build", func() {
 				Context("when the pipeline is not set by build", func() {
 					It("never gets archived", func() {
 						build, _ := defaultJob.CreateBuild(defaultBuildCreatedBy)
-						teamPipeline, _, _ := defaultTeam.SavePipeline(atc.PipelineRef{Name: "team-pipeline"}, defaultPipelineConfig, db.ConfigVersion(0), false)
+						teamPipeline, _, _ := defaultTeam.SavePipeline(atc.PipelineRef{Name: "team-pipeline"}, defaultPipelineConfig, db.ConfigVersion(0), false, "some-user")
 						build.Finish(db.BuildStatusSucceeded)
 
 						teamPipeline.Reload()
@@ -933,7 +1142,7 @@ var _ = Describe("Build", func() {
 					},
 				})
 
-				pipeline, _, err := defaultTeam.SavePipeline(defaultPipelineRef, config, defaultPipeline.ConfigVersion(), false)
+				pipeline, _, err := defaultTeam.SavePipeline(defaultPipelineRef, config, defaultPipeline.ConfigVersion(), false, "some-user")
 				Expect(err).ToNot(HaveOccurred())
 
 				job, found, err := pipeline.Job(defaultJob.Name())
@@ -1094,6 +1303,92 @@ var _ = Describe("Build", func() {
 		})
 	})
 
+	Describe("ArchiveEvents", func() {
+		var (
+			archive        *filesystem.Archive
+			archiveFactory db.BuildFactory
+			archivingBuild db.Build
+		)
+
+		BeforeEach(func() {
+			archive = &filesystem.Archive{Directory: GinkgoT().TempDir()}
+			archiveFactory = db.NewBuildFactory(dbConn, lockFactory, 0, 0, archive)
+
+			started, err := build.Start(atc.Plan{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(started).To(BeTrue())
+
+			err = build.Finish(db.BuildStatusSucceeded)
+			Expect(err).NotTo(HaveOccurred())
+
+			var found bool
+			archivingBuild, found, err = archiveFactory.Build(build.ID())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		It("moves the events out of the database", func() {
+			err := archivingBuild.ArchiveEvents(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(archivingBuild.LogsArchived()).To(BeTrue())
+
+			var count int
+			err = dbConn.QueryRow(`SELECT COUNT(*) FROM build_events WHERE build_id = $1`, build.ID()).Scan(&count)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(BeZero())
+
+			found, err := archivingBuild.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(archivingBuild.LogsArchived()).To(BeTrue())
+		})
+
+		It("reads the events back from the archive", func() {
+			err := archivingBuild.ArchiveEvents(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			events, err := archivingBuild.Events(1)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer db.Close(events)
+
+			Expect(events.Next()).To(Equal(envelope(event.Status{
+				Status: atc.StatusSucceeded,
+				Time:   archivingBuild.EndTime().Unix(),
+			}, "1")))
+
+			_, err = events.Next()
+			Expect(err).To(Equal(db.ErrEndOfBuildEventStream))
+		})
+
+		It("deletes the archived events once the build is deleted", func() {
+			err := archivingBuild.ArchiveEvents(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			deleted, err := archiveFactory.DeleteUnusedArchivedBuildEvents(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deleted).To(BeZero())
+
+			_, err = archivingBuild.Delete()
+			Expect(err).NotTo(HaveOccurred())
+
+			deleted, err = archiveFactory.DeleteUnusedArchivedBuildEvents(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deleted).To(Equal(1))
+
+			_, err = archive.Retrieve(ctx, fmt.Sprintf("builds/%d.json.gz", build.ID()))
+			Expect(err).To(Equal(logarchive.ErrNotFound))
+		})
+
+		Context("when the build didn't come from a factory with an archive", func() {
+			It("leaves the events in the database", func() {
+				err := build.ArchiveEvents(ctx)
+				Expect(err).To(Equal(db.ErrNoBuildLogArchive))
+				Expect(build.LogsArchived()).To(BeFalse())
+			})
+		})
+	})
+
 	Describe("SaveEvent", func() {
 		var marker *db.BuildBeingWatchedMarker
 		BeforeEach(func() {
@@ -1564,7 +1859,7 @@ var _ = Describe("Build", func() {
 							Name: "some-job",
 						},
 					},
-				}, db.ConfigVersion(1), false)
+				}, db.ConfigVersion(1), false, "some-user")
 				Expect(err).ToNot(HaveOccurred())
 
 				job, found, err := createdPipeline.Job("some-job")
@@ -1628,6 +1923,7 @@ var _ = Describe("Build", func() {
 				PausedPipeline:      db.BuildPreparationStatusNotBlocking,
 				PausedJob:           db.BuildPreparationStatusNotBlocking,
 				MaxRunningBuilds:    db.BuildPreparationStatusNotBlocking,
+				ConcurrencyGroup:    db.BuildPreparationStatusNotBlocking,
 				Inputs:              map[string]db.BuildPreparationStatus{},
 				InputsSatisfied:     db.BuildPreparationStatusNotBlocking,
 				MissingInputReasons: db.MissingInputReasons{},
@@ -2680,7 +2976,7 @@ var _ = Describe("Build", func() {
 
 		It("unpauses the pipeline if it was previously archived", func() {
 			By("creating and archiving a pipeline")
-			pipeline, _, err := defaultTeam.SavePipeline(defaultPipelineRef, defaultPipelineConfig, db.ConfigVersion(1), false)
+			pipeline, _, err := defaultTeam.SavePipeline(defaultPipelineRef, defaultPipelineConfig, db.ConfigVersion(1), false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
 			err = pipeline.Archive()
@@ -2700,7 +2996,7 @@ var _ = Describe("Build", func() {
 
 		It("does not unpause the pipeline if it was previously paused", func() {
 			By("creating and pausing a pipeline")
-			pipeline, _, err := defaultTeam.SavePipeline(defaultPipelineRef, defaultPipelineConfig, db.ConfigVersion(1), false)
+			pipeline, _, err := defaultTeam.SavePipeline(defaultPipelineRef, defaultPipelineConfig, db.ConfigVersion(1), false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
 			err = pipeline.Pause("")
@@ -692,7 +692,7 @@ var _ = Describe("CheckFactory", func() {
 			}
 
 			somePipelineRef := atc.PipelineRef{Name: "some-pipeline"}
-			somePipeline, _, err = defaultTeam.SavePipeline(somePipelineRef, somePipelineConfig, db.ConfigVersion(1), false)
+			somePipeline, _, err = defaultTeam.SavePipeline(somePipelineRef, somePipelineConfig, db.ConfigVersion(1), false, "some-user")
 			Expect(err).NotTo(HaveOccurred())
 		})
 
@@ -2,6 +2,7 @@
 package dbfakes
 
 import (
+	"context"
 	"encoding/json"
 	"sync"
 	"time"
@@ -60,6 +61,20 @@ type FakeBuild struct {
 		result2 bool
 		result3 error
 	}
+	AdoptMatrixInputsAndPipesStub        func() ([]db.BuildInput, bool, error)
+	adoptMatrixInputsAndPipesMutex       sync.RWMutex
+	adoptMatrixInputsAndPipesArgsForCall []struct {
+	}
+	adoptMatrixInputsAndPipesReturns struct {
+		result1 []db.BuildInput
+		result2 bool
+		result3 error
+	}
+	adoptMatrixInputsAndPipesReturnsOnCall map[int]struct {
+		result1 []db.BuildInput
+		result2 bool
+		result3 error
+	}
 	AdoptRerunInputsAndPipesStub        func() ([]db.BuildInput, bool, error)
 	adoptRerunInputsAndPipesMutex       sync.RWMutex
 	adoptRerunInputsAndPipesArgsForCall []struct {
@@ -84,6 +99,44 @@ type FakeBuild struct {
 	allAssociatedTeamNamesReturnsOnCall map[int]struct {
 		result1 []string
 	}
+	ApprovalStub        func(atc.PlanID) (db.BuildApproval, bool, error)
+	approvalMutex       sync.RWMutex
+	approvalArgsForCall []struct {
+		arg1 atc.PlanID
+	}
+	approvalReturns struct {
+		result1 db.BuildApproval
+		result2 bool
+		result3 error
+	}
+	approvalReturnsOnCall map[int]struct {
+		result1 db.BuildApproval
+		result2 bool
+		result3 error
+	}
+	ApprovalsStub        func() ([]db.BuildApproval, error)
+	approvalsMutex       sync.RWMutex
+	approvalsArgsForCall []struct {
+	}
+	approvalsReturns struct {
+		result1 []db.BuildApproval
+		result2 error
+	}
+	approvalsReturnsOnCall map[int]struct {
+		result1 []db.BuildApproval
+		result2 error
+	}
+	ArchiveEventsStub        func(context.Context) error
+	archiveEventsMutex       sync.RWMutex
+	archiveEventsArgsForCall []struct {
+		arg1 context.Context
+	}
+	archiveEventsReturns struct {
+		result1 error
+	}
+	archiveEventsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ArtifactStub        func(int) (db.WorkerArtifact, error)
 	artifactMutex       sync.RWMutex
 	artifactArgsForCall []struct {
@@ -150,6 +203,21 @@ type FakeBuild struct {
 	createdByReturnsOnCall map[int]struct {
 		result1 *string
 	}
+	DecideApprovalStub        func(atc.PlanID, atc.ApprovalStatus, string) (bool, error)
+	decideApprovalMutex       sync.RWMutex
+	decideApprovalArgsForCall []struct {
+		arg1 atc.PlanID
+		arg2 atc.ApprovalStatus
+		arg3 string
+	}
+	decideApprovalReturns struct {
+		result1 bool
+		result2 error
+	}
+	decideApprovalReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	DeleteStub        func() (bool, error)
 	deleteMutex       sync.RWMutex
 	deleteArgsForCall []struct {
@@ -332,6 +400,16 @@ type FakeBuild struct {
 	jobNameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	JobPriorityStub        func() int
+	jobPriorityMutex       sync.RWMutex
+	jobPriorityArgsForCall []struct {
+	}
+	jobPriorityReturns struct {
+		result1 int
+	}
+	jobPriorityReturnsOnCall map[int]struct {
+		result1 int
+	}
 	LagerDataStub        func() lager.Data
 	lagerDataMutex       sync.RWMutex
 	lagerDataArgsForCall []struct {
@@ -342,6 +420,16 @@ type FakeBuild struct {
 	lagerDataReturnsOnCall map[int]struct {
 		result1 lager.Data
 	}
+	LogsArchivedStub        func() bool
+	logsArchivedMutex       sync.RWMutex
+	logsArchivedArgsForCall []struct {
+	}
+	logsArchivedReturns struct {
+		result1 bool
+	}
+	logsArchivedReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	MarkAsAbortedStub        func() error
 	markAsAbortedMutex       sync.RWMutex
 	markAsAbortedArgsForCall []struct {
@@ -352,6 +440,16 @@ type FakeBuild struct {
 	markAsAbortedReturnsOnCall map[int]struct {
 		result1 error
 	}
+	MatrixValuesStub        func() atc.MatrixValues
+	matrixValuesMutex       sync.RWMutex
+	matrixValuesArgsForCall []struct {
+	}
+	matrixValuesReturns struct {
+		result1 atc.MatrixValues
+	}
+	matrixValuesReturnsOnCall map[int]struct {
+		result1 atc.MatrixValues
+	}
 	NameStub        func() string
 	nameMutex       sync.RWMutex
 	nameArgsForCall []struct {
@@ -384,6 +482,16 @@ type FakeBuild struct {
 	onCheckBuildStartReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ParentBuildIDStub        func() int
+	parentBuildIDMutex       sync.RWMutex
+	parentBuildIDArgsForCall []struct {
+	}
+	parentBuildIDReturns struct {
+		result1 int
+	}
+	parentBuildIDReturnsOnCall map[int]struct {
+		result1 int
+	}
 	PipelineStub        func() (db.Pipeline, bool, error)
 	pipelineMutex       sync.RWMutex
 	pipelineArgsForCall []struct {
@@ -494,6 +602,20 @@ type FakeBuild struct {
 		result1 bool
 		result2 error
 	}
+	RequestApprovalStub        func(atc.PlanID, string, []string, []string) error
+	requestApprovalMutex       sync.RWMutex
+	requestApprovalArgsForCall []struct {
+		arg1 atc.PlanID
+		arg2 string
+		arg3 []string
+		arg4 []string
+	}
+	requestApprovalReturns struct {
+		result1 error
+	}
+	requestApprovalReturnsOnCall map[int]struct {
+		result1 error
+	}
 	RerunNumberStub        func() int
 	rerunNumberMutex       sync.RWMutex
 	rerunNumberArgsForCall []struct {
@@ -658,6 +780,17 @@ type FakeBuild struct {
 		result2 bool
 		result3 error
 	}
+	SaveResolvedVarsStub        func([]vars.Reference) error
+	saveResolvedVarsMutex       sync.RWMutex
+	saveResolvedVarsArgsForCall []struct {
+		arg1 []vars.Reference
+	}
+	saveResolvedVarsReturns struct {
+		result1 error
+	}
+	saveResolvedVarsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SchemaStub        func() string
 	schemaMutex       sync.RWMutex
 	schemaArgsForCall []struct {
@@ -724,6 +857,19 @@ type FakeBuild struct {
 		result1 bool
 		result2 error
 	}
+	StartMatrixStub        func([]atc.MatrixValues) (bool, error)
+	startMatrixMutex       sync.RWMutex
+	startMatrixArgsForCall []struct {
+		arg1 []atc.MatrixValues
+	}
+	startMatrixReturns struct {
+		result1 bool
+		result2 error
+	}
+	startMatrixReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	StartTimeStub        func() time.Time
 	startTimeMutex       sync.RWMutex
 	startTimeArgsForCall []struct {
@@ -987,6 +1133,65 @@ func (fake *FakeBuild) AdoptInputsAndPipesReturnsOnCall(i int, result1 []db.Buil
 	}{result1, result2, result3}
 }
 
+func (fake *FakeBuild) AdoptMatrixInputsAndPipes() ([]db.BuildInput, bool, error) {
+	fake.adoptMatrixInputsAndPipesMutex.Lock()
+	ret, specificReturn := fake.adoptMatrixInputsAndPipesReturnsOnCall[len(fake.adoptMatrixInputsAndPipesArgsForCall)]
+	fake.adoptMatrixInputsAndPipesArgsForCall = append(fake.adoptMatrixInputsAndPipesArgsForCall, struct {
+	}{})
+	stub := fake.AdoptMatrixInputsAndPipesStub
+	fakeReturns := fake.adoptMatrixInputsAndPipesReturns
+	fake.recordInvocation("AdoptMatrixInputsAndPipes", []interface{}{})
+	fake.adoptMatrixInputsAndPipesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeBuild) AdoptMatrixInputsAndPipesCallCount() int {
+	fake.adoptMatrixInputsAndPipesMutex.RLock()
+	defer fake.adoptMatrixInputsAndPipesMutex.RUnlock()
+	return len(fake.adoptMatrixInputsAndPipesArgsForCall)
+}
+
+func (fake *FakeBuild) AdoptMatrixInputsAndPipesCalls(stub func() ([]db.BuildInput, bool, error)) {
+	fake.adoptMatrixInputsAndPipesMutex.Lock()
+	defer fake.adoptMatrixInputsAndPipesMutex.Unlock()
+	fake.AdoptMatrixInputsAndPipesStub = stub
+}
+
+func (fake *FakeBuild) AdoptMatrixInputsAndPipesReturns(result1 []db.BuildInput, result2 bool, result3 error) {
+	fake.adoptMatrixInputsAndPipesMutex.Lock()
+	defer fake.adoptMatrixInputsAndPipesMutex.Unlock()
+	fake.AdoptMatrixInputsAndPipesStub = nil
+	fake.adoptMatrixInputsAndPipesReturns = struct {
+		result1 []db.BuildInput
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) AdoptMatrixInputsAndPipesReturnsOnCall(i int, result1 []db.BuildInput, result2 bool, result3 error) {
+	fake.adoptMatrixInputsAndPipesMutex.Lock()
+	defer fake.adoptMatrixInputsAndPipesMutex.Unlock()
+	fake.AdoptMatrixInputsAndPipesStub = nil
+	if fake.adoptMatrixInputsAndPipesReturnsOnCall == nil {
+		fake.adoptMatrixInputsAndPipesReturnsOnCall = make(map[int]struct {
+			result1 []db.BuildInput
+			result2 bool
+			result3 error
+		})
+	}
+	fake.adoptMatrixInputsAndPipesReturnsOnCall[i] = struct {
+		result1 []db.BuildInput
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeBuild) AdoptRerunInputsAndPipes() ([]db.BuildInput, bool, error) {
 	fake.adoptRerunInputsAndPipesMutex.Lock()
 	ret, specificReturn := fake.adoptRerunInputsAndPipesReturnsOnCall[len(fake.adoptRerunInputsAndPipesArgsForCall)]
@@ -1099,6 +1304,190 @@ func (fake *FakeBuild) AllAssociatedTeamNamesReturnsOnCall(i int, result1 []stri
 	}{result1}
 }
 
+func (fake *FakeBuild) Approval(arg1 atc.PlanID) (db.BuildApproval, bool, error) {
+	fake.approvalMutex.Lock()
+	ret, specificReturn := fake.approvalReturnsOnCall[len(fake.approvalArgsForCall)]
+	fake.approvalArgsForCall = append(fake.approvalArgsForCall, struct {
+		arg1 atc.PlanID
+	}{arg1})
+	stub := fake.ApprovalStub
+	fakeReturns := fake.approvalReturns
+	fake.recordInvocation("Approval", []interface{}{arg1})
+	fake.approvalMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeBuild) ApprovalCallCount() int {
+	fake.approvalMutex.RLock()
+	defer fake.approvalMutex.RUnlock()
+	return len(fake.approvalArgsForCall)
+}
+
+func (fake *FakeBuild) ApprovalCalls(stub func(atc.PlanID) (db.BuildApproval, bool, error)) {
+	fake.approvalMutex.Lock()
+	defer fake.approvalMutex.Unlock()
+	fake.ApprovalStub = stub
+}
+
+func (fake *FakeBuild) ApprovalArgsForCall(i int) atc.PlanID {
+	fake.approvalMutex.RLock()
+	defer fake.approvalMutex.RUnlock()
+	argsForCall := fake.approvalArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) ApprovalReturns(result1 db.BuildApproval, result2 bool, result3 error) {
+	fake.approvalMutex.Lock()
+	defer fake.approvalMutex.Unlock()
+	fake.ApprovalStub = nil
+	fake.approvalReturns = struct {
+		result1 db.BuildApproval
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) ApprovalReturnsOnCall(i int, result1 db.BuildApproval, result2 bool, result3 error) {
+	fake.approvalMutex.Lock()
+	defer fake.approvalMutex.Unlock()
+	fake.ApprovalStub = nil
+	if fake.approvalReturnsOnCall == nil {
+		fake.approvalReturnsOnCall = make(map[int]struct {
+			result1 db.BuildApproval
+			result2 bool
+			result3 error
+		})
+	}
+	fake.approvalReturnsOnCall[i] = struct {
+		result1 db.BuildApproval
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) Approvals() ([]db.BuildApproval, error) {
+	fake.approvalsMutex.Lock()
+	ret, specificReturn := fake.approvalsReturnsOnCall[len(fake.approvalsArgsForCall)]
+	fake.approvalsArgsForCall = append(fake.approvalsArgsForCall, struct {
+	}{})
+	stub := fake.ApprovalsStub
+	fakeReturns := fake.approvalsReturns
+	fake.recordInvocation("Approvals", []interface{}{})
+	fake.approvalsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) ApprovalsCallCount() int {
+	fake.approvalsMutex.RLock()
+	defer fake.approvalsMutex.RUnlock()
+	return len(fake.approvalsArgsForCall)
+}
+
+func (fake *FakeBuild) ApprovalsCalls(stub func() ([]db.BuildApproval, error)) {
+	fake.approvalsMutex.Lock()
+	defer fake.approvalsMutex.Unlock()
+	fake.ApprovalsStub = stub
+}
+
+func (fake *FakeBuild) ApprovalsReturns(result1 []db.BuildApproval, result2 error) {
+	fake.approvalsMutex.Lock()
+	defer fake.approvalsMutex.Unlock()
+	fake.ApprovalsStub = nil
+	fake.approvalsReturns = struct {
+		result1 []db.BuildApproval
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) ApprovalsReturnsOnCall(i int, result1 []db.BuildApproval, result2 error) {
+	fake.approvalsMutex.Lock()
+	defer fake.approvalsMutex.Unlock()
+	fake.ApprovalsStub = nil
+	if fake.approvalsReturnsOnCall == nil {
+		fake.approvalsReturnsOnCall = make(map[int]struct {
+			result1 []db.BuildApproval
+			result2 error
+		})
+	}
+	fake.approvalsReturnsOnCall[i] = struct {
+		result1 []db.BuildApproval
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) ArchiveEvents(arg1 context.Context) error {
+	fake.archiveEventsMutex.Lock()
+	ret, specificReturn := fake.archiveEventsReturnsOnCall[len(fake.archiveEventsArgsForCall)]
+	fake.archiveEventsArgsForCall = append(fake.archiveEventsArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.ArchiveEventsStub
+	fakeReturns := fake.archiveEventsReturns
+	fake.recordInvocation("ArchiveEvents", []interface{}{arg1})
+	fake.archiveEventsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) ArchiveEventsCallCount() int {
+	fake.archiveEventsMutex.RLock()
+	defer fake.archiveEventsMutex.RUnlock()
+	return len(fake.archiveEventsArgsForCall)
+}
+
+func (fake *FakeBuild) ArchiveEventsCalls(stub func(context.Context) error) {
+	fake.archiveEventsMutex.Lock()
+	defer fake.archiveEventsMutex.Unlock()
+	fake.ArchiveEventsStub = stub
+}
+
+func (fake *FakeBuild) ArchiveEventsArgsForCall(i int) context.Context {
+	fake.archiveEventsMutex.RLock()
+	defer fake.archiveEventsMutex.RUnlock()
+	argsForCall := fake.archiveEventsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) ArchiveEventsReturns(result1 error) {
+	fake.archiveEventsMutex.Lock()
+	defer fake.archiveEventsMutex.Unlock()
+	fake.ArchiveEventsStub = nil
+	fake.archiveEventsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) ArchiveEventsReturnsOnCall(i int, result1 error) {
+	fake.archiveEventsMutex.Lock()
+	defer fake.archiveEventsMutex.Unlock()
+	fake.ArchiveEventsStub = nil
+	if fake.archiveEventsReturnsOnCall == nil {
+		fake.archiveEventsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.archiveEventsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) Artifact(arg1 int) (db.WorkerArtifact, error) {
 	fake.artifactMutex.Lock()
 	ret, specificReturn := fake.artifactReturnsOnCall[len(fake.artifactArgsForCall)]
@@ -1439,6 +1828,72 @@ func (fake *FakeBuild) CreatedByReturnsOnCall(i int, result1 *string) {
 	}{result1}
 }
 
+func (fake *FakeBuild) DecideApproval(arg1 atc.PlanID, arg2 atc.ApprovalStatus, arg3 string) (bool, error) {
+	fake.decideApprovalMutex.Lock()
+	ret, specificReturn := fake.decideApprovalReturnsOnCall[len(fake.decideApprovalArgsForCall)]
+	fake.decideApprovalArgsForCall = append(fake.decideApprovalArgsForCall, struct {
+		arg1 atc.PlanID
+		arg2 atc.ApprovalStatus
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.DecideApprovalStub
+	fakeReturns := fake.decideApprovalReturns
+	fake.recordInvocation("DecideApproval", []interface{}{arg1, arg2, arg3})
+	fake.decideApprovalMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) DecideApprovalCallCount() int {
+	fake.decideApprovalMutex.RLock()
+	defer fake.decideApprovalMutex.RUnlock()
+	return len(fake.decideApprovalArgsForCall)
+}
+
+func (fake *FakeBuild) DecideApprovalCalls(stub func(atc.PlanID, atc.ApprovalStatus, string) (bool, error)) {
+	fake.decideApprovalMutex.Lock()
+	defer fake.decideApprovalMutex.Unlock()
+	fake.DecideApprovalStub = stub
+}
+
+func (fake *FakeBuild) DecideApprovalArgsForCall(i int) (atc.PlanID, atc.ApprovalStatus, string) {
+	fake.decideApprovalMutex.RLock()
+	defer fake.decideApprovalMutex.RUnlock()
+	argsForCall := fake.decideApprovalArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeBuild) DecideApprovalReturns(result1 bool, result2 error) {
+	fake.decideApprovalMutex.Lock()
+	defer fake.decideApprovalMutex.Unlock()
+	fake.DecideApprovalStub = nil
+	fake.decideApprovalReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) DecideApprovalReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.decideApprovalMutex.Lock()
+	defer fake.decideApprovalMutex.Unlock()
+	fake.DecideApprovalStub = nil
+	if fake.decideApprovalReturnsOnCall == nil {
+		fake.decideApprovalReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.decideApprovalReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) Delete() (bool, error) {
 	fake.deleteMutex.Lock()
 	ret, specificReturn := fake.deleteReturnsOnCall[len(fake.deleteArgsForCall)]
@@ -2371,6 +2826,59 @@ func (fake *FakeBuild) JobNameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeBuild) JobPriority() int {
+	fake.jobPriorityMutex.Lock()
+	ret, specificReturn := fake.jobPriorityReturnsOnCall[len(fake.jobPriorityArgsForCall)]
+	fake.jobPriorityArgsForCall = append(fake.jobPriorityArgsForCall, struct {
+	}{})
+	stub := fake.JobPriorityStub
+	fakeReturns := fake.jobPriorityReturns
+	fake.recordInvocation("JobPriority", []interface{}{})
+	fake.jobPriorityMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) JobPriorityCallCount() int {
+	fake.jobPriorityMutex.RLock()
+	defer fake.jobPriorityMutex.RUnlock()
+	return len(fake.jobPriorityArgsForCall)
+}
+
+func (fake *FakeBuild) JobPriorityCalls(stub func() int) {
+	fake.jobPriorityMutex.Lock()
+	defer fake.jobPriorityMutex.Unlock()
+	fake.JobPriorityStub = stub
+}
+
+func (fake *FakeBuild) JobPriorityReturns(result1 int) {
+	fake.jobPriorityMutex.Lock()
+	defer fake.jobPriorityMutex.Unlock()
+	fake.JobPriorityStub = nil
+	fake.jobPriorityReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeBuild) JobPriorityReturnsOnCall(i int, result1 int) {
+	fake.jobPriorityMutex.Lock()
+	defer fake.jobPriorityMutex.Unlock()
+	fake.JobPriorityStub = nil
+	if fake.jobPriorityReturnsOnCall == nil {
+		fake.jobPriorityReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.jobPriorityReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
 func (fake *FakeBuild) LagerData() lager.Data {
 	fake.lagerDataMutex.Lock()
 	ret, specificReturn := fake.lagerDataReturnsOnCall[len(fake.lagerDataArgsForCall)]
@@ -2424,6 +2932,59 @@ func (fake *FakeBuild) LagerDataReturnsOnCall(i int, result1 lager.Data) {
 	}{result1}
 }
 
+func (fake *FakeBuild) LogsArchived() bool {
+	fake.logsArchivedMutex.Lock()
+	ret, specificReturn := fake.logsArchivedReturnsOnCall[len(fake.logsArchivedArgsForCall)]
+	fake.logsArchivedArgsForCall = append(fake.logsArchivedArgsForCall, struct {
+	}{})
+	stub := fake.LogsArchivedStub
+	fakeReturns := fake.logsArchivedReturns
+	fake.recordInvocation("LogsArchived", []interface{}{})
+	fake.logsArchivedMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) LogsArchivedCallCount() int {
+	fake.logsArchivedMutex.RLock()
+	defer fake.logsArchivedMutex.RUnlock()
+	return len(fake.logsArchivedArgsForCall)
+}
+
+func (fake *FakeBuild) LogsArchivedCalls(stub func() bool) {
+	fake.logsArchivedMutex.Lock()
+	defer fake.logsArchivedMutex.Unlock()
+	fake.LogsArchivedStub = stub
+}
+
+func (fake *FakeBuild) LogsArchivedReturns(result1 bool) {
+	fake.logsArchivedMutex.Lock()
+	defer fake.logsArchivedMutex.Unlock()
+	fake.LogsArchivedStub = nil
+	fake.logsArchivedReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeBuild) LogsArchivedReturnsOnCall(i int, result1 bool) {
+	fake.logsArchivedMutex.Lock()
+	defer fake.logsArchivedMutex.Unlock()
+	fake.LogsArchivedStub = nil
+	if fake.logsArchivedReturnsOnCall == nil {
+		fake.logsArchivedReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.logsArchivedReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeBuild) MarkAsAborted() error {
 	fake.markAsAbortedMutex.Lock()
 	ret, specificReturn := fake.markAsAbortedReturnsOnCall[len(fake.markAsAbortedArgsForCall)]
@@ -2477,6 +3038,59 @@ func (fake *FakeBuild) MarkAsAbortedReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeBuild) MatrixValues() atc.MatrixValues {
+	fake.matrixValuesMutex.Lock()
+	ret, specificReturn := fake.matrixValuesReturnsOnCall[len(fake.matrixValuesArgsForCall)]
+	fake.matrixValuesArgsForCall = append(fake.matrixValuesArgsForCall, struct {
+	}{})
+	stub := fake.MatrixValuesStub
+	fakeReturns := fake.matrixValuesReturns
+	fake.recordInvocation("MatrixValues", []interface{}{})
+	fake.matrixValuesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) MatrixValuesCallCount() int {
+	fake.matrixValuesMutex.RLock()
+	defer fake.matrixValuesMutex.RUnlock()
+	return len(fake.matrixValuesArgsForCall)
+}
+
+func (fake *FakeBuild) MatrixValuesCalls(stub func() atc.MatrixValues) {
+	fake.matrixValuesMutex.Lock()
+	defer fake.matrixValuesMutex.Unlock()
+	fake.MatrixValuesStub = stub
+}
+
+func (fake *FakeBuild) MatrixValuesReturns(result1 atc.MatrixValues) {
+	fake.matrixValuesMutex.Lock()
+	defer fake.matrixValuesMutex.Unlock()
+	fake.MatrixValuesStub = nil
+	fake.matrixValuesReturns = struct {
+		result1 atc.MatrixValues
+	}{result1}
+}
+
+func (fake *FakeBuild) MatrixValuesReturnsOnCall(i int, result1 atc.MatrixValues) {
+	fake.matrixValuesMutex.Lock()
+	defer fake.matrixValuesMutex.Unlock()
+	fake.MatrixValuesStub = nil
+	if fake.matrixValuesReturnsOnCall == nil {
+		fake.matrixValuesReturnsOnCall = make(map[int]struct {
+			result1 atc.MatrixValues
+		})
+	}
+	fake.matrixValuesReturnsOnCall[i] = struct {
+		result1 atc.MatrixValues
+	}{result1}
+}
+
 func (fake *FakeBuild) Name() string {
 	fake.nameMutex.Lock()
 	ret, specificReturn := fake.nameReturnsOnCall[len(fake.nameArgsForCall)]
@@ -2639,6 +3253,59 @@ func (fake *FakeBuild) OnCheckBuildStartReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeBuild) ParentBuildID() int {
+	fake.parentBuildIDMutex.Lock()
+	ret, specificReturn := fake.parentBuildIDReturnsOnCall[len(fake.parentBuildIDArgsForCall)]
+	fake.parentBuildIDArgsForCall = append(fake.parentBuildIDArgsForCall, struct {
+	}{})
+	stub := fake.ParentBuildIDStub
+	fakeReturns := fake.parentBuildIDReturns
+	fake.recordInvocation("ParentBuildID", []interface{}{})
+	fake.parentBuildIDMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) ParentBuildIDCallCount() int {
+	fake.parentBuildIDMutex.RLock()
+	defer fake.parentBuildIDMutex.RUnlock()
+	return len(fake.parentBuildIDArgsForCall)
+}
+
+func (fake *FakeBuild) ParentBuildIDCalls(stub func() int) {
+	fake.parentBuildIDMutex.Lock()
+	defer fake.parentBuildIDMutex.Unlock()
+	fake.ParentBuildIDStub = stub
+}
+
+func (fake *FakeBuild) ParentBuildIDReturns(result1 int) {
+	fake.parentBuildIDMutex.Lock()
+	defer fake.parentBuildIDMutex.Unlock()
+	fake.ParentBuildIDStub = nil
+	fake.parentBuildIDReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeBuild) ParentBuildIDReturnsOnCall(i int, result1 int) {
+	fake.parentBuildIDMutex.Lock()
+	defer fake.parentBuildIDMutex.Unlock()
+	fake.ParentBuildIDStub = nil
+	if fake.parentBuildIDReturnsOnCall == nil {
+		fake.parentBuildIDReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.parentBuildIDReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
 func (fake *FakeBuild) Pipeline() (db.Pipeline, bool, error) {
 	fake.pipelineMutex.Lock()
 	ret, specificReturn := fake.pipelineReturnsOnCall[len(fake.pipelineArgsForCall)]
@@ -3184,6 +3851,80 @@ func (fake *FakeBuild) ReloadReturnsOnCall(i int, result1 bool, result2 error) {
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) RequestApproval(arg1 atc.PlanID, arg2 string, arg3 []string, arg4 []string) error {
+	var arg3Copy []string
+	if arg3 != nil {
+		arg3Copy = make([]string, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	var arg4Copy []string
+	if arg4 != nil {
+		arg4Copy = make([]string, len(arg4))
+		copy(arg4Copy, arg4)
+	}
+	fake.requestApprovalMutex.Lock()
+	ret, specificReturn := fake.requestApprovalReturnsOnCall[len(fake.requestApprovalArgsForCall)]
+	fake.requestApprovalArgsForCall = append(fake.requestApprovalArgsForCall, struct {
+		arg1 atc.PlanID
+		arg2 string
+		arg3 []string
+		arg4 []string
+	}{arg1, arg2, arg3Copy, arg4Copy})
+	stub := fake.RequestApprovalStub
+	fakeReturns := fake.requestApprovalReturns
+	fake.recordInvocation("RequestApproval", []interface{}{arg1, arg2, arg3Copy, arg4Copy})
+	fake.requestApprovalMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) RequestApprovalCallCount() int {
+	fake.requestApprovalMutex.RLock()
+	defer fake.requestApprovalMutex.RUnlock()
+	return len(fake.requestApprovalArgsForCall)
+}
+
+func (fake *FakeBuild) RequestApprovalCalls(stub func(atc.PlanID, string, []string, []string) error) {
+	fake.requestApprovalMutex.Lock()
+	defer fake.requestApprovalMutex.Unlock()
+	fake.RequestApprovalStub = stub
+}
+
+func (fake *FakeBuild) RequestApprovalArgsForCall(i int) (atc.PlanID, string, []string, []string) {
+	fake.requestApprovalMutex.RLock()
+	defer fake.requestApprovalMutex.RUnlock()
+	argsForCall := fake.requestApprovalArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeBuild) RequestApprovalReturns(result1 error) {
+	fake.requestApprovalMutex.Lock()
+	defer fake.requestApprovalMutex.Unlock()
+	fake.RequestApprovalStub = nil
+	fake.requestApprovalReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) RequestApprovalReturnsOnCall(i int, result1 error) {
+	fake.requestApprovalMutex.Lock()
+	defer fake.requestApprovalMutex.Unlock()
+	fake.RequestApprovalStub = nil
+	if fake.requestApprovalReturnsOnCall == nil {
+		fake.requestApprovalReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.requestApprovalReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) RerunNumber() int {
 	fake.rerunNumberMutex.Lock()
 	ret, specificReturn := fake.rerunNumberReturnsOnCall[len(fake.rerunNumberArgsForCall)]
@@ -3983,6 +4724,72 @@ func (fake *FakeBuild) SavePipelineReturnsOnCall(i int, result1 db.Pipeline, res
 	}{result1, result2, result3}
 }
 
+func (fake *FakeBuild) SaveResolvedVars(arg1 []vars.Reference) error {
+	var arg1Copy []vars.Reference
+	if arg1 != nil {
+		arg1Copy = make([]vars.Reference, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.saveResolvedVarsMutex.Lock()
+	ret, specificReturn := fake.saveResolvedVarsReturnsOnCall[len(fake.saveResolvedVarsArgsForCall)]
+	fake.saveResolvedVarsArgsForCall = append(fake.saveResolvedVarsArgsForCall, struct {
+		arg1 []vars.Reference
+	}{arg1Copy})
+	stub := fake.SaveResolvedVarsStub
+	fakeReturns := fake.saveResolvedVarsReturns
+	fake.recordInvocation("SaveResolvedVars", []interface{}{arg1Copy})
+	fake.saveResolvedVarsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) SaveResolvedVarsCallCount() int {
+	fake.saveResolvedVarsMutex.RLock()
+	defer fake.saveResolvedVarsMutex.RUnlock()
+	return len(fake.saveResolvedVarsArgsForCall)
+}
+
+func (fake *FakeBuild) SaveResolvedVarsCalls(stub func([]vars.Reference) error) {
+	fake.saveResolvedVarsMutex.Lock()
+	defer fake.saveResolvedVarsMutex.Unlock()
+	fake.SaveResolvedVarsStub = stub
+}
+
+func (fake *FakeBuild) SaveResolvedVarsArgsForCall(i int) []vars.Reference {
+	fake.saveResolvedVarsMutex.RLock()
+	defer fake.saveResolvedVarsMutex.RUnlock()
+	argsForCall := fake.saveResolvedVarsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) SaveResolvedVarsReturns(result1 error) {
+	fake.saveResolvedVarsMutex.Lock()
+	defer fake.saveResolvedVarsMutex.Unlock()
+	fake.SaveResolvedVarsStub = nil
+	fake.saveResolvedVarsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveResolvedVarsReturnsOnCall(i int, result1 error) {
+	fake.saveResolvedVarsMutex.Lock()
+	defer fake.saveResolvedVarsMutex.Unlock()
+	fake.SaveResolvedVarsStub = nil
+	if fake.saveResolvedVarsReturnsOnCall == nil {
+		fake.saveResolvedVarsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveResolvedVarsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) Schema() string {
 	fake.schemaMutex.Lock()
 	ret, specificReturn := fake.schemaReturnsOnCall[len(fake.schemaArgsForCall)]
@@ -4336,6 +5143,75 @@ func (fake *FakeBuild) StartReturnsOnCall(i int, result1 bool, result2 error) {
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) StartMatrix(arg1 []atc.MatrixValues) (bool, error) {
+	var arg1Copy []atc.MatrixValues
+	if arg1 != nil {
+		arg1Copy = make([]atc.MatrixValues, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.startMatrixMutex.Lock()
+	ret, specificReturn := fake.startMatrixReturnsOnCall[len(fake.startMatrixArgsForCall)]
+	fake.startMatrixArgsForCall = append(fake.startMatrixArgsForCall, struct {
+		arg1 []atc.MatrixValues
+	}{arg1Copy})
+	stub := fake.StartMatrixStub
+	fakeReturns := fake.startMatrixReturns
+	fake.recordInvocation("StartMatrix", []interface{}{arg1Copy})
+	fake.startMatrixMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) StartMatrixCallCount() int {
+	fake.startMatrixMutex.RLock()
+	defer fake.startMatrixMutex.RUnlock()
+	return len(fake.startMatrixArgsForCall)
+}
+
+func (fake *FakeBuild) StartMatrixCalls(stub func([]atc.MatrixValues) (bool, error)) {
+	fake.startMatrixMutex.Lock()
+	defer fake.startMatrixMutex.Unlock()
+	fake.StartMatrixStub = stub
+}
+
+func (fake *FakeBuild) StartMatrixArgsForCall(i int) []atc.MatrixValues {
+	fake.startMatrixMutex.RLock()
+	defer fake.startMatrixMutex.RUnlock()
+	argsForCall := fake.startMatrixArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) StartMatrixReturns(result1 bool, result2 error) {
+	fake.startMatrixMutex.Lock()
+	defer fake.startMatrixMutex.Unlock()
+	fake.StartMatrixStub = nil
+	fake.startMatrixReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) StartMatrixReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.startMatrixMutex.Lock()
+	defer fake.startMatrixMutex.Unlock()
+	fake.StartMatrixStub = nil
+	if fake.startMatrixReturnsOnCall == nil {
+		fake.startMatrixReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.startMatrixReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) StartTime() time.Time {
 	fake.startTimeMutex.Lock()
 	ret, specificReturn := fake.startTimeReturnsOnCall[len(fake.startTimeArgsForCall)]
@@ -2,7 +2,9 @@
 package dbfakes
 
 import (
+	"context"
 	"sync"
+	"time"
 
 	"github.com/concourse/concourse/atc/db"
 )
@@ -53,6 +55,19 @@ type FakeBuildFactory struct {
 		result2 bool
 		result3 error
 	}
+	DeleteUnusedArchivedBuildEventsStub        func(context.Context) (int, error)
+	deleteUnusedArchivedBuildEventsMutex       sync.RWMutex
+	deleteUnusedArchivedBuildEventsArgsForCall []struct {
+		arg1 context.Context
+	}
+	deleteUnusedArchivedBuildEventsReturns struct {
+		result1 int
+		result2 error
+	}
+	deleteUnusedArchivedBuildEventsReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
 	GetAllStartedBuildsStub        func() ([]db.Build, error)
 	getAllStartedBuildsMutex       sync.RWMutex
 	getAllStartedBuildsArgsForCall []struct {
@@ -65,6 +80,21 @@ type FakeBuildFactory struct {
 		result1 []db.Build
 		result2 error
 	}
+	GetArchivableBuildsStub        func(time.Time, bool, int) ([]db.Build, error)
+	getArchivableBuildsMutex       sync.RWMutex
+	getArchivableBuildsArgsForCall []struct {
+		arg1 time.Time
+		arg2 bool
+		arg3 int
+	}
+	getArchivableBuildsReturns struct {
+		result1 []db.Build
+		result2 error
+	}
+	getArchivableBuildsReturnsOnCall map[int]struct {
+		result1 []db.Build
+		result2 error
+	}
 	GetDrainableBuildsStub        func() ([]db.Build, error)
 	getDrainableBuildsMutex       sync.RWMutex
 	getDrainableBuildsArgsForCall []struct {
@@ -323,6 +353,70 @@ func (fake *FakeBuildFactory) BuildForAPIReturnsOnCall(i int, result1 db.BuildFo
 	}{result1, result2, result3}
 }
 
+func (fake *FakeBuildFactory) DeleteUnusedArchivedBuildEvents(arg1 context.Context) (int, error) {
+	fake.deleteUnusedArchivedBuildEventsMutex.Lock()
+	ret, specificReturn := fake.deleteUnusedArchivedBuildEventsReturnsOnCall[len(fake.deleteUnusedArchivedBuildEventsArgsForCall)]
+	fake.deleteUnusedArchivedBuildEventsArgsForCall = append(fake.deleteUnusedArchivedBuildEventsArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.DeleteUnusedArchivedBuildEventsStub
+	fakeReturns := fake.deleteUnusedArchivedBuildEventsReturns
+	fake.recordInvocation("DeleteUnusedArchivedBuildEvents", []interface{}{arg1})
+	fake.deleteUnusedArchivedBuildEventsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuildFactory) DeleteUnusedArchivedBuildEventsCallCount() int {
+	fake.deleteUnusedArchivedBuildEventsMutex.RLock()
+	defer fake.deleteUnusedArchivedBuildEventsMutex.RUnlock()
+	return len(fake.deleteUnusedArchivedBuildEventsArgsForCall)
+}
+
+func (fake *FakeBuildFactory) DeleteUnusedArchivedBuildEventsCalls(stub func(context.Context) (int, error)) {
+	fake.deleteUnusedArchivedBuildEventsMutex.Lock()
+	defer fake.deleteUnusedArchivedBuildEventsMutex.Unlock()
+	fake.DeleteUnusedArchivedBuildEventsStub = stub
+}
+
+func (fake *FakeBuildFactory) DeleteUnusedArchivedBuildEventsArgsForCall(i int) context.Context {
+	fake.deleteUnusedArchivedBuildEventsMutex.RLock()
+	defer fake.deleteUnusedArchivedBuildEventsMutex.RUnlock()
+	argsForCall := fake.deleteUnusedArchivedBuildEventsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuildFactory) DeleteUnusedArchivedBuildEventsReturns(result1 int, result2 error) {
+	fake.deleteUnusedArchivedBuildEventsMutex.Lock()
+	defer fake.deleteUnusedArchivedBuildEventsMutex.Unlock()
+	fake.DeleteUnusedArchivedBuildEventsStub = nil
+	fake.deleteUnusedArchivedBuildEventsReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildFactory) DeleteUnusedArchivedBuildEventsReturnsOnCall(i int, result1 int, result2 error) {
+	fake.deleteUnusedArchivedBuildEventsMutex.Lock()
+	defer fake.deleteUnusedArchivedBuildEventsMutex.Unlock()
+	fake.DeleteUnusedArchivedBuildEventsStub = nil
+	if fake.deleteUnusedArchivedBuildEventsReturnsOnCall == nil {
+		fake.deleteUnusedArchivedBuildEventsReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.deleteUnusedArchivedBuildEventsReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuildFactory) GetAllStartedBuilds() ([]db.Build, error) {
 	fake.getAllStartedBuildsMutex.Lock()
 	ret, specificReturn := fake.getAllStartedBuildsReturnsOnCall[len(fake.getAllStartedBuildsArgsForCall)]
@@ -379,6 +473,72 @@ func (fake *FakeBuildFactory) GetAllStartedBuildsReturnsOnCall(i int, result1 []
 	}{result1, result2}
 }
 
+func (fake *FakeBuildFactory) GetArchivableBuilds(arg1 time.Time, arg2 bool, arg3 int) ([]db.Build, error) {
+	fake.getArchivableBuildsMutex.Lock()
+	ret, specificReturn := fake.getArchivableBuildsReturnsOnCall[len(fake.getArchivableBuildsArgsForCall)]
+	fake.getArchivableBuildsArgsForCall = append(fake.getArchivableBuildsArgsForCall, struct {
+		arg1 time.Time
+		arg2 bool
+		arg3 int
+	}{arg1, arg2, arg3})
+	stub := fake.GetArchivableBuildsStub
+	fakeReturns := fake.getArchivableBuildsReturns
+	fake.recordInvocation("GetArchivableBuilds", []interface{}{arg1, arg2, arg3})
+	fake.getArchivableBuildsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuildFactory) GetArchivableBuildsCallCount() int {
+	fake.getArchivableBuildsMutex.RLock()
+	defer fake.getArchivableBuildsMutex.RUnlock()
+	return len(fake.getArchivableBuildsArgsForCall)
+}
+
+func (fake *FakeBuildFactory) GetArchivableBuildsCalls(stub func(time.Time, bool, int) ([]db.Build, error)) {
+	fake.getArchivableBuildsMutex.Lock()
+	defer fake.getArchivableBuildsMutex.Unlock()
+	fake.GetArchivableBuildsStub = stub
+}
+
+func (fake *FakeBuildFactory) GetArchivableBuildsArgsForCall(i int) (time.Time, bool, int) {
+	fake.getArchivableBuildsMutex.RLock()
+	defer fake.getArchivableBuildsMutex.RUnlock()
+	argsForCall := fake.getArchivableBuildsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeBuildFactory) GetArchivableBuildsReturns(result1 []db.Build, result2 error) {
+	fake.getArchivableBuildsMutex.Lock()
+	defer fake.getArchivableBuildsMutex.Unlock()
+	fake.GetArchivableBuildsStub = nil
+	fake.getArchivableBuildsReturns = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildFactory) GetArchivableBuildsReturnsOnCall(i int, result1 []db.Build, result2 error) {
+	fake.getArchivableBuildsMutex.Lock()
+	defer fake.getArchivableBuildsMutex.Unlock()
+	fake.GetArchivableBuildsStub = nil
+	if fake.getArchivableBuildsReturnsOnCall == nil {
+		fake.getArchivableBuildsReturnsOnCall = make(map[int]struct {
+			result1 []db.Build
+			result2 error
+		})
+	}
+	fake.getArchivableBuildsReturnsOnCall[i] = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuildFactory) GetDrainableBuilds() ([]db.Build, error) {
 	fake.getDrainableBuildsMutex.Lock()
 	ret, specificReturn := fake.getDrainableBuildsReturnsOnCall[len(fake.getDrainableBuildsArgsForCall)]
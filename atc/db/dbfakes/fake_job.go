@@ -139,6 +139,21 @@ type FakeJob struct {
 		result1 db.Build
 		result2 error
 	}
+	CreateScheduledBuildStub        func(time.Time) (db.Build, bool, error)
+	createScheduledBuildMutex       sync.RWMutex
+	createScheduledBuildArgsForCall []struct {
+		arg1 time.Time
+	}
+	createScheduledBuildReturns struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}
+	createScheduledBuildReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}
 	DisableManualTriggerStub        func() bool
 	disableManualTriggerMutex       sync.RWMutex
 	disableManualTriggerArgsForCall []struct {
@@ -296,6 +311,16 @@ type FakeJob struct {
 	nameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	NextScheduledTriggerStub        func() time.Time
+	nextScheduledTriggerMutex       sync.RWMutex
+	nextScheduledTriggerArgsForCall []struct {
+	}
+	nextScheduledTriggerReturns struct {
+		result1 time.Time
+	}
+	nextScheduledTriggerReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
 	OutputsStub        func() ([]atc.JobOutput, error)
 	outputsMutex       sync.RWMutex
 	outputsArgsForCall []struct {
@@ -423,6 +448,16 @@ type FakeJob struct {
 	pipelineRefReturnsOnCall map[int]struct {
 		result1 atc.PipelineRef
 	}
+	PriorityStub        func() int
+	priorityMutex       sync.RWMutex
+	priorityArgsForCall []struct {
+	}
+	priorityReturns struct {
+		result1 int
+	}
+	priorityReturnsOnCall map[int]struct {
+		result1 int
+	}
 	PublicStub        func() bool
 	publicMutex       sync.RWMutex
 	publicArgsForCall []struct {
@@ -515,6 +550,19 @@ type FakeJob struct {
 	setHasNewInputsReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SkipScheduledTriggerStub        func(time.Time) (bool, error)
+	skipScheduledTriggerMutex       sync.RWMutex
+	skipScheduledTriggerArgsForCall []struct {
+		arg1 time.Time
+	}
+	skipScheduledTriggerReturns struct {
+		result1 bool
+		result2 error
+	}
+	skipScheduledTriggerReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	TagsStub        func() []string
 	tagsMutex       sync.RWMutex
 	tagsArgsForCall []struct {
@@ -1157,6 +1205,73 @@ func (fake *FakeJob) CreateBuildReturnsOnCall(i int, result1 db.Build, result2 e
 	}{result1, result2}
 }
 
+func (fake *FakeJob) CreateScheduledBuild(arg1 time.Time) (db.Build, bool, error) {
+	fake.createScheduledBuildMutex.Lock()
+	ret, specificReturn := fake.createScheduledBuildReturnsOnCall[len(fake.createScheduledBuildArgsForCall)]
+	fake.createScheduledBuildArgsForCall = append(fake.createScheduledBuildArgsForCall, struct {
+		arg1 time.Time
+	}{arg1})
+	stub := fake.CreateScheduledBuildStub
+	fakeReturns := fake.createScheduledBuildReturns
+	fake.recordInvocation("CreateScheduledBuild", []interface{}{arg1})
+	fake.createScheduledBuildMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeJob) CreateScheduledBuildCallCount() int {
+	fake.createScheduledBuildMutex.RLock()
+	defer fake.createScheduledBuildMutex.RUnlock()
+	return len(fake.createScheduledBuildArgsForCall)
+}
+
+func (fake *FakeJob) CreateScheduledBuildCalls(stub func(time.Time) (db.Build, bool, error)) {
+	fake.createScheduledBuildMutex.Lock()
+	defer fake.createScheduledBuildMutex.Unlock()
+	fake.CreateScheduledBuildStub = stub
+}
+
+func (fake *FakeJob) CreateScheduledBuildArgsForCall(i int) time.Time {
+	fake.createScheduledBuildMutex.RLock()
+	defer fake.createScheduledBuildMutex.RUnlock()
+	argsForCall := fake.createScheduledBuildArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeJob) CreateScheduledBuildReturns(result1 db.Build, result2 bool, result3 error) {
+	fake.createScheduledBuildMutex.Lock()
+	defer fake.createScheduledBuildMutex.Unlock()
+	fake.CreateScheduledBuildStub = nil
+	fake.createScheduledBuildReturns = struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeJob) CreateScheduledBuildReturnsOnCall(i int, result1 db.Build, result2 bool, result3 error) {
+	fake.createScheduledBuildMutex.Lock()
+	defer fake.createScheduledBuildMutex.Unlock()
+	fake.CreateScheduledBuildStub = nil
+	if fake.createScheduledBuildReturnsOnCall == nil {
+		fake.createScheduledBuildReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 bool
+			result3 error
+		})
+	}
+	fake.createScheduledBuildReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeJob) DisableManualTrigger() bool {
 	fake.disableManualTriggerMutex.Lock()
 	ret, specificReturn := fake.disableManualTriggerReturnsOnCall[len(fake.disableManualTriggerArgsForCall)]
@@ -1931,6 +2046,59 @@ func (fake *FakeJob) NameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeJob) NextScheduledTrigger() time.Time {
+	fake.nextScheduledTriggerMutex.Lock()
+	ret, specificReturn := fake.nextScheduledTriggerReturnsOnCall[len(fake.nextScheduledTriggerArgsForCall)]
+	fake.nextScheduledTriggerArgsForCall = append(fake.nextScheduledTriggerArgsForCall, struct {
+	}{})
+	stub := fake.NextScheduledTriggerStub
+	fakeReturns := fake.nextScheduledTriggerReturns
+	fake.recordInvocation("NextScheduledTrigger", []interface{}{})
+	fake.nextScheduledTriggerMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeJob) NextScheduledTriggerCallCount() int {
+	fake.nextScheduledTriggerMutex.RLock()
+	defer fake.nextScheduledTriggerMutex.RUnlock()
+	return len(fake.nextScheduledTriggerArgsForCall)
+}
+
+func (fake *FakeJob) NextScheduledTriggerCalls(stub func() time.Time) {
+	fake.nextScheduledTriggerMutex.Lock()
+	defer fake.nextScheduledTriggerMutex.Unlock()
+	fake.NextScheduledTriggerStub = stub
+}
+
+func (fake *FakeJob) NextScheduledTriggerReturns(result1 time.Time) {
+	fake.nextScheduledTriggerMutex.Lock()
+	defer fake.nextScheduledTriggerMutex.Unlock()
+	fake.NextScheduledTriggerStub = nil
+	fake.nextScheduledTriggerReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeJob) NextScheduledTriggerReturnsOnCall(i int, result1 time.Time) {
+	fake.nextScheduledTriggerMutex.Lock()
+	defer fake.nextScheduledTriggerMutex.Unlock()
+	fake.NextScheduledTriggerStub = nil
+	if fake.nextScheduledTriggerReturnsOnCall == nil {
+		fake.nextScheduledTriggerReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.nextScheduledTriggerReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
 func (fake *FakeJob) Outputs() ([]atc.JobOutput, error) {
 	fake.outputsMutex.Lock()
 	ret, specificReturn := fake.outputsReturnsOnCall[len(fake.outputsArgsForCall)]
@@ -2584,6 +2752,59 @@ func (fake *FakeJob) PipelineRefReturnsOnCall(i int, result1 atc.PipelineRef) {
 	}{result1}
 }
 
+func (fake *FakeJob) Priority() int {
+	fake.priorityMutex.Lock()
+	ret, specificReturn := fake.priorityReturnsOnCall[len(fake.priorityArgsForCall)]
+	fake.priorityArgsForCall = append(fake.priorityArgsForCall, struct {
+	}{})
+	stub := fake.PriorityStub
+	fakeReturns := fake.priorityReturns
+	fake.recordInvocation("Priority", []interface{}{})
+	fake.priorityMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeJob) PriorityCallCount() int {
+	fake.priorityMutex.RLock()
+	defer fake.priorityMutex.RUnlock()
+	return len(fake.priorityArgsForCall)
+}
+
+func (fake *FakeJob) PriorityCalls(stub func() int) {
+	fake.priorityMutex.Lock()
+	defer fake.priorityMutex.Unlock()
+	fake.PriorityStub = stub
+}
+
+func (fake *FakeJob) PriorityReturns(result1 int) {
+	fake.priorityMutex.Lock()
+	defer fake.priorityMutex.Unlock()
+	fake.PriorityStub = nil
+	fake.priorityReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeJob) PriorityReturnsOnCall(i int, result1 int) {
+	fake.priorityMutex.Lock()
+	defer fake.priorityMutex.Unlock()
+	fake.PriorityStub = nil
+	if fake.priorityReturnsOnCall == nil {
+		fake.priorityReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.priorityReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
 func (fake *FakeJob) Public() bool {
 	fake.publicMutex.Lock()
 	ret, specificReturn := fake.publicReturnsOnCall[len(fake.publicArgsForCall)]
@@ -3051,6 +3272,70 @@ func (fake *FakeJob) SetHasNewInputsReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeJob) SkipScheduledTrigger(arg1 time.Time) (bool, error) {
+	fake.skipScheduledTriggerMutex.Lock()
+	ret, specificReturn := fake.skipScheduledTriggerReturnsOnCall[len(fake.skipScheduledTriggerArgsForCall)]
+	fake.skipScheduledTriggerArgsForCall = append(fake.skipScheduledTriggerArgsForCall, struct {
+		arg1 time.Time
+	}{arg1})
+	stub := fake.SkipScheduledTriggerStub
+	fakeReturns := fake.skipScheduledTriggerReturns
+	fake.recordInvocation("SkipScheduledTrigger", []interface{}{arg1})
+	fake.skipScheduledTriggerMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeJob) SkipScheduledTriggerCallCount() int {
+	fake.skipScheduledTriggerMutex.RLock()
+	defer fake.skipScheduledTriggerMutex.RUnlock()
+	return len(fake.skipScheduledTriggerArgsForCall)
+}
+
+func (fake *FakeJob) SkipScheduledTriggerCalls(stub func(time.Time) (bool, error)) {
+	fake.skipScheduledTriggerMutex.Lock()
+	defer fake.skipScheduledTriggerMutex.Unlock()
+	fake.SkipScheduledTriggerStub = stub
+}
+
+func (fake *FakeJob) SkipScheduledTriggerArgsForCall(i int) time.Time {
+	fake.skipScheduledTriggerMutex.RLock()
+	defer fake.skipScheduledTriggerMutex.RUnlock()
+	argsForCall := fake.skipScheduledTriggerArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeJob) SkipScheduledTriggerReturns(result1 bool, result2 error) {
+	fake.skipScheduledTriggerMutex.Lock()
+	defer fake.skipScheduledTriggerMutex.Unlock()
+	fake.SkipScheduledTriggerStub = nil
+	fake.skipScheduledTriggerReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) SkipScheduledTriggerReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.skipScheduledTriggerMutex.Lock()
+	defer fake.skipScheduledTriggerMutex.Unlock()
+	fake.SkipScheduledTriggerStub = nil
+	if fake.skipScheduledTriggerReturnsOnCall == nil {
+		fake.skipScheduledTriggerReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.skipScheduledTriggerReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeJob) Tags() []string {
 	fake.tagsMutex.Lock()
 	ret, specificReturn := fake.tagsReturnsOnCall[len(fake.tagsArgsForCall)]
@@ -33,6 +33,30 @@ type FakeJobFactory struct {
 		result1 db.SchedulerJobs
 		result2 error
 	}
+	JobsToTriggerStub        func() (db.Jobs, error)
+	jobsToTriggerMutex       sync.RWMutex
+	jobsToTriggerArgsForCall []struct {
+	}
+	jobsToTriggerReturns struct {
+		result1 db.Jobs
+		result2 error
+	}
+	jobsToTriggerReturnsOnCall map[int]struct {
+		result1 db.Jobs
+		result2 error
+	}
+	TeamBuildQueuesStub        func() ([]db.TeamBuildQueue, error)
+	teamBuildQueuesMutex       sync.RWMutex
+	teamBuildQueuesArgsForCall []struct {
+	}
+	teamBuildQueuesReturns struct {
+		result1 []db.TeamBuildQueue
+		result2 error
+	}
+	teamBuildQueuesReturnsOnCall map[int]struct {
+		result1 []db.TeamBuildQueue
+		result2 error
+	}
 	VisibleJobsStub        func([]string) ([]atc.JobSummary, error)
 	visibleJobsMutex       sync.RWMutex
 	visibleJobsArgsForCall []struct {
@@ -162,6 +186,118 @@ func (fake *FakeJobFactory) JobsToScheduleReturnsOnCall(i int, result1 db.Schedu
 	}{result1, result2}
 }
 
+func (fake *FakeJobFactory) JobsToTrigger() (db.Jobs, error) {
+	fake.jobsToTriggerMutex.Lock()
+	ret, specificReturn := fake.jobsToTriggerReturnsOnCall[len(fake.jobsToTriggerArgsForCall)]
+	fake.jobsToTriggerArgsForCall = append(fake.jobsToTriggerArgsForCall, struct {
+	}{})
+	stub := fake.JobsToTriggerStub
+	fakeReturns := fake.jobsToTriggerReturns
+	fake.recordInvocation("JobsToTrigger", []interface{}{})
+	fake.jobsToTriggerMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeJobFactory) JobsToTriggerCallCount() int {
+	fake.jobsToTriggerMutex.RLock()
+	defer fake.jobsToTriggerMutex.RUnlock()
+	return len(fake.jobsToTriggerArgsForCall)
+}
+
+func (fake *FakeJobFactory) JobsToTriggerCalls(stub func() (db.Jobs, error)) {
+	fake.jobsToTriggerMutex.Lock()
+	defer fake.jobsToTriggerMutex.Unlock()
+	fake.JobsToTriggerStub = stub
+}
+
+func (fake *FakeJobFactory) JobsToTriggerReturns(result1 db.Jobs, result2 error) {
+	fake.jobsToTriggerMutex.Lock()
+	defer fake.jobsToTriggerMutex.Unlock()
+	fake.JobsToTriggerStub = nil
+	fake.jobsToTriggerReturns = struct {
+		result1 db.Jobs
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJobFactory) JobsToTriggerReturnsOnCall(i int, result1 db.Jobs, result2 error) {
+	fake.jobsToTriggerMutex.Lock()
+	defer fake.jobsToTriggerMutex.Unlock()
+	fake.JobsToTriggerStub = nil
+	if fake.jobsToTriggerReturnsOnCall == nil {
+		fake.jobsToTriggerReturnsOnCall = make(map[int]struct {
+			result1 db.Jobs
+			result2 error
+		})
+	}
+	fake.jobsToTriggerReturnsOnCall[i] = struct {
+		result1 db.Jobs
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJobFactory) TeamBuildQueues() ([]db.TeamBuildQueue, error) {
+	fake.teamBuildQueuesMutex.Lock()
+	ret, specificReturn := fake.teamBuildQueuesReturnsOnCall[len(fake.teamBuildQueuesArgsForCall)]
+	fake.teamBuildQueuesArgsForCall = append(fake.teamBuildQueuesArgsForCall, struct {
+	}{})
+	stub := fake.TeamBuildQueuesStub
+	fakeReturns := fake.teamBuildQueuesReturns
+	fake.recordInvocation("TeamBuildQueues", []interface{}{})
+	fake.teamBuildQueuesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeJobFactory) TeamBuildQueuesCallCount() int {
+	fake.teamBuildQueuesMutex.RLock()
+	defer fake.teamBuildQueuesMutex.RUnlock()
+	return len(fake.teamBuildQueuesArgsForCall)
+}
+
+func (fake *FakeJobFactory) TeamBuildQueuesCalls(stub func() ([]db.TeamBuildQueue, error)) {
+	fake.teamBuildQueuesMutex.Lock()
+	defer fake.teamBuildQueuesMutex.Unlock()
+	fake.TeamBuildQueuesStub = stub
+}
+
+func (fake *FakeJobFactory) TeamBuildQueuesReturns(result1 []db.TeamBuildQueue, result2 error) {
+	fake.teamBuildQueuesMutex.Lock()
+	defer fake.teamBuildQueuesMutex.Unlock()
+	fake.TeamBuildQueuesStub = nil
+	fake.teamBuildQueuesReturns = struct {
+		result1 []db.TeamBuildQueue
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJobFactory) TeamBuildQueuesReturnsOnCall(i int, result1 []db.TeamBuildQueue, result2 error) {
+	fake.teamBuildQueuesMutex.Lock()
+	defer fake.teamBuildQueuesMutex.Unlock()
+	fake.TeamBuildQueuesStub = nil
+	if fake.teamBuildQueuesReturnsOnCall == nil {
+		fake.teamBuildQueuesReturnsOnCall = make(map[int]struct {
+			result1 []db.TeamBuildQueue
+			result2 error
+		})
+	}
+	fake.teamBuildQueuesReturnsOnCall[i] = struct {
+		result1 []db.TeamBuildQueue
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeJobFactory) VisibleJobs(arg1 []string) ([]atc.JobSummary, error) {
 	var arg1Copy []string
 	if arg1 != nil {
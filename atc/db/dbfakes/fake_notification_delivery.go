@@ -0,0 +1,1143 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeNotificationDelivery struct {
+	AttemptsStub        func() int
+	attemptsMutex       sync.RWMutex
+	attemptsArgsForCall []struct {
+	}
+	attemptsReturns struct {
+		result1 int
+	}
+	attemptsReturnsOnCall map[int]struct {
+		result1 int
+	}
+	BuildIDStub        func() int
+	buildIDMutex       sync.RWMutex
+	buildIDArgsForCall []struct {
+	}
+	buildIDReturns struct {
+		result1 int
+	}
+	buildIDReturnsOnCall map[int]struct {
+		result1 int
+	}
+	BuildNameStub        func() string
+	buildNameMutex       sync.RWMutex
+	buildNameArgsForCall []struct {
+	}
+	buildNameReturns struct {
+		result1 string
+	}
+	buildNameReturnsOnCall map[int]struct {
+		result1 string
+	}
+	BuildStatusStub        func() db.BuildStatus
+	buildStatusMutex       sync.RWMutex
+	buildStatusArgsForCall []struct {
+	}
+	buildStatusReturns struct {
+		result1 db.BuildStatus
+	}
+	buildStatusReturnsOnCall map[int]struct {
+		result1 db.BuildStatus
+	}
+	CreatedAtStub        func() time.Time
+	createdAtMutex       sync.RWMutex
+	createdAtArgsForCall []struct {
+	}
+	createdAtReturns struct {
+		result1 time.Time
+	}
+	createdAtReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
+	FailStub        func(int, string) error
+	failMutex       sync.RWMutex
+	failArgsForCall []struct {
+		arg1 int
+		arg2 string
+	}
+	failReturns struct {
+		result1 error
+	}
+	failReturnsOnCall map[int]struct {
+		result1 error
+	}
+	IDStub        func() int
+	iDMutex       sync.RWMutex
+	iDArgsForCall []struct {
+	}
+	iDReturns struct {
+		result1 int
+	}
+	iDReturnsOnCall map[int]struct {
+		result1 int
+	}
+	JobNameStub        func() string
+	jobNameMutex       sync.RWMutex
+	jobNameArgsForCall []struct {
+	}
+	jobNameReturns struct {
+		result1 string
+	}
+	jobNameReturnsOnCall map[int]struct {
+		result1 string
+	}
+	LastErrorStub        func() string
+	lastErrorMutex       sync.RWMutex
+	lastErrorArgsForCall []struct {
+	}
+	lastErrorReturns struct {
+		result1 string
+	}
+	lastErrorReturnsOnCall map[int]struct {
+		result1 string
+	}
+	NameStub        func() string
+	nameMutex       sync.RWMutex
+	nameArgsForCall []struct {
+	}
+	nameReturns struct {
+		result1 string
+	}
+	nameReturnsOnCall map[int]struct {
+		result1 string
+	}
+	PipelineIDStub        func() int
+	pipelineIDMutex       sync.RWMutex
+	pipelineIDArgsForCall []struct {
+	}
+	pipelineIDReturns struct {
+		result1 int
+	}
+	pipelineIDReturnsOnCall map[int]struct {
+		result1 int
+	}
+	PreviousStatusStub        func() db.BuildStatus
+	previousStatusMutex       sync.RWMutex
+	previousStatusArgsForCall []struct {
+	}
+	previousStatusReturns struct {
+		result1 db.BuildStatus
+	}
+	previousStatusReturnsOnCall map[int]struct {
+		result1 db.BuildStatus
+	}
+	ResponseCodeStub        func() int
+	responseCodeMutex       sync.RWMutex
+	responseCodeArgsForCall []struct {
+	}
+	responseCodeReturns struct {
+		result1 int
+	}
+	responseCodeReturnsOnCall map[int]struct {
+		result1 int
+	}
+	RetryStub        func(int, string, time.Time) error
+	retryMutex       sync.RWMutex
+	retryArgsForCall []struct {
+		arg1 int
+		arg2 string
+		arg3 time.Time
+	}
+	retryReturns struct {
+		result1 error
+	}
+	retryReturnsOnCall map[int]struct {
+		result1 error
+	}
+	StatusStub        func() atc.NotificationDeliveryStatus
+	statusMutex       sync.RWMutex
+	statusArgsForCall []struct {
+	}
+	statusReturns struct {
+		result1 atc.NotificationDeliveryStatus
+	}
+	statusReturnsOnCall map[int]struct {
+		result1 atc.NotificationDeliveryStatus
+	}
+	SucceedStub        func(int) error
+	succeedMutex       sync.RWMutex
+	succeedArgsForCall []struct {
+		arg1 int
+	}
+	succeedReturns struct {
+		result1 error
+	}
+	succeedReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdatedAtStub        func() time.Time
+	updatedAtMutex       sync.RWMutex
+	updatedAtArgsForCall []struct {
+	}
+	updatedAtReturns struct {
+		result1 time.Time
+	}
+	updatedAtReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeNotificationDelivery) Attempts() int {
+	fake.attemptsMutex.Lock()
+	ret, specificReturn := fake.attemptsReturnsOnCall[len(fake.attemptsArgsForCall)]
+	fake.attemptsArgsForCall = append(fake.attemptsArgsForCall, struct {
+	}{})
+	stub := fake.AttemptsStub
+	fakeReturns := fake.attemptsReturns
+	fake.recordInvocation("Attempts", []interface{}{})
+	fake.attemptsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNotificationDelivery) AttemptsCallCount() int {
+	fake.attemptsMutex.RLock()
+	defer fake.attemptsMutex.RUnlock()
+	return len(fake.attemptsArgsForCall)
+}
+
+func (fake *FakeNotificationDelivery) AttemptsCalls(stub func() int) {
+	fake.attemptsMutex.Lock()
+	defer fake.attemptsMutex.Unlock()
+	fake.AttemptsStub = stub
+}
+
+func (fake *FakeNotificationDelivery) AttemptsReturns(result1 int) {
+	fake.attemptsMutex.Lock()
+	defer fake.attemptsMutex.Unlock()
+	fake.AttemptsStub = nil
+	fake.attemptsReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) AttemptsReturnsOnCall(i int, result1 int) {
+	fake.attemptsMutex.Lock()
+	defer fake.attemptsMutex.Unlock()
+	fake.AttemptsStub = nil
+	if fake.attemptsReturnsOnCall == nil {
+		fake.attemptsReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.attemptsReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) BuildID() int {
+	fake.buildIDMutex.Lock()
+	ret, specificReturn := fake.buildIDReturnsOnCall[len(fake.buildIDArgsForCall)]
+	fake.buildIDArgsForCall = append(fake.buildIDArgsForCall, struct {
+	}{})
+	stub := fake.BuildIDStub
+	fakeReturns := fake.buildIDReturns
+	fake.recordInvocation("BuildID", []interface{}{})
+	fake.buildIDMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNotificationDelivery) BuildIDCallCount() int {
+	fake.buildIDMutex.RLock()
+	defer fake.buildIDMutex.RUnlock()
+	return len(fake.buildIDArgsForCall)
+}
+
+func (fake *FakeNotificationDelivery) BuildIDCalls(stub func() int) {
+	fake.buildIDMutex.Lock()
+	defer fake.buildIDMutex.Unlock()
+	fake.BuildIDStub = stub
+}
+
+func (fake *FakeNotificationDelivery) BuildIDReturns(result1 int) {
+	fake.buildIDMutex.Lock()
+	defer fake.buildIDMutex.Unlock()
+	fake.BuildIDStub = nil
+	fake.buildIDReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) BuildIDReturnsOnCall(i int, result1 int) {
+	fake.buildIDMutex.Lock()
+	defer fake.buildIDMutex.Unlock()
+	fake.BuildIDStub = nil
+	if fake.buildIDReturnsOnCall == nil {
+		fake.buildIDReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.buildIDReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) BuildName() string {
+	fake.buildNameMutex.Lock()
+	ret, specificReturn := fake.buildNameReturnsOnCall[len(fake.buildNameArgsForCall)]
+	fake.buildNameArgsForCall = append(fake.buildNameArgsForCall, struct {
+	}{})
+	stub := fake.BuildNameStub
+	fakeReturns := fake.buildNameReturns
+	fake.recordInvocation("BuildName", []interface{}{})
+	fake.buildNameMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNotificationDelivery) BuildNameCallCount() int {
+	fake.buildNameMutex.RLock()
+	defer fake.buildNameMutex.RUnlock()
+	return len(fake.buildNameArgsForCall)
+}
+
+func (fake *FakeNotificationDelivery) BuildNameCalls(stub func() string) {
+	fake.buildNameMutex.Lock()
+	defer fake.buildNameMutex.Unlock()
+	fake.BuildNameStub = stub
+}
+
+func (fake *FakeNotificationDelivery) BuildNameReturns(result1 string) {
+	fake.buildNameMutex.Lock()
+	defer fake.buildNameMutex.Unlock()
+	fake.BuildNameStub = nil
+	fake.buildNameReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) BuildNameReturnsOnCall(i int, result1 string) {
+	fake.buildNameMutex.Lock()
+	defer fake.buildNameMutex.Unlock()
+	fake.BuildNameStub = nil
+	if fake.buildNameReturnsOnCall == nil {
+		fake.buildNameReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.buildNameReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) BuildStatus() db.BuildStatus {
+	fake.buildStatusMutex.Lock()
+	ret, specificReturn := fake.buildStatusReturnsOnCall[len(fake.buildStatusArgsForCall)]
+	fake.buildStatusArgsForCall = append(fake.buildStatusArgsForCall, struct {
+	}{})
+	stub := fake.BuildStatusStub
+	fakeReturns := fake.buildStatusReturns
+	fake.recordInvocation("BuildStatus", []interface{}{})
+	fake.buildStatusMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNotificationDelivery) BuildStatusCallCount() int {
+	fake.buildStatusMutex.RLock()
+	defer fake.buildStatusMutex.RUnlock()
+	return len(fake.buildStatusArgsForCall)
+}
+
+func (fake *FakeNotificationDelivery) BuildStatusCalls(stub func() db.BuildStatus) {
+	fake.buildStatusMutex.Lock()
+	defer fake.buildStatusMutex.Unlock()
+	fake.BuildStatusStub = stub
+}
+
+func (fake *FakeNotificationDelivery) BuildStatusReturns(result1 db.BuildStatus) {
+	fake.buildStatusMutex.Lock()
+	defer fake.buildStatusMutex.Unlock()
+	fake.BuildStatusStub = nil
+	fake.buildStatusReturns = struct {
+		result1 db.BuildStatus
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) BuildStatusReturnsOnCall(i int, result1 db.BuildStatus) {
+	fake.buildStatusMutex.Lock()
+	defer fake.buildStatusMutex.Unlock()
+	fake.BuildStatusStub = nil
+	if fake.buildStatusReturnsOnCall == nil {
+		fake.buildStatusReturnsOnCall = make(map[int]struct {
+			result1 db.BuildStatus
+		})
+	}
+	fake.buildStatusReturnsOnCall[i] = struct {
+		result1 db.BuildStatus
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) CreatedAt() time.Time {
+	fake.createdAtMutex.Lock()
+	ret, specificReturn := fake.createdAtReturnsOnCall[len(fake.createdAtArgsForCall)]
+	fake.createdAtArgsForCall = append(fake.createdAtArgsForCall, struct {
+	}{})
+	stub := fake.CreatedAtStub
+	fakeReturns := fake.createdAtReturns
+	fake.recordInvocation("CreatedAt", []interface{}{})
+	fake.createdAtMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNotificationDelivery) CreatedAtCallCount() int {
+	fake.createdAtMutex.RLock()
+	defer fake.createdAtMutex.RUnlock()
+	return len(fake.createdAtArgsForCall)
+}
+
+func (fake *FakeNotificationDelivery) CreatedAtCalls(stub func() time.Time) {
+	fake.createdAtMutex.Lock()
+	defer fake.createdAtMutex.Unlock()
+	fake.CreatedAtStub = stub
+}
+
+func (fake *FakeNotificationDelivery) CreatedAtReturns(result1 time.Time) {
+	fake.createdAtMutex.Lock()
+	defer fake.createdAtMutex.Unlock()
+	fake.CreatedAtStub = nil
+	fake.createdAtReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) CreatedAtReturnsOnCall(i int, result1 time.Time) {
+	fake.createdAtMutex.Lock()
+	defer fake.createdAtMutex.Unlock()
+	fake.CreatedAtStub = nil
+	if fake.createdAtReturnsOnCall == nil {
+		fake.createdAtReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.createdAtReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) Fail(arg1 int, arg2 string) error {
+	fake.failMutex.Lock()
+	ret, specificReturn := fake.failReturnsOnCall[len(fake.failArgsForCall)]
+	fake.failArgsForCall = append(fake.failArgsForCall, struct {
+		arg1 int
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.FailStub
+	fakeReturns := fake.failReturns
+	fake.recordInvocation("Fail", []interface{}{arg1, arg2})
+	fake.failMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNotificationDelivery) FailCallCount() int {
+	fake.failMutex.RLock()
+	defer fake.failMutex.RUnlock()
+	return len(fake.failArgsForCall)
+}
+
+func (fake *FakeNotificationDelivery) FailCalls(stub func(int, string) error) {
+	fake.failMutex.Lock()
+	defer fake.failMutex.Unlock()
+	fake.FailStub = stub
+}
+
+func (fake *FakeNotificationDelivery) FailArgsForCall(i int) (int, string) {
+	fake.failMutex.RLock()
+	defer fake.failMutex.RUnlock()
+	argsForCall := fake.failArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeNotificationDelivery) FailReturns(result1 error) {
+	fake.failMutex.Lock()
+	defer fake.failMutex.Unlock()
+	fake.FailStub = nil
+	fake.failReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) FailReturnsOnCall(i int, result1 error) {
+	fake.failMutex.Lock()
+	defer fake.failMutex.Unlock()
+	fake.FailStub = nil
+	if fake.failReturnsOnCall == nil {
+		fake.failReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.failReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) ID() int {
+	fake.iDMutex.Lock()
+	ret, specificReturn := fake.iDReturnsOnCall[len(fake.iDArgsForCall)]
+	fake.iDArgsForCall = append(fake.iDArgsForCall, struct {
+	}{})
+	stub := fake.IDStub
+	fakeReturns := fake.iDReturns
+	fake.recordInvocation("ID", []interface{}{})
+	fake.iDMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNotificationDelivery) IDCallCount() int {
+	fake.iDMutex.RLock()
+	defer fake.iDMutex.RUnlock()
+	return len(fake.iDArgsForCall)
+}
+
+func (fake *FakeNotificationDelivery) IDCalls(stub func() int) {
+	fake.iDMutex.Lock()
+	defer fake.iDMutex.Unlock()
+	fake.IDStub = stub
+}
+
+func (fake *FakeNotificationDelivery) IDReturns(result1 int) {
+	fake.iDMutex.Lock()
+	defer fake.iDMutex.Unlock()
+	fake.IDStub = nil
+	fake.iDReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) IDReturnsOnCall(i int, result1 int) {
+	fake.iDMutex.Lock()
+	defer fake.iDMutex.Unlock()
+	fake.IDStub = nil
+	if fake.iDReturnsOnCall == nil {
+		fake.iDReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.iDReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) JobName() string {
+	fake.jobNameMutex.Lock()
+	ret, specificReturn := fake.jobNameReturnsOnCall[len(fake.jobNameArgsForCall)]
+	fake.jobNameArgsForCall = append(fake.jobNameArgsForCall, struct {
+	}{})
+	stub := fake.JobNameStub
+	fakeReturns := fake.jobNameReturns
+	fake.recordInvocation("JobName", []interface{}{})
+	fake.jobNameMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNotificationDelivery) JobNameCallCount() int {
+	fake.jobNameMutex.RLock()
+	defer fake.jobNameMutex.RUnlock()
+	return len(fake.jobNameArgsForCall)
+}
+
+func (fake *FakeNotificationDelivery) JobNameCalls(stub func() string) {
+	fake.jobNameMutex.Lock()
+	defer fake.jobNameMutex.Unlock()
+	fake.JobNameStub = stub
+}
+
+func (fake *FakeNotificationDelivery) JobNameReturns(result1 string) {
+	fake.jobNameMutex.Lock()
+	defer fake.jobNameMutex.Unlock()
+	fake.JobNameStub = nil
+	fake.jobNameReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) JobNameReturnsOnCall(i int, result1 string) {
+	fake.jobNameMutex.Lock()
+	defer fake.jobNameMutex.Unlock()
+	fake.JobNameStub = nil
+	if fake.jobNameReturnsOnCall == nil {
+		fake.jobNameReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.jobNameReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) LastError() string {
+	fake.lastErrorMutex.Lock()
+	ret, specificReturn := fake.lastErrorReturnsOnCall[len(fake.lastErrorArgsForCall)]
+	fake.lastErrorArgsForCall = append(fake.lastErrorArgsForCall, struct {
+	}{})
+	stub := fake.LastErrorStub
+	fakeReturns := fake.lastErrorReturns
+	fake.recordInvocation("LastError", []interface{}{})
+	fake.lastErrorMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNotificationDelivery) LastErrorCallCount() int {
+	fake.lastErrorMutex.RLock()
+	defer fake.lastErrorMutex.RUnlock()
+	return len(fake.lastErrorArgsForCall)
+}
+
+func (fake *FakeNotificationDelivery) LastErrorCalls(stub func() string) {
+	fake.lastErrorMutex.Lock()
+	defer fake.lastErrorMutex.Unlock()
+	fake.LastErrorStub = stub
+}
+
+func (fake *FakeNotificationDelivery) LastErrorReturns(result1 string) {
+	fake.lastErrorMutex.Lock()
+	defer fake.lastErrorMutex.Unlock()
+	fake.LastErrorStub = nil
+	fake.lastErrorReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) LastErrorReturnsOnCall(i int, result1 string) {
+	fake.lastErrorMutex.Lock()
+	defer fake.lastErrorMutex.Unlock()
+	fake.LastErrorStub = nil
+	if fake.lastErrorReturnsOnCall == nil {
+		fake.lastErrorReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.lastErrorReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) Name() string {
+	fake.nameMutex.Lock()
+	ret, specificReturn := fake.nameReturnsOnCall[len(fake.nameArgsForCall)]
+	fake.nameArgsForCall = append(fake.nameArgsForCall, struct {
+	}{})
+	stub := fake.NameStub
+	fakeReturns := fake.nameReturns
+	fake.recordInvocation("Name", []interface{}{})
+	fake.nameMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNotificationDelivery) NameCallCount() int {
+	fake.nameMutex.RLock()
+	defer fake.nameMutex.RUnlock()
+	return len(fake.nameArgsForCall)
+}
+
+func (fake *FakeNotificationDelivery) NameCalls(stub func() string) {
+	fake.nameMutex.Lock()
+	defer fake.nameMutex.Unlock()
+	fake.NameStub = stub
+}
+
+func (fake *FakeNotificationDelivery) NameReturns(result1 string) {
+	fake.nameMutex.Lock()
+	defer fake.nameMutex.Unlock()
+	fake.NameStub = nil
+	fake.nameReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) NameReturnsOnCall(i int, result1 string) {
+	fake.nameMutex.Lock()
+	defer fake.nameMutex.Unlock()
+	fake.NameStub = nil
+	if fake.nameReturnsOnCall == nil {
+		fake.nameReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.nameReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) PipelineID() int {
+	fake.pipelineIDMutex.Lock()
+	ret, specificReturn := fake.pipelineIDReturnsOnCall[len(fake.pipelineIDArgsForCall)]
+	fake.pipelineIDArgsForCall = append(fake.pipelineIDArgsForCall, struct {
+	}{})
+	stub := fake.PipelineIDStub
+	fakeReturns := fake.pipelineIDReturns
+	fake.recordInvocation("PipelineID", []interface{}{})
+	fake.pipelineIDMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNotificationDelivery) PipelineIDCallCount() int {
+	fake.pipelineIDMutex.RLock()
+	defer fake.pipelineIDMutex.RUnlock()
+	return len(fake.pipelineIDArgsForCall)
+}
+
+func (fake *FakeNotificationDelivery) PipelineIDCalls(stub func() int) {
+	fake.pipelineIDMutex.Lock()
+	defer fake.pipelineIDMutex.Unlock()
+	fake.PipelineIDStub = stub
+}
+
+func (fake *FakeNotificationDelivery) PipelineIDReturns(result1 int) {
+	fake.pipelineIDMutex.Lock()
+	defer fake.pipelineIDMutex.Unlock()
+	fake.PipelineIDStub = nil
+	fake.pipelineIDReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) PipelineIDReturnsOnCall(i int, result1 int) {
+	fake.pipelineIDMutex.Lock()
+	defer fake.pipelineIDMutex.Unlock()
+	fake.PipelineIDStub = nil
+	if fake.pipelineIDReturnsOnCall == nil {
+		fake.pipelineIDReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.pipelineIDReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) PreviousStatus() db.BuildStatus {
+	fake.previousStatusMutex.Lock()
+	ret, specificReturn := fake.previousStatusReturnsOnCall[len(fake.previousStatusArgsForCall)]
+	fake.previousStatusArgsForCall = append(fake.previousStatusArgsForCall, struct {
+	}{})
+	stub := fake.PreviousStatusStub
+	fakeReturns := fake.previousStatusReturns
+	fake.recordInvocation("PreviousStatus", []interface{}{})
+	fake.previousStatusMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNotificationDelivery) PreviousStatusCallCount() int {
+	fake.previousStatusMutex.RLock()
+	defer fake.previousStatusMutex.RUnlock()
+	return len(fake.previousStatusArgsForCall)
+}
+
+func (fake *FakeNotificationDelivery) PreviousStatusCalls(stub func() db.BuildStatus) {
+	fake.previousStatusMutex.Lock()
+	defer fake.previousStatusMutex.Unlock()
+	fake.PreviousStatusStub = stub
+}
+
+func (fake *FakeNotificationDelivery) PreviousStatusReturns(result1 db.BuildStatus) {
+	fake.previousStatusMutex.Lock()
+	defer fake.previousStatusMutex.Unlock()
+	fake.PreviousStatusStub = nil
+	fake.previousStatusReturns = struct {
+		result1 db.BuildStatus
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) PreviousStatusReturnsOnCall(i int, result1 db.BuildStatus) {
+	fake.previousStatusMutex.Lock()
+	defer fake.previousStatusMutex.Unlock()
+	fake.PreviousStatusStub = nil
+	if fake.previousStatusReturnsOnCall == nil {
+		fake.previousStatusReturnsOnCall = make(map[int]struct {
+			result1 db.BuildStatus
+		})
+	}
+	fake.previousStatusReturnsOnCall[i] = struct {
+		result1 db.BuildStatus
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) ResponseCode() int {
+	fake.responseCodeMutex.Lock()
+	ret, specificReturn := fake.responseCodeReturnsOnCall[len(fake.responseCodeArgsForCall)]
+	fake.responseCodeArgsForCall = append(fake.responseCodeArgsForCall, struct {
+	}{})
+	stub := fake.ResponseCodeStub
+	fakeReturns := fake.responseCodeReturns
+	fake.recordInvocation("ResponseCode", []interface{}{})
+	fake.responseCodeMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNotificationDelivery) ResponseCodeCallCount() int {
+	fake.responseCodeMutex.RLock()
+	defer fake.responseCodeMutex.RUnlock()
+	return len(fake.responseCodeArgsForCall)
+}
+
+func (fake *FakeNotificationDelivery) ResponseCodeCalls(stub func() int) {
+	fake.responseCodeMutex.Lock()
+	defer fake.responseCodeMutex.Unlock()
+	fake.ResponseCodeStub = stub
+}
+
+func (fake *FakeNotificationDelivery) ResponseCodeReturns(result1 int) {
+	fake.responseCodeMutex.Lock()
+	defer fake.responseCodeMutex.Unlock()
+	fake.ResponseCodeStub = nil
+	fake.responseCodeReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) ResponseCodeReturnsOnCall(i int, result1 int) {
+	fake.responseCodeMutex.Lock()
+	defer fake.responseCodeMutex.Unlock()
+	fake.ResponseCodeStub = nil
+	if fake.responseCodeReturnsOnCall == nil {
+		fake.responseCodeReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.responseCodeReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) Retry(arg1 int, arg2 string, arg3 time.Time) error {
+	fake.retryMutex.Lock()
+	ret, specificReturn := fake.retryReturnsOnCall[len(fake.retryArgsForCall)]
+	fake.retryArgsForCall = append(fake.retryArgsForCall, struct {
+		arg1 int
+		arg2 string
+		arg3 time.Time
+	}{arg1, arg2, arg3})
+	stub := fake.RetryStub
+	fakeReturns := fake.retryReturns
+	fake.recordInvocation("Retry", []interface{}{arg1, arg2, arg3})
+	fake.retryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNotificationDelivery) RetryCallCount() int {
+	fake.retryMutex.RLock()
+	defer fake.retryMutex.RUnlock()
+	return len(fake.retryArgsForCall)
+}
+
+func (fake *FakeNotificationDelivery) RetryCalls(stub func(int, string, time.Time) error) {
+	fake.retryMutex.Lock()
+	defer fake.retryMutex.Unlock()
+	fake.RetryStub = stub
+}
+
+func (fake *FakeNotificationDelivery) RetryArgsForCall(i int) (int, string, time.Time) {
+	fake.retryMutex.RLock()
+	defer fake.retryMutex.RUnlock()
+	argsForCall := fake.retryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeNotificationDelivery) RetryReturns(result1 error) {
+	fake.retryMutex.Lock()
+	defer fake.retryMutex.Unlock()
+	fake.RetryStub = nil
+	fake.retryReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) RetryReturnsOnCall(i int, result1 error) {
+	fake.retryMutex.Lock()
+	defer fake.retryMutex.Unlock()
+	fake.RetryStub = nil
+	if fake.retryReturnsOnCall == nil {
+		fake.retryReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.retryReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) Status() atc.NotificationDeliveryStatus {
+	fake.statusMutex.Lock()
+	ret, specificReturn := fake.statusReturnsOnCall[len(fake.statusArgsForCall)]
+	fake.statusArgsForCall = append(fake.statusArgsForCall, struct {
+	}{})
+	stub := fake.StatusStub
+	fakeReturns := fake.statusReturns
+	fake.recordInvocation("Status", []interface{}{})
+	fake.statusMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNotificationDelivery) StatusCallCount() int {
+	fake.statusMutex.RLock()
+	defer fake.statusMutex.RUnlock()
+	return len(fake.statusArgsForCall)
+}
+
+func (fake *FakeNotificationDelivery) StatusCalls(stub func() atc.NotificationDeliveryStatus) {
+	fake.statusMutex.Lock()
+	defer fake.statusMutex.Unlock()
+	fake.StatusStub = stub
+}
+
+func (fake *FakeNotificationDelivery) StatusReturns(result1 atc.NotificationDeliveryStatus) {
+	fake.statusMutex.Lock()
+	defer fake.statusMutex.Unlock()
+	fake.StatusStub = nil
+	fake.statusReturns = struct {
+		result1 atc.NotificationDeliveryStatus
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) StatusReturnsOnCall(i int, result1 atc.NotificationDeliveryStatus) {
+	fake.statusMutex.Lock()
+	defer fake.statusMutex.Unlock()
+	fake.StatusStub = nil
+	if fake.statusReturnsOnCall == nil {
+		fake.statusReturnsOnCall = make(map[int]struct {
+			result1 atc.NotificationDeliveryStatus
+		})
+	}
+	fake.statusReturnsOnCall[i] = struct {
+		result1 atc.NotificationDeliveryStatus
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) Succeed(arg1 int) error {
+	fake.succeedMutex.Lock()
+	ret, specificReturn := fake.succeedReturnsOnCall[len(fake.succeedArgsForCall)]
+	fake.succeedArgsForCall = append(fake.succeedArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	stub := fake.SucceedStub
+	fakeReturns := fake.succeedReturns
+	fake.recordInvocation("Succeed", []interface{}{arg1})
+	fake.succeedMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNotificationDelivery) SucceedCallCount() int {
+	fake.succeedMutex.RLock()
+	defer fake.succeedMutex.RUnlock()
+	return len(fake.succeedArgsForCall)
+}
+
+func (fake *FakeNotificationDelivery) SucceedCalls(stub func(int) error) {
+	fake.succeedMutex.Lock()
+	defer fake.succeedMutex.Unlock()
+	fake.SucceedStub = stub
+}
+
+func (fake *FakeNotificationDelivery) SucceedArgsForCall(i int) int {
+	fake.succeedMutex.RLock()
+	defer fake.succeedMutex.RUnlock()
+	argsForCall := fake.succeedArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeNotificationDelivery) SucceedReturns(result1 error) {
+	fake.succeedMutex.Lock()
+	defer fake.succeedMutex.Unlock()
+	fake.SucceedStub = nil
+	fake.succeedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) SucceedReturnsOnCall(i int, result1 error) {
+	fake.succeedMutex.Lock()
+	defer fake.succeedMutex.Unlock()
+	fake.SucceedStub = nil
+	if fake.succeedReturnsOnCall == nil {
+		fake.succeedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.succeedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) UpdatedAt() time.Time {
+	fake.updatedAtMutex.Lock()
+	ret, specificReturn := fake.updatedAtReturnsOnCall[len(fake.updatedAtArgsForCall)]
+	fake.updatedAtArgsForCall = append(fake.updatedAtArgsForCall, struct {
+	}{})
+	stub := fake.UpdatedAtStub
+	fakeReturns := fake.updatedAtReturns
+	fake.recordInvocation("UpdatedAt", []interface{}{})
+	fake.updatedAtMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNotificationDelivery) UpdatedAtCallCount() int {
+	fake.updatedAtMutex.RLock()
+	defer fake.updatedAtMutex.RUnlock()
+	return len(fake.updatedAtArgsForCall)
+}
+
+func (fake *FakeNotificationDelivery) UpdatedAtCalls(stub func() time.Time) {
+	fake.updatedAtMutex.Lock()
+	defer fake.updatedAtMutex.Unlock()
+	fake.UpdatedAtStub = stub
+}
+
+func (fake *FakeNotificationDelivery) UpdatedAtReturns(result1 time.Time) {
+	fake.updatedAtMutex.Lock()
+	defer fake.updatedAtMutex.Unlock()
+	fake.UpdatedAtStub = nil
+	fake.updatedAtReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) UpdatedAtReturnsOnCall(i int, result1 time.Time) {
+	fake.updatedAtMutex.Lock()
+	defer fake.updatedAtMutex.Unlock()
+	fake.UpdatedAtStub = nil
+	if fake.updatedAtReturnsOnCall == nil {
+		fake.updatedAtReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.updatedAtReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeNotificationDelivery) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeNotificationDelivery) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.NotificationDelivery = new(FakeNotificationDelivery)
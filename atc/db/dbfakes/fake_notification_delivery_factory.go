@@ -0,0 +1,336 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeNotificationDeliveryFactory struct {
+	PendingDeliveriesStub        func() ([]db.NotificationDelivery, error)
+	pendingDeliveriesMutex       sync.RWMutex
+	pendingDeliveriesArgsForCall []struct {
+	}
+	pendingDeliveriesReturns struct {
+		result1 []db.NotificationDelivery
+		result2 error
+	}
+	pendingDeliveriesReturnsOnCall map[int]struct {
+		result1 []db.NotificationDelivery
+		result2 error
+	}
+	PreviousBuildStatusStub        func(db.Build) (db.BuildStatus, bool, error)
+	previousBuildStatusMutex       sync.RWMutex
+	previousBuildStatusArgsForCall []struct {
+		arg1 db.Build
+	}
+	previousBuildStatusReturns struct {
+		result1 db.BuildStatus
+		result2 bool
+		result3 error
+	}
+	previousBuildStatusReturnsOnCall map[int]struct {
+		result1 db.BuildStatus
+		result2 bool
+		result3 error
+	}
+	QueueDeliveriesStub        func(db.Build, db.BuildStatus, []string) error
+	queueDeliveriesMutex       sync.RWMutex
+	queueDeliveriesArgsForCall []struct {
+		arg1 db.Build
+		arg2 db.BuildStatus
+		arg3 []string
+	}
+	queueDeliveriesReturns struct {
+		result1 error
+	}
+	queueDeliveriesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UnnotifiedBuildsStub        func() ([]db.Build, error)
+	unnotifiedBuildsMutex       sync.RWMutex
+	unnotifiedBuildsArgsForCall []struct {
+	}
+	unnotifiedBuildsReturns struct {
+		result1 []db.Build
+		result2 error
+	}
+	unnotifiedBuildsReturnsOnCall map[int]struct {
+		result1 []db.Build
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeNotificationDeliveryFactory) PendingDeliveries() ([]db.NotificationDelivery, error) {
+	fake.pendingDeliveriesMutex.Lock()
+	ret, specificReturn := fake.pendingDeliveriesReturnsOnCall[len(fake.pendingDeliveriesArgsForCall)]
+	fake.pendingDeliveriesArgsForCall = append(fake.pendingDeliveriesArgsForCall, struct {
+	}{})
+	stub := fake.PendingDeliveriesStub
+	fakeReturns := fake.pendingDeliveriesReturns
+	fake.recordInvocation("PendingDeliveries", []interface{}{})
+	fake.pendingDeliveriesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeNotificationDeliveryFactory) PendingDeliveriesCallCount() int {
+	fake.pendingDeliveriesMutex.RLock()
+	defer fake.pendingDeliveriesMutex.RUnlock()
+	return len(fake.pendingDeliveriesArgsForCall)
+}
+
+func (fake *FakeNotificationDeliveryFactory) PendingDeliveriesCalls(stub func() ([]db.NotificationDelivery, error)) {
+	fake.pendingDeliveriesMutex.Lock()
+	defer fake.pendingDeliveriesMutex.Unlock()
+	fake.PendingDeliveriesStub = stub
+}
+
+func (fake *FakeNotificationDeliveryFactory) PendingDeliveriesReturns(result1 []db.NotificationDelivery, result2 error) {
+	fake.pendingDeliveriesMutex.Lock()
+	defer fake.pendingDeliveriesMutex.Unlock()
+	fake.PendingDeliveriesStub = nil
+	fake.pendingDeliveriesReturns = struct {
+		result1 []db.NotificationDelivery
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeNotificationDeliveryFactory) PendingDeliveriesReturnsOnCall(i int, result1 []db.NotificationDelivery, result2 error) {
+	fake.pendingDeliveriesMutex.Lock()
+	defer fake.pendingDeliveriesMutex.Unlock()
+	fake.PendingDeliveriesStub = nil
+	if fake.pendingDeliveriesReturnsOnCall == nil {
+		fake.pendingDeliveriesReturnsOnCall = make(map[int]struct {
+			result1 []db.NotificationDelivery
+			result2 error
+		})
+	}
+	fake.pendingDeliveriesReturnsOnCall[i] = struct {
+		result1 []db.NotificationDelivery
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeNotificationDeliveryFactory) PreviousBuildStatus(arg1 db.Build) (db.BuildStatus, bool, error) {
+	fake.previousBuildStatusMutex.Lock()
+	ret, specificReturn := fake.previousBuildStatusReturnsOnCall[len(fake.previousBuildStatusArgsForCall)]
+	fake.previousBuildStatusArgsForCall = append(fake.previousBuildStatusArgsForCall, struct {
+		arg1 db.Build
+	}{arg1})
+	stub := fake.PreviousBuildStatusStub
+	fakeReturns := fake.previousBuildStatusReturns
+	fake.recordInvocation("PreviousBuildStatus", []interface{}{arg1})
+	fake.previousBuildStatusMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeNotificationDeliveryFactory) PreviousBuildStatusCallCount() int {
+	fake.previousBuildStatusMutex.RLock()
+	defer fake.previousBuildStatusMutex.RUnlock()
+	return len(fake.previousBuildStatusArgsForCall)
+}
+
+func (fake *FakeNotificationDeliveryFactory) PreviousBuildStatusCalls(stub func(db.Build) (db.BuildStatus, bool, error)) {
+	fake.previousBuildStatusMutex.Lock()
+	defer fake.previousBuildStatusMutex.Unlock()
+	fake.PreviousBuildStatusStub = stub
+}
+
+func (fake *FakeNotificationDeliveryFactory) PreviousBuildStatusArgsForCall(i int) db.Build {
+	fake.previousBuildStatusMutex.RLock()
+	defer fake.previousBuildStatusMutex.RUnlock()
+	argsForCall := fake.previousBuildStatusArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeNotificationDeliveryFactory) PreviousBuildStatusReturns(result1 db.BuildStatus, result2 bool, result3 error) {
+	fake.previousBuildStatusMutex.Lock()
+	defer fake.previousBuildStatusMutex.Unlock()
+	fake.PreviousBuildStatusStub = nil
+	fake.previousBuildStatusReturns = struct {
+		result1 db.BuildStatus
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeNotificationDeliveryFactory) PreviousBuildStatusReturnsOnCall(i int, result1 db.BuildStatus, result2 bool, result3 error) {
+	fake.previousBuildStatusMutex.Lock()
+	defer fake.previousBuildStatusMutex.Unlock()
+	fake.PreviousBuildStatusStub = nil
+	if fake.previousBuildStatusReturnsOnCall == nil {
+		fake.previousBuildStatusReturnsOnCall = make(map[int]struct {
+			result1 db.BuildStatus
+			result2 bool
+			result3 error
+		})
+	}
+	fake.previousBuildStatusReturnsOnCall[i] = struct {
+		result1 db.BuildStatus
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeNotificationDeliveryFactory) QueueDeliveries(arg1 db.Build, arg2 db.BuildStatus, arg3 []string) error {
+	var arg3Copy []string
+	if arg3 != nil {
+		arg3Copy = make([]string, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.queueDeliveriesMutex.Lock()
+	ret, specificReturn := fake.queueDeliveriesReturnsOnCall[len(fake.queueDeliveriesArgsForCall)]
+	fake.queueDeliveriesArgsForCall = append(fake.queueDeliveriesArgsForCall, struct {
+		arg1 db.Build
+		arg2 db.BuildStatus
+		arg3 []string
+	}{arg1, arg2, arg3Copy})
+	stub := fake.QueueDeliveriesStub
+	fakeReturns := fake.queueDeliveriesReturns
+	fake.recordInvocation("QueueDeliveries", []interface{}{arg1, arg2, arg3Copy})
+	fake.queueDeliveriesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNotificationDeliveryFactory) QueueDeliveriesCallCount() int {
+	fake.queueDeliveriesMutex.RLock()
+	defer fake.queueDeliveriesMutex.RUnlock()
+	return len(fake.queueDeliveriesArgsForCall)
+}
+
+func (fake *FakeNotificationDeliveryFactory) QueueDeliveriesCalls(stub func(db.Build, db.BuildStatus, []string) error) {
+	fake.queueDeliveriesMutex.Lock()
+	defer fake.queueDeliveriesMutex.Unlock()
+	fake.QueueDeliveriesStub = stub
+}
+
+func (fake *FakeNotificationDeliveryFactory) QueueDeliveriesArgsForCall(i int) (db.Build, db.BuildStatus, []string) {
+	fake.queueDeliveriesMutex.RLock()
+	defer fake.queueDeliveriesMutex.RUnlock()
+	argsForCall := fake.queueDeliveriesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeNotificationDeliveryFactory) QueueDeliveriesReturns(result1 error) {
+	fake.queueDeliveriesMutex.Lock()
+	defer fake.queueDeliveriesMutex.Unlock()
+	fake.QueueDeliveriesStub = nil
+	fake.queueDeliveriesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNotificationDeliveryFactory) QueueDeliveriesReturnsOnCall(i int, result1 error) {
+	fake.queueDeliveriesMutex.Lock()
+	defer fake.queueDeliveriesMutex.Unlock()
+	fake.QueueDeliveriesStub = nil
+	if fake.queueDeliveriesReturnsOnCall == nil {
+		fake.queueDeliveriesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.queueDeliveriesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNotificationDeliveryFactory) UnnotifiedBuilds() ([]db.Build, error) {
+	fake.unnotifiedBuildsMutex.Lock()
+	ret, specificReturn := fake.unnotifiedBuildsReturnsOnCall[len(fake.unnotifiedBuildsArgsForCall)]
+	fake.unnotifiedBuildsArgsForCall = append(fake.unnotifiedBuildsArgsForCall, struct {
+	}{})
+	stub := fake.UnnotifiedBuildsStub
+	fakeReturns := fake.unnotifiedBuildsReturns
+	fake.recordInvocation("UnnotifiedBuilds", []interface{}{})
+	fake.unnotifiedBuildsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeNotificationDeliveryFactory) UnnotifiedBuildsCallCount() int {
+	fake.unnotifiedBuildsMutex.RLock()
+	defer fake.unnotifiedBuildsMutex.RUnlock()
+	return len(fake.unnotifiedBuildsArgsForCall)
+}
+
+func (fake *FakeNotificationDeliveryFactory) UnnotifiedBuildsCalls(stub func() ([]db.Build, error)) {
+	fake.unnotifiedBuildsMutex.Lock()
+	defer fake.unnotifiedBuildsMutex.Unlock()
+	fake.UnnotifiedBuildsStub = stub
+}
+
+func (fake *FakeNotificationDeliveryFactory) UnnotifiedBuildsReturns(result1 []db.Build, result2 error) {
+	fake.unnotifiedBuildsMutex.Lock()
+	defer fake.unnotifiedBuildsMutex.Unlock()
+	fake.UnnotifiedBuildsStub = nil
+	fake.unnotifiedBuildsReturns = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeNotificationDeliveryFactory) UnnotifiedBuildsReturnsOnCall(i int, result1 []db.Build, result2 error) {
+	fake.unnotifiedBuildsMutex.Lock()
+	defer fake.unnotifiedBuildsMutex.Unlock()
+	fake.UnnotifiedBuildsStub = nil
+	if fake.unnotifiedBuildsReturnsOnCall == nil {
+		fake.unnotifiedBuildsReturnsOnCall = make(map[int]struct {
+			result1 []db.Build
+			result2 error
+		})
+	}
+	fake.unnotifiedBuildsReturnsOnCall[i] = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeNotificationDeliveryFactory) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeNotificationDeliveryFactory) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.NotificationDeliveryFactory = new(FakeNotificationDeliveryFactory)
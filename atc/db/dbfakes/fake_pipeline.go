@@ -87,6 +87,33 @@ type FakePipeline struct {
 		result1 atc.Config
 		result2 error
 	}
+	ConfigRevisionStub        func(int) (db.PipelineConfigRevision, bool, error)
+	configRevisionMutex       sync.RWMutex
+	configRevisionArgsForCall []struct {
+		arg1 int
+	}
+	configRevisionReturns struct {
+		result1 db.PipelineConfigRevision
+		result2 bool
+		result3 error
+	}
+	configRevisionReturnsOnCall map[int]struct {
+		result1 db.PipelineConfigRevision
+		result2 bool
+		result3 error
+	}
+	ConfigRevisionsStub        func() ([]db.PipelineConfigRevision, error)
+	configRevisionsMutex       sync.RWMutex
+	configRevisionsArgsForCall []struct {
+	}
+	configRevisionsReturns struct {
+		result1 []db.PipelineConfigRevision
+		result2 error
+	}
+	configRevisionsReturnsOnCall map[int]struct {
+		result1 []db.PipelineConfigRevision
+		result2 error
+	}
 	ConfigVersionStub        func() db.ConfigVersion
 	configVersionMutex       sync.RWMutex
 	configVersionArgsForCall []struct {
@@ -302,6 +329,30 @@ type FakePipeline struct {
 	nameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	NotificationDeliveriesStub        func([]atc.NotificationDeliveryStatus, int) ([]db.NotificationDelivery, error)
+	notificationDeliveriesMutex       sync.RWMutex
+	notificationDeliveriesArgsForCall []struct {
+		arg1 []atc.NotificationDeliveryStatus
+		arg2 int
+	}
+	notificationDeliveriesReturns struct {
+		result1 []db.NotificationDelivery
+		result2 error
+	}
+	notificationDeliveriesReturnsOnCall map[int]struct {
+		result1 []db.NotificationDelivery
+		result2 error
+	}
+	NotificationsStub        func() atc.NotificationConfigs
+	notificationsMutex       sync.RWMutex
+	notificationsArgsForCall []struct {
+	}
+	notificationsReturns struct {
+		result1 atc.NotificationConfigs
+	}
+	notificationsReturnsOnCall map[int]struct {
+		result1 atc.NotificationConfigs
+	}
 	ParentBuildIDStub        func() int
 	parentBuildIDMutex       sync.RWMutex
 	parentBuildIDArgsForCall []struct {
@@ -363,6 +414,19 @@ type FakePipeline struct {
 	pausedByReturnsOnCall map[int]struct {
 		result1 string
 	}
+	PlanConfigStub        func(atc.Config) (atc.ConfigPlan, error)
+	planConfigMutex       sync.RWMutex
+	planConfigArgsForCall []struct {
+		arg1 atc.Config
+	}
+	planConfigReturns struct {
+		result1 atc.ConfigPlan
+		result2 error
+	}
+	planConfigReturnsOnCall map[int]struct {
+		result1 atc.ConfigPlan
+		result2 error
+	}
 	PrototypeStub        func(string) (db.Prototype, bool, error)
 	prototypeMutex       sync.RWMutex
 	prototypeArgsForCall []struct {
@@ -511,6 +575,33 @@ type FakePipeline struct {
 		result1 db.Resources
 		result2 error
 	}
+	RestoreConfigRevisionStub        func(int, string) (bool, error)
+	restoreConfigRevisionMutex       sync.RWMutex
+	restoreConfigRevisionArgsForCall []struct {
+		arg1 int
+		arg2 string
+	}
+	restoreConfigRevisionReturns struct {
+		result1 bool
+		result2 error
+	}
+	restoreConfigRevisionReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	SearchBuildLogsStub        func(db.BuildLogSearch) (db.BuildLogSearchResult, error)
+	searchBuildLogsMutex       sync.RWMutex
+	searchBuildLogsArgsForCall []struct {
+		arg1 db.BuildLogSearch
+	}
+	searchBuildLogsReturns struct {
+		result1 db.BuildLogSearchResult
+		result2 error
+	}
+	searchBuildLogsReturnsOnCall map[int]struct {
+		result1 db.BuildLogSearchResult
+		result2 error
+	}
 	SetParentIDsStub        func(int, int) error
 	setParentIDsMutex       sync.RWMutex
 	setParentIDsArgsForCall []struct {
@@ -981,6 +1072,129 @@ func (fake *FakePipeline) ConfigReturnsOnCall(i int, result1 atc.Config, result2
 	}{result1, result2}
 }
 
+func (fake *FakePipeline) ConfigRevision(arg1 int) (db.PipelineConfigRevision, bool, error) {
+	fake.configRevisionMutex.Lock()
+	ret, specificReturn := fake.configRevisionReturnsOnCall[len(fake.configRevisionArgsForCall)]
+	fake.configRevisionArgsForCall = append(fake.configRevisionArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	stub := fake.ConfigRevisionStub
+	fakeReturns := fake.configRevisionReturns
+	fake.recordInvocation("ConfigRevision", []interface{}{arg1})
+	fake.configRevisionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakePipeline) ConfigRevisionCallCount() int {
+	fake.configRevisionMutex.RLock()
+	defer fake.configRevisionMutex.RUnlock()
+	return len(fake.configRevisionArgsForCall)
+}
+
+func (fake *FakePipeline) ConfigRevisionCalls(stub func(int) (db.PipelineConfigRevision, bool, error)) {
+	fake.configRevisionMutex.Lock()
+	defer fake.configRevisionMutex.Unlock()
+	fake.ConfigRevisionStub = stub
+}
+
+func (fake *FakePipeline) ConfigRevisionArgsForCall(i int) int {
+	fake.configRevisionMutex.RLock()
+	defer fake.configRevisionMutex.RUnlock()
+	argsForCall := fake.configRevisionArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakePipeline) ConfigRevisionReturns(result1 db.PipelineConfigRevision, result2 bool, result3 error) {
+	fake.configRevisionMutex.Lock()
+	defer fake.configRevisionMutex.Unlock()
+	fake.ConfigRevisionStub = nil
+	fake.configRevisionReturns = struct {
+		result1 db.PipelineConfigRevision
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipeline) ConfigRevisionReturnsOnCall(i int, result1 db.PipelineConfigRevision, result2 bool, result3 error) {
+	fake.configRevisionMutex.Lock()
+	defer fake.configRevisionMutex.Unlock()
+	fake.ConfigRevisionStub = nil
+	if fake.configRevisionReturnsOnCall == nil {
+		fake.configRevisionReturnsOnCall = make(map[int]struct {
+			result1 db.PipelineConfigRevision
+			result2 bool
+			result3 error
+		})
+	}
+	fake.configRevisionReturnsOnCall[i] = struct {
+		result1 db.PipelineConfigRevision
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipeline) ConfigRevisions() ([]db.PipelineConfigRevision, error) {
+	fake.configRevisionsMutex.Lock()
+	ret, specificReturn := fake.configRevisionsReturnsOnCall[len(fake.configRevisionsArgsForCall)]
+	fake.configRevisionsArgsForCall = append(fake.configRevisionsArgsForCall, struct {
+	}{})
+	stub := fake.ConfigRevisionsStub
+	fakeReturns := fake.configRevisionsReturns
+	fake.recordInvocation("ConfigRevisions", []interface{}{})
+	fake.configRevisionsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePipeline) ConfigRevisionsCallCount() int {
+	fake.configRevisionsMutex.RLock()
+	defer fake.configRevisionsMutex.RUnlock()
+	return len(fake.configRevisionsArgsForCall)
+}
+
+func (fake *FakePipeline) ConfigRevisionsCalls(stub func() ([]db.PipelineConfigRevision, error)) {
+	fake.configRevisionsMutex.Lock()
+	defer fake.configRevisionsMutex.Unlock()
+	fake.ConfigRevisionsStub = stub
+}
+
+func (fake *FakePipeline) ConfigRevisionsReturns(result1 []db.PipelineConfigRevision, result2 error) {
+	fake.configRevisionsMutex.Lock()
+	defer fake.configRevisionsMutex.Unlock()
+	fake.ConfigRevisionsStub = nil
+	fake.configRevisionsReturns = struct {
+		result1 []db.PipelineConfigRevision
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) ConfigRevisionsReturnsOnCall(i int, result1 []db.PipelineConfigRevision, result2 error) {
+	fake.configRevisionsMutex.Lock()
+	defer fake.configRevisionsMutex.Unlock()
+	fake.ConfigRevisionsStub = nil
+	if fake.configRevisionsReturnsOnCall == nil {
+		fake.configRevisionsReturnsOnCall = make(map[int]struct {
+			result1 []db.PipelineConfigRevision
+			result2 error
+		})
+	}
+	fake.configRevisionsReturnsOnCall[i] = struct {
+		result1 []db.PipelineConfigRevision
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipeline) ConfigVersion() db.ConfigVersion {
 	fake.configVersionMutex.Lock()
 	ret, specificReturn := fake.configVersionReturnsOnCall[len(fake.configVersionArgsForCall)]
@@ -2062,6 +2276,129 @@ func (fake *FakePipeline) NameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakePipeline) NotificationDeliveries(arg1 []atc.NotificationDeliveryStatus, arg2 int) ([]db.NotificationDelivery, error) {
+	var arg1Copy []atc.NotificationDeliveryStatus
+	if arg1 != nil {
+		arg1Copy = make([]atc.NotificationDeliveryStatus, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.notificationDeliveriesMutex.Lock()
+	ret, specificReturn := fake.notificationDeliveriesReturnsOnCall[len(fake.notificationDeliveriesArgsForCall)]
+	fake.notificationDeliveriesArgsForCall = append(fake.notificationDeliveriesArgsForCall, struct {
+		arg1 []atc.NotificationDeliveryStatus
+		arg2 int
+	}{arg1Copy, arg2})
+	stub := fake.NotificationDeliveriesStub
+	fakeReturns := fake.notificationDeliveriesReturns
+	fake.recordInvocation("NotificationDeliveries", []interface{}{arg1Copy, arg2})
+	fake.notificationDeliveriesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePipeline) NotificationDeliveriesCallCount() int {
+	fake.notificationDeliveriesMutex.RLock()
+	defer fake.notificationDeliveriesMutex.RUnlock()
+	return len(fake.notificationDeliveriesArgsForCall)
+}
+
+func (fake *FakePipeline) NotificationDeliveriesCalls(stub func([]atc.NotificationDeliveryStatus, int) ([]db.NotificationDelivery, error)) {
+	fake.notificationDeliveriesMutex.Lock()
+	defer fake.notificationDeliveriesMutex.Unlock()
+	fake.NotificationDeliveriesStub = stub
+}
+
+func (fake *FakePipeline) NotificationDeliveriesArgsForCall(i int) ([]atc.NotificationDeliveryStatus, int) {
+	fake.notificationDeliveriesMutex.RLock()
+	defer fake.notificationDeliveriesMutex.RUnlock()
+	argsForCall := fake.notificationDeliveriesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakePipeline) NotificationDeliveriesReturns(result1 []db.NotificationDelivery, result2 error) {
+	fake.notificationDeliveriesMutex.Lock()
+	defer fake.notificationDeliveriesMutex.Unlock()
+	fake.NotificationDeliveriesStub = nil
+	fake.notificationDeliveriesReturns = struct {
+		result1 []db.NotificationDelivery
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) NotificationDeliveriesReturnsOnCall(i int, result1 []db.NotificationDelivery, result2 error) {
+	fake.notificationDeliveriesMutex.Lock()
+	defer fake.notificationDeliveriesMutex.Unlock()
+	fake.NotificationDeliveriesStub = nil
+	if fake.notificationDeliveriesReturnsOnCall == nil {
+		fake.notificationDeliveriesReturnsOnCall = make(map[int]struct {
+			result1 []db.NotificationDelivery
+			result2 error
+		})
+	}
+	fake.notificationDeliveriesReturnsOnCall[i] = struct {
+		result1 []db.NotificationDelivery
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) Notifications() atc.NotificationConfigs {
+	fake.notificationsMutex.Lock()
+	ret, specificReturn := fake.notificationsReturnsOnCall[len(fake.notificationsArgsForCall)]
+	fake.notificationsArgsForCall = append(fake.notificationsArgsForCall, struct {
+	}{})
+	stub := fake.NotificationsStub
+	fakeReturns := fake.notificationsReturns
+	fake.recordInvocation("Notifications", []interface{}{})
+	fake.notificationsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) NotificationsCallCount() int {
+	fake.notificationsMutex.RLock()
+	defer fake.notificationsMutex.RUnlock()
+	return len(fake.notificationsArgsForCall)
+}
+
+func (fake *FakePipeline) NotificationsCalls(stub func() atc.NotificationConfigs) {
+	fake.notificationsMutex.Lock()
+	defer fake.notificationsMutex.Unlock()
+	fake.NotificationsStub = stub
+}
+
+func (fake *FakePipeline) NotificationsReturns(result1 atc.NotificationConfigs) {
+	fake.notificationsMutex.Lock()
+	defer fake.notificationsMutex.Unlock()
+	fake.NotificationsStub = nil
+	fake.notificationsReturns = struct {
+		result1 atc.NotificationConfigs
+	}{result1}
+}
+
+func (fake *FakePipeline) NotificationsReturnsOnCall(i int, result1 atc.NotificationConfigs) {
+	fake.notificationsMutex.Lock()
+	defer fake.notificationsMutex.Unlock()
+	fake.NotificationsStub = nil
+	if fake.notificationsReturnsOnCall == nil {
+		fake.notificationsReturnsOnCall = make(map[int]struct {
+			result1 atc.NotificationConfigs
+		})
+	}
+	fake.notificationsReturnsOnCall[i] = struct {
+		result1 atc.NotificationConfigs
+	}{result1}
+}
+
 func (fake *FakePipeline) ParentBuildID() int {
 	fake.parentBuildIDMutex.Lock()
 	ret, specificReturn := fake.parentBuildIDReturnsOnCall[len(fake.parentBuildIDArgsForCall)]
@@ -2388,6 +2725,70 @@ func (fake *FakePipeline) PausedByReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakePipeline) PlanConfig(arg1 atc.Config) (atc.ConfigPlan, error) {
+	fake.planConfigMutex.Lock()
+	ret, specificReturn := fake.planConfigReturnsOnCall[len(fake.planConfigArgsForCall)]
+	fake.planConfigArgsForCall = append(fake.planConfigArgsForCall, struct {
+		arg1 atc.Config
+	}{arg1})
+	stub := fake.PlanConfigStub
+	fakeReturns := fake.planConfigReturns
+	fake.recordInvocation("PlanConfig", []interface{}{arg1})
+	fake.planConfigMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePipeline) PlanConfigCallCount() int {
+	fake.planConfigMutex.RLock()
+	defer fake.planConfigMutex.RUnlock()
+	return len(fake.planConfigArgsForCall)
+}
+
+func (fake *FakePipeline) PlanConfigCalls(stub func(atc.Config) (atc.ConfigPlan, error)) {
+	fake.planConfigMutex.Lock()
+	defer fake.planConfigMutex.Unlock()
+	fake.PlanConfigStub = stub
+}
+
+func (fake *FakePipeline) PlanConfigArgsForCall(i int) atc.Config {
+	fake.planConfigMutex.RLock()
+	defer fake.planConfigMutex.RUnlock()
+	argsForCall := fake.planConfigArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakePipeline) PlanConfigReturns(result1 atc.ConfigPlan, result2 error) {
+	fake.planConfigMutex.Lock()
+	defer fake.planConfigMutex.Unlock()
+	fake.PlanConfigStub = nil
+	fake.planConfigReturns = struct {
+		result1 atc.ConfigPlan
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) PlanConfigReturnsOnCall(i int, result1 atc.ConfigPlan, result2 error) {
+	fake.planConfigMutex.Lock()
+	defer fake.planConfigMutex.Unlock()
+	fake.PlanConfigStub = nil
+	if fake.planConfigReturnsOnCall == nil {
+		fake.planConfigReturnsOnCall = make(map[int]struct {
+			result1 atc.ConfigPlan
+			result2 error
+		})
+	}
+	fake.planConfigReturnsOnCall[i] = struct {
+		result1 atc.ConfigPlan
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipeline) Prototype(arg1 string) (db.Prototype, bool, error) {
 	fake.prototypeMutex.Lock()
 	ret, specificReturn := fake.prototypeReturnsOnCall[len(fake.prototypeArgsForCall)]
@@ -3067,6 +3468,135 @@ func (fake *FakePipeline) ResourcesReturnsOnCall(i int, result1 db.Resources, re
 	}{result1, result2}
 }
 
+func (fake *FakePipeline) RestoreConfigRevision(arg1 int, arg2 string) (bool, error) {
+	fake.restoreConfigRevisionMutex.Lock()
+	ret, specificReturn := fake.restoreConfigRevisionReturnsOnCall[len(fake.restoreConfigRevisionArgsForCall)]
+	fake.restoreConfigRevisionArgsForCall = append(fake.restoreConfigRevisionArgsForCall, struct {
+		arg1 int
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.RestoreConfigRevisionStub
+	fakeReturns := fake.restoreConfigRevisionReturns
+	fake.recordInvocation("RestoreConfigRevision", []interface{}{arg1, arg2})
+	fake.restoreConfigRevisionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePipeline) RestoreConfigRevisionCallCount() int {
+	fake.restoreConfigRevisionMutex.RLock()
+	defer fake.restoreConfigRevisionMutex.RUnlock()
+	return len(fake.restoreConfigRevisionArgsForCall)
+}
+
+func (fake *FakePipeline) RestoreConfigRevisionCalls(stub func(int, string) (bool, error)) {
+	fake.restoreConfigRevisionMutex.Lock()
+	defer fake.restoreConfigRevisionMutex.Unlock()
+	fake.RestoreConfigRevisionStub = stub
+}
+
+func (fake *FakePipeline) RestoreConfigRevisionArgsForCall(i int) (int, string) {
+	fake.restoreConfigRevisionMutex.RLock()
+	defer fake.restoreConfigRevisionMutex.RUnlock()
+	argsForCall := fake.restoreConfigRevisionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakePipeline) RestoreConfigRevisionReturns(result1 bool, result2 error) {
+	fake.restoreConfigRevisionMutex.Lock()
+	defer fake.restoreConfigRevisionMutex.Unlock()
+	fake.RestoreConfigRevisionStub = nil
+	fake.restoreConfigRevisionReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) RestoreConfigRevisionReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.restoreConfigRevisionMutex.Lock()
+	defer fake.restoreConfigRevisionMutex.Unlock()
+	fake.RestoreConfigRevisionStub = nil
+	if fake.restoreConfigRevisionReturnsOnCall == nil {
+		fake.restoreConfigRevisionReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.restoreConfigRevisionReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) SearchBuildLogs(arg1 db.BuildLogSearch) (db.BuildLogSearchResult, error) {
+	fake.searchBuildLogsMutex.Lock()
+	ret, specificReturn := fake.searchBuildLogsReturnsOnCall[len(fake.searchBuildLogsArgsForCall)]
+	fake.searchBuildLogsArgsForCall = append(fake.searchBuildLogsArgsForCall, struct {
+		arg1 db.BuildLogSearch
+	}{arg1})
+	stub := fake.SearchBuildLogsStub
+	fakeReturns := fake.searchBuildLogsReturns
+	fake.recordInvocation("SearchBuildLogs", []interface{}{arg1})
+	fake.searchBuildLogsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePipeline) SearchBuildLogsCallCount() int {
+	fake.searchBuildLogsMutex.RLock()
+	defer fake.searchBuildLogsMutex.RUnlock()
+	return len(fake.searchBuildLogsArgsForCall)
+}
+
+func (fake *FakePipeline) SearchBuildLogsCalls(stub func(db.BuildLogSearch) (db.BuildLogSearchResult, error)) {
+	fake.searchBuildLogsMutex.Lock()
+	defer fake.searchBuildLogsMutex.Unlock()
+	fake.SearchBuildLogsStub = stub
+}
+
+func (fake *FakePipeline) SearchBuildLogsArgsForCall(i int) db.BuildLogSearch {
+	fake.searchBuildLogsMutex.RLock()
+	defer fake.searchBuildLogsMutex.RUnlock()
+	argsForCall := fake.searchBuildLogsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakePipeline) SearchBuildLogsReturns(result1 db.BuildLogSearchResult, result2 error) {
+	fake.searchBuildLogsMutex.Lock()
+	defer fake.searchBuildLogsMutex.Unlock()
+	fake.SearchBuildLogsStub = nil
+	fake.searchBuildLogsReturns = struct {
+		result1 db.BuildLogSearchResult
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) SearchBuildLogsReturnsOnCall(i int, result1 db.BuildLogSearchResult, result2 error) {
+	fake.searchBuildLogsMutex.Lock()
+	defer fake.searchBuildLogsMutex.Unlock()
+	fake.SearchBuildLogsStub = nil
+	if fake.searchBuildLogsReturnsOnCall == nil {
+		fake.searchBuildLogsReturnsOnCall = make(map[int]struct {
+			result1 db.BuildLogSearchResult
+			result2 error
+		})
+	}
+	fake.searchBuildLogsReturnsOnCall[i] = struct {
+		result1 db.BuildLogSearchResult
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipeline) SetParentIDs(arg1 int, arg2 int) error {
 	fake.setParentIDsMutex.Lock()
 	ret, specificReturn := fake.setParentIDsReturnsOnCall[len(fake.setParentIDsArgsForCall)]
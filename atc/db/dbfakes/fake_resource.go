@@ -366,6 +366,20 @@ type FakeResource struct {
 	publicReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	RecordWebhookDeliveryStub        func(string, time.Duration) (bool, error)
+	recordWebhookDeliveryMutex       sync.RWMutex
+	recordWebhookDeliveryArgsForCall []struct {
+		arg1 string
+		arg2 time.Duration
+	}
+	recordWebhookDeliveryReturns struct {
+		result1 bool
+		result2 error
+	}
+	recordWebhookDeliveryReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	ReloadStub        func() (bool, error)
 	reloadMutex       sync.RWMutex
 	reloadArgsForCall []struct {
@@ -2300,6 +2314,71 @@ func (fake *FakeResource) PublicReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
+func (fake *FakeResource) RecordWebhookDelivery(arg1 string, arg2 time.Duration) (bool, error) {
+	fake.recordWebhookDeliveryMutex.Lock()
+	ret, specificReturn := fake.recordWebhookDeliveryReturnsOnCall[len(fake.recordWebhookDeliveryArgsForCall)]
+	fake.recordWebhookDeliveryArgsForCall = append(fake.recordWebhookDeliveryArgsForCall, struct {
+		arg1 string
+		arg2 time.Duration
+	}{arg1, arg2})
+	stub := fake.RecordWebhookDeliveryStub
+	fakeReturns := fake.recordWebhookDeliveryReturns
+	fake.recordInvocation("RecordWebhookDelivery", []interface{}{arg1, arg2})
+	fake.recordWebhookDeliveryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeResource) RecordWebhookDeliveryCallCount() int {
+	fake.recordWebhookDeliveryMutex.RLock()
+	defer fake.recordWebhookDeliveryMutex.RUnlock()
+	return len(fake.recordWebhookDeliveryArgsForCall)
+}
+
+func (fake *FakeResource) RecordWebhookDeliveryCalls(stub func(string, time.Duration) (bool, error)) {
+	fake.recordWebhookDeliveryMutex.Lock()
+	defer fake.recordWebhookDeliveryMutex.Unlock()
+	fake.RecordWebhookDeliveryStub = stub
+}
+
+func (fake *FakeResource) RecordWebhookDeliveryArgsForCall(i int) (string, time.Duration) {
+	fake.recordWebhookDeliveryMutex.RLock()
+	defer fake.recordWebhookDeliveryMutex.RUnlock()
+	argsForCall := fake.recordWebhookDeliveryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeResource) RecordWebhookDeliveryReturns(result1 bool, result2 error) {
+	fake.recordWebhookDeliveryMutex.Lock()
+	defer fake.recordWebhookDeliveryMutex.Unlock()
+	fake.RecordWebhookDeliveryStub = nil
+	fake.recordWebhookDeliveryReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResource) RecordWebhookDeliveryReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.recordWebhookDeliveryMutex.Lock()
+	defer fake.recordWebhookDeliveryMutex.Unlock()
+	fake.RecordWebhookDeliveryStub = nil
+	if fake.recordWebhookDeliveryReturnsOnCall == nil {
+		fake.recordWebhookDeliveryReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.recordWebhookDeliveryReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeResource) Reload() (bool, error) {
 	fake.reloadMutex.Lock()
 	ret, specificReturn := fake.reloadReturnsOnCall[len(fake.reloadArgsForCall)]
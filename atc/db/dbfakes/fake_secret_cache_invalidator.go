@@ -0,0 +1,110 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeSecretCacheInvalidator struct {
+	InvalidateStub        func(creds.SecretCacheInvalidation) error
+	invalidateMutex       sync.RWMutex
+	invalidateArgsForCall []struct {
+		arg1 creds.SecretCacheInvalidation
+	}
+	invalidateReturns struct {
+		result1 error
+	}
+	invalidateReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeSecretCacheInvalidator) Invalidate(arg1 creds.SecretCacheInvalidation) error {
+	fake.invalidateMutex.Lock()
+	ret, specificReturn := fake.invalidateReturnsOnCall[len(fake.invalidateArgsForCall)]
+	fake.invalidateArgsForCall = append(fake.invalidateArgsForCall, struct {
+		arg1 creds.SecretCacheInvalidation
+	}{arg1})
+	stub := fake.InvalidateStub
+	fakeReturns := fake.invalidateReturns
+	fake.recordInvocation("Invalidate", []interface{}{arg1})
+	fake.invalidateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeSecretCacheInvalidator) InvalidateCallCount() int {
+	fake.invalidateMutex.RLock()
+	defer fake.invalidateMutex.RUnlock()
+	return len(fake.invalidateArgsForCall)
+}
+
+func (fake *FakeSecretCacheInvalidator) InvalidateCalls(stub func(creds.SecretCacheInvalidation) error) {
+	fake.invalidateMutex.Lock()
+	defer fake.invalidateMutex.Unlock()
+	fake.InvalidateStub = stub
+}
+
+func (fake *FakeSecretCacheInvalidator) InvalidateArgsForCall(i int) creds.SecretCacheInvalidation {
+	fake.invalidateMutex.RLock()
+	defer fake.invalidateMutex.RUnlock()
+	argsForCall := fake.invalidateArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeSecretCacheInvalidator) InvalidateReturns(result1 error) {
+	fake.invalidateMutex.Lock()
+	defer fake.invalidateMutex.Unlock()
+	fake.InvalidateStub = nil
+	fake.invalidateReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSecretCacheInvalidator) InvalidateReturnsOnCall(i int, result1 error) {
+	fake.invalidateMutex.Lock()
+	defer fake.invalidateMutex.Unlock()
+	fake.InvalidateStub = nil
+	if fake.invalidateReturnsOnCall == nil {
+		fake.invalidateReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.invalidateReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSecretCacheInvalidator) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeSecretCacheInvalidator) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.SecretCacheInvalidator = new(FakeSecretCacheInvalidator)
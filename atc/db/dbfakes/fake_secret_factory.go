@@ -0,0 +1,123 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeSecretFactory struct {
+	SecretStub        func(string, string, string) (string, bool, error)
+	secretMutex       sync.RWMutex
+	secretArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	secretReturns struct {
+		result1 string
+		result2 bool
+		result3 error
+	}
+	secretReturnsOnCall map[int]struct {
+		result1 string
+		result2 bool
+		result3 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeSecretFactory) Secret(arg1 string, arg2 string, arg3 string) (string, bool, error) {
+	fake.secretMutex.Lock()
+	ret, specificReturn := fake.secretReturnsOnCall[len(fake.secretArgsForCall)]
+	fake.secretArgsForCall = append(fake.secretArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.SecretStub
+	fakeReturns := fake.secretReturns
+	fake.recordInvocation("Secret", []interface{}{arg1, arg2, arg3})
+	fake.secretMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeSecretFactory) SecretCallCount() int {
+	fake.secretMutex.RLock()
+	defer fake.secretMutex.RUnlock()
+	return len(fake.secretArgsForCall)
+}
+
+func (fake *FakeSecretFactory) SecretCalls(stub func(string, string, string) (string, bool, error)) {
+	fake.secretMutex.Lock()
+	defer fake.secretMutex.Unlock()
+	fake.SecretStub = stub
+}
+
+func (fake *FakeSecretFactory) SecretArgsForCall(i int) (string, string, string) {
+	fake.secretMutex.RLock()
+	defer fake.secretMutex.RUnlock()
+	argsForCall := fake.secretArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeSecretFactory) SecretReturns(result1 string, result2 bool, result3 error) {
+	fake.secretMutex.Lock()
+	defer fake.secretMutex.Unlock()
+	fake.SecretStub = nil
+	fake.secretReturns = struct {
+		result1 string
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeSecretFactory) SecretReturnsOnCall(i int, result1 string, result2 bool, result3 error) {
+	fake.secretMutex.Lock()
+	defer fake.secretMutex.Unlock()
+	fake.SecretStub = nil
+	if fake.secretReturnsOnCall == nil {
+		fake.secretReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 bool
+			result3 error
+		})
+	}
+	fake.secretReturnsOnCall[i] = struct {
+		result1 string
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeSecretFactory) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeSecretFactory) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.SecretFactory = new(FakeSecretFactory)
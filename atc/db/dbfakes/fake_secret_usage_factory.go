@@ -0,0 +1,199 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeSecretUsageFactory struct {
+	AllSecretUsagesStub        func(string) ([]atc.SecretUsage, error)
+	allSecretUsagesMutex       sync.RWMutex
+	allSecretUsagesArgsForCall []struct {
+		arg1 string
+	}
+	allSecretUsagesReturns struct {
+		result1 []atc.SecretUsage
+		result2 error
+	}
+	allSecretUsagesReturnsOnCall map[int]struct {
+		result1 []atc.SecretUsage
+		result2 error
+	}
+	VisibleSecretUsagesStub        func(string, []string) ([]atc.SecretUsage, error)
+	visibleSecretUsagesMutex       sync.RWMutex
+	visibleSecretUsagesArgsForCall []struct {
+		arg1 string
+		arg2 []string
+	}
+	visibleSecretUsagesReturns struct {
+		result1 []atc.SecretUsage
+		result2 error
+	}
+	visibleSecretUsagesReturnsOnCall map[int]struct {
+		result1 []atc.SecretUsage
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeSecretUsageFactory) AllSecretUsages(arg1 string) ([]atc.SecretUsage, error) {
+	fake.allSecretUsagesMutex.Lock()
+	ret, specificReturn := fake.allSecretUsagesReturnsOnCall[len(fake.allSecretUsagesArgsForCall)]
+	fake.allSecretUsagesArgsForCall = append(fake.allSecretUsagesArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.AllSecretUsagesStub
+	fakeReturns := fake.allSecretUsagesReturns
+	fake.recordInvocation("AllSecretUsages", []interface{}{arg1})
+	fake.allSecretUsagesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSecretUsageFactory) AllSecretUsagesCallCount() int {
+	fake.allSecretUsagesMutex.RLock()
+	defer fake.allSecretUsagesMutex.RUnlock()
+	return len(fake.allSecretUsagesArgsForCall)
+}
+
+func (fake *FakeSecretUsageFactory) AllSecretUsagesCalls(stub func(string) ([]atc.SecretUsage, error)) {
+	fake.allSecretUsagesMutex.Lock()
+	defer fake.allSecretUsagesMutex.Unlock()
+	fake.AllSecretUsagesStub = stub
+}
+
+func (fake *FakeSecretUsageFactory) AllSecretUsagesArgsForCall(i int) string {
+	fake.allSecretUsagesMutex.RLock()
+	defer fake.allSecretUsagesMutex.RUnlock()
+	argsForCall := fake.allSecretUsagesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeSecretUsageFactory) AllSecretUsagesReturns(result1 []atc.SecretUsage, result2 error) {
+	fake.allSecretUsagesMutex.Lock()
+	defer fake.allSecretUsagesMutex.Unlock()
+	fake.AllSecretUsagesStub = nil
+	fake.allSecretUsagesReturns = struct {
+		result1 []atc.SecretUsage
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSecretUsageFactory) AllSecretUsagesReturnsOnCall(i int, result1 []atc.SecretUsage, result2 error) {
+	fake.allSecretUsagesMutex.Lock()
+	defer fake.allSecretUsagesMutex.Unlock()
+	fake.AllSecretUsagesStub = nil
+	if fake.allSecretUsagesReturnsOnCall == nil {
+		fake.allSecretUsagesReturnsOnCall = make(map[int]struct {
+			result1 []atc.SecretUsage
+			result2 error
+		})
+	}
+	fake.allSecretUsagesReturnsOnCall[i] = struct {
+		result1 []atc.SecretUsage
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSecretUsageFactory) VisibleSecretUsages(arg1 string, arg2 []string) ([]atc.SecretUsage, error) {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.visibleSecretUsagesMutex.Lock()
+	ret, specificReturn := fake.visibleSecretUsagesReturnsOnCall[len(fake.visibleSecretUsagesArgsForCall)]
+	fake.visibleSecretUsagesArgsForCall = append(fake.visibleSecretUsagesArgsForCall, struct {
+		arg1 string
+		arg2 []string
+	}{arg1, arg2Copy})
+	stub := fake.VisibleSecretUsagesStub
+	fakeReturns := fake.visibleSecretUsagesReturns
+	fake.recordInvocation("VisibleSecretUsages", []interface{}{arg1, arg2Copy})
+	fake.visibleSecretUsagesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSecretUsageFactory) VisibleSecretUsagesCallCount() int {
+	fake.visibleSecretUsagesMutex.RLock()
+	defer fake.visibleSecretUsagesMutex.RUnlock()
+	return len(fake.visibleSecretUsagesArgsForCall)
+}
+
+func (fake *FakeSecretUsageFactory) VisibleSecretUsagesCalls(stub func(string, []string) ([]atc.SecretUsage, error)) {
+	fake.visibleSecretUsagesMutex.Lock()
+	defer fake.visibleSecretUsagesMutex.Unlock()
+	fake.VisibleSecretUsagesStub = stub
+}
+
+func (fake *FakeSecretUsageFactory) VisibleSecretUsagesArgsForCall(i int) (string, []string) {
+	fake.visibleSecretUsagesMutex.RLock()
+	defer fake.visibleSecretUsagesMutex.RUnlock()
+	argsForCall := fake.visibleSecretUsagesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeSecretUsageFactory) VisibleSecretUsagesReturns(result1 []atc.SecretUsage, result2 error) {
+	fake.visibleSecretUsagesMutex.Lock()
+	defer fake.visibleSecretUsagesMutex.Unlock()
+	fake.VisibleSecretUsagesStub = nil
+	fake.visibleSecretUsagesReturns = struct {
+		result1 []atc.SecretUsage
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSecretUsageFactory) VisibleSecretUsagesReturnsOnCall(i int, result1 []atc.SecretUsage, result2 error) {
+	fake.visibleSecretUsagesMutex.Lock()
+	defer fake.visibleSecretUsagesMutex.Unlock()
+	fake.VisibleSecretUsagesStub = nil
+	if fake.visibleSecretUsagesReturnsOnCall == nil {
+		fake.visibleSecretUsagesReturnsOnCall = make(map[int]struct {
+			result1 []atc.SecretUsage
+			result2 error
+		})
+	}
+	fake.visibleSecretUsagesReturnsOnCall[i] = struct {
+		result1 []atc.SecretUsage
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSecretUsageFactory) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeSecretUsageFactory) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.SecretUsageFactory = new(FakeSecretUsageFactory)
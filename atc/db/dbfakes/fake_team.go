@@ -108,6 +108,20 @@ type FakeTeam struct {
 	deleteReturnsOnCall map[int]struct {
 		result1 error
 	}
+	DeleteSecretStub        func(string, string) (bool, error)
+	deleteSecretMutex       sync.RWMutex
+	deleteSecretArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	deleteSecretReturns struct {
+		result1 bool
+		result2 error
+	}
+	deleteSecretReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	FindCheckContainersStub        func(lager.Logger, atc.PipelineRef, string) ([]db.Container, map[int]time.Time, error)
 	findCheckContainersMutex       sync.RWMutex
 	findCheckContainersArgsForCall []struct {
@@ -376,13 +390,27 @@ type FakeTeam struct {
 		result1 bool
 		result2 error
 	}
-	SavePipelineStub        func(atc.PipelineRef, atc.Config, db.ConfigVersion, bool) (db.Pipeline, bool, error)
+	ResourcesOfTypeStub        func(string) (db.Resources, error)
+	resourcesOfTypeMutex       sync.RWMutex
+	resourcesOfTypeArgsForCall []struct {
+		arg1 string
+	}
+	resourcesOfTypeReturns struct {
+		result1 db.Resources
+		result2 error
+	}
+	resourcesOfTypeReturnsOnCall map[int]struct {
+		result1 db.Resources
+		result2 error
+	}
+	SavePipelineStub        func(atc.PipelineRef, atc.Config, db.ConfigVersion, bool, string) (db.Pipeline, bool, error)
 	savePipelineMutex       sync.RWMutex
 	savePipelineArgsForCall []struct {
 		arg1 atc.PipelineRef
 		arg2 atc.Config
 		arg3 db.ConfigVersion
 		arg4 bool
+		arg5 string
 	}
 	savePipelineReturns struct {
 		result1 db.Pipeline
@@ -408,6 +436,32 @@ type FakeTeam struct {
 		result1 db.Worker
 		result2 error
 	}
+	SecretsStub        func() ([]db.Secret, error)
+	secretsMutex       sync.RWMutex
+	secretsArgsForCall []struct {
+	}
+	secretsReturns struct {
+		result1 []db.Secret
+		result2 error
+	}
+	secretsReturnsOnCall map[int]struct {
+		result1 []db.Secret
+		result2 error
+	}
+	SetSecretStub        func(string, string, string, string) error
+	setSecretMutex       sync.RWMutex
+	setSecretArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+	}
+	setSecretReturns struct {
+		result1 error
+	}
+	setSecretReturnsOnCall map[int]struct {
+		result1 error
+	}
 	UpdateProviderAuthStub        func(atc.TeamAuth) error
 	updateProviderAuthMutex       sync.RWMutex
 	updateProviderAuthArgsForCall []struct {
@@ -419,6 +473,39 @@ type FakeTeam struct {
 	updateProviderAuthReturnsOnCall map[int]struct {
 		result1 error
 	}
+	UpdateProviderAuthAndVarSourcesStub        func(atc.TeamAuth, atc.VarSourceConfigs) error
+	updateProviderAuthAndVarSourcesMutex       sync.RWMutex
+	updateProviderAuthAndVarSourcesArgsForCall []struct {
+		arg1 atc.TeamAuth
+		arg2 atc.VarSourceConfigs
+	}
+	updateProviderAuthAndVarSourcesReturns struct {
+		result1 error
+	}
+	updateProviderAuthAndVarSourcesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateVarSourcesStub        func(atc.VarSourceConfigs) error
+	updateVarSourcesMutex       sync.RWMutex
+	updateVarSourcesArgsForCall []struct {
+		arg1 atc.VarSourceConfigs
+	}
+	updateVarSourcesReturns struct {
+		result1 error
+	}
+	updateVarSourcesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	VarSourcesStub        func() atc.VarSourceConfigs
+	varSourcesMutex       sync.RWMutex
+	varSourcesArgsForCall []struct {
+	}
+	varSourcesReturns struct {
+		result1 atc.VarSourceConfigs
+	}
+	varSourcesReturnsOnCall map[int]struct {
+		result1 atc.VarSourceConfigs
+	}
 	WorkersStub        func() ([]db.Worker, error)
 	workersMutex       sync.RWMutex
 	workersArgsForCall []struct {
@@ -904,6 +991,71 @@ func (fake *FakeTeam) DeleteReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeTeam) DeleteSecret(arg1 string, arg2 string) (bool, error) {
+	fake.deleteSecretMutex.Lock()
+	ret, specificReturn := fake.deleteSecretReturnsOnCall[len(fake.deleteSecretArgsForCall)]
+	fake.deleteSecretArgsForCall = append(fake.deleteSecretArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.DeleteSecretStub
+	fakeReturns := fake.deleteSecretReturns
+	fake.recordInvocation("DeleteSecret", []interface{}{arg1, arg2})
+	fake.deleteSecretMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) DeleteSecretCallCount() int {
+	fake.deleteSecretMutex.RLock()
+	defer fake.deleteSecretMutex.RUnlock()
+	return len(fake.deleteSecretArgsForCall)
+}
+
+func (fake *FakeTeam) DeleteSecretCalls(stub func(string, string) (bool, error)) {
+	fake.deleteSecretMutex.Lock()
+	defer fake.deleteSecretMutex.Unlock()
+	fake.DeleteSecretStub = stub
+}
+
+func (fake *FakeTeam) DeleteSecretArgsForCall(i int) (string, string) {
+	fake.deleteSecretMutex.RLock()
+	defer fake.deleteSecretMutex.RUnlock()
+	argsForCall := fake.deleteSecretArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) DeleteSecretReturns(result1 bool, result2 error) {
+	fake.deleteSecretMutex.Lock()
+	defer fake.deleteSecretMutex.Unlock()
+	fake.DeleteSecretStub = nil
+	fake.deleteSecretReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) DeleteSecretReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.deleteSecretMutex.Lock()
+	defer fake.deleteSecretMutex.Unlock()
+	fake.DeleteSecretStub = nil
+	if fake.deleteSecretReturnsOnCall == nil {
+		fake.deleteSecretReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.deleteSecretReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) FindCheckContainers(arg1 lager.Logger, arg2 atc.PipelineRef, arg3 string) ([]db.Container, map[int]time.Time, error) {
 	fake.findCheckContainersMutex.Lock()
 	ret, specificReturn := fake.findCheckContainersReturnsOnCall[len(fake.findCheckContainersArgsForCall)]
@@ -2177,7 +2329,71 @@ func (fake *FakeTeam) RenamePipelineReturnsOnCall(i int, result1 bool, result2 e
 	}{result1, result2}
 }
 
-func (fake *FakeTeam) SavePipeline(arg1 atc.PipelineRef, arg2 atc.Config, arg3 db.ConfigVersion, arg4 bool) (db.Pipeline, bool, error) {
+func (fake *FakeTeam) ResourcesOfType(arg1 string) (db.Resources, error) {
+	fake.resourcesOfTypeMutex.Lock()
+	ret, specificReturn := fake.resourcesOfTypeReturnsOnCall[len(fake.resourcesOfTypeArgsForCall)]
+	fake.resourcesOfTypeArgsForCall = append(fake.resourcesOfTypeArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ResourcesOfTypeStub
+	fakeReturns := fake.resourcesOfTypeReturns
+	fake.recordInvocation("ResourcesOfType", []interface{}{arg1})
+	fake.resourcesOfTypeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) ResourcesOfTypeCallCount() int {
+	fake.resourcesOfTypeMutex.RLock()
+	defer fake.resourcesOfTypeMutex.RUnlock()
+	return len(fake.resourcesOfTypeArgsForCall)
+}
+
+func (fake *FakeTeam) ResourcesOfTypeCalls(stub func(string) (db.Resources, error)) {
+	fake.resourcesOfTypeMutex.Lock()
+	defer fake.resourcesOfTypeMutex.Unlock()
+	fake.ResourcesOfTypeStub = stub
+}
+
+func (fake *FakeTeam) ResourcesOfTypeArgsForCall(i int) string {
+	fake.resourcesOfTypeMutex.RLock()
+	defer fake.resourcesOfTypeMutex.RUnlock()
+	argsForCall := fake.resourcesOfTypeArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) ResourcesOfTypeReturns(result1 db.Resources, result2 error) {
+	fake.resourcesOfTypeMutex.Lock()
+	defer fake.resourcesOfTypeMutex.Unlock()
+	fake.ResourcesOfTypeStub = nil
+	fake.resourcesOfTypeReturns = struct {
+		result1 db.Resources
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) ResourcesOfTypeReturnsOnCall(i int, result1 db.Resources, result2 error) {
+	fake.resourcesOfTypeMutex.Lock()
+	defer fake.resourcesOfTypeMutex.Unlock()
+	fake.ResourcesOfTypeStub = nil
+	if fake.resourcesOfTypeReturnsOnCall == nil {
+		fake.resourcesOfTypeReturnsOnCall = make(map[int]struct {
+			result1 db.Resources
+			result2 error
+		})
+	}
+	fake.resourcesOfTypeReturnsOnCall[i] = struct {
+		result1 db.Resources
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) SavePipeline(arg1 atc.PipelineRef, arg2 atc.Config, arg3 db.ConfigVersion, arg4 bool, arg5 string) (db.Pipeline, bool, error) {
 	fake.savePipelineMutex.Lock()
 	ret, specificReturn := fake.savePipelineReturnsOnCall[len(fake.savePipelineArgsForCall)]
 	fake.savePipelineArgsForCall = append(fake.savePipelineArgsForCall, struct {
@@ -2185,13 +2401,14 @@ func (fake *FakeTeam) SavePipeline(arg1 atc.PipelineRef, arg2 atc.Config, arg3 d
 		arg2 atc.Config
 		arg3 db.ConfigVersion
 		arg4 bool
-	}{arg1, arg2, arg3, arg4})
+		arg5 string
+	}{arg1, arg2, arg3, arg4, arg5})
 	stub := fake.SavePipelineStub
 	fakeReturns := fake.savePipelineReturns
-	fake.recordInvocation("SavePipeline", []interface{}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("SavePipeline", []interface{}{arg1, arg2, arg3, arg4, arg5})
 	fake.savePipelineMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3, arg4)
+		return stub(arg1, arg2, arg3, arg4, arg5)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2, ret.result3
@@ -2205,17 +2422,17 @@ func (fake *FakeTeam) SavePipelineCallCount() int {
 	return len(fake.savePipelineArgsForCall)
 }
 
-func (fake *FakeTeam) SavePipelineCalls(stub func(atc.PipelineRef, atc.Config, db.ConfigVersion, bool) (db.Pipeline, bool, error)) {
+func (fake *FakeTeam) SavePipelineCalls(stub func(atc.PipelineRef, atc.Config, db.ConfigVersion, bool, string) (db.Pipeline, bool, error)) {
 	fake.savePipelineMutex.Lock()
 	defer fake.savePipelineMutex.Unlock()
 	fake.SavePipelineStub = stub
 }
 
-func (fake *FakeTeam) SavePipelineArgsForCall(i int) (atc.PipelineRef, atc.Config, db.ConfigVersion, bool) {
+func (fake *FakeTeam) SavePipelineArgsForCall(i int) (atc.PipelineRef, atc.Config, db.ConfigVersion, bool, string) {
 	fake.savePipelineMutex.RLock()
 	defer fake.savePipelineMutex.RUnlock()
 	argsForCall := fake.savePipelineArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
 }
 
 func (fake *FakeTeam) SavePipelineReturns(result1 db.Pipeline, result2 bool, result3 error) {
@@ -2312,6 +2529,126 @@ func (fake *FakeTeam) SaveWorkerReturnsOnCall(i int, result1 db.Worker, result2
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) Secrets() ([]db.Secret, error) {
+	fake.secretsMutex.Lock()
+	ret, specificReturn := fake.secretsReturnsOnCall[len(fake.secretsArgsForCall)]
+	fake.secretsArgsForCall = append(fake.secretsArgsForCall, struct {
+	}{})
+	stub := fake.SecretsStub
+	fakeReturns := fake.secretsReturns
+	fake.recordInvocation("Secrets", []interface{}{})
+	fake.secretsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) SecretsCallCount() int {
+	fake.secretsMutex.RLock()
+	defer fake.secretsMutex.RUnlock()
+	return len(fake.secretsArgsForCall)
+}
+
+func (fake *FakeTeam) SecretsCalls(stub func() ([]db.Secret, error)) {
+	fake.secretsMutex.Lock()
+	defer fake.secretsMutex.Unlock()
+	fake.SecretsStub = stub
+}
+
+func (fake *FakeTeam) SecretsReturns(result1 []db.Secret, result2 error) {
+	fake.secretsMutex.Lock()
+	defer fake.secretsMutex.Unlock()
+	fake.SecretsStub = nil
+	fake.secretsReturns = struct {
+		result1 []db.Secret
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) SecretsReturnsOnCall(i int, result1 []db.Secret, result2 error) {
+	fake.secretsMutex.Lock()
+	defer fake.secretsMutex.Unlock()
+	fake.SecretsStub = nil
+	if fake.secretsReturnsOnCall == nil {
+		fake.secretsReturnsOnCall = make(map[int]struct {
+			result1 []db.Secret
+			result2 error
+		})
+	}
+	fake.secretsReturnsOnCall[i] = struct {
+		result1 []db.Secret
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) SetSecret(arg1 string, arg2 string, arg3 string, arg4 string) error {
+	fake.setSecretMutex.Lock()
+	ret, specificReturn := fake.setSecretReturnsOnCall[len(fake.setSecretArgsForCall)]
+	fake.setSecretArgsForCall = append(fake.setSecretArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.SetSecretStub
+	fakeReturns := fake.setSecretReturns
+	fake.recordInvocation("SetSecret", []interface{}{arg1, arg2, arg3, arg4})
+	fake.setSecretMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) SetSecretCallCount() int {
+	fake.setSecretMutex.RLock()
+	defer fake.setSecretMutex.RUnlock()
+	return len(fake.setSecretArgsForCall)
+}
+
+func (fake *FakeTeam) SetSecretCalls(stub func(string, string, string, string) error) {
+	fake.setSecretMutex.Lock()
+	defer fake.setSecretMutex.Unlock()
+	fake.SetSecretStub = stub
+}
+
+func (fake *FakeTeam) SetSecretArgsForCall(i int) (string, string, string, string) {
+	fake.setSecretMutex.RLock()
+	defer fake.setSecretMutex.RUnlock()
+	argsForCall := fake.setSecretArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeTeam) SetSecretReturns(result1 error) {
+	fake.setSecretMutex.Lock()
+	defer fake.setSecretMutex.Unlock()
+	fake.SetSecretStub = nil
+	fake.setSecretReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) SetSecretReturnsOnCall(i int, result1 error) {
+	fake.setSecretMutex.Lock()
+	defer fake.setSecretMutex.Unlock()
+	fake.SetSecretStub = nil
+	if fake.setSecretReturnsOnCall == nil {
+		fake.setSecretReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setSecretReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeTeam) UpdateProviderAuth(arg1 atc.TeamAuth) error {
 	fake.updateProviderAuthMutex.Lock()
 	ret, specificReturn := fake.updateProviderAuthReturnsOnCall[len(fake.updateProviderAuthArgsForCall)]
@@ -2373,6 +2710,182 @@ func (fake *FakeTeam) UpdateProviderAuthReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeTeam) UpdateProviderAuthAndVarSources(arg1 atc.TeamAuth, arg2 atc.VarSourceConfigs) error {
+	fake.updateProviderAuthAndVarSourcesMutex.Lock()
+	ret, specificReturn := fake.updateProviderAuthAndVarSourcesReturnsOnCall[len(fake.updateProviderAuthAndVarSourcesArgsForCall)]
+	fake.updateProviderAuthAndVarSourcesArgsForCall = append(fake.updateProviderAuthAndVarSourcesArgsForCall, struct {
+		arg1 atc.TeamAuth
+		arg2 atc.VarSourceConfigs
+	}{arg1, arg2})
+	stub := fake.UpdateProviderAuthAndVarSourcesStub
+	fakeReturns := fake.updateProviderAuthAndVarSourcesReturns
+	fake.recordInvocation("UpdateProviderAuthAndVarSources", []interface{}{arg1, arg2})
+	fake.updateProviderAuthAndVarSourcesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) UpdateProviderAuthAndVarSourcesCallCount() int {
+	fake.updateProviderAuthAndVarSourcesMutex.RLock()
+	defer fake.updateProviderAuthAndVarSourcesMutex.RUnlock()
+	return len(fake.updateProviderAuthAndVarSourcesArgsForCall)
+}
+
+func (fake *FakeTeam) UpdateProviderAuthAndVarSourcesCalls(stub func(atc.TeamAuth, atc.VarSourceConfigs) error) {
+	fake.updateProviderAuthAndVarSourcesMutex.Lock()
+	defer fake.updateProviderAuthAndVarSourcesMutex.Unlock()
+	fake.UpdateProviderAuthAndVarSourcesStub = stub
+}
+
+func (fake *FakeTeam) UpdateProviderAuthAndVarSourcesArgsForCall(i int) (atc.TeamAuth, atc.VarSourceConfigs) {
+	fake.updateProviderAuthAndVarSourcesMutex.RLock()
+	defer fake.updateProviderAuthAndVarSourcesMutex.RUnlock()
+	argsForCall := fake.updateProviderAuthAndVarSourcesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) UpdateProviderAuthAndVarSourcesReturns(result1 error) {
+	fake.updateProviderAuthAndVarSourcesMutex.Lock()
+	defer fake.updateProviderAuthAndVarSourcesMutex.Unlock()
+	fake.UpdateProviderAuthAndVarSourcesStub = nil
+	fake.updateProviderAuthAndVarSourcesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateProviderAuthAndVarSourcesReturnsOnCall(i int, result1 error) {
+	fake.updateProviderAuthAndVarSourcesMutex.Lock()
+	defer fake.updateProviderAuthAndVarSourcesMutex.Unlock()
+	fake.UpdateProviderAuthAndVarSourcesStub = nil
+	if fake.updateProviderAuthAndVarSourcesReturnsOnCall == nil {
+		fake.updateProviderAuthAndVarSourcesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateProviderAuthAndVarSourcesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateVarSources(arg1 atc.VarSourceConfigs) error {
+	fake.updateVarSourcesMutex.Lock()
+	ret, specificReturn := fake.updateVarSourcesReturnsOnCall[len(fake.updateVarSourcesArgsForCall)]
+	fake.updateVarSourcesArgsForCall = append(fake.updateVarSourcesArgsForCall, struct {
+		arg1 atc.VarSourceConfigs
+	}{arg1})
+	stub := fake.UpdateVarSourcesStub
+	fakeReturns := fake.updateVarSourcesReturns
+	fake.recordInvocation("UpdateVarSources", []interface{}{arg1})
+	fake.updateVarSourcesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) UpdateVarSourcesCallCount() int {
+	fake.updateVarSourcesMutex.RLock()
+	defer fake.updateVarSourcesMutex.RUnlock()
+	return len(fake.updateVarSourcesArgsForCall)
+}
+
+func (fake *FakeTeam) UpdateVarSourcesCalls(stub func(atc.VarSourceConfigs) error) {
+	fake.updateVarSourcesMutex.Lock()
+	defer fake.updateVarSourcesMutex.Unlock()
+	fake.UpdateVarSourcesStub = stub
+}
+
+func (fake *FakeTeam) UpdateVarSourcesArgsForCall(i int) atc.VarSourceConfigs {
+	fake.updateVarSourcesMutex.RLock()
+	defer fake.updateVarSourcesMutex.RUnlock()
+	argsForCall := fake.updateVarSourcesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) UpdateVarSourcesReturns(result1 error) {
+	fake.updateVarSourcesMutex.Lock()
+	defer fake.updateVarSourcesMutex.Unlock()
+	fake.UpdateVarSourcesStub = nil
+	fake.updateVarSourcesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateVarSourcesReturnsOnCall(i int, result1 error) {
+	fake.updateVarSourcesMutex.Lock()
+	defer fake.updateVarSourcesMutex.Unlock()
+	fake.UpdateVarSourcesStub = nil
+	if fake.updateVarSourcesReturnsOnCall == nil {
+		fake.updateVarSourcesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateVarSourcesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) VarSources() atc.VarSourceConfigs {
+	fake.varSourcesMutex.Lock()
+	ret, specificReturn := fake.varSourcesReturnsOnCall[len(fake.varSourcesArgsForCall)]
+	fake.varSourcesArgsForCall = append(fake.varSourcesArgsForCall, struct {
+	}{})
+	stub := fake.VarSourcesStub
+	fakeReturns := fake.varSourcesReturns
+	fake.recordInvocation("VarSources", []interface{}{})
+	fake.varSourcesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) VarSourcesCallCount() int {
+	fake.varSourcesMutex.RLock()
+	defer fake.varSourcesMutex.RUnlock()
+	return len(fake.varSourcesArgsForCall)
+}
+
+func (fake *FakeTeam) VarSourcesCalls(stub func() atc.VarSourceConfigs) {
+	fake.varSourcesMutex.Lock()
+	defer fake.varSourcesMutex.Unlock()
+	fake.VarSourcesStub = stub
+}
+
+func (fake *FakeTeam) VarSourcesReturns(result1 atc.VarSourceConfigs) {
+	fake.varSourcesMutex.Lock()
+	defer fake.varSourcesMutex.Unlock()
+	fake.VarSourcesStub = nil
+	fake.varSourcesReturns = struct {
+		result1 atc.VarSourceConfigs
+	}{result1}
+}
+
+func (fake *FakeTeam) VarSourcesReturnsOnCall(i int, result1 atc.VarSourceConfigs) {
+	fake.varSourcesMutex.Lock()
+	defer fake.varSourcesMutex.Unlock()
+	fake.VarSourcesStub = nil
+	if fake.varSourcesReturnsOnCall == nil {
+		fake.varSourcesReturnsOnCall = make(map[int]struct {
+			result1 atc.VarSourceConfigs
+		})
+	}
+	fake.varSourcesReturnsOnCall[i] = struct {
+		result1 atc.VarSourceConfigs
+	}{result1}
+}
+
 func (fake *FakeTeam) Workers() ([]db.Worker, error) {
 	fake.workersMutex.Lock()
 	ret, specificReturn := fake.workersReturnsOnCall[len(fake.workersArgsForCall)]
@@ -218,7 +218,7 @@ func (builder Builder) WithPipeline(config atc.Config) SetupFunc {
 			from = scenario.Pipeline.ConfigVersion()
 		}
 
-		p, _, err := scenario.Team.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, config, from, false)
+		p, _, err := scenario.Team.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, config, from, false, "some-user")
 		if err != nil {
 			return err
 		}
@@ -13,6 +13,7 @@ const (
 	LatestVersionNotFound ResolutionFailure = "latest version of resource not found"
 	VersionNotFound       ResolutionFailure = "version of resource not found"
 	NoSatisfiableBuilds   ResolutionFailure = "no satisfiable builds from passed jobs found for set of inputs"
+	NoMatchingVersion     ResolutionFailure = "no version of resource matching filter found"
 )
 
 type PinnedVersionNotFound struct {
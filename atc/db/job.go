@@ -30,8 +30,23 @@ type InputConfig struct {
 	Passed          JobSet
 	UseEveryVersion bool
 	PinnedVersion   atc.Version
+	VersionFilter   *atc.VersionFilter
 	ResourceID      int
 	JobID           int
+
+	// PassedResourceIDs maps passed jobs in other pipelines to the resource in
+	// their pipeline which shares the input's resource config.
+	PassedResourceIDs map[int]int
+}
+
+// PassedResourceID returns the resource whose versions the passed job must
+// have used for them to satisfy the input.
+func (cfg InputConfig) PassedResourceID(passedJobID int) int {
+	if resourceID, found := cfg.PassedResourceIDs[passedJobID]; found {
+		return resourceID
+	}
+
+	return cfg.ResourceID
 }
 
 func (cfgs InputConfigs) String() string {
@@ -68,8 +83,10 @@ type Job interface {
 	Public() bool
 	ScheduleRequestedTime() time.Time
 	MaxInFlight() int
+	Priority() int
 	DisableManualTrigger() bool
 	DisableReruns() bool
+	NextScheduledTrigger() time.Time
 
 	Config() (atc.JobConfig, error)
 	Inputs() ([]atc.JobInput, error)
@@ -91,6 +108,9 @@ type Job interface {
 	CreateBuild(createdBy string) (Build, error)
 	RerunBuild(build Build, createdBy string) (Build, error)
 
+	CreateScheduledBuild(nextTrigger time.Time) (Build, bool, error)
+	SkipScheduledTrigger(nextTrigger time.Time) (bool, error)
+
 	RequestSchedule() error
 	UpdateLastScheduled(time.Time) error
 
@@ -133,12 +153,14 @@ var jobsQuery = psql.Select(
 	"j.has_new_inputs",
 	"j.schedule_requested",
 	"j.max_in_flight",
+	"j.priority",
 	"j.disable_manual_trigger",
 	"j.disable_reruns",
 	"j.paused_by",
 	"j.paused_at",
 	"p.paused",
-	"p.archived").
+	"p.archived",
+	"j.next_scheduled_trigger").
 	From("jobs j").
 	LeftJoin("pipelines p ON j.pipeline_id = p.id").
 	LeftJoin("teams t ON p.team_id = t.id")
@@ -163,6 +185,7 @@ type job struct {
 	paused                bool
 	pausedBy              string
 	pausedAt              time.Time
+	nextScheduledTrigger  time.Time
 	pipelinePaused        bool
 	pipelineArchived      bool
 	public                bool
@@ -173,6 +196,7 @@ type job struct {
 	hasNewInputs          bool
 	scheduleRequestedTime time.Time
 	maxInFlight           int
+	priority              int
 	disableManualTrigger  bool
 	disableReruns         bool
 
@@ -229,6 +253,7 @@ func (j *job) Name() string                     { return j.name }
 func (j *job) Paused() bool                     { return j.paused }
 func (j *job) PausedAt() time.Time              { return j.pausedAt }
 func (j *job) PausedBy() string                 { return j.pausedBy }
+func (j *job) NextScheduledTrigger() time.Time  { return j.nextScheduledTrigger }
 func (j *job) PipelineIsPaused() bool           { return j.pipelinePaused }
 func (j *job) PipelineIsArchived() bool         { return j.pipelineArchived }
 func (j *job) Public() bool                     { return j.public }
@@ -239,6 +264,7 @@ func (j *job) Tags() []string                   { return j.tags }
 func (j *job) HasNewInputs() bool               { return j.hasNewInputs }
 func (j *job) ScheduleRequestedTime() time.Time { return j.scheduleRequestedTime }
 func (j *job) MaxInFlight() int                 { return j.maxInFlight }
+func (j *job) Priority() int                    { return j.priority }
 func (j *job) DisableManualTrigger() bool       { return j.disableManualTrigger }
 func (j *job) DisableReruns() bool              { return j.disableReruns }
 
@@ -332,6 +358,7 @@ func (j *job) AlgorithmInputs() (InputConfigs, error) {
 			}
 
 			inputConfig.UseEveryVersion = version.Every
+			inputConfig.VersionFilter = version.Filter
 
 			if version.Pinned != nil {
 				inputConfig.PinnedVersion = version.Pinned
@@ -350,11 +377,64 @@ func (j *job) AlgorithmInputs() (InputConfigs, error) {
 		inputs = append(inputs, inputConfig)
 	}
 
+	err = j.loadPassedResourceIDs(inputs)
+	if err != nil {
+		return nil, err
+	}
+
 	return inputs, nil
 }
 
+// loadPassedResourceIDs finds, for each input passing through jobs in other
+// pipelines, the resource in each of those pipelines with the same resource
+// config as the input's resource, preferring one with the same name.
+func (j *job) loadPassedResourceIDs(inputs InputConfigs) error {
+	rows, err := psql.Select("ji.name", "ji.passed_job_id", "ur.id").
+		Options("DISTINCT ON (ji.name, ji.passed_job_id)").
+		From("job_inputs ji").
+		Join("jobs pj ON pj.id = ji.passed_job_id").
+		Join("resources r ON r.id = ji.resource_id").
+		Join("resources ur ON ur.pipeline_id = pj.pipeline_id AND ur.resource_config_id = r.resource_config_id").
+		Where(sq.Eq{
+			"ji.job_id": j.id,
+			"ur.active": true,
+		}).
+		Where(sq.NotEq{"ji.passed_job_ref": nil}).
+		OrderBy("ji.name", "ji.passed_job_id", "ur.name = r.name DESC", "ur.id").
+		RunWith(j.conn).
+		Query()
+	if err != nil {
+		return err
+	}
+
+	defer Close(rows)
+
+	for rows.Next() {
+		var inputName string
+		var passedJobID, resourceID int
+		err = rows.Scan(&inputName, &passedJobID, &resourceID)
+		if err != nil {
+			return err
+		}
+
+		for i, input := range inputs {
+			if input.Name != inputName {
+				continue
+			}
+
+			if input.PassedResourceIDs == nil {
+				inputs[i].PassedResourceIDs = map[int]int{}
+			}
+
+			inputs[i].PassedResourceIDs[passedJobID] = resourceID
+		}
+	}
+
+	return rows.Err()
+}
+
 func (j *job) Inputs() ([]atc.JobInput, error) {
-	rows, err := psql.Select("ji.name", "r.name", "array_remove(array_agg(p.name ORDER BY p.id), NULL)", "ji.trigger", "ji.version").
+	rows, err := psql.Select("ji.name", "r.name", "array_remove(array_agg(COALESCE(ji.passed_job_ref, p.name) ORDER BY p.id), NULL)", "ji.trigger", "ji.version").
 		From("job_inputs ji").
 		Join("resources r ON r.id = ji.resource_id").
 		LeftJoin("jobs p ON p.id = ji.passed_job_id").
@@ -589,7 +669,10 @@ func (j *job) Build(name string) (Build, bool, error) {
 
 	if name == "latest" {
 		query = buildsQuery.
-			Where(sq.Eq{"b.job_id": j.id}).
+			Where(sq.Eq{
+				"b.job_id":          j.id,
+				"b.parent_build_id": nil,
+			}).
 			OrderBy("b.id DESC").
 			Limit(1)
 	} else {
@@ -623,6 +706,20 @@ func (j *job) ScheduleBuild(build Build) (bool, error) {
 		return false, nil
 	}
 
+	if build.ParentBuildID() != 0 {
+		// the cells of a matrix build run within the slot of their parent
+		_, err := psql.Update("builds").
+			Set("scheduled", true).
+			Where(sq.Eq{"id": build.ID()}).
+			RunWith(j.conn).
+			Exec()
+		if err != nil {
+			return false, err
+		}
+
+		return true, nil
+	}
+
 	tx, err := j.conn.Begin()
 	if err != nil {
 		return false, err
@@ -635,8 +732,17 @@ func (j *job) ScheduleBuild(build Build) (bool, error) {
 		return false, err
 	}
 
+	var concurrencyGroupReached bool
+	if !reached {
+		concurrencyGroupReached, err = j.isConcurrencyGroupReached(tx, build.ID())
+		if err != nil {
+			return false, err
+		}
+	}
+
 	result, err := psql.Update("jobs").
 		Set("max_in_flight_reached", reached).
+		Set("concurrency_group_reached", concurrencyGroupReached).
 		Where(sq.Eq{
 			"id": j.id,
 		}).
@@ -656,7 +762,7 @@ func (j *job) ScheduleBuild(build Build) (bool, error) {
 	}
 
 	var scheduled bool
-	if !reached {
+	if !reached && !concurrencyGroupReached {
 		result, err = psql.Update("builds").
 			Set("scheduled", true).
 			Where(sq.Eq{"id": build.ID()}).
@@ -813,7 +919,7 @@ func (j *job) GetPendingBuilds() ([]Build, error) {
 			"b.job_id": j.id,
 			"b.status": BuildStatusPending,
 		}).
-		OrderBy("COALESCE(b.rerun_of, b.rerun_of_old, b.id) ASC, b.id ASC").
+		OrderBy("COALESCE(b.parent_build_id, b.rerun_of, b.rerun_of_old, b.id) ASC, b.id ASC").
 		RunWith(j.conn).
 		Query()
 	if err != nil {
@@ -843,6 +949,103 @@ func (j *job) CreateBuild(createdBy string) (Build, error) {
 
 	defer Rollback(tx)
 
+	build, err := j.createPendingBuild(tx, createdBy)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return build, nil
+}
+
+// CreateScheduledBuild creates a build for the job's schedule and moves its
+// next trigger to the given time. No build is created if the trigger has
+// already been handled, e.g. by another ATC.
+func (j *job) CreateScheduledBuild(nextTrigger time.Time) (Build, bool, error) {
+	tx, err := j.conn.Begin()
+	if err != nil {
+		return nil, false, err
+	}
+
+	defer Rollback(tx)
+
+	advanced, err := j.advanceScheduledTrigger(tx, nextTrigger)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !advanced {
+		return nil, false, nil
+	}
+
+	build, err := j.createPendingBuild(tx, atc.ScheduledBuildCreator)
+	if err != nil {
+		return nil, false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, false, err
+	}
+
+	return build, true, nil
+}
+
+// SkipScheduledTrigger moves the job's next trigger to the given time without
+// creating a build, e.g. because the job is paused.
+func (j *job) SkipScheduledTrigger(nextTrigger time.Time) (bool, error) {
+	tx, err := j.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	defer Rollback(tx)
+
+	advanced, err := j.advanceScheduledTrigger(tx, nextTrigger)
+	if err != nil {
+		return false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	return advanced, nil
+}
+
+func (j *job) advanceScheduledTrigger(tx Tx, nextTrigger time.Time) (bool, error) {
+	result, err := psql.Update("jobs").
+		Set("next_scheduled_trigger", nextTrigger).
+		Where(sq.Eq{
+			"id":                     j.id,
+			"next_scheduled_trigger": j.nextScheduledTrigger,
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	if rowsAffected == 0 {
+		return false, nil
+	}
+
+	j.nextScheduledTrigger = nextTrigger
+
+	return true, nil
+}
+
+func (j *job) createPendingBuild(tx Tx, createdBy string) (Build, error) {
 	buildName, err := j.getNewBuildName(tx)
 	if err != nil {
 		return nil, err
@@ -877,11 +1080,6 @@ func (j *job) CreateBuild(createdBy string) (Build, error) {
 		return nil, err
 	}
 
-	err = tx.Commit()
-	if err != nil {
-		return nil, err
-	}
-
 	return build, nil
 }
 
@@ -918,8 +1116,7 @@ func (j *job) tryRerunBuild(buildToRerun Build, createdBy string) (Build, error)
 		return nil, err
 	}
 
-	rerunBuild := newEmptyBuild(j.conn, j.lockFactory)
-	err = createBuild(tx, rerunBuild, map[string]any{
+	vals := map[string]any{
 		"name":         rerunBuildName,
 		"job_id":       j.id,
 		"pipeline_id":  j.pipelineID,
@@ -928,7 +1125,26 @@ func (j *job) tryRerunBuild(buildToRerun Build, createdBy string) (Build, error)
 		"rerun_of":     buildToRerunID,
 		"rerun_number": rerunNumber,
 		"created_by":   createdBy,
-	})
+	}
+
+	if buildToRerun.ParentBuildID() != 0 {
+		err = j.reopenMatrixBuild(tx, buildToRerun.ParentBuildID())
+		if err != nil {
+			return nil, err
+		}
+
+		matrixValues, err := json.Marshal(buildToRerun.MatrixValues())
+		if err != nil {
+			return nil, err
+		}
+
+		vals["parent_build_id"] = buildToRerun.ParentBuildID()
+		vals["matrix_values"] = matrixValues
+		vals["notified"] = true
+	}
+
+	rerunBuild := newEmptyBuild(j.conn, j.lockFactory)
+	err = createBuild(tx, rerunBuild, vals)
 	if err != nil {
 		return nil, err
 	}
@@ -956,6 +1172,24 @@ func (j *job) tryRerunBuild(buildToRerun Build, createdBy string) (Build, error)
 	return rerunBuild, nil
 }
 
+// reopenMatrixBuild returns the parent of a rerun matrix cell to started so
+// that its status is aggregated again once the rerun finishes.
+func (j *job) reopenMatrixBuild(tx Tx, parentBuildID int) error {
+	parent := newEmptyBuild(j.conn, j.lockFactory)
+	err := scanBuild(parent, buildsQuery.
+		Where(sq.Eq{"b.id": parentBuildID}).
+		Suffix("FOR UPDATE OF b").
+		RunWith(tx).
+		QueryRow(),
+		j.conn.EncryptionStrategy(),
+	)
+	if err != nil {
+		return err
+	}
+
+	return parent.reopenMatrix(tx)
+}
+
 func (j *job) ClearTaskCache(stepName string, cachePath string) (int64, error) {
 	tx, err := j.conn.Begin()
 	if err != nil {
@@ -1036,6 +1270,85 @@ func (j *job) isMaxInFlightReached(tx Tx, buildID int) (bool, error) {
 	return false, nil
 }
 
+// isConcurrencyGroupReached returns whether a build of another job in the
+// job's concurrency group is running, or is ahead of the given build in the
+// queue. Jobs in paused pipelines do not hold up the queue.
+func (j *job) isConcurrencyGroupReached(tx Tx, buildID int) (bool, error) {
+	var name, scope sql.NullString
+	err := psql.Select("concurrency_group", "concurrency_group_scope").
+		From("jobs").
+		Where(sq.Eq{
+			"id": j.id,
+		}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&name, &scope)
+	if err != nil {
+		return false, err
+	}
+
+	if !name.Valid {
+		return false, nil
+	}
+
+	inGroup := sq.Eq{
+		"j.concurrency_group":       name.String,
+		"j.concurrency_group_scope": scope.String,
+		"j.active":                  true,
+	}
+
+	if scope.String == atc.ConcurrencyGroupScopePipeline {
+		inGroup["j.pipeline_id"] = j.pipelineID
+	} else {
+		inGroup["p.team_id"] = j.teamID
+	}
+
+	var running int
+	err = psql.Select("COUNT(*)").
+		From("builds b").
+		Join("jobs j ON j.id = b.job_id").
+		Join("pipelines p ON p.id = j.pipeline_id").
+		Where(inGroup).
+		Where(sq.Eq{"b.completed": false, "b.scheduled": true, "b.parent_build_id": nil}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&running)
+	if err != nil {
+		return false, err
+	}
+
+	if running > 0 {
+		return true, nil
+	}
+
+	var nextBuildID int
+	err = psql.Select("b.id").
+		From("builds b").
+		Join("jobs j ON j.id = b.job_id").
+		Join("pipelines p ON p.id = j.pipeline_id").
+		Where(inGroup).
+		Where(sq.Eq{
+			"b.status":            BuildStatusPending,
+			"b.parent_build_id":   nil,
+			"j.paused":            false,
+			"j.inputs_determined": true,
+			"p.paused":            false,
+		}).
+		OrderBy("COALESCE(b.rerun_of, b.id) ASC", "b.id ASC").
+		Limit(1).
+		RunWith(tx).
+		QueryRow().
+		Scan(&nextBuildID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return true, nil
+		}
+		return false, err
+	}
+
+	return nextBuildID != buildID, nil
+}
+
 func (j *job) getSerialGroups(tx Tx) ([]string, error) {
 	rows, err := psql.Select("serial_group").
 		From("jobs_serial_groups").
@@ -1095,8 +1408,9 @@ func (j *job) getRunningBuildsBySerialGroup(tx Tx, serialGroups []string) ([]Bui
 	rows, err := buildsQuery.Options(`DISTINCT ON (b.id)`).
 		Join(`jobs_serial_groups jsg ON j.id = jsg.job_id`).
 		Where(sq.Eq{
-			"jsg.serial_group": serialGroups,
-			"j.pipeline_id":    j.pipelineID,
+			"jsg.serial_group":  serialGroups,
+			"j.pipeline_id":     j.pipelineID,
+			"b.parent_build_id": nil,
 		}).
 		Where(sq.Eq{"b.completed": false, "b.scheduled": true}).
 		RunWith(tx).
@@ -1128,6 +1442,7 @@ func (j *job) getNextPendingBuildBySerialGroup(tx Tx, serialGroups []string) (Bu
 		Where(sq.Eq{
 			"jsg.serial_group":    serialGroups,
 			"b.status":            BuildStatusPending,
+			"b.parent_build_id":   nil,
 			"j.paused":            false,
 			"j.inputs_determined": true,
 			"j.pipeline_id":       j.pipelineID}).
@@ -1404,10 +1719,11 @@ func scanJob(j *job, row scannable) error {
 		pipelineInstanceVars sql.NullString
 		pausedBy             sql.NullString
 		pausedAt             sql.NullTime
+		nextScheduledTrigger sql.NullTime
 	)
 
 	m := pgtype.NewMap()
-	err := row.Scan(&j.id, &j.name, &config, &j.paused, &j.public, &j.firstLoggedBuildID, &j.pipelineID, &j.pipelineName, &pipelineInstanceVars, &j.teamID, &j.teamName, &nonce, m.SQLScanner(&j.tags), &j.hasNewInputs, &j.scheduleRequestedTime, &j.maxInFlight, &j.disableManualTrigger, &j.disableReruns, &pausedBy, &pausedAt, &j.pipelinePaused, &j.pipelineArchived)
+	err := row.Scan(&j.id, &j.name, &config, &j.paused, &j.public, &j.firstLoggedBuildID, &j.pipelineID, &j.pipelineName, &pipelineInstanceVars, &j.teamID, &j.teamName, &nonce, m.SQLScanner(&j.tags), &j.hasNewInputs, &j.scheduleRequestedTime, &j.maxInFlight, &j.priority, &j.disableManualTrigger, &j.disableReruns, &pausedBy, &pausedAt, &j.pipelinePaused, &j.pipelineArchived, &nextScheduledTrigger)
 	if err != nil {
 		return err
 	}
@@ -1435,6 +1751,10 @@ func scanJob(j *job, row scannable) error {
 		j.pausedAt = pausedAt.Time
 	}
 
+	if nextScheduledTrigger.Valid {
+		j.nextScheduledTrigger = nextScheduledTrigger.Time
+	}
+
 	return nil
 }
 
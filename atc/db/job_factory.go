@@ -21,6 +21,8 @@ type JobFactory interface {
 	VisibleJobs([]string) ([]atc.JobSummary, error)
 	AllActiveJobs() ([]atc.JobSummary, error)
 	JobsToSchedule() (SchedulerJobs, error)
+	JobsToTrigger() (Jobs, error)
+	TeamBuildQueues() ([]TeamBuildQueue, error)
 }
 
 type jobFactory struct {
@@ -75,6 +77,68 @@ func (resources SchedulerResources) Lookup(name string) (*SchedulerResource, boo
 	return nil, false
 }
 
+// TeamBuildQueue counts a team's job builds which are waiting to start and
+// which are running.
+type TeamBuildQueue struct {
+	TeamName string
+	Pending  int
+	Running  int
+}
+
+// TeamBuildQueues returns the build queue of every team which has job builds
+// which haven't completed, ordered by team name.
+func (j *jobFactory) TeamBuildQueues() ([]TeamBuildQueue, error) {
+	rows, err := psql.Select(
+		"t.name",
+		"count(*) FILTER (WHERE b.status = 'pending')",
+		"count(*) FILTER (WHERE b.status = 'started')",
+	).
+		From("builds b").
+		Join("teams t ON t.id = b.team_id").
+		Where(sq.NotEq{"b.job_id": nil}).
+		Where(sq.Eq{"b.completed": false}).
+		GroupBy("t.name").
+		OrderBy("t.name").
+		RunWith(j.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	var queues []TeamBuildQueue
+	for rows.Next() {
+		var queue TeamBuildQueue
+		err := rows.Scan(&queue.TeamName, &queue.Pending, &queue.Running)
+		if err != nil {
+			return nil, err
+		}
+
+		queues = append(queues, queue)
+	}
+
+	return queues, rows.Err()
+}
+
+// JobsToTrigger returns the active jobs whose schedule is due to trigger a
+// build, including paused jobs so that their triggers can be skipped.
+func (j *jobFactory) JobsToTrigger() (Jobs, error) {
+	rows, err := jobsQuery.
+		Where(sq.Expr("j.next_scheduled_trigger <= now()")).
+		Where(sq.Eq{
+			"j.active":   true,
+			"p.archived": false,
+		}).
+		RunWith(j.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	return scanJobs(j.conn, j.lockFactory, rows)
+}
+
 func (j *jobFactory) JobsToSchedule() (SchedulerJobs, error) {
 	tx, err := j.conn.Begin()
 	if err != nil {
@@ -363,7 +427,8 @@ func (d dashboardFactory) constructJobsForDashboard() ([]atc.JobSummary, error)
 		"n.id", "n.name", "n.status", "n.start_time", "n.end_time",
 		"t.id", "t.name", "t.status", "t.start_time", "t.end_time",
 		"j.paused_by",
-		"j.paused_at").
+		"j.paused_at",
+		"j.next_scheduled_trigger").
 		From("jobs j").
 		Join("pipelines p ON j.pipeline_id = p.id").
 		Join("teams tm ON p.team_id = tm.id").
@@ -396,6 +461,7 @@ func (d dashboardFactory) constructJobsForDashboard() ([]atc.JobSummary, error)
 			f, n, t              nullableBuild
 			jobPausedBy          sql.NullString
 			jobPausedAt          sql.NullTime
+			nextScheduledTrigger sql.NullTime
 			pipelineInstanceVars sql.NullString
 		)
 
@@ -405,7 +471,7 @@ func (d dashboardFactory) constructJobsForDashboard() ([]atc.JobSummary, error)
 			&f.id, &f.name, &f.status, &f.startTime, &f.endTime,
 			&n.id, &n.name, &n.status, &n.startTime, &n.endTime,
 			&t.id, &t.name, &t.status, &t.startTime, &t.endTime,
-			&jobPausedBy, &jobPausedAt)
+			&jobPausedBy, &jobPausedAt, &nextScheduledTrigger)
 		if err != nil {
 			return nil, err
 		}
@@ -418,6 +484,10 @@ func (d dashboardFactory) constructJobsForDashboard() ([]atc.JobSummary, error)
 			j.PausedAt = jobPausedAt.Time.Unix()
 		}
 
+		if nextScheduledTrigger.Valid {
+			j.NextScheduledTrigger = nextScheduledTrigger.Time.Unix()
+		}
+
 		if pipelineInstanceVars.Valid {
 			err = json.Unmarshal([]byte(pipelineInstanceVars.String), &j.PipelineInstanceVars)
 			if err != nil {
@@ -477,7 +547,7 @@ func (d dashboardFactory) constructJobsForDashboard() ([]atc.JobSummary, error)
 }
 
 func (d dashboardFactory) fetchJobInputs() (map[int][]atc.JobInputSummary, error) {
-	rows, err := psql.Select("j.id", "i.name", "r.name", "array_remove(array_agg(jp.name ORDER BY jp.id), NULL) passed", "i.trigger").
+	rows, err := psql.Select("j.id", "i.name", "r.name", "array_remove(array_agg(COALESCE(i.passed_job_ref, jp.name) ORDER BY jp.id), NULL) passed", "i.trigger").
 		From("job_inputs i").
 		Join("jobs j ON j.id = i.job_id").
 		Join("pipelines p ON p.id = j.pipeline_id").
@@ -100,7 +100,7 @@ var _ = Describe("JobFactory", func() {
 						Type: "some-type",
 					},
 				},
-			}, db.ConfigVersion(0), false)
+			}, db.ConfigVersion(0), false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 			Expect(publicPipeline.Expose()).To(Succeed())
 
@@ -128,7 +128,7 @@ var _ = Describe("JobFactory", func() {
 						Type: "some-type",
 					},
 				},
-			}, db.ConfigVersion(0), false)
+			}, db.ConfigVersion(0), false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 		})
 
@@ -346,6 +346,102 @@ var _ = Describe("JobFactory", func() {
 		})
 	})
 
+	Describe("JobsToTrigger", func() {
+		var scheduledJob db.Job
+
+		BeforeEach(func() {
+			pipeline, _, err := defaultTeam.SavePipeline(atc.PipelineRef{Name: "scheduled-pipeline"}, atc.Config{
+				Jobs: atc.JobConfigs{
+					{
+						Name:     "nightly",
+						Schedule: &atc.JobScheduleConfig{Cron: "@daily"},
+					},
+					{Name: "unscheduled"},
+				},
+			}, db.ConfigVersion(0), false, "some-user")
+			Expect(err).ToNot(HaveOccurred())
+
+			var found bool
+			scheduledJob, found, err = pipeline.Job("nightly")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		It("does not fetch jobs whose next trigger is in the future", func() {
+			jobs, err := jobFactory.JobsToTrigger()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(jobs).To(BeEmpty())
+		})
+
+		Context("when the next trigger has passed", func() {
+			BeforeEach(func() {
+				_, err := dbConn.Exec("UPDATE jobs SET next_scheduled_trigger = now() - interval '1 minute' WHERE id = $1", scheduledJob.ID())
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("fetches the job", func() {
+				jobs, err := jobFactory.JobsToTrigger()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(jobs).To(HaveLen(1))
+				Expect(jobs[0].Name()).To(Equal("nightly"))
+			})
+
+			It("fetches the job even when it is paused", func() {
+				Expect(scheduledJob.Pause("some-user")).To(Succeed())
+
+				jobs, err := jobFactory.JobsToTrigger()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(jobs).To(HaveLen(1))
+			})
+		})
+	})
+
+	Describe("TeamBuildQueues", func() {
+		BeforeEach(func() {
+			pipeline, _, err := defaultTeam.SavePipeline(atc.PipelineRef{Name: "queued-pipeline"}, atc.Config{
+				Jobs: atc.JobConfigs{
+					{Name: "urgent", Priority: 10},
+				},
+			}, db.ConfigVersion(0), false, "some-user")
+			Expect(err).ToNot(HaveOccurred())
+
+			job, found, err := pipeline.Job("urgent")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(job.Priority()).To(Equal(10))
+
+			for range 2 {
+				_, err := job.CreateBuild(defaultBuildCreatedBy)
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			startedBuild, err := job.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).ToNot(HaveOccurred())
+
+			started, err := startedBuild.Start(atc.Plan{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(started).To(BeTrue())
+
+			finishedBuild, err := job.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(finishedBuild.Finish(db.BuildStatusSucceeded)).To(Succeed())
+
+			// one-off builds are not queued by the scheduler
+			_, err = defaultTeam.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("counts each team's pending and running job builds", func() {
+			queues, err := jobFactory.TeamBuildQueues()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(queues).To(ContainElement(db.TeamBuildQueue{
+				TeamName: defaultTeam.Name(),
+				Pending:  2,
+				Running:  1,
+			}))
+		})
+	})
+
 	Describe("JobsToSchedule", func() {
 		var (
 			job1 db.Job
@@ -364,7 +460,7 @@ var _ = Describe("JobFactory", func() {
 					Jobs: atc.JobConfigs{
 						{Name: "job-name"},
 					},
-				}, db.ConfigVersion(1), false)
+				}, db.ConfigVersion(1), false, "some-user")
 				Expect(err).ToNot(HaveOccurred())
 
 				var found bool
@@ -390,7 +486,7 @@ var _ = Describe("JobFactory", func() {
 					Jobs: atc.JobConfigs{
 						{Name: "job-name"},
 					},
-				}, db.ConfigVersion(1), false)
+				}, db.ConfigVersion(1), false, "some-user")
 				Expect(err).ToNot(HaveOccurred())
 
 				var found bool
@@ -415,7 +511,7 @@ var _ = Describe("JobFactory", func() {
 					Jobs: atc.JobConfigs{
 						{Name: "job-name"},
 					},
-				}, db.ConfigVersion(1), false)
+				}, db.ConfigVersion(1), false, "some-user")
 				Expect(err).ToNot(HaveOccurred())
 
 				var found bool
@@ -447,7 +543,7 @@ var _ = Describe("JobFactory", func() {
 					Jobs: atc.JobConfigs{
 						{Name: "job-name"},
 					},
-				}, db.ConfigVersion(1), false)
+				}, db.ConfigVersion(1), false, "some-user")
 				Expect(err).ToNot(HaveOccurred())
 
 				var found bool
@@ -465,7 +561,7 @@ var _ = Describe("JobFactory", func() {
 					Jobs: atc.JobConfigs{
 						{Name: "job-fake"},
 					},
-				}, db.ConfigVersion(1), false)
+				}, db.ConfigVersion(1), false, "some-user")
 				Expect(err).ToNot(HaveOccurred())
 
 				job2, found, err = pipeline2.Job("job-fake")
@@ -476,7 +572,7 @@ var _ = Describe("JobFactory", func() {
 					Jobs: atc.JobConfigs{
 						{Name: "job-fake-two"},
 					},
-				}, db.ConfigVersion(1), false)
+				}, db.ConfigVersion(1), false, "some-user")
 				Expect(err).ToNot(HaveOccurred())
 
 				job3, found, err = pipeline3.Job("job-fake-two")
@@ -514,7 +610,7 @@ var _ = Describe("JobFactory", func() {
 					Prototypes: atc.Prototypes{
 						{Name: "prototype-name"},
 					},
-				}, db.ConfigVersion(1), false)
+				}, db.ConfigVersion(1), false, "some-user")
 				Expect(err).ToNot(HaveOccurred())
 
 				var found bool
@@ -560,7 +656,7 @@ var _ = Describe("JobFactory", func() {
 					Prototypes: atc.Prototypes{
 						{Name: "prototype-name"},
 					},
-				}, db.ConfigVersion(1), false)
+				}, db.ConfigVersion(1), false, "some-user")
 				Expect(err).ToNot(HaveOccurred())
 
 				var found bool
@@ -597,7 +693,7 @@ var _ = Describe("JobFactory", func() {
 					Jobs: atc.JobConfigs{
 						{Name: "job-name"},
 					},
-				}, db.ConfigVersion(1), false)
+				}, db.ConfigVersion(1), false, "some-user")
 				Expect(err).ToNot(HaveOccurred())
 
 				var found bool
@@ -608,7 +704,7 @@ var _ = Describe("JobFactory", func() {
 				err = job1.RequestSchedule()
 				Expect(err).ToNot(HaveOccurred())
 
-				_, _, err = defaultTeam.SavePipeline(atc.PipelineRef{Name: "fake-pipeline"}, atc.Config{}, pipeline1.ConfigVersion(), false)
+				_, _, err = defaultTeam.SavePipeline(atc.PipelineRef{Name: "fake-pipeline"}, atc.Config{}, pipeline1.ConfigVersion(), false, "some-user")
 				Expect(err).ToNot(HaveOccurred())
 			})
 
@@ -626,7 +722,7 @@ var _ = Describe("JobFactory", func() {
 						Jobs: atc.JobConfigs{
 							{Name: "job-name"},
 						},
-					}, db.ConfigVersion(1), false)
+					}, db.ConfigVersion(1), false, "some-user")
 					Expect(err).ToNot(HaveOccurred())
 
 					var found bool
@@ -675,7 +771,7 @@ var _ = Describe("JobFactory", func() {
 								Name: "unused-resource",
 							},
 						},
-					}, db.ConfigVersion(1), false)
+					}, db.ConfigVersion(1), false, "some-user")
 					Expect(err).ToNot(HaveOccurred())
 
 					var found bool
@@ -748,7 +844,7 @@ var _ = Describe("JobFactory", func() {
 								Type: "some-type",
 							},
 						},
-					}, db.ConfigVersion(1), false)
+					}, db.ConfigVersion(1), false, "some-user")
 					Expect(err).ToNot(HaveOccurred())
 
 					pipeline2, _, err := defaultTeam.SavePipeline(atc.PipelineRef{Name: "fake-pipeline-2"}, atc.Config{
@@ -780,7 +876,7 @@ var _ = Describe("JobFactory", func() {
 								Type: "other-type",
 							},
 						},
-					}, db.ConfigVersion(1), false)
+					}, db.ConfigVersion(1), false, "some-user")
 					Expect(err).ToNot(HaveOccurred())
 
 					var found bool
@@ -872,7 +968,7 @@ var _ = Describe("JobFactory", func() {
 								Name: "unused-resource",
 							},
 						},
-					}, db.ConfigVersion(1), false)
+					}, db.ConfigVersion(1), false, "some-user")
 					Expect(err).ToNot(HaveOccurred())
 
 					var found bool
@@ -932,7 +1028,7 @@ var _ = Describe("JobFactory", func() {
 								Name: "unused-resource",
 							},
 						},
-					}, db.ConfigVersion(1), false)
+					}, db.ConfigVersion(1), false, "some-user")
 					Expect(err).ToNot(HaveOccurred())
 
 					var found bool
@@ -973,7 +1069,7 @@ var _ = Describe("JobFactory", func() {
 								Type: "other-type",
 							},
 						},
-					}, db.ConfigVersion(1), false)
+					}, db.ConfigVersion(1), false, "some-user")
 					Expect(err).ToNot(HaveOccurred())
 
 					var found bool
@@ -1012,7 +1108,7 @@ var _ = Describe("JobFactory", func() {
 								Type: "other-type",
 							},
 						},
-					}, db.ConfigVersion(1), false)
+					}, db.ConfigVersion(1), false, "some-user")
 					Expect(err).ToNot(HaveOccurred())
 
 					pipeline2, _, err := defaultTeam.SavePipeline(atc.PipelineRef{Name: "fake-pipeline-2"}, atc.Config{
@@ -1029,7 +1125,7 @@ var _ = Describe("JobFactory", func() {
 								Type: "other-type-2",
 							},
 						},
-					}, db.ConfigVersion(1), false)
+					}, db.ConfigVersion(1), false, "some-user")
 					Expect(err).ToNot(HaveOccurred())
 
 					var found bool
@@ -1105,7 +1201,7 @@ var _ = Describe("JobFactory", func() {
 								Type: "other-type",
 							},
 						},
-					}, db.ConfigVersion(1), false)
+					}, db.ConfigVersion(1), false, "some-user")
 					Expect(err).ToNot(HaveOccurred())
 
 					var found bool
@@ -105,7 +105,7 @@ var _ = Describe("Job", func() {
 					Type: "some-type",
 				},
 			},
-		}, db.ConfigVersion(0), false)
+		}, db.ConfigVersion(0), false, "some-user")
 		Expect(err).ToNot(HaveOccurred())
 		Expect(created).To(BeTrue())
 
@@ -267,7 +267,7 @@ var _ = Describe("Job", func() {
 				Jobs: atc.JobConfigs{
 					{Name: "some-job"},
 				},
-			}, db.ConfigVersion(0), false)
+			}, db.ConfigVersion(0), false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 			Expect(created).To(BeTrue())
 
@@ -557,7 +557,7 @@ var _ = Describe("Job", func() {
 					},
 				},
 			}
-			pipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, config, db.ConfigVersion(1), false)
+			pipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, config, db.ConfigVersion(1), false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
 			job, found, err = pipeline.Job("some-job")
@@ -865,7 +865,7 @@ var _ = Describe("Job", func() {
 							Type: "some-type",
 						},
 					},
-				}, pipeline.ConfigVersion(), false)
+				}, pipeline.ConfigVersion(), false, "some-user")
 				Expect(err).ToNot(HaveOccurred())
 			})
 		}
@@ -950,7 +950,7 @@ var _ = Describe("Job", func() {
 							Type: "some-type",
 						},
 					},
-				}, pipeline.ConfigVersion(), false)
+				}, pipeline.ConfigVersion(), false, "some-user")
 				Expect(err).ToNot(HaveOccurred())
 			})
 		}
@@ -985,7 +985,7 @@ var _ = Describe("Job", func() {
 								Name: "some-job",
 							},
 						},
-					}, db.ConfigVersion(0), false)
+					}, db.ConfigVersion(0), false, "some-user")
 					Expect(err).ToNot(HaveOccurred())
 					Expect(created).To(BeTrue())
 
@@ -1393,6 +1393,263 @@ var _ = Describe("Job", func() {
 		})
 	})
 
+	Describe("ScheduleBuild with a concurrency group", func() {
+		var (
+			deployJob      db.Job
+			otherDeployJob db.Job
+		)
+
+		saveDeployPipeline := func(ref atc.PipelineRef, scope string) db.Job {
+			deployPipeline, _, err := team.SavePipeline(ref, atc.Config{
+				Jobs: atc.JobConfigs{
+					{
+						Name: "deploy",
+						ConcurrencyGroup: &atc.ConcurrencyGroupConfig{
+							Name:  "prod-deploy",
+							Scope: scope,
+						},
+					},
+				},
+			}, db.ConfigVersion(0), false, "some-user")
+			Expect(err).ToNot(HaveOccurred())
+
+			job, found, err := deployPipeline.Job("deploy")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			err = job.SaveNextInputMapping(nil, true)
+			Expect(err).ToNot(HaveOccurred())
+
+			return job
+		}
+
+		Context("when the group is team scoped", func() {
+			BeforeEach(func() {
+				deployJob = saveDeployPipeline(atc.PipelineRef{Name: "app", InstanceVars: atc.InstanceVars{"env": "prod"}}, atc.ConcurrencyGroupScopeTeam)
+				otherDeployJob = saveDeployPipeline(atc.PipelineRef{Name: "other-app"}, atc.ConcurrencyGroupScopeTeam)
+			})
+
+			Context("when a build of a job in another pipeline is running", func() {
+				var build db.Build
+
+				BeforeEach(func() {
+					runningBuild, err := otherDeployJob.CreateBuild(defaultBuildCreatedBy)
+					Expect(err).ToNot(HaveOccurred())
+
+					scheduled, err := otherDeployJob.ScheduleBuild(runningBuild)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(scheduled).To(BeTrue())
+
+					build, err = deployJob.CreateBuild(defaultBuildCreatedBy)
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("does not schedule the build", func() {
+					scheduled, err := deployJob.ScheduleBuild(build)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(scheduled).To(BeFalse())
+				})
+
+				It("blocks the build's preparation on the concurrency group", func() {
+					_, err := deployJob.ScheduleBuild(build)
+					Expect(err).ToNot(HaveOccurred())
+
+					prep, found, err := build.Preparation()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(prep.ConcurrencyGroup).To(Equal(db.BuildPreparationStatusBlocking))
+					Expect(prep.MaxRunningBuilds).To(Equal(db.BuildPreparationStatusNotBlocking))
+				})
+			})
+
+			Context("when a build of a job in another pipeline was created earlier", func() {
+				var build db.Build
+
+				BeforeEach(func() {
+					_, err := otherDeployJob.CreateBuild(defaultBuildCreatedBy)
+					Expect(err).ToNot(HaveOccurred())
+
+					build, err = deployJob.CreateBuild(defaultBuildCreatedBy)
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("does not schedule the build", func() {
+					scheduled, err := deployJob.ScheduleBuild(build)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(scheduled).To(BeFalse())
+				})
+
+				Context("when the other pipeline is paused", func() {
+					BeforeEach(func() {
+						otherPipeline, found, err := otherDeployJob.Pipeline()
+						Expect(err).ToNot(HaveOccurred())
+						Expect(found).To(BeTrue())
+						Expect(otherPipeline.Pause("")).To(Succeed())
+					})
+
+					It("schedules the build", func() {
+						scheduled, err := deployJob.ScheduleBuild(build)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(scheduled).To(BeTrue())
+					})
+				})
+			})
+
+			Context("when the other builds in the group have finished", func() {
+				var build db.Build
+
+				BeforeEach(func() {
+					finishedBuild, err := otherDeployJob.CreateBuild(defaultBuildCreatedBy)
+					Expect(err).ToNot(HaveOccurred())
+
+					scheduled, err := otherDeployJob.ScheduleBuild(finishedBuild)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(scheduled).To(BeTrue())
+
+					err = finishedBuild.Finish(db.BuildStatusSucceeded)
+					Expect(err).ToNot(HaveOccurred())
+
+					build, err = deployJob.CreateBuild(defaultBuildCreatedBy)
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("schedules the build", func() {
+					scheduled, err := deployJob.ScheduleBuild(build)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(scheduled).To(BeTrue())
+				})
+			})
+		})
+
+		Context("when the group is pipeline scoped", func() {
+			BeforeEach(func() {
+				deployJob = saveDeployPipeline(atc.PipelineRef{Name: "app"}, atc.ConcurrencyGroupScopePipeline)
+				otherDeployJob = saveDeployPipeline(atc.PipelineRef{Name: "other-app"}, atc.ConcurrencyGroupScopePipeline)
+			})
+
+			It("does not wait on jobs in other pipelines", func() {
+				runningBuild, err := otherDeployJob.CreateBuild(defaultBuildCreatedBy)
+				Expect(err).ToNot(HaveOccurred())
+
+				scheduled, err := otherDeployJob.ScheduleBuild(runningBuild)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(scheduled).To(BeTrue())
+
+				build, err := deployJob.CreateBuild(defaultBuildCreatedBy)
+				Expect(err).ToNot(HaveOccurred())
+
+				scheduled, err = deployJob.ScheduleBuild(build)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(scheduled).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("schedules", func() {
+		var (
+			scheduledPipeline db.Pipeline
+			scheduledJob      db.Job
+			schedule          *atc.JobScheduleConfig
+		)
+
+		savePipeline := func() {
+			var err error
+			version := db.ConfigVersion(0)
+			if scheduledPipeline != nil {
+				version = scheduledPipeline.ConfigVersion()
+			}
+
+			scheduledPipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "scheduled-pipeline"}, atc.Config{
+				Jobs: atc.JobConfigs{
+					{
+						Name:     "nightly",
+						Schedule: schedule,
+					},
+				},
+			}, version, false, "some-user")
+			Expect(err).ToNot(HaveOccurred())
+
+			var found bool
+			scheduledJob, found, err = scheduledPipeline.Job("nightly")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+		}
+
+		BeforeEach(func() {
+			scheduledPipeline = nil
+			schedule = &atc.JobScheduleConfig{Cron: "0 2 * * *", Jitter: "10m"}
+			savePipeline()
+		})
+
+		It("saves the next trigger of the schedule", func() {
+			Expect(scheduledJob.NextScheduledTrigger()).To(BeTemporally(">", time.Now()))
+			Expect(scheduledJob.NextScheduledTrigger()).To(BeTemporally("<", time.Now().Add(24*time.Hour+10*time.Minute)))
+		})
+
+		It("keeps the next trigger when the schedule is unchanged", func() {
+			nextTrigger := scheduledJob.NextScheduledTrigger()
+
+			savePipeline()
+			Expect(scheduledJob.NextScheduledTrigger()).To(BeTemporally("==", nextTrigger))
+		})
+
+		It("clears the next trigger when the schedule is removed", func() {
+			schedule = nil
+			savePipeline()
+			Expect(scheduledJob.NextScheduledTrigger()).To(BeZero())
+		})
+
+		Describe("CreateScheduledBuild", func() {
+			It("creates a build and moves the next trigger", func() {
+				nextTrigger := time.Now().Add(time.Hour).Truncate(time.Second)
+
+				build, created, err := scheduledJob.CreateScheduledBuild(nextTrigger)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(created).To(BeTrue())
+				Expect(build.IsManuallyTriggered()).To(BeTrue())
+				Expect(*build.CreatedBy()).To(Equal(atc.ScheduledBuildCreator))
+
+				found, err := scheduledJob.Reload()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(scheduledJob.NextScheduledTrigger()).To(BeTemporally("==", nextTrigger))
+			})
+
+			It("does not create a build when the trigger was already handled", func() {
+				staleJob, found, err := scheduledPipeline.Job("nightly")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				_, created, err := scheduledJob.CreateScheduledBuild(time.Now().Add(time.Hour))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(created).To(BeTrue())
+
+				_, created, err = staleJob.CreateScheduledBuild(time.Now().Add(2 * time.Hour))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(created).To(BeFalse())
+			})
+		})
+
+		Describe("SkipScheduledTrigger", func() {
+			It("moves the next trigger without creating a build", func() {
+				nextTrigger := time.Now().Add(time.Hour).Truncate(time.Second)
+
+				skipped, err := scheduledJob.SkipScheduledTrigger(nextTrigger)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(skipped).To(BeTrue())
+
+				builds, _, err := scheduledJob.Builds(db.Page{Limit: 10})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(builds).To(BeEmpty())
+
+				found, err := scheduledJob.Reload()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(scheduledJob.NextScheduledTrigger()).To(BeTemporally("==", nextTrigger))
+			})
+		})
+	})
+
 	Describe("GetNextBuildInputs", func() {
 		var (
 			versions    []atc.ResourceVersion
@@ -1800,7 +2057,7 @@ var _ = Describe("Job", func() {
 				},
 			}
 			var err error
-			otherPipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-other-pipeline"}, pipelineConfig, db.ConfigVersion(1), false)
+			otherPipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-other-pipeline"}, pipelineConfig, db.ConfigVersion(1), false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
 			build1DB, err = job.CreateBuild(defaultBuildCreatedBy)
@@ -2328,6 +2585,82 @@ var _ = Describe("Job", func() {
 			})
 		})
 
+		Context("when the input passed through a job in another pipeline", func() {
+			var upstream db.Pipeline
+
+			BeforeEach(func() {
+				resources := atc.ResourceConfigs{
+					{
+						Name:   "some-resource",
+						Type:   dbtest.BaseResourceType,
+						Source: atc.Source{"some": "source"},
+					},
+				}
+
+				scenario = dbtest.Setup(
+					builder.WithPipeline(atc.Config{
+						Jobs: atc.JobConfigs{
+							{
+								Name: "build",
+								PlanSequence: []atc.Step{
+									{Config: &atc.GetStep{Name: "some-resource"}},
+								},
+							},
+						},
+						Resources: resources,
+					}),
+					builder.WithResourceVersions("some-resource", time.Minute),
+				)
+
+				upstream = scenario.Pipeline
+
+				downstream, _, err := scenario.Team.SavePipeline(atc.PipelineRef{Name: "deploy"}, atc.Config{
+					Jobs: atc.JobConfigs{
+						{
+							Name: "some-job",
+							PlanSequence: []atc.Step{
+								{
+									Config: &atc.GetStep{
+										Name:   "some-resource",
+										Passed: []string{"some-pipeline/build"},
+									},
+								},
+							},
+						},
+					},
+					Resources: resources,
+				}, db.ConfigVersion(0), false, "some-user")
+				Expect(err).ToNot(HaveOccurred())
+
+				scenario.Pipeline = downstream
+				scenario.Run(builder.WithResourceVersions("some-resource", time.Minute))
+			})
+
+			It("maps the passed job to the resource in its pipeline with the same config", func() {
+				upstreamJob, found, err := upstream.Job("build")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				upstreamResource, found, err := upstream.Resource("some-resource")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				Expect(inputs).To(Equal(db.InputConfigs{
+					{
+						Name:       "some-resource",
+						JobID:      scenario.Job("some-job").ID(),
+						ResourceID: scenario.Resource("some-resource").ID(),
+						Passed: db.JobSet{
+							upstreamJob.ID(): true,
+						},
+						PassedResourceIDs: map[int]int{
+							upstreamJob.ID(): upstreamResource.ID(),
+						},
+					},
+				}))
+			})
+		})
+
 		Context("when the input is pinned through the get step", func() {
 			BeforeEach(func() {
 				scenario = dbtest.Setup(
@@ -2688,7 +3021,7 @@ var _ = Describe("Job", func() {
 						Type: "some-type",
 					},
 				},
-			}, db.ConfigVersion(0), false)
+			}, db.ConfigVersion(0), false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
 			var found bool
@@ -2726,6 +3059,133 @@ var _ = Describe("Job", func() {
 				},
 			}))
 		})
+
+		Context("when an input passed through jobs in another pipeline", func() {
+			var downstreamJob db.Job
+
+			BeforeEach(func() {
+				downstreamPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "downstream-pipeline"}, atc.Config{
+					Jobs: atc.JobConfigs{
+						{
+							Name: "some-job",
+							PlanSequence: []atc.Step{
+								{
+									Config: &atc.GetStep{
+										Name:   "some-resource",
+										Passed: []string{"inputs-pipeline/job-*"},
+									},
+								},
+							},
+						},
+					},
+					Resources: atc.ResourceConfigs{
+						{
+							Name: "some-resource",
+							Type: "some-type",
+						},
+					},
+				}, db.ConfigVersion(0), false, "some-user")
+				Expect(err).ToNot(HaveOccurred())
+
+				var found bool
+				downstreamJob, found, err = downstreamPipeline.Job("some-job")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+
+			It("names the jobs by their pipeline", func() {
+				inputs, err := downstreamJob.Inputs()
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(inputs).To(Equal([]atc.JobInput{
+					{
+						Name:     "some-resource",
+						Resource: "some-resource",
+						Passed:   []string{"inputs-pipeline/job-1", "inputs-pipeline/job-2"},
+					},
+				}))
+			})
+
+			Context("when the upstream pipeline is destroyed", func() {
+				var (
+					upstreamPipeline db.Pipeline
+					destroyErr       error
+				)
+
+				BeforeEach(func() {
+					var found bool
+					var err error
+					upstreamPipeline, found, err = team.Pipeline(atc.PipelineRef{Name: "inputs-pipeline"})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					destroyErr = upstreamPipeline.Destroy()
+				})
+
+				It("refuses, naming the pipelines which pass through it", func() {
+					Expect(destroyErr).To(Equal(db.PipelineReferencedError{
+						Pipelines: []atc.PipelineRef{{Name: "downstream-pipeline"}},
+					}))
+
+					found, err := upstreamPipeline.Reload()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+				})
+
+				It("keeps the constraint", func() {
+					inputs, err := downstreamJob.Inputs()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(inputs[0].Passed).To(Equal([]string{"inputs-pipeline/job-1", "inputs-pipeline/job-2"}))
+				})
+
+				Context("once the downstream pipeline no longer passes through it", func() {
+					BeforeEach(func() {
+						downstreamPipeline, found, err := team.Pipeline(atc.PipelineRef{Name: "downstream-pipeline"})
+						Expect(err).ToNot(HaveOccurred())
+						Expect(found).To(BeTrue())
+
+						Expect(downstreamPipeline.Destroy()).To(Succeed())
+
+						destroyErr = upstreamPipeline.Destroy()
+					})
+
+					It("destroys it", func() {
+						Expect(destroyErr).ToNot(HaveOccurred())
+
+						found, err := upstreamPipeline.Reload()
+						Expect(err).ToNot(HaveOccurred())
+						Expect(found).To(BeFalse())
+					})
+				})
+			})
+		})
+
+		Context("when an input passed through a job in a pipeline which does not exist", func() {
+			It("fails to save the pipeline", func() {
+				_, _, err := team.SavePipeline(atc.PipelineRef{Name: "downstream-pipeline"}, atc.Config{
+					Jobs: atc.JobConfigs{
+						{
+							Name: "some-job",
+							PlanSequence: []atc.Step{
+								{
+									Config: &atc.GetStep{
+										Name:   "some-resource",
+										Passed: []string{"bogus-pipeline/job-1"},
+									},
+								},
+							},
+						},
+					},
+					Resources: atc.ResourceConfigs{
+						{
+							Name: "some-resource",
+							Type: "some-type",
+						},
+					},
+				}, db.ConfigVersion(0), false, "some-user")
+				Expect(err).To(Equal(db.PassedJobNotFoundError{Passed: "bogus-pipeline/job-1"}))
+			})
+		})
 	})
 
 	Describe("Outputs", func() {
@@ -2793,7 +3253,7 @@ var _ = Describe("Job", func() {
 						Type: "some-type",
 					},
 				},
-			}, db.ConfigVersion(0), false)
+			}, db.ConfigVersion(0), false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
 			var found bool
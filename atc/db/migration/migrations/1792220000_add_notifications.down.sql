@@ -0,0 +1,6 @@
+DROP TABLE notification_deliveries;
+
+DROP INDEX builds_unnotified_idx;
+ALTER TABLE builds DROP COLUMN notified;
+
+ALTER TABLE pipelines DROP COLUMN notifications;
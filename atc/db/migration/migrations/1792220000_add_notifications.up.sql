@@ -0,0 +1,29 @@
+ALTER TABLE pipelines ADD COLUMN notifications text;
+
+-- existing builds are considered notified so that enabling notifications
+-- does not deliver the entire build history
+ALTER TABLE builds ADD COLUMN notified boolean NOT NULL DEFAULT true;
+ALTER TABLE builds ALTER COLUMN notified SET DEFAULT false;
+
+-- the cells of a build matrix notify through their parent build
+CREATE INDEX builds_unnotified_idx ON builds (id) WHERE NOT notified AND completed AND job_id IS NOT NULL AND parent_build_id IS NULL;
+
+CREATE TABLE notification_deliveries (
+    id bigserial PRIMARY KEY,
+    pipeline_id integer NOT NULL REFERENCES pipelines (id) ON DELETE CASCADE,
+    build_id bigint NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+    name text NOT NULL,
+    status text NOT NULL DEFAULT 'pending',
+    build_status text NOT NULL,
+    previous_status text,
+    attempts integer NOT NULL DEFAULT 0,
+    response_code integer,
+    last_error text,
+    next_attempt_at timestamp with time zone DEFAULT now() NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+CREATE INDEX notification_deliveries_pending_idx ON notification_deliveries (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX notification_deliveries_pipeline_id_idx ON notification_deliveries (pipeline_id);
+CREATE INDEX notification_deliveries_build_id_idx ON notification_deliveries (build_id);
@@ -0,0 +1,6 @@
+CREATE TABLE resource_webhook_deliveries (
+  resource_id integer NOT NULL REFERENCES resources (id) ON DELETE CASCADE,
+  delivery_id text NOT NULL,
+  created_at timestamp with time zone NOT NULL DEFAULT now(),
+  PRIMARY KEY (resource_id, delivery_id)
+);
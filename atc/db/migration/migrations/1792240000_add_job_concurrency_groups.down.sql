@@ -0,0 +1,6 @@
+DROP INDEX jobs_concurrency_group_idx;
+
+ALTER TABLE jobs
+  DROP COLUMN concurrency_group,
+  DROP COLUMN concurrency_group_scope,
+  DROP COLUMN concurrency_group_reached;
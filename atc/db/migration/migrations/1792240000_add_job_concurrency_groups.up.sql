@@ -0,0 +1,6 @@
+ALTER TABLE jobs
+  ADD COLUMN concurrency_group text,
+  ADD COLUMN concurrency_group_scope text,
+  ADD COLUMN concurrency_group_reached boolean NOT NULL DEFAULT false;
+
+CREATE INDEX jobs_concurrency_group_idx ON jobs (concurrency_group) WHERE concurrency_group IS NOT NULL;
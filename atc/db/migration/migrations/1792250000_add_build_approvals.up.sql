@@ -0,0 +1,12 @@
+CREATE TABLE build_approvals (
+  build_id bigint NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+  plan_id text NOT NULL,
+  name text NOT NULL,
+  users jsonb NOT NULL DEFAULT '[]',
+  roles jsonb NOT NULL DEFAULT '[]',
+  status text NOT NULL DEFAULT 'pending',
+  decided_by text,
+  created_at timestamp with time zone NOT NULL DEFAULT now(),
+  decided_at timestamp with time zone,
+  PRIMARY KEY (build_id, plan_id)
+);
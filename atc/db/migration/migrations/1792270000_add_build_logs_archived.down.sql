@@ -0,0 +1,4 @@
+DROP INDEX builds_unarchived_end_time_idx;
+
+ALTER TABLE builds
+  DROP COLUMN logs_archived;
@@ -0,0 +1,4 @@
+ALTER TABLE builds
+  ADD COLUMN logs_archived boolean NOT NULL DEFAULT false;
+
+CREATE INDEX builds_unarchived_end_time_idx ON builds (end_time) WHERE completed AND NOT logs_archived AND reap_time IS NULL;
@@ -0,0 +1,12 @@
+CREATE TABLE pipeline_config_revisions (
+    id bigserial PRIMARY KEY,
+    pipeline_id integer NOT NULL REFERENCES pipelines (id) ON DELETE CASCADE,
+    revision integer NOT NULL,
+    config_version integer NOT NULL,
+    config text NOT NULL,
+    nonce text,
+    created_by text,
+    build_id bigint,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    UNIQUE (pipeline_id, revision)
+);
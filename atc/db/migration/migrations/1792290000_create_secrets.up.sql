@@ -0,0 +1,11 @@
+CREATE TABLE secrets (
+    id serial PRIMARY KEY,
+    team_id integer NOT NULL REFERENCES teams (id) ON DELETE CASCADE,
+    pipeline_name text NOT NULL DEFAULT '',
+    name text NOT NULL,
+    value text NOT NULL,
+    nonce text,
+    updated_by text,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    UNIQUE (team_id, pipeline_name, name)
+);
@@ -0,0 +1,2 @@
+DROP TABLE build_var_resolutions;
+DROP TABLE pipeline_var_references;
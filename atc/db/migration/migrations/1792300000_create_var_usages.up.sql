@@ -0,0 +1,24 @@
+CREATE TABLE pipeline_var_references (
+    pipeline_id integer NOT NULL REFERENCES pipelines (id) ON DELETE CASCADE,
+    var_name text NOT NULL,
+    kind text NOT NULL,
+    name text NOT NULL,
+    PRIMARY KEY (pipeline_id, var_name, kind, name)
+);
+
+CREATE INDEX pipeline_var_references_var_name_idx ON pipeline_var_references (var_name);
+
+CREATE TABLE build_var_resolutions (
+    var_name text NOT NULL,
+    team_id integer NOT NULL REFERENCES teams (id) ON DELETE CASCADE,
+    pipeline_id integer REFERENCES pipelines (id) ON DELETE CASCADE,
+    job_id integer REFERENCES jobs (id) ON DELETE CASCADE,
+    build_id bigint REFERENCES builds (id) ON DELETE SET NULL,
+    build_name text NOT NULL,
+    resolved_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX build_var_resolutions_uniq
+    ON build_var_resolutions (var_name, team_id, COALESCE(pipeline_id, 0), COALESCE(job_id, 0));
+
+CREATE INDEX build_var_resolutions_build_id_idx ON build_var_resolutions (build_id);
@@ -0,0 +1,3 @@
+ALTER TABLE teams
+    ADD COLUMN var_sources text,
+    ADD COLUMN var_sources_nonce text;
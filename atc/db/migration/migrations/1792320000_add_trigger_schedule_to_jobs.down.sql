@@ -0,0 +1,5 @@
+DROP INDEX jobs_next_scheduled_trigger_idx;
+
+ALTER TABLE jobs
+  DROP COLUMN trigger_schedule,
+  DROP COLUMN next_scheduled_trigger;
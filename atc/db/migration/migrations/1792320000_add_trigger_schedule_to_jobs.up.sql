@@ -0,0 +1,5 @@
+ALTER TABLE jobs
+  ADD COLUMN trigger_schedule jsonb,
+  ADD COLUMN next_scheduled_trigger timestamp with time zone;
+
+CREATE INDEX jobs_next_scheduled_trigger_idx ON jobs (next_scheduled_trigger) WHERE next_scheduled_trigger IS NOT NULL;
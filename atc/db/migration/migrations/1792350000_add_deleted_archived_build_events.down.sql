@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS deleted_archived_build_events_insert_trigger ON builds;
+DROP FUNCTION IF EXISTS on_archived_build_delete();
+
+DROP TABLE deleted_archived_build_events;
@@ -0,0 +1,13 @@
+CREATE TABLE deleted_archived_build_events (
+  build_id bigint NOT NULL,
+  deleted_at timestamp without time zone DEFAULT now() NOT NULL
+);
+
+CREATE OR REPLACE FUNCTION on_archived_build_delete() RETURNS TRIGGER AS $$
+BEGIN
+  INSERT INTO deleted_archived_build_events (build_id) VALUES (OLD.id);
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER deleted_archived_build_events_insert_trigger AFTER DELETE ON builds FOR EACH ROW WHEN (OLD.logs_archived) EXECUTE PROCEDURE on_archived_build_delete();
@@ -0,0 +1,328 @@
+package db
+
+import (
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db/lock"
+)
+
+//counterfeiter:generate . NotificationDeliveryFactory
+type NotificationDeliveryFactory interface {
+	// UnnotifiedBuilds returns the completed job builds which have not yet been
+	// considered for notification. The cells of a build matrix are left out,
+	// as the matrix notifies once for all of them through its parent build.
+	UnnotifiedBuilds() ([]Build, error)
+
+	// PreviousBuildStatus returns the status of the last completed build of the
+	// build's job which was created before it, not counting the cells of build
+	// matrices.
+	PreviousBuildStatus(build Build) (BuildStatus, bool, error)
+
+	// QueueDeliveries creates a pending delivery for each of the given
+	// notifications and marks the build, along with its cells if it is a build
+	// matrix, as notified.
+	QueueDeliveries(build Build, previousStatus BuildStatus, names []string) error
+
+	// PendingDeliveries returns the deliveries which are due to be attempted.
+	PendingDeliveries() ([]NotificationDelivery, error)
+}
+
+//counterfeiter:generate . NotificationDelivery
+type NotificationDelivery interface {
+	ID() int
+	PipelineID() int
+	BuildID() int
+	BuildName() string
+	JobName() string
+	Name() string
+	Status() atc.NotificationDeliveryStatus
+	BuildStatus() BuildStatus
+	PreviousStatus() BuildStatus
+	Attempts() int
+	ResponseCode() int
+	LastError() string
+	CreatedAt() time.Time
+	UpdatedAt() time.Time
+
+	Succeed(responseCode int) error
+	Retry(responseCode int, reason string, nextAttempt time.Time) error
+	Fail(responseCode int, reason string) error
+}
+
+var notificationDeliveriesQuery = psql.Select(
+	"d.id",
+	"d.pipeline_id",
+	"d.build_id",
+	"b.name",
+	"j.name",
+	"d.name",
+	"d.status",
+	"d.build_status",
+	"d.previous_status",
+	"d.attempts",
+	"d.response_code",
+	"d.last_error",
+	"d.created_at",
+	"d.updated_at",
+).
+	From("notification_deliveries d").
+	Join("builds b ON b.id = d.build_id").
+	LeftJoin("jobs j ON j.id = b.job_id")
+
+type notificationDeliveryFactory struct {
+	conn        DbConn
+	lockFactory lock.LockFactory
+}
+
+func NewNotificationDeliveryFactory(conn DbConn, lockFactory lock.LockFactory) NotificationDeliveryFactory {
+	return &notificationDeliveryFactory{
+		conn:        conn,
+		lockFactory: lockFactory,
+	}
+}
+
+func (f *notificationDeliveryFactory) UnnotifiedBuilds() ([]Build, error) {
+	query := buildsQuery.
+		Where(sq.And{
+			sq.Eq{
+				"b.completed":       true,
+				"b.notified":        false,
+				"b.parent_build_id": nil,
+			},
+			sq.NotEq{"b.job_id": nil},
+		}).
+		OrderBy("b.id ASC")
+
+	return getBuilds(query, f.conn, f.lockFactory, nil)
+}
+
+func (f *notificationDeliveryFactory) PreviousBuildStatus(build Build) (BuildStatus, bool, error) {
+	var status string
+	err := psql.Select("status").
+		From("builds").
+		Where(sq.And{
+			sq.Eq{
+				"job_id":          build.JobID(),
+				"completed":       true,
+				"parent_build_id": nil,
+			},
+			sq.Lt{"id": build.ID()},
+		}).
+		OrderBy("id DESC").
+		Limit(1).
+		RunWith(f.conn).
+		QueryRow().
+		Scan(&status)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+
+	return BuildStatus(status), true, nil
+}
+
+func (f *notificationDeliveryFactory) QueueDeliveries(build Build, previousStatus BuildStatus, names []string) error {
+	tx, err := f.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	var previous sql.NullString
+	if previousStatus != "" {
+		previous = sql.NullString{String: string(previousStatus), Valid: true}
+	}
+
+	for _, name := range names {
+		_, err = psql.Insert("notification_deliveries").
+			Columns("pipeline_id", "build_id", "name", "build_status", "previous_status").
+			Values(build.PipelineID(), build.ID(), name, string(build.Status()), previous).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = psql.Update("builds").
+		Set("notified", true).
+		// the cells of a build matrix notify through their parent build, so
+		// they are marked along with it
+		Where(sq.Or{
+			sq.Eq{"id": build.ID()},
+			sq.And{
+				sq.Eq{"parent_build_id": build.ID()},
+				sq.Eq{"notified": false},
+			},
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (f *notificationDeliveryFactory) PendingDeliveries() ([]NotificationDelivery, error) {
+	query := notificationDeliveriesQuery.
+		Where(sq.And{
+			sq.Eq{"d.status": atc.NotificationDeliveryPending},
+			sq.Expr("d.next_attempt_at <= now()"),
+		}).
+		OrderBy("d.id ASC")
+
+	return getNotificationDeliveries(query, f.conn)
+}
+
+func getNotificationDeliveries(query sq.SelectBuilder, conn DbConn) ([]NotificationDelivery, error) {
+	rows, err := query.RunWith(conn).Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	deliveries := []NotificationDelivery{}
+	for rows.Next() {
+		d := &notificationDelivery{conn: conn}
+		err = scanNotificationDelivery(d, rows)
+		if err != nil {
+			return nil, err
+		}
+
+		deliveries = append(deliveries, d)
+	}
+
+	return deliveries, nil
+}
+
+type notificationDelivery struct {
+	id             int
+	pipelineID     int
+	buildID        int
+	buildName      string
+	jobName        string
+	name           string
+	status         atc.NotificationDeliveryStatus
+	buildStatus    BuildStatus
+	previousStatus BuildStatus
+	attempts       int
+	responseCode   int
+	lastError      string
+	createdAt      time.Time
+	updatedAt      time.Time
+
+	conn DbConn
+}
+
+func (d *notificationDelivery) ID() int                                { return d.id }
+func (d *notificationDelivery) PipelineID() int                        { return d.pipelineID }
+func (d *notificationDelivery) BuildID() int                           { return d.buildID }
+func (d *notificationDelivery) BuildName() string                      { return d.buildName }
+func (d *notificationDelivery) JobName() string                        { return d.jobName }
+func (d *notificationDelivery) Name() string                           { return d.name }
+func (d *notificationDelivery) Status() atc.NotificationDeliveryStatus { return d.status }
+func (d *notificationDelivery) BuildStatus() BuildStatus               { return d.buildStatus }
+func (d *notificationDelivery) PreviousStatus() BuildStatus            { return d.previousStatus }
+func (d *notificationDelivery) Attempts() int                          { return d.attempts }
+func (d *notificationDelivery) ResponseCode() int                      { return d.responseCode }
+func (d *notificationDelivery) LastError() string                      { return d.lastError }
+func (d *notificationDelivery) CreatedAt() time.Time                   { return d.createdAt }
+func (d *notificationDelivery) UpdatedAt() time.Time                   { return d.updatedAt }
+
+func (d *notificationDelivery) Succeed(responseCode int) error {
+	return d.update(atc.NotificationDeliverySucceeded, responseCode, "", nil)
+}
+
+func (d *notificationDelivery) Retry(responseCode int, reason string, nextAttempt time.Time) error {
+	return d.update(atc.NotificationDeliveryPending, responseCode, reason, &nextAttempt)
+}
+
+func (d *notificationDelivery) Fail(responseCode int, reason string) error {
+	return d.update(atc.NotificationDeliveryFailed, responseCode, reason, nil)
+}
+
+func (d *notificationDelivery) update(status atc.NotificationDeliveryStatus, responseCode int, reason string, nextAttempt *time.Time) error {
+	var code sql.NullInt64
+	if responseCode != 0 {
+		code = sql.NullInt64{Int64: int64(responseCode), Valid: true}
+	}
+
+	var lastError sql.NullString
+	if reason != "" {
+		lastError = sql.NullString{String: reason, Valid: true}
+	}
+
+	q := psql.Update("notification_deliveries").
+		Set("status", status).
+		Set("attempts", sq.Expr("attempts + 1")).
+		Set("response_code", code).
+		Set("last_error", lastError).
+		Set("updated_at", sq.Expr("now()")).
+		Where(sq.Eq{"id": d.id}).
+		Suffix("RETURNING attempts, updated_at")
+
+	if nextAttempt != nil {
+		q = q.Set("next_attempt_at", *nextAttempt)
+	}
+
+	err := q.RunWith(d.conn).
+		QueryRow().
+		Scan(&d.attempts, &d.updatedAt)
+	if err != nil {
+		return err
+	}
+
+	d.status = status
+	d.responseCode = responseCode
+	d.lastError = reason
+
+	return nil
+}
+
+func scanNotificationDelivery(d *notificationDelivery, row scannable) error {
+	var (
+		jobName        sql.NullString
+		previousStatus sql.NullString
+		responseCode   sql.NullInt64
+		lastError      sql.NullString
+		status         string
+		buildStatus    string
+	)
+
+	err := row.Scan(
+		&d.id,
+		&d.pipelineID,
+		&d.buildID,
+		&d.buildName,
+		&jobName,
+		&d.name,
+		&status,
+		&buildStatus,
+		&previousStatus,
+		&d.attempts,
+		&responseCode,
+		&lastError,
+		&d.createdAt,
+		&d.updatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	d.jobName = jobName.String
+	d.status = atc.NotificationDeliveryStatus(status)
+	d.buildStatus = BuildStatus(buildStatus)
+	d.previousStatus = BuildStatus(previousStatus.String)
+	d.responseCode = int(responseCode.Int64)
+	d.lastError = lastError.String
+
+	return nil
+}
@@ -0,0 +1,287 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NotificationDeliveryFactory", func() {
+	var factory db.NotificationDeliveryFactory
+
+	BeforeEach(func() {
+		factory = db.NewNotificationDeliveryFactory(dbConn, lockFactory)
+	})
+
+	// startMatrix starts a build matrix of the default job, returning it along
+	// with its cells.
+	startMatrix := func() (db.Build, []db.Build) {
+		matrix, err := defaultJob.CreateBuild(defaultBuildCreatedBy)
+		Expect(err).ToNot(HaveOccurred())
+
+		started, err := matrix.StartMatrix([]atc.MatrixValues{
+			{"go": "1.21"},
+			{"go": "1.22"},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(started).To(BeTrue())
+
+		pending, err := defaultJob.GetPendingBuilds()
+		Expect(err).ToNot(HaveOccurred())
+
+		var cells []db.Build
+		for _, build := range pending {
+			if build.ParentBuildID() == matrix.ID() {
+				cells = append(cells, build)
+			}
+		}
+
+		Expect(cells).To(HaveLen(2))
+
+		return matrix, cells
+	}
+
+	Describe("UnnotifiedBuilds", func() {
+		var finishedBuild db.Build
+
+		BeforeEach(func() {
+			var err error
+			finishedBuild, err = defaultJob.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = finishedBuild.Finish(db.BuildStatusFailed)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = defaultJob.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).ToNot(HaveOccurred())
+
+			oneOffBuild, err := defaultTeam.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			err = oneOffBuild.Finish(db.BuildStatusSucceeded)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns completed job builds", func() {
+			builds, err := factory.UnnotifiedBuilds()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(builds).To(HaveLen(1))
+			Expect(builds[0].ID()).To(Equal(finishedBuild.ID()))
+		})
+
+		Context("once deliveries have been queued for the build", func() {
+			BeforeEach(func() {
+				err := factory.QueueDeliveries(finishedBuild, "", nil)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("no longer returns it", func() {
+				builds, err := factory.UnnotifiedBuilds()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(builds).To(BeEmpty())
+			})
+		})
+
+		Context("when a build matrix is running", func() {
+			var matrix db.Build
+			var cells []db.Build
+
+			BeforeEach(func() {
+				matrix, cells = startMatrix()
+
+				err := cells[0].Finish(db.BuildStatusFailed)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("does not return its finished cells", func() {
+				builds, err := factory.UnnotifiedBuilds()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(builds).To(HaveLen(1))
+				Expect(builds[0].ID()).To(Equal(finishedBuild.ID()))
+			})
+
+			Context("once every cell has finished", func() {
+				BeforeEach(func() {
+					err := cells[1].Finish(db.BuildStatusSucceeded)
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("returns the matrix build rather than its cells", func() {
+					builds, err := factory.UnnotifiedBuilds()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(builds).To(HaveLen(2))
+					Expect(builds[0].ID()).To(Equal(finishedBuild.ID()))
+					Expect(builds[1].ID()).To(Equal(matrix.ID()))
+				})
+
+				Context("once deliveries have been queued for the matrix", func() {
+					BeforeEach(func() {
+						_, err := dbConn.Exec(`UPDATE builds SET notified = false WHERE parent_build_id = $1`, matrix.ID())
+						Expect(err).ToNot(HaveOccurred())
+
+						err = factory.QueueDeliveries(matrix, "", nil)
+						Expect(err).ToNot(HaveOccurred())
+					})
+
+					It("marks its cells as notified too", func() {
+						var unnotified int
+						err := dbConn.QueryRow(`SELECT COUNT(*) FROM builds WHERE parent_build_id = $1 AND NOT notified`, matrix.ID()).Scan(&unnotified)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(unnotified).To(BeZero())
+					})
+				})
+			})
+		})
+	})
+
+	Describe("PreviousBuildStatus", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = defaultJob.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		Context("when there is no previous build", func() {
+			It("returns not found", func() {
+				_, found, err := factory.PreviousBuildStatus(build)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when there are previous builds", func() {
+			BeforeEach(func() {
+				err := build.Finish(db.BuildStatusSucceeded)
+				Expect(err).ToNot(HaveOccurred())
+
+				build, err = defaultJob.CreateBuild(defaultBuildCreatedBy)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = build.Finish(db.BuildStatusFailed)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("returns the status of the latest completed one", func() {
+				status, found, err := factory.PreviousBuildStatus(build)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(status).To(Equal(db.BuildStatusSucceeded))
+			})
+		})
+
+		Context("when a cell of a build matrix finished in between", func() {
+			BeforeEach(func() {
+				err := build.Finish(db.BuildStatusSucceeded)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, cells := startMatrix()
+
+				err = cells[0].Finish(db.BuildStatusFailed)
+				Expect(err).ToNot(HaveOccurred())
+
+				build, err = defaultJob.CreateBuild(defaultBuildCreatedBy)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("does not compare against them", func() {
+				status, found, err := factory.PreviousBuildStatus(build)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(status).To(Equal(db.BuildStatusSucceeded))
+			})
+		})
+	})
+
+	Describe("delivering", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = defaultJob.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = build.Finish(db.BuildStatusFailed)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = factory.QueueDeliveries(build, db.BuildStatusSucceeded, []string{"slack", "pager"})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("creates pending deliveries", func() {
+			deliveries, err := factory.PendingDeliveries()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deliveries).To(HaveLen(2))
+
+			Expect(deliveries[0].Name()).To(Equal("slack"))
+			Expect(deliveries[0].BuildID()).To(Equal(build.ID()))
+			Expect(deliveries[0].BuildName()).To(Equal(build.Name()))
+			Expect(deliveries[0].JobName()).To(Equal("some-job"))
+			Expect(deliveries[0].PipelineID()).To(Equal(defaultPipeline.ID()))
+			Expect(deliveries[0].Status()).To(Equal(atc.NotificationDeliveryPending))
+			Expect(deliveries[0].BuildStatus()).To(Equal(db.BuildStatusFailed))
+			Expect(deliveries[0].PreviousStatus()).To(Equal(db.BuildStatusSucceeded))
+			Expect(deliveries[0].Attempts()).To(BeZero())
+		})
+
+		Context("when a delivery succeeds", func() {
+			BeforeEach(func() {
+				deliveries, err := factory.PendingDeliveries()
+				Expect(err).ToNot(HaveOccurred())
+
+				err = deliveries[0].Succeed(200)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(deliveries[0].Attempts()).To(Equal(1))
+			})
+
+			It("is no longer pending", func() {
+				deliveries, err := factory.PendingDeliveries()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(deliveries).To(HaveLen(1))
+				Expect(deliveries[0].Name()).To(Equal("pager"))
+			})
+		})
+
+		Context("when a delivery is retried later", func() {
+			BeforeEach(func() {
+				deliveries, err := factory.PendingDeliveries()
+				Expect(err).ToNot(HaveOccurred())
+
+				err = deliveries[0].Retry(502, "bad gateway", time.Now().Add(time.Hour))
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("is not pending until the next attempt", func() {
+				deliveries, err := factory.PendingDeliveries()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(deliveries).To(HaveLen(1))
+				Expect(deliveries[0].Name()).To(Equal("pager"))
+			})
+		})
+
+		Context("when a delivery fails", func() {
+			BeforeEach(func() {
+				deliveries, err := factory.PendingDeliveries()
+				Expect(err).ToNot(HaveOccurred())
+
+				err = deliveries[1].Fail(500, "internal server error")
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("is listed in the pipeline's failed deliveries", func() {
+				deliveries, err := defaultPipeline.NotificationDeliveries([]atc.NotificationDeliveryStatus{atc.NotificationDeliveryFailed}, 10)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(deliveries).To(HaveLen(1))
+				Expect(deliveries[0].Name()).To(Equal("pager"))
+				Expect(deliveries[0].Status()).To(Equal(atc.NotificationDeliveryFailed))
+				Expect(deliveries[0].ResponseCode()).To(Equal(500))
+				Expect(deliveries[0].LastError()).To(Equal("internal server error"))
+				Expect(deliveries[0].Attempts()).To(Equal(1))
+			})
+		})
+	})
+})
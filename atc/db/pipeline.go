@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"code.cloudfoundry.org/lager/v3"
@@ -63,6 +64,7 @@ type Pipeline interface {
 	VarSources() atc.VarSourceConfigs
 	Display() *atc.DisplayConfig
 	UserData() any
+	Notifications() atc.NotificationConfigs
 	ConfigVersion() ConfigVersion
 	Config() (atc.Config, error)
 	Public() bool
@@ -85,6 +87,16 @@ type Pipeline interface {
 
 	DeleteBuildEventsByBuildIDs(buildIDs []int) error
 
+	NotificationDeliveries(statuses []atc.NotificationDeliveryStatus, limit int) ([]NotificationDelivery, error)
+
+	SearchBuildLogs(search BuildLogSearch) (BuildLogSearchResult, error)
+
+	PlanConfig(config atc.Config) (atc.ConfigPlan, error)
+
+	ConfigRevisions() ([]PipelineConfigRevision, error)
+	ConfigRevision(revision int) (PipelineConfigRevision, bool, error)
+	RestoreConfigRevision(revision int, restoredBy string) (bool, error)
+
 	LoadDebugVersionsDB() (*atc.DebugVersionsDB, error)
 
 	Resource(name string) (Resource, bool, error)
@@ -142,6 +154,7 @@ type pipeline struct {
 	varSources    atc.VarSourceConfigs
 	display       *atc.DisplayConfig
 	userData      any
+	notifications atc.NotificationConfigs
 	configVersion ConfigVersion
 	paused        bool
 	pausedBy      string
@@ -150,6 +163,11 @@ type pipeline struct {
 	archived      bool
 	lastUpdated   time.Time
 
+	// the team's var sources are loaded with the pipeline, still encrypted,
+	// as they are only needed by Variables
+	teamVarSources      sql.NullString
+	teamVarSourcesNonce sql.NullString
+
 	conn        DbConn
 	lockFactory lock.LockFactory
 }
@@ -164,6 +182,7 @@ var pipelinesQuery = psql.Select(`
 		p.var_sources,
 		p.display,
 		p.user_data,
+		p.notifications,
 		p.nonce,
 		p.version,
 		p.team_id,
@@ -176,7 +195,9 @@ var pipelinesQuery = psql.Select(`
 		p.parent_build_id,
 		p.instance_vars,
 		p.paused_by,
-		p.paused_at`).
+		p.paused_at,
+		t.var_sources,
+		t.var_sources_nonce`).
 	From("pipelines p").
 	LeftJoin("teams t ON p.team_id = t.id")
 
@@ -187,24 +208,25 @@ func newPipeline(conn DbConn, lockFactory lock.LockFactory) *pipeline {
 	}
 }
 
-func (p *pipeline) ID() int                          { return p.id }
-func (p *pipeline) Name() string                     { return p.name }
-func (p *pipeline) TeamID() int                      { return p.teamID }
-func (p *pipeline) TeamName() string                 { return p.teamName }
-func (p *pipeline) ParentJobID() int                 { return p.parentJobID }
-func (p *pipeline) ParentBuildID() int               { return p.parentBuildID }
-func (p *pipeline) InstanceVars() atc.InstanceVars   { return p.instanceVars }
-func (p *pipeline) Groups() atc.GroupConfigs         { return p.groups }
-func (p *pipeline) VarSources() atc.VarSourceConfigs { return p.varSources }
-func (p *pipeline) Display() *atc.DisplayConfig      { return p.display }
-func (p *pipeline) UserData() any                    { return p.userData }
-func (p *pipeline) ConfigVersion() ConfigVersion     { return p.configVersion }
-func (p *pipeline) Public() bool                     { return p.public }
-func (p *pipeline) Paused() bool                     { return p.paused }
-func (p *pipeline) PausedAt() time.Time              { return p.pausedAt }
-func (p *pipeline) PausedBy() string                 { return p.pausedBy }
-func (p *pipeline) Archived() bool                   { return p.archived }
-func (p *pipeline) LastUpdated() time.Time           { return p.lastUpdated }
+func (p *pipeline) ID() int                                { return p.id }
+func (p *pipeline) Name() string                           { return p.name }
+func (p *pipeline) TeamID() int                            { return p.teamID }
+func (p *pipeline) TeamName() string                       { return p.teamName }
+func (p *pipeline) ParentJobID() int                       { return p.parentJobID }
+func (p *pipeline) ParentBuildID() int                     { return p.parentBuildID }
+func (p *pipeline) InstanceVars() atc.InstanceVars         { return p.instanceVars }
+func (p *pipeline) Groups() atc.GroupConfigs               { return p.groups }
+func (p *pipeline) VarSources() atc.VarSourceConfigs       { return p.varSources }
+func (p *pipeline) Display() *atc.DisplayConfig            { return p.display }
+func (p *pipeline) UserData() any                          { return p.userData }
+func (p *pipeline) Notifications() atc.NotificationConfigs { return p.notifications }
+func (p *pipeline) ConfigVersion() ConfigVersion           { return p.configVersion }
+func (p *pipeline) Public() bool                           { return p.public }
+func (p *pipeline) Paused() bool                           { return p.paused }
+func (p *pipeline) PausedAt() time.Time                    { return p.pausedAt }
+func (p *pipeline) PausedBy() string                       { return p.pausedBy }
+func (p *pipeline) Archived() bool                         { return p.archived }
+func (p *pipeline) LastUpdated() time.Time                 { return p.lastUpdated }
 
 func (p *pipeline) CheckPaused() (bool, error) {
 	var paused bool
@@ -271,6 +293,7 @@ func (p *pipeline) Config() (atc.Config, error) {
 		ResourceTypes: resourceTypes.Configs(),
 		Prototypes:    prototypes.Configs(),
 		Jobs:          jobConfigs,
+		Notifications: p.Notifications(),
 		Display:       p.Display(),
 		UserData:      p.userData,
 	}
@@ -746,6 +769,18 @@ func (p *pipeline) archive(tx Tx) error {
 		}
 	}
 
+	// forget the schedules so that unarchiving the pipeline doesn't trigger
+	// builds for everything that was missed in the meantime
+	_, err = psql.Update("jobs").
+		Set("trigger_schedule", nil).
+		Set("next_scheduled_trigger", nil).
+		Where(sq.Eq{"pipeline_id": p.id}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -788,8 +823,34 @@ func (p *pipeline) Destroy() error {
 	return tx.Commit()
 }
 
+// PipelineReferencedError is returned when destroying a pipeline whose jobs
+// are named in passed constraints of other pipelines. Destroying it would
+// delete the constraints along with the jobs, leaving the inputs of the other
+// pipelines unconstrained.
+type PipelineReferencedError struct {
+	Pipelines []atc.PipelineRef
+}
+
+func (e PipelineReferencedError) Error() string {
+	names := make([]string, len(e.Pipelines))
+	for i, ref := range e.Pipelines {
+		names[i] = ref.String()
+	}
+
+	return fmt.Sprintf("pipeline is referenced by passed constraints in other pipelines: %s", strings.Join(names, ", "))
+}
+
 func (p *pipeline) destroy(tx Tx) error {
-	_, err := psql.Delete("pipelines").
+	referencing, err := p.referencingPipelines(tx)
+	if err != nil {
+		return err
+	}
+
+	if len(referencing) > 0 {
+		return PipelineReferencedError{Pipelines: referencing}
+	}
+
+	_, err = psql.Delete("pipelines").
 		Where(sq.Eq{
 			"id": p.id,
 		}).
@@ -799,6 +860,53 @@ func (p *pipeline) destroy(tx Tx) error {
 	return err
 }
 
+// referencingPipelines returns the other pipelines with active jobs whose
+// inputs pass through the pipeline's jobs.
+func (p *pipeline) referencingPipelines(tx Tx) ([]atc.PipelineRef, error) {
+	rows, err := psql.Select("DISTINCT dp.name", "dp.instance_vars").
+		From("job_inputs ji").
+		Join("jobs pj ON pj.id = ji.passed_job_id").
+		Join("jobs j ON j.id = ji.job_id").
+		Join("pipelines dp ON dp.id = j.pipeline_id").
+		Where(sq.Eq{
+			"pj.pipeline_id": p.id,
+			"j.active":       true,
+		}).
+		Where(sq.NotEq{"dp.id": p.id}).
+		OrderBy("dp.name", "dp.instance_vars").
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	var refs []atc.PipelineRef
+	for rows.Next() {
+		var (
+			ref          atc.PipelineRef
+			instanceVars sql.NullString
+		)
+
+		err = rows.Scan(&ref.Name, &instanceVars)
+		if err != nil {
+			return nil, err
+		}
+
+		if instanceVars.Valid {
+			err = json.Unmarshal([]byte(instanceVars.String), &ref.InstanceVars)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		refs = append(refs, ref)
+	}
+
+	return refs, nil
+}
+
 func (p *pipeline) LoadDebugVersionsDB() (*atc.DebugVersionsDB, error) {
 	db := &atc.DebugVersionsDB{
 		BuildOutputs:     []atc.DebugBuildOutput{},
@@ -807,6 +915,7 @@ func (p *pipeline) LoadDebugVersionsDB() (*atc.DebugVersionsDB, error) {
 		BuildReruns:      []atc.DebugBuildRerun{},
 		Resources:        []atc.DebugResource{},
 		Jobs:             []atc.DebugJob{},
+		Dependencies:     []atc.DebugDependency{},
 	}
 
 	tx, err := p.conn.Begin()
@@ -971,6 +1080,33 @@ func (p *pipeline) LoadDebugVersionsDB() (*atc.DebugVersionsDB, error) {
 		db.Jobs = append(db.Jobs, job)
 	}
 
+	rows, err = psql.Select("ji.job_id, ji.name, ji.resource_id, ji.passed_job_id, COALESCE(ji.passed_job_ref, '')").
+		From("job_inputs ji").
+		Join("jobs j ON j.id = ji.job_id").
+		Where(sq.Eq{
+			"j.pipeline_id": p.id,
+			"j.active":      true,
+		}).
+		Where(sq.NotEq{"ji.passed_job_id": nil}).
+		OrderBy("ji.job_id", "ji.name", "ji.passed_job_id").
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	for rows.Next() {
+		var dependency atc.DebugDependency
+		err = rows.Scan(&dependency.JobID, &dependency.InputName, &dependency.ResourceID, &dependency.PassedJobID, &dependency.PassedJobRef)
+		if err != nil {
+			return nil, err
+		}
+
+		db.Dependencies = append(db.Dependencies, dependency)
+	}
+
 	rows, err = psql.Select("r.name, r.id, r.resource_config_scope_id").
 		From("resources r").
 		Where(sq.Eq{
@@ -1021,6 +1157,11 @@ func (p *pipeline) DeleteBuildEventsByBuildIDs(buildIDs []int) error {
 
 	defer Rollback(tx)
 
+	err = queueArchivedBuildEventsForDeletion(tx, buildIDs)
+	if err != nil {
+		return err
+	}
+
 	_, err = tx.Exec(`
    DELETE FROM `+p.eventsTable()+`
 	 WHERE build_id = ANY($1)
@@ -1042,6 +1183,22 @@ func (p *pipeline) DeleteBuildEventsByBuildIDs(buildIDs []int) error {
 	return err
 }
 
+func (p *pipeline) NotificationDeliveries(statuses []atc.NotificationDeliveryStatus, limit int) ([]NotificationDelivery, error) {
+	query := notificationDeliveriesQuery.
+		Where(sq.Eq{"d.pipeline_id": p.id}).
+		OrderBy("d.id DESC")
+
+	if len(statuses) > 0 {
+		query = query.Where(sq.Eq{"d.status": statuses})
+	}
+
+	if limit > 0 {
+		query = query.Limit(uint64(limit))
+	}
+
+	return getNotificationDeliveries(query, p.conn)
+}
+
 func (p *pipeline) eventsTable() string {
 	return fmt.Sprintf("pipeline_build_events_%d", p.id)
 }
@@ -1131,9 +1288,10 @@ func (p *pipeline) CreateStartedBuild(plan atc.Plan) (Build, error) {
 	return build, nil
 }
 
-// Variables creates variables for this pipeline. If this pipeline has its own
-// var_sources, a vars.MultiVars containing all pipeline specific var_sources
-// plug the global variables, otherwise just return the global variables.
+// Variables creates variables for this pipeline. If this pipeline or its team
+// has var_sources, a vars.MultiVars containing all pipeline specific
+// var_sources, then the team's var_sources, plus the global variables is
+// returned, otherwise just return the global variables.
 func (p *pipeline) Variables(logger lager.Logger, globalSecrets creds.Secrets, varSourcePool creds.VarSourcePool, secretLookupParams creds.SecretLookupParams) (vars.Variables, error) {
 	globalVars := creds.NewVariables(globalSecrets, secretLookupParams, false)
 	namedVarsMap := vars.NamedVariables{}
@@ -1142,37 +1300,64 @@ func (p *pipeline) Variables(logger lager.Logger, globalSecrets creds.Secrets, v
 	// a map is passed by reference.
 	allVars := vars.NewMultiVars([]vars.Variables{namedVarsMap, globalVars})
 
-	orderedVarSources, err := p.varSources.OrderByDependency()
-	if err != nil {
-		return nil, err
-	}
-
-	for _, cm := range orderedVarSources {
+	addVarSource := func(cm atc.VarSourceConfig, configVars vars.Variables) error {
 		factory := creds.ManagerFactories()[cm.Type]
 		if factory == nil {
-			return nil, fmt.Errorf("unknown credential manager type: %s", cm.Type)
+			return fmt.Errorf("unknown credential manager type: %s", cm.Type)
 		}
 
-		// Interpolate variables in pipeline credential manager's config
-		newConfig, err := creds.NewParams(allVars, atc.Params{"config": cm.Config}).Evaluate()
+		// Interpolate variables in the credential manager's config
+		newConfig, err := creds.NewParams(configVars, atc.Params{"config": cm.Config}).Evaluate()
 		if err != nil {
-			return nil, fmt.Errorf("evaluate var_source '%s' error: %w", cm.Name, err)
+			return fmt.Errorf("evaluate var_source '%s' error: %w", cm.Name, err)
 		}
 
 		config, ok := newConfig["config"].(map[string]any)
 		if !ok {
-			return nil, fmt.Errorf("var_source '%s' invalid config", cm.Name)
+			return fmt.Errorf("var_source '%s' invalid config", cm.Name)
 		}
 		secrets, err := varSourcePool.FindOrCreate(logger, config, factory)
 		if err != nil {
-			return nil, fmt.Errorf("create var_source '%s' error: %w", cm.Name, err)
+			return fmt.Errorf("create var_source '%s' error: %w", cm.Name, err)
 		}
 		namedVarsMap[cm.Name] = creds.NewVariables(secrets, secretLookupParams, true)
 
+		return nil
+	}
+
+	teamVarSources, err := decryptVarSources(p.conn.EncryptionStrategy(), p.teamVarSources, p.teamVarSourcesNonce)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load team var_sources: %w", err)
+	}
+
+	// Pipeline var_sources replace team var_sources of the same name. The
+	// credentials of team var_sources can only come from the global
+	// credential manager.
+	for _, cm := range teamVarSources {
+		if _, found := p.varSources.Lookup(cm.Name); found {
+			continue
+		}
+
+		err = addVarSource(cm, globalVars)
+		if err != nil {
+			return nil, fmt.Errorf("team %w", err)
+		}
+	}
+
+	orderedVarSources, err := p.varSources.OrderByDependency()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, cm := range orderedVarSources {
+		err = addVarSource(cm, allVars)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	// If there is no var_source from the pipeline, then just return the global
-	// vars.
+	// If there is no var_source from the pipeline or team, then just return
+	// the global vars.
 	if len(namedVarsMap) == 0 {
 		return globalVars, nil
 	}
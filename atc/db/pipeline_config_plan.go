@@ -0,0 +1,335 @@
+package db
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+)
+
+// PlanConfig compares the given config against the pipeline's current config
+// and state without saving anything, mirroring what SavePipeline would do to
+// resource config scopes, job histories, pins and disabled versions.
+func (p *pipeline) PlanConfig(config atc.Config) (atc.ConfigPlan, error) {
+	current, err := p.Config()
+	if err != nil {
+		return atc.ConfigPlan{}, err
+	}
+
+	plan := atc.ConfigPlan{
+		RecheckedResources:       []atc.ConfigPlanResource{},
+		RemovedJobs:              []atc.ConfigPlanJob{},
+		OrphanedPins:             []atc.ConfigPlanVersion{},
+		OrphanedDisabledVersions: []atc.ConfigPlanVersion{},
+		PassedConstraints:        []atc.ConfigPlanPassedChange{},
+	}
+
+	// keyed by the resource's current name, so that pins and disabled
+	// versions, which follow a resource through a rename, can be looked up
+	orphanReasons := map[string]string{}
+
+	changedTypes := changedResourceTypes(current.ResourceTypes, config.ResourceTypes)
+
+	keptResources := map[string]bool{}
+	for _, resource := range config.Resources {
+		existing, found := current.Resources.Lookup(resource.Name)
+		if !found && resource.OldName != "" {
+			existing, found = current.Resources.Lookup(resource.OldName)
+		}
+
+		if !found {
+			continue
+		}
+
+		keptResources[existing.Name] = true
+
+		var reason string
+		switch {
+		case resource.Type != existing.Type:
+			reason = fmt.Sprintf("type changed from '%s' to '%s'", existing.Type, resource.Type)
+		case mapHash(resource.Source) != mapHash(existing.Source):
+			reason = "source changed"
+		case changedTypes[resource.Type]:
+			reason = fmt.Sprintf("resource type '%s' changed", resource.Type)
+		default:
+			continue
+		}
+
+		plan.RecheckedResources = append(plan.RecheckedResources, atc.ConfigPlanResource{
+			Name:   resource.Name,
+			Reason: reason,
+		})
+
+		orphanReasons[existing.Name] = reason
+	}
+
+	for _, resource := range current.Resources {
+		if !keptResources[resource.Name] {
+			orphanReasons[resource.Name] = "resource removed"
+		}
+	}
+
+	renamedJobs := map[string]string{}
+	for _, job := range config.Jobs {
+		if job.OldName == "" || job.OldName == job.Name {
+			continue
+		}
+
+		if _, found := current.Jobs.Lookup(job.Name); found {
+			continue
+		}
+
+		if _, found := current.Jobs.Lookup(job.OldName); found {
+			renamedJobs[job.OldName] = job.Name
+		}
+	}
+
+	plan.RemovedJobs, err = p.removedJobs(current.Jobs, config.Jobs, renamedJobs)
+	if err != nil {
+		return atc.ConfigPlan{}, err
+	}
+
+	plan.OrphanedPins, err = p.orphanedPins(orphanReasons)
+	if err != nil {
+		return atc.ConfigPlan{}, err
+	}
+
+	plan.OrphanedDisabledVersions, err = p.orphanedDisabledVersions(orphanReasons)
+	if err != nil {
+		return atc.ConfigPlan{}, err
+	}
+
+	currentJobNames := map[string]string{}
+	for _, job := range current.Jobs {
+		currentJobNames[job.Name] = job.Name
+	}
+
+	for oldName, newName := range renamedJobs {
+		currentJobNames[newName] = oldName
+	}
+
+	for _, job := range config.Jobs {
+		existing, found := current.Jobs.Lookup(currentJobNames[job.Name])
+		if !found {
+			continue
+		}
+
+		plan.PassedConstraints = append(plan.PassedConstraints, passedChanges(job, existing, renamedJobs)...)
+	}
+
+	return plan, nil
+}
+
+// changedResourceTypes returns the names of the resource types whose resource
+// configs change, including those of types whose parent type changes.
+func changedResourceTypes(current atc.ResourceTypes, updated atc.ResourceTypes) map[string]bool {
+	changed := map[string]bool{}
+	for _, resourceType := range updated {
+		existing, found := current.Lookup(resourceType.Name)
+		if !found {
+			continue
+		}
+
+		if resourceType.Type != existing.Type || mapHash(resourceType.Source) != mapHash(existing.Source) {
+			changed[resourceType.Name] = true
+		}
+	}
+
+	for {
+		propagated := false
+		for _, resourceType := range updated {
+			if !changed[resourceType.Name] && changed[resourceType.Type] {
+				changed[resourceType.Name] = true
+				propagated = true
+			}
+		}
+
+		if !propagated {
+			return changed
+		}
+	}
+}
+
+func (p *pipeline) removedJobs(current atc.JobConfigs, updated atc.JobConfigs, renamedJobs map[string]string) ([]atc.ConfigPlanJob, error) {
+	var names []string
+	for _, job := range current {
+		if _, found := updated.Lookup(job.Name); found {
+			continue
+		}
+
+		if _, renamed := renamedJobs[job.Name]; renamed {
+			continue
+		}
+
+		names = append(names, job.Name)
+	}
+
+	removed := []atc.ConfigPlanJob{}
+	if len(names) == 0 {
+		return removed, nil
+	}
+
+	rows, err := psql.Select("j.name", "COUNT(*)").
+		From("jobs j").
+		Join("builds b ON b.job_id = j.id").
+		Where(sq.Eq{
+			"j.pipeline_id": p.id,
+			"j.name":        names,
+		}).
+		GroupBy("j.name").
+		OrderBy("j.name").
+		RunWith(p.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	for rows.Next() {
+		var job atc.ConfigPlanJob
+		err = rows.Scan(&job.Name, &job.Builds)
+		if err != nil {
+			return nil, err
+		}
+
+		removed = append(removed, job)
+	}
+
+	return removed, rows.Err()
+}
+
+// pins set through the config are replaced by the new config, so only pins
+// set through the API can be orphaned
+func (p *pipeline) orphanedPins(orphanReasons map[string]string) ([]atc.ConfigPlanVersion, error) {
+	return p.orphanedVersions(orphanReasons, psql.Select("r.name", "rp.version").
+		From("resource_pins rp").
+		Join("resources r ON r.id = rp.resource_id").
+		Where(sq.Eq{"rp.config": false}))
+}
+
+func (p *pipeline) orphanedDisabledVersions(orphanReasons map[string]string) ([]atc.ConfigPlanVersion, error) {
+	return p.orphanedVersions(orphanReasons, psql.Select("r.name", "v.version").
+		From("resource_disabled_versions d").
+		Join("resources r ON r.id = d.resource_id").
+		Join("resource_config_versions v ON v.resource_config_scope_id = r.resource_config_scope_id AND d.version_digest IN (v.version_md5, v.version_sha256)"))
+}
+
+func (p *pipeline) orphanedVersions(orphanReasons map[string]string, query sq.SelectBuilder) ([]atc.ConfigPlanVersion, error) {
+	orphaned := []atc.ConfigPlanVersion{}
+	if len(orphanReasons) == 0 {
+		return orphaned, nil
+	}
+
+	var names []string
+	for name := range orphanReasons {
+		names = append(names, name)
+	}
+
+	rows, err := query.
+		Where(sq.Eq{
+			"r.pipeline_id": p.id,
+			"r.active":      true,
+			"r.name":        names,
+		}).
+		OrderBy("r.name").
+		RunWith(p.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	for rows.Next() {
+		var (
+			name    string
+			version string
+		)
+
+		err = rows.Scan(&name, &version)
+		if err != nil {
+			return nil, err
+		}
+
+		orphan := atc.ConfigPlanVersion{
+			Resource: name,
+			Reason:   orphanReasons[name],
+		}
+
+		err = json.Unmarshal([]byte(version), &orphan.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		orphaned = append(orphaned, orphan)
+	}
+
+	return orphaned, rows.Err()
+}
+
+func passedChanges(job atc.JobConfig, existing atc.JobConfig, renamedJobs map[string]string) []atc.ConfigPlanPassedChange {
+	before := map[string][]string{}
+	for _, input := range existing.Inputs() {
+		var passed []string
+		for _, name := range input.Passed {
+			if newName, renamed := renamedJobs[name]; renamed {
+				name = newName
+			}
+
+			passed = append(passed, name)
+		}
+
+		before[input.Name] = passed
+	}
+
+	var changes []atc.ConfigPlanPassedChange
+
+	after := map[string]bool{}
+	for _, input := range job.Inputs() {
+		after[input.Name] = true
+
+		if samePassed(before[input.Name], input.Passed) {
+			continue
+		}
+
+		changes = append(changes, atc.ConfigPlanPassedChange{
+			Job:    job.Name,
+			Input:  input.Name,
+			Before: sortedPassed(before[input.Name]),
+			After:  sortedPassed(input.Passed),
+		})
+	}
+
+	for _, input := range existing.Inputs() {
+		if after[input.Name] || len(input.Passed) == 0 {
+			continue
+		}
+
+		changes = append(changes, atc.ConfigPlanPassedChange{
+			Job:    job.Name,
+			Input:  input.Name,
+			Before: sortedPassed(before[input.Name]),
+			After:  []string{},
+		})
+	}
+
+	return changes
+}
+
+func samePassed(a []string, b []string) bool {
+	return slices.Equal(sortedPassed(a), sortedPassed(b))
+}
+
+func sortedPassed(passed []string) []string {
+	sorted := slices.Clone(passed)
+	if sorted == nil {
+		sorted = []string{}
+	}
+
+	slices.Sort(sorted)
+
+	return sorted
+}
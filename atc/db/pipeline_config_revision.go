@@ -0,0 +1,227 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+)
+
+// MaxPipelineConfigRevisions is the number of revisions kept for each
+// pipeline. Older revisions are removed as new ones are saved.
+var MaxPipelineConfigRevisions = 100
+
+// PipelineConfigRevision is a config which was saved for a pipeline. BuildID
+// is set if the config was saved by a set_pipeline step.
+type PipelineConfigRevision struct {
+	Revision      int
+	ConfigVersion ConfigVersion
+	Config        atc.Config
+	CreatedBy     string
+	CreatedAt     time.Time
+
+	BuildID           int
+	BuildName         string
+	BuildJobName      string
+	BuildPipelineName string
+}
+
+func saveConfigRevision(tx Tx, pipelineID int, config atc.Config, buildID sql.NullInt64, savedBy sql.NullString) error {
+	payload, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	encryptedPayload, nonce, err := tx.EncryptionStrategy().Encrypt(payload)
+	if err != nil {
+		return err
+	}
+
+	var revision int
+	err = psql.Insert("pipeline_config_revisions").
+		SetMap(map[string]any{
+			"pipeline_id":    pipelineID,
+			"revision":       sq.Expr("(SELECT COALESCE(MAX(revision), 0) + 1 FROM pipeline_config_revisions WHERE pipeline_id = ?)", pipelineID),
+			"config_version": sq.Expr("(SELECT version FROM pipelines WHERE id = ?)", pipelineID),
+			"config":         encryptedPayload,
+			"nonce":          nonce,
+			"created_by":     savedBy,
+			"build_id":       buildID,
+		}).
+		Suffix("RETURNING revision").
+		RunWith(tx).
+		QueryRow().
+		Scan(&revision)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Delete("pipeline_config_revisions").
+		Where(sq.Eq{"pipeline_id": pipelineID}).
+		Where(sq.LtOrEq{"revision": revision - MaxPipelineConfigRevisions}).
+		RunWith(tx).
+		Exec()
+	return err
+}
+
+var pipelineConfigRevisionsQuery = psql.Select(
+	"r.revision",
+	"r.config_version",
+	"r.created_by",
+	"r.created_at",
+	"r.build_id",
+	"b.name",
+	"j.name",
+	"bp.name",
+).
+	From("pipeline_config_revisions r").
+	LeftJoin("builds b ON b.id = r.build_id").
+	LeftJoin("jobs j ON j.id = b.job_id").
+	LeftJoin("pipelines bp ON bp.id = b.pipeline_id")
+
+// ConfigRevisions returns the pipeline's revisions, newest first, without
+// their configs.
+func (p *pipeline) ConfigRevisions() ([]PipelineConfigRevision, error) {
+	rows, err := pipelineConfigRevisionsQuery.
+		Where(sq.Eq{"r.pipeline_id": p.id}).
+		OrderBy("r.revision DESC").
+		RunWith(p.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	var revisions []PipelineConfigRevision
+	for rows.Next() {
+		revision, err := scanPipelineConfigRevision(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		revisions = append(revisions, revision)
+	}
+
+	return revisions, rows.Err()
+}
+
+func (p *pipeline) ConfigRevision(revision int) (PipelineConfigRevision, bool, error) {
+	row := pipelineConfigRevisionsQuery.
+		Columns("r.config", "r.nonce").
+		Where(sq.Eq{
+			"r.pipeline_id": p.id,
+			"r.revision":    revision,
+		}).
+		RunWith(p.conn).
+		QueryRow()
+
+	var (
+		configBlob string
+		nonce      sql.NullString
+	)
+
+	rev, err := scanPipelineConfigRevision(row, &configBlob, &nonce)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return PipelineConfigRevision{}, false, nil
+		}
+
+		return PipelineConfigRevision{}, false, err
+	}
+
+	var noncense *string
+	if nonce.Valid {
+		noncense = &nonce.String
+	}
+
+	decryptedConfig, err := p.conn.EncryptionStrategy().Decrypt(configBlob, noncense)
+	if err != nil {
+		return PipelineConfigRevision{}, false, err
+	}
+
+	err = json.Unmarshal(decryptedConfig, &rev.Config)
+	if err != nil {
+		return PipelineConfigRevision{}, false, err
+	}
+
+	return rev, true, nil
+}
+
+// RestoreConfigRevision saves the config of a previous revision as a new
+// revision. It fails with ErrConfigComparisonFailed if the pipeline has been
+// configured since it was loaded.
+func (p *pipeline) RestoreConfigRevision(revision int, restoredBy string) (bool, error) {
+	rev, found, err := p.ConfigRevision(revision)
+	if err != nil {
+		return false, err
+	}
+
+	if !found {
+		return false, nil
+	}
+
+	tx, err := p.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	defer Rollback(tx)
+
+	nullID := sql.NullInt64{Valid: false}
+	_, _, err = savePipeline(
+		tx,
+		atc.PipelineRef{Name: p.name, InstanceVars: p.instanceVars},
+		rev.Config,
+		p.configVersion,
+		true,
+		p.teamID,
+		nullID,
+		nullID,
+		sql.NullString{String: restoredBy, Valid: restoredBy != ""},
+	)
+	if err != nil {
+		return false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func scanPipelineConfigRevision(row scannable, extra ...any) (PipelineConfigRevision, error) {
+	var (
+		rev                                         PipelineConfigRevision
+		createdBy, buildName, jobName, pipelineName sql.NullString
+		buildID                                     sql.NullInt64
+	)
+
+	dest := append([]any{
+		&rev.Revision,
+		&rev.ConfigVersion,
+		&createdBy,
+		&rev.CreatedAt,
+		&buildID,
+		&buildName,
+		&jobName,
+		&pipelineName,
+	}, extra...)
+
+	err := row.Scan(dest...)
+	if err != nil {
+		return PipelineConfigRevision{}, err
+	}
+
+	rev.CreatedBy = createdBy.String
+	rev.BuildID = int(buildID.Int64)
+	rev.BuildName = buildName.String
+	rev.BuildJobName = jobName.String
+	rev.BuildPipelineName = pipelineName.String
+
+	return rev, nil
+}
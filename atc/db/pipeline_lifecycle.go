@@ -2,6 +2,7 @@ package db
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 
@@ -209,6 +210,13 @@ func destroyArchivedPipelines(tx Tx, conn DbConn, lockFactory lock.LockFactory,
 	for _, pipeline := range toDestroy {
 		err := pipeline.destroy(tx)
 		if err != nil {
+			// keep pipelines which others pass through until they are no
+			// longer referenced
+			var referencedErr PipelineReferencedError
+			if errors.As(err, &referencedErr) {
+				continue
+			}
+
 			return err
 		}
 	}
@@ -106,7 +106,7 @@ var _ = Describe("PipelineLifecycle", func() {
 							Name: "a-different-job",
 						},
 					}
-					defaultTeam.SavePipeline(defaultPipelineRef, defaultPipelineConfig, defaultPipeline.ConfigVersion(), false)
+					defaultTeam.SavePipeline(defaultPipelineRef, defaultPipelineConfig, defaultPipeline.ConfigVersion(), false, "some-user")
 				})
 
 				It("archives all child pipelines set by the deleted job", func() {
@@ -169,11 +169,11 @@ var _ = Describe("PipelineLifecycle", func() {
 		BeforeEach(func() {
 			olderThan = 48 * time.Hour
 
-			p1, _, err = defaultTeam.SavePipeline(atc.PipelineRef{Name: "p1"}, defaultPipelineConfig, db.ConfigVersion(0), false)
+			p1, _, err = defaultTeam.SavePipeline(atc.PipelineRef{Name: "p1"}, defaultPipelineConfig, db.ConfigVersion(0), false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
-			p2, _, err = defaultTeam.SavePipeline(atc.PipelineRef{Name: "p2"}, defaultPipelineConfig, db.ConfigVersion(0), false)
+			p2, _, err = defaultTeam.SavePipeline(atc.PipelineRef{Name: "p2"}, defaultPipelineConfig, db.ConfigVersion(0), false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
-			p3, _, err = defaultTeam.SavePipeline(atc.PipelineRef{Name: "p3"}, defaultPipelineConfig, db.ConfigVersion(0), false)
+			p3, _, err = defaultTeam.SavePipeline(atc.PipelineRef{Name: "p3"}, defaultPipelineConfig, db.ConfigVersion(0), false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 		})
 
@@ -250,7 +250,40 @@ var _ = Describe("PipelineLifecycle", func() {
 			})
 		})
 
+		Context("an archived pipeline is passed through by another pipeline", func() {
+			BeforeEach(func() {
+				_, _, err = defaultTeam.SavePipeline(atc.PipelineRef{Name: "p2"}, atc.Config{
+					Jobs: atc.JobConfigs{
+						{
+							Name: "some-job",
+							PlanSequence: []atc.Step{
+								{
+									Config: &atc.GetStep{
+										Name:   "some-resource",
+										Passed: []string{"p1/some-job"},
+									},
+								},
+							},
+						},
+					},
+					Resources: defaultPipelineConfig.Resources,
+				}, p2.ConfigVersion(), false, "some-user")
+				Expect(err).ToNot(HaveOccurred())
+
+				err = p1.Archive()
+				Expect(err).ToNot(HaveOccurred())
+				_, err = dbConn.Exec(`UPDATE pipelines SET paused_at = NOW() - INTERVAL '3' DAY WHERE id = $1`, p1.ID())
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("keeps it so that the constraint is not lost", func() {
+				found, err := p1.Reload()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+		})
 	})
+
 	Describe("RemoveBuildEventsForDeletedPipelines", func() {
 		var (
 			pipeline1 db.Pipeline
@@ -258,9 +291,9 @@ var _ = Describe("PipelineLifecycle", func() {
 		)
 
 		BeforeEach(func() {
-			pipeline1, _, err = defaultTeam.SavePipeline(atc.PipelineRef{Name: "pipeline1"}, defaultPipelineConfig, 0, false)
+			pipeline1, _, err = defaultTeam.SavePipeline(atc.PipelineRef{Name: "pipeline1"}, defaultPipelineConfig, 0, false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
-			pipeline2, _, err = defaultTeam.SavePipeline(atc.PipelineRef{Name: "pipeline2"}, defaultPipelineConfig, 0, false)
+			pipeline2, _, err = defaultTeam.SavePipeline(atc.PipelineRef{Name: "pipeline2"}, defaultPipelineConfig, 0, false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 		})
 
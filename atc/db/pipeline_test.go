@@ -3,6 +3,7 @@ package db_test
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"strconv"
 	"time"
 
@@ -176,7 +177,7 @@ var _ = Describe("Pipeline", func() {
 			},
 		}
 		var created bool
-		pipeline, created, err = team.SavePipeline(atc.PipelineRef{Name: "fake-pipeline"}, pipelineConfig, db.ConfigVersion(0), false)
+		pipeline, created, err = team.SavePipeline(atc.PipelineRef{Name: "fake-pipeline"}, pipelineConfig, db.ConfigVersion(0), false, "some-user")
 		Expect(err).ToNot(HaveOccurred())
 		Expect(created).To(BeTrue())
 
@@ -1843,6 +1844,158 @@ var _ = Describe("Pipeline", func() {
 		})
 	})
 
+	Describe("SearchBuildLogs", func() {
+		var (
+			build      db.Build
+			otherBuild db.Build
+		)
+
+		BeforeEach(func() {
+			job, found, err := pipeline.Job("job-name")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			build, err = job.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).ToNot(HaveOccurred())
+
+			found, err = build.Start(atc.Plan{
+				ID:   "some-plan",
+				Task: &atc.TaskPlan{Name: "unit"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			err = build.SaveEvent(event.Log{
+				Origin:  event.Origin{ID: "some-plan"},
+				Time:    1,
+				Payload: "running tests\nFAIL: some",
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = build.SaveEvent(event.Log{
+				Origin:  event.Origin{ID: "some-plan"},
+				Time:    2,
+				Payload: "-test\n\x1b[1mok\x1b[0m\nFAIL: last",
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			otherJob, found, err := pipeline.Job("some-other-job")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			otherBuild, err = otherJob.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).ToNot(HaveOccurred())
+
+			found, err = otherBuild.Start(atc.Plan{ID: "other-plan"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			err = otherBuild.SaveEvent(event.Log{
+				Origin:  event.Origin{ID: "other-plan"},
+				Time:    3,
+				Payload: "FAIL: elsewhere\n",
+			})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns matching lines, joined across events, with their step and line number", func() {
+			result, err := pipeline.SearchBuildLogs(db.BuildLogSearch{
+				Pattern: regexp.MustCompile(`FAIL: \S+`),
+				Jobs:    []string{"job-name"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Truncated).To(BeFalse())
+			Expect(result.ArchivedBuilds).To(BeZero())
+			Expect(result.Matches).To(Equal([]db.BuildLogMatch{
+				{
+					BuildID:   build.ID(),
+					BuildName: build.Name(),
+					JobName:   "job-name",
+					PlanID:    "some-plan",
+					StepName:  "unit",
+					Line:      2,
+					Text:      "FAIL: some-test",
+					Time:      time.Unix(2, 0),
+				},
+				{
+					BuildID:   build.ID(),
+					BuildName: build.Name(),
+					JobName:   "job-name",
+					PlanID:    "some-plan",
+					StepName:  "unit",
+					Line:      4,
+					Text:      "FAIL: last",
+					Time:      time.Unix(2, 0),
+				},
+			}))
+		})
+
+		It("matches against lines without escape codes", func() {
+			result, err := pipeline.SearchBuildLogs(db.BuildLogSearch{
+				Pattern: regexp.MustCompile(`^ok$`),
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Matches).To(HaveLen(1))
+			Expect(result.Matches[0].Line).To(Equal(3))
+		})
+
+		It("searches every job's builds, oldest first", func() {
+			result, err := pipeline.SearchBuildLogs(db.BuildLogSearch{
+				Pattern: regexp.MustCompile(`FAIL`),
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Matches).To(HaveLen(3))
+			Expect(result.Matches[2].BuildID).To(Equal(otherBuild.ID()))
+			Expect(result.Matches[2].Text).To(Equal("FAIL: elsewhere"))
+		})
+
+		It("stops at the limit", func() {
+			result, err := pipeline.SearchBuildLogs(db.BuildLogSearch{
+				Pattern: regexp.MustCompile(`FAIL`),
+				Limit:   1,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Truncated).To(BeTrue())
+			Expect(result.Matches).To(HaveLen(1))
+		})
+
+		It("only scans the most recent builds", func() {
+			db.MaxBuildLogSearchBuilds = 1
+			defer func() { db.MaxBuildLogSearchBuilds = 100 }()
+
+			result, err := pipeline.SearchBuildLogs(db.BuildLogSearch{
+				Pattern: regexp.MustCompile(`FAIL`),
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Truncated).To(BeTrue())
+			Expect(result.Matches).To(HaveLen(1))
+			Expect(result.Matches[0].BuildID).To(Equal(otherBuild.ID()))
+		})
+
+		It("excludes builds which started outside of the range", func() {
+			result, err := pipeline.SearchBuildLogs(db.BuildLogSearch{
+				Pattern: regexp.MustCompile(`FAIL`),
+				To:      time.Now().Add(-time.Hour),
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Matches).To(BeEmpty())
+		})
+
+		It("counts the builds whose logs have been archived rather than searching them", func() {
+			_, err := dbConn.Exec(`UPDATE builds SET logs_archived = true WHERE id = $1`, otherBuild.ID())
+			Expect(err).ToNot(HaveOccurred())
+
+			result, err := pipeline.SearchBuildLogs(db.BuildLogSearch{
+				Pattern: regexp.MustCompile(`FAIL`),
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.ArchivedBuilds).To(Equal(1))
+			Expect(result.Matches).To(HaveLen(2))
+			Expect(result.Matches[0].BuildID).To(Equal(build.ID()))
+			Expect(result.Matches[1].BuildID).To(Equal(build.ID()))
+		})
+	})
+
 	Describe("CreateStartedBuild", func() {
 		var (
 			plan         atc.Plan
@@ -1939,6 +2092,259 @@ var _ = Describe("Pipeline", func() {
 		})
 	})
 
+	Describe("ConfigRevisions", func() {
+		var updatedConfig atc.Config
+
+		BeforeEach(func() {
+			updatedConfig = pipelineConfig
+			updatedConfig.Jobs = append(atc.JobConfigs{{Name: "some-new-job"}}, pipelineConfig.Jobs...)
+
+			var err error
+			pipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "fake-pipeline"}, updatedConfig, pipeline.ConfigVersion(), false, "some-other-user")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("records a revision for each saved config, newest first", func() {
+			revisions, err := pipeline.ConfigRevisions()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(revisions).To(HaveLen(2))
+
+			Expect(revisions[0].Revision).To(Equal(2))
+			Expect(revisions[0].ConfigVersion).To(Equal(pipeline.ConfigVersion()))
+			Expect(revisions[0].CreatedBy).To(Equal("some-other-user"))
+
+			Expect(revisions[1].Revision).To(Equal(1))
+			Expect(revisions[1].CreatedBy).To(Equal("some-user"))
+		})
+
+		It("returns the config of a revision", func() {
+			revision, found, err := pipeline.ConfigRevision(1)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			_, found = revision.Config.Jobs.Lookup("some-new-job")
+			Expect(found).To(BeFalse())
+			Expect(revision.Config.Jobs).To(HaveLen(len(pipelineConfig.Jobs)))
+		})
+
+		It("does not find a revision which does not exist", func() {
+			_, found, err := pipeline.ConfigRevision(3)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		Context("when the config is saved by a build", func() {
+			BeforeEach(func() {
+				job, found, err := pipeline.Job("job-name")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				build, err := job.CreateBuild(defaultBuildCreatedBy)
+				Expect(err).ToNot(HaveOccurred())
+
+				pipeline, _, err = build.SavePipeline(atc.PipelineRef{Name: "fake-pipeline"}, team.ID(), pipelineConfig, pipeline.ConfigVersion(), false)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("records the build", func() {
+				revisions, err := pipeline.ConfigRevisions()
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(revisions[0].Revision).To(Equal(3))
+				Expect(revisions[0].BuildName).To(Equal("1"))
+				Expect(revisions[0].BuildJobName).To(Equal("job-name"))
+				Expect(revisions[0].BuildPipelineName).To(Equal("fake-pipeline"))
+				Expect(revisions[0].CreatedBy).To(Equal(defaultBuildCreatedBy))
+			})
+		})
+
+		Describe("RestoreConfigRevision", func() {
+			It("saves the config of the revision as a new revision", func() {
+				found, err := pipeline.RestoreConfigRevision(1, "some-admin")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				_, found, err = pipeline.Job("some-new-job")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+
+				revisions, err := pipeline.ConfigRevisions()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(revisions).To(HaveLen(3))
+				Expect(revisions[0].Revision).To(Equal(3))
+				Expect(revisions[0].CreatedBy).To(Equal("some-admin"))
+			})
+
+			It("fails if the pipeline has been configured since it was loaded", func() {
+				_, _, err := team.SavePipeline(atc.PipelineRef{Name: "fake-pipeline"}, pipelineConfig, pipeline.ConfigVersion(), false, "some-user")
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = pipeline.RestoreConfigRevision(1, "some-admin")
+				Expect(err).To(Equal(db.ErrConfigComparisonFailed))
+			})
+
+			It("does not find a revision which does not exist", func() {
+				found, err := pipeline.RestoreConfigRevision(42, "some-admin")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("PlanConfig", func() {
+		var (
+			scenario      *dbtest.Scenario
+			currentConfig atc.Config
+			newConfig     atc.Config
+			plan          atc.ConfigPlan
+		)
+
+		BeforeEach(func() {
+			currentConfig = atc.Config{
+				Resources: atc.ResourceConfigs{
+					{
+						Name:   "some-resource",
+						Type:   "some-base-resource-type",
+						Source: atc.Source{"some": "source"},
+					},
+					{
+						Name:   "some-other-resource",
+						Type:   "some-base-resource-type",
+						Source: atc.Source{"some": "other-source"},
+					},
+				},
+				Jobs: atc.JobConfigs{
+					{
+						Name: "some-job",
+						PlanSequence: []atc.Step{
+							{Config: &atc.GetStep{Name: "some-resource"}},
+						},
+					},
+					{
+						Name: "downstream-job",
+						PlanSequence: []atc.Step{
+							{Config: &atc.GetStep{Name: "some-resource", Passed: []string{"some-job"}}},
+						},
+					},
+					{
+						Name: "some-old-job",
+					},
+				},
+			}
+
+			var build db.Build
+			scenario = dbtest.Setup(
+				builder.WithPipeline(currentConfig),
+				builder.WithPinnedVersion("some-resource", atc.Version{"version": "1"}),
+				builder.WithDisabledVersion("some-other-resource", atc.Version{"version": "2"}),
+				builder.WithPendingJobBuild(&build, "some-old-job"),
+			)
+
+			newConfig = currentConfig
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			plan, err = scenario.Pipeline.PlanConfig(newConfig)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		Context("when the config is unchanged", func() {
+			It("has no consequences", func() {
+				Expect(plan.IsEmpty()).To(BeTrue())
+			})
+		})
+
+		Context("when a resource's source changes", func() {
+			BeforeEach(func() {
+				newConfig.Resources = atc.ResourceConfigs{
+					{
+						Name:   "some-resource",
+						Type:   "some-base-resource-type",
+						Source: atc.Source{"some": "new-source"},
+					},
+					currentConfig.Resources[1],
+				}
+			})
+
+			It("rechecks the resource and orphans its pin", func() {
+				Expect(plan.RecheckedResources).To(Equal([]atc.ConfigPlanResource{
+					{Name: "some-resource", Reason: "source changed"},
+				}))
+
+				Expect(plan.OrphanedPins).To(Equal([]atc.ConfigPlanVersion{
+					{Resource: "some-resource", Version: atc.Version{"version": "1"}, Reason: "source changed"},
+				}))
+
+				Expect(plan.OrphanedDisabledVersions).To(BeEmpty())
+			})
+		})
+
+		Context("when a resource is removed", func() {
+			BeforeEach(func() {
+				newConfig.Resources = currentConfig.Resources[:1]
+			})
+
+			It("orphans its disabled versions", func() {
+				Expect(plan.RecheckedResources).To(BeEmpty())
+				Expect(plan.OrphanedDisabledVersions).To(Equal([]atc.ConfigPlanVersion{
+					{Resource: "some-other-resource", Version: atc.Version{"version": "2"}, Reason: "resource removed"},
+				}))
+			})
+		})
+
+		Context("when a job with builds is removed", func() {
+			BeforeEach(func() {
+				newConfig.Jobs = currentConfig.Jobs[:2]
+			})
+
+			It("loses the job's build history", func() {
+				Expect(plan.RemovedJobs).To(Equal([]atc.ConfigPlanJob{
+					{Name: "some-old-job", Builds: 1},
+				}))
+			})
+
+			Context("when it is renamed with old_name", func() {
+				BeforeEach(func() {
+					newConfig.Jobs = append(currentConfig.Jobs[:2:2], atc.JobConfig{
+						Name:    "some-new-job",
+						OldName: "some-old-job",
+					})
+				})
+
+				It("keeps the job's build history", func() {
+					Expect(plan.RemovedJobs).To(BeEmpty())
+				})
+			})
+		})
+
+		Context("when a passed constraint changes", func() {
+			BeforeEach(func() {
+				newConfig.Jobs = atc.JobConfigs{
+					currentConfig.Jobs[0],
+					{
+						Name: "downstream-job",
+						PlanSequence: []atc.Step{
+							{Config: &atc.GetStep{Name: "some-resource", Passed: []string{"some-job", "some-old-job"}}},
+						},
+					},
+					currentConfig.Jobs[2],
+				}
+			})
+
+			It("lists the change", func() {
+				Expect(plan.PassedConstraints).To(Equal([]atc.ConfigPlanPassedChange{
+					{
+						Job:    "downstream-job",
+						Input:  "some-resource",
+						Before: []string{"some-job"},
+						After:  []string{"some-job", "some-old-job"},
+					},
+				}))
+			})
+		})
+	})
+
 	Describe("ResourceVersion", func() {
 		var (
 			rv                    atc.ResourceVersion
@@ -2024,7 +2430,7 @@ var _ = Describe("Pipeline", func() {
 					},
 				},
 			}
-			pipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, config, db.ConfigVersion(1), false)
+			pipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, config, db.ConfigVersion(1), false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
 			job, found, err = pipeline.Job("some-job")
@@ -2044,7 +2450,7 @@ var _ = Describe("Pipeline", func() {
 				Expect(found).To(BeTrue())
 			}
 
-			otherPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "another-pipeline"}, config, db.ConfigVersion(1), false)
+			otherPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "another-pipeline"}, config, db.ConfigVersion(1), false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
 			otherJob, found, err := otherPipeline.Job("some-job")
@@ -2206,7 +2612,7 @@ var _ = Describe("Pipeline", func() {
 				})
 
 				var created bool
-				pipeline, created, err = team.SavePipeline(atc.PipelineRef{Name: "fake-pipeline"}, pipelineConfig, pipeline.ConfigVersion(), false)
+				pipeline, created, err = team.SavePipeline(atc.PipelineRef{Name: "fake-pipeline"}, pipelineConfig, pipeline.ConfigVersion(), false, "some-user")
 				Expect(err).ToNot(HaveOccurred())
 				Expect(created).To(BeFalse())
 			})
@@ -2220,6 +2626,47 @@ var _ = Describe("Pipeline", func() {
 				Expect(v.(string)).To(Equal("pv"))
 			})
 		})
+
+		Context("when the team has var sources", func() {
+			BeforeEach(func() {
+				err := team.UpdateVarSources(atc.VarSourceConfigs{
+					{
+						Name: "team-var-source",
+						Type: "dummy",
+						Config: map[string]any{
+							"vars": map[string]any{"tk": "tv"},
+						},
+					},
+					{
+						Name: "some-var-source",
+						Type: "dummy",
+						Config: map[string]any{
+							"vars": map[string]any{"pk": "team-pv"},
+						},
+					},
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				// team var sources are loaded with the pipeline
+				found, err := pipeline.Reload()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+
+			It("should get var from the team var source", func() {
+				v, found, err := pvars.Get(vars.Reference{Source: "team-var-source", Path: "tk"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(v.(string)).To(Equal("tv"))
+			})
+
+			It("should prefer the pipeline var source with the same name", func() {
+				v, found, err := pvars.Get(vars.Reference{Source: "some-var-source", Path: "pk"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(v.(string)).To(Equal("pv"))
+			})
+		})
 	})
 
 	Describe("SetParentIDs", func() {
@@ -2274,6 +2721,32 @@ var _ = Describe("Pipeline", func() {
 		})
 	})
 
+	Describe("Notifications", func() {
+		It("round-trips the notifications config", func() {
+			configWithNotifications := pipelineConfig
+			configWithNotifications.Notifications = atc.NotificationConfigs{
+				{
+					Name: "slack",
+					Webhook: atc.NotificationWebhook{
+						URL:     "((slack-url))",
+						Headers: map[string]string{"X-Token": "((token))"},
+					},
+					Jobs:        []string{"*"},
+					Statuses:    []atc.BuildStatus{atc.StatusFailed},
+					Transitions: []atc.NotificationTransition{{From: atc.StatusFailed, To: atc.StatusSucceeded}},
+				},
+			}
+
+			pipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "pipeline-with-notifications"}, configWithNotifications, 0, false, "some-user")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pipeline.Notifications()).To(Equal(configWithNotifications.Notifications))
+
+			config, err := pipeline.Config()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(config.Notifications).To(Equal(configWithNotifications.Notifications))
+		})
+	})
+
 	Describe("UserData", func() {
 		Context("when pipeline is created with user_data", func() {
 			It("preserves user_data as a map", func() {
@@ -2286,7 +2759,7 @@ var _ = Describe("Pipeline", func() {
 				configWithUserData := pipelineConfig
 				configWithUserData.UserData = userData
 
-				pipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "pipeline-with-userdata"}, configWithUserData, 0, false)
+				pipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "pipeline-with-userdata"}, configWithUserData, 0, false, "some-user")
 				Expect(err).ToNot(HaveOccurred())
 
 				config, err := pipeline.Config()
@@ -2304,7 +2777,7 @@ var _ = Describe("Pipeline", func() {
 				configWithUserData := pipelineConfig
 				configWithUserData.UserData = "simple metadata"
 
-				pipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "pipeline-with-string-userdata"}, configWithUserData, 0, false)
+				pipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "pipeline-with-string-userdata"}, configWithUserData, 0, false, "some-user")
 				Expect(err).ToNot(HaveOccurred())
 
 				config, err := pipeline.Config()
@@ -2316,7 +2789,7 @@ var _ = Describe("Pipeline", func() {
 				configWithUserData := pipelineConfig
 				configWithUserData.UserData = []any{"tag1", "tag2", "tag3"}
 
-				pipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "pipeline-with-array-userdata"}, configWithUserData, 0, false)
+				pipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "pipeline-with-array-userdata"}, configWithUserData, 0, false, "some-user")
 				Expect(err).ToNot(HaveOccurred())
 
 				config, err := pipeline.Config()
@@ -2331,7 +2804,7 @@ var _ = Describe("Pipeline", func() {
 
 		Context("when pipeline is created without user_data", func() {
 			It("returns nil for user_data", func() {
-				pipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "pipeline-without-userdata"}, pipelineConfig, 0, false)
+				pipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "pipeline-without-userdata"}, pipelineConfig, 0, false, "some-user")
 				Expect(err).ToNot(HaveOccurred())
 
 				config, err := pipeline.Config()
@@ -2346,7 +2819,7 @@ var _ = Describe("Pipeline", func() {
 				configWithUserData := pipelineConfig
 				configWithUserData.UserData = initialUserData
 
-				pipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "pipeline-update-userdata"}, configWithUserData, 0, false)
+				pipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "pipeline-update-userdata"}, configWithUserData, 0, false, "some-user")
 				Expect(err).ToNot(HaveOccurred())
 
 				// Update with new user_data
@@ -2356,7 +2829,7 @@ var _ = Describe("Pipeline", func() {
 				}
 				configWithUserData.UserData = updatedUserData
 
-				pipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "pipeline-update-userdata"}, configWithUserData, pipeline.ConfigVersion(), false)
+				pipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "pipeline-update-userdata"}, configWithUserData, pipeline.ConfigVersion(), false, "some-user")
 				Expect(err).ToNot(HaveOccurred())
 
 				config, err := pipeline.Config()
@@ -56,6 +56,7 @@ var _ = Describe("Prototype", func() {
 			},
 			0,
 			false,
+			"some-user",
 		)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(created).To(BeTrue())
@@ -125,6 +126,7 @@ var _ = Describe("Prototype", func() {
 					},
 					pipeline.ConfigVersion(),
 					false,
+					"some-user",
 				)
 				Expect(err).ToNot(HaveOccurred())
 				Expect(created).To(BeFalse())
@@ -94,6 +94,7 @@ type Resource interface {
 	APIPinnedVersion() atc.Version
 	PinComment() string
 	SetPinComment(string) error
+	RecordWebhookDelivery(deliveryID string, window time.Duration) (bool, error)
 	ResourceConfigID() int
 	ResourceConfigScopeID() int
 	Icon() string
@@ -243,7 +244,7 @@ func (r *resource) ResourceConfigID() int            { return r.resourceConfigID
 func (r *resource) ResourceConfigScopeID() int       { return r.resourceConfigScopeID }
 func (r *resource) Icon() string                     { return r.config.Icon }
 
-func (r *resource) HasWebhook() bool { return r.WebhookToken() != "" }
+func (r *resource) HasWebhook() bool { return r.WebhookToken() != "" || r.config.Webhook != nil }
 
 func (r *resource) Reload() (bool, error) {
 	row := resourcesQuery.Where(sq.Eq{"r.id": r.id}).
@@ -476,6 +477,48 @@ func (r *resource) SetPinComment(comment string) error {
 	return err
 }
 
+// RecordWebhookDelivery records the ID of a webhook delivery, returning false
+// if a delivery with the same ID was already recorded within the window.
+func (r *resource) RecordWebhookDelivery(deliveryID string, window time.Duration) (bool, error) {
+	tx, err := r.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	defer Rollback(tx)
+
+	_, err = psql.Delete("resource_webhook_deliveries").
+		Where(sq.Eq{"resource_id": r.id}).
+		Where(sq.Expr(fmt.Sprintf("created_at < now() - '%d seconds'::interval", int(window.Seconds())))).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	result, err := psql.Insert("resource_webhook_deliveries").
+		Columns("resource_id", "delivery_id").
+		Values(r.id, deliveryID).
+		Suffix("ON CONFLICT DO NOTHING").
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected == 1, nil
+}
+
 func (r *resource) CurrentPinnedVersion() atc.Version {
 	if r.configPinnedVersion != nil {
 		return r.configPinnedVersion
@@ -61,6 +61,7 @@ var _ = Describe("ResourceConfig", func() {
 				},
 				0,
 				false,
+				"some-user",
 			)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(created).To(BeTrue())
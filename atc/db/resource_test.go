@@ -79,6 +79,7 @@ var _ = Describe("Resource", func() {
 			},
 			0,
 			false,
+			"some-user",
 		)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(created).To(BeTrue())
@@ -425,6 +426,7 @@ var _ = Describe("Resource", func() {
 				config,
 				0,
 				false,
+				"some-user",
 			)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(created).To(BeTrue())
@@ -1462,6 +1464,35 @@ var _ = Describe("Resource", func() {
 		})
 	})
 
+	Describe("RecordWebhookDelivery", func() {
+		It("only records a delivery once within the window", func() {
+			recorded, err := defaultResource.RecordWebhookDelivery("some-delivery", time.Hour)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(recorded).To(BeTrue())
+
+			recorded, err = defaultResource.RecordWebhookDelivery("some-delivery", time.Hour)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(recorded).To(BeFalse())
+
+			recorded, err = defaultResource.RecordWebhookDelivery("other-delivery", time.Hour)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(recorded).To(BeTrue())
+		})
+
+		It("forgets deliveries older than the window", func() {
+			recorded, err := defaultResource.RecordWebhookDelivery("some-delivery", time.Hour)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(recorded).To(BeTrue())
+
+			_, err = dbConn.Exec(`UPDATE resource_webhook_deliveries SET created_at = now() - interval '2 hours'`)
+			Expect(err).ToNot(HaveOccurred())
+
+			recorded, err = defaultResource.RecordWebhookDelivery("some-delivery", time.Hour)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(recorded).To(BeTrue())
+		})
+	})
+
 	Describe("CheckPlan", func() {
 		var (
 			createdCheckPlan                      atc.Plan
@@ -1479,7 +1510,7 @@ var _ = Describe("Resource", func() {
 		})
 
 		setupCheckPlan := func(pipelineName string, config atc.Config, resourceName string, sourceDefault atc.Source, resourceTypes atc.ResourceTypes) {
-			pipeline, created, err := defaultTeam.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, false)
+			pipeline, created, err := defaultTeam.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 			Expect(created).To(BeTrue())
 
@@ -57,6 +57,7 @@ var _ = Describe("ResourceType", func() {
 			},
 			0,
 			false,
+			"some-user",
 		)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(created).To(BeTrue())
@@ -129,6 +130,7 @@ var _ = Describe("ResourceType", func() {
 					},
 					pipeline.ConfigVersion(),
 					false,
+					"some-user",
 				)
 				Expect(err).ToNot(HaveOccurred())
 				Expect(created).To(BeFalse())
@@ -168,6 +170,7 @@ var _ = Describe("ResourceType", func() {
 					},
 					pipeline.ConfigVersion(),
 					false,
+					"some-user",
 				)
 				Expect(err).ToNot(HaveOccurred())
 				Expect(created).To(BeFalse())
@@ -207,6 +210,7 @@ var _ = Describe("ResourceType", func() {
 					},
 					db.ConfigVersion(0),
 					false,
+					"some-user",
 				)
 				Expect(err).ToNot(HaveOccurred())
 				Expect(created).To(BeTrue())
@@ -262,6 +266,7 @@ var _ = Describe("ResourceType", func() {
 					},
 					pipeline.ConfigVersion(),
 					false,
+					"some-user",
 				)
 				Expect(err).ToNot(HaveOccurred())
 				Expect(created).To(BeFalse())
@@ -316,6 +321,7 @@ var _ = Describe("ResourceType", func() {
 					},
 					pipeline.ConfigVersion(),
 					false,
+					"some-user",
 				)
 				Expect(err).ToNot(HaveOccurred())
 				Expect(created).To(BeFalse())
@@ -592,7 +598,7 @@ var _ = Describe("ResourceType", func() {
 		})
 
 		setupCheckPlan := func(pipelineName string, config atc.Config, resourceTypeName string, sourceDefault atc.Source, resourceTypes atc.ResourceTypes) {
-			pipeline, created, err := defaultTeam.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, false)
+			pipeline, created, err := defaultTeam.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 			Expect(created).To(BeTrue())
 
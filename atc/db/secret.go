@@ -0,0 +1,158 @@
+package db
+
+import (
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// Secret is a value stored in the database for use by the postgres credential
+// manager. Secrets without a PipelineName are visible to every pipeline of the
+// team. The value itself is never loaded when listing secrets.
+type Secret struct {
+	Name         string
+	PipelineName string
+	UpdatedBy    string
+	UpdatedAt    time.Time
+}
+
+//counterfeiter:generate . SecretFactory
+type SecretFactory interface {
+	Secret(teamName string, pipelineName string, name string) (string, bool, error)
+}
+
+type secretFactory struct {
+	conn DbConn
+}
+
+func NewSecretFactory(conn DbConn) SecretFactory {
+	return &secretFactory{
+		conn: conn,
+	}
+}
+
+// Secret returns the decrypted value of a secret. Pass an empty pipelineName
+// to look up a team-scoped secret.
+func (f *secretFactory) Secret(teamName string, pipelineName string, name string) (string, bool, error) {
+	var (
+		value string
+		nonce sql.NullString
+	)
+
+	err := psql.Select("s.value", "s.nonce").
+		From("secrets s").
+		Join("teams t ON t.id = s.team_id").
+		Where(sq.Eq{
+			"t.name":          teamName,
+			"s.pipeline_name": pipelineName,
+			"s.name":          name,
+		}).
+		RunWith(f.conn).
+		QueryRow().
+		Scan(&value, &nonce)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", false, nil
+		}
+
+		return "", false, err
+	}
+
+	var noncense *string
+	if nonce.Valid {
+		noncense = &nonce.String
+	}
+
+	decrypted, err := f.conn.EncryptionStrategy().Decrypt(value, noncense)
+	if err != nil {
+		return "", false, err
+	}
+
+	return string(decrypted), true, nil
+}
+
+func (t *team) Secrets() ([]Secret, error) {
+	rows, err := psql.Select("name", "pipeline_name", "updated_by", "updated_at").
+		From("secrets").
+		Where(sq.Eq{"team_id": t.id}).
+		OrderBy("pipeline_name", "name").
+		RunWith(t.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	var secrets []Secret
+	for rows.Next() {
+		var (
+			secret    Secret
+			updatedBy sql.NullString
+		)
+
+		err = rows.Scan(&secret.Name, &secret.PipelineName, &updatedBy, &secret.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		secret.UpdatedBy = updatedBy.String
+
+		secrets = append(secrets, secret)
+	}
+
+	return secrets, rows.Err()
+}
+
+// SetSecret creates or replaces a secret. Pass an empty pipelineName to set a
+// secret for the whole team.
+func (t *team) SetSecret(pipelineName string, name string, value string, setBy string) error {
+	encryptedValue, nonce, err := t.conn.EncryptionStrategy().Encrypt([]byte(value))
+	if err != nil {
+		return err
+	}
+
+	updatedBy := sql.NullString{String: setBy, Valid: setBy != ""}
+
+	_, err = psql.Insert("secrets").
+		SetMap(map[string]any{
+			"team_id":       t.id,
+			"pipeline_name": pipelineName,
+			"name":          name,
+			"value":         encryptedValue,
+			"nonce":         nonce,
+			"updated_by":    updatedBy,
+		}).
+		Suffix(`
+			ON CONFLICT (team_id, pipeline_name, name) DO UPDATE SET
+				value = EXCLUDED.value,
+				nonce = EXCLUDED.nonce,
+				updated_by = EXCLUDED.updated_by,
+				updated_at = now()
+		`).
+		RunWith(t.conn).
+		Exec()
+	return err
+}
+
+func (t *team) DeleteSecret(pipelineName string, name string) (bool, error) {
+	result, err := psql.Delete("secrets").
+		Where(sq.Eq{
+			"team_id":       t.id,
+			"pipeline_name": pipelineName,
+			"name":          name,
+		}).
+		RunWith(t.conn).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
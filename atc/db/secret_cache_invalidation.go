@@ -0,0 +1,69 @@
+package db
+
+import (
+	"encoding/json"
+
+	"code.cloudfoundry.org/lager/v3"
+	"github.com/concourse/concourse/atc/creds"
+)
+
+const secretCacheInvalidationChannel = "secret_cache_invalidations"
+
+//counterfeiter:generate . SecretCacheInvalidator
+type SecretCacheInvalidator interface {
+	// Invalidate asks every web node to remove the matching secrets from
+	// their caches.
+	Invalidate(creds.SecretCacheInvalidation) error
+}
+
+type secretCacheInvalidator struct {
+	conn DbConn
+}
+
+func NewSecretCacheInvalidator(conn DbConn) SecretCacheInvalidator {
+	return &secretCacheInvalidator{
+		conn: conn,
+	}
+}
+
+func (i *secretCacheInvalidator) Invalidate(invalidation creds.SecretCacheInvalidation) error {
+	payload, err := json.Marshal(invalidation)
+	if err != nil {
+		return err
+	}
+
+	_, err = i.conn.Exec("SELECT pg_notify($1, $2)", secretCacheInvalidationChannel, string(payload))
+	return err
+}
+
+// ListenForSecretCacheInvalidations calls invalidate with every invalidation
+// made by any web node, including this one. If the connection to the
+// database is lost, invalidations may have been missed, so everything is
+// invalidated.
+func ListenForSecretCacheInvalidations(logger lager.Logger, conn DbConn, invalidate func(creds.SecretCacheInvalidation)) error {
+	notifications, err := conn.Bus().Listen(secretCacheInvalidationChannel, 16)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		for notification := range notifications {
+			if !notification.Healthy {
+				logger.Info("notification-unhealthy-will-invalidate-everything")
+				invalidate(creds.SecretCacheInvalidation{})
+				continue
+			}
+
+			var invalidation creds.SecretCacheInvalidation
+			err := json.Unmarshal([]byte(notification.Payload), &invalidation)
+			if err != nil {
+				logger.Error("invalid-payload", err)
+				continue
+			}
+
+			invalidate(invalidation)
+		}
+	}()
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package db_test
+
+import (
+	"code.cloudfoundry.org/lager/v3/lagertest"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SecretCacheInvalidator", func() {
+	var invalidations chan creds.SecretCacheInvalidation
+
+	BeforeEach(func() {
+		invalidations = make(chan creds.SecretCacheInvalidation, 1)
+
+		err := db.ListenForSecretCacheInvalidations(lagertest.NewTestLogger("test"), dbConn, func(invalidation creds.SecretCacheInvalidation) {
+			invalidations <- invalidation
+		})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("notifies the listeners of the invalidation", func() {
+		err := db.NewSecretCacheInvalidator(dbConn).Invalidate(creds.SecretCacheInvalidation{Team: "some-team", Var: "some-var"})
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(invalidations).Should(Receive(Equal(creds.SecretCacheInvalidation{Team: "some-team", Var: "some-var"})))
+	})
+})
@@ -0,0 +1,94 @@
+package db_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Secrets", func() {
+	var (
+		team          db.Team
+		secretFactory db.SecretFactory
+	)
+
+	BeforeEach(func() {
+		var err error
+		team, err = teamFactory.CreateTeam(atc.Team{Name: "some-secret-team"})
+		Expect(err).ToNot(HaveOccurred())
+
+		secretFactory = db.NewSecretFactory(dbConn)
+
+		err = team.SetSecret("", "some-secret", "some-team-value", "some-user")
+		Expect(err).ToNot(HaveOccurred())
+
+		err = team.SetSecret("some-pipeline", "some-secret", "some-pipeline-value", "some-user")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("looks up secrets by their scope", func() {
+		value, found, err := secretFactory.Secret("some-secret-team", "", "some-secret")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(value).To(Equal("some-team-value"))
+
+		value, found, err = secretFactory.Secret("some-secret-team", "some-pipeline", "some-secret")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(value).To(Equal("some-pipeline-value"))
+
+		_, found, err = secretFactory.Secret("some-secret-team", "other-pipeline", "some-secret")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeFalse())
+
+		_, found, err = secretFactory.Secret(defaultTeam.Name(), "", "some-secret")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeFalse())
+	})
+
+	It("lists the secrets of the team without their values", func() {
+		secrets, err := team.Secrets()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(secrets).To(HaveLen(2))
+
+		Expect(secrets[0].Name).To(Equal("some-secret"))
+		Expect(secrets[0].PipelineName).To(BeEmpty())
+		Expect(secrets[0].UpdatedBy).To(Equal("some-user"))
+		Expect(secrets[1].PipelineName).To(Equal("some-pipeline"))
+
+		secrets, err = defaultTeam.Secrets()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(secrets).To(BeEmpty())
+	})
+
+	It("replaces the value of an existing secret", func() {
+		err := team.SetSecret("", "some-secret", "some-new-value", "some-other-user")
+		Expect(err).ToNot(HaveOccurred())
+
+		value, found, err := secretFactory.Secret("some-secret-team", "", "some-secret")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(value).To(Equal("some-new-value"))
+
+		secrets, err := team.Secrets()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(secrets).To(HaveLen(2))
+		Expect(secrets[0].UpdatedBy).To(Equal("some-other-user"))
+	})
+
+	It("deletes secrets", func() {
+		found, err := team.DeleteSecret("some-pipeline", "some-secret")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+
+		_, found, err = secretFactory.Secret("some-secret-team", "some-pipeline", "some-secret")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeFalse())
+
+		found, err = team.DeleteSecret("some-pipeline", "some-secret")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeFalse())
+	})
+})
@@ -0,0 +1,393 @@
+package db
+
+import (
+	"cmp"
+	"database/sql"
+	"encoding/json"
+	"slices"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/vars"
+)
+
+const (
+	varReferenceJob          = "job"
+	varReferenceResource     = "resource"
+	varReferenceResourceType = "resource_type"
+	varReferencePrototype    = "prototype"
+	varReferenceVarSource    = "var_source"
+)
+
+//counterfeiter:generate . SecretUsageFactory
+type SecretUsageFactory interface {
+	// AllSecretUsages reports where the var is used across every team.
+	AllSecretUsages(varName string) ([]atc.SecretUsage, error)
+
+	// VisibleSecretUsages reports where the var is used by the given teams.
+	VisibleSecretUsages(varName string, teamNames []string) ([]atc.SecretUsage, error)
+}
+
+type secretUsageFactory struct {
+	conn DbConn
+}
+
+func NewSecretUsageFactory(conn DbConn) SecretUsageFactory {
+	return &secretUsageFactory{
+		conn: conn,
+	}
+}
+
+func (f *secretUsageFactory) AllSecretUsages(varName string) ([]atc.SecretUsage, error) {
+	return f.secretUsages(varName, nil)
+}
+
+func (f *secretUsageFactory) VisibleSecretUsages(varName string, teamNames []string) ([]atc.SecretUsage, error) {
+	return f.secretUsages(varName, sq.Eq{"t.name": teamNames})
+}
+
+type secretUsageKey struct {
+	teamName   string
+	pipelineID int
+	varName    string
+}
+
+func (f *secretUsageFactory) secretUsages(varName string, teamFilter sq.Sqlizer) ([]atc.SecretUsage, error) {
+	usages := map[secretUsageKey]*atc.SecretUsage{}
+
+	usage := func(teamName string, pipelineID sql.NullInt64, pipelineName sql.NullString, instanceVars sql.NullString, varName string) (*atc.SecretUsage, error) {
+		key := secretUsageKey{teamName, int(pipelineID.Int64), varName}
+		if existing, found := usages[key]; found {
+			return existing, nil
+		}
+
+		u := &atc.SecretUsage{
+			Var:          varName,
+			TeamName:     teamName,
+			PipelineID:   int(pipelineID.Int64),
+			PipelineName: pipelineName.String,
+		}
+
+		if instanceVars.Valid {
+			err := json.Unmarshal([]byte(instanceVars.String), &u.PipelineInstanceVars)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		usages[key] = u
+		return u, nil
+	}
+
+	referencesQuery := psql.Select("t.name", "p.id", "p.name", "p.instance_vars", "r.var_name", "r.kind", "r.name").
+		From("pipeline_var_references r").
+		Join("pipelines p ON p.id = r.pipeline_id").
+		Join("teams t ON t.id = p.team_id").
+		Where(varNameMatches("r.var_name", varName)).
+		OrderBy("r.kind", "r.name")
+	if teamFilter != nil {
+		referencesQuery = referencesQuery.Where(teamFilter)
+	}
+
+	rows, err := referencesQuery.RunWith(f.conn).Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	for rows.Next() {
+		var (
+			teamName, usedVar, kind, name string
+			pipelineID                    sql.NullInt64
+			pipelineName, instanceVars    sql.NullString
+		)
+
+		err = rows.Scan(&teamName, &pipelineID, &pipelineName, &instanceVars, &usedVar, &kind, &name)
+		if err != nil {
+			return nil, err
+		}
+
+		u, err := usage(teamName, pipelineID, pipelineName, instanceVars, usedVar)
+		if err != nil {
+			return nil, err
+		}
+
+		switch kind {
+		case varReferenceJob:
+			u.Jobs = append(u.Jobs, name)
+		case varReferenceResource:
+			u.Resources = append(u.Resources, name)
+		case varReferenceResourceType:
+			u.ResourceTypes = append(u.ResourceTypes, name)
+		case varReferencePrototype:
+			u.Prototypes = append(u.Prototypes, name)
+		case varReferenceVarSource:
+			u.VarSources = append(u.VarSources, name)
+		}
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	resolutionsQuery := psql.Select("t.name", "p.id", "p.name", "p.instance_vars", "v.var_name", "j.name", "v.build_id", "v.build_name", "v.resolved_at").
+		From("build_var_resolutions v").
+		Join("teams t ON t.id = v.team_id").
+		LeftJoin("pipelines p ON p.id = v.pipeline_id").
+		LeftJoin("jobs j ON j.id = v.job_id").
+		Where(varNameMatches("v.var_name", varName)).
+		OrderBy("v.resolved_at")
+	if teamFilter != nil {
+		resolutionsQuery = resolutionsQuery.Where(teamFilter)
+	}
+
+	rows, err = resolutionsQuery.RunWith(f.conn).Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	for rows.Next() {
+		var (
+			teamName, usedVar, buildName string
+			pipelineID, buildID          sql.NullInt64
+			pipelineName, instanceVars   sql.NullString
+			jobName                      sql.NullString
+			resolvedAt                   time.Time
+		)
+
+		err = rows.Scan(&teamName, &pipelineID, &pipelineName, &instanceVars, &usedVar, &jobName, &buildID, &buildName, &resolvedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		u, err := usage(teamName, pipelineID, pipelineName, instanceVars, usedVar)
+		if err != nil {
+			return nil, err
+		}
+
+		// jobs can resolve vars which their pipeline's config doesn't
+		// reference, e.g. through task config files
+		if jobName.Valid && !slices.Contains(u.Jobs, jobName.String) {
+			u.Jobs = append(u.Jobs, jobName.String)
+			slices.Sort(u.Jobs)
+		}
+
+		// ordered by resolved_at, so the last row is the latest
+		u.LastBuild = &atc.SecretUsageBuild{
+			ID:         int(buildID.Int64),
+			Name:       buildName,
+			JobName:    jobName.String,
+			ResolvedAt: resolvedAt.Unix(),
+		}
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	result := []atc.SecretUsage{}
+	for _, u := range usages {
+		result = append(result, *u)
+	}
+
+	slices.SortFunc(result, func(a, b atc.SecretUsage) int {
+		return cmp.Or(
+			cmp.Compare(a.TeamName, b.TeamName),
+			cmp.Compare(a.PipelineName, b.PipelineName),
+			cmp.Compare(a.PipelineID, b.PipelineID),
+			cmp.Compare(a.Var, b.Var),
+		)
+	})
+
+	return result, nil
+}
+
+// varNameMatches matches the var itself, vars which are fields of it, and
+// vars which it is a field of, so that looking up github.token finds
+// ((github)) and looking up github finds ((github.token)).
+func varNameMatches(column string, varName string) sq.Sqlizer {
+	return sq.Or{
+		sq.Eq{column: varName},
+		sq.Expr("left("+column+", ?) = ?", len(varName)+1, varName+"."),
+		sq.Expr("left(?, length("+column+") + 1) = "+column+" || '.'", varName),
+	}
+}
+
+// SaveResolvedVars records the vars which the build resolved from credential
+// managers and var sources, replacing the last build recorded for its job.
+// Values are not recorded.
+func (b *build) SaveResolvedVars(refs []vars.Reference) error {
+	if len(refs) == 0 {
+		return nil
+	}
+
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	for _, ref := range refs {
+		if ref.Source == "." {
+			continue
+		}
+
+		_, err = psql.Insert("build_var_resolutions").
+			SetMap(map[string]any{
+				"var_name":    ref.String(),
+				"team_id":     b.teamID,
+				"pipeline_id": sql.NullInt64{Int64: int64(b.pipelineID), Valid: b.pipelineID != 0},
+				"job_id":      sql.NullInt64{Int64: int64(b.jobID), Valid: b.jobID != 0},
+				"build_id":    b.id,
+				"build_name":  b.name,
+			}).
+			Suffix(`
+				ON CONFLICT (var_name, team_id, COALESCE(pipeline_id, 0), COALESCE(job_id, 0)) DO UPDATE SET
+					build_id = EXCLUDED.build_id,
+					build_name = EXCLUDED.build_name,
+					resolved_at = now()
+			`).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func saveVarReferences(tx Tx, pipelineID int, config atc.Config) error {
+	_, err := psql.Delete("pipeline_var_references").
+		Where(sq.Eq{"pipeline_id": pipelineID}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	type reference struct {
+		kind, name, varName string
+	}
+
+	var references []reference
+	collect := func(kind string, name string, config any) error {
+		varNames, err := configVarNames(config)
+		if err != nil {
+			return err
+		}
+
+		for _, varName := range varNames {
+			references = append(references, reference{kind, name, varName})
+		}
+
+		return nil
+	}
+
+	for _, job := range config.Jobs {
+		err = collect(varReferenceJob, job.Name, job)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, resource := range config.Resources {
+		err = collect(varReferenceResource, resource.Name, resource)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, resourceType := range config.ResourceTypes {
+		err = collect(varReferenceResourceType, resourceType.Name, resourceType)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, prototype := range config.Prototypes {
+		err = collect(varReferencePrototype, prototype.Name, prototype)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, varSource := range config.VarSources {
+		err = collect(varReferenceVarSource, varSource.Name, varSource)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(references) == 0 {
+		return nil
+	}
+
+	insert := psql.Insert("pipeline_var_references").
+		Columns("pipeline_id", "var_name", "kind", "name").
+		Suffix("ON CONFLICT DO NOTHING")
+
+	for _, ref := range references {
+		insert = insert.Values(pipelineID, ref.varName, ref.kind, ref.name)
+	}
+
+	_, err = insert.RunWith(tx).Exec()
+	return err
+}
+
+// configVarNames returns the vars referenced anywhere in the config, leaving
+// out local vars.
+func configVarNames(config any) ([]string, error) {
+	payload, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+
+	var node any
+	err = json.Unmarshal(payload, &node)
+	if err != nil {
+		return nil, err
+	}
+
+	names := map[string]bool{}
+	collectVarNames(node, names)
+
+	var result []string
+	for name := range names {
+		result = append(result, name)
+	}
+
+	slices.Sort(result)
+
+	return result, nil
+}
+
+func collectVarNames(node any, names map[string]bool) {
+	switch typed := node.(type) {
+	case map[string]any:
+		for key, value := range typed {
+			collectVarNames(key, names)
+			collectVarNames(value, names)
+		}
+	case []any:
+		for _, value := range typed {
+			collectVarNames(value, names)
+		}
+	case string:
+		for _, name := range vars.NewTemplate([]byte(typed)).ExtraVarNames() {
+			ref, err := vars.ParseReference(name)
+			if err != nil || ref.Source == "." {
+				continue
+			}
+
+			names[ref.String()] = true
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package db_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/vars"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SecretUsageFactory", func() {
+	var (
+		secretUsageFactory db.SecretUsageFactory
+		pipeline           db.Pipeline
+	)
+
+	BeforeEach(func() {
+		secretUsageFactory = db.NewSecretUsageFactory(dbConn)
+
+		var err error
+		pipeline, _, err = defaultTeam.SavePipeline(atc.PipelineRef{Name: "usage-pipeline"}, atc.Config{
+			Jobs: atc.JobConfigs{
+				{
+					Name: "some-job",
+					PlanSequence: []atc.Step{
+						{
+							Config: &atc.TaskStep{
+								Name: "some-task",
+								Params: atc.TaskEnv{
+									"TOKEN": "((github.token))",
+									"LOCAL": "((.:local-var))",
+								},
+							},
+						},
+					},
+				},
+				{
+					Name: "other-job",
+				},
+			},
+			Resources: atc.ResourceConfigs{
+				{
+					Name: "some-repo",
+					Type: "git",
+					Source: atc.Source{
+						"password": "((github))",
+					},
+				},
+			},
+		}, db.ConfigVersion(0), false, "some-user")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("finds the config which references the var", func() {
+		usages, err := secretUsageFactory.AllSecretUsages("github.token")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(usages).To(Equal([]atc.SecretUsage{
+			{
+				Var:          "github",
+				TeamName:     defaultTeam.Name(),
+				PipelineID:   pipeline.ID(),
+				PipelineName: "usage-pipeline",
+				Resources:    []string{"some-repo"},
+			},
+			{
+				Var:          "github.token",
+				TeamName:     defaultTeam.Name(),
+				PipelineID:   pipeline.ID(),
+				PipelineName: "usage-pipeline",
+				Jobs:         []string{"some-job"},
+			},
+		}))
+	})
+
+	It("does not record local vars", func() {
+		usages, err := secretUsageFactory.AllSecretUsages(".:local-var")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(usages).To(BeEmpty())
+	})
+
+	It("replaces the references when the config is saved again", func() {
+		_, _, err := defaultTeam.SavePipeline(atc.PipelineRef{Name: "usage-pipeline"}, atc.Config{
+			Jobs: atc.JobConfigs{{Name: "some-job"}},
+		}, pipeline.ConfigVersion(), false, "some-user")
+		Expect(err).ToNot(HaveOccurred())
+
+		usages, err := secretUsageFactory.AllSecretUsages("github.token")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(usages).To(BeEmpty())
+	})
+
+	Context("when a build resolves the var", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			job, found, err := pipeline.Job("other-job")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			build, err = job.CreateBuild("some-user")
+			Expect(err).ToNot(HaveOccurred())
+
+			err = build.SaveResolvedVars([]vars.Reference{
+				{Path: "github", Fields: []string{"token"}},
+				{Source: ".", Path: "local-var"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("reports the job and the build", func() {
+			usages, err := secretUsageFactory.AllSecretUsages("github.token")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(usages).To(HaveLen(2))
+
+			Expect(usages[1].Jobs).To(Equal([]string{"other-job", "some-job"}))
+			Expect(usages[1].LastBuild).ToNot(BeNil())
+			Expect(usages[1].LastBuild.ID).To(Equal(build.ID()))
+			Expect(usages[1].LastBuild.Name).To(Equal(build.Name()))
+			Expect(usages[1].LastBuild.JobName).To(Equal("other-job"))
+		})
+
+		It("keeps only the last build of the job", func() {
+			job, _, err := pipeline.Job("other-job")
+			Expect(err).ToNot(HaveOccurred())
+
+			newerBuild, err := job.CreateBuild("some-user")
+			Expect(err).ToNot(HaveOccurred())
+
+			err = newerBuild.SaveResolvedVars([]vars.Reference{{Path: "github", Fields: []string{"token"}}})
+			Expect(err).ToNot(HaveOccurred())
+
+			usages, err := secretUsageFactory.AllSecretUsages("github.token")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(usages[1].LastBuild.ID).To(Equal(newerBuild.ID()))
+		})
+	})
+
+	Context("when a one-off build resolves the var", func() {
+		BeforeEach(func() {
+			build, err := defaultTeam.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			err = build.SaveResolvedVars([]vars.Reference{{Path: "github", Fields: []string{"token"}}})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("reports it without a pipeline", func() {
+			usages, err := secretUsageFactory.AllSecretUsages("github.token")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(usages).To(HaveLen(3))
+			Expect(usages[0].PipelineName).To(BeEmpty())
+			Expect(usages[0].LastBuild).ToNot(BeNil())
+		})
+	})
+
+	It("only reports the usages of visible teams", func() {
+		usages, err := secretUsageFactory.VisibleSecretUsages("github.token", []string{"some-other-team"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(usages).To(BeEmpty())
+
+		usages, err = secretUsageFactory.VisibleSecretUsages("github.token", []string{defaultTeam.Name()})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(usages).To(HaveLen(2))
+	})
+})
@@ -47,6 +47,7 @@ type Team interface {
 		config atc.Config,
 		from ConfigVersion,
 		initiallyPaused bool,
+		savedBy string,
 	) (Pipeline, bool, error)
 	RenamePipeline(oldName string, newName string) (bool, error)
 
@@ -56,6 +57,8 @@ type Team interface {
 	OrderPipelines([]string) error
 	OrderPipelinesWithinGroup(string, []atc.InstanceVars) error
 
+	ResourcesOfType(resourceType string) (Resources, error)
+
 	CreateOneOffBuild() (Build, error)
 	CreateStartedBuild(plan atc.Plan) (Build, error)
 
@@ -80,6 +83,14 @@ type Team interface {
 	FindWorkersForResourceCache(rcId int, shouldBeValidBefore time.Time) ([]Worker, error)
 
 	UpdateProviderAuth(auth atc.TeamAuth) error
+
+	VarSources() atc.VarSourceConfigs
+	UpdateVarSources(varSources atc.VarSourceConfigs) error
+	UpdateProviderAuthAndVarSources(auth atc.TeamAuth, varSources atc.VarSourceConfigs) error
+
+	Secrets() ([]Secret, error)
+	SetSecret(pipelineName string, name string, value string, setBy string) error
+	DeleteSecret(pipelineName string, name string) (bool, error)
 }
 
 type team struct {
@@ -91,6 +102,8 @@ type team struct {
 	admin bool
 
 	auth atc.TeamAuth
+
+	varSources atc.VarSourceConfigs
 }
 
 func (t *team) ID() int      { return t.id }
@@ -99,6 +112,8 @@ func (t *team) Admin() bool  { return t.admin }
 
 func (t *team) Auth() atc.TeamAuth { return t.auth }
 
+func (t *team) VarSources() atc.VarSourceConfigs { return t.varSources }
+
 func (t *team) Delete() error {
 	_, err := psql.Delete("teams").
 		Where(sq.Eq{
@@ -415,6 +430,7 @@ func savePipeline(
 	teamID int,
 	jobID sql.NullInt64,
 	buildID sql.NullInt64,
+	savedBy sql.NullString,
 ) (int, bool, error) {
 
 	var instanceVars sql.NullString
@@ -472,6 +488,14 @@ func savePipeline(
 		}
 	}
 
+	var notificationsPayload []byte
+	if len(config.Notifications) > 0 {
+		notificationsPayload, err = json.Marshal(config.Notifications)
+		if err != nil {
+			return 0, false, err
+		}
+	}
+
 	var pipelineID int
 	if !existingConfig {
 		values := map[string]any{
@@ -480,6 +504,7 @@ func savePipeline(
 			"var_sources":     encryptedVarSourcesPayload,
 			"display":         displayPayload,
 			"user_data":       userDataPayload,
+			"notifications":   notificationsPayload,
 			"nonce":           nonce,
 			"version":         sq.Expr("nextval('config_version_seq')"),
 			"paused":          initiallyPaused,
@@ -526,6 +551,7 @@ func savePipeline(
 			Set("var_sources", encryptedVarSourcesPayload).
 			Set("display", displayPayload).
 			Set("user_data", userDataPayload).
+			Set("notifications", notificationsPayload).
 			Set("nonce", nonce).
 			Set("version", sq.Expr("nextval('config_version_seq')")).
 			Set("last_updated", sq.Expr("now()")).
@@ -613,7 +639,7 @@ func savePipeline(
 		return 0, false, err
 	}
 
-	err = insertJobPipes(tx, config.Jobs, resourceNameToID, jobNameToID, pipelineID)
+	err = insertJobPipes(tx, config.Jobs, resourceNameToID, jobNameToID, pipelineID, teamID)
 	if err != nil {
 		return 0, false, err
 	}
@@ -623,6 +649,16 @@ func savePipeline(
 		return 0, false, err
 	}
 
+	err = saveVarReferences(tx, pipelineID, config)
+	if err != nil {
+		return 0, false, err
+	}
+
+	err = saveConfigRevision(tx, pipelineID, config, buildID, savedBy)
+	if err != nil {
+		return 0, false, err
+	}
+
 	return pipelineID, !existingConfig, nil
 }
 
@@ -631,6 +667,7 @@ func (t *team) SavePipeline(
 	config atc.Config,
 	from ConfigVersion,
 	initiallyPaused bool,
+	savedBy string,
 ) (Pipeline, bool, error) {
 	tx, err := t.conn.Begin()
 	if err != nil {
@@ -640,7 +677,7 @@ func (t *team) SavePipeline(
 	defer Rollback(tx)
 
 	nullID := sql.NullInt64{Valid: false}
-	pipelineID, isNewPipeline, err := savePipeline(tx, pipelineRef, config, from, initiallyPaused, t.id, nullID, nullID)
+	pipelineID, isNewPipeline, err := savePipeline(tx, pipelineRef, config, from, initiallyPaused, t.id, nullID, nullID, sql.NullString{String: savedBy, Valid: savedBy != ""})
 	if err != nil {
 		return nil, false, err
 	}
@@ -739,6 +776,25 @@ func (t *team) Pipelines() ([]Pipeline, error) {
 	return pipelines, nil
 }
 
+// ResourcesOfType returns the active resources of the given type across all of
+// the team's unarchived pipelines.
+func (t *team) ResourcesOfType(resourceType string) (Resources, error) {
+	rows, err := resourcesQuery.
+		Where(sq.Eq{
+			"t.id":       t.id,
+			"r.type":     resourceType,
+			"p.archived": false,
+		}).
+		OrderBy("r.id ASC").
+		RunWith(t.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	return scanResources(rows, t.conn, t.lockFactory)
+}
+
 func (t *team) PublicPipelines() ([]Pipeline, error) {
 	rows, err := pipelinesQuery.
 		Where(sq.Eq{
@@ -970,6 +1026,70 @@ func (t *team) UpdateProviderAuth(auth atc.TeamAuth) error {
 	}
 	defer Rollback(tx)
 
+	err = t.updateProviderAuth(tx, auth)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// UpdateVarSources replaces the var sources which the team's pipelines
+// inherit.
+func (t *team) UpdateVarSources(varSources atc.VarSourceConfigs) error {
+	tx, err := t.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	err = t.updateVarSources(tx, varSources)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	t.varSources = varSources
+
+	return nil
+}
+
+// UpdateProviderAuthAndVarSources updates the team's auth and var sources
+// together, so that neither is saved if the other fails.
+func (t *team) UpdateProviderAuthAndVarSources(auth atc.TeamAuth, varSources atc.VarSourceConfigs) error {
+	tx, err := t.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	err = t.updateProviderAuth(tx, auth)
+	if err != nil {
+		return err
+	}
+
+	err = t.updateVarSources(tx, varSources)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	t.varSources = varSources
+
+	return nil
+}
+
+func (t *team) updateProviderAuth(tx Tx, auth atc.TeamAuth) error {
 	jsonEncodedProviderAuth, err := json.Marshal(auth)
 	if err != nil {
 		return err
@@ -981,12 +1101,69 @@ func (t *team) UpdateProviderAuth(auth atc.TeamAuth) error {
 		WHERE id = $2
 		RETURNING id, name, admin, auth, nonce
 	`
-	err = t.queryTeam(tx, query, jsonEncodedProviderAuth, t.id)
+	return t.queryTeam(tx, query, jsonEncodedProviderAuth, t.id)
+}
+
+func (t *team) updateVarSources(tx Tx, varSources atc.VarSourceConfigs) error {
+	payload, nonce, err := encryptVarSources(tx.EncryptionStrategy(), varSources)
 	if err != nil {
 		return err
 	}
 
-	return tx.Commit()
+	_, err = psql.Update("teams").
+		Set("var_sources", payload).
+		Set("var_sources_nonce", nonce).
+		Where(sq.Eq{"id": t.id}).
+		RunWith(tx).
+		Exec()
+	return err
+}
+
+func encryptVarSources(es encryption.Strategy, varSources atc.VarSourceConfigs) (sql.NullString, sql.NullString, error) {
+	if len(varSources) == 0 {
+		return sql.NullString{}, sql.NullString{}, nil
+	}
+
+	payload, err := json.Marshal(varSources)
+	if err != nil {
+		return sql.NullString{}, sql.NullString{}, err
+	}
+
+	encrypted, nonce, err := es.Encrypt(payload)
+	if err != nil {
+		return sql.NullString{}, sql.NullString{}, err
+	}
+
+	var nullableNonce sql.NullString
+	if nonce != nil {
+		nullableNonce = sql.NullString{String: *nonce, Valid: true}
+	}
+
+	return sql.NullString{String: encrypted, Valid: true}, nullableNonce, nil
+}
+
+func decryptVarSources(es encryption.Strategy, payload sql.NullString, nonce sql.NullString) (atc.VarSourceConfigs, error) {
+	if !payload.Valid {
+		return nil, nil
+	}
+
+	var noncePtr *string
+	if nonce.Valid {
+		noncePtr = &nonce.String
+	}
+
+	decrypted, err := es.Decrypt(payload.String, noncePtr)
+	if err != nil {
+		return nil, err
+	}
+
+	var varSources atc.VarSourceConfigs
+	err = json.Unmarshal(decrypted, &varSources)
+	if err != nil {
+		return nil, err
+	}
+
+	return varSources, nil
 }
 
 func (t *team) FindCheckContainers(logger lager.Logger, pipelineRef atc.PipelineRef, resourceName string) ([]Container, map[int]time.Time, error) {
@@ -1230,11 +1407,36 @@ func saveJob(tx Tx, job atc.JobConfig, pipelineID int, groups []string) (int, er
 		return 0, err
 	}
 
+	var concurrencyGroup, concurrencyGroupScope sql.NullString
+	if job.ConcurrencyGroup != nil {
+		concurrencyGroup = sql.NullString{String: job.ConcurrencyGroup.Name, Valid: true}
+		concurrencyGroupScope = sql.NullString{String: job.ConcurrencyGroup.ScopeName(), Valid: true}
+	}
+
+	var triggerSchedule sql.NullString
+	var nextScheduledTrigger sql.NullTime
+	if job.Schedule != nil {
+		schedulePayload, err := json.Marshal(job.Schedule)
+		if err != nil {
+			return 0, err
+		}
+
+		nextTrigger, err := job.Schedule.NextTrigger(time.Now())
+		if err != nil {
+			return 0, err
+		}
+
+		triggerSchedule = sql.NullString{String: string(schedulePayload), Valid: true}
+		nextScheduledTrigger = sql.NullTime{Time: nextTrigger, Valid: true}
+	}
+
+	// the next trigger of an unchanged schedule is kept, so that saving the
+	// pipeline doesn't postpone or re-roll the jitter of the next build
 	var jobID int
 	err = psql.Insert("jobs").
-		Columns("name", "pipeline_id", "config", "public", "max_in_flight", "disable_manual_trigger", "disable_reruns", "interruptible", "active", "nonce", "tags").
-		Values(job.Name, pipelineID, encryptedPayload, job.Public, job.MaxInFlight(), job.DisableManualTrigger, job.DisableReruns, job.Interruptible, true, nonce, groups).
-		Suffix("ON CONFLICT (name, pipeline_id) DO UPDATE SET config = EXCLUDED.config, public = EXCLUDED.public, max_in_flight = EXCLUDED.max_in_flight, disable_manual_trigger = EXCLUDED.disable_manual_trigger, disable_reruns = EXCLUDED.disable_reruns, interruptible = EXCLUDED.interruptible, active = EXCLUDED.active, nonce = EXCLUDED.nonce, tags = EXCLUDED.tags").
+		Columns("name", "pipeline_id", "config", "public", "max_in_flight", "disable_manual_trigger", "disable_reruns", "interruptible", "active", "nonce", "tags", "concurrency_group", "concurrency_group_scope", "trigger_schedule", "next_scheduled_trigger", "priority").
+		Values(job.Name, pipelineID, encryptedPayload, job.Public, job.MaxInFlight(), job.DisableManualTrigger, job.DisableReruns, job.Interruptible, true, nonce, groups, concurrencyGroup, concurrencyGroupScope, triggerSchedule, nextScheduledTrigger, job.Priority).
+		Suffix("ON CONFLICT (name, pipeline_id) DO UPDATE SET config = EXCLUDED.config, public = EXCLUDED.public, max_in_flight = EXCLUDED.max_in_flight, disable_manual_trigger = EXCLUDED.disable_manual_trigger, disable_reruns = EXCLUDED.disable_reruns, interruptible = EXCLUDED.interruptible, active = EXCLUDED.active, nonce = EXCLUDED.nonce, tags = EXCLUDED.tags, concurrency_group = EXCLUDED.concurrency_group, concurrency_group_scope = EXCLUDED.concurrency_group_scope, trigger_schedule = EXCLUDED.trigger_schedule, priority = EXCLUDED.priority, next_scheduled_trigger = CASE WHEN jobs.trigger_schedule = EXCLUDED.trigger_schedule THEN jobs.next_scheduled_trigger ELSE EXCLUDED.next_scheduled_trigger END").
 		Suffix("RETURNING id").
 		RunWith(tx).
 		QueryRow().
@@ -1345,6 +1547,7 @@ func scanPipeline(p *pipeline, scan scannable) error {
 		varSources    sql.NullString
 		display       sql.NullString
 		userData      sql.NullString
+		notifications sql.NullString
 		nonce         sql.NullString
 		nonceStr      *string
 		lastUpdated   sql.NullTime
@@ -1354,7 +1557,7 @@ func scanPipeline(p *pipeline, scan scannable) error {
 		pausedBy      sql.NullString
 		pausedAt      sql.NullTime
 	)
-	err := scan.Scan(&p.id, &p.name, &groups, &varSources, &display, &userData, &nonce, &p.configVersion, &p.teamID, &p.teamName, &p.paused, &p.public, &p.archived, &lastUpdated, &parentJobID, &parentBuildID, &instanceVars, &pausedBy, &pausedAt)
+	err := scan.Scan(&p.id, &p.name, &groups, &varSources, &display, &userData, &notifications, &nonce, &p.configVersion, &p.teamID, &p.teamName, &p.paused, &p.public, &p.archived, &lastUpdated, &parentJobID, &parentBuildID, &instanceVars, &pausedBy, &pausedAt, &p.teamVarSources, &p.teamVarSourcesNonce)
 	if err != nil {
 		return err
 	}
@@ -1397,6 +1600,16 @@ func scanPipeline(p *pipeline, scan scannable) error {
 		p.userData = userDataObj
 	}
 
+	if notifications.Valid {
+		var notificationConfigs atc.NotificationConfigs
+		err = json.Unmarshal([]byte(notifications.String), &notificationConfigs)
+		if err != nil {
+			return err
+		}
+
+		p.notifications = notificationConfigs
+	}
+
 	if varSources.Valid {
 		var pipelineVarSources atc.VarSourceConfigs
 		decryptedVarSource, err := p.conn.EncryptionStrategy().Decrypt(varSources.String, nonceStr)
@@ -1779,7 +1992,7 @@ func saveJobsAndSerialGroups(tx Tx, jobs atc.JobConfigs, groups atc.GroupConfigs
 	return jobNameToID, nil
 }
 
-func insertJobPipes(tx Tx, jobConfigs atc.JobConfigs, resourceNameToID map[string]int, jobNameToID map[string]int, pipelineID int) error {
+func insertJobPipes(tx Tx, jobConfigs atc.JobConfigs, resourceNameToID map[string]int, jobNameToID map[string]int, pipelineID int, teamID int) error {
 	_, err := psql.Delete("job_inputs").
 		Where(sq.Expr(`job_id in (
         SELECT j.id
@@ -1807,7 +2020,7 @@ func insertJobPipes(tx Tx, jobConfigs atc.JobConfigs, resourceNameToID map[strin
 	for _, jobConfig := range jobConfigs {
 		err := jobConfig.StepConfig().Visit(atc.StepRecursor{
 			OnGet: func(step *atc.GetStep) error {
-				return insertJobInput(tx, step, jobConfig.Name, resourceNameToID, jobNameToID)
+				return insertJobInput(tx, step, jobConfig.Name, resourceNameToID, jobNameToID, teamID)
 			},
 			OnPut: func(step *atc.PutStep) error {
 				return insertJobOutput(tx, step, jobConfig.Name, resourceNameToID, jobNameToID)
@@ -1821,58 +2034,123 @@ func insertJobPipes(tx Tx, jobConfigs atc.JobConfigs, resourceNameToID map[strin
 	return nil
 }
 
-func insertJobInput(tx Tx, step *atc.GetStep, jobName string, resourceNameToID map[string]int, jobNameToID map[string]int) error {
-	if len(step.Passed) != 0 {
-		for _, passedJobGlob := range step.Passed {
-			for job, jobID := range jobNameToID {
-				matched, _ := path.Match(passedJobGlob, job)
-
-				if matched {
-					var version sql.NullString
-					if step.Version != nil {
-						versionJSON, err := step.Version.MarshalJSON()
-						if err != nil {
-							return err
-						}
-
-						version = sql.NullString{Valid: true, String: string(versionJSON)}
-					}
-
-					_, err := psql.Insert("job_inputs").
-						Columns("name", "job_id", "resource_id", "passed_job_id", "trigger", "version").
-						Values(step.Name, jobNameToID[jobName], resourceNameToID[step.ResourceName()], jobID, step.Trigger, version).
-						RunWith(tx).
-						Exec()
-					if err != nil {
-						return err
-					}
-				}
-			}
+func insertJobInput(tx Tx, step *atc.GetStep, jobName string, resourceNameToID map[string]int, jobNameToID map[string]int, teamID int) error {
+	var version sql.NullString
+	if step.Version != nil {
+		versionJSON, err := step.Version.MarshalJSON()
+		if err != nil {
+			return err
 		}
-	} else {
-		var version sql.NullString
-		if step.Version != nil {
-			versionJSON, err := step.Version.MarshalJSON()
-			if err != nil {
-				return err
-			}
 
-			version = sql.NullString{Valid: true, String: string(versionJSON)}
-		}
+		version = sql.NullString{Valid: true, String: string(versionJSON)}
+	}
 
+	if len(step.Passed) == 0 {
 		_, err := psql.Insert("job_inputs").
 			Columns("name", "job_id", "resource_id", "trigger", "version").
 			Values(step.Name, jobNameToID[jobName], resourceNameToID[step.ResourceName()], step.Trigger, version).
 			RunWith(tx).
 			Exec()
+		return err
+	}
+
+	for _, passedJobGlob := range step.Passed {
+		passedJobs, err := findPassedJobs(tx, teamID, passedJobGlob, jobNameToID)
 		if err != nil {
 			return err
 		}
+
+		for jobID, ref := range passedJobs {
+			_, err := psql.Insert("job_inputs").
+				Columns("name", "job_id", "resource_id", "passed_job_id", "passed_job_ref", "trigger", "version").
+				Values(step.Name, jobNameToID[jobName], resourceNameToID[step.ResourceName()], jobID, ref, step.Trigger, version).
+				RunWith(tx).
+				Exec()
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
 }
 
+// PassedJobNotFoundError is returned when a passed constraint names a job in
+// another pipeline which does not exist.
+type PassedJobNotFoundError struct {
+	Passed string
+}
+
+func (e PassedJobNotFoundError) Error() string {
+	return fmt.Sprintf("no matching job(s) for passed constraint '%s'", e.Passed)
+}
+
+// findPassedJobs returns the IDs of the jobs matching a passed constraint. Jobs
+// in another pipeline are mapped to how they are referred to, e.g.
+// "build/unit".
+func findPassedJobs(tx Tx, teamID int, passedJobGlob string, jobNameToID map[string]int) (map[int]sql.NullString, error) {
+	passedJobs := map[int]sql.NullString{}
+	for job, jobID := range jobNameToID {
+		matched, _ := path.Match(passedJobGlob, job)
+		if matched {
+			passedJobs[jobID] = sql.NullString{}
+		}
+	}
+
+	if len(passedJobs) > 0 || !strings.Contains(passedJobGlob, "/") {
+		return passedJobs, nil
+	}
+
+	passed, err := atc.ParsePassedJob(passedJobGlob)
+	if err != nil {
+		return nil, err
+	}
+
+	var instanceVars sql.NullString
+	if passed.Pipeline.InstanceVars != nil {
+		bytes, _ := json.Marshal(passed.Pipeline.InstanceVars)
+		instanceVars = sql.NullString{String: string(bytes), Valid: true}
+	}
+
+	rows, err := psql.Select("j.id", "j.name").
+		From("jobs j").
+		Join("pipelines p ON p.id = j.pipeline_id").
+		Where(sq.Eq{
+			"p.team_id":       teamID,
+			"p.name":          passed.Pipeline.Name,
+			"p.instance_vars": instanceVars,
+			"j.active":        true,
+		}).
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	for rows.Next() {
+		var jobID int
+		var name string
+		err = rows.Scan(&jobID, &name)
+		if err != nil {
+			return nil, err
+		}
+
+		matched, _ := path.Match(passed.Job, name)
+		if matched {
+			ref := atc.PassedJob{Pipeline: passed.Pipeline, Job: name}
+			passedJobs[jobID] = sql.NullString{String: ref.String(), Valid: true}
+		}
+	}
+
+	if len(passedJobs) == 0 {
+		return nil, PassedJobNotFoundError{Passed: passedJobGlob}
+	}
+
+	return passedJobs, nil
+}
+
 func insertJobOutput(tx Tx, step *atc.PutStep, jobName string, resourceNameToID map[string]int, jobNameToID map[string]int) error {
 	_, err := psql.Insert("job_outputs").
 		Columns("name", "job_id", "resource_id").
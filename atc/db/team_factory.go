@@ -51,10 +51,20 @@ func (factory *teamFactory) createTeam(t atc.Team, admin bool) (Team, error) {
 		return nil, err
 	}
 
+	var teamVarSources atc.VarSourceConfigs
+	if t.VarSources != nil {
+		teamVarSources = *t.VarSources
+	}
+
+	varSources, nonce, err := encryptVarSources(tx.EncryptionStrategy(), teamVarSources)
+	if err != nil {
+		return nil, err
+	}
+
 	row := psql.Insert("teams").
-		Columns("name, auth, admin").
-		Values(t.Name, auth, admin).
-		Suffix("RETURNING id, name, admin, auth").
+		Columns("name, auth, admin, var_sources, var_sources_nonce").
+		Values(t.Name, auth, admin, varSources, nonce).
+		Suffix("RETURNING id, name, admin, auth, var_sources, var_sources_nonce").
 		RunWith(tx).
 		QueryRow()
 
@@ -90,7 +100,7 @@ func (factory *teamFactory) FindTeam(teamName string) (Team, bool, error) {
 		lockFactory: factory.lockFactory,
 	}
 
-	row := psql.Select("id, name, admin, auth").
+	row := psql.Select("id, name, admin, auth, var_sources, var_sources_nonce").
 		From("teams").
 		Where(sq.Eq{"LOWER(name)": strings.ToLower(teamName)}).
 		RunWith(factory.conn).
@@ -109,7 +119,7 @@ func (factory *teamFactory) FindTeam(teamName string) (Team, bool, error) {
 }
 
 func (factory *teamFactory) GetTeams() ([]Team, error) {
-	rows, err := psql.Select("id, name, admin, auth").
+	rows, err := psql.Select("id, name, admin, auth, var_sources, var_sources_nonce").
 		From("teams").
 		OrderBy("name ASC").
 		RunWith(factory.conn).
@@ -175,14 +185,19 @@ func (factory *teamFactory) NotifyCacher() error {
 }
 
 func (factory *teamFactory) scanTeam(t *team, rows scannable) error {
-	var providerAuth sql.NullString
+	var providerAuth, varSources, nonce sql.NullString
 
 	err := rows.Scan(
 		&t.id,
 		&t.name,
 		&t.admin,
 		&providerAuth,
+		&varSources,
+		&nonce,
 	)
+	if err != nil {
+		return err
+	}
 
 	if providerAuth.Valid {
 		err = json.Unmarshal([]byte(providerAuth.String), &t.auth)
@@ -191,5 +206,6 @@ func (factory *teamFactory) scanTeam(t *team, rows scannable) error {
 		}
 	}
 
+	t.varSources, err = decryptVarSources(factory.conn.EncryptionStrategy(), varSources, nonce)
 	return err
 }
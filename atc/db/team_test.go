@@ -1057,6 +1057,62 @@ var _ = Describe("Team", func() {
 			}
 		})
 
+		Describe("UpdateVarSources", func() {
+			varSources := atc.VarSourceConfigs{
+				{
+					Name:   "some-vault",
+					Type:   "vault",
+					Config: map[string]any{"url": "https://vault.example.com"},
+				},
+			}
+
+			It("saves the var sources to the existing team", func() {
+				err := team.UpdateVarSources(varSources)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(team.VarSources()).To(Equal(varSources))
+
+				reloaded, found, err := teamFactory.FindTeam(team.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(reloaded.VarSources()).To(Equal(varSources))
+			})
+
+			It("clears the var sources when given none", func() {
+				Expect(team.UpdateVarSources(varSources)).To(Succeed())
+				Expect(team.UpdateVarSources(nil)).To(Succeed())
+
+				reloaded, found, err := teamFactory.FindTeam(team.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(reloaded.VarSources()).To(BeEmpty())
+			})
+		})
+
+		Describe("UpdateProviderAuthAndVarSources", func() {
+			varSources := atc.VarSourceConfigs{
+				{
+					Name:   "some-vault",
+					Type:   "vault",
+					Config: map[string]any{"url": "https://vault.example.com"},
+				},
+			}
+
+			It("saves both to the existing team", func() {
+				err := team.UpdateProviderAuthAndVarSources(authProvider, varSources)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(team.Auth()).To(Equal(authProvider))
+				Expect(team.VarSources()).To(Equal(varSources))
+
+				reloaded, found, err := teamFactory.FindTeam(team.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(reloaded.Auth()).To(Equal(authProvider))
+				Expect(reloaded.VarSources()).To(Equal(varSources))
+			})
+		})
+
 		Describe("UpdateProviderAuth", func() {
 			It("saves auth team info to the existing team", func() {
 				err := team.UpdateProviderAuth(authProvider)
@@ -1119,21 +1175,21 @@ var _ = Describe("Team", func() {
 					Jobs: atc.JobConfigs{
 						{Name: "job-name"},
 					},
-				}, db.ConfigVersion(1), false)
+				}, db.ConfigVersion(1), false, "some-user")
 				Expect(err).ToNot(HaveOccurred())
 
 				pipeline2, _, err = team.SavePipeline(atc.PipelineRef{Name: "fake-pipeline", InstanceVars: atc.InstanceVars{"branch": "feature/foo"}}, atc.Config{
 					Jobs: atc.JobConfigs{
 						{Name: "job-fake"},
 					},
-				}, db.ConfigVersion(1), false)
+				}, db.ConfigVersion(1), false, "some-user")
 				Expect(err).ToNot(HaveOccurred())
 
 				pipeline3, _, err = team.SavePipeline(atc.PipelineRef{Name: "fake-pipeline-two"}, atc.Config{
 					Jobs: atc.JobConfigs{
 						{Name: "job-fake"},
 					},
-				}, db.ConfigVersion(1), false)
+				}, db.ConfigVersion(1), false, "some-user")
 				Expect(err).ToNot(HaveOccurred())
 			})
 
@@ -1157,7 +1213,7 @@ var _ = Describe("Team", func() {
 						Jobs: atc.JobConfigs{
 							{Name: "job-name"},
 						},
-					}, db.ConfigVersion(1), false)
+					}, db.ConfigVersion(1), false, "some-user")
 					Expect(err).ToNot(HaveOccurred())
 				})
 
@@ -1183,6 +1239,63 @@ var _ = Describe("Team", func() {
 		})
 	})
 
+	Describe("ResourcesOfType", func() {
+		var (
+			resources db.Resources
+			pipeline1 db.Pipeline
+			pipeline2 db.Pipeline
+		)
+
+		BeforeEach(func() {
+			config := atc.Config{
+				Resources: atc.ResourceConfigs{
+					{Name: "some-repo", Type: "git", Source: atc.Source{"uri": "some-uri"}},
+					{Name: "some-image", Type: "registry-image", Source: atc.Source{"repository": "some-repo"}},
+				},
+				Jobs: atc.JobConfigs{
+					{
+						Name: "some-job",
+						PlanSequence: []atc.Step{
+							{Config: &atc.GetStep{Name: "some-repo"}},
+							{Config: &atc.GetStep{Name: "some-image"}},
+						},
+					},
+				},
+			}
+
+			var err error
+			pipeline1, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-pipeline", InstanceVars: atc.InstanceVars{"branch": "master"}}, config, db.ConfigVersion(0), false, "some-user")
+			Expect(err).ToNot(HaveOccurred())
+
+			pipeline2, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-pipeline", InstanceVars: atc.InstanceVars{"branch": "feature"}}, config, db.ConfigVersion(0), false, "some-user")
+			Expect(err).ToNot(HaveOccurred())
+
+			archivedPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "archived-pipeline"}, config, db.ConfigVersion(0), false, "some-user")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(archivedPipeline.Archive()).To(Succeed())
+
+			_, _, err = otherTeam.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, config, db.ConfigVersion(0), false, "some-user")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			resources, err = team.ResourcesOfType("git")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns the team's resources of that type in unarchived pipelines", func() {
+			Expect(resources).To(HaveLen(2))
+
+			Expect(resources[0].Name()).To(Equal("some-repo"))
+			Expect(resources[0].PipelineID()).To(Equal(pipeline1.ID()))
+			Expect(resources[0].Source()).To(Equal(atc.Source{"uri": "some-uri"}))
+
+			Expect(resources[1].Name()).To(Equal("some-repo"))
+			Expect(resources[1].PipelineID()).To(Equal(pipeline2.ID()))
+		})
+	})
+
 	Describe("PublicPipelines", func() {
 		var (
 			pipelines []db.Pipeline
@@ -1202,14 +1315,14 @@ var _ = Describe("Team", func() {
 					Jobs: atc.JobConfigs{
 						{Name: "job-name"},
 					},
-				}, db.ConfigVersion(1), false)
+				}, db.ConfigVersion(1), false, "some-user")
 				Expect(err).ToNot(HaveOccurred())
 
 				pipeline2, _, err = team.SavePipeline(atc.PipelineRef{Name: "fake-pipeline-two"}, atc.Config{
 					Jobs: atc.JobConfigs{
 						{Name: "job-fake"},
 					},
-				}, db.ConfigVersion(1), false)
+				}, db.ConfigVersion(1), false, "some-user")
 				Expect(err).ToNot(HaveOccurred())
 
 				err = pipeline2.Expose()
@@ -1244,19 +1357,19 @@ var _ = Describe("Team", func() {
 
 		BeforeEach(func() {
 			var err error
-			instancePipeline1, _, err = team.SavePipeline(atc.PipelineRef{Name: "group", InstanceVars: atc.InstanceVars{"branch": "master"}}, atc.Config{}, 0, false)
+			instancePipeline1, _, err = team.SavePipeline(atc.PipelineRef{Name: "group", InstanceVars: atc.InstanceVars{"branch": "master"}}, atc.Config{}, 0, false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
-			instancePipeline2, _, err = team.SavePipeline(atc.PipelineRef{Name: "group", InstanceVars: atc.InstanceVars{"branch": "feature/foo"}}, atc.Config{}, 0, false)
+			instancePipeline2, _, err = team.SavePipeline(atc.PipelineRef{Name: "group", InstanceVars: atc.InstanceVars{"branch": "feature/foo"}}, atc.Config{}, 0, false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
-			pipeline1, _, err = team.SavePipeline(atc.PipelineRef{Name: "pipeline1"}, atc.Config{}, 0, false)
+			pipeline1, _, err = team.SavePipeline(atc.PipelineRef{Name: "pipeline1"}, atc.Config{}, 0, false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
-			pipeline2, _, err = team.SavePipeline(atc.PipelineRef{Name: "pipeline2"}, atc.Config{}, 0, false)
+			pipeline2, _, err = team.SavePipeline(atc.PipelineRef{Name: "pipeline2"}, atc.Config{}, 0, false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
-			otherTeamPipeline1, _, err = otherTeam.SavePipeline(atc.PipelineRef{Name: "pipeline1"}, atc.Config{}, 0, false)
+			otherTeamPipeline1, _, err = otherTeam.SavePipeline(atc.PipelineRef{Name: "pipeline1"}, atc.Config{}, 0, false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
-			otherTeamPipeline2, _, err = otherTeam.SavePipeline(atc.PipelineRef{Name: "pipeline2"}, atc.Config{}, 0, false)
+			otherTeamPipeline2, _, err = otherTeam.SavePipeline(atc.PipelineRef{Name: "pipeline2"}, atc.Config{}, 0, false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 		})
 
@@ -1433,7 +1546,7 @@ var _ = Describe("Team", func() {
 					},
 				}
 				var err error
-				pipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, config, db.ConfigVersion(1), false)
+				pipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, config, db.ConfigVersion(1), false, "some-user")
 				Expect(err).ToNot(HaveOccurred())
 
 				job, found, err := pipeline.Job("some-job")
@@ -1584,7 +1697,7 @@ var _ = Describe("Team", func() {
 					},
 				},
 			}
-			pipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, config, db.ConfigVersion(1), false)
+			pipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, config, db.ConfigVersion(1), false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
 			job, found, err := pipeline.Job("some-job")
@@ -1690,7 +1803,7 @@ var _ = Describe("Team", func() {
 					},
 				},
 			}
-			pipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, config, db.ConfigVersion(1), false)
+			pipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, config, db.ConfigVersion(1), false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
 			job, found, err := pipeline.Job("some-job")
@@ -1845,7 +1958,7 @@ var _ = Describe("Team", func() {
 					Name:         "fake-pipeline",
 					InstanceVars: atc.InstanceVars{"branch": "feature"},
 				}
-				instancedPipeline, _, err = team.SavePipeline(instancedPipelineRef, atc.Config{}, db.ConfigVersion(0), false)
+				instancedPipeline, _, err = team.SavePipeline(instancedPipelineRef, atc.Config{}, db.ConfigVersion(0), false, "some-user")
 				Expect(err).ToNot(HaveOccurred())
 			})
 
@@ -1872,7 +1985,7 @@ var _ = Describe("Team", func() {
 				BeforeEach(func() {
 					var err error
 					namedPipelineRef = atc.PipelineRef{Name: "fake-pipeline"}
-					namedPipeline, _, err = team.SavePipeline(namedPipelineRef, atc.Config{}, db.ConfigVersion(0), false)
+					namedPipeline, _, err = team.SavePipeline(namedPipelineRef, atc.Config{}, db.ConfigVersion(0), false, "some-user")
 					Expect(err).ToNot(HaveOccurred())
 				})
 
@@ -2021,13 +2134,13 @@ var _ = Describe("Team", func() {
 		})
 
 		It("returns true for created", func() {
-			_, created, err := team.SavePipeline(pipelineRef, config, 0, false)
+			_, created, err := team.SavePipeline(pipelineRef, config, 0, false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 			Expect(created).To(BeTrue())
 		})
 
 		It("caches the team id", func() {
-			_, _, err := team.SavePipeline(pipelineRef, config, 0, false)
+			_, _, err := team.SavePipeline(pipelineRef, config, 0, false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
 			pipeline, found, err := team.Pipeline(pipelineRef)
@@ -2037,7 +2150,7 @@ var _ = Describe("Team", func() {
 		})
 
 		It("can be saved as paused", func() {
-			_, _, err := team.SavePipeline(pipelineRef, config, 0, true)
+			_, _, err := team.SavePipeline(pipelineRef, config, 0, true, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
 			pipeline, found, err := team.Pipeline(pipelineRef)
@@ -2048,7 +2161,7 @@ var _ = Describe("Team", func() {
 		})
 
 		It("can be saved as unpaused", func() {
-			_, _, err := team.SavePipeline(pipelineRef, config, 0, false)
+			_, _, err := team.SavePipeline(pipelineRef, config, 0, false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
 			pipeline, found, err := team.Pipeline(pipelineRef)
@@ -2059,7 +2172,7 @@ var _ = Describe("Team", func() {
 		})
 
 		It("is not archived by default", func() {
-			_, _, err := team.SavePipeline(pipelineRef, config, 0, true)
+			_, _, err := team.SavePipeline(pipelineRef, config, 0, true, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
 			pipeline, found, err := team.Pipeline(pipelineRef)
@@ -2070,10 +2183,10 @@ var _ = Describe("Team", func() {
 		})
 
 		It("requests schedule on the pipeline", func() {
-			requestedPipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false)
+			requestedPipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
-			otherPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "other-pipeline"}, otherConfig, 0, false)
+			otherPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "other-pipeline"}, otherConfig, 0, false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
 			requestedJob, found, err := requestedPipeline.Job("some-job")
@@ -2091,7 +2204,7 @@ var _ = Describe("Team", func() {
 				"source-other-config": "some-other-value",
 			}
 
-			_, _, err = team.SavePipeline(pipelineRef, config, requestedPipeline.ConfigVersion(), false)
+			_, _, err = team.SavePipeline(pipelineRef, config, requestedPipeline.ConfigVersion(), false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
 			found, err = requestedJob.Reload()
@@ -2107,7 +2220,7 @@ var _ = Describe("Team", func() {
 		})
 
 		It("creates all of the resources from the pipeline in the database", func() {
-			savedPipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false)
+			savedPipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
 			resource, found, err := savedPipeline.Resource("some-resource")
@@ -2231,7 +2344,7 @@ var _ = Describe("Team", func() {
 				"version": "v1",
 			}
 
-			pipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false)
+			pipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
 			resource, found, err := pipeline.Resource("some-resource")
@@ -2242,7 +2355,7 @@ var _ = Describe("Team", func() {
 
 			config.Resources[0].Version = nil
 
-			savedPipeline, _, err := team.SavePipeline(pipelineRef, config, pipeline.ConfigVersion(), false)
+			savedPipeline, _, err := team.SavePipeline(pipelineRef, config, pipeline.ConfigVersion(), false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
 			resource, found, err = savedPipeline.Resource("some-resource")
@@ -2275,7 +2388,7 @@ var _ = Describe("Team", func() {
 		})
 
 		It("marks resource as inactive if it is no longer in config", func() {
-			pipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false)
+			pipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
 			config.Resources = []atc.ResourceConfig{}
@@ -2323,7 +2436,7 @@ var _ = Describe("Team", func() {
 				},
 			}
 
-			savedPipeline, _, err := team.SavePipeline(pipelineRef, config, pipeline.ConfigVersion(), false)
+			savedPipeline, _, err := team.SavePipeline(pipelineRef, config, pipeline.ConfigVersion(), false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
 			_, found, err := savedPipeline.Resource("some-other-resource")
@@ -2332,7 +2445,7 @@ var _ = Describe("Team", func() {
 		})
 
 		It("creates all of the resource types from the pipeline in the database", func() {
-			savedPipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false)
+			savedPipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
 			resourceType, found, err := savedPipeline.ResourceType("some-resource-type")
@@ -2345,14 +2458,14 @@ var _ = Describe("Team", func() {
 		})
 
 		It("updates resource type config from the pipeline in the database", func() {
-			pipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false)
+			pipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
 			config.ResourceTypes[0].Source = atc.Source{
 				"source-other-config": "some-other-value",
 			}
 
-			savedPipeline, _, err := team.SavePipeline(pipelineRef, config, pipeline.ConfigVersion(), false)
+			savedPipeline, _, err := team.SavePipeline(pipelineRef, config, pipeline.ConfigVersion(), false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
 			resourceType, found, err := savedPipeline.ResourceType("some-resource-type")
@@ -2365,12 +2478,12 @@ var _ = Describe("Team", func() {
 		})
 
 		It("marks resource type as inactive if it is no longer in config", func() {
-			pipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false)
+			pipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
 			config.ResourceTypes = []atc.ResourceType{}
 
-			savedPipeline, _, err := team.SavePipeline(pipelineRef, config, pipeline.ConfigVersion(), false)
+			savedPipeline, _, err := team.SavePipeline(pipelineRef, config, pipeline.ConfigVersion(), false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
 			_, found, err := savedPipeline.ResourceType("some-resource-type")
@@ -2379,7 +2492,7 @@ var _ = Describe("Team", func() {
 		})
 
 		It("creates all of the prototypes from the pipeline in the database", func() {
-			savedPipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false)
+			savedPipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
 			prototype, found, err := savedPipeline.Prototype("some-prototype")
@@ -2392,14 +2505,14 @@ var _ = Describe("Team", func() {
 		})
 
 		It("updates prototype config from the pipeline in the database", func() {
-			pipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false)
+			pipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
 			config.Prototypes[0].Source = atc.Source{
 				"source-other-config": "some-other-value",
 			}
 
-			savedPipeline, _, err := team.SavePipeline(pipelineRef, config, pipeline.ConfigVersion(), false)
+			savedPipeline, _, err := team.SavePipeline(pipelineRef, config, pipeline.ConfigVersion(), false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
 			prototype, found, err := savedPipeline.Prototype("some-prototype")
@@ -2412,12 +2525,12 @@ var _ = Describe("Team", func() {
 		})
 
 		It("marks prototype as inactive if it is no longer in config", func() {
-			pipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false)
+			pipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
 			config.Prototypes = atc.Prototypes{}
 
-			savedPipeline, _, err := team.SavePipeline(pipelineRef, config, pipeline.ConfigVersion(), false)
+			savedPipeline, _, err := team.SavePipeline(pipelineRef, config, pipeline.ConfigVersion(), false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
 			_, found, err := savedPipeline.Prototype("some-resource-type")
@@ -2426,7 +2539,7 @@ var _ = Describe("Team", func() {
 		})
 
 		It("creates all of the jobs from the pipeline in the database", func() {
-			savedPipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false)
+			savedPipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
 			job, found, err := savedPipeline.Job("some-job")
@@ -2436,12 +2549,12 @@ var _ = Describe("Team", func() {
 		})
 
 		It("updates job config", func() {
-			pipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false)
+			pipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
 			config.Jobs[0].Public = false
 
-			_, _, err = team.SavePipeline(pipelineRef, config, pipeline.ConfigVersion(), false)
+			_, _, err = team.SavePipeline(pipelineRef, config, pipeline.ConfigVersion(), false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
 			job, found, err := pipeline.Job("some-job")
@@ -2451,12 +2564,12 @@ var _ = Describe("Team", func() {
 		})
 
 		It("marks job inactive when it is no longer in pipeline", func() {
-			pipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false)
+			pipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
 			config.Jobs = []atc.JobConfig{}
 
-			savedPipeline, _, err := team.SavePipeline(pipelineRef, config, pipeline.ConfigVersion(), false)
+			savedPipeline, _, err := team.SavePipeline(pipelineRef, config, pipeline.ConfigVersion(), false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
 			_, found, err := savedPipeline.Job("some-job")
@@ -2523,7 +2636,7 @@ var _ = Describe("Team", func() {
 			})
 
 			It("resolves appropriately", func() {
-				pipeline, _, err := team.SavePipeline(pipelineRef, newConfig, 0, false)
+				pipeline, _, err := team.SavePipeline(pipelineRef, newConfig, 0, false, "some-user")
 				Expect(err).ToNot(HaveOccurred())
 
 				job, found, err := pipeline.Job("final-tasking")
@@ -2591,7 +2704,7 @@ var _ = Describe("Team", func() {
 			})
 
 			It("should handle when there are multiple name changes", func() {
-				pipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false)
+				pipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false, "some-user")
 				Expect(err).ToNot(HaveOccurred())
 
 				job, _, _ := pipeline.Job("some-job")
@@ -2603,7 +2716,7 @@ var _ = Describe("Team", func() {
 				config.Jobs[3].Name = "new-other-job"
 				config.Jobs[3].OldName = "new-job"
 
-				updatedPipeline, _, err := team.SavePipeline(pipelineRef, config, pipeline.ConfigVersion(), false)
+				updatedPipeline, _, err := team.SavePipeline(pipelineRef, config, pipeline.ConfigVersion(), false, "some-user")
 				Expect(err).ToNot(HaveOccurred())
 
 				updatedJob, _, _ := updatedPipeline.Job("new-job")
@@ -2614,7 +2727,7 @@ var _ = Describe("Team", func() {
 			})
 
 			It("should handle when old job has the same name as new job", func() {
-				pipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false)
+				pipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false, "some-user")
 				Expect(err).ToNot(HaveOccurred())
 
 				job, _, _ := pipeline.Job("some-job")
@@ -2622,7 +2735,7 @@ var _ = Describe("Team", func() {
 				config.Jobs[0].Name = "some-job"
 				config.Jobs[0].OldName = "some-job"
 
-				updatedPipeline, _, err := team.SavePipeline(pipelineRef, config, pipeline.ConfigVersion(), false)
+				updatedPipeline, _, err := team.SavePipeline(pipelineRef, config, pipeline.ConfigVersion(), false, "some-user")
 				Expect(err).ToNot(HaveOccurred())
 
 				updatedJob, _, _ := updatedPipeline.Job("some-job")
@@ -2630,7 +2743,7 @@ var _ = Describe("Team", func() {
 			})
 
 			It("should return an error when there is a swap with job name", func() {
-				pipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false)
+				pipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false, "some-user")
 				Expect(err).ToNot(HaveOccurred())
 
 				config.Jobs[0].Name = "new-job"
@@ -2639,24 +2752,24 @@ var _ = Describe("Team", func() {
 				config.Jobs[1].Name = "some-job"
 				config.Jobs[1].OldName = "new-job"
 
-				_, _, err = team.SavePipeline(pipelineRef, config, pipeline.ConfigVersion(), false)
+				_, _, err = team.SavePipeline(pipelineRef, config, pipeline.ConfigVersion(), false, "some-user")
 				Expect(err).To(HaveOccurred())
 			})
 
 			Context("when new job name is in database but is inactive", func() {
 				It("should successfully update job name", func() {
-					pipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false)
+					pipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false, "some-user")
 					Expect(err).ToNot(HaveOccurred())
 
 					config.Jobs = config.Jobs[:len(config.Jobs)-1]
 
-					_, _, err = team.SavePipeline(pipelineRef, config, pipeline.ConfigVersion(), false)
+					_, _, err = team.SavePipeline(pipelineRef, config, pipeline.ConfigVersion(), false, "some-user")
 					Expect(err).ToNot(HaveOccurred())
 
 					config.Jobs[0].Name = "new-job"
 					config.Jobs[0].OldName = "some-job"
 
-					_, _, err = team.SavePipeline(pipelineRef, config, pipeline.ConfigVersion()+1, false)
+					_, _, err = team.SavePipeline(pipelineRef, config, pipeline.ConfigVersion()+1, false, "some-user")
 					Expect(err).ToNot(HaveOccurred())
 				})
 			})
@@ -2676,7 +2789,7 @@ var _ = Describe("Team", func() {
 			})
 
 			It("should successfully update resource name", func() {
-				pipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false)
+				pipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false, "some-user")
 				Expect(err).ToNot(HaveOccurred())
 
 				resource, _, _ := pipeline.Resource("some-resource")
@@ -2698,7 +2811,7 @@ var _ = Describe("Team", func() {
 					},
 				}
 
-				updatedPipeline, _, err := team.SavePipeline(pipelineRef, config, pipeline.ConfigVersion(), false)
+				updatedPipeline, _, err := team.SavePipeline(pipelineRef, config, pipeline.ConfigVersion(), false, "some-user")
 				Expect(err).ToNot(HaveOccurred())
 
 				updatedResource, _, _ := updatedPipeline.Resource("renamed-resource")
@@ -2706,7 +2819,7 @@ var _ = Describe("Team", func() {
 			})
 
 			It("should handle when there are multiple name changes", func() {
-				pipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false)
+				pipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false, "some-user")
 				Expect(err).ToNot(HaveOccurred())
 
 				resource, _, _ := pipeline.Resource("some-resource")
@@ -2732,7 +2845,7 @@ var _ = Describe("Team", func() {
 					},
 				}
 
-				updatedPipeline, _, err := team.SavePipeline(pipelineRef, config, pipeline.ConfigVersion(), false)
+				updatedPipeline, _, err := team.SavePipeline(pipelineRef, config, pipeline.ConfigVersion(), false, "some-user")
 				Expect(err).ToNot(HaveOccurred())
 
 				updatedResource, _, _ := updatedPipeline.Resource("new-resource")
@@ -2743,7 +2856,7 @@ var _ = Describe("Team", func() {
 			})
 
 			It("should handle when old resource has the same name as new resource", func() {
-				pipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false)
+				pipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false, "some-user")
 				Expect(err).ToNot(HaveOccurred())
 
 				resource, _, _ := pipeline.Resource("some-resource")
@@ -2751,7 +2864,7 @@ var _ = Describe("Team", func() {
 				config.Resources[0].Name = "some-resource"
 				config.Resources[0].OldName = "some-resource"
 
-				updatedPipeline, _, err := team.SavePipeline(pipelineRef, config, pipeline.ConfigVersion(), false)
+				updatedPipeline, _, err := team.SavePipeline(pipelineRef, config, pipeline.ConfigVersion(), false, "some-user")
 				Expect(err).ToNot(HaveOccurred())
 
 				updatedResource, _, _ := updatedPipeline.Resource("some-resource")
@@ -2759,7 +2872,7 @@ var _ = Describe("Team", func() {
 			})
 
 			It("should return an error when there is a swap with resource name", func() {
-				pipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false)
+				pipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false, "some-user")
 				Expect(err).ToNot(HaveOccurred())
 
 				config.Resources[0].Name = "new-resource"
@@ -2768,7 +2881,7 @@ var _ = Describe("Team", func() {
 				config.Resources[1].Name = "some-resource"
 				config.Resources[1].OldName = "new-resource"
 
-				_, _, err = team.SavePipeline(pipelineRef, config, pipeline.ConfigVersion(), false)
+				_, _, err = team.SavePipeline(pipelineRef, config, pipeline.ConfigVersion(), false, "some-user")
 				Expect(err).To(HaveOccurred())
 			})
 
@@ -2878,7 +2991,7 @@ var _ = Describe("Team", func() {
 		})
 
 		It("removes task caches for jobs that are no longer in pipeline", func() {
-			pipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false)
+			pipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
 			job, found, err := pipeline.Job("some-job")
@@ -2901,7 +3014,7 @@ var _ = Describe("Team", func() {
 
 			config.Jobs = []atc.JobConfig{}
 
-			_, _, err = team.SavePipeline(pipelineRef, config, pipeline.ConfigVersion(), false)
+			_, _, err = team.SavePipeline(pipelineRef, config, pipeline.ConfigVersion(), false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
 			_, found, err = taskCacheFactory.Find(job.ID(), "some-task", "some-path")
@@ -2914,7 +3027,7 @@ var _ = Describe("Team", func() {
 		})
 
 		It("removes task caches for tasks that are no longer exist", func() {
-			pipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false)
+			pipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
 			job, found, err := pipeline.Job("some-job")
@@ -2949,7 +3062,7 @@ var _ = Describe("Team", func() {
 				},
 			}
 
-			_, _, err = team.SavePipeline(pipelineRef, config, pipeline.ConfigVersion(), false)
+			_, _, err = team.SavePipeline(pipelineRef, config, pipeline.ConfigVersion(), false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
 			_, found, err = taskCacheFactory.Find(job.ID(), "some-task", "some-path")
@@ -2962,10 +3075,10 @@ var _ = Describe("Team", func() {
 		})
 
 		It("should not remove task caches in other pipeline", func() {
-			pipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false)
+			pipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
-			otherPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "other-pipeline"}, config, 0, false)
+			otherPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "other-pipeline"}, config, 0, false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
 			job, found, err := pipeline.Job("some-job")
@@ -3004,7 +3117,7 @@ var _ = Describe("Team", func() {
 				},
 			}
 
-			_, _, err = team.SavePipeline(pipelineRef, config, pipeline.ConfigVersion(), false)
+			_, _, err = team.SavePipeline(pipelineRef, config, pipeline.ConfigVersion(), false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
 			_, found, err = taskCacheFactory.Find(job.ID(), "some-task", "some-path")
@@ -3017,7 +3130,7 @@ var _ = Describe("Team", func() {
 		})
 
 		It("creates all of the serial groups from the jobs in the database", func() {
-			savedPipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false)
+			savedPipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
 			serialGroups := []SerialGroup{}
@@ -3048,7 +3161,7 @@ var _ = Describe("Team", func() {
 		})
 
 		It("saves tags in the jobs table", func() {
-			savedPipeline, _, err := team.SavePipeline(pipelineRef, otherConfig, 0, false)
+			savedPipeline, _, err := team.SavePipeline(pipelineRef, otherConfig, 0, false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
 			job, found, err := savedPipeline.Job("some-other-job")
@@ -3060,7 +3173,7 @@ var _ = Describe("Team", func() {
 
 		It("saves tags in the jobs table based on globs", func() {
 			otherConfig.Groups[0].Jobs = []string{"*-other-job"}
-			savedPipeline, _, err := team.SavePipeline(pipelineRef, otherConfig, 0, false)
+			savedPipeline, _, err := team.SavePipeline(pipelineRef, otherConfig, 0, false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
 			job, found, err := savedPipeline.Job("some-other-job")
@@ -3071,7 +3184,7 @@ var _ = Describe("Team", func() {
 		})
 
 		It("updates tags in the jobs table", func() {
-			savedPipeline, _, err := team.SavePipeline(pipelineRef, otherConfig, 0, false)
+			savedPipeline, _, err := team.SavePipeline(pipelineRef, otherConfig, 0, false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
 			job, found, err := savedPipeline.Job("some-other-job")
@@ -3091,7 +3204,7 @@ var _ = Describe("Team", func() {
 				},
 			}
 
-			savedPipeline, _, err = team.SavePipeline(pipelineRef, otherConfig, savedPipeline.ConfigVersion(), false)
+			savedPipeline, _, err = team.SavePipeline(pipelineRef, otherConfig, savedPipeline.ConfigVersion(), false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
 			job, found, err = savedPipeline.Job("some-other-job")
@@ -3102,10 +3215,10 @@ var _ = Describe("Team", func() {
 		})
 
 		It("it returns created as false when updated", func() {
-			pipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false)
+			pipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
-			_, created, err := team.SavePipeline(pipelineRef, config, pipeline.ConfigVersion(), false)
+			_, created, err := team.SavePipeline(pipelineRef, config, pipeline.ConfigVersion(), false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 			Expect(created).To(BeFalse())
 		})
@@ -3216,7 +3329,7 @@ var _ = Describe("Team", func() {
 				},
 			}
 
-			pipeline, _, err := team.SavePipeline(pipelineRef, config, 0, true)
+			pipeline, _, err := team.SavePipeline(pipelineRef, config, 0, true, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
 			rows, err := psql.Select("name", "job_id", "resource_id", "passed_job_id").
@@ -3372,7 +3485,7 @@ var _ = Describe("Team", func() {
 				},
 			}
 
-			_, _, err = team.SavePipeline(pipelineRef, config, pipeline.ConfigVersion(), false)
+			_, _, err = team.SavePipeline(pipelineRef, config, pipeline.ConfigVersion(), false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
 			rows, err = psql.Select("name", "job_id", "resource_id", "passed_job_id").
@@ -3417,7 +3530,7 @@ var _ = Describe("Team", func() {
 
 		Context("updating an existing pipeline", func() {
 			It("maintains paused if the pipeline is paused", func() {
-				_, _, err := team.SavePipeline(pipelineRef, config, 0, true)
+				_, _, err := team.SavePipeline(pipelineRef, config, 0, true, "some-user")
 				Expect(err).ToNot(HaveOccurred())
 
 				pipeline, found, err := team.Pipeline(pipelineRef)
@@ -3425,7 +3538,7 @@ var _ = Describe("Team", func() {
 				Expect(found).To(BeTrue())
 				Expect(pipeline.Paused()).To(BeTrue())
 
-				_, _, err = team.SavePipeline(pipelineRef, config, pipeline.ConfigVersion(), false)
+				_, _, err = team.SavePipeline(pipelineRef, config, pipeline.ConfigVersion(), false, "some-user")
 				Expect(err).ToNot(HaveOccurred())
 
 				pipeline, found, err = team.Pipeline(pipelineRef)
@@ -3435,7 +3548,7 @@ var _ = Describe("Team", func() {
 			})
 
 			It("maintains unpaused if the pipeline is unpaused", func() {
-				_, _, err := team.SavePipeline(pipelineRef, config, 0, false)
+				_, _, err := team.SavePipeline(pipelineRef, config, 0, false, "some-user")
 				Expect(err).ToNot(HaveOccurred())
 
 				pipeline, found, err := team.Pipeline(pipelineRef)
@@ -3443,7 +3556,7 @@ var _ = Describe("Team", func() {
 				Expect(found).To(BeTrue())
 				Expect(pipeline.Paused()).To(BeFalse())
 
-				_, _, err = team.SavePipeline(pipelineRef, config, pipeline.ConfigVersion(), true)
+				_, _, err = team.SavePipeline(pipelineRef, config, pipeline.ConfigVersion(), true, "some-user")
 				Expect(err).ToNot(HaveOccurred())
 
 				pipeline, found, err = team.Pipeline(pipelineRef)
@@ -3453,11 +3566,11 @@ var _ = Describe("Team", func() {
 			})
 
 			It("resets to unarchived", func() {
-				team.SavePipeline(pipelineRef, config, 0, false)
+				team.SavePipeline(pipelineRef, config, 0, false, "some-user")
 				pipeline, _, _ := team.Pipeline(pipelineRef)
 				pipeline.Archive()
 
-				team.SavePipeline(pipelineRef, config, db.ConfigVersion(0), true)
+				team.SavePipeline(pipelineRef, config, db.ConfigVersion(0), true, "some-user")
 				pipeline.Reload()
 				Expect(pipeline.Archived()).To(BeFalse(), "the pipeline remained archived")
 			})
@@ -3466,9 +3579,9 @@ var _ = Describe("Team", func() {
 		It("can lookup a pipeline by name", func() {
 			otherPipelineFilter := atc.PipelineRef{Name: "an-other-pipeline-name"}
 
-			_, _, err := team.SavePipeline(pipelineRef, config, 0, false)
+			_, _, err := team.SavePipeline(pipelineRef, config, 0, false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
-			_, _, err = team.SavePipeline(otherPipelineFilter, otherConfig, 0, false)
+			_, _, err = team.SavePipeline(otherPipelineFilter, otherConfig, 0, false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
 			pipeline, found, err := team.Pipeline(pipelineRef)
@@ -3517,10 +3630,10 @@ var _ = Describe("Team", func() {
 			otherPipelineFilter := atc.PipelineRef{Name: "an-other-pipeline-name"}
 
 			By("being able to save the config")
-			pipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false)
+			pipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
-			otherPipeline, _, err := team.SavePipeline(otherPipelineFilter, otherConfig, 0, false)
+			otherPipeline, _, err := team.SavePipeline(otherPipelineFilter, otherConfig, 0, false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
 			By("returning the saved config to later gets")
@@ -3598,22 +3711,22 @@ var _ = Describe("Team", func() {
 			})
 
 			By("not allowing non-sequential updates")
-			_, _, err = team.SavePipeline(pipelineRef, updatedConfig, pipeline.ConfigVersion()-1, false)
+			_, _, err = team.SavePipeline(pipelineRef, updatedConfig, pipeline.ConfigVersion()-1, false, "some-user")
 			Expect(err).To(Equal(db.ErrConfigComparisonFailed))
 
-			_, _, err = team.SavePipeline(pipelineRef, updatedConfig, pipeline.ConfigVersion()+10, false)
+			_, _, err = team.SavePipeline(pipelineRef, updatedConfig, pipeline.ConfigVersion()+10, false, "some-user")
 			Expect(err).To(Equal(db.ErrConfigComparisonFailed))
 
-			_, _, err = team.SavePipeline(otherPipelineFilter, updatedConfig, otherPipeline.ConfigVersion()-1, false)
+			_, _, err = team.SavePipeline(otherPipelineFilter, updatedConfig, otherPipeline.ConfigVersion()-1, false, "some-user")
 			Expect(err).To(Equal(db.ErrConfigComparisonFailed))
 
-			_, _, err = team.SavePipeline(otherPipelineFilter, updatedConfig, otherPipeline.ConfigVersion()+10, false)
+			_, _, err = team.SavePipeline(otherPipelineFilter, updatedConfig, otherPipeline.ConfigVersion()+10, false, "some-user")
 			Expect(err).To(Equal(db.ErrConfigComparisonFailed))
 
 			By("being able to update the config with a valid con")
-			pipeline, _, err = team.SavePipeline(pipelineRef, updatedConfig, pipeline.ConfigVersion(), false)
+			pipeline, _, err = team.SavePipeline(pipelineRef, updatedConfig, pipeline.ConfigVersion(), false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
-			otherPipeline, _, err = team.SavePipeline(otherPipelineFilter, updatedConfig, otherPipeline.ConfigVersion(), false)
+			otherPipeline, _, err = team.SavePipeline(otherPipelineFilter, updatedConfig, otherPipeline.ConfigVersion(), false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
 			By("returning the updated config")
@@ -3672,7 +3785,7 @@ var _ = Describe("Team", func() {
 				},
 			})
 
-			pipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false)
+			pipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
 			resourceTypes, err := pipeline.ResourceTypes()
@@ -3692,27 +3805,27 @@ var _ = Describe("Team", func() {
 			It("can allow pipelines with the same name across teams", func() {
 				pipelineRef := atc.PipelineRef{Name: "steve"}
 
-				teamPipeline, _, err := team.SavePipeline(pipelineRef, config, 0, true)
+				teamPipeline, _, err := team.SavePipeline(pipelineRef, config, 0, true, "some-user")
 				Expect(err).ToNot(HaveOccurred())
 				Expect(teamPipeline.Paused()).To(BeTrue())
 
 				By("allowing you to save a pipeline with the same name in another team")
-				otherTeamPipeline, _, err := otherTeam.SavePipeline(pipelineRef, otherConfig, 0, true)
+				otherTeamPipeline, _, err := otherTeam.SavePipeline(pipelineRef, otherConfig, 0, true, "some-user")
 				Expect(err).ToNot(HaveOccurred())
 				Expect(otherTeamPipeline.Paused()).To(BeTrue())
 
 				By("updating the pipeline config for the correct team's pipeline")
-				_, _, err = team.SavePipeline(pipelineRef, otherConfig, teamPipeline.ConfigVersion(), false)
+				_, _, err = team.SavePipeline(pipelineRef, otherConfig, teamPipeline.ConfigVersion(), false, "some-user")
 				Expect(err).ToNot(HaveOccurred())
 
-				_, _, err = otherTeam.SavePipeline(pipelineRef, config, otherTeamPipeline.ConfigVersion(), false)
+				_, _, err = otherTeam.SavePipeline(pipelineRef, config, otherTeamPipeline.ConfigVersion(), false, "some-user")
 				Expect(err).ToNot(HaveOccurred())
 
 				By("cannot cross update configs")
-				_, _, err = team.SavePipeline(pipelineRef, otherConfig, otherTeamPipeline.ConfigVersion(), false)
+				_, _, err = team.SavePipeline(pipelineRef, otherConfig, otherTeamPipeline.ConfigVersion(), false, "some-user")
 				Expect(err).To(HaveOccurred())
 
-				_, _, err = team.SavePipeline(pipelineRef, otherConfig, otherTeamPipeline.ConfigVersion(), true)
+				_, _, err = team.SavePipeline(pipelineRef, otherConfig, otherTeamPipeline.ConfigVersion(), true, "some-user")
 				Expect(err).To(HaveOccurred())
 			})
 		})
@@ -3782,6 +3895,7 @@ var _ = Describe("Team", func() {
 					config,
 					pipeline.ConfigVersion(),
 					false,
+					"some-user",
 				)
 				if err != nil {
 					panic(err)
@@ -3862,19 +3976,19 @@ var _ = Describe("Team", func() {
 				p1, _, err = defaultTeam.SavePipeline(atc.PipelineRef{
 					Name:         "release",
 					InstanceVars: atc.InstanceVars{"version": "6.7.x"},
-				}, defaultPipelineConfig, db.ConfigVersion(0), false)
+				}, defaultPipelineConfig, db.ConfigVersion(0), false, "some-user")
 				Expect(err).ToNot(HaveOccurred())
 
 				p2, _, err = defaultTeam.SavePipeline(atc.PipelineRef{
 					Name:         "release",
 					InstanceVars: atc.InstanceVars{"version": "7.0.x"},
-				}, defaultPipelineConfig, db.ConfigVersion(0), false)
+				}, defaultPipelineConfig, db.ConfigVersion(0), false, "some-user")
 				Expect(err).ToNot(HaveOccurred())
 
 				p3, _, err = defaultTeam.SavePipeline(atc.PipelineRef{
 					Name:         "release",
 					InstanceVars: nil,
-				}, defaultPipelineConfig, db.ConfigVersion(0), false)
+				}, defaultPipelineConfig, db.ConfigVersion(0), false, "some-user")
 				Expect(err).ToNot(HaveOccurred())
 			})
 
@@ -3983,7 +4097,7 @@ var _ = Describe("Team", func() {
 										},
 									},
 								},
-							}, db.ConfigVersion(0), false)
+							}, db.ConfigVersion(0), false, "some-user")
 							Expect(err).NotTo(HaveOccurred())
 
 							otherResource, found, err = otherPipeline.Resource("some-resource")
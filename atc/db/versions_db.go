@@ -624,6 +624,148 @@ func (versions VersionsDB) latestVersionOfResource(ctx context.Context, tx Tx, r
 	return version, true, nil
 }
 
+// LatestVersionOfResourceMatching returns the latest enabled version of the
+// resource which matches the filter.
+func (versions VersionsDB) LatestVersionOfResourceMatching(ctx context.Context, resourceID int, filter atc.VersionFilter) (ResourceVersion, bool, error) {
+	var scopeID sql.NullInt64
+	err := psql.Select("resource_config_scope_id").
+		From("resources").
+		Where(sq.Eq{"id": resourceID}).
+		RunWith(versions.conn).
+		QueryRowContext(ctx).
+		Scan(&scopeID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+
+	if !scopeID.Valid {
+		return "", false, nil
+	}
+
+	// versions are matched a page at a time, as filters such as semver ranges
+	// cannot be expressed in the query
+	var lastCheckOrder int
+	for {
+		builder := psql.Select("rcv.id", "COALESCE(rcv.version_md5, rcv.version_sha256)", "rcv.version", "rcv.metadata", "rcv.check_order").
+			From("resource_config_versions rcv").
+			Where(sq.Eq{"resource_config_scope_id": scopeID}).
+			Where(`NOT EXISTS (
+				SELECT 1
+				FROM resource_disabled_versions rdv
+				WHERE rdv.resource_id = ?
+				AND rdv.version_digest IN (rcv.version_md5, rcv.version_sha256)
+			)`, resourceID).
+			OrderBy("check_order DESC").
+			Limit(uint64(versions.limitRows))
+
+		if lastCheckOrder != 0 {
+			builder = builder.Where(sq.Lt{"check_order": lastCheckOrder})
+		}
+
+		rows, err := builder.RunWith(versions.conn).QueryContext(ctx)
+		if err != nil {
+			return "", false, err
+		}
+
+		digests := map[int]ResourceVersion{}
+		var page []atc.ResourceVersion
+		for rows.Next() {
+			var id int
+			var digest ResourceVersion
+			var versionJSON, metadataJSON sql.NullString
+
+			err := rows.Scan(&id, &digest, &versionJSON, &metadataJSON, &lastCheckOrder)
+			if err != nil {
+				rows.Close()
+				return "", false, err
+			}
+
+			resourceVersion := atc.ResourceVersion{ID: id, Enabled: true}
+
+			if versionJSON.Valid {
+				err = json.Unmarshal([]byte(versionJSON.String), &resourceVersion.Version)
+				if err != nil {
+					rows.Close()
+					return "", false, err
+				}
+			}
+
+			if metadataJSON.Valid {
+				err = json.Unmarshal([]byte(metadataJSON.String), &resourceVersion.Metadata)
+				if err != nil {
+					rows.Close()
+					return "", false, err
+				}
+			}
+
+			digests[id] = digest
+			page = append(page, resourceVersion)
+		}
+
+		err = rows.Close()
+		if err != nil {
+			return "", false, err
+		}
+
+		if len(page) == 0 {
+			return "", false, nil
+		}
+
+		match, found := filter.MatchingVersion(page)
+		if found {
+			return digests[match.ID], true, nil
+		}
+
+		if len(page) < versions.limitRows {
+			return "", false, nil
+		}
+	}
+}
+
+// VersionMatchesFilter reports whether the version of the resource matches
+// the filter.
+func (versions VersionsDB) VersionMatchesFilter(ctx context.Context, resourceID int, version ResourceVersion, filter atc.VersionFilter) (bool, error) {
+	var versionJSON, metadataJSON sql.NullString
+	err := psql.Select("rcv.version", "rcv.metadata").
+		From("resource_config_versions rcv").
+		Join("resources r ON r.resource_config_scope_id = rcv.resource_config_scope_id").
+		Where(sq.Eq{"r.id": resourceID}).
+		Where(sq.Or{
+			sq.Eq{"rcv.version_md5": version},
+			sq.Eq{"rcv.version_sha256": version},
+		}).
+		RunWith(versions.conn).
+		QueryRowContext(ctx).
+		Scan(&versionJSON, &metadataJSON)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, err
+	}
+
+	var v atc.Version
+	if versionJSON.Valid {
+		err = json.Unmarshal([]byte(versionJSON.String), &v)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	var metadata atc.Metadata
+	if metadataJSON.Valid {
+		err = json.Unmarshal([]byte(metadataJSON.String), &metadata)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return filter.Matches(v, metadata), nil
+}
+
 func (versions VersionsDB) migrateSingle(ctx context.Context, buildID int) (string, error) {
 	ctx, span := tracing.StartSpan(ctx, "VersionsDB.migrateSingle", tracing.Attrs{})
 	defer span.End()
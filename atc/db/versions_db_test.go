@@ -3,6 +3,7 @@ package db_test
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -764,4 +765,104 @@ var _ = Describe("VersionsDB", func() {
 			})
 		})
 	})
+
+	Describe("LatestVersionOfResourceMatching", func() {
+		var (
+			scenario *dbtest.Scenario
+			filter   atc.VersionFilter
+
+			resourceVersion db.ResourceVersion
+			found           bool
+		)
+
+		BeforeEach(func() {
+			versions := []atc.Version{}
+			for i := range 12 {
+				versions = append(versions, atc.Version{"tag": fmt.Sprintf("%d.0.0", i)})
+			}
+
+			scenario = dbtest.Setup(
+				builder.WithResourceVersions("some-resource", time.Minute, versions...),
+				builder.WithVersionMetadata("some-resource", atc.Version{"tag": "1.0.0"}, db.ResourceConfigMetadataFields{
+					{Name: "channel", Value: "stable"},
+				}),
+				builder.WithDisabledVersion("some-resource", atc.Version{"tag": "10.0.0"}),
+			)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			resourceVersion, found, err = vdb.LatestVersionOfResourceMatching(
+				ctx,
+				scenario.Resource("some-resource").ID(),
+				filter,
+			)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		Context("when a version on a later page matches", func() {
+			BeforeEach(func() {
+				filter = atc.VersionFilter{Semver: "< 2"}
+			})
+
+			It("returns the latest matching version", func() {
+				Expect(found).To(BeTrue())
+				Expect(string(resourceVersion)).To(Equal(convertToSHA256(atc.Version{"tag": "1.0.0"})))
+			})
+		})
+
+		Context("when the latest matching version is disabled", func() {
+			BeforeEach(func() {
+				filter = atc.VersionFilter{Semver: ">= 10, < 11"}
+			})
+
+			It("does not find it", func() {
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when matching metadata", func() {
+			BeforeEach(func() {
+				filter = atc.VersionFilter{Metadata: map[string]string{"channel": "stable"}}
+			})
+
+			It("returns the version with the metadata", func() {
+				Expect(found).To(BeTrue())
+				Expect(string(resourceVersion)).To(Equal(convertToSHA256(atc.Version{"tag": "1.0.0"})))
+			})
+		})
+
+		Context("when no version matches", func() {
+			BeforeEach(func() {
+				filter = atc.VersionFilter{Semver: ">= 20"}
+			})
+
+			It("does not find one", func() {
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("VersionMatchesFilter", func() {
+		var scenario *dbtest.Scenario
+
+		BeforeEach(func() {
+			scenario = dbtest.Setup(
+				builder.WithResourceVersions("some-resource", time.Minute, atc.Version{"tag": "1.0.0"}, atc.Version{"tag": "2.0.0"}),
+			)
+		})
+
+		It("reports whether the version matches", func() {
+			resourceID := scenario.Resource("some-resource").ID()
+			filter := atc.VersionFilter{Semver: "1.x"}
+
+			matches, err := vdb.VersionMatchesFilter(ctx, resourceID, db.ResourceVersion(convertToSHA256(atc.Version{"tag": "1.0.0"})), filter)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(matches).To(BeTrue())
+
+			matches, err = vdb.VersionMatchesFilter(ctx, resourceID, db.ResourceVersion(convertToSHA256(atc.Version{"tag": "2.0.0"})), filter)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(matches).To(BeFalse())
+		})
+	})
 })
@@ -8,6 +8,9 @@ type DebugVersionsDB struct {
 	BuildInputs      []DebugBuildInput
 	BuildReruns      []DebugBuildRerun
 
+	// not present before passed constraints could reference other pipelines
+	Dependencies []DebugDependency
+
 	// backwards-compatibility with pre-6.0 VersionsDB
 	LegacyJobIDs      map[string]int `json:"JobIDs,omitempty"`
 	LegacyResourceIDs map[string]int `json:"ResourceIDs,omitempty"`
@@ -51,3 +54,14 @@ type DebugResource struct {
 	ID      int
 	ScopeID *int
 }
+
+// DebugDependency is an edge from a job to a job its input passed through.
+type DebugDependency struct {
+	JobID       int
+	InputName   string
+	ResourceID  int
+	PassedJobID int
+
+	// set when the passed job is in another pipeline
+	PassedJobRef string `json:",omitempty"`
+}
@@ -0,0 +1,124 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager/v3"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/policy"
+)
+
+var ErrApprovalNotFound = errors.New("approval not found")
+
+func NewApproveStepDelegate(
+	build db.Build,
+	planID atc.PlanID,
+	state exec.RunState,
+	clock clock.Clock,
+	policyChecker policy.Checker,
+) *approveStepDelegate {
+	return &approveStepDelegate{
+		buildStepDelegate{
+			build:                build,
+			planID:               planID,
+			clock:                clock,
+			state:                state,
+			stdout:               nil,
+			stderr:               nil,
+			policyChecker:        policyChecker,
+			disableRedactSecrets: atc.DisableRedactSecrets,
+		},
+	}
+}
+
+type approveStepDelegate struct {
+	buildStepDelegate
+}
+
+func (delegate *approveStepDelegate) WaitingForApproval(logger lager.Logger, plan atc.ApprovePlan) (db.BuildApproval, error) {
+	err := delegate.build.RequestApproval(delegate.planID, plan.Name, plan.Users, plan.Roles)
+	if err != nil {
+		logger.Error("failed-to-request-approval", err)
+		return db.BuildApproval{}, err
+	}
+
+	approval, err := delegate.Approval(logger)
+	if err != nil {
+		return db.BuildApproval{}, err
+	}
+
+	if approval.Status != atc.ApprovalPending {
+		return approval, nil
+	}
+
+	err = delegate.build.SaveEvent(event.ApprovalPending{
+		Origin: event.Origin{
+			ID: event.OriginID(delegate.planID),
+		},
+		Time:    delegate.clock.Now().Unix(),
+		Name:    plan.Name,
+		Users:   plan.Users,
+		Roles:   plan.Roles,
+		Timeout: plan.Timeout,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-approval-pending-event", err)
+		return db.BuildApproval{}, err
+	}
+
+	logger.Info("waiting-for-approval")
+
+	return approval, nil
+}
+
+func (delegate *approveStepDelegate) Approval(logger lager.Logger) (db.BuildApproval, error) {
+	approval, found, err := delegate.build.Approval(delegate.planID)
+	if err != nil {
+		logger.Error("failed-to-get-approval", err)
+		return db.BuildApproval{}, err
+	}
+
+	if !found {
+		return db.BuildApproval{}, fmt.Errorf("%w: %s", ErrApprovalNotFound, delegate.planID)
+	}
+
+	return approval, nil
+}
+
+func (delegate *approveStepDelegate) TimeOutApproval(logger lager.Logger) (db.BuildApproval, error) {
+	_, err := delegate.build.DecideApproval(delegate.planID, atc.ApprovalTimedOut, "")
+	if err != nil {
+		logger.Error("failed-to-time-out-approval", err)
+		return db.BuildApproval{}, err
+	}
+
+	// the approval may have been decided just before timing out, in which
+	// case that decision stands
+	return delegate.Approval(logger)
+}
+
+func (delegate *approveStepDelegate) ApprovalDecided(logger lager.Logger, approval db.BuildApproval) {
+	err := delegate.build.SaveEvent(event.ApprovalDecided{
+		Origin: event.Origin{
+			ID: event.OriginID(delegate.planID),
+		},
+		Time:      delegate.clock.Now().Unix(),
+		Name:      approval.Name,
+		Status:    approval.Status,
+		DecidedBy: approval.DecidedBy,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-approval-decided-event", err)
+		return
+	}
+
+	logger.Info("approval-decided", lager.Data{
+		"status":     approval.Status,
+		"decided-by": approval.DecidedBy,
+	})
+}
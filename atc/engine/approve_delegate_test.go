@@ -0,0 +1,180 @@
+package engine_test
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/v3/lagertest"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/engine"
+	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/policy/policyfakes"
+	"github.com/concourse/concourse/vars"
+)
+
+var _ = Describe("ApproveStepDelegate", func() {
+	var (
+		logger            *lagertest.TestLogger
+		fakeBuild         *dbfakes.FakeBuild
+		fakeClock         *fakeclock.FakeClock
+		fakePolicyChecker *policyfakes.FakeChecker
+
+		state exec.RunState
+
+		now      = time.Date(1991, 6, 3, 5, 30, 0, 0, time.UTC)
+		delegate exec.ApproveStepDelegate
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeClock = fakeclock.NewFakeClock(now)
+		state = exec.NewRunState(noopStepper, vars.StaticVariables{})
+		fakePolicyChecker = new(policyfakes.FakeChecker)
+
+		delegate = engine.NewApproveStepDelegate(fakeBuild, "some-plan-id", state, fakeClock, fakePolicyChecker)
+	})
+
+	Describe("WaitingForApproval", func() {
+		var (
+			plan     atc.ApprovePlan
+			approval db.BuildApproval
+			err      error
+		)
+
+		BeforeEach(func() {
+			plan = atc.ApprovePlan{
+				Name:    "ship-it",
+				Users:   []string{"some-user"},
+				Roles:   []string{"owner"},
+				Timeout: "1h",
+			}
+		})
+
+		JustBeforeEach(func() {
+			approval, err = delegate.WaitingForApproval(logger, plan)
+		})
+
+		Context("when the approval is pending", func() {
+			BeforeEach(func() {
+				fakeBuild.ApprovalReturns(db.BuildApproval{
+					PlanID: "some-plan-id",
+					Name:   "ship-it",
+					Status: atc.ApprovalPending,
+				}, true, nil)
+			})
+
+			It("requests the approval", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(fakeBuild.RequestApprovalCallCount()).To(Equal(1))
+				planID, name, users, roles := fakeBuild.RequestApprovalArgsForCall(0)
+				Expect(planID).To(Equal(atc.PlanID("some-plan-id")))
+				Expect(name).To(Equal("ship-it"))
+				Expect(users).To(Equal([]string{"some-user"}))
+				Expect(roles).To(Equal([]string{"owner"}))
+			})
+
+			It("returns the approval", func() {
+				Expect(approval.Status).To(Equal(atc.ApprovalPending))
+			})
+
+			It("saves an approval-pending event", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+				Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.ApprovalPending{
+					Origin:  event.Origin{ID: event.OriginID("some-plan-id")},
+					Time:    now.Unix(),
+					Name:    "ship-it",
+					Users:   []string{"some-user"},
+					Roles:   []string{"owner"},
+					Timeout: "1h",
+				}))
+			})
+		})
+
+		Context("when the approval was already decided", func() {
+			BeforeEach(func() {
+				fakeBuild.ApprovalReturns(db.BuildApproval{
+					PlanID: "some-plan-id",
+					Name:   "ship-it",
+					Status: atc.ApprovalApproved,
+				}, true, nil)
+			})
+
+			It("does not save an event", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(approval.Status).To(Equal(atc.ApprovalApproved))
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when requesting the approval fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeBuild.RequestApprovalReturns(disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(err).To(Equal(disaster))
+			})
+		})
+	})
+
+	Describe("Approval", func() {
+		Context("when the approval does not exist", func() {
+			It("returns an error", func() {
+				_, err := delegate.Approval(logger)
+				Expect(err).To(MatchError(engine.ErrApprovalNotFound))
+			})
+		})
+	})
+
+	Describe("TimeOutApproval", func() {
+		BeforeEach(func() {
+			fakeBuild.ApprovalReturns(db.BuildApproval{
+				PlanID: "some-plan-id",
+				Status: atc.ApprovalTimedOut,
+			}, true, nil)
+		})
+
+		It("marks the approval as timed out and returns it", func() {
+			approval, err := delegate.TimeOutApproval(logger)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(approval.Status).To(Equal(atc.ApprovalTimedOut))
+
+			Expect(fakeBuild.DecideApprovalCallCount()).To(Equal(1))
+			planID, status, decidedBy := fakeBuild.DecideApprovalArgsForCall(0)
+			Expect(planID).To(Equal(atc.PlanID("some-plan-id")))
+			Expect(status).To(Equal(atc.ApprovalTimedOut))
+			Expect(decidedBy).To(BeEmpty())
+		})
+	})
+
+	Describe("ApprovalDecided", func() {
+		It("saves an approval-decided event", func() {
+			delegate.ApprovalDecided(logger, db.BuildApproval{
+				Name:      "ship-it",
+				Status:    atc.ApprovalRejected,
+				DecidedBy: "some-user",
+			})
+
+			Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+			Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.ApprovalDecided{
+				Origin:    event.Origin{ID: event.OriginID("some-plan-id")},
+				Time:      now.Unix(),
+				Name:      "ship-it",
+				Status:    atc.ApprovalRejected,
+				DecidedBy: "some-user",
+			}))
+		})
+	})
+})
@@ -198,6 +198,31 @@ func (delegate *buildStepDelegate) SelectedWorker(logger lager.Logger, worker st
 	}
 }
 
+func (delegate *buildStepDelegate) SecretsResolved(logger lager.Logger, resolved []exec.ResolvedVar) {
+	secrets := make([]event.ResolvedSecret, len(resolved))
+	for i, v := range resolved {
+		secrets[i] = event.ResolvedSecret{
+			Var:      v.Ref.String(),
+			Manager:  v.Resolution.Manager,
+			Path:     v.Resolution.SecretPath,
+			Redacted: v.Redactable && !delegate.disableRedactSecrets,
+		}
+	}
+
+	err := delegate.build.SaveEvent(event.SecretsResolved{
+		Time: delegate.clock.Now().Unix(),
+		Origin: event.Origin{
+			ID: event.OriginID(delegate.planID),
+		},
+		Secrets: secrets,
+	})
+
+	if err != nil {
+		logger.Error("failed-to-save-secrets-resolved-event", err)
+		return
+	}
+}
+
 func (delegate *buildStepDelegate) StreamingVolume(logger lager.Logger, volume string, sourceWorker string, destWorker string) {
 	err := delegate.build.SaveEvent(event.StreamingVolume{
 		Time: time.Now().Unix(),
@@ -244,6 +269,19 @@ func (delegate *buildStepDelegate) Errored(logger lager.Logger, message string)
 	}
 }
 
+func (delegate *buildStepDelegate) Skipped(logger lager.Logger, condition string) {
+	err := delegate.build.SaveEvent(event.Skipped{
+		Origin: event.Origin{
+			ID: event.OriginID(delegate.planID),
+		},
+		Time:      delegate.clock.Now().Unix(),
+		Condition: condition,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-skipped-event", err)
+	}
+}
+
 func (delegate *buildStepDelegate) FetchImage(
 	ctx context.Context,
 	getPlan atc.Plan,
@@ -904,6 +904,103 @@ var _ = Describe("BuildStepDelegate", func() {
 		})
 	})
 
+	Describe("Skipped", func() {
+		JustBeforeEach(func() {
+			delegate.Skipped(logger, "vars.deploy")
+		})
+
+		Context("when saving the event succeeds", func() {
+			BeforeEach(func() {
+				fakeBuild.SaveEventReturns(nil)
+			})
+
+			It("saves it with the current time", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+				Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.Skipped{
+					Time:      now.Unix(),
+					Condition: "vars.deploy",
+					Origin: event.Origin{
+						ID: "some-plan-id",
+					},
+				}))
+			})
+		})
+
+		Context("when saving the event fails", func() {
+			BeforeEach(func() {
+				fakeBuild.SaveEventReturns(errors.New("nope"))
+			})
+
+			It("logs an error", func() {
+				logs := logger.Logs()
+				Expect(len(logs)).To(Equal(1))
+				Expect(logs[0].Message).To(Equal("test.failed-to-save-skipped-event"))
+				Expect(logs[0].Data).To(Equal(lager.Data{"error": "nope"}))
+			})
+		})
+	})
+
+	Describe("SecretsResolved", func() {
+		var disableRedactSecrets bool
+
+		BeforeEach(func() {
+			disableRedactSecrets = false
+		})
+
+		JustBeforeEach(func() {
+			delegate = engine.NewBuildStepDelegate(fakeBuild, planID, runState, fakeClock, fakePolicyChecker, disableRedactSecrets)
+			delegate.SecretsResolved(logger, []exec.ResolvedVar{
+				{
+					Ref:        vars.Reference{Path: "github", Fields: []string{"token"}},
+					Resolution: vars.Resolution{Manager: "vault", SecretPath: "/concourse/main/github"},
+					Redactable: true,
+				},
+				{
+					Ref:        vars.Reference{Source: "some-source", Path: "flag"},
+					Resolution: vars.Resolution{Manager: "some-source", SecretPath: "flag"},
+					Redactable: false,
+				},
+			})
+		})
+
+		It("saves the vars without their values", func() {
+			Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+			Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.SecretsResolved{
+				Time: now.Unix(),
+				Origin: event.Origin{
+					ID: "some-plan-id",
+				},
+				Secrets: []event.ResolvedSecret{
+					{Var: "github.token", Manager: "vault", Path: "/concourse/main/github", Redacted: true},
+					{Var: "some-source:flag", Manager: "some-source", Path: "flag", Redacted: false},
+				},
+			}))
+		})
+
+		Context("when secret redaction is disabled", func() {
+			BeforeEach(func() {
+				disableRedactSecrets = true
+			})
+
+			It("reports that nothing was redacted", func() {
+				e := fakeBuild.SaveEventArgsForCall(0).(event.SecretsResolved)
+				Expect(e.Secrets[0].Redacted).To(BeFalse())
+			})
+		})
+
+		Context("when saving the event fails", func() {
+			BeforeEach(func() {
+				fakeBuild.SaveEventReturns(errors.New("nope"))
+			})
+
+			It("logs an error", func() {
+				logs := logger.Logs()
+				Expect(len(logs)).To(Equal(1))
+				Expect(logs[0].Message).To(Equal("test.failed-to-save-secrets-resolved-event"))
+			})
+		})
+	})
+
 	Describe("Secrets redaction", func() {
 		var (
 			runState     exec.RunState
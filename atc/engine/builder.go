@@ -24,6 +24,7 @@ type CoreStepFactory interface {
 	CheckStep(atc.Plan, exec.StepMetadata, db.ContainerMetadata, DelegateFactory) exec.Step
 	SetPipelineStep(atc.Plan, exec.StepMetadata, DelegateFactory) exec.Step
 	LoadVarStep(atc.Plan, exec.StepMetadata, DelegateFactory) exec.Step
+	ApproveStep(atc.Plan, exec.StepMetadata, DelegateFactory) exec.Step
 	ArtifactInputStep(atc.Plan, db.Build) exec.Step
 	ArtifactOutputStep(atc.Plan, db.Build) exec.Step
 }
@@ -105,6 +106,10 @@ func (pb *planBuilder) buildStep(plan atc.Plan) exec.Step {
 		return pb.buildDoStep(plan)
 	}
 
+	if plan.If != nil {
+		return pb.buildIfStep(plan)
+	}
+
 	if plan.Timeout != nil {
 		return pb.buildTimeoutStep(plan)
 	}
@@ -138,15 +143,19 @@ func (pb *planBuilder) buildStep(plan atc.Plan) exec.Step {
 	}
 
 	if plan.Task != nil {
-		return pb.buildTaskStep(plan)
+		return exec.RecordStatus(plan, pb.buildTaskStep(plan))
 	}
 
 	if plan.SetPipeline != nil {
-		return pb.buildSetPipelineStep(plan)
+		return exec.RecordStatus(plan, pb.buildSetPipelineStep(plan))
 	}
 
 	if plan.LoadVar != nil {
-		return pb.buildLoadVarStep(plan)
+		return exec.RecordStatus(plan, pb.buildLoadVarStep(plan))
+	}
+
+	if plan.Approve != nil {
+		return exec.RecordStatus(plan, pb.buildApproveStep(plan))
 	}
 
 	if plan.Check != nil {
@@ -154,11 +163,11 @@ func (pb *planBuilder) buildStep(plan atc.Plan) exec.Step {
 	}
 
 	if plan.Get != nil {
-		return pb.buildGetStep(plan)
+		return exec.RecordStatus(plan, pb.buildGetStep(plan))
 	}
 
 	if plan.Put != nil {
-		return pb.buildPutStep(plan)
+		return exec.RecordStatus(plan, pb.buildPutStep(plan))
 	}
 
 	if plan.Retry != nil {
@@ -221,6 +230,21 @@ func (pb *planBuilder) buildTimeoutStep(plan atc.Plan) exec.Step {
 	return exec.Timeout(step, plan.Timeout.Duration)
 }
 
+func (pb *planBuilder) buildIfStep(plan atc.Plan) exec.Step {
+	innerPlan := plan.If.Step
+	innerPlan.Attempts = plan.Attempts
+	step := pb.buildStep(innerPlan)
+
+	ifStep := exec.If(
+		*plan.If,
+		step,
+		pb.stepMetadata(false),
+		pb.buildDelegateFactory(plan),
+	)
+
+	return exec.LogError(ifStep, pb.buildDelegateFactory(plan))
+}
+
 func (pb *planBuilder) buildTryStep(plan atc.Plan) exec.Step {
 	innerPlan := plan.Try.Step
 	innerPlan.Attempts = plan.Attempts
@@ -391,6 +415,17 @@ func (pb *planBuilder) buildLoadVarStep(plan atc.Plan) exec.Step {
 	)
 }
 
+func (pb *planBuilder) buildApproveStep(plan atc.Plan) exec.Step {
+
+	stepMetadata := pb.stepMetadata(false)
+
+	return pb.factory.coreFactory.ApproveStep(
+		plan,
+		stepMetadata,
+		pb.buildDelegateFactory(plan),
+	)
+}
+
 func (pb *planBuilder) buildArtifactInputStep(plan atc.Plan) exec.Step {
 	return pb.factory.coreFactory.ArtifactInputStep(
 		plan,
@@ -448,6 +483,7 @@ func (pb *planBuilder) stepMetadata(
 		TeamName:             pb.build.TeamName(),
 		JobID:                pb.build.JobID(),
 		JobName:              pb.build.JobName(),
+		JobPriority:          pb.build.JobPriority(),
 		PipelineID:           pb.build.PipelineID(),
 		PipelineName:         pb.build.PipelineName(),
 		PipelineInstanceVars: pb.build.PipelineInstanceVars(),
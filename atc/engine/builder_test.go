@@ -490,6 +490,32 @@ var _ = Describe("Builder", func() {
 					})
 				})
 
+				Context("with an if plan", func() {
+					var taskPlan atc.Plan
+
+					BeforeEach(func() {
+						taskPlan = planFactory.NewPlan(atc.TaskPlan{
+							Name:       "some-task",
+							ConfigPath: "some-config-path",
+						})
+
+						expectedPlan = planFactory.NewPlan(atc.IfPlan{
+							Step:      taskPlan,
+							Condition: "vars.deploy",
+						})
+						expectedPlan.Attempts = []int{2}
+					})
+
+					It("constructs the wrapped step", func() {
+						Expect(fakeCoreStepFactory.TaskStepCallCount()).To(Equal(1))
+
+						plan, stepMetadata, containerMetadata, _ := fakeCoreStepFactory.TaskStepArgsForCall(0)
+						Expect(plan.ID).To(Equal(taskPlan.ID))
+						Expect(stepMetadata).To(Equal(expectedMetadataWithoutCreatedBy))
+						Expect(containerMetadata.Attempt).To(Equal("2"))
+					})
+				})
+
 				Context("with a basic plan", func() {
 
 					Context("that contains inputs", func() {
@@ -226,9 +226,13 @@ func (b *engineBuild) Run(ctx context.Context) {
 			return
 		}
 
+		b.saveResolvedVars(logger, state)
+
 		// An in-memory build only generates a real build id once start to run,
 		// so let's update logger with the latest lager data.
 		b.finish(logger.Session("finish").WithData(b.build.LagerData()), runErr, succeeded)
+
+		b.revokeLeases(logger.Session("revoke-leases").WithData(b.build.LagerData()))
 	}
 }
 
@@ -264,6 +268,38 @@ func (b *engineBuild) finish(logger lager.Logger, err error, succeeded bool) {
 	}
 }
 
+// saveResolvedVars records which vars the build resolved so that their usages
+// can be found, e.g. when rotating them. Checks are left out, as the vars they
+// resolve are recorded through their resources' configs.
+func (b *engineBuild) saveResolvedVars(logger lager.Logger, state exec.RunState) {
+	if b.build.Name() == db.CheckBuildName {
+		return
+	}
+
+	err := b.build.SaveResolvedVars(state.ResolvedCredVars())
+	if err != nil {
+		logger.Error("failed-to-save-resolved-vars", err)
+	}
+}
+
+// revokeLeases revokes the dynamic secrets which the build leased, whether it
+// succeeded, failed or was aborted, so that they do not outlive it.
+func (b *engineBuild) revokeLeases(logger lager.Logger) {
+	runStateID := b.build.RunStateID()
+
+	err := creds.RevokeLeases(logger, b.globalSecrets, runStateID)
+	if err != nil {
+		logger.Error("failed-to-revoke-leases", err)
+	}
+
+	if b.varSourcePool != nil {
+		err = b.varSourcePool.RevokeLeases(logger, runStateID)
+		if err != nil {
+			logger.Error("failed-to-revoke-var-source-leases", err)
+		}
+	}
+}
+
 func (b *engineBuild) saveStatus(logger lager.Logger, status atc.BuildStatus) {
 	if err := b.build.Finish(db.BuildStatus(status)); err != nil {
 		logger.Error("failed-to-finish-build", err)
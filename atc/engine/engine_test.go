@@ -191,6 +191,21 @@ var _ = Describe("Engine", func() {
 									Expect(val).To(Equal("bar"))
 								})
 
+								Context("when the build resolves vars", func() {
+									BeforeEach(func() {
+										fakeStep.RunStub = func(ctx context.Context, state exec.RunState) (bool, error) {
+											_, _, err := state.Get(vars.Reference{Path: "foo"})
+											return true, err
+										}
+									})
+
+									It("saves the vars which it resolved", func() {
+										waitGroup.Wait()
+										Expect(fakeBuild.SaveResolvedVarsCallCount()).To(Equal(1))
+										Expect(fakeBuild.SaveResolvedVarsArgsForCall(0)).To(ConsistOf(vars.Reference{Path: "foo"}))
+									})
+								})
+
 								Context("when the build is released", func() {
 									BeforeEach(func() {
 										readyToRelease := make(chan bool)
@@ -211,6 +226,11 @@ var _ = Describe("Engine", func() {
 										waitGroup.Wait()
 										Expect(fakeBuild.FinishCallCount()).To(Equal(0))
 									})
+
+									It("does not revoke the build's leases", func() {
+										waitGroup.Wait()
+										Expect(fakeVarSourcePool.RevokeLeasesCallCount()).To(Equal(0))
+									})
 								})
 
 								Context("when the build is aborted", func() {
@@ -234,6 +254,11 @@ var _ = Describe("Engine", func() {
 										stepCtx, _ := fakeStep.RunArgsForCall(0)
 										Expect(stepCtx.Done()).To(BeClosed())
 									})
+
+									It("revokes the build's leases", func() {
+										waitGroup.Wait()
+										Expect(fakeVarSourcePool.RevokeLeasesCallCount()).To(Equal(1))
+									})
 								})
 
 								Context("when the build finishes successfully", func() {
@@ -246,6 +271,47 @@ var _ = Describe("Engine", func() {
 										Expect(fakeBuild.FinishCallCount()).To(Equal(1))
 										Expect(fakeBuild.FinishArgsForCall(0)).To(Equal(db.BuildStatusSucceeded))
 									})
+
+									Context("when the build leased secrets", func() {
+										var fakeLeasedCreds *credsfakes.FakeLeasedSecrets
+
+										BeforeEach(func() {
+											fakeBuild.RunStateIDReturns("build:128")
+
+											fakeLeasedCreds = new(credsfakes.FakeLeasedSecrets)
+											build = NewBuild(
+												fakeBuild,
+												fakeStepperFactory,
+												fakeLeasedCreds,
+												fakeVarSourcePool,
+												release,
+												new(sync.Map),
+												waitGroup,
+											)
+										})
+
+										It("revokes the leases after finishing the build", func() {
+											waitGroup.Wait()
+											Expect(fakeLeasedCreds.RevokeLeasesCallCount()).To(Equal(1))
+											_, runStateID := fakeLeasedCreds.RevokeLeasesArgsForCall(0)
+											Expect(runStateID).To(Equal("build:128"))
+
+											Expect(fakeVarSourcePool.RevokeLeasesCallCount()).To(Equal(1))
+											_, runStateID = fakeVarSourcePool.RevokeLeasesArgsForCall(0)
+											Expect(runStateID).To(Equal("build:128"))
+										})
+
+										Context("when revoking fails", func() {
+											BeforeEach(func() {
+												fakeLeasedCreds.RevokeLeasesReturns(errors.New("nope"))
+											})
+
+											It("still finishes the build", func() {
+												waitGroup.Wait()
+												Expect(fakeBuild.FinishCallCount()).To(Equal(1))
+											})
+										})
+									})
 								})
 
 								Context("when the build finishes woefully", func() {
@@ -338,7 +404,8 @@ var _ = Describe("Engine", func() {
 								})
 
 								It("build.RunState should be called", func() {
-									Expect(fakeBuild.RunStateIDCallCount()).To(Equal(2))
+									// once each to track the run state, clear it and revoke its leases
+									Expect(fakeBuild.RunStateIDCallCount()).To(Equal(3))
 								})
 							})
 
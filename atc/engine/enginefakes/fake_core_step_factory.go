@@ -11,6 +11,19 @@ import (
 )
 
 type FakeCoreStepFactory struct {
+	ApproveStepStub        func(atc.Plan, exec.StepMetadata, engine.DelegateFactory) exec.Step
+	approveStepMutex       sync.RWMutex
+	approveStepArgsForCall []struct {
+		arg1 atc.Plan
+		arg2 exec.StepMetadata
+		arg3 engine.DelegateFactory
+	}
+	approveStepReturns struct {
+		result1 exec.Step
+	}
+	approveStepReturnsOnCall map[int]struct {
+		result1 exec.Step
+	}
 	ArtifactInputStepStub        func(atc.Plan, db.Build) exec.Step
 	artifactInputStepMutex       sync.RWMutex
 	artifactInputStepArgsForCall []struct {
@@ -135,6 +148,69 @@ type FakeCoreStepFactory struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeCoreStepFactory) ApproveStep(arg1 atc.Plan, arg2 exec.StepMetadata, arg3 engine.DelegateFactory) exec.Step {
+	fake.approveStepMutex.Lock()
+	ret, specificReturn := fake.approveStepReturnsOnCall[len(fake.approveStepArgsForCall)]
+	fake.approveStepArgsForCall = append(fake.approveStepArgsForCall, struct {
+		arg1 atc.Plan
+		arg2 exec.StepMetadata
+		arg3 engine.DelegateFactory
+	}{arg1, arg2, arg3})
+	stub := fake.ApproveStepStub
+	fakeReturns := fake.approveStepReturns
+	fake.recordInvocation("ApproveStep", []interface{}{arg1, arg2, arg3})
+	fake.approveStepMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeCoreStepFactory) ApproveStepCallCount() int {
+	fake.approveStepMutex.RLock()
+	defer fake.approveStepMutex.RUnlock()
+	return len(fake.approveStepArgsForCall)
+}
+
+func (fake *FakeCoreStepFactory) ApproveStepCalls(stub func(atc.Plan, exec.StepMetadata, engine.DelegateFactory) exec.Step) {
+	fake.approveStepMutex.Lock()
+	defer fake.approveStepMutex.Unlock()
+	fake.ApproveStepStub = stub
+}
+
+func (fake *FakeCoreStepFactory) ApproveStepArgsForCall(i int) (atc.Plan, exec.StepMetadata, engine.DelegateFactory) {
+	fake.approveStepMutex.RLock()
+	defer fake.approveStepMutex.RUnlock()
+	argsForCall := fake.approveStepArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeCoreStepFactory) ApproveStepReturns(result1 exec.Step) {
+	fake.approveStepMutex.Lock()
+	defer fake.approveStepMutex.Unlock()
+	fake.ApproveStepStub = nil
+	fake.approveStepReturns = struct {
+		result1 exec.Step
+	}{result1}
+}
+
+func (fake *FakeCoreStepFactory) ApproveStepReturnsOnCall(i int, result1 exec.Step) {
+	fake.approveStepMutex.Lock()
+	defer fake.approveStepMutex.Unlock()
+	fake.ApproveStepStub = nil
+	if fake.approveStepReturnsOnCall == nil {
+		fake.approveStepReturnsOnCall = make(map[int]struct {
+			result1 exec.Step
+		})
+	}
+	fake.approveStepReturnsOnCall[i] = struct {
+		result1 exec.Step
+	}{result1}
+}
+
 func (fake *FakeCoreStepFactory) ArtifactInputStep(arg1 atc.Plan, arg2 db.Build) exec.Step {
 	fake.artifactInputStepMutex.Lock()
 	ret, specificReturn := fake.artifactInputStepReturnsOnCall[len(fake.artifactInputStepArgsForCall)]
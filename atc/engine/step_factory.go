@@ -31,6 +31,7 @@ type coreStepFactory struct {
 	defaultPutTimeout     time.Duration
 	defaultTaskTimeout    time.Duration
 	defaultTaskCacheTTL   time.Duration
+	idTokenGenerator      exec.IDTokenGenerator
 }
 
 func NewCoreStepFactory(
@@ -50,6 +51,7 @@ func NewCoreStepFactory(
 	defaultPutTimeout time.Duration,
 	defaultTaskTimeout time.Duration,
 	defaultTaskCacheTTL time.Duration,
+	idTokenGenerator exec.IDTokenGenerator,
 ) CoreStepFactory {
 	return &coreStepFactory{
 		pool:                  pool,
@@ -68,6 +70,7 @@ func NewCoreStepFactory(
 		defaultPutTimeout:     defaultPutTimeout,
 		defaultTaskTimeout:    defaultTaskTimeout,
 		defaultTaskCacheTTL:   defaultTaskCacheTTL,
+		idTokenGenerator:      idTokenGenerator,
 	}
 }
 
@@ -92,6 +95,7 @@ func (factory *coreStepFactory) GetStep(
 		factory.defaultGetTimeout,
 	)
 
+	getStep = exec.ReportResolvedVars(getStep, delegateFactory)
 	getStep = exec.LogError(getStep, delegateFactory)
 	if atc.EnableBuildRerunWhenWorkerDisappears {
 		getStep = exec.RetryError(getStep, delegateFactory)
@@ -118,6 +122,7 @@ func (factory *coreStepFactory) PutStep(
 		factory.defaultPutTimeout,
 	)
 
+	putStep = exec.ReportResolvedVars(putStep, delegateFactory)
 	putStep = exec.LogError(putStep, delegateFactory)
 	if atc.EnableBuildRerunWhenWorkerDisappears {
 		putStep = exec.RetryError(putStep, delegateFactory)
@@ -146,6 +151,7 @@ func (factory *coreStepFactory) CheckStep(
 		factory.defaultCheckTimeout,
 	)
 
+	checkStep = exec.ReportResolvedVars(checkStep, delegateFactory)
 	checkStep = exec.LogError(checkStep, delegateFactory)
 	if atc.EnableBuildRerunWhenWorkerDisappears {
 		checkStep = exec.RetryError(checkStep, delegateFactory)
@@ -167,6 +173,7 @@ func (factory *coreStepFactory) RunStep(
 		delegateFactory,
 	)
 
+	runStep = exec.ReportResolvedVars(runStep, delegateFactory)
 	runStep = exec.LogError(runStep, delegateFactory)
 	if atc.EnableBuildRerunWhenWorkerDisappears {
 		runStep = exec.RetryError(runStep, delegateFactory)
@@ -193,10 +200,12 @@ func (factory *coreStepFactory) TaskStep(
 		factory.pool,
 		factory.streamer,
 		delegateFactory,
+		factory.idTokenGenerator,
 		factory.defaultTaskTimeout,
 		factory.defaultTaskCacheTTL,
 	)
 
+	taskStep = exec.ReportResolvedVars(taskStep, delegateFactory)
 	taskStep = exec.LogError(taskStep, delegateFactory)
 	if atc.EnableBuildRerunWhenWorkerDisappears {
 		taskStep = exec.RetryError(taskStep, delegateFactory)
@@ -219,6 +228,7 @@ func (factory *coreStepFactory) SetPipelineStep(
 		factory.streamer,
 	)
 
+	spStep = exec.ReportResolvedVars(spStep, delegateFactory)
 	spStep = exec.LogError(spStep, delegateFactory)
 	if atc.EnableBuildRerunWhenWorkerDisappears {
 		spStep = exec.RetryError(spStep, delegateFactory)
@@ -239,6 +249,7 @@ func (factory *coreStepFactory) LoadVarStep(
 		factory.streamer,
 	)
 
+	loadVarStep = exec.ReportResolvedVars(loadVarStep, delegateFactory)
 	loadVarStep = exec.LogError(loadVarStep, delegateFactory)
 	if atc.EnableBuildRerunWhenWorkerDisappears {
 		loadVarStep = exec.RetryError(loadVarStep, delegateFactory)
@@ -246,6 +257,21 @@ func (factory *coreStepFactory) LoadVarStep(
 	return loadVarStep
 }
 
+func (factory *coreStepFactory) ApproveStep(
+	plan atc.Plan,
+	stepMetadata exec.StepMetadata,
+	delegateFactory DelegateFactory,
+) exec.Step {
+	approveStep := exec.NewApproveStep(
+		plan.ID,
+		*plan.Approve,
+		stepMetadata,
+		delegateFactory,
+	)
+
+	return exec.LogError(approveStep, delegateFactory)
+}
+
 func (factory *coreStepFactory) ArtifactInputStep(
 	plan atc.Plan,
 	build db.Build,
@@ -228,6 +228,38 @@ type SetPipelineChanged struct {
 func (SetPipelineChanged) EventType() atc.EventType  { return EventTypeSetPipelineChanged }
 func (SetPipelineChanged) Version() atc.EventVersion { return "1.0" }
 
+type ApprovalPending struct {
+	Origin  Origin   `json:"origin"`
+	Time    int64    `json:"time"`
+	Name    string   `json:"name"`
+	Users   []string `json:"users,omitempty"`
+	Roles   []string `json:"roles,omitempty"`
+	Timeout string   `json:"timeout,omitempty"`
+}
+
+func (ApprovalPending) EventType() atc.EventType  { return EventTypeApprovalPending }
+func (ApprovalPending) Version() atc.EventVersion { return "1.0" }
+
+type ApprovalDecided struct {
+	Origin    Origin             `json:"origin"`
+	Time      int64              `json:"time"`
+	Name      string             `json:"name"`
+	Status    atc.ApprovalStatus `json:"status"`
+	DecidedBy string             `json:"decided_by,omitempty"`
+}
+
+func (ApprovalDecided) EventType() atc.EventType  { return EventTypeApprovalDecided }
+func (ApprovalDecided) Version() atc.EventVersion { return "1.0" }
+
+type Skipped struct {
+	Origin    Origin `json:"origin"`
+	Time      int64  `json:"time"`
+	Condition string `json:"condition"`
+}
+
+func (Skipped) EventType() atc.EventType  { return EventTypeSkipped }
+func (Skipped) Version() atc.EventVersion { return "1.0" }
+
 type Initialize struct {
 	Origin Origin `json:"origin"`
 	Time   int64  `json:"time,omitempty"`
@@ -279,3 +311,28 @@ type AcrossSubsteps struct {
 
 func (AcrossSubsteps) EventType() atc.EventType  { return EventTypeAcrossSubsteps }
 func (AcrossSubsteps) Version() atc.EventVersion { return "1.0" }
+
+// SecretsResolved records the credential vars which a step resolved, for
+// auditing. Values are never included.
+type SecretsResolved struct {
+	Time    int64            `json:"time"`
+	Origin  Origin           `json:"origin"`
+	Secrets []ResolvedSecret `json:"secrets"`
+}
+
+func (SecretsResolved) EventType() atc.EventType  { return EventTypeSecretsResolved }
+func (SecretsResolved) Version() atc.EventVersion { return "1.0" }
+
+type ResolvedSecret struct {
+	// The var as it is referenced, e.g. some-vault:github.token.
+	Var string `json:"var"`
+
+	// The credential manager or var_source which served the var.
+	Manager string `json:"manager,omitempty"`
+
+	// The path of the secret in the manager.
+	Path string `json:"path,omitempty"`
+
+	// Whether the value is redacted from the step's output.
+	Redacted bool `json:"redacted"`
+}
@@ -45,6 +45,9 @@ func init() {
 	RegisterEvent(StartPut{})
 	RegisterEvent(FinishPut{})
 	RegisterEvent(SetPipelineChanged{})
+	RegisterEvent(ApprovalPending{})
+	RegisterEvent(ApprovalDecided{})
+	RegisterEvent(Skipped{})
 	RegisterEvent(Status{})
 	RegisterEvent(WaitingForWorker{})
 	RegisterEvent(SelectedWorker{})
@@ -55,6 +58,7 @@ func init() {
 	RegisterEvent(ImageCheck{})
 	RegisterEvent(ImageGet{})
 	RegisterEvent(AcrossSubsteps{})
+	RegisterEvent(SecretsResolved{})
 
 	// deprecated:
 	RegisterEvent(InitializeV10{})
@@ -66,6 +66,9 @@ var _ = Describe("ParseEvent", func() {
 		Entry("StartPut", event.StartPut{}),
 		Entry("FinishPut", event.FinishPut{}),
 		Entry("SetPipelineChanged", event.SetPipelineChanged{}),
+		Entry("ApprovalPending", event.ApprovalPending{}),
+		Entry("ApprovalDecided", event.ApprovalDecided{}),
+		Entry("Skipped", event.Skipped{}),
 		Entry("Status", event.Status{}),
 		Entry("WaitingForWorker", event.WaitingForWorker{}),
 		Entry("SelectedWorker", event.SelectedWorker{}),
@@ -75,5 +78,6 @@ var _ = Describe("ParseEvent", func() {
 		Entry("ImageCheck", event.ImageCheck{}),
 		Entry("ImageGet", event.ImageGet{}),
 		Entry("AcrossSubsteps", event.AcrossSubsteps{}),
+		Entry("SecretsResolved", event.SecretsResolved{}),
 	)
 })
@@ -53,6 +53,15 @@ const (
 
 	EventTypeSetPipelineChanged atc.EventType = "set-pipeline-changed"
 
+	// an approve step is waiting for a decision
+	EventTypeApprovalPending atc.EventType = "approval-pending"
+
+	// an approve step was approved, rejected, or timed out
+	EventTypeApprovalDecided atc.EventType = "approval-decided"
+
+	// a step was skipped because its `if:` condition did not hold
+	EventTypeSkipped atc.EventType = "skipped"
+
 	// initialize step
 	EventTypeInitialize atc.EventType = "initialize"
 
@@ -73,4 +82,7 @@ const (
 
 	// across step substeps (sent dynamically as of Concourse 7.4)
 	EventTypeAcrossSubsteps atc.EventType = "across-substeps"
+
+	// the credential vars a step resolved, without their values
+	EventTypeSecretsResolved atc.EventType = "secrets-resolved"
 )
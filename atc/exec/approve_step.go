@@ -0,0 +1,116 @@
+package exec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/lager/v3"
+	"code.cloudfoundry.org/lager/v3/lagerctx"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/tracing"
+)
+
+var ApprovalPollInterval = 5 * time.Second
+
+// ApproveStep blocks the build until a user approves or rejects it through
+// the API, or until its timeout elapses.
+type ApproveStep struct {
+	planID          atc.PlanID
+	plan            atc.ApprovePlan
+	metadata        StepMetadata
+	delegateFactory ApproveStepDelegateFactory
+}
+
+func NewApproveStep(
+	planID atc.PlanID,
+	plan atc.ApprovePlan,
+	metadata StepMetadata,
+	delegateFactory ApproveStepDelegateFactory,
+) Step {
+	return &ApproveStep{
+		planID:          planID,
+		plan:            plan,
+		metadata:        metadata,
+		delegateFactory: delegateFactory,
+	}
+}
+
+func (step *ApproveStep) Run(ctx context.Context, state RunState) (bool, error) {
+	delegate := step.delegateFactory.ApproveStepDelegate(state)
+	ctx, span := delegate.StartSpan(ctx, "approve", tracing.Attrs{
+		"name": step.plan.Name,
+	})
+
+	ok, err := step.run(ctx, delegate)
+	tracing.End(span, err)
+
+	return ok, err
+}
+
+func (step *ApproveStep) run(ctx context.Context, delegate ApproveStepDelegate) (bool, error) {
+	logger := lagerctx.FromContext(ctx)
+	logger = logger.Session("approve-step", lager.Data{
+		"step-name": step.plan.Name,
+		"job-id":    step.metadata.JobID,
+	})
+
+	delegate.Initializing(logger)
+
+	var timeout time.Duration
+	if step.plan.Timeout != "" {
+		var err error
+		timeout, err = time.ParseDuration(step.plan.Timeout)
+		if err != nil {
+			return false, fmt.Errorf("parse timeout: %w", err)
+		}
+	}
+
+	delegate.Starting(logger)
+
+	approval, err := delegate.WaitingForApproval(logger, step.plan)
+	if err != nil {
+		return false, err
+	}
+
+	// the deadline is based on when the approval was first requested so that
+	// resuming a build does not restart the timeout
+	waitCtx := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithDeadline(ctx, approval.CreatedAt.Add(timeout))
+		defer cancel()
+	}
+
+	ticker := time.NewTicker(ApprovalPollInterval)
+	defer ticker.Stop()
+
+	for approval.Status == atc.ApprovalPending {
+		select {
+		case <-waitCtx.Done():
+			if !errors.Is(waitCtx.Err(), context.DeadlineExceeded) || ctx.Err() != nil {
+				return false, ctx.Err()
+			}
+
+			approval, err = delegate.TimeOutApproval(logger)
+			if err != nil {
+				return false, err
+			}
+
+		case <-ticker.C:
+			approval, err = delegate.Approval(logger)
+			if err != nil {
+				return false, err
+			}
+		}
+	}
+
+	delegate.ApprovalDecided(logger, approval)
+
+	succeeded := approval.Status == atc.ApprovalApproved
+	delegate.Finished(logger, succeeded)
+
+	return succeeded, nil
+}
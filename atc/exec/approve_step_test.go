@@ -0,0 +1,216 @@
+package exec_test
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/lager/v3/lagerctx"
+	"code.cloudfoundry.org/lager/v3/lagertest"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/tracing"
+)
+
+var _ = Describe("ApproveStep", func() {
+	var (
+		ctx        context.Context
+		cancel     func()
+		testLogger *lagertest.TestLogger
+
+		fakeDelegate        *execfakes.FakeApproveStepDelegate
+		fakeDelegateFactory *execfakes.FakeApproveStepDelegateFactory
+
+		approvePlan atc.ApprovePlan
+		state       *execfakes.FakeRunState
+
+		step    exec.Step
+		stepOk  bool
+		stepErr error
+
+		stepMetadata = exec.StepMetadata{
+			TeamID:       123,
+			TeamName:     "some-team",
+			BuildID:      42,
+			BuildName:    "some-build",
+			PipelineID:   4567,
+			PipelineName: "some-pipeline",
+		}
+
+		pending = db.BuildApproval{
+			PlanID:    "56",
+			Name:      "ship-it",
+			Status:    atc.ApprovalPending,
+			CreatedAt: time.Now(),
+		}
+	)
+
+	BeforeEach(func() {
+		testLogger = lagertest.NewTestLogger("approve-step-test")
+		ctx, cancel = context.WithCancel(context.Background())
+		ctx = lagerctx.NewContext(ctx, testLogger)
+
+		state = new(execfakes.FakeRunState)
+
+		fakeDelegate = new(execfakes.FakeApproveStepDelegate)
+		fakeDelegate.StartSpanReturns(ctx, tracing.NoopSpan)
+		fakeDelegate.WaitingForApprovalReturns(pending, nil)
+
+		fakeDelegateFactory = new(execfakes.FakeApproveStepDelegateFactory)
+		fakeDelegateFactory.ApproveStepDelegateReturns(fakeDelegate)
+
+		approvePlan = atc.ApprovePlan{Name: "ship-it"}
+
+		exec.ApprovalPollInterval = time.Millisecond
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	JustBeforeEach(func() {
+		step = exec.NewApproveStep(
+			"56",
+			approvePlan,
+			stepMetadata,
+			fakeDelegateFactory,
+		)
+
+		stepOk, stepErr = step.Run(ctx, state)
+	})
+
+	It("waits for approval", func() {
+		Expect(fakeDelegate.WaitingForApprovalCallCount()).To(Equal(1))
+		_, plan := fakeDelegate.WaitingForApprovalArgsForCall(0)
+		Expect(plan).To(Equal(approvePlan))
+	})
+
+	Context("when the approval is approved", func() {
+		BeforeEach(func() {
+			fakeDelegate.ApprovalReturnsOnCall(0, pending, nil)
+			fakeDelegate.ApprovalReturnsOnCall(1, db.BuildApproval{
+				Name:      "ship-it",
+				Status:    atc.ApprovalApproved,
+				DecidedBy: "some-user",
+			}, nil)
+		})
+
+		It("succeeds", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stepOk).To(BeTrue())
+		})
+
+		It("polls until the approval is decided", func() {
+			Expect(fakeDelegate.ApprovalCallCount()).To(Equal(2))
+		})
+
+		It("emits the decision", func() {
+			Expect(fakeDelegate.ApprovalDecidedCallCount()).To(Equal(1))
+			_, approval := fakeDelegate.ApprovalDecidedArgsForCall(0)
+			Expect(approval.Status).To(Equal(atc.ApprovalApproved))
+			Expect(approval.DecidedBy).To(Equal("some-user"))
+		})
+
+		It("finishes the step successfully", func() {
+			Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+			_, succeeded := fakeDelegate.FinishedArgsForCall(0)
+			Expect(succeeded).To(BeTrue())
+		})
+	})
+
+	Context("when the approval is rejected", func() {
+		BeforeEach(func() {
+			fakeDelegate.ApprovalReturns(db.BuildApproval{
+				Name:      "ship-it",
+				Status:    atc.ApprovalRejected,
+				DecidedBy: "some-user",
+			}, nil)
+		})
+
+		It("fails", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stepOk).To(BeFalse())
+		})
+
+		It("finishes the step unsuccessfully", func() {
+			Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+			_, succeeded := fakeDelegate.FinishedArgsForCall(0)
+			Expect(succeeded).To(BeFalse())
+		})
+	})
+
+	Context("when the approval was already decided", func() {
+		BeforeEach(func() {
+			fakeDelegate.WaitingForApprovalReturns(db.BuildApproval{
+				Name:   "ship-it",
+				Status: atc.ApprovalApproved,
+			}, nil)
+		})
+
+		It("succeeds without polling", func() {
+			Expect(stepOk).To(BeTrue())
+			Expect(fakeDelegate.ApprovalCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the timeout elapses", func() {
+		BeforeEach(func() {
+			approvePlan.Timeout = "10ms"
+			exec.ApprovalPollInterval = time.Hour
+
+			fakeDelegate.TimeOutApprovalReturns(db.BuildApproval{
+				Name:   "ship-it",
+				Status: atc.ApprovalTimedOut,
+			}, nil)
+		})
+
+		It("times out the approval", func() {
+			Expect(fakeDelegate.TimeOutApprovalCallCount()).To(Equal(1))
+		})
+
+		It("fails", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stepOk).To(BeFalse())
+		})
+
+		Context("when the approval was decided before it could time out", func() {
+			BeforeEach(func() {
+				fakeDelegate.TimeOutApprovalReturns(db.BuildApproval{
+					Name:   "ship-it",
+					Status: atc.ApprovalApproved,
+				}, nil)
+			})
+
+			It("honours the decision", func() {
+				Expect(stepOk).To(BeTrue())
+			})
+		})
+	})
+
+	Context("when the timeout is invalid", func() {
+		BeforeEach(func() {
+			approvePlan.Timeout = "forever"
+		})
+
+		It("errors", func() {
+			Expect(stepErr).To(HaveOccurred())
+			Expect(fakeDelegate.WaitingForApprovalCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the build is aborted", func() {
+		BeforeEach(func() {
+			exec.ApprovalPollInterval = time.Hour
+			cancel()
+		})
+
+		It("returns the context error", func() {
+			Expect(stepErr).To(Equal(context.Canceled))
+			Expect(fakeDelegate.ApprovalDecidedCallCount()).To(Equal(0))
+		})
+	})
+})
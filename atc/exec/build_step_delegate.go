@@ -32,12 +32,14 @@ type BuildStepDelegate interface {
 	Starting(lager.Logger)
 	Finished(lager.Logger, bool)
 	Errored(lager.Logger, string)
+	Skipped(lager.Logger, string)
 
 	BeforeSelectWorker(lager.Logger) error
 	WaitingForWorker(lager.Logger)
 	SelectedWorker(lager.Logger, string)
 	StreamingVolume(lager.Logger, string, string, string)
 	WaitingForStreamedVolume(lager.Logger, string, string)
+	SecretsResolved(lager.Logger, []ResolvedVar)
 	BuildStartTime() time.Time
 
 	ConstructAcrossSubsteps([]byte, []atc.AcrossVar, [][]any) ([]atc.VarScopedPlan, error)
@@ -55,3 +57,17 @@ type SetPipelineStepDelegate interface {
 	SetPipelineChanged(lager.Logger, bool)
 	CheckRunSetPipelinePolicy(targetPipeline string, config *atc.Config) error
 }
+
+//counterfeiter:generate . ApproveStepDelegateFactory
+type ApproveStepDelegateFactory interface {
+	ApproveStepDelegate(state RunState) ApproveStepDelegate
+}
+
+//counterfeiter:generate . ApproveStepDelegate
+type ApproveStepDelegate interface {
+	BuildStepDelegate
+	WaitingForApproval(lager.Logger, atc.ApprovePlan) (db.BuildApproval, error)
+	Approval(lager.Logger) (db.BuildApproval, error)
+	TimeOutApproval(lager.Logger) (db.BuildApproval, error)
+	ApprovalDecided(lager.Logger, db.BuildApproval)
+}
@@ -10,6 +10,7 @@ type buildVariables struct {
 	parentScope interface {
 		vars.Variables
 		IterateInterpolatedCreds(iter vars.TrackedVarsIterator)
+		ResolvedCredVars() []vars.Reference
 	}
 
 	localVars vars.StaticVariables
@@ -30,15 +31,22 @@ func newBuildVariables(credVars vars.Variables) *buildVariables {
 }
 
 func (b *buildVariables) Get(ref vars.Reference) (any, bool, error) {
+	val, _, found, err := b.Resolve(ref)
+	return val, found, err
+}
+
+// Resolve is like Get, also reporting where credential vars were resolved
+// from. Local vars have no resolution.
+func (b *buildVariables) Resolve(ref vars.Reference) (any, vars.Resolution, bool, error) {
 	if ref.Source == "." {
 		b.lock.RLock()
 		val, found, err := b.localVars.Get(ref.WithoutSource())
 		b.lock.RUnlock()
 		if found || err != nil {
-			return val, found, err
+			return val, vars.Resolution{}, found, err
 		}
 	}
-	return b.parentScope.Get(ref)
+	return vars.Resolve(b.parentScope, ref)
 }
 
 func (b *buildVariables) List() ([]vars.Reference, error) {
@@ -59,6 +67,12 @@ func (b *buildVariables) IterateInterpolatedCreds(iter vars.TrackedVarsIterator)
 	b.parentScope.IterateInterpolatedCreds(iter)
 }
 
+// ResolvedCredVars returns the credential manager and var source vars which
+// have been resolved, leaving out local vars.
+func (b *buildVariables) ResolvedCredVars() []vars.Reference {
+	return b.parentScope.ResolvedCredVars()
+}
+
 func (b *buildVariables) NewLocalScope() *buildVariables {
 	return &buildVariables{
 		parentScope: b,
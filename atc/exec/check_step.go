@@ -269,6 +269,7 @@ func (step *CheckStep) runCheck(
 		TeamID:   step.metadata.TeamID,
 		TeamName: step.metadata.TeamName,
 		JobID:    step.metadata.JobID,
+		Priority: step.metadata.JobPriority,
 
 		ImageSpec: imageSpec,
 		Env:       step.metadata.Env(),
@@ -99,12 +99,24 @@ type FakeBuildStepDelegate struct {
 	initializingArgsForCall []struct {
 		arg1 lager.Logger
 	}
+	SecretsResolvedStub        func(lager.Logger, []exec.ResolvedVar)
+	secretsResolvedMutex       sync.RWMutex
+	secretsResolvedArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 []exec.ResolvedVar
+	}
 	SelectedWorkerStub        func(lager.Logger, string)
 	selectedWorkerMutex       sync.RWMutex
 	selectedWorkerArgsForCall []struct {
 		arg1 lager.Logger
 		arg2 string
 	}
+	SkippedStub        func(lager.Logger, string)
+	skippedMutex       sync.RWMutex
+	skippedArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+	}
 	StartSpanStub        func(context.Context, string, tracing.Attrs) (context.Context, trace.Span)
 	startSpanMutex       sync.RWMutex
 	startSpanArgsForCall []struct {
@@ -593,6 +605,44 @@ func (fake *FakeBuildStepDelegate) InitializingArgsForCall(i int) lager.Logger {
 	return argsForCall.arg1
 }
 
+func (fake *FakeBuildStepDelegate) SecretsResolved(arg1 lager.Logger, arg2 []exec.ResolvedVar) {
+	var arg2Copy []exec.ResolvedVar
+	if arg2 != nil {
+		arg2Copy = make([]exec.ResolvedVar, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.secretsResolvedMutex.Lock()
+	fake.secretsResolvedArgsForCall = append(fake.secretsResolvedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 []exec.ResolvedVar
+	}{arg1, arg2Copy})
+	stub := fake.SecretsResolvedStub
+	fake.recordInvocation("SecretsResolved", []interface{}{arg1, arg2Copy})
+	fake.secretsResolvedMutex.Unlock()
+	if stub != nil {
+		fake.SecretsResolvedStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeBuildStepDelegate) SecretsResolvedCallCount() int {
+	fake.secretsResolvedMutex.RLock()
+	defer fake.secretsResolvedMutex.RUnlock()
+	return len(fake.secretsResolvedArgsForCall)
+}
+
+func (fake *FakeBuildStepDelegate) SecretsResolvedCalls(stub func(lager.Logger, []exec.ResolvedVar)) {
+	fake.secretsResolvedMutex.Lock()
+	defer fake.secretsResolvedMutex.Unlock()
+	fake.SecretsResolvedStub = stub
+}
+
+func (fake *FakeBuildStepDelegate) SecretsResolvedArgsForCall(i int) (lager.Logger, []exec.ResolvedVar) {
+	fake.secretsResolvedMutex.RLock()
+	defer fake.secretsResolvedMutex.RUnlock()
+	argsForCall := fake.secretsResolvedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeBuildStepDelegate) SelectedWorker(arg1 lager.Logger, arg2 string) {
 	fake.selectedWorkerMutex.Lock()
 	fake.selectedWorkerArgsForCall = append(fake.selectedWorkerArgsForCall, struct {
@@ -626,6 +676,39 @@ func (fake *FakeBuildStepDelegate) SelectedWorkerArgsForCall(i int) (lager.Logge
 	return argsForCall.arg1, argsForCall.arg2
 }
 
+func (fake *FakeBuildStepDelegate) Skipped(arg1 lager.Logger, arg2 string) {
+	fake.skippedMutex.Lock()
+	fake.skippedArgsForCall = append(fake.skippedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.SkippedStub
+	fake.recordInvocation("Skipped", []interface{}{arg1, arg2})
+	fake.skippedMutex.Unlock()
+	if stub != nil {
+		fake.SkippedStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeBuildStepDelegate) SkippedCallCount() int {
+	fake.skippedMutex.RLock()
+	defer fake.skippedMutex.RUnlock()
+	return len(fake.skippedArgsForCall)
+}
+
+func (fake *FakeBuildStepDelegate) SkippedCalls(stub func(lager.Logger, string)) {
+	fake.skippedMutex.Lock()
+	defer fake.skippedMutex.Unlock()
+	fake.SkippedStub = stub
+}
+
+func (fake *FakeBuildStepDelegate) SkippedArgsForCall(i int) (lager.Logger, string) {
+	fake.skippedMutex.RLock()
+	defer fake.skippedMutex.RUnlock()
+	argsForCall := fake.skippedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeBuildStepDelegate) StartSpan(arg1 context.Context, arg2 string, arg3 tracing.Attrs) (context.Context, trace.Span) {
 	fake.startSpanMutex.Lock()
 	ret, specificReturn := fake.startSpanReturnsOnCall[len(fake.startSpanArgsForCall)]
@@ -124,12 +124,24 @@ type FakeCheckDelegate struct {
 	pointToCheckedConfigReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SecretsResolvedStub        func(lager.Logger, []exec.ResolvedVar)
+	secretsResolvedMutex       sync.RWMutex
+	secretsResolvedArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 []exec.ResolvedVar
+	}
 	SelectedWorkerStub        func(lager.Logger, string)
 	selectedWorkerMutex       sync.RWMutex
 	selectedWorkerArgsForCall []struct {
 		arg1 lager.Logger
 		arg2 string
 	}
+	SkippedStub        func(lager.Logger, string)
+	skippedMutex       sync.RWMutex
+	skippedArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+	}
 	StartSpanStub        func(context.Context, string, tracing.Attrs) (context.Context, trace.Span)
 	startSpanMutex       sync.RWMutex
 	startSpanArgsForCall []struct {
@@ -790,6 +802,44 @@ func (fake *FakeCheckDelegate) PointToCheckedConfigReturnsOnCall(i int, result1
 	}{result1}
 }
 
+func (fake *FakeCheckDelegate) SecretsResolved(arg1 lager.Logger, arg2 []exec.ResolvedVar) {
+	var arg2Copy []exec.ResolvedVar
+	if arg2 != nil {
+		arg2Copy = make([]exec.ResolvedVar, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.secretsResolvedMutex.Lock()
+	fake.secretsResolvedArgsForCall = append(fake.secretsResolvedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 []exec.ResolvedVar
+	}{arg1, arg2Copy})
+	stub := fake.SecretsResolvedStub
+	fake.recordInvocation("SecretsResolved", []interface{}{arg1, arg2Copy})
+	fake.secretsResolvedMutex.Unlock()
+	if stub != nil {
+		fake.SecretsResolvedStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeCheckDelegate) SecretsResolvedCallCount() int {
+	fake.secretsResolvedMutex.RLock()
+	defer fake.secretsResolvedMutex.RUnlock()
+	return len(fake.secretsResolvedArgsForCall)
+}
+
+func (fake *FakeCheckDelegate) SecretsResolvedCalls(stub func(lager.Logger, []exec.ResolvedVar)) {
+	fake.secretsResolvedMutex.Lock()
+	defer fake.secretsResolvedMutex.Unlock()
+	fake.SecretsResolvedStub = stub
+}
+
+func (fake *FakeCheckDelegate) SecretsResolvedArgsForCall(i int) (lager.Logger, []exec.ResolvedVar) {
+	fake.secretsResolvedMutex.RLock()
+	defer fake.secretsResolvedMutex.RUnlock()
+	argsForCall := fake.secretsResolvedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeCheckDelegate) SelectedWorker(arg1 lager.Logger, arg2 string) {
 	fake.selectedWorkerMutex.Lock()
 	fake.selectedWorkerArgsForCall = append(fake.selectedWorkerArgsForCall, struct {
@@ -823,6 +873,39 @@ func (fake *FakeCheckDelegate) SelectedWorkerArgsForCall(i int) (lager.Logger, s
 	return argsForCall.arg1, argsForCall.arg2
 }
 
+func (fake *FakeCheckDelegate) Skipped(arg1 lager.Logger, arg2 string) {
+	fake.skippedMutex.Lock()
+	fake.skippedArgsForCall = append(fake.skippedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.SkippedStub
+	fake.recordInvocation("Skipped", []interface{}{arg1, arg2})
+	fake.skippedMutex.Unlock()
+	if stub != nil {
+		fake.SkippedStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeCheckDelegate) SkippedCallCount() int {
+	fake.skippedMutex.RLock()
+	defer fake.skippedMutex.RUnlock()
+	return len(fake.skippedArgsForCall)
+}
+
+func (fake *FakeCheckDelegate) SkippedCalls(stub func(lager.Logger, string)) {
+	fake.skippedMutex.Lock()
+	defer fake.skippedMutex.Unlock()
+	fake.SkippedStub = stub
+}
+
+func (fake *FakeCheckDelegate) SkippedArgsForCall(i int) (lager.Logger, string) {
+	fake.skippedMutex.RLock()
+	defer fake.skippedMutex.RUnlock()
+	argsForCall := fake.skippedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeCheckDelegate) StartSpan(arg1 context.Context, arg2 string, arg3 tracing.Attrs) (context.Context, trace.Span) {
 	fake.startSpanMutex.Lock()
 	ret, specificReturn := fake.startSpanReturnsOnCall[len(fake.startSpanArgsForCall)]
@@ -0,0 +1,125 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package execfakes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/exec"
+)
+
+type FakeIDTokenGenerator struct {
+	GenerateIDTokenStub        func(atc.IDTokenConfig, exec.StepMetadata, string) (string, time.Time, error)
+	generateIDTokenMutex       sync.RWMutex
+	generateIDTokenArgsForCall []struct {
+		arg1 atc.IDTokenConfig
+		arg2 exec.StepMetadata
+		arg3 string
+	}
+	generateIDTokenReturns struct {
+		result1 string
+		result2 time.Time
+		result3 error
+	}
+	generateIDTokenReturnsOnCall map[int]struct {
+		result1 string
+		result2 time.Time
+		result3 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeIDTokenGenerator) GenerateIDToken(arg1 atc.IDTokenConfig, arg2 exec.StepMetadata, arg3 string) (string, time.Time, error) {
+	fake.generateIDTokenMutex.Lock()
+	ret, specificReturn := fake.generateIDTokenReturnsOnCall[len(fake.generateIDTokenArgsForCall)]
+	fake.generateIDTokenArgsForCall = append(fake.generateIDTokenArgsForCall, struct {
+		arg1 atc.IDTokenConfig
+		arg2 exec.StepMetadata
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GenerateIDTokenStub
+	fakeReturns := fake.generateIDTokenReturns
+	fake.recordInvocation("GenerateIDToken", []interface{}{arg1, arg2, arg3})
+	fake.generateIDTokenMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeIDTokenGenerator) GenerateIDTokenCallCount() int {
+	fake.generateIDTokenMutex.RLock()
+	defer fake.generateIDTokenMutex.RUnlock()
+	return len(fake.generateIDTokenArgsForCall)
+}
+
+func (fake *FakeIDTokenGenerator) GenerateIDTokenCalls(stub func(atc.IDTokenConfig, exec.StepMetadata, string) (string, time.Time, error)) {
+	fake.generateIDTokenMutex.Lock()
+	defer fake.generateIDTokenMutex.Unlock()
+	fake.GenerateIDTokenStub = stub
+}
+
+func (fake *FakeIDTokenGenerator) GenerateIDTokenArgsForCall(i int) (atc.IDTokenConfig, exec.StepMetadata, string) {
+	fake.generateIDTokenMutex.RLock()
+	defer fake.generateIDTokenMutex.RUnlock()
+	argsForCall := fake.generateIDTokenArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeIDTokenGenerator) GenerateIDTokenReturns(result1 string, result2 time.Time, result3 error) {
+	fake.generateIDTokenMutex.Lock()
+	defer fake.generateIDTokenMutex.Unlock()
+	fake.GenerateIDTokenStub = nil
+	fake.generateIDTokenReturns = struct {
+		result1 string
+		result2 time.Time
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeIDTokenGenerator) GenerateIDTokenReturnsOnCall(i int, result1 string, result2 time.Time, result3 error) {
+	fake.generateIDTokenMutex.Lock()
+	defer fake.generateIDTokenMutex.Unlock()
+	fake.GenerateIDTokenStub = nil
+	if fake.generateIDTokenReturnsOnCall == nil {
+		fake.generateIDTokenReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 time.Time
+			result3 error
+		})
+	}
+	fake.generateIDTokenReturnsOnCall[i] = struct {
+		result1 string
+		result2 time.Time
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeIDTokenGenerator) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeIDTokenGenerator) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ exec.IDTokenGenerator = new(FakeIDTokenGenerator)
@@ -81,6 +81,16 @@ type FakeRunState struct {
 	parentReturnsOnCall map[int]struct {
 		result1 exec.RunState
 	}
+	ResolvedCredVarsStub        func() []vars.Reference
+	resolvedCredVarsMutex       sync.RWMutex
+	resolvedCredVarsArgsForCall []struct {
+	}
+	resolvedCredVarsReturns struct {
+		result1 []vars.Reference
+	}
+	resolvedCredVarsReturnsOnCall map[int]struct {
+		result1 []vars.Reference
+	}
 	ResultStub        func(atc.PlanID, any) bool
 	resultMutex       sync.RWMutex
 	resultArgsForCall []struct {
@@ -107,12 +117,31 @@ type FakeRunState struct {
 		result1 bool
 		result2 error
 	}
+	StepStatusStub        func(string) (exec.StepStatus, bool)
+	stepStatusMutex       sync.RWMutex
+	stepStatusArgsForCall []struct {
+		arg1 string
+	}
+	stepStatusReturns struct {
+		result1 exec.StepStatus
+		result2 bool
+	}
+	stepStatusReturnsOnCall map[int]struct {
+		result1 exec.StepStatus
+		result2 bool
+	}
 	StoreResultStub        func(atc.PlanID, any)
 	storeResultMutex       sync.RWMutex
 	storeResultArgsForCall []struct {
 		arg1 atc.PlanID
 		arg2 any
 	}
+	StoreStepStatusStub        func(string, exec.StepStatus)
+	storeStepStatusMutex       sync.RWMutex
+	storeStepStatusArgsForCall []struct {
+		arg1 string
+		arg2 exec.StepStatus
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -465,6 +494,59 @@ func (fake *FakeRunState) ParentReturnsOnCall(i int, result1 exec.RunState) {
 	}{result1}
 }
 
+func (fake *FakeRunState) ResolvedCredVars() []vars.Reference {
+	fake.resolvedCredVarsMutex.Lock()
+	ret, specificReturn := fake.resolvedCredVarsReturnsOnCall[len(fake.resolvedCredVarsArgsForCall)]
+	fake.resolvedCredVarsArgsForCall = append(fake.resolvedCredVarsArgsForCall, struct {
+	}{})
+	stub := fake.ResolvedCredVarsStub
+	fakeReturns := fake.resolvedCredVarsReturns
+	fake.recordInvocation("ResolvedCredVars", []interface{}{})
+	fake.resolvedCredVarsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeRunState) ResolvedCredVarsCallCount() int {
+	fake.resolvedCredVarsMutex.RLock()
+	defer fake.resolvedCredVarsMutex.RUnlock()
+	return len(fake.resolvedCredVarsArgsForCall)
+}
+
+func (fake *FakeRunState) ResolvedCredVarsCalls(stub func() []vars.Reference) {
+	fake.resolvedCredVarsMutex.Lock()
+	defer fake.resolvedCredVarsMutex.Unlock()
+	fake.ResolvedCredVarsStub = stub
+}
+
+func (fake *FakeRunState) ResolvedCredVarsReturns(result1 []vars.Reference) {
+	fake.resolvedCredVarsMutex.Lock()
+	defer fake.resolvedCredVarsMutex.Unlock()
+	fake.ResolvedCredVarsStub = nil
+	fake.resolvedCredVarsReturns = struct {
+		result1 []vars.Reference
+	}{result1}
+}
+
+func (fake *FakeRunState) ResolvedCredVarsReturnsOnCall(i int, result1 []vars.Reference) {
+	fake.resolvedCredVarsMutex.Lock()
+	defer fake.resolvedCredVarsMutex.Unlock()
+	fake.ResolvedCredVarsStub = nil
+	if fake.resolvedCredVarsReturnsOnCall == nil {
+		fake.resolvedCredVarsReturnsOnCall = make(map[int]struct {
+			result1 []vars.Reference
+		})
+	}
+	fake.resolvedCredVarsReturnsOnCall[i] = struct {
+		result1 []vars.Reference
+	}{result1}
+}
+
 func (fake *FakeRunState) Result(arg1 atc.PlanID, arg2 any) bool {
 	fake.resultMutex.Lock()
 	ret, specificReturn := fake.resultReturnsOnCall[len(fake.resultArgsForCall)]
@@ -592,6 +674,70 @@ func (fake *FakeRunState) RunReturnsOnCall(i int, result1 bool, result2 error) {
 	}{result1, result2}
 }
 
+func (fake *FakeRunState) StepStatus(arg1 string) (exec.StepStatus, bool) {
+	fake.stepStatusMutex.Lock()
+	ret, specificReturn := fake.stepStatusReturnsOnCall[len(fake.stepStatusArgsForCall)]
+	fake.stepStatusArgsForCall = append(fake.stepStatusArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.StepStatusStub
+	fakeReturns := fake.stepStatusReturns
+	fake.recordInvocation("StepStatus", []interface{}{arg1})
+	fake.stepStatusMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRunState) StepStatusCallCount() int {
+	fake.stepStatusMutex.RLock()
+	defer fake.stepStatusMutex.RUnlock()
+	return len(fake.stepStatusArgsForCall)
+}
+
+func (fake *FakeRunState) StepStatusCalls(stub func(string) (exec.StepStatus, bool)) {
+	fake.stepStatusMutex.Lock()
+	defer fake.stepStatusMutex.Unlock()
+	fake.StepStatusStub = stub
+}
+
+func (fake *FakeRunState) StepStatusArgsForCall(i int) string {
+	fake.stepStatusMutex.RLock()
+	defer fake.stepStatusMutex.RUnlock()
+	argsForCall := fake.stepStatusArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeRunState) StepStatusReturns(result1 exec.StepStatus, result2 bool) {
+	fake.stepStatusMutex.Lock()
+	defer fake.stepStatusMutex.Unlock()
+	fake.StepStatusStub = nil
+	fake.stepStatusReturns = struct {
+		result1 exec.StepStatus
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *FakeRunState) StepStatusReturnsOnCall(i int, result1 exec.StepStatus, result2 bool) {
+	fake.stepStatusMutex.Lock()
+	defer fake.stepStatusMutex.Unlock()
+	fake.StepStatusStub = nil
+	if fake.stepStatusReturnsOnCall == nil {
+		fake.stepStatusReturnsOnCall = make(map[int]struct {
+			result1 exec.StepStatus
+			result2 bool
+		})
+	}
+	fake.stepStatusReturnsOnCall[i] = struct {
+		result1 exec.StepStatus
+		result2 bool
+	}{result1, result2}
+}
+
 func (fake *FakeRunState) StoreResult(arg1 atc.PlanID, arg2 any) {
 	fake.storeResultMutex.Lock()
 	fake.storeResultArgsForCall = append(fake.storeResultArgsForCall, struct {
@@ -625,6 +771,39 @@ func (fake *FakeRunState) StoreResultArgsForCall(i int) (atc.PlanID, any) {
 	return argsForCall.arg1, argsForCall.arg2
 }
 
+func (fake *FakeRunState) StoreStepStatus(arg1 string, arg2 exec.StepStatus) {
+	fake.storeStepStatusMutex.Lock()
+	fake.storeStepStatusArgsForCall = append(fake.storeStepStatusArgsForCall, struct {
+		arg1 string
+		arg2 exec.StepStatus
+	}{arg1, arg2})
+	stub := fake.StoreStepStatusStub
+	fake.recordInvocation("StoreStepStatus", []interface{}{arg1, arg2})
+	fake.storeStepStatusMutex.Unlock()
+	if stub != nil {
+		fake.StoreStepStatusStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeRunState) StoreStepStatusCallCount() int {
+	fake.storeStepStatusMutex.RLock()
+	defer fake.storeStepStatusMutex.RUnlock()
+	return len(fake.storeStepStatusArgsForCall)
+}
+
+func (fake *FakeRunState) StoreStepStatusCalls(stub func(string, exec.StepStatus)) {
+	fake.storeStepStatusMutex.Lock()
+	defer fake.storeStepStatusMutex.Unlock()
+	fake.StoreStepStatusStub = stub
+}
+
+func (fake *FakeRunState) StoreStepStatusArgsForCall(i int) (string, exec.StepStatus) {
+	fake.storeStepStatusMutex.RLock()
+	defer fake.storeStepStatusMutex.RUnlock()
+	argsForCall := fake.storeStepStatusArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeRunState) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -185,6 +185,7 @@ func (step *GetStep) run(ctx context.Context, state RunState, delegate GetDelega
 		TeamID:   step.metadata.TeamID,
 		TeamName: step.metadata.TeamName,
 		JobID:    step.metadata.JobID,
+		Priority: step.metadata.JobPriority,
 
 		ImageSpec: imageSpec,
 
@@ -0,0 +1,188 @@
+package exec
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"time"
+
+	"code.cloudfoundry.org/lager/v3"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/compression"
+	"github.com/concourse/concourse/atc/runtime"
+)
+
+const (
+	// IDTokenDir is the directory which a step's id_token is written to in its
+	// container.
+	IDTokenDir = "/concourse/id-token"
+
+	// IDTokenFileEnv is the environment variable which holds the path of the
+	// id_token file.
+	IDTokenFileEnv = "CONCOURSE_ID_TOKEN_FILE"
+
+	idTokenFileName = "token"
+
+	// not a valid output name, so that it never clashes with the task's outputs
+	idTokenOutputName = ".id-token"
+
+	idTokenMinRefreshInterval = time.Second
+)
+
+var ErrIDTokensNotConfigured = errors.New("id tokens are not configured")
+
+//counterfeiter:generate . IDTokenGenerator
+type IDTokenGenerator interface {
+	GenerateIDToken(config atc.IDTokenConfig, metadata StepMetadata, stepName string) (string, time.Time, error)
+}
+
+// idToken mints the id_token of a step and keeps it up to date in the step's
+// container. Every token minted is redacted from the build's output.
+type idToken struct {
+	generator IDTokenGenerator
+	config    atc.IDTokenConfig
+	metadata  StepMetadata
+	stepName  string
+	state     RunState
+
+	token      string
+	validUntil time.Time
+	generated  int
+}
+
+func newIDToken(generator IDTokenGenerator, config atc.IDTokenConfig, metadata StepMetadata, stepName string, state RunState) (*idToken, error) {
+	if generator == nil {
+		return nil, ErrIDTokensNotConfigured
+	}
+
+	token := &idToken{
+		generator: generator,
+		config:    config,
+		metadata:  metadata,
+		stepName:  stepName,
+		state:     state,
+	}
+
+	err := token.generate()
+	if err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+func (token *idToken) generate() error {
+	value, validUntil, err := token.generator.GenerateIDToken(token.config, token.metadata, token.stepName)
+	if err != nil {
+		return err
+	}
+
+	token.token = value
+	token.validUntil = validUntil
+
+	// tokens minted earlier remain valid once refreshed, so each is tracked
+	// under its own name to keep all of them redacted
+	token.generated++
+	token.state.AddLocalVar(fmt.Sprintf("%s.%s.%d", idTokenOutputName, token.stepName, token.generated), value, true)
+
+	return nil
+}
+
+// Env returns the environment variables to set in the step's container.
+func (token *idToken) Env() []string {
+	env := []string{IDTokenFileEnv + "=" + path.Join(IDTokenDir, idTokenFileName)}
+	if token.config.Env != "" {
+		env = append(env, token.config.Env+"="+token.token)
+	}
+
+	return env
+}
+
+// Write writes the token into the volume mounted at IDTokenDir.
+func (token *idToken) Write(ctx context.Context, volumeMounts []runtime.VolumeMount) error {
+	for _, mount := range volumeMounts {
+		if filepath.Clean(mount.MountPath) != IDTokenDir {
+			continue
+		}
+
+		buf := new(bytes.Buffer)
+		gzipWriter := gzip.NewWriter(buf)
+		tarWriter := tar.NewWriter(gzipWriter)
+
+		err := tarWriter.WriteHeader(&tar.Header{
+			Name: idTokenFileName,
+			Mode: 0644,
+			Size: int64(len(token.token)),
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = tarWriter.Write([]byte(token.token))
+		if err != nil {
+			return err
+		}
+
+		err = tarWriter.Close()
+		if err != nil {
+			return err
+		}
+
+		err = gzipWriter.Close()
+		if err != nil {
+			return err
+		}
+
+		return mount.Volume.StreamIn(ctx, ".", compression.NewGzipCompression(), 0, buf)
+	}
+
+	return errors.New("id token volume not found")
+}
+
+// StartRefreshing refreshes the token in the background until the returned
+// func is called.
+func (token *idToken) StartRefreshing(ctx context.Context, logger lager.Logger, volumeMounts []runtime.VolumeMount) func() {
+	ctx, cancel := context.WithCancel(ctx)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		token.refresh(ctx, logger, volumeMounts)
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// refresh mints a new token whenever half of the current token's lifetime has
+// passed, until the context is done. Failures are logged and retried, as the
+// current token may well still be valid.
+func (token *idToken) refresh(ctx context.Context, logger lager.Logger, volumeMounts []runtime.VolumeMount) {
+	for {
+		interval := max(time.Until(token.validUntil)/2, idTokenMinRefreshInterval)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+
+		err := token.generate()
+		if err != nil {
+			logger.Error("failed-to-refresh-id-token", err)
+			continue
+		}
+
+		err = token.Write(ctx, volumeMounts)
+		if err != nil {
+			logger.Error("failed-to-write-id-token", err)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+
+	"code.cloudfoundry.org/lager/v3"
+	"code.cloudfoundry.org/lager/v3/lagerctx"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/expr"
+	"github.com/concourse/concourse/vars"
+)
+
+// IfStep runs the wrapped step only when its condition holds. Otherwise, the
+// step is skipped and the IfStep succeeds without running it.
+type IfStep struct {
+	plan            atc.IfPlan
+	step            Step
+	metadata        StepMetadata
+	delegateFactory BuildStepDelegateFactory
+}
+
+func If(
+	plan atc.IfPlan,
+	step Step,
+	metadata StepMetadata,
+	delegateFactory BuildStepDelegateFactory,
+) Step {
+	return IfStep{
+		plan:            plan,
+		step:            step,
+		metadata:        metadata,
+		delegateFactory: delegateFactory,
+	}
+}
+
+func (step IfStep) Run(ctx context.Context, state RunState) (bool, error) {
+	logger := lagerctx.FromContext(ctx).Session("if-step", lager.Data{
+		"condition": step.plan.Condition,
+	})
+
+	condition, err := expr.Parse(step.plan.Condition)
+	if err != nil {
+		return false, err
+	}
+
+	holds, err := condition.Evaluate(conditionEnv{
+		state:    state,
+		metadata: step.metadata,
+	})
+	if err != nil {
+		return false, fmt.Errorf("evaluate condition '%s': %w", step.plan.Condition, err)
+	}
+
+	if holds {
+		return step.step.Run(ctx, state)
+	}
+
+	logger.Info("skipped")
+
+	step.plan.Step.Each(func(plan *atc.Plan) {
+		if name, ok := stepName(*plan); ok {
+			state.StoreStepStatus(name, StepStatusSkipped)
+		}
+	})
+
+	delegate := step.delegateFactory.BuildStepDelegate(state)
+	delegate.Skipped(logger, step.plan.Condition)
+
+	return true, nil
+}
+
+// conditionEnv resolves the references made by `if:` conditions:
+//
+//   - vars.NAME: local vars set by steps which ran before, e.g. `load_var`,
+//     or by an enclosing `across` step. The condition is evaluated before any
+//     `across` on the same step, so that step's own across vars are not set.
+//   - instance_vars.NAME: the pipeline's instance vars
+//   - build.FIELD: the build's id (a number), name, job_name, pipeline_name
+//     and team_name
+//   - steps.NAME.status: the status of the last step with the name to have
+//     run, or "pending" if it hasn't run. Statuses are stored in the scope the
+//     step ran in, so the steps within an `across` step are only visible to
+//     other steps within it.
+//   - steps.NAME.succeeded: whether that step succeeded
+type conditionEnv struct {
+	state    RunState
+	metadata StepMetadata
+}
+
+func (env conditionEnv) Lookup(ref expr.Reference) (any, error) {
+	switch ref[0] {
+	case "vars":
+		if len(ref) < 2 {
+			break
+		}
+
+		val, found, err := env.state.Get(vars.Reference{Source: ".", Path: ref[1], Fields: ref[2:]})
+		if err != nil {
+			return nil, err
+		}
+
+		if !found {
+			return nil, fmt.Errorf("undefined var '%s'", ref)
+		}
+
+		return val, nil
+
+	case "instance_vars":
+		if len(ref) < 2 {
+			break
+		}
+
+		val, found := env.metadata.PipelineInstanceVars[ref[1]]
+		if !found {
+			return nil, fmt.Errorf("undefined instance var '%s'", ref)
+		}
+
+		return vars.Traverse(val, ref.String(), ref[2:])
+
+	case "build":
+		if len(ref) != 2 {
+			break
+		}
+
+		fields := map[string]any{
+			"id":            env.metadata.BuildID,
+			"name":          env.metadata.BuildName,
+			"job_name":      env.metadata.JobName,
+			"pipeline_name": env.metadata.PipelineName,
+			"team_name":     env.metadata.TeamName,
+		}
+
+		val, found := fields[ref[1]]
+		if !found {
+			break
+		}
+
+		return val, nil
+
+	case "steps":
+		if len(ref) != 3 {
+			break
+		}
+
+		status, found := env.state.StepStatus(ref[1])
+		if !found {
+			status = StepStatusPending
+		}
+
+		switch ref[2] {
+		case "status":
+			return string(status), nil
+		case "succeeded":
+			return status == StepStatusSucceeded, nil
+		}
+	}
+
+	return nil, fmt.Errorf("invalid reference '%s'", ref)
+}
@@ -0,0 +1,209 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/vars"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("IfStep", func() {
+	var (
+		ctx context.Context
+
+		fakeStep *execfakes.FakeStep
+
+		fakeDelegate        *execfakes.FakeBuildStepDelegate
+		fakeDelegateFactory *execfakes.FakeBuildStepDelegateFactory
+
+		state RunState
+
+		plan     atc.IfPlan
+		metadata StepMetadata
+
+		runOk  bool
+		runErr error
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		fakeStep = new(execfakes.FakeStep)
+		fakeStep.RunReturns(true, nil)
+
+		fakeDelegate = new(execfakes.FakeBuildStepDelegate)
+		fakeDelegateFactory = new(execfakes.FakeBuildStepDelegateFactory)
+		fakeDelegateFactory.BuildStepDelegateReturns(fakeDelegate)
+
+		state = NewRunState(noopStepper, vars.StaticVariables{})
+
+		plan = atc.IfPlan{
+			Step: atc.Plan{
+				ID: "some-id",
+				Task: &atc.TaskPlan{
+					Name: "deploy",
+				},
+			},
+		}
+
+		metadata = StepMetadata{
+			BuildID:              42,
+			BuildName:            "7",
+			JobName:              "some-job",
+			PipelineName:         "some-pipeline",
+			TeamName:             "some-team",
+			PipelineInstanceVars: map[string]any{"branch": map[string]any{"name": "main"}},
+		}
+	})
+
+	JustBeforeEach(func() {
+		runOk, runErr = If(plan, fakeStep, metadata, fakeDelegateFactory).Run(ctx, state)
+	})
+
+	Context("when the condition holds", func() {
+		BeforeEach(func() {
+			state.AddLocalVar("deploy", map[string]any{"enabled": true}, false)
+			state.StoreStepStatus("unit", StepStatusSucceeded)
+
+			plan.Condition = `vars.deploy.enabled && steps.unit.succeeded && instance_vars.branch.name == "main" && build.job_name == "some-job"`
+		})
+
+		It("runs the step", func() {
+			Expect(fakeStep.RunCallCount()).To(Equal(1))
+			Expect(runOk).To(BeTrue())
+			Expect(runErr).ToNot(HaveOccurred())
+		})
+
+		It("does not emit a skipped event", func() {
+			Expect(fakeDelegate.SkippedCallCount()).To(BeZero())
+		})
+
+		Context("when the step fails", func() {
+			BeforeEach(func() {
+				fakeStep.RunReturns(false, nil)
+			})
+
+			It("fails", func() {
+				Expect(runOk).To(BeFalse())
+			})
+		})
+	})
+
+	Context("when the condition does not hold", func() {
+		BeforeEach(func() {
+			state.StoreStepStatus("unit", StepStatusFailed)
+
+			plan.Condition = `steps.unit.status == "succeeded"`
+		})
+
+		It("skips the step and succeeds", func() {
+			Expect(fakeStep.RunCallCount()).To(BeZero())
+			Expect(runOk).To(BeTrue())
+			Expect(runErr).ToNot(HaveOccurred())
+		})
+
+		It("emits a skipped event with the condition", func() {
+			Expect(fakeDelegate.SkippedCallCount()).To(Equal(1))
+			_, condition := fakeDelegate.SkippedArgsForCall(0)
+			Expect(condition).To(Equal(`steps.unit.status == "succeeded"`))
+		})
+
+		It("records the named steps it skipped", func() {
+			status, found := state.StepStatus("deploy")
+			Expect(found).To(BeTrue())
+			Expect(status).To(Equal(StepStatusSkipped))
+		})
+	})
+
+	Context("when the condition refers to a step which has not run", func() {
+		BeforeEach(func() {
+			plan.Condition = `steps.unit.status == "pending" && !steps.unit.succeeded`
+		})
+
+		It("treats it as pending", func() {
+			Expect(fakeStep.RunCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when the condition compares the build id with a number", func() {
+		BeforeEach(func() {
+			plan.Condition = `build.id > 40`
+		})
+
+		It("compares it as a number", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(fakeStep.RunCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when the condition refers to an undefined var", func() {
+		BeforeEach(func() {
+			plan.Condition = "vars.missing"
+		})
+
+		It("errors without running the step", func() {
+			Expect(runErr).To(MatchError("evaluate condition 'vars.missing': undefined var 'vars.missing'"))
+			Expect(fakeStep.RunCallCount()).To(BeZero())
+			Expect(fakeDelegate.SkippedCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the condition is invalid", func() {
+		BeforeEach(func() {
+			plan.Condition = "vars.missing =="
+		})
+
+		It("errors without running the step", func() {
+			Expect(runErr).To(HaveOccurred())
+			Expect(fakeStep.RunCallCount()).To(BeZero())
+		})
+	})
+})
+
+var _ = Describe("RecordStatusStep", func() {
+	var (
+		fakeStep *execfakes.FakeStep
+		state    RunState
+		plan     atc.Plan
+	)
+
+	BeforeEach(func() {
+		fakeStep = new(execfakes.FakeStep)
+		state = NewRunState(noopStepper, vars.StaticVariables{})
+		plan = atc.Plan{
+			ID:      "some-id",
+			LoadVar: &atc.LoadVarPlan{Name: "some-var"},
+		}
+	})
+
+	DescribeTable("recording the status of the step",
+		func(ok bool, err error, expected StepStatus) {
+			fakeStep.RunReturns(ok, err)
+
+			runOk, runErr := RecordStatus(plan, fakeStep).Run(context.Background(), state)
+			Expect(runOk).To(Equal(ok))
+			if err == nil {
+				Expect(runErr).ToNot(HaveOccurred())
+			} else {
+				Expect(runErr).To(MatchError(err))
+			}
+
+			status, found := state.StepStatus("some-var")
+			Expect(found).To(BeTrue())
+			Expect(status).To(Equal(expected))
+		},
+		Entry("succeeded", true, nil, StepStatusSucceeded),
+		Entry("failed", false, nil, StepStatusFailed),
+		Entry("errored", false, errors.New("nope"), StepStatusErrored),
+		Entry("aborted", false, context.Canceled, StepStatusAborted),
+	)
+
+	It("does not wrap steps without a name", func() {
+		Expect(RecordStatus(atc.Plan{ID: "some-id", Do: &atc.DoPlan{}}, fakeStep)).To(Equal(fakeStep))
+	})
+})
@@ -166,6 +166,7 @@ func (step *PutStep) run(ctx context.Context, state RunState, delegate PutDelega
 		TeamID:   step.metadata.TeamID,
 		TeamName: step.metadata.TeamName,
 		JobID:    step.metadata.JobID,
+		Priority: step.metadata.JobPriority,
 
 		ImageSpec: imageSpec,
 
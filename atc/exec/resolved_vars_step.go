@@ -0,0 +1,130 @@
+package exec
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+
+	"code.cloudfoundry.org/lager/v3/lagerctx"
+	"github.com/concourse/concourse/vars"
+)
+
+// ResolvedVar is a credential var which a step resolved. Its value is not
+// kept.
+type ResolvedVar struct {
+	Ref        vars.Reference
+	Resolution vars.Resolution
+
+	// Redactable is true when the value is one which gets redacted from build
+	// output, i.e. it has at least one line longer than a single character.
+	Redactable bool
+}
+
+type ReportResolvedVarsStep struct {
+	Step
+
+	delegateFactory BuildStepDelegateFactory
+}
+
+// ReportResolvedVars reports the credential vars which the step resolved to
+// its delegate once it has run, so that builds can be audited.
+func ReportResolvedVars(step Step, delegateFactory BuildStepDelegateFactory) Step {
+	return ReportResolvedVarsStep{
+		Step: step,
+
+		delegateFactory: delegateFactory,
+	}
+}
+
+func (step ReportResolvedVarsStep) Run(ctx context.Context, state RunState) (bool, error) {
+	logger := lagerctx.FromContext(ctx)
+
+	recorder := &resolvedVarsRecorder{
+		RunState: state,
+		resolved: map[string]ResolvedVar{},
+	}
+
+	runOk, runErr := step.Step.Run(ctx, recorder)
+
+	resolved := recorder.ResolvedVars()
+	if len(resolved) > 0 {
+		delegate := step.delegateFactory.BuildStepDelegate(state)
+		delegate.SecretsResolved(logger, resolved)
+	}
+
+	return runOk, runErr
+}
+
+type resolvedVarsRecorder struct {
+	RunState
+
+	lock     sync.Mutex
+	resolved map[string]ResolvedVar
+}
+
+func (r *resolvedVarsRecorder) Get(ref vars.Reference) (any, bool, error) {
+	val, _, found, err := r.Resolve(ref)
+	return val, found, err
+}
+
+func (r *resolvedVarsRecorder) Resolve(ref vars.Reference) (any, vars.Resolution, bool, error) {
+	val, resolution, found, err := vars.Resolve(r.RunState, ref)
+	if !found || ref.Source == "." {
+		return val, resolution, found, err
+	}
+
+	r.lock.Lock()
+	r.resolved[ref.String()] = ResolvedVar{
+		Ref:        ref,
+		Resolution: resolution,
+		Redactable: redactable(val),
+	}
+	r.lock.Unlock()
+
+	return val, resolution, found, err
+}
+
+// ResolvedVars returns the vars resolved so far, ordered by name.
+func (r *resolvedVarsRecorder) ResolvedVars() []ResolvedVar {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	resolved := make([]ResolvedVar, 0, len(r.resolved))
+	for _, v := range r.resolved {
+		resolved = append(resolved, v)
+	}
+
+	slices.SortFunc(resolved, func(a, b ResolvedVar) int {
+		return strings.Compare(a.Ref.String(), b.Ref.String())
+	})
+
+	return resolved
+}
+
+// redactable mirrors the build output redaction, which only redacts string
+// values and skips lines of a single character.
+func redactable(val any) bool {
+	switch v := val.(type) {
+	case map[any]any:
+		for _, vv := range v {
+			if redactable(vv) {
+				return true
+			}
+		}
+	case map[string]any:
+		for _, vv := range v {
+			if redactable(vv) {
+				return true
+			}
+		}
+	case string:
+		for line := range strings.SplitSeq(v, "\n") {
+			if len(strings.TrimSpace(line)) > 1 {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,126 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/vars"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ReportResolvedVarsStep", func() {
+	var (
+		ctx context.Context
+
+		fakeStep *execfakes.FakeStep
+
+		fakeDelegate        *execfakes.FakeBuildStepDelegate
+		fakeDelegateFactory *execfakes.FakeBuildStepDelegateFactory
+
+		state RunState
+
+		step   Step
+		runOk  bool
+		runErr error
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		fakeStep = new(execfakes.FakeStep)
+		fakeDelegate = new(execfakes.FakeBuildStepDelegate)
+		fakeDelegateFactory = new(execfakes.FakeBuildStepDelegateFactory)
+		fakeDelegateFactory.BuildStepDelegateReturns(fakeDelegate)
+
+		state = NewRunState(noopStepper, vars.NewMultiVars([]vars.Variables{
+			vars.NamedVariables{
+				"some-source": vars.StaticVariables{"flag": "y"},
+			},
+			vars.StaticVariables{
+				"github": map[string]any{"token": "some-token"},
+			},
+		}))
+		state.AddLocalVar("local", "some-local-value", false)
+
+		step = ReportResolvedVars(fakeStep, fakeDelegateFactory)
+	})
+
+	JustBeforeEach(func() {
+		runOk, runErr = step.Run(ctx, state)
+	})
+
+	Context("when the step resolves vars", func() {
+		BeforeEach(func() {
+			fakeStep.RunStub = func(ctx context.Context, state RunState) (bool, error) {
+				for _, ref := range []vars.Reference{
+					{Path: "github", Fields: []string{"token"}},
+					{Source: "some-source", Path: "flag"},
+					{Source: ".", Path: "local"},
+					{Path: "missing"},
+					{Path: "github", Fields: []string{"token"}},
+				} {
+					_, _, err := state.Get(ref)
+					if err != nil {
+						return false, err
+					}
+				}
+
+				return true, nil
+			}
+		})
+
+		It("returns the result of the step", func() {
+			Expect(runOk).To(BeTrue())
+			Expect(runErr).ToNot(HaveOccurred())
+		})
+
+		It("reports each credential var once, ordered by name", func() {
+			Expect(fakeDelegateFactory.BuildStepDelegateArgsForCall(0)).To(Equal(state))
+
+			Expect(fakeDelegate.SecretsResolvedCallCount()).To(Equal(1))
+			_, resolved := fakeDelegate.SecretsResolvedArgsForCall(0)
+			Expect(resolved).To(Equal([]ResolvedVar{
+				{
+					Ref:        vars.Reference{Path: "github", Fields: []string{"token"}},
+					Redactable: true,
+				},
+				{
+					Ref:        vars.Reference{Source: "some-source", Path: "flag"},
+					Resolution: vars.Resolution{Manager: "some-source"},
+					Redactable: false,
+				},
+			}))
+		})
+	})
+
+	Context("when the step fails after resolving vars", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeStep.RunStub = func(ctx context.Context, state RunState) (bool, error) {
+				_, _, err := state.Get(vars.Reference{Path: "github"})
+				Expect(err).ToNot(HaveOccurred())
+				return false, disaster
+			}
+		})
+
+		It("still reports the vars", func() {
+			Expect(runErr).To(Equal(disaster))
+			Expect(fakeDelegate.SecretsResolvedCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when the step resolves no credential vars", func() {
+		BeforeEach(func() {
+			fakeStep.RunReturns(true, nil)
+		})
+
+		It("does not report anything", func() {
+			Expect(fakeDelegateFactory.BuildStepDelegateCallCount()).To(BeZero())
+			Expect(fakeDelegate.SecretsResolvedCallCount()).To(BeZero())
+		})
+	})
+})
@@ -17,6 +17,7 @@ type runState struct {
 
 	artifacts *build.Repository
 	results   *sync.Map
+	statuses  *sync.Map
 
 	parent RunState
 }
@@ -34,6 +35,7 @@ func NewRunState(
 
 		artifacts: build.NewRepository(),
 		results:   &sync.Map{},
+		statuses:  &sync.Map{},
 	}
 }
 
@@ -59,10 +61,33 @@ func (state *runState) StoreResult(id atc.PlanID, val any) {
 	state.results.Store(id, val)
 }
 
+// StepStatus returns the status of the last step with the given name to have
+// run in this scope or any of its parents.
+func (state *runState) StepStatus(name string) (StepStatus, bool) {
+	val, ok := state.statuses.Load(name)
+	if !ok {
+		if state.parent != nil {
+			return state.parent.StepStatus(name)
+		}
+
+		return "", false
+	}
+
+	return val.(StepStatus), true
+}
+
+func (state *runState) StoreStepStatus(name string, status StepStatus) {
+	state.statuses.Store(name, status)
+}
+
 func (state *runState) Get(ref vars.Reference) (any, bool, error) {
 	return state.vars.Get(ref)
 }
 
+func (state *runState) Resolve(ref vars.Reference) (any, vars.Resolution, bool, error) {
+	return state.vars.Resolve(ref)
+}
+
 func (state *runState) List() ([]vars.Reference, error) {
 	return state.vars.List()
 }
@@ -71,10 +96,15 @@ func (state *runState) IterateInterpolatedCreds(iter vars.TrackedVarsIterator) {
 	state.vars.IterateInterpolatedCreds(iter)
 }
 
+func (state *runState) ResolvedCredVars() []vars.Reference {
+	return state.vars.ResolvedCredVars()
+}
+
 func (state *runState) NewLocalScope() RunState {
 	clone := *state
 	clone.vars = state.vars.NewLocalScope()
 	clone.artifacts = state.artifacts.NewLocalScope()
+	clone.statuses = &sync.Map{}
 	clone.parent = state
 	return &clone
 }
@@ -202,6 +202,30 @@ var _ = Describe("RunState", func() {
 		})
 	})
 
+	Describe("ResolvedCredVars", func() {
+		BeforeEach(func() {
+			state = exec.NewRunState(stepper, credVars)
+		})
+
+		It("returns the cred vars which were fetched from any scope", func() {
+			state.Get(vars.Reference{Path: "k1"})
+			state.NewLocalScope().Get(vars.Reference{Path: "k2"})
+			state.Get(vars.Reference{Path: "missing"})
+
+			Expect(state.ResolvedCredVars()).To(ConsistOf(
+				vars.Reference{Path: "k1"},
+				vars.Reference{Path: "k2"},
+			))
+		})
+
+		It("does not include local vars", func() {
+			state.AddLocalVar("foo", "bar", true)
+			state.Get(vars.Reference{Source: ".", Path: "foo"})
+
+			Expect(state.ResolvedCredVars()).To(BeEmpty())
+		})
+	})
+
 	Describe("List", func() {
 		It("returns list of names from multiple vars with duplicates", func() {
 			defs, err := state.List()
@@ -315,6 +339,23 @@ var _ = Describe("RunState", func() {
 			Expect(dst).To(Equal("hello"))
 		})
 
+		It("step statuses set in parent scope are accessible in child", func() {
+			state.StoreStepStatus("unit", exec.StepStatusFailed)
+			child := state.NewLocalScope()
+
+			status, found := child.StepStatus("unit")
+			Expect(found).To(BeTrue())
+			Expect(status).To(Equal(exec.StepStatusFailed))
+		})
+
+		It("step statuses set in child scope are not accessible in parent", func() {
+			child := state.NewLocalScope()
+			child.StoreStepStatus("unit", exec.StepStatusSucceeded)
+
+			_, found := state.StepStatus("unit")
+			Expect(found).To(BeFalse())
+		})
+
 		It("has a local artifact scope inheriting from the outer scope", func() {
 			Expect(state.NewLocalScope().ArtifactRepository().Parent()).To(Equal(state.ArtifactRepository()))
 		})
@@ -132,15 +132,15 @@ func (step *SetPipelineStep) run(ctx context.Context, state RunState, delegate S
 		return false, nil
 	}
 
-	warnings, errors := configvalidate.Validate(atcConfig)
+	warnings, validationErrors := configvalidate.Validate(atcConfig)
 	for _, warning := range warnings {
 		fmt.Fprintf(stderr, "WARNING: %s\n", warning.Message)
 	}
 
-	if len(errors) > 0 {
+	if len(validationErrors) > 0 {
 		fmt.Fprintln(delegate.Stderr(), "invalid pipeline:")
 
-		for _, e := range errors {
+		for _, e := range validationErrors {
 			fmt.Fprintf(stderr, "- %s", e)
 		}
 
@@ -248,6 +248,14 @@ func (step *SetPipelineStep) run(ctx context.Context, state RunState, delegate S
 			delegate.Finished(logger, true)
 			return true, nil
 		}
+
+		var passedErr db.PassedJobNotFoundError
+		if errors.As(err, &passedErr) {
+			fmt.Fprintf(stderr, "invalid pipeline:\n- %s\n", err)
+			delegate.Finished(logger, false)
+			return false, nil
+		}
+
 		return false, err
 	}
 
@@ -461,6 +461,23 @@ jobs:
 							Expect(stepOk).To(BeTrue())
 						})
 					})
+
+					Context("due to a passed constraint naming a job which does not exist", func() {
+						BeforeEach(func() {
+							fakeBuild.SavePipelineReturns(nil, false, db.PassedJobNotFoundError{Passed: "other-pipeline/some-job"})
+						})
+						It("logs the error", func() {
+							Expect(stderr).To(gbytes.Say("invalid pipeline:"))
+							Expect(stderr).To(gbytes.Say("no matching job\\(s\\) for passed constraint 'other-pipeline/some-job'"))
+						})
+						It("fails the step without erroring", func() {
+							Expect(stepErr).ToNot(HaveOccurred())
+							Expect(stepOk).To(BeFalse())
+							Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+							_, succeeded := fakeDelegate.FinishedArgsForCall(0)
+							Expect(succeeded).To(BeFalse())
+						})
+					})
 				})
 
 				It("should save the pipeline un-paused", func() {
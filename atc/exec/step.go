@@ -47,12 +47,16 @@ type RunState interface {
 	AddLocalVar(name string, val any, redact bool)
 
 	IterateInterpolatedCreds(vars.TrackedVarsIterator)
+	ResolvedCredVars() []vars.Reference
 
 	ArtifactRepository() *build.Repository
 
 	Result(atc.PlanID, any) bool
 	StoreResult(atc.PlanID, any)
 
+	StepStatus(name string) (StepStatus, bool)
+	StoreStepStatus(name string, status StepStatus)
+
 	Run(context.Context, atc.Plan) (bool, error)
 
 	Parent() RunState
@@ -13,6 +13,7 @@ type StepMetadata struct {
 	TeamName             string
 	JobID                int
 	JobName              string
+	JobPriority          int
 	PipelineID           int
 	PipelineName         string
 	PipelineInstanceVars map[string]any
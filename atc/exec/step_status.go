@@ -0,0 +1,79 @@
+package exec
+
+import (
+	"context"
+	"errors"
+
+	"github.com/concourse/concourse/atc"
+)
+
+// StepStatus is the outcome of a named step, which `if:` conditions can refer
+// to as `steps.NAME.status`.
+type StepStatus string
+
+const (
+	StepStatusPending   StepStatus = "pending"
+	StepStatusSucceeded StepStatus = "succeeded"
+	StepStatusFailed    StepStatus = "failed"
+	StepStatusErrored   StepStatus = "errored"
+	StepStatusAborted   StepStatus = "aborted"
+	StepStatusSkipped   StepStatus = "skipped"
+)
+
+// RecordStatusStep stores the status of a named step in the RunState once it
+// has run.
+type RecordStatusStep struct {
+	Step
+
+	name string
+}
+
+// RecordStatus wraps the step so that its status is recorded under the name
+// of the step in the plan. Steps without a name are returned as-is.
+func RecordStatus(plan atc.Plan, step Step) Step {
+	name, ok := stepName(plan)
+	if !ok {
+		return step
+	}
+
+	return RecordStatusStep{
+		Step: step,
+		name: name,
+	}
+}
+
+func (step RecordStatusStep) Run(ctx context.Context, state RunState) (bool, error) {
+	ok, err := step.Step.Run(ctx, state)
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		state.StoreStepStatus(step.name, StepStatusAborted)
+	case err != nil:
+		state.StoreStepStatus(step.name, StepStatusErrored)
+	case ok:
+		state.StoreStepStatus(step.name, StepStatusSucceeded)
+	default:
+		state.StoreStepStatus(step.name, StepStatusFailed)
+	}
+
+	return ok, err
+}
+
+func stepName(plan atc.Plan) (string, bool) {
+	switch {
+	case plan.Get != nil:
+		return plan.Get.Name, true
+	case plan.Put != nil:
+		return plan.Put.Name, true
+	case plan.Task != nil:
+		return plan.Task.Name, true
+	case plan.SetPipeline != nil:
+		return plan.SetPipeline.Name, true
+	case plan.LoadVar != nil:
+		return plan.LoadVar.Name, true
+	case plan.Approve != nil:
+		return plan.Approve.Name, true
+	}
+
+	return "", false
+}
@@ -95,6 +95,7 @@ type TaskStep struct {
 	workerPool          Pool
 	streamer            Streamer
 	delegateFactory     TaskDelegateFactory
+	idTokenGenerator    IDTokenGenerator
 	defaultTaskTimeout  time.Duration
 	defaultTaskCacheTTL time.Duration
 }
@@ -109,6 +110,7 @@ func NewTaskStep(
 	workerPool Pool,
 	streamer Streamer,
 	delegateFactory TaskDelegateFactory,
+	idTokenGenerator IDTokenGenerator,
 	defaultTaskTimeout time.Duration,
 	defaultTaskCacheTTL time.Duration,
 ) Step {
@@ -122,6 +124,7 @@ func NewTaskStep(
 		workerPool:          workerPool,
 		streamer:            streamer,
 		delegateFactory:     delegateFactory,
+		idTokenGenerator:    idTokenGenerator,
 		defaultTaskTimeout:  defaultTaskTimeout,
 		defaultTaskCacheTTL: defaultTaskCacheTTL,
 	}
@@ -259,6 +262,17 @@ func (step *TaskStep) run(ctx context.Context, state RunState, delegate TaskDele
 	if err != nil {
 		return false, err
 	}
+
+	var idToken *idToken
+	if step.plan.IDToken != nil {
+		idToken, err = newIDToken(step.idTokenGenerator, *step.plan.IDToken, step.metadata, step.plan.Name, state)
+		if err != nil {
+			return false, fmt.Errorf("generate id token: %w", err)
+		}
+
+		containerSpec.Env = append(containerSpec.Env, idToken.Env()...)
+		containerSpec.Outputs[idTokenOutputName] = IDTokenDir
+	}
 	tracing.Inject(ctx, &containerSpec)
 
 	owner := db.NewBuildStepContainerOwner(step.metadata.BuildID, step.planID, step.metadata.TeamID)
@@ -310,6 +324,16 @@ func (step *TaskStep) run(ctx context.Context, state RunState, delegate TaskDele
 		return false, err
 	}
 
+	if idToken != nil {
+		err = idToken.Write(ctx, volumeMounts)
+		if err != nil {
+			return false, fmt.Errorf("write id token: %w", err)
+		}
+
+		stopRefreshing := idToken.StartRefreshing(ctx, logger, volumeMounts)
+		defer stopRefreshing()
+	}
+
 	delegate.Starting(logger)
 	process, err := attachOrRun(
 		ctx,
@@ -444,6 +468,7 @@ func (step *TaskStep) containerSpec(logger lager.Logger, state RunState, imageSp
 		TeamID:   step.metadata.TeamID,
 		TeamName: step.metadata.TeamName,
 		JobID:    step.metadata.JobID,
+		Priority: step.metadata.JobPriority,
 		StepName: step.plan.Name,
 
 		ImageSpec: imageSpec,
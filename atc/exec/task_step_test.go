@@ -42,6 +42,9 @@ var _ = Describe("TaskStep", func() {
 
 		fakeDelegateFactory *execfakes.FakeTaskDelegateFactory
 
+		fakeIDTokenGenerator *execfakes.FakeIDTokenGenerator
+		idTokenGenerator     exec.IDTokenGenerator
+
 		taskPlan *atc.TaskPlan
 
 		state exec.RunState
@@ -91,6 +94,9 @@ var _ = Describe("TaskStep", func() {
 		fakeDelegateFactory = new(execfakes.FakeTaskDelegateFactory)
 		fakeDelegateFactory.TaskDelegateReturns(fakeDelegate)
 
+		fakeIDTokenGenerator = new(execfakes.FakeIDTokenGenerator)
+		idTokenGenerator = fakeIDTokenGenerator
+
 		state = exec.NewRunState(noopStepper, vars.StaticVariables{"source-param": "super-secret-source"})
 		repo = state.ArtifactRepository()
 
@@ -125,6 +131,7 @@ var _ = Describe("TaskStep", func() {
 			fakePool,
 			fakeStreamer,
 			fakeDelegateFactory,
+			idTokenGenerator,
 			defaultTaskTimeout,
 			defaultTaskCacheTTL,
 		)
@@ -305,6 +312,111 @@ var _ = Describe("TaskStep", func() {
 			})
 		})
 
+		Context("when an id token is configured", func() {
+			var idTokenVolume *runtimetest.Volume
+
+			BeforeEach(func() {
+				taskPlan.IDToken = &atc.IDTokenConfig{
+					Audience: []string{"sts.amazonaws.com"},
+					Env:      "AWS_WEB_IDENTITY_TOKEN",
+				}
+
+				fakeIDTokenGenerator.GenerateIDTokenReturns("some-token", time.Now().Add(time.Hour), nil)
+
+				idTokenVolume = runtimetest.NewVolume("id-token")
+				chosenContainer.Mounts = []runtime.VolumeMount{
+					{
+						Volume:    idTokenVolume,
+						MountPath: "/concourse/id-token",
+					},
+				}
+			})
+
+			It("generates a token for the step", func() {
+				Expect(fakeIDTokenGenerator.GenerateIDTokenCallCount()).To(Equal(1))
+				config, metadata, stepName := fakeIDTokenGenerator.GenerateIDTokenArgsForCall(0)
+				Expect(config).To(Equal(*taskPlan.IDToken))
+				Expect(metadata).To(Equal(stepMetadata))
+				Expect(stepName).To(Equal("some-task"))
+			})
+
+			It("sets the token and the path of its file in the container's env", func() {
+				Expect(chosenContainer.Spec.Env).To(ContainElements(
+					"CONCOURSE_ID_TOKEN_FILE=/concourse/id-token/token",
+					"AWS_WEB_IDENTITY_TOKEN=some-token",
+				))
+			})
+
+			It("mounts a volume for the token file", func() {
+				Expect(chosenContainer.Spec.Outputs).To(HaveKeyWithValue(".id-token", "/concourse/id-token"))
+			})
+
+			It("writes the token to the file", func() {
+				Expect(idTokenVolume.Content).To(HaveKey("token"))
+				Expect(string(idTokenVolume.Content["token"].Data)).To(Equal("some-token"))
+			})
+
+			It("does not register the token volume as an output", func() {
+				Expect(repo.AsMap()).ToNot(HaveKey(build.ArtifactName(".id-token")))
+			})
+
+			It("redacts the token from the build's output", func() {
+				mapit := vars.TrackedVarsMap{}
+				state.IterateInterpolatedCreds(mapit)
+				Expect(mapit).To(ContainElement("some-token"))
+			})
+
+			Context("when the token expires while the task is running", func() {
+				BeforeEach(func() {
+					fakeIDTokenGenerator.GenerateIDTokenReturns("refreshed-token", time.Now().Add(time.Hour), nil)
+					fakeIDTokenGenerator.GenerateIDTokenReturnsOnCall(0, "some-token", time.Now(), nil)
+					fakeIDTokenGenerator.GenerateIDTokenReturnsOnCall(1, "refreshed-token", time.Now(), nil)
+
+					chosenContainer.ProcessDefs[0].Stub.Do = func(_ context.Context, _ *runtimetest.Process) error {
+						defer GinkgoRecover()
+
+						// the token is written before the next one is generated
+						Eventually(fakeIDTokenGenerator.GenerateIDTokenCallCount, 5*time.Second).Should(BeNumerically(">=", 3))
+
+						return nil
+					}
+				})
+
+				It("refreshes the token file", func() {
+					Expect(stepErr).ToNot(HaveOccurred())
+					Expect(stepOk).To(BeTrue())
+					Expect(string(idTokenVolume.Content["token"].Data)).To(Equal("refreshed-token"))
+				})
+
+				It("keeps redacting the earlier token along with the refreshed one", func() {
+					mapit := vars.TrackedVarsMap{}
+					state.IterateInterpolatedCreds(mapit)
+					Expect(mapit).To(ContainElements("some-token", "refreshed-token"))
+				})
+			})
+
+			Context("when generating the token fails", func() {
+				BeforeEach(func() {
+					fakeIDTokenGenerator.GenerateIDTokenReturns("", time.Time{}, errors.New("nope"))
+				})
+
+				It("errors before creating the container", func() {
+					Expect(stepErr).To(MatchError("generate id token: nope"))
+					Expect(fakePool.FindOrSelectWorkerCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when id tokens are not configured", func() {
+				BeforeEach(func() {
+					idTokenGenerator = nil
+				})
+
+				It("errors", func() {
+					Expect(stepErr).To(MatchError(exec.ErrIDTokensNotConfigured))
+				})
+			})
+		})
+
 		Context("when a timeout is configured", func() {
 			BeforeEach(func() {
 				taskPlan.Timeout = "1ms"
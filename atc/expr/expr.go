@@ -0,0 +1,330 @@
+// Package expr parses and evaluates the boolean conditions given to `if:`
+// steps, e.g.
+//
+//	vars.version.major >= 2 && steps.unit.status == "succeeded"
+//
+// An expression is made of references (dotted paths such as `vars.version`),
+// string, number, boolean and null literals, the comparison operators `==`,
+// `!=`, `<`, `<=`, `>`, `>=` and `=~` (regular expression match), the logical
+// operators `!`, `&&` and `||`, and parentheses.
+//
+// What references resolve to is up to the Env the expression is evaluated
+// with.
+package expr
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// Reference is a dotted path to a value, e.g. `vars.version.major`.
+type Reference []string
+
+func (ref Reference) String() string {
+	segs := make([]string, len(ref))
+	for i, seg := range ref {
+		if isIdentifier(seg) {
+			segs[i] = seg
+		} else {
+			segs[i] = fmt.Sprintf("%q", seg)
+		}
+	}
+
+	return strings.Join(segs, ".")
+}
+
+// Env resolves the references made by an expression while it is evaluated.
+type Env interface {
+	Lookup(Reference) (any, error)
+}
+
+// Expression is a parsed condition.
+type Expression struct {
+	source string
+	root   node
+}
+
+// Parse parses the source of an expression.
+func Parse(source string) (Expression, error) {
+	p := &parser{source: source}
+
+	err := p.scan()
+	if err != nil {
+		return Expression{}, err
+	}
+
+	if p.peek().kind == tokenEOF {
+		return Expression{}, p.errorf(p.peek(), "expression is empty")
+	}
+
+	root, err := p.parseOr()
+	if err != nil {
+		return Expression{}, err
+	}
+
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return Expression{}, p.errorf(tok, "unexpected %s", tok)
+	}
+
+	return Expression{source: source, root: root}, nil
+}
+
+func (expression Expression) String() string {
+	return expression.source
+}
+
+// References returns every reference made by the expression, in the order
+// they appear.
+func (expression Expression) References() []Reference {
+	var refs []Reference
+	if expression.root != nil {
+		expression.root.references(&refs)
+	}
+
+	return refs
+}
+
+// Evaluate evaluates the expression, returning whether it holds.
+//
+// The result, and the operands of `!`, `&&` and `||`, are interpreted as
+// booleans: false, null, the empty string, zero, and empty lists and maps
+// are false, and everything else is true. `&&` and `||` short-circuit, so
+// references on their right hand side are only resolved when needed.
+func (expression Expression) Evaluate(env Env) (bool, error) {
+	val, err := expression.root.eval(env)
+	if err != nil {
+		return false, err
+	}
+
+	return truthy(val), nil
+}
+
+type node interface {
+	eval(Env) (any, error)
+	references(*[]Reference)
+}
+
+type literalNode struct {
+	value any
+}
+
+func (n literalNode) eval(Env) (any, error) {
+	return n.value, nil
+}
+
+func (literalNode) references(*[]Reference) {}
+
+type referenceNode struct {
+	ref Reference
+}
+
+func (n referenceNode) eval(env Env) (any, error) {
+	return env.Lookup(n.ref)
+}
+
+func (n referenceNode) references(refs *[]Reference) {
+	*refs = append(*refs, n.ref)
+}
+
+type notNode struct {
+	operand node
+}
+
+func (n notNode) eval(env Env) (any, error) {
+	val, err := n.operand.eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	return !truthy(val), nil
+}
+
+func (n notNode) references(refs *[]Reference) {
+	n.operand.references(refs)
+}
+
+type logicalNode struct {
+	and         bool
+	left, right node
+}
+
+func (n logicalNode) eval(env Env) (any, error) {
+	left, err := n.left.eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	if truthy(left) != n.and {
+		return !n.and, nil
+	}
+
+	right, err := n.right.eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	return truthy(right), nil
+}
+
+func (n logicalNode) references(refs *[]Reference) {
+	n.left.references(refs)
+	n.right.references(refs)
+}
+
+type comparisonNode struct {
+	op          string
+	left, right node
+
+	// pattern is compiled up front when the right hand side of `=~` is a
+	// literal.
+	pattern *regexp.Regexp
+}
+
+func (n comparisonNode) eval(env Env) (any, error) {
+	left, err := n.left.eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	right, err := n.right.eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	left, right = normalize(left), normalize(right)
+
+	switch n.op {
+	case "==":
+		return equal(left, right), nil
+	case "!=":
+		return !equal(left, right), nil
+	case "=~":
+		return n.match(left, right)
+	}
+
+	switch l := left.(type) {
+	case float64:
+		if r, ok := right.(float64); ok {
+			return compare(n.op, l < r, l == r), nil
+		}
+	case string:
+		if r, ok := right.(string); ok {
+			return compare(n.op, l < r, l == r), nil
+		}
+	}
+
+	return nil, fmt.Errorf("cannot compare %s %s %s", typeName(left), n.op, typeName(right))
+}
+
+func (n comparisonNode) match(left, right any) (any, error) {
+	str, ok := left.(string)
+	if !ok {
+		return nil, fmt.Errorf("cannot match %s against a pattern", typeName(left))
+	}
+
+	pattern := n.pattern
+	if pattern == nil {
+		source, ok := right.(string)
+		if !ok {
+			return nil, fmt.Errorf("pattern must be a string, not %s", typeName(right))
+		}
+
+		var err error
+		pattern, err = regexp.Compile(source)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern: %w", err)
+		}
+	}
+
+	return pattern.MatchString(str), nil
+}
+
+func (n comparisonNode) references(refs *[]Reference) {
+	n.left.references(refs)
+	n.right.references(refs)
+}
+
+func compare(op string, less, equal bool) bool {
+	switch op {
+	case "<":
+		return less
+	case "<=":
+		return less || equal
+	case ">":
+		return !less && !equal
+	default:
+		return !less
+	}
+}
+
+func equal(left, right any) bool {
+	return reflect.DeepEqual(left, right)
+}
+
+// normalize converts the many numeric types vars may hold into float64 so
+// that they can be compared with each other and with number literals.
+func normalize(val any) any {
+	rv := reflect.ValueOf(val)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		return rv.Float()
+	}
+
+	if num, ok := val.(interface{ Float64() (float64, error) }); ok {
+		f, err := num.Float64()
+		if err == nil {
+			return f
+		}
+	}
+
+	return val
+}
+
+func truthy(val any) bool {
+	switch v := normalize(val).(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != ""
+	case float64:
+		return v != 0
+	}
+
+	rv := reflect.ValueOf(val)
+	switch rv.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array:
+		return rv.Len() > 0
+	}
+
+	return true
+}
+
+func typeName(val any) string {
+	switch normalize(val).(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	}
+
+	switch reflect.ValueOf(val).Kind() {
+	case reflect.Map:
+		return "map"
+	case reflect.Slice, reflect.Array:
+		return "list"
+	}
+
+	return fmt.Sprintf("%T", val)
+}
@@ -0,0 +1,121 @@
+package expr_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/concourse/concourse/atc/expr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type staticEnv map[string]any
+
+func (env staticEnv) Lookup(ref expr.Reference) (any, error) {
+	val, found := env[strings.Join(ref, ".")]
+	if !found {
+		return nil, fmt.Errorf("undefined reference '%s'", ref)
+	}
+
+	return val, nil
+}
+
+var _ = Describe("Expressions", func() {
+	env := staticEnv{
+		"vars.branch":            "release/1.2",
+		"vars.count":             3,
+		"vars.ratio":             json.Number("0.5"),
+		"vars.enabled":           true,
+		"vars.empty":             "",
+		"vars.nothing":           nil,
+		"vars.list":              []any{"a"},
+		"steps.unit.status":      "succeeded",
+		"steps.unit-tests.ok":    false,
+		"instance_vars.some.key": "value",
+	}
+
+	DescribeTable("evaluating",
+		func(source string, expected bool) {
+			expression, err := expr.Parse(source)
+			Expect(err).ToNot(HaveOccurred())
+
+			result, err := expression.Evaluate(env)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal(expected))
+		},
+		Entry("true literal", "true", true),
+		Entry("false literal", "false", false),
+		Entry("string equality", `steps.unit.status == "succeeded"`, true),
+		Entry("single quoted strings", `steps.unit.status != 'failed'`, true),
+		Entry("number comparison", "vars.count > 2", true),
+		Entry("number comparison with negative numbers", "vars.count >= -1", true),
+		Entry("numbers of different types", "vars.count == 3 && vars.ratio < 1.5", true),
+		Entry("string ordering", `"a" < "b"`, true),
+		Entry("pattern matching", `vars.branch =~ "^release/"`, true),
+		Entry("hyphenated names", "!steps.unit-tests.ok", true),
+		Entry("quoted field names", `instance_vars."some.key" == "value"`, true),
+		Entry("truthy values", "vars.enabled && vars.list && vars.count", true),
+		Entry("falsy values", "vars.empty || vars.nothing || vars.nothing == null && false", false),
+		Entry("precedence of && over ||", "true || false && false", true),
+		Entry("parentheses", "(true || false) && false", false),
+		Entry("double negation", "!!vars.enabled", true),
+	)
+
+	It("short-circuits so that the right hand side is only resolved when needed", func() {
+		expression, err := expr.Parse("vars.enabled || vars.undefined")
+		Expect(err).ToNot(HaveOccurred())
+
+		result, err := expression.Evaluate(env)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeTrue())
+	})
+
+	It("returns errors from the env", func() {
+		expression, err := expr.Parse("vars.enabled && vars.undefined")
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = expression.Evaluate(env)
+		Expect(err).To(MatchError("undefined reference 'vars.undefined'"))
+	})
+
+	It("refuses to order values of different types", func() {
+		expression, err := expr.Parse(`vars.count < "4"`)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = expression.Evaluate(env)
+		Expect(err).To(MatchError("cannot compare number < string"))
+	})
+
+	It("lists the references it makes", func() {
+		expression, err := expr.Parse(`steps.unit.status == "succeeded" && (vars.version.0 > 1 || vars."some.var")`)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(expression.References()).To(Equal([]expr.Reference{
+			{"steps", "unit", "status"},
+			{"vars", "version", "0"},
+			{"vars", "some.var"},
+		}))
+		Expect(expression.References()[2].String()).To(Equal(`vars."some.var"`))
+	})
+
+	DescribeTable("parse errors",
+		func(source string, message string) {
+			_, err := expr.Parse(source)
+
+			var parseErr expr.ParseError
+			Expect(errors.As(err, &parseErr)).To(BeTrue())
+			Expect(err).To(MatchError(fmt.Sprintf("invalid expression '%s': %s", source, message)))
+		},
+		Entry("empty", "", "expression is empty at column 1"),
+		Entry("unbalanced parentheses", "(true", "expected ')' but got end of expression at column 6"),
+		Entry("trailing tokens", "true false", "unexpected 'false' at column 6"),
+		Entry("missing operand", "vars.a ==", "unexpected end of expression at column 10"),
+		Entry("unknown characters", "vars.a = 1", "unexpected character '=' at column 8"),
+		Entry("unterminated strings", `vars.a == "b`, "unterminated string at column 11"),
+		Entry("missing field names", "vars. == 1", "expected a field name after '.' but got '==' at column 7"),
+		Entry("chained comparisons", "1 < 2 < 3", "comparisons cannot be chained at column 7"),
+		Entry("invalid patterns", `vars.a =~ "("`, "invalid pattern: error parsing regexp: missing closing ): `(` at column 8"),
+	)
+})
@@ -0,0 +1,387 @@
+package expr
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ParseError is returned when an expression cannot be parsed.
+type ParseError struct {
+	Source  string
+	Column  int
+	Message string
+}
+
+func (err ParseError) Error() string {
+	return fmt.Sprintf("invalid expression '%s': %s at column %d", err.Source, err.Message, err.Column)
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdentifier
+	tokenString
+	tokenNumber
+	tokenOperator
+	tokenDot
+	tokenLeftParen
+	tokenRightParen
+)
+
+type token struct {
+	kind  tokenKind
+	text  string
+	value any
+	pos   int
+}
+
+func (tok token) String() string {
+	if tok.kind == tokenEOF {
+		return "end of expression"
+	}
+
+	return fmt.Sprintf("'%s'", tok.text)
+}
+
+var operators = []string{"&&", "||", "==", "!=", "=~", "<=", ">=", "<", ">", "!"}
+
+type parser struct {
+	source string
+	tokens []token
+	next   int
+}
+
+func (p *parser) errorf(tok token, format string, args ...any) error {
+	return ParseError{
+		Source:  p.source,
+		Column:  utf8.RuneCountInString(p.source[:tok.pos]) + 1,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+func (p *parser) scan() error {
+	src := p.source
+
+	for pos := 0; pos < len(src); {
+		c := src[pos]
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			pos++
+
+		case c == '.':
+			p.tokens = append(p.tokens, token{kind: tokenDot, text: ".", pos: pos})
+			pos++
+
+		case c == '(':
+			p.tokens = append(p.tokens, token{kind: tokenLeftParen, text: "(", pos: pos})
+			pos++
+
+		case c == ')':
+			p.tokens = append(p.tokens, token{kind: tokenRightParen, text: ")", pos: pos})
+			pos++
+
+		case c == '"' || c == '\'':
+			end := pos + 1
+			for end < len(src) && src[end] != c {
+				if src[end] == '\\' {
+					end++
+				}
+				end++
+			}
+
+			if end >= len(src) {
+				return p.errorf(token{pos: pos}, "unterminated string")
+			}
+
+			text := src[pos : end+1]
+			value, err := unquote(text)
+			if err != nil {
+				return p.errorf(token{pos: pos}, "invalid string %s", text)
+			}
+
+			p.tokens = append(p.tokens, token{kind: tokenString, text: text, value: value, pos: pos})
+			pos = end + 1
+
+		case !p.follows(tokenDot) && (isDigit(c) || (c == '-' && pos+1 < len(src) && isDigit(src[pos+1]) && p.expectsOperand())):
+			end := pos + 1
+			for end < len(src) && isDigit(src[end]) {
+				end++
+			}
+
+			if end+1 < len(src) && src[end] == '.' && isDigit(src[end+1]) {
+				end++
+				for end < len(src) && isDigit(src[end]) {
+					end++
+				}
+			}
+
+			text := src[pos:end]
+			value, err := strconv.ParseFloat(text, 64)
+			if err != nil {
+				return p.errorf(token{pos: pos}, "invalid number '%s'", text)
+			}
+
+			p.tokens = append(p.tokens, token{kind: tokenNumber, text: text, value: value, pos: pos})
+			pos = end
+
+		case isIdentifierChar(c):
+			end := pos + 1
+			for end < len(src) && isIdentifierChar(src[end]) {
+				end++
+			}
+
+			p.tokens = append(p.tokens, token{kind: tokenIdentifier, text: src[pos:end], pos: pos})
+			pos = end
+
+		default:
+			var op string
+			for _, candidate := range operators {
+				if strings.HasPrefix(src[pos:], candidate) {
+					op = candidate
+					break
+				}
+			}
+
+			if op == "" {
+				r, _ := utf8.DecodeRuneInString(src[pos:])
+				return p.errorf(token{pos: pos}, "unexpected character '%c'", r)
+			}
+
+			p.tokens = append(p.tokens, token{kind: tokenOperator, text: op, pos: pos})
+			pos += len(op)
+		}
+	}
+
+	p.tokens = append(p.tokens, token{kind: tokenEOF, pos: len(src)})
+
+	return nil
+}
+
+// expectsOperand reports whether the next token must start an operand, in
+// which case a '-' starts a negative number.
+func (p *parser) expectsOperand() bool {
+	return len(p.tokens) == 0 || p.follows(tokenOperator) || p.follows(tokenLeftParen)
+}
+
+// follows reports whether the last token scanned is of the given kind. Field
+// names following a '.' are always scanned as identifiers, even when they
+// look like numbers.
+func (p *parser) follows(kind tokenKind) bool {
+	return len(p.tokens) > 0 && p.tokens[len(p.tokens)-1].kind == kind
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.next]
+}
+
+func (p *parser) advance() token {
+	tok := p.tokens[p.next]
+	if tok.kind != tokenEOF {
+		p.next++
+	}
+
+	return tok
+}
+
+func (p *parser) acceptOperator(ops ...string) (token, bool) {
+	tok := p.peek()
+	if tok.kind != tokenOperator {
+		return tok, false
+	}
+
+	for _, op := range ops {
+		if tok.text == op {
+			return p.advance(), true
+		}
+	}
+
+	return tok, false
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		_, ok := p.acceptOperator("||")
+		if !ok {
+			return left, nil
+		}
+
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+
+		left = logicalNode{and: false, left: left, right: right}
+	}
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		_, ok := p.acceptOperator("&&")
+		if !ok {
+			return left, nil
+		}
+
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+
+		left = logicalNode{and: true, left: left, right: right}
+	}
+}
+
+func (p *parser) parseNot() (node, error) {
+	if _, ok := p.acceptOperator("!"); ok {
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+
+		return notNode{operand: operand}, nil
+	}
+
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (node, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	tok, ok := p.acceptOperator("==", "!=", "=~", "<=", ">=", "<", ">")
+	if !ok {
+		return left, nil
+	}
+
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	comparison := comparisonNode{op: tok.text, left: left, right: right}
+
+	if lit, ok := right.(literalNode); ok && tok.text == "=~" {
+		source, ok := lit.value.(string)
+		if !ok {
+			return nil, p.errorf(tok, "pattern must be a string")
+		}
+
+		comparison.pattern, err = regexp.Compile(source)
+		if err != nil {
+			return nil, p.errorf(tok, "invalid pattern: %s", err)
+		}
+	}
+
+	if _, ok := p.acceptOperator("==", "!=", "=~", "<=", ">=", "<", ">"); ok {
+		return nil, p.errorf(p.tokens[p.next-1], "comparisons cannot be chained")
+	}
+
+	return comparison, nil
+}
+
+func (p *parser) parseOperand() (node, error) {
+	tok := p.advance()
+
+	switch tok.kind {
+	case tokenString, tokenNumber:
+		return literalNode{value: tok.value}, nil
+
+	case tokenLeftParen:
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if closing := p.advance(); closing.kind != tokenRightParen {
+			return nil, p.errorf(closing, "expected ')' but got %s", closing)
+		}
+
+		return inner, nil
+
+	case tokenIdentifier:
+		if p.peek().kind != tokenDot {
+			switch tok.text {
+			case "true":
+				return literalNode{value: true}, nil
+			case "false":
+				return literalNode{value: false}, nil
+			case "null":
+				return literalNode{value: nil}, nil
+			}
+		}
+
+		if isDigit(tok.text[0]) || tok.text[0] == '-' {
+			return nil, p.errorf(tok, "unexpected %s", tok)
+		}
+
+		ref := Reference{tok.text}
+		for p.peek().kind == tokenDot {
+			p.advance()
+
+			seg := p.advance()
+			switch seg.kind {
+			case tokenIdentifier:
+				ref = append(ref, seg.text)
+			case tokenString:
+				ref = append(ref, seg.value.(string))
+			default:
+				return nil, p.errorf(seg, "expected a field name after '.' but got %s", seg)
+			}
+		}
+
+		return referenceNode{ref: ref}, nil
+	}
+
+	return nil, p.errorf(tok, "unexpected %s", tok)
+}
+
+func unquote(text string) (string, error) {
+	if text[0] == '\'' {
+		// convert to a double-quoted string so that strconv can handle the
+		// escape sequences
+		inner := text[1 : len(text)-1]
+		inner = strings.ReplaceAll(inner, `\'`, `'`)
+		inner = strings.ReplaceAll(inner, `"`, `\"`)
+		text = `"` + inner + `"`
+	}
+
+	return strconv.Unquote(text)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentifierChar(c byte) bool {
+	return c == '_' || c == '-' || isDigit(c) || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentifier(s string) bool {
+	if s == "" || isDigit(s[0]) || s[0] == '-' {
+		return false
+	}
+
+	for i := 0; i < len(s); i++ {
+		if !isIdentifierChar(s[i]) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,267 @@
+// Package fairshare orders work queued by several teams using weighted fair
+// queueing, so that a team with a lot of queued work cannot starve the others.
+package fairshare
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+)
+
+// DefaultWeight is the weight of the teams which a policy doesn't name.
+const DefaultWeight = 1.0
+
+// Policy assigns each team a weight. Teams are entitled to a share of the
+// capacity proportional to their weight, e.g. a team with weight 2 is served
+// twice as often as a team with weight 1 while both have work queued.
+type Policy struct {
+	Weights map[string]float64
+}
+
+// Weight returns the weight of the team.
+func (policy Policy) Weight(team string) float64 {
+	if weight, found := policy.Weights[team]; found {
+		return weight
+	}
+
+	return DefaultWeight
+}
+
+// Validate returns an error if any of the weights isn't positive.
+func (policy Policy) Validate() error {
+	for _, team := range slices.Sorted(maps.Keys(policy.Weights)) {
+		if policy.Weights[team] <= 0 {
+			return fmt.Errorf("invalid fair share weight %v for team '%s': must be greater than 0", policy.Weights[team], team)
+		}
+	}
+
+	return nil
+}
+
+// Entry is a unit of work queued by a team.
+type Entry struct {
+	Team     string
+	Priority int
+}
+
+// Order returns the indexes of the entries in the order in which they should
+// be served, given the amount of work each team already has in progress.
+//
+// A team's entries are served by descending priority, and then in the order
+// given. Each entry is given a virtual finish time: the team's work in
+// progress plus the entry's position within the team, divided by the team's
+// weight. Entries are served by ascending finish time, so teams take turns in
+// proportion to their weights. Ties are broken by priority, and then by the
+// order given.
+func (policy Policy) Order(entries []Entry, inProgress map[string]int) []int {
+	byTeam := map[string][]int{}
+	for i, entry := range entries {
+		byTeam[entry.Team] = append(byTeam[entry.Team], i)
+	}
+
+	finish := make([]float64, len(entries))
+	for team, indexes := range byTeam {
+		slices.SortStableFunc(indexes, func(a, b int) int {
+			return cmp.Compare(entries[b].Priority, entries[a].Priority)
+		})
+
+		weight := policy.Weight(team)
+		for position, i := range indexes {
+			finish[i] = float64(inProgress[team]+position+1) / weight
+		}
+	}
+
+	order := make([]int, len(entries))
+	for i := range order {
+		order[i] = i
+	}
+
+	slices.SortStableFunc(order, func(a, b int) int {
+		return cmp.Or(
+			cmp.Compare(finish[a], finish[b]),
+			cmp.Compare(entries[b].Priority, entries[a].Priority),
+		)
+	})
+
+	return order
+}
+
+// Queue tracks work waiting for a limited set of candidates, such as steps
+// waiting for a worker, so that candidates are granted in fair share order
+// rather than to whichever waiter happens to ask first.
+//
+// A Queue only knows about the work of the process it lives in: the waiting
+// entries and the work in progress are kept in memory. In a cluster of
+// several ATCs each one orders its own waiters, so fair share is not enforced
+// across the cluster as a whole.
+type Queue struct {
+	policy Policy
+
+	lock       sync.Mutex
+	tickets    []*Ticket
+	inProgress map[string]int
+}
+
+func NewQueue(policy Policy) *Queue {
+	return &Queue{
+		policy:     policy,
+		inProgress: map[string]int{},
+	}
+}
+
+// Ticket is an entry's place in a Queue.
+type Ticket struct {
+	entry Entry
+	queue *Queue
+
+	// candidates is set once the entry has had to wait, and names what it is
+	// waiting for.
+	candidates map[string]bool
+
+	woken chan struct{}
+}
+
+// Join adds the entry to the queue. The ticket must be left once the entry
+// has been served or has given up.
+func (queue *Queue) Join(entry Entry) *Ticket {
+	ticket := &Ticket{
+		entry: entry,
+		queue: queue,
+		woken: make(chan struct{}, 1),
+	}
+
+	queue.lock.Lock()
+	queue.tickets = append(queue.tickets, ticket)
+	queue.lock.Unlock()
+
+	return ticket
+}
+
+// Leave removes the ticket from its queue, if it hasn't been served.
+func (ticket *Ticket) Leave() {
+	queue := ticket.queue
+
+	queue.lock.Lock()
+	defer queue.lock.Unlock()
+
+	queue.tickets = slices.DeleteFunc(queue.tickets, func(t *Ticket) bool {
+		return t == ticket
+	})
+}
+
+// Serve removes the ticket from its queue, counting its entry as work in
+// progress for its team until Done is called.
+func (ticket *Ticket) Serve() {
+	queue := ticket.queue
+
+	queue.lock.Lock()
+	defer queue.lock.Unlock()
+
+	queue.tickets = slices.DeleteFunc(queue.tickets, func(t *Ticket) bool {
+		return t == ticket
+	})
+
+	queue.inProgress[ticket.entry.Team]++
+}
+
+// Done records that a served entry of the team is no longer in progress.
+func (queue *Queue) Done(team string) {
+	queue.lock.Lock()
+	defer queue.lock.Unlock()
+
+	if queue.inProgress[team] > 1 {
+		queue.inProgress[team]--
+	} else {
+		delete(queue.inProgress, team)
+	}
+}
+
+// Wait records that the entry is waiting for any of the candidates.
+func (ticket *Ticket) Wait(candidates []string) {
+	queue := ticket.queue
+
+	queue.lock.Lock()
+	defer queue.lock.Unlock()
+
+	ticket.candidates = map[string]bool{}
+	for _, candidate := range candidates {
+		ticket.candidates[candidate] = true
+	}
+}
+
+// Woken returns a channel which receives when the ticket is woken.
+func (ticket *Ticket) Woken() <-chan struct{} {
+	return ticket.woken
+}
+
+// Wake wakes the first entry, in fair share order, which is waiting for the
+// candidate, e.g. because the candidate has just become available. It returns
+// false if no entry is waiting for it.
+func (queue *Queue) Wake(candidate string) bool {
+	queue.lock.Lock()
+	defer queue.lock.Unlock()
+
+	var waiting []*Ticket
+	for _, ticket := range queue.tickets {
+		if ticket.candidates[candidate] {
+			waiting = append(waiting, ticket)
+		}
+	}
+
+	if len(waiting) == 0 {
+		return false
+	}
+
+	select {
+	case waiting[queue.policy.Order(entries(waiting), queue.inProgress)[0]].woken <- struct{}{}:
+	default:
+		// already woken
+	}
+
+	return true
+}
+
+// Yield reports whether the entry should give way to a waiting entry which
+// comes before it in fair share order and is waiting for the candidate that
+// the entry was about to take. Entries which haven't had to wait never hold
+// back others, and neither do entries which couldn't use the candidate.
+func (ticket *Ticket) Yield(candidate string) bool {
+	queue := ticket.queue
+
+	queue.lock.Lock()
+	defer queue.lock.Unlock()
+
+	competing := []*Ticket{ticket}
+	for _, other := range queue.tickets {
+		if other == ticket || other.candidates == nil {
+			continue
+		}
+
+		if other.candidates[candidate] {
+			competing = append(competing, other)
+		}
+	}
+
+	if len(competing) == 1 {
+		return false
+	}
+
+	// tickets are kept in the order they joined, which breaks ties between
+	// entries of the same team and priority
+	slices.SortStableFunc(competing, func(a, b *Ticket) int {
+		return cmp.Compare(slices.Index(queue.tickets, a), slices.Index(queue.tickets, b))
+	})
+
+	return competing[queue.policy.Order(entries(competing), queue.inProgress)[0]] != ticket
+}
+
+func entries(tickets []*Ticket) []Entry {
+	entries := make([]Entry, len(tickets))
+	for i, ticket := range tickets {
+		entries[i] = ticket.entry
+	}
+
+	return entries
+}
@@ -0,0 +1,13 @@
+package fairshare_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestFairShare(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Fair Share Suite")
+}
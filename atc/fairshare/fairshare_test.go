@@ -0,0 +1,168 @@
+package fairshare_test
+
+import (
+	"github.com/concourse/concourse/atc/fairshare"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Fair share", func() {
+	Describe("Policy", func() {
+		It("defaults the weight of teams it doesn't name", func() {
+			policy := fairshare.Policy{Weights: map[string]float64{"main": 3}}
+			Expect(policy.Weight("main")).To(Equal(3.0))
+			Expect(policy.Weight("other")).To(Equal(fairshare.DefaultWeight))
+		})
+
+		It("rejects weights which aren't positive", func() {
+			policy := fairshare.Policy{Weights: map[string]float64{"main": 1, "other": 0}}
+			Expect(policy.Validate()).To(MatchError("invalid fair share weight 0 for team 'other': must be greater than 0"))
+		})
+	})
+
+	Describe("Order", func() {
+		teams := func(entries []fairshare.Entry, order []int) []string {
+			var names []string
+			for _, i := range order {
+				names = append(names, entries[i].Team)
+			}
+			return names
+		}
+
+		It("takes turns between teams rather than serving the team with the most work first", func() {
+			entries := []fairshare.Entry{
+				{Team: "busy"}, {Team: "busy"}, {Team: "busy"}, {Team: "busy"},
+				{Team: "quiet"}, {Team: "quiet"},
+			}
+
+			order := fairshare.Policy{}.Order(entries, nil)
+			Expect(teams(entries, order)).To(Equal([]string{"busy", "quiet", "busy", "quiet", "busy", "busy"}))
+			Expect(order[:3]).To(Equal([]int{0, 4, 1}))
+		})
+
+		It("serves teams in proportion to their weights", func() {
+			entries := []fairshare.Entry{
+				{Team: "a"}, {Team: "a"}, {Team: "a"}, {Team: "a"},
+				{Team: "b"}, {Team: "b"}, {Team: "b"}, {Team: "b"},
+			}
+
+			order := fairshare.Policy{Weights: map[string]float64{"b": 2}}.Order(entries, nil)
+			Expect(teams(entries, order)).To(Equal([]string{"b", "a", "b", "b", "a", "b", "a", "a"}))
+		})
+
+		It("accounts for work already in progress", func() {
+			entries := []fairshare.Entry{{Team: "busy"}, {Team: "quiet"}, {Team: "quiet"}}
+
+			order := fairshare.Policy{}.Order(entries, map[string]int{"busy": 2})
+			Expect(order).To(Equal([]int{1, 2, 0}))
+		})
+
+		It("serves a team's entries by priority", func() {
+			entries := []fairshare.Entry{
+				{Team: "a", Priority: 0},
+				{Team: "a", Priority: 10},
+				{Team: "b", Priority: 5},
+				{Team: "a", Priority: 10},
+			}
+
+			order := fairshare.Policy{}.Order(entries, nil)
+			Expect(order).To(Equal([]int{1, 2, 3, 0}))
+		})
+	})
+
+	Describe("Queue", func() {
+		var queue *fairshare.Queue
+
+		BeforeEach(func() {
+			queue = fairshare.NewQueue(fairshare.Policy{})
+		})
+
+		It("does not yield when nothing else is waiting", func() {
+			ticket := queue.Join(fairshare.Entry{Team: "a"})
+			defer ticket.Leave()
+
+			queue.Join(fairshare.Entry{Team: "b"})
+
+			Expect(ticket.Yield("worker")).To(BeFalse())
+		})
+
+		It("yields to a waiting entry which comes first and competes for the same candidates", func() {
+			busy := []*fairshare.Ticket{
+				queue.Join(fairshare.Entry{Team: "busy"}),
+				queue.Join(fairshare.Entry{Team: "busy"}),
+			}
+			quiet := queue.Join(fairshare.Entry{Team: "quiet"})
+
+			for _, ticket := range append(busy, quiet) {
+				ticket.Wait([]string{"worker"})
+			}
+
+			Expect(busy[0].Yield("worker")).To(BeFalse())
+			Expect(busy[1].Yield("worker")).To(BeTrue())
+			Expect(quiet.Yield("worker")).To(BeTrue())
+
+			busy[0].Leave()
+
+			Expect(busy[1].Yield("worker")).To(BeFalse())
+			Expect(quiet.Yield("worker")).To(BeTrue())
+		})
+
+		It("accounts for served entries until they are done", func() {
+			busy := []*fairshare.Ticket{
+				queue.Join(fairshare.Entry{Team: "busy"}),
+				queue.Join(fairshare.Entry{Team: "busy"}),
+			}
+			quiet := queue.Join(fairshare.Entry{Team: "quiet"})
+
+			for _, ticket := range append(busy, quiet) {
+				ticket.Wait([]string{"worker"})
+			}
+
+			busy[0].Serve()
+
+			Expect(busy[1].Yield("worker")).To(BeTrue())
+			Expect(quiet.Yield("worker")).To(BeFalse())
+
+			queue.Done("busy")
+
+			Expect(busy[1].Yield("worker")).To(BeFalse())
+			Expect(quiet.Yield("worker")).To(BeTrue())
+		})
+
+		It("does not yield to entries waiting for other candidates", func() {
+			other := queue.Join(fairshare.Entry{Team: "a"})
+			other.Wait([]string{"tagged-worker"})
+
+			ticket := queue.Join(fairshare.Entry{Team: "a"})
+			Expect(ticket.Yield("worker")).To(BeFalse())
+			Expect(ticket.Yield("tagged-worker")).To(BeTrue())
+		})
+
+		It("wakes the first entry waiting for a candidate", func() {
+			queue = fairshare.NewQueue(fairshare.Policy{Weights: map[string]float64{"quiet": 2}})
+
+			busy := queue.Join(fairshare.Entry{Team: "busy"})
+			busy.Wait([]string{"worker"})
+
+			quiet := queue.Join(fairshare.Entry{Team: "quiet"})
+			quiet.Wait([]string{"worker", "other-worker"})
+
+			Expect(queue.Wake("worker")).To(BeTrue())
+			Expect(quiet.Woken()).To(Receive())
+			Expect(busy.Woken()).ToNot(Receive())
+
+			Expect(queue.Wake("unknown-worker")).To(BeFalse())
+		})
+
+		It("lets higher priority entries of the same team go first", func() {
+			low := queue.Join(fairshare.Entry{Team: "a"})
+			low.Wait([]string{"worker"})
+
+			high := queue.Join(fairshare.Entry{Team: "a", Priority: 1})
+			Expect(high.Yield("worker")).To(BeFalse())
+
+			high.Wait([]string{"worker"})
+			Expect(low.Yield("worker")).To(BeTrue())
+		})
+	})
+})
@@ -20,4 +20,8 @@ func FeatureFlags() map[string]bool {
 
 var (
 	DisableRedactSecrets bool
+
+	// RejectConfigSecrets makes literal secrets found in pipeline configs an
+	// error rather than a warning.
+	RejectConfigSecrets bool
 )
@@ -0,0 +1,79 @@
+package gc
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager/v3"
+	"code.cloudfoundry.org/lager/v3/lagerctx"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type buildLogArchiver struct {
+	buildFactory      db.BuildFactory
+	clock             clock.Clock
+	archiveAfter      time.Duration
+	batchSize         int
+	drainerConfigured bool
+}
+
+// NewBuildLogArchiver moves the events of builds which finished more than
+// archiveAfter ago out of the database and into the build log archive, and
+// removes the archived events of builds which have since been reaped or
+// deleted.
+func NewBuildLogArchiver(
+	buildFactory db.BuildFactory,
+	clock clock.Clock,
+	archiveAfter time.Duration,
+	batchSize int,
+	drainerConfigured bool,
+) *buildLogArchiver {
+	return &buildLogArchiver{
+		buildFactory:      buildFactory,
+		clock:             clock,
+		archiveAfter:      archiveAfter,
+		batchSize:         batchSize,
+		drainerConfigured: drainerConfigured,
+	}
+}
+
+func (a *buildLogArchiver) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("build-log-archiver")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	deleted, err := a.buildFactory.DeleteUnusedArchivedBuildEvents(ctx)
+	if err != nil {
+		logger.Error("failed-to-delete-unused-archived-build-events", err)
+	}
+
+	if deleted > 0 {
+		logger.Debug("deleted-unused-archived-build-events", lager.Data{"count": deleted})
+	}
+
+	// builds must be drained to syslog before their events leave the database
+	builds, err := a.buildFactory.GetArchivableBuilds(a.clock.Now().Add(-a.archiveAfter), a.drainerConfigured, a.batchSize)
+	if err != nil {
+		logger.Error("failed-to-get-archivable-builds", err)
+		return err
+	}
+
+	archived := 0
+	for _, build := range builds {
+		err := build.ArchiveEvents(ctx)
+		if err != nil {
+			logger.Error("failed-to-archive-build-events", err, build.LagerData())
+			continue
+		}
+
+		archived++
+	}
+
+	if archived > 0 {
+		logger.Debug("archived-builds", lager.Data{"count": archived})
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package gc_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/v3/lagerctx"
+	"code.cloudfoundry.org/lager/v3/lagertest"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	. "github.com/concourse/concourse/atc/gc"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BuildLogArchiver", func() {
+	var (
+		archiver          GcCollector
+		fakeBuildFactory  *dbfakes.FakeBuildFactory
+		fakeClock         *fakeclock.FakeClock
+		drainerConfigured bool
+		ctx               context.Context
+		runErr            error
+	)
+
+	BeforeEach(func() {
+		fakeBuildFactory = new(dbfakes.FakeBuildFactory)
+		fakeClock = fakeclock.NewFakeClock(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+		drainerConfigured = false
+		ctx = lagerctx.NewContext(context.Background(), lagertest.NewTestLogger("test"))
+	})
+
+	JustBeforeEach(func() {
+		archiver = NewBuildLogArchiver(fakeBuildFactory, fakeClock, time.Hour, 10, drainerConfigured)
+		runErr = archiver.Run(ctx)
+	})
+
+	It("looks for builds which ended before the archive delay", func() {
+		Expect(fakeBuildFactory.GetArchivableBuildsCallCount()).To(Equal(1))
+		endedBefore, onlyDrained, limit := fakeBuildFactory.GetArchivableBuildsArgsForCall(0)
+		Expect(endedBefore).To(Equal(fakeClock.Now().Add(-time.Hour)))
+		Expect(onlyDrained).To(BeFalse())
+		Expect(limit).To(Equal(10))
+	})
+
+	Context("when a syslog drainer is configured", func() {
+		BeforeEach(func() {
+			drainerConfigured = true
+		})
+
+		It("only archives drained builds", func() {
+			_, onlyDrained, _ := fakeBuildFactory.GetArchivableBuildsArgsForCall(0)
+			Expect(onlyDrained).To(BeTrue())
+		})
+	})
+
+	Context("when there are builds to archive", func() {
+		var failingBuild, build *dbfakes.FakeBuild
+
+		BeforeEach(func() {
+			failingBuild = new(dbfakes.FakeBuild)
+			failingBuild.ArchiveEventsReturns(errors.New("disaster"))
+
+			build = new(dbfakes.FakeBuild)
+
+			fakeBuildFactory.GetArchivableBuildsReturns([]db.Build{failingBuild, build}, nil)
+		})
+
+		It("archives each of them, carrying on past failures", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(failingBuild.ArchiveEventsCallCount()).To(Equal(1))
+			Expect(build.ArchiveEventsCallCount()).To(Equal(1))
+		})
+	})
+
+	It("deletes the archived events of builds which are gone", func() {
+		Expect(fakeBuildFactory.DeleteUnusedArchivedBuildEventsCallCount()).To(Equal(1))
+	})
+
+	Context("when deleting unused archived events fails", func() {
+		BeforeEach(func() {
+			fakeBuildFactory.DeleteUnusedArchivedBuildEventsReturns(0, errors.New("disaster"))
+		})
+
+		It("still archives builds", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(fakeBuildFactory.GetArchivableBuildsCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when getting the builds fails", func() {
+		BeforeEach(func() {
+			fakeBuildFactory.GetArchivableBuildsReturns(nil, errors.New("disaster"))
+		})
+
+		It("errors", func() {
+			Expect(runErr).To(HaveOccurred())
+		})
+	})
+})
@@ -77,7 +77,7 @@ var _ = BeforeEach(func() {
 	builder = dbtest.NewBuilder(dbConn, lockFactory)
 
 	teamFactory = db.NewTeamFactory(dbConn, lockFactory)
-	buildFactory = db.NewBuildFactory(dbConn, lockFactory, 0, time.Hour)
+	buildFactory = db.NewBuildFactory(dbConn, lockFactory, 0, time.Hour, nil)
 
 	defaultTeam, err = teamFactory.CreateTeam(atc.Team{Name: "default-team"})
 	Expect(err).NotTo(HaveOccurred())
@@ -111,7 +111,7 @@ var _ = BeforeEach(func() {
 	}
 
 	defaultPipelineRef = atc.PipelineRef{Name: "default-pipeline"}
-	defaultPipeline, _, err = defaultTeam.SavePipeline(defaultPipelineRef, atcConfig, db.ConfigVersion(0), false)
+	defaultPipeline, _, err = defaultTeam.SavePipeline(defaultPipelineRef, atcConfig, db.ConfigVersion(0), false, "some-user")
 	Expect(err).NotTo(HaveOccurred())
 
 	var found bool
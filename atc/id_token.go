@@ -0,0 +1,65 @@
+package atc
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// MaxIDTokenExpiresIn is the longest lifetime a step's ID token may have.
+const MaxIDTokenExpiresIn = 24 * time.Hour
+
+var envNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// IDTokenConfig configures the OIDC token minted for a task or run step right
+// before its container starts. The token is always written to a file in the
+// container, which is refreshed for as long as the step runs.
+type IDTokenConfig struct {
+	// The aud claim of the token.
+	Audience []string `json:"audience,omitempty"`
+
+	// The environment variable to set the token in, if any. The variable is
+	// not refreshed, so long-running steps should read the file instead.
+	Env string `json:"env,omitempty"`
+
+	// How much of the build's identity goes into the sub claim: team,
+	// pipeline, instance or job. Defaults to pipeline.
+	SubjectScope string `json:"subject_scope,omitempty"`
+
+	// How long the token is valid for, e.g. 15m. Defaults to 1h.
+	ExpiresIn string `json:"expires_in,omitempty"`
+
+	// The signing algorithm, either RS256 or ES256. Defaults to RS256.
+	Algorithm string `json:"algorithm,omitempty"`
+}
+
+func (config IDTokenConfig) Validate() []string {
+	var errorMessages []string
+
+	if config.Env != "" && !envNameRegex.MatchString(config.Env) {
+		errorMessages = append(errorMessages, fmt.Sprintf("invalid env '%s'", config.Env))
+	}
+
+	switch config.SubjectScope {
+	case "", "team", "pipeline", "instance", "job":
+	default:
+		errorMessages = append(errorMessages, fmt.Sprintf("invalid subject_scope '%s': must be one of team, pipeline, instance or job", config.SubjectScope))
+	}
+
+	if config.ExpiresIn != "" {
+		expiresIn, err := time.ParseDuration(config.ExpiresIn)
+		if err != nil {
+			errorMessages = append(errorMessages, fmt.Sprintf("invalid expires_in: %s", err))
+		} else if expiresIn <= 0 || expiresIn > MaxIDTokenExpiresIn {
+			errorMessages = append(errorMessages, fmt.Sprintf("expires_in must be greater than 0 and at most %s", MaxIDTokenExpiresIn))
+		}
+	}
+
+	switch config.Algorithm {
+	case "", "RS256", "ES256":
+	default:
+		errorMessages = append(errorMessages, fmt.Sprintf("invalid algorithm '%s': must be RS256 or ES256", config.Algorithm))
+	}
+
+	return errorMessages
+}
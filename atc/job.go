@@ -1,5 +1,10 @@
 package atc
 
+import (
+	"fmt"
+	"strings"
+)
+
 type Job struct {
 	ID   int    `json:"id"`
 	Name string `json:"name"`
@@ -17,6 +22,10 @@ type Job struct {
 
 	Groups []string `json:"groups,omitempty"`
 
+	// NextScheduledTrigger is when the job's schedule will next trigger a
+	// build, as a unix timestamp.
+	NextScheduledTrigger int64 `json:"next_scheduled_trigger,omitempty"`
+
 	FirstLoggedBuildID   int  `json:"first_logged_build_id,omitempty"`
 	DisableManualTrigger bool `json:"disable_manual_trigger,omitempty"`
 	DisableReruns        bool `json:"disable_reruns,omitempty"`
@@ -37,6 +46,49 @@ type JobInput struct {
 	Version  *VersionConfig `json:"version,omitempty"`
 }
 
+// PassedJob is a job named by a passed constraint. Jobs in other pipelines of
+// the same team are named as <pipeline>/<job>, or as
+// <pipeline>/<instance vars>/<job> for instanced pipelines.
+type PassedJob struct {
+	// Pipeline is set when the job is in another pipeline.
+	Pipeline *PipelineRef
+
+	// Job is the name of the job, or a glob matching the names of jobs.
+	Job string
+}
+
+func ParsePassedJob(passed string) (PassedJob, error) {
+	jobIdx := strings.LastIndex(passed, "/")
+	if jobIdx == -1 {
+		return PassedJob{Job: passed}, nil
+	}
+
+	job := passed[jobIdx+1:]
+	pipelineName, rawInstanceVars, hasInstanceVars := strings.Cut(passed[:jobIdx], "/")
+	if pipelineName == "" || job == "" {
+		return PassedJob{}, fmt.Errorf("invalid passed job '%s': must be formatted as <job> or <pipeline>/<job>", passed)
+	}
+
+	ref := PipelineRef{Name: pipelineName}
+	if hasInstanceVars {
+		var err error
+		ref.InstanceVars, err = ParseInstanceVars(rawInstanceVars)
+		if err != nil {
+			return PassedJob{}, fmt.Errorf("invalid passed job '%s': %w", passed, err)
+		}
+	}
+
+	return PassedJob{Pipeline: &ref, Job: job}, nil
+}
+
+func (passed PassedJob) String() string {
+	if passed.Pipeline == nil {
+		return passed.Job
+	}
+
+	return passed.Pipeline.String() + "/" + passed.Job
+}
+
 type JobInputParams struct {
 	JobInput
 	Params Params `json:"params,omitempty"`
@@ -1,5 +1,13 @@
 package atc
 
+import (
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/concourse/concourse/atc/cron"
+)
+
 type JobConfig struct {
 	Name    string `json:"name"`
 	OldName string `json:"old_name,omitempty"`
@@ -13,11 +21,22 @@ type JobConfig struct {
 	RawMaxInFlight       int      `json:"max_in_flight,omitempty"`
 	Tags                 Tags     `json:"tags,omitempty"`
 
+	// Priority orders the steps of the job's builds waiting for a worker,
+	// and the scheduling of the job, relative to the other jobs of the same
+	// team. Higher priorities go first.
+	Priority int `json:"priority,omitempty"`
+
 	// Deprecated: users should use BuildLogRetention
 	BuildLogsToRetain int `json:"build_logs_to_retain,omitempty"`
 
 	BuildLogRetention *BuildLogRetention `json:"build_log_retention,omitempty"`
 
+	ConcurrencyGroup *ConcurrencyGroupConfig `json:"concurrency_group,omitempty"`
+
+	Schedule *JobScheduleConfig `json:"schedule,omitempty"`
+
+	Matrix []MatrixVarConfig `json:"matrix,omitempty"`
+
 	OnSuccess *Step `json:"on_success,omitempty"`
 	OnFailure *Step `json:"on_failure,omitempty"`
 	OnAbort   *Step `json:"on_abort,omitempty"`
@@ -33,6 +52,145 @@ type BuildLogRetention struct {
 	Days                   int `json:"days,omitempty"`
 }
 
+const (
+	ConcurrencyGroupScopeTeam     = "team"
+	ConcurrencyGroupScopePipeline = "pipeline"
+)
+
+// ConcurrencyGroupConfig serializes the builds of every job which belongs to
+// the same named group. Unlike serial groups, a group with team scope spans
+// all of the team's pipelines, including instanced pipelines.
+type ConcurrencyGroupConfig struct {
+	Name  string `json:"name"`
+	Scope string `json:"scope,omitempty"`
+}
+
+func (config ConcurrencyGroupConfig) ScopeName() string {
+	if config.Scope == "" {
+		return ConcurrencyGroupScopeTeam
+	}
+
+	return config.Scope
+}
+
+// JobScheduleConfig triggers builds of a job at the times matched by a cron
+// expression, without needing a time resource.
+type JobScheduleConfig struct {
+	Cron string `json:"cron"`
+
+	// Location is the time zone the cron expression is evaluated in. It
+	// defaults to UTC.
+	Location string `json:"location,omitempty"`
+
+	// Jitter is the upper bound of a random delay added to each trigger, so
+	// that jobs scheduled for the same time don't all start at once.
+	Jitter string `json:"jitter,omitempty"`
+}
+
+// Parse returns the cron schedule, time zone and jitter of the config.
+func (config JobScheduleConfig) Parse() (cron.Schedule, *time.Location, time.Duration, error) {
+	schedule, err := cron.Parse(config.Cron)
+	if err != nil {
+		return cron.Schedule{}, nil, 0, err
+	}
+
+	location, err := time.LoadLocation(config.Location)
+	if err != nil {
+		return cron.Schedule{}, nil, 0, fmt.Errorf("invalid location %q: %w", config.Location, err)
+	}
+
+	var jitter time.Duration
+	if config.Jitter != "" {
+		jitter, err = time.ParseDuration(config.Jitter)
+		if err != nil {
+			return cron.Schedule{}, nil, 0, fmt.Errorf("invalid jitter %q: %w", config.Jitter, err)
+		}
+
+		if jitter < 0 {
+			return cron.Schedule{}, nil, 0, fmt.Errorf("invalid jitter %q: must not be negative", config.Jitter)
+		}
+	}
+
+	return schedule, location, jitter, nil
+}
+
+// NextTrigger returns the time after the given time at which the job should
+// next be triggered, including a random jitter.
+func (config JobScheduleConfig) NextTrigger(after time.Time) (time.Time, error) {
+	schedule, location, jitter, err := config.Parse()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	next := schedule.Next(after.In(location))
+	if next.IsZero() {
+		return time.Time{}, fmt.Errorf("cron expression %q never matches", config.Cron)
+	}
+
+	if jitter > 0 {
+		next = next.Add(rand.N(jitter))
+	}
+
+	return next, nil
+}
+
+// MatrixVarConfig is one dimension of a job's build matrix. Each build of
+// the job runs a child build for every combination of the values of each
+// dimension, with the combination's values bound as local vars.
+type MatrixVarConfig struct {
+	Var    string `json:"var"`
+	Values []any  `json:"values"`
+}
+
+// MatrixValues maps the vars of a job's build matrix to the values of a
+// single combination.
+type MatrixValues map[string]any
+
+// MatrixCombinations returns every combination of the job's matrix values,
+// varying the last dimension fastest.
+func (config JobConfig) MatrixCombinations() []MatrixValues {
+	if len(config.Matrix) == 0 {
+		return nil
+	}
+
+	combinations := []MatrixValues{{}}
+	for _, dimension := range config.Matrix {
+		var next []MatrixValues
+		for _, combination := range combinations {
+			for _, value := range dimension.Values {
+				values := MatrixValues{}
+				for k, v := range combination {
+					values[k] = v
+				}
+
+				values[dimension.Var] = value
+				next = append(next, values)
+			}
+		}
+
+		combinations = next
+	}
+
+	return combinations
+}
+
+// MatrixStepConfig returns the step config of a single cell of the job's
+// build matrix, binding the given values to the matrix vars.
+func (config JobConfig) MatrixStepConfig(values MatrixValues) StepConfig {
+	vars := make([]AcrossVarConfig, len(config.Matrix))
+	for i, dimension := range config.Matrix {
+		vars[i] = AcrossVarConfig{
+			Var:    dimension.Var,
+			Values: []any{values[dimension.Var]},
+		}
+	}
+
+	return &AcrossStep{
+		Step: config.StepConfig(),
+		Vars: vars,
+	}
+}
+
 func (config JobConfig) Step() Step {
 	return Step{Config: config.StepConfig()}
 }
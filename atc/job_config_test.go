@@ -1,6 +1,8 @@
 package atc_test
 
 import (
+	"time"
+
 	"github.com/concourse/concourse/atc"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -671,4 +673,89 @@ var _ = Describe("JobConfig", func() {
 			})
 		})
 	})
+
+	Describe("MatrixCombinations", func() {
+		It("returns nothing without a matrix", func() {
+			Expect(atc.JobConfig{}.MatrixCombinations()).To(BeEmpty())
+		})
+
+		It("returns every combination of the matrix values", func() {
+			jobConfig := atc.JobConfig{
+				Matrix: []atc.MatrixVarConfig{
+					{Var: "go", Values: []any{"1.21", "1.22"}},
+					{Var: "os", Values: []any{"linux", "windows", "darwin"}},
+				},
+			}
+
+			Expect(jobConfig.MatrixCombinations()).To(Equal([]atc.MatrixValues{
+				{"go": "1.21", "os": "linux"},
+				{"go": "1.21", "os": "windows"},
+				{"go": "1.21", "os": "darwin"},
+				{"go": "1.22", "os": "linux"},
+				{"go": "1.22", "os": "windows"},
+				{"go": "1.22", "os": "darwin"},
+			}))
+		})
+	})
+
+	Describe("MatrixStepConfig", func() {
+		It("binds the values of the cell with an across step", func() {
+			jobConfig := atc.JobConfig{
+				Matrix: []atc.MatrixVarConfig{
+					{Var: "go", Values: []any{"1.21", "1.22"}},
+					{Var: "os", Values: []any{"linux", "windows"}},
+				},
+				PlanSequence: []atc.Step{
+					{Config: &atc.GetStep{Name: "some-get"}},
+				},
+			}
+
+			Expect(jobConfig.MatrixStepConfig(atc.MatrixValues{"go": "1.22", "os": "linux"})).To(Equal(&atc.AcrossStep{
+				Step: jobConfig.StepConfig(),
+				Vars: []atc.AcrossVarConfig{
+					{Var: "go", Values: []any{"1.22"}},
+					{Var: "os", Values: []any{"linux"}},
+				},
+			}))
+		})
+	})
+
+	Describe("JobScheduleConfig", func() {
+		var after time.Time
+
+		BeforeEach(func() {
+			after = time.Date(2024, 3, 5, 10, 15, 0, 0, time.UTC)
+		})
+
+		It("returns the next time matching the cron expression", func() {
+			next, err := atc.JobScheduleConfig{Cron: "0 2 * * *"}.NextTrigger(after)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(next).To(BeTemporally("==", time.Date(2024, 3, 6, 2, 0, 0, 0, time.UTC)))
+		})
+
+		It("evaluates the cron expression in the location", func() {
+			next, err := atc.JobScheduleConfig{Cron: "0 2 * * *", Location: "Asia/Tokyo"}.NextTrigger(after)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(next).To(BeTemporally("==", time.Date(2024, 3, 5, 17, 0, 0, 0, time.UTC)))
+		})
+
+		It("adds a jitter below the configured bound", func() {
+			next, err := atc.JobScheduleConfig{Cron: "0 2 * * *", Jitter: "10m"}.NextTrigger(after)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(next).To(BeTemporally(">=", time.Date(2024, 3, 6, 2, 0, 0, 0, time.UTC)))
+			Expect(next).To(BeTemporally("<", time.Date(2024, 3, 6, 2, 10, 0, 0, time.UTC)))
+		})
+
+		DescribeTable("rejects invalid configs",
+			func(config atc.JobScheduleConfig, message string) {
+				_, err := config.NextTrigger(after)
+				Expect(err).To(MatchError(ContainSubstring(message)))
+			},
+			Entry("bad cron expression", atc.JobScheduleConfig{Cron: "0 2 * *"}, "invalid cron expression"),
+			Entry("unknown location", atc.JobScheduleConfig{Cron: "@daily", Location: "Mars/Olympus_Mons"}, `invalid location "Mars/Olympus_Mons"`),
+			Entry("bad jitter", atc.JobScheduleConfig{Cron: "@daily", Jitter: "soon"}, `invalid jitter "soon"`),
+			Entry("negative jitter", atc.JobScheduleConfig{Cron: "@daily", Jitter: "-1m"}, "must not be negative"),
+			Entry("never matches", atc.JobScheduleConfig{Cron: "0 0 30 2 *"}, "never matches"),
+		)
+	})
 })
@@ -0,0 +1,43 @@
+package atc_test
+
+import (
+	"encoding/json"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PassedJob", func() {
+	DescribeTable("ParsePassedJob",
+		func(passed string, expected atc.PassedJob) {
+			parsed, err := atc.ParsePassedJob(passed)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(parsed).To(Equal(expected))
+			Expect(parsed.String()).To(Equal(passed))
+		},
+		Entry("a job in the same pipeline", "unit", atc.PassedJob{Job: "unit"}),
+		Entry("a glob in the same pipeline", "unit-*", atc.PassedJob{Job: "unit-*"}),
+		Entry("a job in another pipeline", "build/unit", atc.PassedJob{
+			Pipeline: &atc.PipelineRef{Name: "build"},
+			Job:      "unit",
+		}),
+		Entry("a job in an instanced pipeline", "build/branch:main,version:1/unit", atc.PassedJob{
+			Pipeline: &atc.PipelineRef{Name: "build", InstanceVars: atc.InstanceVars{
+				"branch":  "main",
+				"version": json.Number("1"),
+			}},
+			Job: "unit",
+		}),
+	)
+
+	DescribeTable("invalid passed jobs",
+		func(passed string, message string) {
+			_, err := atc.ParsePassedJob(passed)
+			Expect(err).To(MatchError(ContainSubstring(message)))
+		},
+		Entry("without a pipeline", "/unit", "must be formatted as <job> or <pipeline>/<job>"),
+		Entry("without a job", "build/", "must be formatted as <job> or <pipeline>/<job>"),
+		Entry("with malformed instance vars", "build/main/unit", "instance vars should be formatted as"),
+	)
+})
@@ -0,0 +1,77 @@
+package logarchive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"code.cloudfoundry.org/lager/v3"
+	"github.com/jessevdk/go-flags"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+
+var ErrNotFound = errors.New("archived build log not found")
+
+//counterfeiter:generate . Archive
+
+// Archive stores the events of finished builds outside of the database.
+// Objects are written once under a key and are never modified afterwards.
+type Archive interface {
+	Store(ctx context.Context, key string, data io.Reader) error
+
+	// Retrieve returns ErrNotFound if nothing is stored under the key.
+	Retrieve(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Delete does not return an error if nothing is stored under the key.
+	Delete(ctx context.Context, key string) error
+}
+
+//counterfeiter:generate . ArchiveFactory
+type ArchiveFactory interface {
+	Description() string
+	IsConfigured() bool
+	NewArchive(lager.Logger) (Archive, error)
+}
+
+var archiveFactories []ArchiveFactory
+
+func RegisterArchive(factory ArchiveFactory) {
+	archiveFactories = append(archiveFactories, factory)
+}
+
+func WireArchives(group *flags.Group) {
+	for _, factory := range archiveFactories {
+		_, err := group.AddGroup(fmt.Sprintf("Build Log Archive (%s)", factory.Description()), "", factory)
+		if err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Initialize returns the configured archive, or nil if build logs are not
+// archived.
+func Initialize(logger lager.Logger) (Archive, error) {
+	var configured []ArchiveFactory
+	for _, factory := range archiveFactories {
+		if factory.IsConfigured() {
+			configured = append(configured, factory)
+		}
+	}
+
+	switch len(configured) {
+	case 0:
+		return nil, nil
+	case 1:
+		return configured[0].NewArchive(logger.Session("build-log-archive"))
+	default:
+		var descriptions []string
+		for _, factory := range configured {
+			descriptions = append(descriptions, factory.Description())
+		}
+
+		return nil, fmt.Errorf("multiple build log archives configured: %s", strings.Join(descriptions, ", "))
+	}
+}
@@ -0,0 +1,57 @@
+package logarchive_test
+
+import (
+	"github.com/concourse/concourse/atc/logarchive"
+	"github.com/concourse/concourse/atc/logarchive/logarchivefakes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Initialize", func() {
+	var (
+		archive logarchive.Archive
+		err     error
+	)
+
+	BeforeEach(func() {
+		fakeFactory.IsConfiguredReturns(false)
+		otherFakeFactory.IsConfiguredReturns(false)
+	})
+
+	JustBeforeEach(func() {
+		archive, err = logarchive.Initialize(testLogger)
+	})
+
+	Context("when no archive is configured", func() {
+		It("returns no archive", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(archive).To(BeNil())
+		})
+	})
+
+	Context("when an archive is configured", func() {
+		var fakeArchive *logarchivefakes.FakeArchive
+
+		BeforeEach(func() {
+			fakeArchive = new(logarchivefakes.FakeArchive)
+			fakeFactory.IsConfiguredReturns(true)
+			fakeFactory.NewArchiveReturns(fakeArchive, nil)
+		})
+
+		It("returns it", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(archive).To(Equal(fakeArchive))
+		})
+	})
+
+	Context("when more than one archive is configured", func() {
+		BeforeEach(func() {
+			fakeFactory.IsConfiguredReturns(true)
+			otherFakeFactory.IsConfiguredReturns(true)
+		})
+
+		It("errors", func() {
+			Expect(err).To(MatchError("multiple build log archives configured: fake, other fake"))
+		})
+	})
+})
@@ -0,0 +1,114 @@
+package filesystem
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"code.cloudfoundry.org/lager/v3"
+
+	"github.com/concourse/concourse/atc/logarchive"
+)
+
+type DirectoryConfig struct {
+	Directory string `long:"build-log-archive-directory" description:"Directory in which to archive build logs, e.g. a mounted network volume."`
+}
+
+func init() {
+	logarchive.RegisterArchive(&DirectoryConfig{})
+}
+
+func (c *DirectoryConfig) Description() string { return "Directory" }
+func (c *DirectoryConfig) IsConfigured() bool  { return c.Directory != "" }
+
+func (c *DirectoryConfig) NewArchive(logger lager.Logger) (logarchive.Archive, error) {
+	err := os.MkdirAll(c.Directory, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("create archive directory: %w", err)
+	}
+
+	return &Archive{Directory: c.Directory}, nil
+}
+
+// Archive stores each object as a file under Directory.
+type Archive struct {
+	Directory string
+}
+
+func (a *Archive) Store(ctx context.Context, key string, data io.Reader) error {
+	path, err := a.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+
+	// write to a temporary file first so that a partially written object is
+	// never visible under the key
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".archive-*")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	_, err = io.Copy(tmp, data)
+	if err != nil {
+		_ = tmp.Close()
+		return err
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func (a *Archive) Retrieve(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := a.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, logarchive.ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return file, nil
+}
+
+func (a *Archive) Delete(ctx context.Context, key string) error {
+	path, err := a.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+func (a *Archive) path(key string) (string, error) {
+	if !filepath.IsLocal(key) {
+		return "", fmt.Errorf("invalid archive key: %s", key)
+	}
+
+	return filepath.Join(a.Directory, key), nil
+}
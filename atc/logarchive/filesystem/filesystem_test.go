@@ -0,0 +1,86 @@
+package filesystem_test
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"code.cloudfoundry.org/lager/v3/lagertest"
+	"github.com/concourse/concourse/atc/logarchive"
+	"github.com/concourse/concourse/atc/logarchive/filesystem"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Archive", func() {
+	var (
+		ctx     context.Context
+		dir     string
+		archive logarchive.Archive
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		dir = filepath.Join(GinkgoT().TempDir(), "archive")
+
+		var err error
+		archive, err = (&filesystem.DirectoryConfig{Directory: dir}).NewArchive(lagertest.NewTestLogger("test"))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("creates the directory", func() {
+		Expect(dir).To(BeADirectory())
+	})
+
+	It("retrieves what was stored", func() {
+		err := archive.Store(ctx, "builds/42", strings.NewReader("some-events"))
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(filepath.Join(dir, "builds", "42")).To(BeARegularFile())
+
+		reader, err := archive.Retrieve(ctx, "builds/42")
+		Expect(err).ToNot(HaveOccurred())
+		defer reader.Close()
+
+		data, err := io.ReadAll(reader)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(data)).To(Equal("some-events"))
+	})
+
+	It("does not leave temporary files behind", func() {
+		err := archive.Store(ctx, "builds/42", strings.NewReader("some-events"))
+		Expect(err).ToNot(HaveOccurred())
+
+		entries, err := os.ReadDir(filepath.Join(dir, "builds"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(entries).To(HaveLen(1))
+	})
+
+	It("returns ErrNotFound for missing keys", func() {
+		_, err := archive.Retrieve(ctx, "builds/42")
+		Expect(err).To(Equal(logarchive.ErrNotFound))
+	})
+
+	It("deletes what was stored", func() {
+		err := archive.Store(ctx, "builds/42", strings.NewReader("some-events"))
+		Expect(err).ToNot(HaveOccurred())
+
+		err = archive.Delete(ctx, "builds/42")
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = archive.Retrieve(ctx, "builds/42")
+		Expect(err).To(Equal(logarchive.ErrNotFound))
+
+		By("ignoring keys which are already gone")
+		err = archive.Delete(ctx, "builds/42")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("rejects keys outside of the directory", func() {
+		err := archive.Store(ctx, "../escaped", strings.NewReader("some-events"))
+		Expect(err).To(HaveOccurred())
+		Expect(filepath.Join(dir, "..", "escaped")).ToNot(BeAnExistingFile())
+	})
+})
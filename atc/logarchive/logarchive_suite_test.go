@@ -0,0 +1,33 @@
+package logarchive_test
+
+import (
+	"testing"
+
+	"code.cloudfoundry.org/lager/v3/lagertest"
+	"github.com/concourse/concourse/atc/logarchive"
+	"github.com/concourse/concourse/atc/logarchive/logarchivefakes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestLogArchive(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Log Archive Suite")
+}
+
+var (
+	testLogger = lagertest.NewTestLogger("test")
+
+	fakeFactory      *logarchivefakes.FakeArchiveFactory
+	otherFakeFactory *logarchivefakes.FakeArchiveFactory
+)
+
+var _ = BeforeSuite(func() {
+	fakeFactory = new(logarchivefakes.FakeArchiveFactory)
+	fakeFactory.DescriptionReturns("fake")
+	logarchive.RegisterArchive(fakeFactory)
+
+	otherFakeFactory = new(logarchivefakes.FakeArchiveFactory)
+	otherFakeFactory.DescriptionReturns("other fake")
+	logarchive.RegisterArchive(otherFakeFactory)
+})
@@ -0,0 +1,241 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package logarchivefakes
+
+import (
+	"sync"
+
+	lager "code.cloudfoundry.org/lager/v3"
+	"github.com/concourse/concourse/atc/logarchive"
+)
+
+type FakeArchiveFactory struct {
+	DescriptionStub        func() string
+	descriptionMutex       sync.RWMutex
+	descriptionArgsForCall []struct {
+	}
+	descriptionReturns struct {
+		result1 string
+	}
+	descriptionReturnsOnCall map[int]struct {
+		result1 string
+	}
+	IsConfiguredStub        func() bool
+	isConfiguredMutex       sync.RWMutex
+	isConfiguredArgsForCall []struct {
+	}
+	isConfiguredReturns struct {
+		result1 bool
+	}
+	isConfiguredReturnsOnCall map[int]struct {
+		result1 bool
+	}
+	NewArchiveStub        func(lager.Logger) (logarchive.Archive, error)
+	newArchiveMutex       sync.RWMutex
+	newArchiveArgsForCall []struct {
+		arg1 lager.Logger
+	}
+	newArchiveReturns struct {
+		result1 logarchive.Archive
+		result2 error
+	}
+	newArchiveReturnsOnCall map[int]struct {
+		result1 logarchive.Archive
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeArchiveFactory) Description() string {
+	fake.descriptionMutex.Lock()
+	ret, specificReturn := fake.descriptionReturnsOnCall[len(fake.descriptionArgsForCall)]
+	fake.descriptionArgsForCall = append(fake.descriptionArgsForCall, struct {
+	}{})
+	stub := fake.DescriptionStub
+	fakeReturns := fake.descriptionReturns
+	fake.recordInvocation("Description", []interface{}{})
+	fake.descriptionMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeArchiveFactory) DescriptionCallCount() int {
+	fake.descriptionMutex.RLock()
+	defer fake.descriptionMutex.RUnlock()
+	return len(fake.descriptionArgsForCall)
+}
+
+func (fake *FakeArchiveFactory) DescriptionCalls(stub func() string) {
+	fake.descriptionMutex.Lock()
+	defer fake.descriptionMutex.Unlock()
+	fake.DescriptionStub = stub
+}
+
+func (fake *FakeArchiveFactory) DescriptionReturns(result1 string) {
+	fake.descriptionMutex.Lock()
+	defer fake.descriptionMutex.Unlock()
+	fake.DescriptionStub = nil
+	fake.descriptionReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeArchiveFactory) DescriptionReturnsOnCall(i int, result1 string) {
+	fake.descriptionMutex.Lock()
+	defer fake.descriptionMutex.Unlock()
+	fake.DescriptionStub = nil
+	if fake.descriptionReturnsOnCall == nil {
+		fake.descriptionReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.descriptionReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeArchiveFactory) IsConfigured() bool {
+	fake.isConfiguredMutex.Lock()
+	ret, specificReturn := fake.isConfiguredReturnsOnCall[len(fake.isConfiguredArgsForCall)]
+	fake.isConfiguredArgsForCall = append(fake.isConfiguredArgsForCall, struct {
+	}{})
+	stub := fake.IsConfiguredStub
+	fakeReturns := fake.isConfiguredReturns
+	fake.recordInvocation("IsConfigured", []interface{}{})
+	fake.isConfiguredMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeArchiveFactory) IsConfiguredCallCount() int {
+	fake.isConfiguredMutex.RLock()
+	defer fake.isConfiguredMutex.RUnlock()
+	return len(fake.isConfiguredArgsForCall)
+}
+
+func (fake *FakeArchiveFactory) IsConfiguredCalls(stub func() bool) {
+	fake.isConfiguredMutex.Lock()
+	defer fake.isConfiguredMutex.Unlock()
+	fake.IsConfiguredStub = stub
+}
+
+func (fake *FakeArchiveFactory) IsConfiguredReturns(result1 bool) {
+	fake.isConfiguredMutex.Lock()
+	defer fake.isConfiguredMutex.Unlock()
+	fake.IsConfiguredStub = nil
+	fake.isConfiguredReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeArchiveFactory) IsConfiguredReturnsOnCall(i int, result1 bool) {
+	fake.isConfiguredMutex.Lock()
+	defer fake.isConfiguredMutex.Unlock()
+	fake.IsConfiguredStub = nil
+	if fake.isConfiguredReturnsOnCall == nil {
+		fake.isConfiguredReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.isConfiguredReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeArchiveFactory) NewArchive(arg1 lager.Logger) (logarchive.Archive, error) {
+	fake.newArchiveMutex.Lock()
+	ret, specificReturn := fake.newArchiveReturnsOnCall[len(fake.newArchiveArgsForCall)]
+	fake.newArchiveArgsForCall = append(fake.newArchiveArgsForCall, struct {
+		arg1 lager.Logger
+	}{arg1})
+	stub := fake.NewArchiveStub
+	fakeReturns := fake.newArchiveReturns
+	fake.recordInvocation("NewArchive", []interface{}{arg1})
+	fake.newArchiveMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeArchiveFactory) NewArchiveCallCount() int {
+	fake.newArchiveMutex.RLock()
+	defer fake.newArchiveMutex.RUnlock()
+	return len(fake.newArchiveArgsForCall)
+}
+
+func (fake *FakeArchiveFactory) NewArchiveCalls(stub func(lager.Logger) (logarchive.Archive, error)) {
+	fake.newArchiveMutex.Lock()
+	defer fake.newArchiveMutex.Unlock()
+	fake.NewArchiveStub = stub
+}
+
+func (fake *FakeArchiveFactory) NewArchiveArgsForCall(i int) lager.Logger {
+	fake.newArchiveMutex.RLock()
+	defer fake.newArchiveMutex.RUnlock()
+	argsForCall := fake.newArchiveArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeArchiveFactory) NewArchiveReturns(result1 logarchive.Archive, result2 error) {
+	fake.newArchiveMutex.Lock()
+	defer fake.newArchiveMutex.Unlock()
+	fake.NewArchiveStub = nil
+	fake.newArchiveReturns = struct {
+		result1 logarchive.Archive
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeArchiveFactory) NewArchiveReturnsOnCall(i int, result1 logarchive.Archive, result2 error) {
+	fake.newArchiveMutex.Lock()
+	defer fake.newArchiveMutex.Unlock()
+	fake.NewArchiveStub = nil
+	if fake.newArchiveReturnsOnCall == nil {
+		fake.newArchiveReturnsOnCall = make(map[int]struct {
+			result1 logarchive.Archive
+			result2 error
+		})
+	}
+	fake.newArchiveReturnsOnCall[i] = struct {
+		result1 logarchive.Archive
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeArchiveFactory) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeArchiveFactory) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ logarchive.ArchiveFactory = new(FakeArchiveFactory)
@@ -0,0 +1,209 @@
+package s3
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager/v3"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+
+	"github.com/concourse/concourse/atc/logarchive"
+)
+
+type S3Config struct {
+	Endpoint        string        `long:"build-log-archive-s3-endpoint" description:"URL of an S3-compatible API, such as a MinIO server. Defaults to AWS S3 in the configured region."`
+	Bucket          string        `long:"build-log-archive-s3-bucket" description:"Bucket in which to archive build logs."`
+	Prefix          string        `long:"build-log-archive-s3-prefix" description:"Prefix for the keys of archived build logs."`
+	Region          string        `long:"build-log-archive-s3-region" default:"us-east-1" description:"Region of the bucket."`
+	AccessKeyID     string        `long:"build-log-archive-s3-access-key-id" description:"Access key ID. Credentials are loaded from the environment if not specified."`
+	SecretAccessKey string        `long:"build-log-archive-s3-secret-access-key" description:"Secret access key."`
+	SessionToken    string        `long:"build-log-archive-s3-session-token" description:"Session token."`
+	Timeout         time.Duration `long:"build-log-archive-s3-timeout" default:"5m" description:"Timeout for a single request to the S3 API."`
+}
+
+func init() {
+	logarchive.RegisterArchive(&S3Config{})
+}
+
+func (c *S3Config) Description() string { return "S3" }
+func (c *S3Config) IsConfigured() bool  { return c.Bucket != "" }
+
+func (c *S3Config) NewArchive(logger lager.Logger) (logarchive.Archive, error) {
+	endpoint := c.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", c.Region)
+	}
+
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("parse endpoint: %w", err)
+	}
+
+	opts := []func(*config.LoadOptions) error{
+		config.WithRegion(c.Region),
+	}
+
+	if c.AccessKeyID != "" {
+		opts = append(opts, config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(
+			c.AccessKeyID, c.SecretAccessKey, c.SessionToken,
+		)))
+	}
+
+	cfg, err := config.LoadDefaultConfig(context.TODO(), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("load aws config: %w", err)
+	}
+
+	return &Archive{
+		Endpoint:    endpointURL,
+		Bucket:      c.Bucket,
+		Prefix:      c.Prefix,
+		Region:      c.Region,
+		Credentials: cfg.Credentials,
+		Client:      &http.Client{Timeout: c.Timeout},
+		logger:      logger,
+	}, nil
+}
+
+// Archive stores each object in a bucket through the S3 REST API, addressing
+// the bucket in the path so that S3-compatible stores such as MinIO work
+// without any DNS setup.
+type Archive struct {
+	Endpoint    *url.URL
+	Bucket      string
+	Prefix      string
+	Region      string
+	Credentials aws.CredentialsProvider
+	Client      *http.Client
+
+	logger lager.Logger
+}
+
+// Store spools the object to a temporary file while computing its checksum,
+// which is needed up front to sign the request, so that the object is never
+// held in memory.
+func (a *Archive) Store(ctx context.Context, key string, data io.Reader) error {
+	spool, err := os.CreateTemp("", "build-log-archive-*")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(spool.Name())
+	defer spool.Close()
+
+	checksum := sha256.New()
+	size, err := io.Copy(io.MultiWriter(spool, checksum), data)
+	if err != nil {
+		return err
+	}
+
+	_, err = spool.Seek(0, io.SeekStart)
+	if err != nil {
+		return err
+	}
+
+	response, err := a.do(ctx, http.MethodPut, key, spool, size, hex.EncodeToString(checksum.Sum(nil)))
+	if err != nil {
+		return err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return unexpectedResponse(response)
+	}
+
+	return nil
+}
+
+func (a *Archive) Retrieve(ctx context.Context, key string) (io.ReadCloser, error) {
+	response, err := a.do(ctx, http.MethodGet, key, nil, 0, emptyPayloadHash)
+	if err != nil {
+		return nil, err
+	}
+
+	switch response.StatusCode {
+	case http.StatusOK:
+		return response.Body, nil
+	case http.StatusNotFound:
+		response.Body.Close()
+		return nil, logarchive.ErrNotFound
+	default:
+		defer response.Body.Close()
+		return nil, unexpectedResponse(response)
+	}
+}
+
+func (a *Archive) Delete(ctx context.Context, key string) error {
+	response, err := a.do(ctx, http.MethodDelete, key, nil, 0, emptyPayloadHash)
+	if err != nil {
+		return err
+	}
+
+	defer response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	default:
+		return unexpectedResponse(response)
+	}
+}
+
+// the checksum of an empty payload, for requests without a body
+var emptyPayloadHash = func() string {
+	checksum := sha256.Sum256(nil)
+	return hex.EncodeToString(checksum[:])
+}()
+
+func (a *Archive) do(ctx context.Context, method string, key string, body io.Reader, size int64, payloadHash string) (*http.Response, error) {
+	objectURL := *a.Endpoint
+	objectURL.Path = path.Join("/", a.Endpoint.Path, a.Bucket, a.Prefix, key)
+
+	request, err := http.NewRequestWithContext(ctx, method, objectURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	if body == nil {
+		request.Body = http.NoBody
+	} else {
+		request.ContentLength = size
+	}
+
+	request.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	creds, err := a.Credentials.Retrieve(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("retrieve credentials: %w", err)
+	}
+
+	err = v4.NewSigner().SignHTTP(ctx, creds, request, payloadHash, "s3", a.Region, time.Now(), func(opts *v4.SignerOptions) {
+		// S3 signs the path as it is sent rather than escaping it again
+		opts.DisableURIPathEscaping = true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("sign request: %w", err)
+	}
+
+	a.logger.Debug("request", lager.Data{"method": method, "key": key})
+
+	return a.Client.Do(request)
+}
+
+func unexpectedResponse(response *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+	return fmt.Errorf("unexpected response from s3: %s: %s", response.Status, strings.TrimSpace(string(body)))
+}
@@ -0,0 +1,136 @@
+package s3_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strings"
+
+	"code.cloudfoundry.org/lager/v3/lagertest"
+	"github.com/concourse/concourse/atc/logarchive"
+	"github.com/concourse/concourse/atc/logarchive/s3"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Archive", func() {
+	var (
+		ctx     context.Context
+		server  *ghttp.Server
+		archive logarchive.Archive
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		server = ghttp.NewServer()
+
+		var err error
+		archive, err = (&s3.S3Config{
+			Endpoint:        server.URL(),
+			Bucket:          "some-bucket",
+			Prefix:          "concourse",
+			Region:          "some-region",
+			AccessKeyID:     "some-key",
+			SecretAccessKey: "some-secret",
+		}).NewArchive(lagertest.NewTestLogger("test"))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	verifySigned := func(payload string) http.HandlerFunc {
+		checksum := sha256.Sum256([]byte(payload))
+
+		return ghttp.CombineHandlers(
+			ghttp.VerifyHeaderKV("X-Amz-Content-Sha256", hex.EncodeToString(checksum[:])),
+			func(w http.ResponseWriter, r *http.Request) {
+				Expect(r.Header.Get("Authorization")).To(HavePrefix("AWS4-HMAC-SHA256 Credential=some-key/"))
+				Expect(r.Header.Get("Authorization")).To(ContainSubstring("/some-region/s3/aws4_request"))
+			},
+		)
+	}
+
+	Describe("Store", func() {
+		Context("when the bucket accepts the object", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/some-bucket/concourse/builds/42"),
+					verifySigned("some-events"),
+					ghttp.VerifyBody([]byte("some-events")),
+					ghttp.RespondWith(http.StatusOK, ""),
+				))
+			})
+
+			It("puts the object in the bucket", func() {
+				err := archive.Store(ctx, "builds/42", strings.NewReader("some-events"))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(server.ReceivedRequests()).To(HaveLen(1))
+			})
+		})
+
+		Context("when the request fails", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(ghttp.RespondWith(http.StatusForbidden, "<Error>AccessDenied</Error>"))
+			})
+
+			It("returns the response", func() {
+				err := archive.Store(ctx, "builds/42", strings.NewReader("some-events"))
+				Expect(err).To(MatchError(ContainSubstring("403 Forbidden: <Error>AccessDenied</Error>")))
+			})
+		})
+	})
+
+	Describe("Retrieve", func() {
+		Context("when the object exists", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/some-bucket/concourse/builds/42"),
+					verifySigned(""),
+					ghttp.RespondWith(http.StatusOK, "some-events"),
+				))
+			})
+
+			It("returns its contents", func() {
+				reader, err := archive.Retrieve(ctx, "builds/42")
+				Expect(err).ToNot(HaveOccurred())
+				defer reader.Close()
+
+				data, err := io.ReadAll(reader)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(data)).To(Equal("some-events"))
+			})
+		})
+
+		Context("when the object does not exist", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(ghttp.RespondWith(http.StatusNotFound, ""))
+			})
+
+			It("returns ErrNotFound", func() {
+				_, err := archive.Retrieve(ctx, "builds/42")
+				Expect(err).To(Equal(logarchive.ErrNotFound))
+			})
+		})
+	})
+
+	Describe("Delete", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("DELETE", "/some-bucket/concourse/builds/42"),
+				verifySigned(""),
+				ghttp.RespondWith(http.StatusNoContent, ""),
+			))
+		})
+
+		It("deletes the object", func() {
+			err := archive.Delete(ctx, "builds/42")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+})
@@ -27,6 +27,7 @@ type PrometheusEmitter struct {
 
 	buildsStarted prometheus.Counter
 	buildsRunning prometheus.Gauge
+	buildsPending *prometheus.GaugeVec
 
 	checkBuildsStarted prometheus.Counter
 	checkBuildsRunning prometheus.Gauge
@@ -78,6 +79,8 @@ type PrometheusEmitter struct {
 
 	checksEnqueued prometheus.Counter
 
+	credentialLookupsServed *prometheus.CounterVec
+
 	volumesStreamed            prometheus.Counter
 	volumesStreamedViaFallback prometheus.Counter
 
@@ -247,6 +250,15 @@ func (config *PrometheusConfig) NewEmitter(attributes map[string]string) (metric
 	})
 	prometheus.MustRegister(buildsRunning)
 
+	buildsPending := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace:   "concourse",
+		Subsystem:   "builds",
+		Name:        "pending",
+		Help:        "Number of Concourse job builds waiting to start, per team.",
+		ConstLabels: attributes,
+	}, []string{"teamName"})
+	prometheus.MustRegister(buildsPending)
+
 	checkBuildsStarted := prometheus.NewCounter(prometheus.CounterOpts{
 		Namespace:   "concourse",
 		Subsystem:   "builds",
@@ -566,6 +578,17 @@ func (config *PrometheusConfig) NewEmitter(attributes map[string]string) (metric
 	)
 	prometheus.MustRegister(checksEnqueued)
 
+	credentialLookupsServed := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace:   "concourse",
+			Subsystem:   "creds",
+			Name:        "lookups_served_total",
+			Help:        "Total number of credential lookups served by each credential manager",
+			ConstLabels: attributes,
+		}, []string{"manager"},
+	)
+	prometheus.MustRegister(credentialLookupsServed)
+
 	volumesStreamed := prometheus.NewCounter(
 		prometheus.CounterOpts{
 			Namespace:   "concourse",
@@ -832,6 +855,7 @@ func (config *PrometheusConfig) NewEmitter(attributes map[string]string) (metric
 
 		buildsStarted: buildsStarted,
 		buildsRunning: buildsRunning,
+		buildsPending: buildsPending,
 
 		checkBuildsStarted: checkBuildsStarted,
 		checkBuildsRunning: checkBuildsRunning,
@@ -890,6 +914,8 @@ func (config *PrometheusConfig) NewEmitter(attributes map[string]string) (metric
 
 		checksEnqueued: checksEnqueued,
 
+		credentialLookupsServed: credentialLookupsServed,
+
 		workerContainers:                   workerContainers,
 		workersRegistered:                  workersRegistered,
 		workerContainersLabels:             map[string]map[string]prometheus.Labels{},
@@ -940,6 +966,9 @@ func (emitter *PrometheusEmitter) Emit(logger lager.Logger, event metric.Event)
 		emitter.buildsStarted.Add(event.Value)
 	case "builds running":
 		emitter.buildsRunning.Set(event.Value)
+	case "builds pending":
+		emitter.buildsPending.
+			WithLabelValues(event.Attributes["teamName"]).Set(event.Value)
 	case "check builds started":
 		emitter.checkBuildsStarted.Add(event.Value)
 	case "check builds running":
@@ -1044,6 +1073,8 @@ func (emitter *PrometheusEmitter) Emit(logger lager.Logger, event metric.Event)
 		emitter.checksStarted.Add(event.Value)
 	case "checks enqueued":
 		emitter.checksEnqueued.Add(event.Value)
+	case "credential lookups served":
+		emitter.credentialLookupsServed.WithLabelValues(event.Attributes["manager"]).Add(event.Value)
 	case "volumes streamed":
 		emitter.volumesStreamed.Add(event.Value)
 	case "volumes streamed via fallback":
@@ -205,6 +205,14 @@ var _ = Describe("PrometheusEmitter", func() {
 			},
 		})
 
+		prometheusEmitter.Emit(logger, metric.Event{
+			Name:  "builds pending",
+			Value: 7,
+			Attributes: map[string]string{
+				"teamName": "teamdev",
+			},
+		})
+
 		prometheusEmitter.Emit(logger, metric.Event{
 			Name:  "latest completed build status",
 			Value: 0,
@@ -224,6 +232,7 @@ var _ = Describe("PrometheusEmitter", func() {
 			return string(body)
 		}
 		Eventually(getPrometheusMetrics()).Should(ContainSubstring("concourse_steps_waiting{invalid_label=\"foo\",platform=\"darwin\",prefix_test=\"bar\",prefix_testtwo=\"baz\",teamId=\"42\",teamName=\"teamdev\",type=\"get\",workerTags=\"tester\"} 4"))
+		Eventually(getPrometheusMetrics()).Should(ContainSubstring("concourse_builds_pending{invalid_label=\"foo\",prefix_test=\"bar\",prefix_testtwo=\"baz\",teamName=\"teamdev\"} 7"))
 		Eventually(getPrometheusMetrics()).Should(ContainSubstring("concourse_builds_latest_completed_build_status{invalid_label=\"foo\",jobName=\"job1\",pipelineName=\"pipeline1\",prefix_test=\"bar\",prefix_testtwo=\"baz\",teamName=\"team1\"} 0"))
 
 		prometheusEmitter.Emit(logger, metric.Event{
@@ -209,6 +209,24 @@ func (event SchedulingJobDuration) Emit(logger lager.Logger) {
 	)
 }
 
+type BuildsPending struct {
+	TeamName string
+	Count    int
+}
+
+func (event BuildsPending) Emit(logger lager.Logger) {
+	Metrics.emit(
+		logger.Session("builds-pending"),
+		Event{
+			Name:  "builds pending",
+			Value: float64(event.Count),
+			Attributes: map[string]string{
+				"teamName": event.TeamName,
+			},
+		},
+	)
+}
+
 type WorkerContainers struct {
 	WorkerName string
 	Platform   string
@@ -638,6 +656,23 @@ func LogLockReleased(logger lager.Logger, lockID lock.LockID) {
 	}
 }
 
+type CredentialLookupServed struct {
+	Manager string
+}
+
+func (event CredentialLookupServed) Emit(logger lager.Logger) {
+	Metrics.emit(
+		logger.Session("credential-lookup-served"),
+		Event{
+			Name:  "credential lookups served",
+			Value: 1,
+			Attributes: map[string]string{
+				"manager": event.Manager,
+			},
+		},
+	)
+}
+
 type WorkersState struct {
 	WorkerStateByName map[string]db.WorkerState
 }
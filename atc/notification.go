@@ -0,0 +1,136 @@
+package atc
+
+import (
+	"time"
+
+	"github.com/gobwas/glob"
+)
+
+// NotificationConfig describes an outbound webhook which is delivered when
+// builds of the pipeline's jobs finish.
+type NotificationConfig struct {
+	Name        string                   `json:"name"`
+	Webhook     NotificationWebhook      `json:"webhook"`
+	Jobs        []string                 `json:"jobs,omitempty"`
+	Statuses    []BuildStatus            `json:"statuses,omitempty"`
+	Transitions []NotificationTransition `json:"transitions,omitempty"`
+}
+
+type NotificationWebhook struct {
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// NotificationTransition matches a build whose status differs from the
+// previous completed build of the same job. An empty From or To matches any
+// status.
+type NotificationTransition struct {
+	From BuildStatus `json:"from,omitempty"`
+	To   BuildStatus `json:"to,omitempty"`
+}
+
+type NotificationConfigs []NotificationConfig
+
+func (c NotificationConfigs) Lookup(name string) (NotificationConfig, bool) {
+	for _, n := range c {
+		if n.Name == name {
+			return n, true
+		}
+	}
+
+	return NotificationConfig{}, false
+}
+
+// Matches returns whether a build of the given job which finished with status
+// should be delivered to the notification. previous is the status of the
+// job's prior completed build, or empty if there is none.
+func (n NotificationConfig) Matches(jobName string, status BuildStatus, previous BuildStatus) bool {
+	if len(n.Jobs) > 0 {
+		matched := false
+		for _, jobGlob := range n.Jobs {
+			g, err := glob.Compile(jobGlob)
+			if err == nil && g.Match(jobName) {
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			return false
+		}
+	}
+
+	if len(n.Statuses) > 0 {
+		matched := false
+		for _, s := range n.Statuses {
+			if s == status {
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			return false
+		}
+	}
+
+	if len(n.Transitions) > 0 {
+		if previous == "" || previous == status {
+			return false
+		}
+
+		matched := false
+		for _, t := range n.Transitions {
+			if (t.From == "" || t.From == previous) && (t.To == "" || t.To == status) {
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			return false
+		}
+	}
+
+	return true
+}
+
+type NotificationDeliveryStatus string
+
+const (
+	NotificationDeliveryPending   NotificationDeliveryStatus = "pending"
+	NotificationDeliverySucceeded NotificationDeliveryStatus = "succeeded"
+	NotificationDeliveryFailed    NotificationDeliveryStatus = "failed"
+)
+
+type NotificationDelivery struct {
+	ID             int                        `json:"id"`
+	Name           string                     `json:"name"`
+	Status         NotificationDeliveryStatus `json:"status"`
+	BuildID        int                        `json:"build_id"`
+	BuildName      string                     `json:"build_name"`
+	JobName        string                     `json:"job_name"`
+	BuildStatus    BuildStatus                `json:"build_status"`
+	PreviousStatus BuildStatus                `json:"previous_status,omitempty"`
+	Attempts       int                        `json:"attempts"`
+	ResponseCode   int                        `json:"response_code,omitempty"`
+	LastError      string                     `json:"last_error,omitempty"`
+	CreatedAt      int64                      `json:"created_at"`
+	UpdatedAt      int64                      `json:"updated_at"`
+}
+
+// NotificationPayload is the body POSTed to a notification's webhook URL.
+type NotificationPayload struct {
+	Notification         string       `json:"notification"`
+	TeamName             string       `json:"team_name"`
+	PipelineName         string       `json:"pipeline_name"`
+	PipelineInstanceVars InstanceVars `json:"pipeline_instance_vars,omitempty"`
+	JobName              string       `json:"job_name"`
+	BuildID              int          `json:"build_id"`
+	BuildName            string       `json:"build_name"`
+	Status               BuildStatus  `json:"status"`
+	PreviousStatus       BuildStatus  `json:"previous_status,omitempty"`
+	URL                  string       `json:"url"`
+	StartTime            time.Time    `json:"start_time"`
+	EndTime              time.Time    `json:"end_time"`
+}
@@ -0,0 +1,78 @@
+package atc_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NotificationConfig", func() {
+	Describe("Matches", func() {
+		var notification atc.NotificationConfig
+
+		BeforeEach(func() {
+			notification = atc.NotificationConfig{
+				Name: "some-notification",
+				Webhook: atc.NotificationWebhook{
+					URL: "https://example.com",
+				},
+			}
+		})
+
+		It("matches every build when there are no filters", func() {
+			Expect(notification.Matches("some-job", atc.StatusSucceeded, "")).To(BeTrue())
+			Expect(notification.Matches("other-job", atc.StatusFailed, atc.StatusSucceeded)).To(BeTrue())
+		})
+
+		Context("when jobs are configured", func() {
+			BeforeEach(func() {
+				notification.Jobs = []string{"deploy-*", "unit"}
+			})
+
+			It("matches jobs by glob", func() {
+				Expect(notification.Matches("deploy-prod", atc.StatusSucceeded, "")).To(BeTrue())
+				Expect(notification.Matches("unit", atc.StatusSucceeded, "")).To(BeTrue())
+				Expect(notification.Matches("integration", atc.StatusSucceeded, "")).To(BeFalse())
+			})
+		})
+
+		Context("when statuses are configured", func() {
+			BeforeEach(func() {
+				notification.Statuses = []atc.BuildStatus{atc.StatusFailed, atc.StatusErrored}
+			})
+
+			It("only matches the given statuses", func() {
+				Expect(notification.Matches("some-job", atc.StatusFailed, "")).To(BeTrue())
+				Expect(notification.Matches("some-job", atc.StatusErrored, "")).To(BeTrue())
+				Expect(notification.Matches("some-job", atc.StatusSucceeded, "")).To(BeFalse())
+			})
+		})
+
+		Context("when transitions are configured", func() {
+			BeforeEach(func() {
+				notification.Transitions = []atc.NotificationTransition{
+					{From: atc.StatusFailed, To: atc.StatusSucceeded},
+					{To: atc.StatusFailed},
+				}
+			})
+
+			It("matches builds which changed status", func() {
+				Expect(notification.Matches("some-job", atc.StatusSucceeded, atc.StatusFailed)).To(BeTrue())
+				Expect(notification.Matches("some-job", atc.StatusFailed, atc.StatusSucceeded)).To(BeTrue())
+				Expect(notification.Matches("some-job", atc.StatusFailed, atc.StatusErrored)).To(BeTrue())
+			})
+
+			It("does not match builds whose status did not change", func() {
+				Expect(notification.Matches("some-job", atc.StatusFailed, atc.StatusFailed)).To(BeFalse())
+			})
+
+			It("does not match builds without a previous build", func() {
+				Expect(notification.Matches("some-job", atc.StatusFailed, "")).To(BeFalse())
+			})
+
+			It("does not match transitions which are not configured", func() {
+				Expect(notification.Matches("some-job", atc.StatusSucceeded, atc.StatusErrored)).To(BeFalse())
+			})
+		})
+	})
+})
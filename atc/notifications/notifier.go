@@ -0,0 +1,249 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager/v3"
+	"code.cloudfoundry.org/lager/v3/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type Config struct {
+	ExternalURL  string
+	Timeout      time.Duration
+	MaxAttempts  int
+	RetryBackoff time.Duration
+}
+
+type notifier struct {
+	config Config
+
+	deliveryFactory db.NotificationDeliveryFactory
+	buildFactory    db.BuildFactory
+	secrets         creds.Secrets
+	varSourcePool   creds.VarSourcePool
+	httpClient      *http.Client
+	clock           clock.Clock
+}
+
+// NewNotifier returns a component which queues a delivery for every
+// notification matching a finished job build, and delivers any pending ones
+// to their webhook.
+func NewNotifier(
+	config Config,
+	deliveryFactory db.NotificationDeliveryFactory,
+	buildFactory db.BuildFactory,
+	secrets creds.Secrets,
+	varSourcePool creds.VarSourcePool,
+	clock clock.Clock,
+) *notifier {
+	return &notifier{
+		config:          config,
+		deliveryFactory: deliveryFactory,
+		buildFactory:    buildFactory,
+		secrets:         secrets,
+		varSourcePool:   varSourcePool,
+		httpClient:      &http.Client{Timeout: config.Timeout},
+		clock:           clock,
+	}
+}
+
+func (n *notifier) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("notifier")
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	err := n.queueDeliveries(logger)
+	if err != nil {
+		return err
+	}
+
+	return n.deliver(ctx, logger)
+}
+
+func (n *notifier) queueDeliveries(logger lager.Logger) error {
+	builds, err := n.deliveryFactory.UnnotifiedBuilds()
+	if err != nil {
+		logger.Error("failed-to-get-unnotified-builds", err)
+		return err
+	}
+
+	pipelines := map[int]db.Pipeline{}
+
+	for _, build := range builds {
+		pipeline, cached := pipelines[build.PipelineID()]
+		if !cached {
+			var found bool
+			pipeline, found, err = build.Pipeline()
+			if err != nil {
+				logger.Error("failed-to-get-pipeline", err, build.LagerData())
+				return err
+			}
+
+			if !found {
+				pipeline = nil
+			}
+
+			pipelines[build.PipelineID()] = pipeline
+		}
+
+		var names []string
+		var previous db.BuildStatus
+		if pipeline != nil && len(pipeline.Notifications()) > 0 {
+			previous, _, err = n.deliveryFactory.PreviousBuildStatus(build)
+			if err != nil {
+				logger.Error("failed-to-get-previous-build-status", err, build.LagerData())
+				return err
+			}
+
+			for _, notification := range pipeline.Notifications() {
+				if notification.Matches(build.JobName(), atc.BuildStatus(build.Status()), atc.BuildStatus(previous)) {
+					names = append(names, notification.Name)
+				}
+			}
+		}
+
+		err = n.deliveryFactory.QueueDeliveries(build, previous, names)
+		if err != nil {
+			logger.Error("failed-to-queue-deliveries", err, build.LagerData())
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (n *notifier) deliver(ctx context.Context, logger lager.Logger) error {
+	deliveries, err := n.deliveryFactory.PendingDeliveries()
+	if err != nil {
+		logger.Error("failed-to-get-pending-deliveries", err)
+		return err
+	}
+
+	for _, delivery := range deliveries {
+		dLogger := logger.Session("deliver", lager.Data{
+			"delivery":     delivery.ID(),
+			"notification": delivery.Name(),
+			"build":        delivery.BuildID(),
+		})
+
+		code, err := n.send(ctx, dLogger, delivery)
+		if err == nil {
+			err = delivery.Succeed(code)
+		} else if errors.Is(err, errNotificationRemoved) || delivery.Attempts()+1 >= n.config.MaxAttempts {
+			dLogger.Info("giving-up", lager.Data{"error": err.Error()})
+			err = delivery.Fail(code, err.Error())
+		} else {
+			backoff := n.config.RetryBackoff << delivery.Attempts()
+			err = delivery.Retry(code, err.Error(), n.clock.Now().Add(backoff))
+		}
+
+		if err != nil {
+			dLogger.Error("failed-to-update-delivery", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+var errNotificationRemoved = errors.New("notification is no longer configured")
+
+func (n *notifier) send(ctx context.Context, logger lager.Logger, delivery db.NotificationDelivery) (int, error) {
+	build, found, err := n.buildFactory.Build(delivery.BuildID())
+	if err != nil {
+		return 0, err
+	}
+
+	if !found {
+		return 0, errors.New("build not found")
+	}
+
+	pipeline, found, err := build.Pipeline()
+	if err != nil {
+		return 0, err
+	}
+
+	if !found {
+		return 0, errNotificationRemoved
+	}
+
+	config, found := pipeline.Notifications().Lookup(delivery.Name())
+	if !found {
+		return 0, errNotificationRemoved
+	}
+
+	variables, err := pipeline.Variables(logger, n.secrets, n.varSourcePool, creds.SecretLookupParams{
+		Team:         build.TeamName(),
+		Pipeline:     build.PipelineName(),
+		InstanceVars: build.PipelineInstanceVars(),
+		Job:          build.JobName(),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to create variables: %w", err)
+	}
+
+	url, err := creds.NewString(variables, config.Webhook.URL).Evaluate()
+	if err != nil {
+		return 0, fmt.Errorf("failed to evaluate url: %w", err)
+	}
+
+	payload, err := json.Marshal(atc.NotificationPayload{
+		Notification:         config.Name,
+		TeamName:             build.TeamName(),
+		PipelineName:         build.PipelineName(),
+		PipelineInstanceVars: build.PipelineInstanceVars(),
+		JobName:              build.JobName(),
+		BuildID:              build.ID(),
+		BuildName:            build.Name(),
+		Status:               atc.BuildStatus(delivery.BuildStatus()),
+		PreviousStatus:       atc.BuildStatus(delivery.PreviousStatus()),
+		URL:                  fmt.Sprintf("%s/builds/%d", n.config.ExternalURL, build.ID()),
+		StartTime:            build.StartTime(),
+		EndTime:              build.EndTime(),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	for name, value := range config.Webhook.Headers {
+		evaluated, err := creds.NewString(variables, value).Evaluate()
+		if err != nil {
+			return 0, fmt.Errorf("failed to evaluate header %s: %w", name, err)
+		}
+
+		req.Header.Set(name, evaluated)
+	}
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+
+	defer db.Close(resp.Body)
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected response: %s", resp.Status)
+	}
+
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,254 @@
+package notifications_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/component"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/notifications"
+	"github.com/concourse/concourse/vars"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Notifier", func() {
+	var (
+		fakeDeliveryFactory *dbfakes.FakeNotificationDeliveryFactory
+		fakeBuildFactory    *dbfakes.FakeBuildFactory
+		fakeBuild           *dbfakes.FakeBuild
+		fakePipeline        *dbfakes.FakePipeline
+		fakeClock           *fakeclock.FakeClock
+		server              *ghttp.Server
+
+		notifier component.Runnable
+		runErr   error
+	)
+
+	BeforeEach(func() {
+		fakeDeliveryFactory = new(dbfakes.FakeNotificationDeliveryFactory)
+		fakeBuildFactory = new(dbfakes.FakeBuildFactory)
+		fakeClock = fakeclock.NewFakeClock(time.Unix(1000, 0))
+		server = ghttp.NewServer()
+
+		fakePipeline = new(dbfakes.FakePipeline)
+		fakePipeline.NotificationsReturns(atc.NotificationConfigs{
+			{
+				Name: "on-failure",
+				Webhook: atc.NotificationWebhook{
+					URL:     server.URL() + "/((path))",
+					Headers: map[string]string{"Authorization": "Bearer ((token))"},
+				},
+				Statuses: []atc.BuildStatus{atc.StatusFailed},
+			},
+			{
+				Name: "on-fix",
+				Webhook: atc.NotificationWebhook{
+					URL: server.URL() + "/fixed",
+				},
+				Transitions: []atc.NotificationTransition{{From: atc.StatusFailed, To: atc.StatusSucceeded}},
+			},
+		})
+		fakePipeline.VariablesReturns(vars.StaticVariables{
+			"path":  "hook",
+			"token": "some-token",
+		}, nil)
+
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeBuild.IDReturns(42)
+		fakeBuild.NameReturns("7")
+		fakeBuild.JobNameReturns("some-job")
+		fakeBuild.PipelineIDReturns(1)
+		fakeBuild.PipelineNameReturns("some-pipeline")
+		fakeBuild.TeamNameReturns("some-team")
+		fakeBuild.StatusReturns(db.BuildStatusFailed)
+		fakeBuild.PipelineReturns(fakePipeline, true, nil)
+		fakeBuildFactory.BuildReturns(fakeBuild, true, nil)
+
+		notifier = notifications.NewNotifier(
+			notifications.Config{
+				ExternalURL:  "https://ci.example.com",
+				Timeout:      time.Second,
+				MaxAttempts:  3,
+				RetryBackoff: time.Minute,
+			},
+			fakeDeliveryFactory,
+			fakeBuildFactory,
+			nil,
+			nil,
+			fakeClock,
+		)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	JustBeforeEach(func() {
+		runErr = notifier.Run(context.TODO())
+	})
+
+	Describe("queueing deliveries", func() {
+		BeforeEach(func() {
+			fakeDeliveryFactory.UnnotifiedBuildsReturns([]db.Build{fakeBuild}, nil)
+		})
+
+		Context("when the build matches a notification", func() {
+			BeforeEach(func() {
+				fakeDeliveryFactory.PreviousBuildStatusReturns(db.BuildStatusSucceeded, true, nil)
+			})
+
+			It("queues a delivery for the matching notifications", func() {
+				Expect(runErr).ToNot(HaveOccurred())
+				Expect(fakeDeliveryFactory.QueueDeliveriesCallCount()).To(Equal(1))
+
+				build, previous, names := fakeDeliveryFactory.QueueDeliveriesArgsForCall(0)
+				Expect(build).To(Equal(fakeBuild))
+				Expect(previous).To(Equal(db.BuildStatusSucceeded))
+				Expect(names).To(Equal([]string{"on-failure"}))
+			})
+		})
+
+		Context("when the build fixes a failing job", func() {
+			BeforeEach(func() {
+				fakeBuild.StatusReturns(db.BuildStatusSucceeded)
+				fakeDeliveryFactory.PreviousBuildStatusReturns(db.BuildStatusFailed, true, nil)
+			})
+
+			It("queues a delivery for the transition", func() {
+				Expect(runErr).ToNot(HaveOccurred())
+				_, _, names := fakeDeliveryFactory.QueueDeliveriesArgsForCall(0)
+				Expect(names).To(Equal([]string{"on-fix"}))
+			})
+		})
+
+		Context("when the pipeline has no notifications", func() {
+			BeforeEach(func() {
+				fakePipeline.NotificationsReturns(nil)
+			})
+
+			It("still marks the build as notified", func() {
+				Expect(runErr).ToNot(HaveOccurred())
+				Expect(fakeDeliveryFactory.PreviousBuildStatusCallCount()).To(BeZero())
+				Expect(fakeDeliveryFactory.QueueDeliveriesCallCount()).To(Equal(1))
+
+				_, _, names := fakeDeliveryFactory.QueueDeliveriesArgsForCall(0)
+				Expect(names).To(BeEmpty())
+			})
+		})
+
+		Context("when getting the builds fails", func() {
+			BeforeEach(func() {
+				fakeDeliveryFactory.UnnotifiedBuildsReturns(nil, errors.New("nope"))
+			})
+
+			It("returns the error", func() {
+				Expect(runErr).To(MatchError("nope"))
+			})
+		})
+	})
+
+	Describe("delivering", func() {
+		var fakeDelivery *dbfakes.FakeNotificationDelivery
+
+		BeforeEach(func() {
+			fakeDelivery = new(dbfakes.FakeNotificationDelivery)
+			fakeDelivery.IDReturns(1)
+			fakeDelivery.NameReturns("on-failure")
+			fakeDelivery.BuildIDReturns(42)
+			fakeDelivery.BuildStatusReturns(db.BuildStatusFailed)
+			fakeDelivery.PreviousStatusReturns(db.BuildStatusSucceeded)
+			fakeDeliveryFactory.PendingDeliveriesReturns([]db.NotificationDelivery{fakeDelivery}, nil)
+		})
+
+		Context("when the webhook succeeds", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/hook"),
+						ghttp.VerifyHeaderKV("Authorization", "Bearer some-token"),
+						ghttp.VerifyHeaderKV("Content-Type", "application/json"),
+						ghttp.VerifyJSONRepresenting(atc.NotificationPayload{
+							Notification:   "on-failure",
+							TeamName:       "some-team",
+							PipelineName:   "some-pipeline",
+							JobName:        "some-job",
+							BuildID:        42,
+							BuildName:      "7",
+							Status:         atc.StatusFailed,
+							PreviousStatus: atc.StatusSucceeded,
+							URL:            "https://ci.example.com/builds/42",
+						}),
+						ghttp.RespondWith(http.StatusNoContent, nil),
+					),
+				)
+			})
+
+			It("marks the delivery as succeeded", func() {
+				Expect(runErr).ToNot(HaveOccurred())
+				Expect(server.ReceivedRequests()).To(HaveLen(1))
+				Expect(fakeDelivery.SucceedCallCount()).To(Equal(1))
+				Expect(fakeDelivery.SucceedArgsForCall(0)).To(Equal(http.StatusNoContent))
+			})
+		})
+
+		Context("when the webhook fails", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(ghttp.RespondWith(http.StatusBadGateway, nil))
+			})
+
+			Context("when there are attempts remaining", func() {
+				BeforeEach(func() {
+					fakeDelivery.AttemptsReturns(1)
+				})
+
+				It("retries with an exponential backoff", func() {
+					Expect(runErr).ToNot(HaveOccurred())
+					Expect(fakeDelivery.RetryCallCount()).To(Equal(1))
+
+					code, reason, next := fakeDelivery.RetryArgsForCall(0)
+					Expect(code).To(Equal(http.StatusBadGateway))
+					Expect(reason).To(ContainSubstring("502"))
+					Expect(next).To(Equal(fakeClock.Now().Add(2 * time.Minute)))
+				})
+			})
+
+			Context("when it was the last attempt", func() {
+				BeforeEach(func() {
+					fakeDelivery.AttemptsReturns(2)
+				})
+
+				It("marks the delivery as failed", func() {
+					Expect(runErr).ToNot(HaveOccurred())
+					Expect(fakeDelivery.RetryCallCount()).To(BeZero())
+					Expect(fakeDelivery.FailCallCount()).To(Equal(1))
+
+					code, reason := fakeDelivery.FailArgsForCall(0)
+					Expect(code).To(Equal(http.StatusBadGateway))
+					Expect(reason).To(ContainSubstring("502"))
+				})
+			})
+		})
+
+		Context("when the notification has been removed from the pipeline", func() {
+			BeforeEach(func() {
+				fakeDelivery.NameReturns("bogus")
+			})
+
+			It("fails the delivery without retrying", func() {
+				Expect(runErr).ToNot(HaveOccurred())
+				Expect(server.ReceivedRequests()).To(BeEmpty())
+				Expect(fakeDelivery.FailCallCount()).To(Equal(1))
+
+				_, reason := fakeDelivery.FailArgsForCall(0)
+				Expect(reason).To(Equal("notification is no longer configured"))
+			})
+		})
+	})
+})
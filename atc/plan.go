@@ -11,6 +11,7 @@ type Plan struct {
 	Run         *RunPlan         `json:"run,omitempty"`
 	SetPipeline *SetPipelinePlan `json:"set_pipeline,omitempty"`
 	LoadVar     *LoadVarPlan     `json:"load_var,omitempty"`
+	Approve     *ApprovePlan     `json:"approve,omitempty"`
 
 	Do         *DoPlan         `json:"do,omitempty"`
 	InParallel *InParallelPlan `json:"in_parallel,omitempty"`
@@ -25,6 +26,7 @@ type Plan struct {
 	Try     *TryPlan     `json:"try,omitempty"`
 	Timeout *TimeoutPlan `json:"timeout,omitempty"`
 	Retry   *RetryPlan   `json:"retry,omitempty"`
+	If      *IfPlan      `json:"if,omitempty"`
 
 	// used for 'fly execute'
 	ArtifactInput  *ArtifactInputPlan  `json:"artifact_input,omitempty"`
@@ -91,6 +93,10 @@ func (plan *Plan) Each(f func(*Plan)) {
 		}
 	}
 
+	if plan.If != nil {
+		plan.If.Step.Each(f)
+	}
+
 	if plan.Get != nil {
 		plan.Get.TypeImage.EachPlan(f)
 	}
@@ -170,6 +176,11 @@ type TimeoutPlan struct {
 	Duration string `json:"duration"`
 }
 
+type IfPlan struct {
+	Step      Plan   `json:"step"`
+	Condition string `json:"condition"`
+}
+
 type TryPlan struct {
 	Step Plan `json:"step"`
 }
@@ -347,6 +358,9 @@ type TaskPlan struct {
 	// image does not count towards the timeout.
 	Timeout string `json:"timeout,omitempty"`
 
+	// An OIDC token to mint for the task's container.
+	IDToken *IDTokenConfig `json:"id_token,omitempty"`
+
 	// Resource types to have available for use when fetching the task's image.
 	ResourceTypes ResourceTypes `json:"resource_types,omitempty"`
 
@@ -379,6 +393,9 @@ type RunPlan struct {
 	// A timeout to enforce on the run step's process. Note that fetching the
 	// prototype's image does not count towards the timeout.
 	Timeout string `json:"timeout,omitempty"`
+
+	// An OIDC token to mint for the run step's container.
+	IDToken *IDTokenConfig `json:"id_token,omitempty"`
 }
 
 type SetPipelinePlan struct {
@@ -397,6 +414,13 @@ type LoadVarPlan struct {
 	Reveal bool   `json:"reveal,omitempty"`
 }
 
+type ApprovePlan struct {
+	Name    string   `json:"name"`
+	Users   []string `json:"users,omitempty"`
+	Roles   []string `json:"roles,omitempty"`
+	Timeout string   `json:"timeout,omitempty"`
+}
+
 type RetryPlan []Plan
 
 type DependentGetPlan struct {
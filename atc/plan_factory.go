@@ -43,6 +43,8 @@ func (factory PlanFactory) NewPlan(step PlanConfig) Plan {
 		plan.SetPipeline = &t
 	case LoadVarPlan:
 		plan.LoadVar = &t
+	case ApprovePlan:
+		plan.Approve = &t
 	case CheckPlan:
 		plan.Check = &t
 	case OnAbortPlan:
@@ -61,6 +63,8 @@ func (factory PlanFactory) NewPlan(step PlanConfig) Plan {
 		plan.Timeout = &t
 	case RetryPlan:
 		plan.Retry = &t
+	case IfPlan:
+		plan.If = &t
 	case ArtifactInputPlan:
 		plan.ArtifactInput = &t
 	case ArtifactOutputPlan:
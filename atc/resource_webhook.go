@@ -0,0 +1,104 @@
+package atc
+
+import "time"
+
+const (
+	WebhookProviderGeneric   = "generic"
+	WebhookProviderGitHub    = "github"
+	WebhookProviderGitLab    = "gitlab"
+	WebhookProviderBitbucket = "bitbucket"
+)
+
+var WebhookProviders = []string{
+	WebhookProviderGeneric,
+	WebhookProviderGitHub,
+	WebhookProviderGitLab,
+	WebhookProviderBitbucket,
+}
+
+const DefaultWebhookReplayWindow = 24 * time.Hour
+
+// ResourceWebhook configures how inbound webhooks for a resource are
+// authenticated and which payloads result in a check.
+//
+// Requests are authenticated by an HMAC-SHA256 signature of the body computed
+// with Secret, by a Token sent in a header, or both. The headers default to
+// the ones used by the Provider.
+//
+// Within ReplayWindow, a signed request with the same body as an earlier one
+// is rejected as a replay, as is an unsigned request with the same delivery
+// ID. Requests without a delivery ID are rejected if the Provider or
+// DeliveryHeader defines where it is sent.
+type ResourceWebhook struct {
+	Provider        string   `json:"provider,omitempty"`
+	Secret          string   `json:"secret,omitempty"`
+	SignatureHeader string   `json:"signature_header,omitempty"`
+	Token           string   `json:"token,omitempty"`
+	TokenHeader     string   `json:"token_header,omitempty"`
+	DeliveryHeader  string   `json:"delivery_header,omitempty"`
+	ReplayWindow    string   `json:"replay_window,omitempty"`
+	Branches        []string `json:"branches,omitempty"`
+	Paths           []string `json:"paths,omitempty"`
+}
+
+func (w ResourceWebhook) ProviderName() string {
+	if w.Provider == "" {
+		return WebhookProviderGeneric
+	}
+
+	return w.Provider
+}
+
+func (w ResourceWebhook) SignatureHeaderName() string {
+	if w.SignatureHeader != "" {
+		return w.SignatureHeader
+	}
+
+	switch w.ProviderName() {
+	case WebhookProviderBitbucket:
+		return "X-Hub-Signature"
+	default:
+		return "X-Hub-Signature-256"
+	}
+}
+
+func (w ResourceWebhook) TokenHeaderName() string {
+	if w.TokenHeader != "" {
+		return w.TokenHeader
+	}
+
+	switch w.ProviderName() {
+	case WebhookProviderGitLab:
+		return "X-Gitlab-Token"
+	default:
+		return "X-Concourse-Webhook-Token"
+	}
+}
+
+// DeliveryHeaderName returns the header carrying the unique ID of a delivery,
+// which requests must send. It is empty for the generic provider unless
+// configured.
+func (w ResourceWebhook) DeliveryHeaderName() string {
+	if w.DeliveryHeader != "" {
+		return w.DeliveryHeader
+	}
+
+	switch w.ProviderName() {
+	case WebhookProviderGitHub:
+		return "X-GitHub-Delivery"
+	case WebhookProviderGitLab:
+		return "X-Gitlab-Event-UUID"
+	case WebhookProviderBitbucket:
+		return "X-Request-UUID"
+	default:
+		return ""
+	}
+}
+
+func (w ResourceWebhook) ReplayWindowDuration() (time.Duration, error) {
+	if w.ReplayWindow == "" {
+		return DefaultWebhookReplayWindow, nil
+	}
+
+	return time.ParseDuration(w.ReplayWindow)
+}
@@ -3,8 +3,9 @@ package atc
 import "github.com/tedsuo/rata"
 
 const (
-	SaveConfig = "SaveConfig"
-	GetConfig  = "GetConfig"
+	SaveConfig         = "SaveConfig"
+	GetConfig          = "GetConfig"
+	PlanPipelineConfig = "PlanPipelineConfig"
 
 	GetBuild            = "GetBuild"
 	GetBuildPlan        = "GetBuildPlan"
@@ -15,6 +16,9 @@ const (
 	AbortBuild          = "AbortBuild"
 	GetBuildPreparation = "GetBuildPreparation"
 	SetBuildComment     = "SetBuildComment"
+	ListBuildApprovals  = "ListBuildApprovals"
+	ApproveBuildStep    = "ApproveBuildStep"
+	RejectBuildStep     = "RejectBuildStep"
 
 	GetJob         = "GetJob"
 	CreateJobBuild = "CreateJobBuild"
@@ -41,6 +45,7 @@ const (
 	GetResource               = "GetResource"
 	CheckResource             = "CheckResource"
 	CheckResourceWebHook      = "CheckResourceWebHook"
+	CheckResourcesWebHook     = "CheckResourcesWebHook"
 	CheckResourceType         = "CheckResourceType"
 	CheckPrototype            = "CheckPrototype"
 
@@ -77,6 +82,13 @@ const (
 	CreatePipelineBuild       = "CreatePipelineBuild"
 	PipelineBadge             = "PipelineBadge"
 
+	ListNotificationDeliveries = "ListNotificationDeliveries"
+	SearchPipelineBuildLogs    = "SearchPipelineBuildLogs"
+
+	ListPipelineConfigRevisions   = "ListPipelineConfigRevisions"
+	GetPipelineConfigRevision     = "GetPipelineConfigRevision"
+	RestorePipelineConfigRevision = "RestorePipelineConfigRevision"
+
 	RegisterWorker  = "RegisterWorker"
 	LandWorker      = "LandWorker"
 	RetireWorker    = "RetireWorker"
@@ -110,6 +122,12 @@ const (
 	DestroyTeam    = "DestroyTeam"
 	ListTeamBuilds = "ListTeamBuilds"
 
+	ListSecrets      = "ListSecrets"
+	SetSecret        = "SetSecret"
+	DeleteSecret     = "DeleteSecret"
+	ListSecretUsages = "ListSecretUsages"
+	ClearSecretCache = "ClearSecretCache"
+
 	CreateArtifact     = "CreateArtifact"
 	GetArtifact        = "GetArtifact"
 	ListBuildArtifacts = "ListBuildArtifacts"
@@ -135,11 +153,17 @@ const (
 	ClearTaskCacheQueryPath = "cache_path"
 	SaveConfigCheckCreds    = "check_creds"
 	AbortBuildForce         = "force"
+	SecretPipelineQuery     = "pipeline"
+	SecretUsageVarQuery     = "var"
+
+	ClearSecretCacheTeamQuery = "team"
+	ClearSecretCacheVarQuery  = "var"
 )
 
 var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config", Method: "PUT", Name: SaveConfig},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config", Method: "GET", Name: GetConfig},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config/plan", Method: "POST", Name: PlanPipelineConfig},
 
 	{Path: "/api/v1/teams/:team_name/builds", Method: "POST", Name: CreateBuild},
 
@@ -152,6 +176,9 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/builds/:build_id/preparation", Method: "GET", Name: GetBuildPreparation},
 	{Path: "/api/v1/builds/:build_id/artifacts", Method: "GET", Name: ListBuildArtifacts},
 	{Path: "/api/v1/builds/:build_id/comment", Method: "PUT", Name: SetBuildComment},
+	{Path: "/api/v1/builds/:build_id/approvals", Method: "GET", Name: ListBuildApprovals},
+	{Path: "/api/v1/builds/:build_id/approvals/:plan_id/approve", Method: "PUT", Name: ApproveBuildStep},
+	{Path: "/api/v1/builds/:build_id/approvals/:plan_id/reject", Method: "PUT", Name: RejectBuildStep},
 
 	{Path: "/api/v1/jobs", Method: "GET", Name: ListAllJobs},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs", Method: "GET", Name: ListJobs},
@@ -185,6 +212,11 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/builds", Method: "GET", Name: ListPipelineBuilds},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/builds", Method: "POST", Name: CreatePipelineBuild},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/badge", Method: "GET", Name: PipelineBadge},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/notification-deliveries", Method: "GET", Name: ListNotificationDeliveries},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/logs/search", Method: "GET", Name: SearchPipelineBuildLogs},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config/revisions", Method: "GET", Name: ListPipelineConfigRevisions},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config/revisions/:revision", Method: "GET", Name: GetPipelineConfigRevision},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config/revisions/:revision/restore", Method: "PUT", Name: RestorePipelineConfigRevision},
 
 	{Path: "/api/v1/resources", Method: "GET", Name: ListAllResources},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources", Method: "GET", Name: ListResources},
@@ -194,6 +226,7 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name", Method: "GET", Name: GetResource},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check", Method: "POST", Name: CheckResource},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check/webhook", Method: "POST", Name: CheckResourceWebHook},
+	{Path: "/api/v1/teams/:team_name/resources/check/webhook", Method: "POST", Name: CheckResourcesWebHook},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resource-types/:resource_type_name/check", Method: "POST", Name: CheckResourceType},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/prototypes/:prototype_name/check", Method: "POST", Name: CheckPrototype},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/cache", Method: "DELETE", Name: ClearResourceCache},
@@ -251,6 +284,12 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name", Method: "DELETE", Name: DestroyTeam},
 	{Path: "/api/v1/teams/:team_name/builds", Method: "GET", Name: ListTeamBuilds},
 
+	{Path: "/api/v1/teams/:team_name/secrets", Method: "GET", Name: ListSecrets},
+	{Path: "/api/v1/teams/:team_name/secrets/:secret_name", Method: "PUT", Name: SetSecret},
+	{Path: "/api/v1/teams/:team_name/secrets/:secret_name", Method: "DELETE", Name: DeleteSecret},
+	{Path: "/api/v1/secret_usages", Method: "GET", Name: ListSecretUsages},
+	{Path: "/api/v1/secret_cache", Method: "DELETE", Name: ClearSecretCache},
+
 	{Path: "/api/v1/teams/:team_name/artifacts", Method: "POST", Name: CreateArtifact},
 	{Path: "/api/v1/teams/:team_name/artifacts/:artifact_id", Method: "GET", Name: GetArtifact},
 
@@ -87,6 +87,9 @@ type ContainerSpec struct {
 	// StepName is the name of the task step, used for identifying task caches.
 	// If the Container is not for a task step, this may be left empty.
 	StepName string
+	// Priority is the priority of the job in which the Container is running,
+	// used for ordering steps waiting for a worker.
+	Priority int
 
 	// ImageSpec defines where the container image should come from.
 	ImageSpec ImageSpec
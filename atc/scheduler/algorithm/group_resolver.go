@@ -259,7 +259,7 @@ outputs:
 			}
 
 			if candidate == nil {
-				exists, err := r.vdb.VersionExists(ctx, output.ResourceID, output.Version)
+				exists, err := r.vdb.VersionExists(ctx, r.inputConfigs[c].ResourceID, output.Version)
 				if err != nil {
 					tracing.End(span, err)
 					return false, err
@@ -410,7 +410,7 @@ func (r *groupResolver) constrainingCandidates(passedJobID int) map[string][]str
 	constrainingCandidates := map[string][]string{}
 	for passedIndex, passedInput := range r.inputConfigs {
 		if passedInput.Passed[passedJobID] && r.candidates[passedIndex] != nil {
-			resID := strconv.Itoa(passedInput.ResourceID)
+			resID := strconv.Itoa(passedInput.PassedResourceID(passedJobID))
 			constrainingCandidates[resID] = append(constrainingCandidates[resID], string(r.candidates[passedIndex].Version))
 		}
 	}
@@ -422,13 +422,13 @@ func (r *groupResolver) outputIsRelatedAndMatches(ctx context.Context, span trac
 	inputConfig := r.inputConfigs[candidateIdx]
 	candidate := r.candidates[candidateIdx]
 
-	if inputConfig.ResourceID != output.ResourceID {
-		// unrelated; different resource
+	if !inputConfig.Passed[passedJobID] {
+		// unrelated; this input is unaffected by the current job
 		return false, false, nil
 	}
 
-	if !inputConfig.Passed[passedJobID] {
-		// unrelated; this input is unaffected by the current job
+	if inputConfig.PassedResourceID(passedJobID) != output.ResourceID {
+		// unrelated; different resource
 		return false, false, nil
 	}
 
@@ -438,7 +438,9 @@ func (r *groupResolver) outputIsRelatedAndMatches(ctx context.Context, span trac
 		return false, true, nil
 	}
 
-	disabled, err := r.vdb.VersionIsDisabled(ctx, output.ResourceID, output.Version)
+	// the version may have come from a job in another pipeline, so check it
+	// against the input's own resource
+	disabled, err := r.vdb.VersionIsDisabled(ctx, inputConfig.ResourceID, output.Version)
 	if err != nil {
 		return false, false, err
 	}
@@ -452,6 +454,22 @@ func (r *groupResolver) outputIsRelatedAndMatches(ctx context.Context, span trac
 		return false, false, nil
 	}
 
+	if inputConfig.VersionFilter != nil {
+		matches, err := r.vdb.VersionMatchesFilter(ctx, inputConfig.ResourceID, output.Version, *inputConfig.VersionFilter)
+		if err != nil {
+			return false, false, err
+		}
+
+		if !matches {
+			// this version is filtered out so it cannot be used
+			span.AddEvent("version filtered", trace.WithAttributes(
+				attribute.Int("resourceID", output.ResourceID),
+				attribute.String("version", string(output.Version)),
+			))
+			return false, false, nil
+		}
+	}
+
 	if inputConfig.PinnedVersion != nil && r.pins[candidateIdx] != output.Version {
 		// input is both pinned and assigned a 'passed' constraint, but the pinned
 		// version doesn't match the job's output version
@@ -26,8 +26,8 @@ func (r *individualResolver) InputConfigs() db.InputConfigs {
 	return db.InputConfigs{r.inputConfig}
 }
 
-// Handles two different configurations of a resource without passed
-// constraints: every and latest
+// Handles three different configurations of a resource without passed
+// constraints: every, latest and latest matching a filter
 func (r *individualResolver) Resolve(ctx context.Context) (map[string]*versionCandidate, db.ResolutionFailure, error) {
 	ctx, span := tracing.StartSpan(ctx, "individualResolver.Resolve", tracing.Attrs{
 		"input": r.inputConfig.Name,
@@ -54,6 +54,24 @@ func (r *individualResolver) Resolve(ctx context.Context) (map[string]*versionCa
 		span.AddEvent("found via every", trace.WithAttributes(
 			attribute.String("version", string(version)),
 		))
+	} else if r.inputConfig.VersionFilter != nil {
+		var err error
+		var found bool
+		version, found, err = r.vdb.LatestVersionOfResourceMatching(ctx, r.inputConfig.ResourceID, *r.inputConfig.VersionFilter)
+		if err != nil {
+			tracing.End(span, err)
+			return nil, "", err
+		}
+
+		if !found {
+			span.AddEvent("matching version not found")
+			span.SetStatus(codes.Error, "matching version not found")
+			return nil, db.NoMatchingVersion, nil
+		}
+
+		span.AddEvent("found via filter", trace.WithAttributes(
+			attribute.String("version", string(version)),
+		))
 	} else {
 		// there are no passed constraints, so just take the latest version
 		var err error
@@ -98,3 +98,26 @@ func (r *rerunBuild) BuildInputs(ctx context.Context) ([]db.BuildInput, bool, er
 
 	return buildInputs, true, nil
 }
+
+var _ Build = (*matrixBuild)(nil)
+
+type matrixBuild struct {
+	db.Build
+}
+
+func (m *matrixBuild) IsReadyToDetermineInputs(logger lager.Logger) (bool, error) {
+	return true, nil
+}
+
+func (m *matrixBuild) BuildInputs(ctx context.Context) ([]db.BuildInput, bool, error) {
+	buildInputs, inputsReady, err := m.AdoptMatrixInputsAndPipes()
+	if err != nil {
+		return nil, false, fmt.Errorf("adopt matrix inputs and pipes: %w", err)
+	}
+
+	if !inputsReady {
+		return nil, false, nil
+	}
+
+	return buildInputs, true, nil
+}
@@ -88,16 +88,17 @@ func (s *buildStarter) TryStartPendingBuildsForJob(
 		}
 
 		if !results.scheduled {
-			// If max in flight is reached, stop scheduling and retry later
+			// If max in flight or the concurrency group limit is reached, stop
+			// scheduling and retry later
 			needsRetry = true
 			break
 		}
 
 		if !results.inputsDetermined {
-			if nextSchedulableBuild.RerunOf() != 0 {
-				// If it is a rerun build, continue on to next build. We don't want to
-				// stop scheduling other builds because of a rerun build cannot
-				// determine inputs
+			if nextSchedulableBuild.RerunOf() != 0 || nextSchedulableBuild.ParentBuildID() != 0 {
+				// If it is a rerun build or a cell of a matrix build, continue on to
+				// next build. We don't want to stop scheduling other builds because
+				// of a build which adopts its inputs cannot determine inputs
 				continue
 			} else {
 				// If it is a regular scheduler build, stop scheduling because it is
@@ -125,6 +126,10 @@ func (s *buildStarter) constructBuilds(job db.Job, jobInputs db.InputConfigs, bu
 			buildsToSchedule = append(buildsToSchedule, &rerunBuild{
 				Build: nextPendingBuild,
 			})
+		} else if nextPendingBuild.ParentBuildID() != 0 {
+			buildsToSchedule = append(buildsToSchedule, &matrixBuild{
+				Build: nextPendingBuild,
+			})
 		} else {
 			buildsToSchedule = append(buildsToSchedule, &schedulerBuild{
 				Build: nextPendingBuild,
@@ -298,7 +303,16 @@ func (s *buildStarter) tryStartNextPendingBuild(
 		return startResults{}, fmt.Errorf("config: %w", err)
 	}
 
-	plan, err := s.planner.Create(config.StepConfig(), job.Resources, job.ResourceTypes, job.Prototypes, buildInputs, nextPendingBuild.IsManuallyTriggered(), config.Tags)
+	if len(config.Matrix) != 0 && nextPendingBuild.ParentBuildID() == 0 {
+		return s.startMatrixBuild(logger, nextPendingBuild, config)
+	}
+
+	stepConfig := config.StepConfig()
+	if nextPendingBuild.ParentBuildID() != 0 {
+		stepConfig = config.MatrixStepConfig(nextPendingBuild.MatrixValues())
+	}
+
+	plan, err := s.planner.Create(stepConfig, job.Resources, job.ResourceTypes, job.Prototypes, buildInputs, nextPendingBuild.IsManuallyTriggered(), config.Tags)
 	if err != nil {
 		logger.Error("failed-to-create-build-plan", err)
 
@@ -341,3 +355,31 @@ func (s *buildStarter) tryStartNextPendingBuild(
 		finished: true,
 	}, nil
 }
+
+// startMatrixBuild starts a build of a job with a matrix as the parent of a
+// build for each combination of the matrix. The cells are scheduled as
+// pending builds of the job and adopt the inputs of their parent.
+func (s *buildStarter) startMatrixBuild(logger lager.Logger, build Build, config atc.JobConfig) (startResults, error) {
+	started, err := build.StartMatrix(config.MatrixCombinations())
+	if err != nil {
+		logger.Error("failed-to-start-matrix-build", err)
+		return startResults{}, fmt.Errorf("start matrix build: %w", err)
+	}
+
+	if !started {
+		if err = build.Finish(db.BuildStatusAborted); err != nil {
+			logger.Error("failed-to-mark-build-as-finished", err)
+			return startResults{}, fmt.Errorf("finish build: %w", err)
+		}
+
+		return startResults{
+			finished: true,
+		}, nil
+	}
+
+	metric.Metrics.BuildsStarted.Inc()
+
+	return startResults{
+		finished: true,
+	}, nil
+}
@@ -603,6 +603,82 @@ var _ = Describe("BuildStarter", func() {
 					})
 				}
 
+				Context("when the job has a matrix", func() {
+					var parentBuild, cellBuild *dbfakes.FakeBuild
+					var matrixJobConfig atc.JobConfig
+
+					BeforeEach(func() {
+						matrixJobConfig = jobConfig
+						matrixJobConfig.Matrix = []atc.MatrixVarConfig{
+							{Var: "go", Values: []any{"1.21", "1.22"}},
+						}
+						job.ConfigReturns(matrixJobConfig, nil)
+						job.ScheduleBuildReturns(true, nil)
+
+						parentBuild = new(dbfakes.FakeBuild)
+						parentBuild.IDReturns(99)
+						parentBuild.NonTriggeringResourcesCheckedReturns(true, nil)
+						parentBuild.AdoptInputsAndPipesReturns([]db.BuildInput{}, true, nil)
+						parentBuild.StartMatrixReturns(true, nil)
+
+						cellBuild = new(dbfakes.FakeBuild)
+						cellBuild.IDReturns(100)
+						cellBuild.ParentBuildIDReturns(99)
+						cellBuild.MatrixValuesReturns(atc.MatrixValues{"go": "1.22"})
+						cellBuild.AdoptMatrixInputsAndPipesReturns([]db.BuildInput{}, true, nil)
+						cellBuild.StartReturns(true, nil)
+
+						fakePlanner.CreateReturns(plannedPlan, nil)
+						job.GetPendingBuildsReturns([]db.Build{parentBuild, cellBuild}, nil)
+					})
+
+					It("starts the parent with a cell for each combination", func() {
+						Expect(tryStartErr).ToNot(HaveOccurred())
+						Expect(parentBuild.StartMatrixCallCount()).To(Equal(1))
+						Expect(parentBuild.StartMatrixArgsForCall(0)).To(Equal([]atc.MatrixValues{
+							{"go": "1.21"},
+							{"go": "1.22"},
+						}))
+						Expect(parentBuild.StartCallCount()).To(Equal(0))
+					})
+
+					It("starts the cell with its matrix values bound", func() {
+						Expect(cellBuild.AdoptMatrixInputsAndPipesCallCount()).To(Equal(1))
+						Expect(cellBuild.AdoptInputsAndPipesCallCount()).To(Equal(0))
+
+						Expect(fakePlanner.CreateCallCount()).To(Equal(1))
+						stepConfig, _, _, _, _, _, _ := fakePlanner.CreateArgsForCall(0)
+						Expect(stepConfig).To(Equal(matrixJobConfig.MatrixStepConfig(atc.MatrixValues{"go": "1.22"})))
+
+						Expect(cellBuild.StartCallCount()).To(Equal(1))
+						Expect(cellBuild.StartArgsForCall(0)).To(Equal(plannedPlan))
+					})
+
+					Context("when the parent is not started", func() {
+						BeforeEach(func() {
+							parentBuild.StartMatrixReturns(false, nil)
+						})
+
+						It("finishes the parent with aborted status", func() {
+							Expect(tryStartErr).ToNot(HaveOccurred())
+							Expect(parentBuild.FinishCallCount()).To(Equal(1))
+							Expect(parentBuild.FinishArgsForCall(0)).To(Equal(db.BuildStatusAborted))
+						})
+					})
+
+					Context("when the cell has no satisfiable inputs", func() {
+						BeforeEach(func() {
+							cellBuild.AdoptMatrixInputsAndPipesReturns(nil, false, nil)
+						})
+
+						It("continues on to the next build", func() {
+							Expect(tryStartErr).ToNot(HaveOccurred())
+							Expect(needsReschedule).To(BeFalse())
+							Expect(cellBuild.StartCallCount()).To(Equal(0))
+						})
+					})
+				})
+
 				Context("when the stars align", func() {
 					BeforeEach(func() {
 						job.PausedReturns(false)
@@ -8,6 +8,7 @@ import (
 
 	"code.cloudfoundry.org/lager/v3"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/fairshare"
 	"github.com/concourse/concourse/atc/metric"
 	"github.com/concourse/concourse/atc/util"
 	"github.com/concourse/concourse/tracing"
@@ -27,19 +28,27 @@ type Runner struct {
 	logger     lager.Logger
 	jobFactory db.JobFactory
 	scheduler  BuildScheduler
+	policy     fairshare.Policy
 
 	guardJobScheduling chan struct{}
 	running            *sync.Map
+
+	// teams which had pending builds as of the last run, so that their
+	// queue depth can be reset once they have none
+	pendingTeams map[string]bool
 }
 
-func NewRunner(logger lager.Logger, jobFactory db.JobFactory, scheduler BuildScheduler, maxJobs uint64) *Runner {
+func NewRunner(logger lager.Logger, jobFactory db.JobFactory, scheduler BuildScheduler, maxJobs uint64, policy fairshare.Policy) *Runner {
 	return &Runner{
 		logger:     logger,
 		jobFactory: jobFactory,
 		scheduler:  scheduler,
+		policy:     policy,
 
 		guardJobScheduling: make(chan struct{}, maxJobs),
 		running:            &sync.Map{},
+
+		pendingTeams: map[string]bool{},
 	}
 }
 
@@ -56,7 +65,34 @@ func (s *Runner) Run(ctx context.Context) error {
 		return fmt.Errorf("find jobs to schedule: %w", err)
 	}
 
-	for _, j := range jobs {
+	queues, err := s.jobFactory.TeamBuildQueues()
+	if err != nil {
+		return fmt.Errorf("find team build queues: %w", err)
+	}
+
+	runningBuilds := s.emitQueueDepths(sLog, queues)
+
+	// Jobs are scheduled in fair share order, so that when there are more
+	// jobs than can be scheduled at once, a team with a lot of builds running
+	// cannot hold back the jobs of the other teams.
+	//
+	// This only decides which jobs are scheduled first within a run. Builds
+	// are started independently of each other, limited only by each job's
+	// max_in_flight, so while there are no more jobs than
+	// JobSchedulingMaxInFlight the order makes no difference. Fair share is
+	// enforced where teams actually compete, which is when their steps are
+	// placed on workers (see worker.Pool).
+	entries := make([]fairshare.Entry, len(jobs))
+	for i, j := range jobs {
+		entries[i] = fairshare.Entry{
+			Team:     j.TeamName(),
+			Priority: j.Priority(),
+		}
+	}
+
+	for _, i := range s.policy.Order(entries, runningBuilds) {
+		j := jobs[i]
+
 		if _, exists := s.running.LoadOrStore(j.ID(), true); exists {
 			// already scheduling this job
 			continue
@@ -101,6 +137,36 @@ func (s *Runner) Run(ctx context.Context) error {
 	return nil
 }
 
+func (s *Runner) emitQueueDepths(logger lager.Logger, queues []db.TeamBuildQueue) map[string]int {
+	runningBuilds := map[string]int{}
+	pendingTeams := map[string]bool{}
+
+	for _, queue := range queues {
+		runningBuilds[queue.TeamName] = queue.Running
+
+		if queue.Pending > 0 {
+			pendingTeams[queue.TeamName] = true
+		}
+
+		if queue.Pending > 0 || s.pendingTeams[queue.TeamName] {
+			metric.BuildsPending{
+				TeamName: queue.TeamName,
+				Count:    queue.Pending,
+			}.Emit(logger)
+		}
+	}
+
+	for team := range s.pendingTeams {
+		if _, found := runningBuilds[team]; !found {
+			metric.BuildsPending{TeamName: team}.Emit(logger)
+		}
+	}
+
+	s.pendingTeams = pendingTeams
+
+	return runningBuilds
+}
+
 func (s *Runner) scheduleJob(ctx context.Context, logger lager.Logger, job db.SchedulerJob) error {
 	metric.Metrics.JobsScheduling.Inc()
 	defer metric.Metrics.JobsScheduling.Dec()
@@ -12,6 +12,7 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/component"
 	"github.com/concourse/concourse/atc/db/lock/lockfakes"
+	"github.com/concourse/concourse/atc/fairshare"
 	. "github.com/concourse/concourse/atc/scheduler"
 	"github.com/concourse/concourse/atc/scheduler/schedulerfakes"
 
@@ -26,6 +27,7 @@ var _ = Describe("Runner", func() {
 		fakePipeline  *dbfakes.FakePipeline
 		fakeScheduler *schedulerfakes.FakeBuildScheduler
 		maxInFlight   uint64
+		policy        fairshare.Policy
 
 		lock *lockfakes.FakeLock
 
@@ -46,6 +48,7 @@ var _ = Describe("Runner", func() {
 		fakeScheduler = new(schedulerfakes.FakeBuildScheduler)
 		fakeJobFactory = new(dbfakes.FakeJobFactory)
 		maxInFlight = 1
+		policy = fairshare.Policy{}
 
 		lock = new(lockfakes.FakeLock)
 	})
@@ -56,6 +59,7 @@ var _ = Describe("Runner", func() {
 			fakeJobFactory,
 			fakeScheduler,
 			maxInFlight,
+			policy,
 		)
 
 		schedulerErr = schedulerRunner.Run(context.TODO())
@@ -540,6 +544,71 @@ var _ = Describe("Runner", func() {
 		})
 	})
 
+	Context("when jobs of several teams need to be scheduled", func() {
+		var scheduled []string
+
+		job := func(id int, name string, team string, priority int) db.SchedulerJob {
+			fakeJob := new(dbfakes.FakeJob)
+			fakeJob.IDReturns(id)
+			fakeJob.NameReturns(name)
+			fakeJob.TeamNameReturns(team)
+			fakeJob.PriorityReturns(priority)
+			fakeJob.ReloadReturns(true, nil)
+			fakeJob.AcquireSchedulingLockReturns(lock, true, nil)
+			return db.SchedulerJob{Job: fakeJob}
+		}
+
+		BeforeEach(func() {
+			scheduled = nil
+
+			var scheduledLock sync.Mutex
+			fakeScheduler.ScheduleStub = func(_ context.Context, _ lager.Logger, job db.SchedulerJob) (bool, error) {
+				scheduledLock.Lock()
+				defer scheduledLock.Unlock()
+				scheduled = append(scheduled, job.Name())
+				return false, nil
+			}
+
+			fakeJobFactory.JobsToScheduleReturns([]db.SchedulerJob{
+				job(1, "busy-job", "busy-team", 0),
+				job(2, "urgent-busy-job", "busy-team", 10),
+				job(3, "quiet-job", "quiet-team", 0),
+			}, nil)
+
+			fakeJobFactory.TeamBuildQueuesReturns([]db.TeamBuildQueue{
+				{TeamName: "busy-team", Pending: 5, Running: 2},
+			}, nil)
+		})
+
+		It("schedules the jobs in fair share order", func() {
+			Expect(schedulerErr).ToNot(HaveOccurred())
+			Eventually(fakeScheduler.ScheduleCallCount).Should(Equal(3))
+			Expect(scheduled).To(Equal([]string{"quiet-job", "urgent-busy-job", "busy-job"}))
+		})
+
+		Context("when the busy team has a larger share", func() {
+			BeforeEach(func() {
+				policy = fairshare.Policy{Weights: map[string]float64{"busy-team": 4}}
+			})
+
+			It("schedules its jobs first", func() {
+				Expect(schedulerErr).ToNot(HaveOccurred())
+				Eventually(fakeScheduler.ScheduleCallCount).Should(Equal(3))
+				Expect(scheduled).To(Equal([]string{"urgent-busy-job", "busy-job", "quiet-job"}))
+			})
+		})
+	})
+
+	Context("when finding team build queues fails", func() {
+		BeforeEach(func() {
+			fakeJobFactory.TeamBuildQueuesReturns(nil, errors.New("disaster"))
+		})
+
+		It("returns an error", func() {
+			Expect(schedulerErr).To(Equal(fmt.Errorf("find team build queues: %w", errors.New("disaster"))))
+		})
+	})
+
 	Context("when finding jobs to schedule fails", func() {
 		BeforeEach(func() {
 			fakeJobFactory.JobsToScheduleReturns(nil, errors.New("disaster"))
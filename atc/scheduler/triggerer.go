@@ -0,0 +1,90 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager/v3"
+	"code.cloudfoundry.org/lager/v3/lagerctx"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// Triggerer creates builds for jobs whose schedule is due. The builds are
+// then started by the scheduler like manually triggered builds.
+type Triggerer struct {
+	jobFactory db.JobFactory
+	clock      clock.Clock
+}
+
+func NewTriggerer(jobFactory db.JobFactory, clock clock.Clock) *Triggerer {
+	return &Triggerer{
+		jobFactory: jobFactory,
+		clock:      clock,
+	}
+}
+
+func (t *Triggerer) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("triggerer")
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	jobs, err := t.jobFactory.JobsToTrigger()
+	if err != nil {
+		return fmt.Errorf("find jobs to trigger: %w", err)
+	}
+
+	for _, job := range jobs {
+		jLog := logger.Session("job", lager.Data{
+			"team":     job.TeamName(),
+			"pipeline": job.PipelineName(),
+			"job":      job.Name(),
+		})
+
+		err := t.trigger(jLog, job)
+		if err != nil {
+			jLog.Error("failed-to-trigger-job", err)
+		}
+	}
+
+	return nil
+}
+
+func (t *Triggerer) trigger(logger lager.Logger, job db.Job) error {
+	config, err := job.Config()
+	if err != nil {
+		return fmt.Errorf("get job config: %w", err)
+	}
+
+	if config.Schedule == nil {
+		return nil
+	}
+
+	// missed triggers, e.g. while the ATC was down, only result in a single
+	// build as the next trigger is always after the current time
+	nextTrigger, err := config.Schedule.NextTrigger(t.clock.Now())
+	if err != nil {
+		return fmt.Errorf("get next trigger: %w", err)
+	}
+
+	if job.Paused() || job.PipelineIsPaused() {
+		_, err := job.SkipScheduledTrigger(nextTrigger)
+		if err != nil {
+			return fmt.Errorf("skip scheduled trigger: %w", err)
+		}
+
+		logger.Debug("skipped-paused-job", lager.Data{"next-trigger": nextTrigger})
+		return nil
+	}
+
+	build, created, err := job.CreateScheduledBuild(nextTrigger)
+	if err != nil {
+		return fmt.Errorf("create scheduled build: %w", err)
+	}
+
+	if created {
+		logger.Info("triggered", lager.Data{"build": build.Name(), "next-trigger": nextTrigger})
+	}
+
+	return nil
+}
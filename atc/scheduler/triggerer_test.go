@@ -0,0 +1,119 @@
+package scheduler_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	. "github.com/concourse/concourse/atc/scheduler"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Triggerer", func() {
+	var (
+		fakeJobFactory *dbfakes.FakeJobFactory
+		fakeJob        *dbfakes.FakeJob
+		fakeClock      *fakeclock.FakeClock
+
+		runErr error
+	)
+
+	BeforeEach(func() {
+		fakeClock = fakeclock.NewFakeClock(time.Date(2024, 3, 5, 2, 0, 10, 0, time.UTC))
+
+		fakeJob = new(dbfakes.FakeJob)
+		fakeJob.NameReturns("nightly")
+		fakeJob.ConfigReturns(atc.JobConfig{
+			Name:     "nightly",
+			Schedule: &atc.JobScheduleConfig{Cron: "0 2 * * *"},
+		}, nil)
+
+		fakeBuild := new(dbfakes.FakeBuild)
+		fakeBuild.NameReturns("42")
+		fakeJob.CreateScheduledBuildReturns(fakeBuild, true, nil)
+
+		fakeJobFactory = new(dbfakes.FakeJobFactory)
+		fakeJobFactory.JobsToTriggerReturns(db.Jobs{fakeJob}, nil)
+	})
+
+	JustBeforeEach(func() {
+		runErr = NewTriggerer(fakeJobFactory, fakeClock).Run(context.TODO())
+	})
+
+	It("creates a scheduled build and moves on to the next trigger", func() {
+		Expect(runErr).ToNot(HaveOccurred())
+		Expect(fakeJob.CreateScheduledBuildCallCount()).To(Equal(1))
+		Expect(fakeJob.CreateScheduledBuildArgsForCall(0)).To(Equal(time.Date(2024, 3, 6, 2, 0, 0, 0, time.UTC)))
+		Expect(fakeJob.SkipScheduledTriggerCallCount()).To(BeZero())
+	})
+
+	Context("when the job is paused", func() {
+		BeforeEach(func() {
+			fakeJob.PausedReturns(true)
+		})
+
+		It("skips the trigger", func() {
+			Expect(fakeJob.CreateScheduledBuildCallCount()).To(BeZero())
+			Expect(fakeJob.SkipScheduledTriggerCallCount()).To(Equal(1))
+			Expect(fakeJob.SkipScheduledTriggerArgsForCall(0)).To(Equal(time.Date(2024, 3, 6, 2, 0, 0, 0, time.UTC)))
+		})
+	})
+
+	Context("when the pipeline is paused", func() {
+		BeforeEach(func() {
+			fakeJob.PipelineIsPausedReturns(true)
+		})
+
+		It("skips the trigger", func() {
+			Expect(fakeJob.CreateScheduledBuildCallCount()).To(BeZero())
+			Expect(fakeJob.SkipScheduledTriggerCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when the job no longer has a schedule", func() {
+		BeforeEach(func() {
+			fakeJob.ConfigReturns(atc.JobConfig{Name: "nightly"}, nil)
+		})
+
+		It("does nothing", func() {
+			Expect(fakeJob.CreateScheduledBuildCallCount()).To(BeZero())
+			Expect(fakeJob.SkipScheduledTriggerCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when triggering a job fails", func() {
+		var otherJob *dbfakes.FakeJob
+
+		BeforeEach(func() {
+			fakeJob.CreateScheduledBuildReturns(nil, false, errors.New("nope"))
+
+			otherJob = new(dbfakes.FakeJob)
+			otherJob.ConfigReturns(atc.JobConfig{
+				Name:     "hourly",
+				Schedule: &atc.JobScheduleConfig{Cron: "@hourly"},
+			}, nil)
+
+			fakeJobFactory.JobsToTriggerReturns(db.Jobs{fakeJob, otherJob}, nil)
+		})
+
+		It("carries on with the other jobs", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(otherJob.CreateScheduledBuildCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when finding jobs to trigger fails", func() {
+		BeforeEach(func() {
+			fakeJobFactory.JobsToTriggerReturns(nil, errors.New("nope"))
+		})
+
+		It("returns the error", func() {
+			Expect(runErr).To(MatchError(ContainSubstring("nope")))
+		})
+	})
+})
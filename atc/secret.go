@@ -0,0 +1,40 @@
+package atc
+
+// Secret describes a secret stored by the postgres credential manager. Its
+// value is never returned by the API.
+type Secret struct {
+	Name      string `json:"name"`
+	Pipeline  string `json:"pipeline,omitempty"`
+	UpdatedBy string `json:"updated_by,omitempty"`
+	UpdatedAt int64  `json:"updated_at"`
+}
+
+type SetSecretRequest struct {
+	Value string `json:"value"`
+}
+
+// SecretUsage lists where a var is referenced by a pipeline's config and
+// which of its jobs last resolved it. Vars resolved by a team's one-off builds
+// are reported without a pipeline.
+type SecretUsage struct {
+	Var                  string            `json:"var"`
+	TeamName             string            `json:"team_name"`
+	PipelineID           int               `json:"pipeline_id,omitempty"`
+	PipelineName         string            `json:"pipeline_name,omitempty"`
+	PipelineInstanceVars InstanceVars      `json:"pipeline_instance_vars,omitempty"`
+	Jobs                 []string          `json:"jobs,omitempty"`
+	Resources            []string          `json:"resources,omitempty"`
+	ResourceTypes        []string          `json:"resource_types,omitempty"`
+	Prototypes           []string          `json:"prototypes,omitempty"`
+	VarSources           []string          `json:"var_sources,omitempty"`
+	LastBuild            *SecretUsageBuild `json:"last_build,omitempty"`
+}
+
+// SecretUsageBuild is the last build which resolved a var. ID is zero once
+// the build has been reaped.
+type SecretUsageBuild struct {
+	ID         int    `json:"id,omitempty"`
+	Name       string `json:"name"`
+	JobName    string `json:"job_name,omitempty"`
+	ResolvedAt int64  `json:"resolved_at"`
+}
@@ -3,8 +3,11 @@ package atc
 import (
 	"fmt"
 	"path"
+	"slices"
 	"strings"
 	"time"
+
+	"github.com/concourse/concourse/atc/expr"
 )
 
 // StepValidator is a StepVisitor which validates each step that visits it,
@@ -28,6 +31,13 @@ type StepValidator struct {
 
 	seenGetName    scope
 	localVarScopes []scope
+
+	// stepNameScopes holds the names of the steps seen so far in each local
+	// scope, mirroring the scopes in which step statuses are stored at
+	// runtime. endedStepNames holds the names seen in scopes which have since
+	// ended, whose statuses are not visible to the steps that follow.
+	stepNameScopes []scope
+	endedStepNames scope
 }
 
 type scope map[string]bool
@@ -46,6 +56,8 @@ func NewStepValidator(config Config, context []string) *StepValidator {
 		context:        context,
 		seenGetName:    scope{},
 		localVarScopes: []scope{{}},
+		stepNameScopes: []scope{{}},
+		endedStepNames: scope{},
 	}
 }
 
@@ -65,6 +77,8 @@ func (validator *StepValidator) VisitTask(plan *TaskStep) error {
 	validator.pushContextf(".task(%s)", plan.Name)
 	defer validator.popContext()
 
+	validator.declareStepName(plan.Name)
+
 	warning, err := ValidateIdentifier(plan.Name, validator.context...)
 	if err != nil {
 		validator.recordError(err.Error())
@@ -95,6 +109,8 @@ func (validator *StepValidator) VisitTask(plan *TaskStep) error {
 		})
 	}
 
+	validator.validateIDToken(plan.IDToken)
+
 	if plan.Config != nil {
 		validator.pushContext(".config")
 
@@ -126,6 +142,8 @@ func (validator *StepValidator) VisitGet(step *GetStep) error {
 	validator.pushContextf(".get(%s)", step.Name)
 	defer validator.popContext()
 
+	validator.declareStepName(step.Name)
+
 	warning, err := ValidateIdentifier(step.Name, validator.context...)
 	if err != nil {
 		validator.recordError(err.Error())
@@ -180,6 +198,16 @@ func (validator *StepValidator) VisitGet(step *GetStep) error {
 			}
 		}
 
+		if !foundJob && strings.Contains(jobGlob, "/") {
+			// jobs in other pipelines are looked up when the pipeline is saved
+			_, err := ParsePassedJob(jobGlob)
+			if err != nil {
+				validator.recordError(err.Error())
+			}
+
+			continue
+		}
+
 		if !foundJob {
 			validator.recordErrorf("no matching job(s) for '%s'", jobGlob)
 		}
@@ -187,6 +215,19 @@ func (validator *StepValidator) VisitGet(step *GetStep) error {
 
 	validator.popContext()
 
+	if step.Version != nil && step.Version.Filter != nil {
+		validator.pushContext(".version.filter")
+
+		err := step.Version.Filter.Validate()
+		if err != nil {
+			for _, message := range strings.Split(err.Error(), "\n") {
+				validator.recordError(message)
+			}
+		}
+
+		validator.popContext()
+	}
+
 	return nil
 }
 
@@ -194,6 +235,8 @@ func (validator *StepValidator) VisitPut(step *PutStep) error {
 	validator.pushContextf(".put(%s)", step.Name)
 	defer validator.popContext()
 
+	validator.declareStepName(step.Name)
+
 	warning, err := ValidateIdentifier(step.Name, validator.context...)
 	if err != nil {
 		validator.recordError(err.Error())
@@ -232,13 +275,34 @@ func (validator *StepValidator) VisitRun(step *RunStep) error {
 		validator.recordErrorf("unknown prototype '%s'", step.Type)
 	}
 
+	// run steps do not run containers yet, so there is nothing to hand the
+	// token to
+	if step.IDToken != nil {
+		validator.recordError("id_token is not supported on run steps yet")
+	}
+
 	return nil
 }
 
+func (validator *StepValidator) validateIDToken(config *IDTokenConfig) {
+	if config == nil {
+		return
+	}
+
+	validator.pushContext(".id_token")
+	defer validator.popContext()
+
+	for _, msg := range config.Validate() {
+		validator.recordError(msg)
+	}
+}
+
 func (validator *StepValidator) VisitSetPipeline(step *SetPipelineStep) error {
 	validator.pushContextf(".set_pipeline(%s)", step.Name)
 	defer validator.popContext()
 
+	validator.declareStepName(step.Name)
+
 	warning, err := ValidateIdentifier(step.Name, validator.context...)
 	if err != nil {
 		validator.recordError(err.Error())
@@ -258,6 +322,8 @@ func (validator *StepValidator) VisitLoadVar(step *LoadVarStep) error {
 	validator.pushContextf(".load_var(%s)", step.Name)
 	defer validator.popContext()
 
+	validator.declareStepName(step.Name)
+
 	warning, err := ValidateIdentifier(step.Name, validator.context...)
 	if err != nil {
 		validator.recordError(err.Error())
@@ -275,6 +341,55 @@ func (validator *StepValidator) VisitLoadVar(step *LoadVarStep) error {
 	return nil
 }
 
+// approverRoles are the roles allowed to approve and reject steps.
+var approverRoles = []string{"owner", "member", "pipeline-operator"}
+
+func (validator *StepValidator) VisitApprove(step *ApproveStep) error {
+	validator.pushContextf(".approve(%s)", step.Name)
+	defer validator.popContext()
+
+	validator.declareStepName(step.Name)
+
+	warning, err := ValidateIdentifier(step.Name, validator.context...)
+	if err != nil {
+		validator.recordError(err.Error())
+	}
+	if warning != nil {
+		validator.recordWarning(*warning)
+	}
+
+	for _, user := range step.Users {
+		if user == "" {
+			validator.recordError("users must not contain an empty name")
+			break
+		}
+
+		if !strings.Contains(user, ":") {
+			validator.recordErrorf("user '%s' must be qualified by the connector it logs in with, e.g. 'github:%s'", user, user)
+		}
+	}
+
+	for _, role := range step.Roles {
+		if role == "" {
+			validator.recordError("roles must not contain an empty role")
+			break
+		}
+
+		if !slices.Contains(approverRoles, role) {
+			validator.recordErrorf("role '%s' cannot approve steps, roles must be one of: %s", role, strings.Join(approverRoles, ", "))
+		}
+	}
+
+	if step.Timeout != "" {
+		_, err := time.ParseDuration(step.Timeout)
+		if err != nil {
+			validator.recordErrorf("invalid timeout '%s'", step.Timeout)
+		}
+	}
+
+	return nil
+}
+
 func (validator *StepValidator) VisitTry(step *TryStep) error {
 	validator.pushContext(".try")
 	defer validator.popContext()
@@ -325,6 +440,9 @@ func (validator *StepValidator) VisitAcross(step *AcrossStep) error {
 	validator.pushLocalVarScope()
 	defer validator.popLocalVarScope()
 
+	validator.pushStepNameScope()
+	defer validator.popStepNameScope()
+
 	if len(step.Vars) == 0 {
 		validator.recordError("no vars specified")
 	}
@@ -362,6 +480,16 @@ func (validator *StepValidator) VisitTimeout(step *TimeoutStep) error {
 	return nil
 }
 
+// VisitIf validates the condition before visiting the wrapped step, since the
+// condition can only refer to steps which run before it.
+func (validator *StepValidator) VisitIf(step *IfStep) error {
+	validator.pushContext(".if")
+	validator.validateCondition(step.Condition)
+	validator.popContext()
+
+	return step.Step.Visit(validator)
+}
+
 func (validator *StepValidator) VisitRetry(step *RetryStep) error {
 	err := step.Step.Visit(validator)
 	if err != nil {
@@ -454,6 +582,52 @@ func (validator *StepValidator) annotate(message string) string {
 	return fmt.Sprintf("%s: %s", strings.Join(validator.context, ""), message)
 }
 
+var conditionBuildFields = []string{"id", "name", "job_name", "pipeline_name", "team_name"}
+
+var conditionStepFields = []string{"status", "succeeded"}
+
+func (validator *StepValidator) validateCondition(condition string) {
+	expression, err := expr.Parse(condition)
+	if err != nil {
+		validator.recordError(err.Error())
+		return
+	}
+
+	for _, ref := range expression.References() {
+		switch ref[0] {
+		case "vars", "instance_vars":
+			if len(ref) < 2 {
+				validator.recordErrorf("invalid reference '%s': must name a var, e.g. '%s.foo'", ref, ref[0])
+			}
+		case "build":
+			if len(ref) != 2 || !slices.Contains(conditionBuildFields, ref[1]) {
+				validator.recordErrorf("invalid reference '%s': must be one of %s", ref, fieldList("build", conditionBuildFields))
+			}
+		case "steps":
+			if len(ref) != 3 || !slices.Contains(conditionStepFields, ref[2]) {
+				validator.recordErrorf("invalid reference '%s': must be one of %s, where NAME is a step which runs before this one", ref, fieldList("steps.NAME", conditionStepFields))
+			} else if !validator.stepNameIsDeclared(ref[1]) {
+				if validator.endedStepNames[ref[1]] {
+					validator.recordErrorf("step '%s' runs within an across step, so its status is only visible to steps within the same across step", ref[1])
+				} else {
+					validator.recordErrorf("unknown step '%s': the condition can only refer to steps which run before it", ref[1])
+				}
+			}
+		default:
+			validator.recordErrorf("invalid reference '%s': must start with 'vars', 'instance_vars', 'build', or 'steps'", ref)
+		}
+	}
+}
+
+func fieldList(prefix string, fields []string) string {
+	refs := make([]string, len(fields))
+	for i, field := range fields {
+		refs[i] = fmt.Sprintf("'%s.%s'", prefix, field)
+	}
+
+	return strings.Join(refs, ", ")
+}
+
 func (validator *StepValidator) pushContext(ctx string) {
 	validator.context = append(validator.context, ctx)
 }
@@ -487,6 +661,31 @@ func (validator *StepValidator) localVarIsDeclared(name string) bool {
 	return false
 }
 
+func (validator *StepValidator) pushStepNameScope() {
+	validator.stepNameScopes = append(validator.stepNameScopes, scope{})
+}
+
+func (validator *StepValidator) popStepNameScope() {
+	for name := range validator.stepNameScopes[len(validator.stepNameScopes)-1] {
+		validator.endedStepNames[name] = true
+	}
+
+	validator.stepNameScopes = validator.stepNameScopes[0 : len(validator.stepNameScopes)-1]
+}
+
+func (validator *StepValidator) declareStepName(name string) {
+	validator.stepNameScopes[len(validator.stepNameScopes)-1][name] = true
+}
+
+func (validator *StepValidator) stepNameIsDeclared(name string) bool {
+	for _, scope := range validator.stepNameScopes {
+		if scope[name] {
+			return true
+		}
+	}
+	return false
+}
+
 func (validator *StepValidator) declareLocalVar(name string) {
 	if validator.currentLocalVarScope()[name] {
 		validator.recordError("repeated var name")
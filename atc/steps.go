@@ -193,12 +193,14 @@ type StepVisitor interface {
 	VisitRun(*RunStep) error
 	VisitSetPipeline(*SetPipelineStep) error
 	VisitLoadVar(*LoadVarStep) error
+	VisitApprove(*ApproveStep) error
 	VisitTry(*TryStep) error
 	VisitDo(*DoStep) error
 	VisitInParallel(*InParallelStep) error
 	VisitAcross(*AcrossStep) error
 	VisitTimeout(*TimeoutStep) error
 	VisitRetry(*RetryStep) error
+	VisitIf(*IfStep) error
 	VisitOnSuccess(*OnSuccessStep) error
 	VisitOnFailure(*OnFailureStep) error
 	VisitOnAbort(*OnAbortStep) error
@@ -221,6 +223,10 @@ type StepDetector struct {
 // some important inter-modifier precedence - while core step types are parsed
 // last.
 var StepPrecedence = []StepDetector{
+	{
+		Key: "if",
+		New: func() StepConfig { return &IfStep{} },
+	},
 	{
 		Key: "ensure",
 		New: func() StepConfig { return &EnsureStep{} },
@@ -265,6 +271,10 @@ var StepPrecedence = []StepDetector{
 		Key: "get",
 		New: func() StepConfig { return &GetStep{} },
 	},
+	{
+		Key: "approve",
+		New: func() StepConfig { return &ApproveStep{} },
+	},
 	{
 		Key: "timeout",
 		New: func() StepConfig { return &TimeoutStep{} },
@@ -351,6 +361,7 @@ type TaskStep struct {
 	OutputMapping     map[string]string `json:"output_mapping,omitempty"`
 	ImageArtifactName string            `json:"image,omitempty"`
 	Timeout           string            `json:"timeout,omitempty"`
+	IDToken           *IDTokenConfig    `json:"id_token,omitempty"`
 }
 
 func (step *TaskStep) Visit(v StepVisitor) error {
@@ -365,6 +376,7 @@ type RunStep struct {
 	Tags       Tags             `json:"tags,omitempty"`
 	Limits     *ContainerLimits `json:"container_limits,omitempty"`
 	Timeout    string           `json:"timeout,omitempty"`
+	IDToken    *IDTokenConfig   `json:"id_token,omitempty"`
 
 	// XXX(prototypes): inputs, outputs, input_mapping, output_mapping?
 	// see https://github.com/concourse/rfcs/pull/103
@@ -403,6 +415,25 @@ func (step *LoadVarStep) Visit(v StepVisitor) error {
 	return v.VisitLoadVar(step)
 }
 
+type ApproveStep struct {
+	Name string `json:"approve"`
+
+	// Users who may decide the approval, qualified by the connector they log
+	// in with as in team auth, e.g. "github:some-user".
+	Users []string `json:"users,omitempty"`
+
+	// Roles on the build's team which may decide the approval. Only roles
+	// allowed to approve steps at all, i.e. pipeline-operator and above, can
+	// be given.
+	Roles []string `json:"roles,omitempty"`
+
+	Timeout string `json:"timeout,omitempty"`
+}
+
+func (step *ApproveStep) Visit(v StepVisitor) error {
+	return v.VisitApprove(step)
+}
+
 type TryStep struct {
 	Step Step `json:"try"`
 }
@@ -542,6 +573,25 @@ func (step *TimeoutStep) Visit(v StepVisitor) error {
 	return v.VisitTimeout(step)
 }
 
+// IfStep only runs the wrapped step, along with its hooks, when its condition
+// holds. See package expr for the syntax of conditions.
+type IfStep struct {
+	Step      StepConfig `json:"-"`
+	Condition string     `json:"if"`
+}
+
+func (step *IfStep) Wrap(sub StepConfig) {
+	step.Step = sub
+}
+
+func (step *IfStep) Unwrap() StepConfig {
+	return step.Step
+}
+
+func (step *IfStep) Visit(v StepVisitor) error {
+	return v.VisitIf(step)
+}
+
 type OnSuccessStep struct {
 	Step StepConfig `json:"-"`
 	Hook Step       `json:"on_success"`
@@ -676,11 +726,13 @@ func (c *MaxInFlightConfig) EffectiveLimit(numSteps int) int {
 }
 
 // A VersionConfig represents the choice to include every version of a
-// resource, the latest version of a resource, or a pinned (specific) one.
+// resource, the latest version of a resource, a pinned (specific) one, or the
+// latest version matching a filter.
 type VersionConfig struct {
 	Every  bool
 	Latest bool
 	Pinned Version
+	Filter *VersionFilter
 }
 
 const VersionLatest = "latest"
@@ -699,6 +751,28 @@ func (c *VersionConfig) UnmarshalJSON(version []byte) error {
 		c.Every = actual == VersionEvery
 		c.Latest = actual == VersionLatest
 	case map[string]any:
+		if filter, ok := actual["filter"].(map[string]any); ok {
+			if len(actual) != 1 {
+				return errors.New("a version filter cannot be combined with other version fields")
+			}
+
+			payload, err := json.Marshal(filter)
+			if err != nil {
+				return err
+			}
+
+			decoder := json.NewDecoder(bytes.NewReader(payload))
+			decoder.DisallowUnknownFields()
+
+			c.Filter = &VersionFilter{}
+			err = decoder.Decode(c.Filter)
+			if err != nil {
+				return fmt.Errorf("invalid version filter: %w", err)
+			}
+
+			return nil
+		}
+
 		version := Version{}
 
 		for k, v := range actual {
@@ -731,6 +805,10 @@ func (c *VersionConfig) MarshalJSON() ([]byte, error) {
 		return json.Marshal(c.Pinned)
 	}
 
+	if c.Filter != nil {
+		return json.Marshal(map[string]*VersionFilter{"filter": c.Filter})
+	}
+
 	return json.Marshal("")
 }
 
@@ -233,6 +233,23 @@ var factoryTests = []StepTest{
 			Reveal: true,
 		},
 	},
+	{
+		Title: "approve step",
+
+		ConfigYAML: `
+			approve: ship-it
+			users: [github:some-user]
+			roles: [owner]
+			timeout: 1h
+		`,
+
+		StepConfig: &atc.ApproveStep{
+			Name:    "ship-it",
+			Users:   []string{"github:some-user"},
+			Roles:   []string{"owner"},
+			Timeout: "1h",
+		},
+	},
 	{
 		Title: "try step",
 
@@ -494,6 +511,23 @@ var factoryTests = []StepTest{
 			Attempts: 3,
 		},
 	},
+	{
+		Title: "if modifier",
+
+		ConfigYAML: `
+			load_var: some-var
+			file: some-file
+			if: steps.unit.succeeded
+		`,
+
+		StepConfig: &atc.IfStep{
+			Step: &atc.LoadVarStep{
+				Name: "some-var",
+				File: "some-file",
+			},
+			Condition: "steps.unit.succeeded",
+		},
+	},
 	{
 		Title: "precedence of all hooks and modifiers",
 
@@ -520,65 +554,69 @@ var factoryTests = []StepTest{
 			ensure:
 			  load_var: ensure-var
 			  file: ensure-file
+			if: vars.deploy
 		`,
 
-		StepConfig: &atc.EnsureStep{
-			Step: &atc.OnErrorStep{
-				Step: &atc.OnAbortStep{
-					Step: &atc.OnFailureStep{
-						Step: &atc.OnSuccessStep{
-							Step: &atc.AcrossStep{
-								Step: &atc.RetryStep{
-									Step: &atc.TimeoutStep{
-										Step: &atc.LoadVarStep{
-											Name: "some-var",
-											File: "some-file",
+		StepConfig: &atc.IfStep{
+			Condition: "vars.deploy",
+			Step: &atc.EnsureStep{
+				Step: &atc.OnErrorStep{
+					Step: &atc.OnAbortStep{
+						Step: &atc.OnFailureStep{
+							Step: &atc.OnSuccessStep{
+								Step: &atc.AcrossStep{
+									Step: &atc.RetryStep{
+										Step: &atc.TimeoutStep{
+											Step: &atc.LoadVarStep{
+												Name: "some-var",
+												File: "some-file",
+											},
+											Duration: "1h",
+										},
+										Attempts: 3,
+									},
+									Vars: []atc.AcrossVarConfig{
+										{
+											Var:    "version",
+											Values: []any{"v1", "v2", "v3"},
 										},
-										Duration: "1h",
 									},
-									Attempts: 3,
 								},
-								Vars: []atc.AcrossVarConfig{
-									{
-										Var:    "version",
-										Values: []any{"v1", "v2", "v3"},
+								Hook: atc.Step{
+									Config: &atc.LoadVarStep{
+										Name: "success-var",
+										File: "success-file",
 									},
 								},
 							},
 							Hook: atc.Step{
 								Config: &atc.LoadVarStep{
-									Name: "success-var",
-									File: "success-file",
+									Name: "failure-var",
+									File: "failure-file",
 								},
 							},
 						},
 						Hook: atc.Step{
 							Config: &atc.LoadVarStep{
-								Name: "failure-var",
-								File: "failure-file",
+								Name: "abort-var",
+								File: "abort-file",
 							},
 						},
 					},
 					Hook: atc.Step{
 						Config: &atc.LoadVarStep{
-							Name: "abort-var",
-							File: "abort-file",
+							Name: "error-var",
+							File: "error-file",
 						},
 					},
 				},
 				Hook: atc.Step{
 					Config: &atc.LoadVarStep{
-						Name: "error-var",
-						File: "error-file",
+						Name: "ensure-var",
+						File: "ensure-file",
 					},
 				},
 			},
-			Hook: atc.Step{
-				Config: &atc.LoadVarStep{
-					Name: "ensure-var",
-					File: "ensure-file",
-				},
-			},
 		},
 	},
 	{
@@ -19,6 +19,10 @@ type JobSummary struct {
 
 	Groups []string `json:"groups,omitempty"`
 
+	// NextScheduledTrigger is when the job's schedule will next trigger a
+	// build, as a unix timestamp.
+	NextScheduledTrigger int64 `json:"next_scheduled_trigger,omitempty"`
+
 	FinishedBuild   *BuildSummary `json:"finished_build,omitempty"`
 	NextBuild       *BuildSummary `json:"next_build,omitempty"`
 	TransitionBuild *BuildSummary `json:"transition_build,omitempty"`
@@ -252,6 +252,16 @@ func (d *drainer) sendEvent(logger lager.Logger, build db.Build, syslog *Syslog,
 		ts = time.Unix(errorEvent.Time, 0)
 		tag = build.SyslogTag(errorEvent.Origin.ID)
 		message = errorEvent.Message
+	case event.EventTypeSecretsResolved:
+		var secretsResolvedEvent event.SecretsResolved
+		err := json.Unmarshal(*ev.Data, &secretsResolvedEvent)
+		if err != nil {
+			logger.Error("failed-to-unmarshal", err)
+			return err
+		}
+		ts = time.Unix(secretsResolvedEvent.Time, 0)
+		tag = build.SyslogTag(secretsResolvedEvent.Origin.ID)
+		message = resolvedSecretsMessage(secretsResolvedEvent.Secrets)
 	case event.EventTypeStatus:
 		var statusEvent event.Status
 		err := json.Unmarshal(*ev.Data, &statusEvent)
@@ -274,3 +284,22 @@ func (d *drainer) sendEvent(logger lager.Logger, build db.Build, syslog *Syslog,
 
 	return nil
 }
+
+func resolvedSecretsMessage(secrets []event.ResolvedSecret) string {
+	described := make([]string, len(secrets))
+	for i, secret := range secrets {
+		redacted := "redacted"
+		if !secret.Redacted {
+			redacted = "not redacted"
+		}
+
+		location := secret.Path
+		if secret.Manager != "" {
+			location = secret.Manager + ":" + secret.Path
+		}
+
+		described[i] = fmt.Sprintf("%s (%s, %s)", secret.Var, location, redacted)
+	}
+
+	return "resolved secrets: " + strings.Join(described, ", ")
+}
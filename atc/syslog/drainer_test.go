@@ -51,7 +51,14 @@ func newFakeBuild(id int) db.Build {
 		EventID: "5",
 	}, nil)
 
-	fakeEventSource.NextReturnsOnCall(5, event.Envelope{}, db.ErrEndOfBuildEventStream)
+	msg6 := json.RawMessage(`{"time":1533744538,"secrets":[{"var":"github.token","manager":"vault","path":"/concourse/main/github","redacted":true},{"var":"flag","path":"flag","redacted":false}]}`)
+	fakeEventSource.NextReturnsOnCall(5, event.Envelope{
+		Data:    &msg6,
+		Event:   "secrets-resolved",
+		EventID: "6",
+	}, nil)
+
+	fakeEventSource.NextReturnsOnCall(6, event.Envelope{}, db.ErrEndOfBuildEventStream)
 
 	fakeEventSource.NextReturns(event.Envelope{}, db.ErrEndOfBuildEventStream)
 
@@ -94,6 +101,7 @@ var _ = Describe("Drainer", func() {
 				Expect(got).To(ContainSubstring("build 345 status"))
 				Expect(got).To(ContainSubstring("selected worker: example-worker"))
 				Expect(got).To(ContainSubstring("task initializing"))
+				Expect(got).To(ContainSubstring("resolved secrets: github.token (vault:/concourse/main/github, redacted), flag (flag, not redacted)"))
 			}, 0.2)
 		})
 
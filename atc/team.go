@@ -13,6 +13,12 @@ type Team struct {
 	ID   int      `json:"id,omitempty"`
 	Name string   `json:"name,omitempty"`
 	Auth TeamAuth `json:"auth,omitempty"`
+
+	// VarSources are inherited by every pipeline of the team, beneath the
+	// pipeline's own var_sources. When setting a team, nil leaves them as they
+	// are. They are never returned by the API, as their config usually
+	// contains credentials.
+	VarSources *VarSourceConfigs `json:"var_sources,omitempty"`
 }
 
 func (team Team) Validate() error {
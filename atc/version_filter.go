@@ -0,0 +1,164 @@
+package atc
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// VersionFilter restricts the versions of a resource which an input may use
+// to the ones meeting every criterion. The latest of them is used.
+type VersionFilter struct {
+	// Semver is a semver range, e.g. ">= 2.0.0, < 3.0.0", which the version
+	// field named by Field must satisfy.
+	Semver string `json:"semver,omitempty"`
+
+	// Field is the version field Semver applies to. It defaults to the
+	// version's only field.
+	Field string `json:"field,omitempty"`
+
+	// Match maps version fields to regular expressions they must match.
+	Match map[string]string `json:"match,omitempty"`
+
+	// Metadata maps the names of metadata fields to regular expressions
+	// their values must match.
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+type compiledVersionFilter struct {
+	semver   *semver.Constraints
+	field    string
+	match    map[string]*regexp.Regexp
+	metadata map[string]*regexp.Regexp
+}
+
+// Validate returns an error describing every criterion which is invalid.
+func (filter VersionFilter) Validate() error {
+	_, err := filter.compile()
+	return err
+}
+
+// Matches reports whether the version and its metadata meet every criterion
+// of the filter. An invalid filter matches nothing.
+func (filter VersionFilter) Matches(version Version, metadata Metadata) bool {
+	compiled, err := filter.compile()
+	if err != nil {
+		return false
+	}
+
+	return compiled.matches(version, metadata)
+}
+
+// MatchingVersion returns the first of the versions which is enabled and
+// matches the filter, given versions ordered from newest to oldest.
+func (filter VersionFilter) MatchingVersion(versions []ResourceVersion) (ResourceVersion, bool) {
+	compiled, err := filter.compile()
+	if err != nil {
+		return ResourceVersion{}, false
+	}
+
+	for _, version := range versions {
+		if version.Enabled && compiled.matches(version.Version, version.Metadata) {
+			return version, true
+		}
+	}
+
+	return ResourceVersion{}, false
+}
+
+func (filter VersionFilter) compile() (compiledVersionFilter, error) {
+	compiled := compiledVersionFilter{
+		field:    filter.Field,
+		match:    map[string]*regexp.Regexp{},
+		metadata: map[string]*regexp.Regexp{},
+	}
+
+	var errs []error
+
+	if filter.Semver == "" && len(filter.Match) == 0 && len(filter.Metadata) == 0 {
+		errs = append(errs, errors.New("must specify at least one of semver, match or metadata"))
+	}
+
+	if filter.Semver != "" {
+		constraints, err := semver.NewConstraint(filter.Semver)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid semver range '%s': %w", filter.Semver, err))
+		}
+
+		compiled.semver = constraints
+	} else if filter.Field != "" {
+		errs = append(errs, errors.New("field can only be given with semver"))
+	}
+
+	for _, field := range slices.Sorted(maps.Keys(filter.Match)) {
+		re, err := regexp.Compile(filter.Match[field])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid match for '%s': %w", field, err))
+		}
+
+		compiled.match[field] = re
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(filter.Metadata)) {
+		re, err := regexp.Compile(filter.Metadata[name])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid metadata match for '%s': %w", name, err))
+		}
+
+		compiled.metadata[name] = re
+	}
+
+	return compiled, errors.Join(errs...)
+}
+
+func (filter compiledVersionFilter) matches(version Version, metadata Metadata) bool {
+	if filter.semver != nil {
+		field := filter.field
+		if field == "" {
+			if len(version) != 1 {
+				return false
+			}
+
+			for name := range version {
+				field = name
+			}
+		}
+
+		value, found := version[field]
+		if !found {
+			return false
+		}
+
+		parsed, err := semver.NewVersion(value)
+		if err != nil || !filter.semver.Check(parsed) {
+			return false
+		}
+	}
+
+	for field, re := range filter.match {
+		value, found := version[field]
+		if !found || !re.MatchString(value) {
+			return false
+		}
+	}
+
+	for name, re := range filter.metadata {
+		matched := false
+		for _, field := range metadata {
+			if field.Name == name && re.MatchString(field.Value) {
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,114 @@
+package atc_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("VersionFilter", func() {
+	Describe("Validate", func() {
+		It("requires at least one criterion", func() {
+			Expect(atc.VersionFilter{}.Validate()).To(MatchError("must specify at least one of semver, match or metadata"))
+		})
+
+		It("only allows a field with semver", func() {
+			filter := atc.VersionFilter{Field: "tag", Match: map[string]string{"ref": "."}}
+			Expect(filter.Validate()).To(MatchError("field can only be given with semver"))
+		})
+
+		It("rejects invalid semver ranges and regular expressions", func() {
+			filter := atc.VersionFilter{
+				Semver:   "not a range",
+				Match:    map[string]string{"ref": "("},
+				Metadata: map[string]string{"channel": "["},
+			}
+
+			err := filter.Validate()
+			Expect(err).To(MatchError(ContainSubstring("invalid semver range 'not a range'")))
+			Expect(err).To(MatchError(ContainSubstring("invalid match for 'ref'")))
+			Expect(err).To(MatchError(ContainSubstring("invalid metadata match for 'channel'")))
+		})
+
+		It("accepts a valid filter", func() {
+			filter := atc.VersionFilter{Semver: ">= 1.2, < 2", Field: "tag"}
+			Expect(filter.Validate()).To(Succeed())
+		})
+	})
+
+	Describe("Matches", func() {
+		It("applies semver ranges to the version's only field by default", func() {
+			filter := atc.VersionFilter{Semver: "2.x"}
+
+			Expect(filter.Matches(atc.Version{"tag": "2.3.1"}, nil)).To(BeTrue())
+			Expect(filter.Matches(atc.Version{"tag": "v2.0.0"}, nil)).To(BeTrue())
+			Expect(filter.Matches(atc.Version{"tag": "3.0.0"}, nil)).To(BeFalse())
+			Expect(filter.Matches(atc.Version{"tag": "latest"}, nil)).To(BeFalse())
+			Expect(filter.Matches(atc.Version{"tag": "2.3.1", "digest": "sha256:abc"}, nil)).To(BeFalse())
+		})
+
+		It("applies semver ranges to the given field", func() {
+			filter := atc.VersionFilter{Semver: "2.x", Field: "tag"}
+
+			Expect(filter.Matches(atc.Version{"tag": "2.3.1", "digest": "sha256:abc"}, nil)).To(BeTrue())
+			Expect(filter.Matches(atc.Version{"digest": "sha256:abc"}, nil)).To(BeFalse())
+		})
+
+		It("matches version fields against regular expressions", func() {
+			filter := atc.VersionFilter{Match: map[string]string{"ref": "^release-"}}
+
+			Expect(filter.Matches(atc.Version{"ref": "release-1"}, nil)).To(BeTrue())
+			Expect(filter.Matches(atc.Version{"ref": "main-1"}, nil)).To(BeFalse())
+			Expect(filter.Matches(atc.Version{"other": "release-1"}, nil)).To(BeFalse())
+		})
+
+		It("matches metadata against regular expressions", func() {
+			filter := atc.VersionFilter{Metadata: map[string]string{"channel": "^stable$"}}
+
+			Expect(filter.Matches(atc.Version{"ref": "a"}, atc.Metadata{{Name: "channel", Value: "stable"}})).To(BeTrue())
+			Expect(filter.Matches(atc.Version{"ref": "a"}, atc.Metadata{{Name: "channel", Value: "beta"}})).To(BeFalse())
+			Expect(filter.Matches(atc.Version{"ref": "a"}, nil)).To(BeFalse())
+		})
+
+		It("requires every criterion to match", func() {
+			filter := atc.VersionFilter{
+				Semver:   "1.x",
+				Field:    "tag",
+				Metadata: map[string]string{"channel": "stable"},
+			}
+
+			Expect(filter.Matches(atc.Version{"tag": "1.0.0"}, atc.Metadata{{Name: "channel", Value: "stable"}})).To(BeTrue())
+			Expect(filter.Matches(atc.Version{"tag": "1.0.0"}, atc.Metadata{{Name: "channel", Value: "beta"}})).To(BeFalse())
+			Expect(filter.Matches(atc.Version{"tag": "2.0.0"}, atc.Metadata{{Name: "channel", Value: "stable"}})).To(BeFalse())
+		})
+
+		It("matches nothing when invalid", func() {
+			filter := atc.VersionFilter{Match: map[string]string{"ref": "("}}
+			Expect(filter.Matches(atc.Version{"ref": "("}, nil)).To(BeFalse())
+		})
+	})
+
+	Describe("MatchingVersion", func() {
+		It("returns the newest enabled version which matches", func() {
+			filter := atc.VersionFilter{Semver: "1.x"}
+
+			version, found := filter.MatchingVersion([]atc.ResourceVersion{
+				{ID: 4, Version: atc.Version{"tag": "2.0.0"}, Enabled: true},
+				{ID: 3, Version: atc.Version{"tag": "1.2.0"}, Enabled: false},
+				{ID: 2, Version: atc.Version{"tag": "1.1.0"}, Enabled: true},
+				{ID: 1, Version: atc.Version{"tag": "1.0.0"}, Enabled: true},
+			})
+			Expect(found).To(BeTrue())
+			Expect(version.ID).To(Equal(2))
+		})
+
+		It("returns false when nothing matches", func() {
+			filter := atc.VersionFilter{Semver: "3.x"}
+
+			_, found := filter.MatchingVersion([]atc.ResourceVersion{
+				{ID: 1, Version: atc.Version{"tag": "1.0.0"}, Enabled: true},
+			})
+			Expect(found).To(BeFalse())
+		})
+	})
+})
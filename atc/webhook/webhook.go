@@ -0,0 +1,201 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/gobwas/glob"
+)
+
+var (
+	ErrMissingSignature = errors.New("missing webhook signature")
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrMissingToken     = errors.New("missing webhook token")
+	ErrInvalidToken     = errors.New("invalid webhook token")
+	ErrNoCredentials    = errors.New("webhook has neither a secret nor a token")
+)
+
+// Verify authenticates a webhook request. When secret is non-empty the body
+// must be signed with it using HMAC-SHA256, and when token is non-empty it
+// must be sent in the configured header. Both are the already-interpolated
+// values of the webhook config. Requests are rejected if both are empty, e.g.
+// because they were interpolated from vars which are unset, so that a webhook
+// is never left open to anyone.
+func Verify(config atc.ResourceWebhook, secret string, token string, header http.Header, body []byte) error {
+	if secret == "" && token == "" {
+		return ErrNoCredentials
+	}
+
+	if secret != "" {
+		signature := header.Get(config.SignatureHeaderName())
+		if signature == "" {
+			return ErrMissingSignature
+		}
+
+		signature = strings.TrimPrefix(signature, "sha256=")
+
+		actual, err := hex.DecodeString(signature)
+		if err != nil {
+			return ErrInvalidSignature
+		}
+
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(body)
+
+		if !hmac.Equal(actual, mac.Sum(nil)) {
+			return ErrInvalidSignature
+		}
+	}
+
+	if token != "" {
+		actual := header.Get(config.TokenHeaderName())
+		if actual == "" {
+			return ErrMissingToken
+		}
+
+		if subtle.ConstantTimeCompare([]byte(actual), []byte(token)) != 1 {
+			return ErrInvalidToken
+		}
+	}
+
+	return nil
+}
+
+// PayloadDigest identifies a signed delivery by the SHA-256 digest of its
+// body. Unlike delivery IDs, which are sent in a header, the body is covered
+// by the signature, so a captured delivery cannot be replayed under a
+// different ID.
+func PayloadDigest(body []byte) string {
+	digest := sha256.Sum256(body)
+	return "sha256:" + hex.EncodeToString(digest[:])
+}
+
+// Event is the subset of a push payload used for filtering.
+type Event struct {
+	// Branch is the branch which was pushed to, or empty if the payload did
+	// not refer to a branch (e.g. a tag push or a ping).
+	Branch string
+
+	// Paths are the files changed by the push. It is nil if the provider does
+	// not include them in its payload.
+	Paths []string
+}
+
+// ParseEvent extracts the pushed branch and changed paths from a provider's
+// payload. Payloads which cannot be parsed result in an empty Event.
+func ParseEvent(provider string, body []byte) Event {
+	switch provider {
+	case atc.WebhookProviderBitbucket:
+		return parseBitbucketEvent(body)
+	default:
+		return parsePushEvent(body)
+	}
+}
+
+// parsePushEvent handles the payload format shared by GitHub and GitLab push
+// events, which is also accepted from generic webhooks.
+func parsePushEvent(body []byte) Event {
+	var payload struct {
+		Ref     string `json:"ref"`
+		Commits []struct {
+			Added    []string `json:"added"`
+			Modified []string `json:"modified"`
+			Removed  []string `json:"removed"`
+		} `json:"commits"`
+	}
+
+	err := json.Unmarshal(body, &payload)
+	if err != nil {
+		return Event{}
+	}
+
+	var event Event
+	if branch, ok := strings.CutPrefix(payload.Ref, "refs/heads/"); ok {
+		event.Branch = branch
+	}
+
+	if payload.Commits != nil {
+		event.Paths = []string{}
+		for _, commit := range payload.Commits {
+			event.Paths = append(event.Paths, commit.Added...)
+			event.Paths = append(event.Paths, commit.Modified...)
+			event.Paths = append(event.Paths, commit.Removed...)
+		}
+	}
+
+	return event
+}
+
+func parseBitbucketEvent(body []byte) Event {
+	var payload struct {
+		Push struct {
+			Changes []struct {
+				New *struct {
+					Type string `json:"type"`
+					Name string `json:"name"`
+				} `json:"new"`
+			} `json:"changes"`
+		} `json:"push"`
+	}
+
+	err := json.Unmarshal(body, &payload)
+	if err != nil {
+		return Event{}
+	}
+
+	for _, change := range payload.Push.Changes {
+		if change.New != nil && change.New.Type == "branch" {
+			return Event{Branch: change.New.Name}
+		}
+	}
+
+	return Event{}
+}
+
+// Matches returns whether the event passes the webhook's branch and path
+// filters. When a branch filter is configured, events without a branch never
+// match. A path filter matches if any changed path matches, and is ignored
+// for events which do not list their changed paths.
+func Matches(config atc.ResourceWebhook, event Event) bool {
+	if len(config.Branches) > 0 && !matchesAny(config.Branches, event.Branch) {
+		return false
+	}
+
+	if len(config.Paths) > 0 && event.Paths != nil {
+		matched := false
+		for _, path := range event.Paths {
+			if matchesAny(config.Paths, path) {
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			return false
+		}
+	}
+
+	return true
+}
+
+func matchesAny(globs []string, value string) bool {
+	if value == "" {
+		return false
+	}
+
+	for _, expr := range globs {
+		g, err := glob.Compile(expr, '/')
+		if err == nil && g.Match(value) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,216 @@
+package webhook_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/webhook"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+var _ = Describe("Verify", func() {
+	var (
+		config atc.ResourceWebhook
+		secret string
+		token  string
+		header http.Header
+		body   []byte
+
+		verifyErr error
+	)
+
+	BeforeEach(func() {
+		config = atc.ResourceWebhook{Provider: atc.WebhookProviderGitHub}
+		secret = ""
+		token = ""
+		header = http.Header{}
+		body = []byte(`{"ref":"refs/heads/main"}`)
+	})
+
+	JustBeforeEach(func() {
+		verifyErr = webhook.Verify(config, secret, token, header, body)
+	})
+
+	Context("with a secret", func() {
+		BeforeEach(func() {
+			secret = "some-secret"
+		})
+
+		Context("when the body is signed with the secret", func() {
+			BeforeEach(func() {
+				header.Set("X-Hub-Signature-256", sign(secret, body))
+			})
+
+			It("succeeds", func() {
+				Expect(verifyErr).ToNot(HaveOccurred())
+			})
+		})
+
+		Context("when the body is signed with another secret", func() {
+			BeforeEach(func() {
+				header.Set("X-Hub-Signature-256", sign("other-secret", body))
+			})
+
+			It("fails", func() {
+				Expect(verifyErr).To(Equal(webhook.ErrInvalidSignature))
+			})
+		})
+
+		Context("when the signature is not hex", func() {
+			BeforeEach(func() {
+				header.Set("X-Hub-Signature-256", "sha256=nope")
+			})
+
+			It("fails", func() {
+				Expect(verifyErr).To(Equal(webhook.ErrInvalidSignature))
+			})
+		})
+
+		Context("when there is no signature", func() {
+			It("fails", func() {
+				Expect(verifyErr).To(Equal(webhook.ErrMissingSignature))
+			})
+		})
+
+		Context("when a custom signature header is configured", func() {
+			BeforeEach(func() {
+				config.SignatureHeader = "X-Signature"
+				header.Set("X-Signature", sign(secret, body))
+			})
+
+			It("uses it", func() {
+				Expect(verifyErr).ToNot(HaveOccurred())
+			})
+		})
+	})
+
+	Context("with a token", func() {
+		BeforeEach(func() {
+			config.Provider = atc.WebhookProviderGitLab
+			token = "some-token"
+		})
+
+		Context("when the provider's token header matches", func() {
+			BeforeEach(func() {
+				header.Set("X-Gitlab-Token", "some-token")
+			})
+
+			It("succeeds", func() {
+				Expect(verifyErr).ToNot(HaveOccurred())
+			})
+		})
+
+		Context("when the token does not match", func() {
+			BeforeEach(func() {
+				header.Set("X-Gitlab-Token", "wrong-token")
+			})
+
+			It("fails", func() {
+				Expect(verifyErr).To(Equal(webhook.ErrInvalidToken))
+			})
+		})
+
+		Context("when there is no token", func() {
+			It("fails", func() {
+				Expect(verifyErr).To(Equal(webhook.ErrMissingToken))
+			})
+		})
+	})
+
+	Context("with neither a secret nor a token", func() {
+		It("fails", func() {
+			Expect(verifyErr).To(Equal(webhook.ErrNoCredentials))
+		})
+	})
+})
+
+var _ = Describe("ParseEvent", func() {
+	It("parses GitHub push events", func() {
+		event := webhook.ParseEvent(atc.WebhookProviderGitHub, []byte(`{
+			"ref": "refs/heads/release/1.0",
+			"commits": [
+				{"added": ["a.go"], "modified": ["b.go"], "removed": []},
+				{"added": [], "modified": ["docs/README.md"], "removed": ["c.go"]}
+			]
+		}`))
+
+		Expect(event).To(Equal(webhook.Event{
+			Branch: "release/1.0",
+			Paths:  []string{"a.go", "b.go", "docs/README.md", "c.go"},
+		}))
+	})
+
+	It("does not treat tags as branches", func() {
+		event := webhook.ParseEvent(atc.WebhookProviderGitLab, []byte(`{"ref": "refs/tags/v1.0"}`))
+		Expect(event.Branch).To(BeEmpty())
+		Expect(event.Paths).To(BeNil())
+	})
+
+	It("parses Bitbucket push events", func() {
+		event := webhook.ParseEvent(atc.WebhookProviderBitbucket, []byte(`{
+			"push": {"changes": [{"new": {"type": "branch", "name": "main"}}]}
+		}`))
+
+		Expect(event).To(Equal(webhook.Event{Branch: "main"}))
+	})
+
+	It("ignores payloads which are not json", func() {
+		Expect(webhook.ParseEvent(atc.WebhookProviderGeneric, []byte("nope"))).To(BeZero())
+	})
+})
+
+var _ = Describe("Matches", func() {
+	var config atc.ResourceWebhook
+
+	BeforeEach(func() {
+		config = atc.ResourceWebhook{}
+	})
+
+	It("matches everything without filters", func() {
+		Expect(webhook.Matches(config, webhook.Event{})).To(BeTrue())
+	})
+
+	Context("with a branch filter", func() {
+		BeforeEach(func() {
+			config.Branches = []string{"main", "release/*"}
+		})
+
+		It("matches branches against the globs", func() {
+			Expect(webhook.Matches(config, webhook.Event{Branch: "main"})).To(BeTrue())
+			Expect(webhook.Matches(config, webhook.Event{Branch: "release/1.0"})).To(BeTrue())
+			Expect(webhook.Matches(config, webhook.Event{Branch: "release/1.0/hotfix"})).To(BeFalse())
+			Expect(webhook.Matches(config, webhook.Event{Branch: "feature"})).To(BeFalse())
+		})
+
+		It("does not match events without a branch", func() {
+			Expect(webhook.Matches(config, webhook.Event{})).To(BeFalse())
+		})
+	})
+
+	Context("with a path filter", func() {
+		BeforeEach(func() {
+			config.Paths = []string{"src/**", "*.go"}
+		})
+
+		It("matches if any changed path matches", func() {
+			Expect(webhook.Matches(config, webhook.Event{Paths: []string{"docs/README.md", "src/a/b.c"}})).To(BeTrue())
+			Expect(webhook.Matches(config, webhook.Event{Paths: []string{"main.go"}})).To(BeTrue())
+			Expect(webhook.Matches(config, webhook.Event{Paths: []string{"docs/main.go"}})).To(BeFalse())
+			Expect(webhook.Matches(config, webhook.Event{Paths: []string{}})).To(BeFalse())
+		})
+
+		It("matches events which do not list their paths", func() {
+			Expect(webhook.Matches(config, webhook.Event{})).To(BeTrue())
+		})
+	})
+})
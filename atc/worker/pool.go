@@ -13,6 +13,7 @@ import (
 	"code.cloudfoundry.org/lager/v3/lagerctx"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/fairshare"
 	"github.com/concourse/concourse/atc/metric"
 	"github.com/concourse/concourse/atc/runtime"
 	"github.com/cppforlife/go-semi-semantic/version"
@@ -26,16 +27,20 @@ type Pool struct {
 	db            DB
 	workerVersion version.Version
 
-	waker chan struct{}
+	// waiting orders the steps waiting for a worker, so that a released
+	// worker goes to the team which is furthest behind its fair share. It
+	// only knows about the steps of this ATC, so fair share is not enforced
+	// between steps waiting on different ATCs.
+	waiting *fairshare.Queue
 }
 
-func NewPool(factory Factory, db DB, workerVersion version.Version) Pool {
+func NewPool(factory Factory, db DB, workerVersion version.Version, policy fairshare.Policy) Pool {
 	return Pool{
 		factory:       factory,
 		db:            db,
 		workerVersion: workerVersion,
 
-		waker: make(chan struct{}),
+		waiting: fairshare.NewQueue(policy),
 	}
 }
 
@@ -61,11 +66,17 @@ func (pool Pool) FindOrSelectWorker(
 		Type:       string(containerSpec.Type),
 		WorkerTags: strings.Join(workerSpec.Tags, "_"),
 	}
+	ticket := pool.waiting.Join(fairshare.Entry{
+		Team:     containerSpec.TeamName,
+		Priority: containerSpec.Priority,
+	})
+	defer ticket.Leave()
+
 	var worker db.Worker
 	var pollingTicker *time.Ticker
 	for {
 		var err error
-		worker, err = pool.findOrSelectWorker(logger, owner, containerSpec, workerSpec, strategy)
+		worker, err = pool.findOrSelectWorker(logger, owner, containerSpec, workerSpec, strategy, ticket)
 		if err != nil {
 			return nil, err
 		}
@@ -97,10 +108,12 @@ func (pool Pool) FindOrSelectWorker(
 			logger.Info("aborted-waiting-for-worker")
 			return nil, ctx.Err()
 		case <-pollingTicker.C:
-		case <-pool.waker:
+		case <-ticket.Woken():
 		}
 	}
 
+	ticket.Serve()
+
 	elapsed := time.Since(started)
 	metric.StepsWaitingDuration{
 		Labels:   labels,
@@ -110,7 +123,7 @@ func (pool Pool) FindOrSelectWorker(
 	return pool.factory.NewWorker(logger, worker), nil
 }
 
-func (pool Pool) findOrSelectWorker(logger lager.Logger, owner db.ContainerOwner, containerSpec runtime.ContainerSpec, workerSpec Spec, strategy PlacementStrategy) (db.Worker, error) {
+func (pool Pool) findOrSelectWorker(logger lager.Logger, owner db.ContainerOwner, containerSpec runtime.ContainerSpec, workerSpec Spec, strategy PlacementStrategy, ticket *fairshare.Ticket) (db.Worker, error) {
 	worker, compatibleWorkers, found, err := pool.findWorkerForContainer(logger, owner, workerSpec)
 	if err != nil {
 		return nil, err
@@ -118,6 +131,9 @@ func (pool Pool) findOrSelectWorker(logger lager.Logger, owner db.ContainerOwner
 	if found {
 		return worker, nil
 	}
+
+	candidates := workerNames(compatibleWorkers)
+
 	orderedWorkers, err := strategy.Order(logger, pool, compatibleWorkers, containerSpec)
 	if err != nil {
 		return nil, err
@@ -128,6 +144,19 @@ func (pool Pool) findOrSelectWorker(logger lager.Logger, owner db.ContainerOwner
 		err := strategy.Approve(logger, candidate, containerSpec)
 
 		if err == nil {
+			// Only give way to a step ahead in fair share order if it is
+			// waiting for the worker this step would take. Steps waiting for
+			// other workers must not hold this one back.
+			if ticket.Yield(candidate.Name()) {
+				strategy.Release(logger, candidate, containerSpec)
+
+				logger.Debug("yielding-to-fair-share", lager.Data{"worker": candidate.Name()})
+				ticket.Wait(candidates)
+				pool.waiting.Wake(candidate.Name())
+
+				return nil, nil
+			}
+
 			return candidate, nil
 		}
 
@@ -138,6 +167,7 @@ func (pool Pool) findOrSelectWorker(logger lager.Logger, owner db.ContainerOwner
 	}
 
 	logger.Debug("all-candidate-workers-rejected-during-selection", lager.Data{"reason": strategyError.Error()})
+	ticket.Wait(candidates)
 
 	return nil, nil
 }
@@ -145,12 +175,12 @@ func (pool Pool) findOrSelectWorker(logger lager.Logger, owner db.ContainerOwner
 func (pool Pool) ReleaseWorker(logger lager.Logger, containerSpec runtime.ContainerSpec, worker runtime.Worker, strategy PlacementStrategy) {
 	strategy.Release(logger, worker.DBWorker(), containerSpec)
 
-	// Attempt to wake a random waiting step to see if it can be
-	// scheduled on the recently released worker.
-	select {
-	case pool.waker <- struct{}{}:
+	pool.waiting.Done(containerSpec.TeamName)
+
+	// Attempt to wake the waiting step which is next in fair share order to
+	// see if it can be scheduled on the recently released worker.
+	if pool.waiting.Wake(worker.Name()) {
 		logger.Debug("attempted-to-wake-waiting-step")
-	default:
 	}
 }
 
@@ -466,6 +496,14 @@ func (pool Pool) isWorkerCompatibleAndRunning(logger lager.Logger, worker db.Wor
 	return true
 }
 
+func workerNames(workers []db.Worker) []string {
+	names := make([]string, len(workers))
+	for i, worker := range workers {
+		names[i] = worker.Name()
+	}
+	return names
+}
+
 func tagsMatch(worker db.Worker, tags []string) bool {
 	if len(worker.Tags()) > 0 && len(tags) == 0 {
 		return false
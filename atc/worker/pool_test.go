@@ -344,6 +344,129 @@ var _ = Describe("Pool", func() {
 		})
 	})
 
+	Describe("FindOrSelectWorker with several teams waiting", func() {
+		Test("gives a freed up worker to the team furthest behind its fair share", func() {
+			concurrentId := GinkgoParallelProcess()
+			scenario := Setup(
+				workertest.WithWorkers(
+					grt.NewWorker(fmt.Sprintf("worker1-%d", concurrentId)).
+						WithActiveTasks(1),
+				),
+			)
+
+			strategy, _, _, err := worker.NewPlacementStrategy(lagertest.NewTestLogger("atc"),
+				worker.PlacementOptions{
+					Strategies:              []string{"limit-active-tasks"},
+					MaxActiveTasksPerWorker: 1,
+				})
+			Expect(err).ToNot(HaveOccurred())
+
+			worker.PollingInterval = 10 * time.Millisecond
+
+			var waiting int32
+			callback := PoolCallback{
+				waitingForWorker: func() { atomic.AddInt32(&waiting, 1) },
+			}
+
+			var started int32
+			placed := make(chan string, 3)
+			wait := func(name string, team string) {
+				started++
+
+				go func() {
+					defer GinkgoRecover()
+
+					_, err := scenario.Pool.FindOrSelectWorker(
+						ctx,
+						db.NewFixedHandleContainerOwner(name),
+						runtime.ContainerSpec{Type: db.ContainerTypeTask, TeamName: team},
+						worker.Spec{},
+						strategy,
+						callback,
+					)
+					Expect(err).ToNot(HaveOccurred())
+
+					placed <- name
+				}()
+
+				Eventually(func() int32 { return atomic.LoadInt32(&waiting) }).Should(Equal(started))
+			}
+
+			wait("busy-1", "busy-team")
+			wait("busy-2", "busy-team")
+			wait("quiet", "quiet-team")
+
+			taskSpec := runtime.ContainerSpec{Type: db.ContainerTypeTask}
+			free := func() string {
+				strategy.Release(logger, scenario.Worker(fmt.Sprintf("worker1-%d", concurrentId)).DBWorker(), taskSpec)
+
+				var name string
+				Eventually(placed).Should(Receive(&name))
+				return name
+			}
+
+			Expect(free()).To(Equal("busy-1"))
+			Expect(free()).To(Equal("quiet"))
+			Expect(free()).To(Equal("busy-2"))
+		})
+
+		Test("does not hold back steps which can be placed on a worker nobody ahead is waiting for", func() {
+			concurrentId := GinkgoParallelProcess()
+			scenario := Setup(
+				workertest.WithWorkers(
+					grt.NewWorker(fmt.Sprintf("worker1-%d", concurrentId)).
+						WithPlatform("windows").
+						WithActiveTasks(1),
+					grt.NewWorker(fmt.Sprintf("worker2-%d", concurrentId)),
+				),
+			)
+
+			strategy, _, _, err := worker.NewPlacementStrategy(lagertest.NewTestLogger("atc"),
+				worker.PlacementOptions{
+					Strategies:              []string{"limit-active-tasks"},
+					MaxActiveTasksPerWorker: 1,
+				})
+			Expect(err).ToNot(HaveOccurred())
+
+			worker.PollingInterval = 10 * time.Millisecond
+
+			var waiting int32
+			callback := PoolCallback{
+				waitingForWorker: func() { atomic.AddInt32(&waiting, 1) },
+			}
+
+			go func() {
+				defer GinkgoRecover()
+
+				_, err := scenario.Pool.FindOrSelectWorker(
+					ctx,
+					db.NewFixedHandleContainerOwner("windows-step"),
+					runtime.ContainerSpec{Type: db.ContainerTypeTask, TeamName: "quiet-team"},
+					worker.Spec{Platform: "windows"},
+					strategy,
+					callback,
+				)
+				Expect(err).ToNot(HaveOccurred())
+			}()
+
+			Eventually(func() int32 { return atomic.LoadInt32(&waiting) }).Should(Equal(int32(1)))
+
+			placed, err := scenario.Pool.FindOrSelectWorker(
+				ctx,
+				db.NewFixedHandleContainerOwner("any-step"),
+				runtime.ContainerSpec{Type: db.ContainerTypeTask, TeamName: "busy-team"},
+				worker.Spec{},
+				strategy,
+				callback,
+			)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(placed.Name()).To(Equal(fmt.Sprintf("worker2-%d", concurrentId)))
+			Expect(atomic.LoadInt32(&waiting)).To(Equal(int32(1)))
+
+			strategy.Release(logger, scenario.Worker(fmt.Sprintf("worker1-%d", concurrentId)).DBWorker(), runtime.ContainerSpec{Type: db.ContainerTypeTask})
+		})
+	})
+
 	Describe("FindResourceCacheVolume", func() {
 		Test("finds a resource cache volume among multiple workers", func() {
 			concurrentId := GinkgoParallelProcess()
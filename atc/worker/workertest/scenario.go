@@ -10,6 +10,7 @@ import (
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbtest"
 	"github.com/concourse/concourse/atc/db/lock"
+	"github.com/concourse/concourse/atc/fairshare"
 	"github.com/concourse/concourse/atc/runtime"
 	"github.com/concourse/concourse/atc/worker"
 	"github.com/cppforlife/go-semi-semantic/version"
@@ -49,6 +50,7 @@ func Setup(dbConn db.DbConn, lockFactory lock.LockFactory, setup ...SetupFunc) *
 		factory,
 		db,
 		version.MustNewVersionFromString(concourse.WorkerVersion),
+		fairshare.Policy{},
 	)
 	builder := dbtest.NewBuilder(dbConn, lockFactory)
 	return setupWithPool(pool, factory, builder, setup...)
@@ -47,12 +47,15 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 		case atc.GetBuildPreparation,
 			atc.BuildEvents,
 			atc.GetBuildPlan,
-			atc.ListBuildArtifacts:
+			atc.ListBuildArtifacts,
+			atc.ListBuildApprovals:
 			newHandler = wrappa.checkBuildReadAccessHandlerFactory.CheckIfPrivateJobHandler(handler, rejector)
 
 		// resource belongs to authorized team
 		case atc.AbortBuild,
-			atc.SetBuildComment:
+			atc.SetBuildComment,
+			atc.ApproveBuildStep,
+			atc.RejectBuildStep:
 			newHandler = wrappa.checkBuildWriteAccessHandlerFactory.HandlerFor(handler, rejector)
 
 		// requester is system, admin team, or worker owning team
@@ -74,6 +77,7 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.GetJob,
 			atc.ListJobBuilds,
 			atc.ListPipelineBuilds,
+			atc.SearchPipelineBuildLogs,
 			atc.GetResource,
 			atc.ListBuildsWithVersionAsInput,
 			atc.ListBuildsWithVersionAsOutput,
@@ -92,7 +96,8 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			newHandler = auth.CheckSystemAccessHandler(handler, rejector)
 
 		// authenticated and has a role on at least one team
-		case atc.ListWorkers:
+		case atc.ListWorkers,
+			atc.ListSecretUsages:
 			newHandler = auth.CheckAnyTeamAccessHandler(handler, rejector)
 
 		// authenticated
@@ -102,6 +107,7 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 		// unauthenticated / delegating to handler (validate token if provided)
 		case atc.DownloadCLI,
 			atc.CheckResourceWebHook,
+			atc.CheckResourcesWebHook,
 			atc.GetInfo,
 			atc.GetHealth,
 			atc.GetCC,
@@ -133,7 +139,8 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.PauseComponent,
 			atc.UnpauseComponent,
 			atc.PauseAllComponents,
-			atc.UnpauseAllComponents:
+			atc.UnpauseAllComponents,
+			atc.ClearSecretCache:
 			newHandler = auth.CheckAdminHandler(handler, rejector)
 
 		// authorized (requested team matches resource team and has required role, or is admin)
@@ -141,6 +148,9 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.SetTeam,
 			atc.RenameTeam,
 			atc.ListTeamBuilds,
+			atc.ListSecrets,
+			atc.SetSecret,
+			atc.DeleteSecret,
 			atc.ListContainers,
 			atc.GetContainer,
 			atc.HijackContainer,
@@ -160,6 +170,10 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.SetPinCommentOnResource,
 			atc.GetConfig,
 			atc.GetVersionsDB,
+			atc.ListNotificationDeliveries,
+			atc.ListPipelineConfigRevisions,
+			atc.GetPipelineConfigRevision,
+			atc.RestorePipelineConfigRevision,
 			atc.ListJobInputs,
 			atc.OrderPipelines,
 			atc.OrderPipelinesWithinGroup,
@@ -171,6 +185,7 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ExposePipeline,
 			atc.HidePipeline,
 			atc.SaveConfig,
+			atc.PlanPipelineConfig,
 			atc.ArchivePipeline,
 			atc.ClearTaskCache,
 			atc.ClearResourceCache,
@@ -36,7 +36,8 @@ func (rw *RejectArchivedWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.PinResourceVersion,
 			atc.UnpinResource,
 			atc.SetPinCommentOnResource,
-			atc.RerunJobBuild:
+			atc.RerunJobBuild,
+			atc.RestorePipelineConfigRevision:
 
 			newHandler = rw.handlerFactory.RejectArchived(handler)
 
@@ -51,6 +52,9 @@ func (rw *RejectArchivedWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.GetBuildPlan,
 			atc.AbortBuild,
 			atc.SetBuildComment,
+			atc.ListBuildApprovals,
+			atc.ApproveBuildStep,
+			atc.RejectBuildStep,
 			atc.PruneWorker,
 			atc.LandWorker,
 			atc.ReportWorkerContainers,
@@ -81,6 +85,11 @@ func (rw *RejectArchivedWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ListContainers,
 			atc.ListVolumes,
 			atc.ListTeamBuilds,
+			atc.ListSecrets,
+			atc.SetSecret,
+			atc.DeleteSecret,
+			atc.ListSecretUsages,
+			atc.ClearSecretCache,
 			atc.ListWorkers,
 			atc.RegisterWorker,
 			atc.HeartbeatWorker,
@@ -93,6 +102,7 @@ func (rw *RejectArchivedWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.GetInfo,
 			atc.DownloadCLI,
 			atc.CheckResourceWebHook,
+			atc.CheckResourcesWebHook,
 			atc.ListAllPipelines,
 			atc.ListBuilds,
 			atc.ListPipelines,
@@ -110,12 +120,17 @@ func (rw *RejectArchivedWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.DeletePipeline,
 			atc.GetCC,
 			atc.GetVersionsDB,
+			atc.ListNotificationDeliveries,
+			atc.SearchPipelineBuildLogs,
+			atc.ListPipelineConfigRevisions,
+			atc.GetPipelineConfigRevision,
 			atc.ListJobInputs,
 			atc.OrderPipelines,
 			atc.OrderPipelinesWithinGroup,
 			atc.ArchivePipeline,
 			atc.RenamePipeline,
 			atc.SaveConfig,
+			atc.PlanPipelineConfig,
 			atc.PauseJob,
 			atc.UnpauseJob,
 			atc.ExposePipeline,
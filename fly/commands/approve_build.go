@@ -0,0 +1,128 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+)
+
+type ApproveBuildCommand struct {
+	Job   flaghelpers.JobFlag  `short:"j" long:"job" value-name:"PIPELINE/JOB" description:"Name of a job to approve"`
+	Build string               `short:"b" long:"build" required:"true" description:"If job is specified: build number to approve. If job not specified: build id"`
+	Step  string               `short:"s" long:"step" description:"Name of the approve step, required if more than one is waiting"`
+	Team  flaghelpers.TeamFlag `long:"team" description:"Name of the team to which the pipeline belongs, if different from the target default"`
+}
+
+func (command *ApproveBuildCommand) Execute([]string) error {
+	return decideApproval(command.Job, command.Build, command.Step, command.Team, true)
+}
+
+type RejectBuildCommand struct {
+	Job   flaghelpers.JobFlag  `short:"j" long:"job" value-name:"PIPELINE/JOB" description:"Name of a job to reject"`
+	Build string               `short:"b" long:"build" required:"true" description:"If job is specified: build number to reject. If job not specified: build id"`
+	Step  string               `short:"s" long:"step" description:"Name of the approve step, required if more than one is waiting"`
+	Team  flaghelpers.TeamFlag `long:"team" description:"Name of the team to which the pipeline belongs, if different from the target default"`
+}
+
+func (command *RejectBuildCommand) Execute([]string) error {
+	return decideApproval(command.Job, command.Build, command.Step, command.Team, false)
+}
+
+func decideApproval(job flaghelpers.JobFlag, buildName string, step string, teamFlag flaghelpers.TeamFlag, approve bool) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	team, err := teamFlag.LoadTeam(target)
+	if err != nil {
+		return err
+	}
+
+	var build atc.Build
+	var exists bool
+	if job.PipelineRef.Name == "" && job.JobName == "" {
+		build, exists, err = target.Client().Build(buildName)
+	} else {
+		build, exists, err = team.JobBuild(job.PipelineRef, job.JobName, buildName)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return fmt.Errorf("build does not exist")
+	}
+
+	buildID := strconv.Itoa(build.ID)
+
+	approvals, err := target.Client().ListBuildApprovals(buildID)
+	if err != nil {
+		return err
+	}
+
+	approval, err := pendingApproval(approvals, step)
+	if err != nil {
+		return err
+	}
+
+	if approve {
+		err = target.Client().ApproveBuildStep(buildID, approval.PlanID)
+	} else {
+		err = target.Client().RejectBuildStep(buildID, approval.PlanID)
+	}
+	if err != nil {
+		return err
+	}
+
+	if approve {
+		fmt.Printf("approved '%s'\n", approval.Name)
+	} else {
+		fmt.Printf("rejected '%s'\n", approval.Name)
+	}
+
+	return nil
+}
+
+func pendingApproval(approvals []atc.BuildApproval, step string) (atc.BuildApproval, error) {
+	var pending []atc.BuildApproval
+	for _, approval := range approvals {
+		if approval.Status != atc.ApprovalPending {
+			continue
+		}
+
+		if step != "" && approval.Name != step {
+			continue
+		}
+
+		pending = append(pending, approval)
+	}
+
+	switch len(pending) {
+	case 0:
+		if step != "" {
+			return atc.BuildApproval{}, fmt.Errorf("approve step '%s' is not waiting for approval", step)
+		}
+
+		return atc.BuildApproval{}, errors.New("build is not waiting for approval")
+	case 1:
+		return pending[0], nil
+	default:
+		var names []string
+		for _, approval := range pending {
+			names = append(names, approval.Name)
+		}
+
+		return atc.BuildApproval{}, fmt.Errorf("more than one approve step is waiting, specify one with --step: %s", strings.Join(names, ", "))
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -217,7 +218,8 @@ func (command *BuildsCommand) displayBuilds(builds []atc.Build) error {
 	}
 
 	buildCap := command.buildCap(builds)
-	for _, b := range builds[:buildCap] {
+	builds, nested := matrixTree(builds[:buildCap])
+	for _, b := range builds {
 		startTimeCell, endTimeCell, durationCell := populateTimeCells(time.Unix(b.StartTime, 0), time.Unix(b.EndTime, 0))
 
 		var nameCell ui.TableCell
@@ -243,6 +245,9 @@ func (command *BuildsCommand) displayBuilds(builds []atc.Build) error {
 		names = append(names, b.Name)
 
 		nameCell.Contents = strings.Join(names, "/")
+		if nested[b.ID] {
+			nameCell.Contents = "  └ " + b.Name + " " + matrixValuesString(b.MatrixValues)
+		}
 
 		createdBy := "system"
 		if b.CreatedBy != nil {
@@ -263,6 +268,59 @@ func (command *BuildsCommand) displayBuilds(builds []atc.Build) error {
 	return table.Render(os.Stdout, Fly.PrintTableHeaders)
 }
 
+// matrixTree orders the cells of each matrix build beneath their parent, and
+// returns which builds were moved. Cells whose parent is not listed are left
+// where they are.
+func matrixTree(builds []atc.Build) ([]atc.Build, map[int]bool) {
+	listed := map[int]bool{}
+	for _, b := range builds {
+		listed[b.ID] = true
+	}
+
+	cells := map[int][]atc.Build{}
+	nested := map[int]bool{}
+	for _, b := range builds {
+		if b.ParentBuildID != 0 && listed[b.ParentBuildID] {
+			cells[b.ParentBuildID] = append(cells[b.ParentBuildID], b)
+			nested[b.ID] = true
+		}
+	}
+
+	tree := make([]atc.Build, 0, len(builds))
+	for _, b := range builds {
+		if nested[b.ID] {
+			continue
+		}
+
+		tree = append(tree, b)
+
+		parentCells := cells[b.ID]
+		sort.Slice(parentCells, func(i, j int) bool {
+			return parentCells[i].ID < parentCells[j].ID
+		})
+
+		tree = append(tree, parentCells...)
+	}
+
+	return tree, nested
+}
+
+func matrixValuesString(values atc.MatrixValues) string {
+	vars := make([]string, 0, len(values))
+	for name := range values {
+		vars = append(vars, name)
+	}
+
+	sort.Strings(vars)
+
+	pairs := make([]string, len(vars))
+	for i, name := range vars {
+		pairs[i] = fmt.Sprintf("%s:%v", name, values[name])
+	}
+
+	return "(" + strings.Join(pairs, ", ") + ")"
+}
+
 func (command *BuildsCommand) validateBuildArguments(timeSince time.Time, page concourse.Page, timeUntil time.Time) (concourse.Page, error) {
 	var err error
 	if command.Since != "" {
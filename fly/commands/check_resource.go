@@ -4,7 +4,11 @@ import (
 	"fmt"
 	"github.com/concourse/concourse/go-concourse/concourse"
 	"os"
+	"sort"
 	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
@@ -18,6 +22,7 @@ type CheckResourceCommand struct {
 	Version  *atc.Version             `short:"f" long:"from"                     value-name:"VERSION"           description:"Version of the resource to check from, e.g. ref:abcd or path:thing-1.2.3.tgz"`
 	Async    bool                     `short:"a" long:"async"                    value-name:"ASYNC"             description:"Return the check without waiting for its result"`
 	Shallow  bool                     `long:"shallow"                          value-name:"SHALLOW"         description:"Check the resource itself only"`
+	Preview  bool                     `long:"preview"                                                         description:"After the check, show the version each get step with a version filter would use"`
 	Team  flaghelpers.TeamFlag  `long:"team" description:"Name of the team to which the pipeline belongs, if different from the target default"`
 }
 
@@ -72,5 +77,102 @@ func (command *CheckResourceCommand) Execute(args []string) error {
 		os.Exit(exitCode)
 	}
 
+	if command.Preview {
+		return command.previewFilters(team)
+	}
+
 	return nil
 }
+
+// previewFilters shows the version which each get step of the resource with a
+// version filter would use, given the versions the resource now has.
+func (command *CheckResourceCommand) previewFilters(team concourse.Team) error {
+	config, _, found, err := team.PipelineConfig(command.Resource.PipelineRef)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return fmt.Errorf("pipeline '%s' not found", command.Resource.PipelineRef.String())
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "job", Color: color.New(color.Bold)},
+			{Contents: "input", Color: color.New(color.Bold)},
+			{Contents: "version", Color: color.New(color.Bold)},
+		},
+	}
+
+	var versions []atc.ResourceVersion
+	var fetched bool
+
+	for _, job := range config.Jobs {
+		var steps []*atc.GetStep
+		_ = job.StepConfig().Visit(atc.StepRecursor{
+			OnGet: func(step *atc.GetStep) error {
+				if step.ResourceName() == command.Resource.ResourceName && step.Version != nil && step.Version.Filter != nil {
+					steps = append(steps, step)
+				}
+				return nil
+			},
+		})
+
+		for _, step := range steps {
+			if !fetched {
+				versions, err = command.allVersions(team)
+				if err != nil {
+					return err
+				}
+
+				fetched = true
+			}
+
+			versionCell := ui.TableCell{Contents: "none", Color: ui.OffColor}
+
+			version, found := step.Version.Filter.MatchingVersion(versions)
+			if found {
+				fields := []string{}
+				for k, v := range version.Version {
+					fields = append(fields, k+":"+v)
+				}
+
+				sort.Strings(fields)
+
+				versionCell = ui.TableCell{Contents: strings.Join(fields, ",")}
+			}
+
+			table.Data = append(table.Data, []ui.TableCell{
+				{Contents: job.Name},
+				{Contents: step.Name},
+				versionCell,
+			})
+		}
+	}
+
+	if len(table.Data) == 0 {
+		fmt.Printf("no get steps of %s have a version filter\n", ui.Embolden(command.Resource.String()))
+		return nil
+	}
+
+	fmt.Println()
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
+
+func (command *CheckResourceCommand) allVersions(team concourse.Team) ([]atc.ResourceVersion, error) {
+	var versions []atc.ResourceVersion
+
+	page := &concourse.Page{Limit: 100}
+	for page != nil {
+		pageVersions, pagination, _, err := team.ResourceVersions(command.Resource.PipelineRef, command.Resource.ResourceName, *page, atc.Version{})
+		if err != nil {
+			return nil, err
+		}
+
+		versions = append(versions, pageVersions...)
+		page = pagination.Next
+	}
+
+	return versions, nil
+}
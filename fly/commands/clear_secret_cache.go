@@ -0,0 +1,32 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/concourse/concourse/fly/rc"
+)
+
+type ClearSecretCacheCommand struct {
+	Team string `long:"team" description:"Only clear secrets looked up by this team"`
+	Var  string `short:"v" long:"var" description:"Only clear the secret of this var, e.g. github.token"`
+}
+
+func (command *ClearSecretCacheCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	err = target.Client().ClearSecretCache(command.Team, command.Var)
+	if err != nil {
+		return fmt.Errorf("failed to clear secret cache: %w", err)
+	}
+
+	fmt.Println("secret cache cleared")
+	return nil
+}
@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/commands/internal/interaction"
+	"github.com/concourse/concourse/fly/rc"
+)
+
+type DeleteSecretCommand struct {
+	Secret          string               `short:"s" long:"secret" required:"true" description:"Name of the secret"`
+	Pipeline        string               `short:"p" long:"pipeline" description:"Delete the secret of this pipeline, instead of the team's secret"`
+	SkipInteractive bool                 `short:"n" long:"non-interactive" description:"Delete the secret without confirmation"`
+	Team            flaghelpers.TeamFlag `long:"team" description:"Name of the team to which the secret belongs, if different from the target default"`
+}
+
+func (command *DeleteSecretCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	team, err := command.Team.LoadTeam(target)
+	if err != nil {
+		return err
+	}
+
+	if !command.SkipInteractive {
+		confirm, err := interaction.Confirm(fmt.Sprintf("delete secret '%s'?", command.Secret))
+		if err != nil || !confirm {
+			fmt.Println("bailing out")
+			return err
+		}
+	}
+
+	found, err := team.DeleteSecret(command.Pipeline, command.Secret)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		displayhelpers.Failf("secret '%s' not found", command.Secret)
+	}
+
+	fmt.Printf("secret '%s' deleted\n", command.Secret)
+
+	return nil
+}
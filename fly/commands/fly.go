@@ -62,6 +62,8 @@ type FlyCommand struct {
 	FormatPipeline            FormatPipelineCommand          `command:"format-pipeline"           alias:"fp"   description:"Format a pipeline config"`
 	OrderPipelines            OrderPipelinesCommand          `command:"order-pipelines"           alias:"op"   description:"Orders pipelines"`
 	OrderPipelinesWithinGroup OrderInstancedPipelinesCommand `command:"order-instanced-pipelines" alias:"oip"  description:"Orders instanced pipelines within an instance group"`
+	PipelineHistory           PipelineHistoryCommand         `command:"pipeline-history"          alias:"ph"   description:"List the saved revisions of a pipeline's configuration"`
+	RollbackPipeline          RollbackPipelineCommand        `command:"rollback-pipeline"         alias:"rbp"  description:"Restore a previous revision of a pipeline's configuration"`
 
 	Resources              ResourcesCommand              `command:"resources"                  alias:"rs"   description:"List the resources in the pipeline"`
 	ResourceVersions       ResourceVersionsCommand       `command:"resource-versions"          alias:"rvs"  description:"List the versions of a resource"`
@@ -77,9 +79,21 @@ type FlyCommand struct {
 
 	ClearTaskCache ClearTaskCacheCommand `command:"clear-task-cache" alias:"ctc" description:"Clears cache from a task container"`
 
-	Builds     BuildsCommand     `command:"builds"      alias:"bs" description:"List builds data"`
-	AbortBuild AbortBuildCommand `command:"abort-build" alias:"ab" description:"Abort a build"`
-	RerunBuild RerunBuildCommand `command:"rerun-build" alias:"rb" description:"Rerun a build"`
+	Notifications NotificationsCommand `command:"notifications" alias:"ns" description:"List the notification deliveries of a pipeline"`
+
+	SearchLogs SearchLogsCommand `command:"search-logs" alias:"sl" description:"Search the build logs of a pipeline or job"`
+
+	Secrets          SecretsCommand          `command:"secrets"            alias:"ss"  description:"List the secrets stored for a team"`
+	SetSecret        SetSecretCommand        `command:"set-secret"         alias:"sst" description:"Create or update a secret stored for a team or pipeline"`
+	DeleteSecret     DeleteSecretCommand     `command:"delete-secret"      alias:"dls" description:"Delete a secret stored for a team or pipeline"`
+	SecretUsages     SecretUsagesCommand     `command:"secret-usages"      alias:"su"  description:"List the pipelines, jobs and resources which use a var"`
+	ClearSecretCache ClearSecretCacheCommand `command:"clear-secret-cache" alias:"csc" description:"Clear cached secrets on every web node"`
+
+	Builds       BuildsCommand       `command:"builds"        alias:"bs"  description:"List builds data"`
+	AbortBuild   AbortBuildCommand   `command:"abort-build"   alias:"ab"  description:"Abort a build"`
+	RerunBuild   RerunBuildCommand   `command:"rerun-build"   alias:"rb"  description:"Rerun a build"`
+	ApproveBuild ApproveBuildCommand `command:"approve-build" alias:"apb" description:"Approve a build's approve step"`
+	RejectBuild  RejectBuildCommand  `command:"reject-build"  alias:"rjb" description:"Reject a build's approve step"`
 
 	TriggerJob TriggerJobCommand `command:"trigger-job" alias:"tj" description:"Start a job in a pipeline"`
 
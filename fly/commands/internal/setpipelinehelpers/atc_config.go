@@ -1,6 +1,7 @@
 package setpipelinehelpers
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"os"
@@ -27,6 +28,7 @@ type ATCConfig struct {
 	SkipInteraction  bool
 	CheckCredentials bool
 	DryRun           bool
+	ShowPlan         bool
 	CommandWarnings  []concourse.ConfigWarning
 	GivenTeamName    string
 }
@@ -92,6 +94,15 @@ func (atcConfig ATCConfig) Set(yamlTemplateWithParams templatehelpers.YamlTempla
 	}
 	fmt.Println()
 
+	if atcConfig.ShowPlan {
+		plan, err := atcConfig.Team.PlanPipelineConfig(atcConfig.PipelineRef, evaluatedTemplate)
+		if err != nil {
+			return err
+		}
+
+		showPlan(plan)
+	}
+
 	if atcConfig.DryRun {
 		fmt.Println("Dry-run mode was set, exiting.")
 		return nil
@@ -179,6 +190,62 @@ func (atcConfig ATCConfig) showPipelineUpdateResult(pipeline atc.Pipeline, creat
 	}
 }
 
+func showPlan(plan atc.ConfigPlan) {
+	fmt.Println(bold("plan:"))
+
+	if plan.IsEmpty() {
+		fmt.Println("  no effect on existing resources, jobs or versions")
+		fmt.Println()
+		return
+	}
+
+	if len(plan.RecheckedResources) > 0 {
+		fmt.Println("  resources which will start over with a new version history:")
+		for _, resource := range plan.RecheckedResources {
+			fmt.Printf("    %s (%s)\n", resource.Name, resource.Reason)
+		}
+	}
+
+	if len(plan.RemovedJobs) > 0 {
+		fmt.Println("  jobs which will lose their build history (set old_name on a renamed job to keep it):")
+		for _, job := range plan.RemovedJobs {
+			fmt.Printf("    %s (%d builds)\n", job.Name, job.Builds)
+		}
+	}
+
+	if len(plan.OrphanedPins) > 0 {
+		fmt.Println("  pinned versions which will be orphaned:")
+		for _, pin := range plan.OrphanedPins {
+			fmt.Printf("    %s %s (%s)\n", pin.Resource, planVersion(pin.Version), pin.Reason)
+		}
+	}
+
+	if len(plan.OrphanedDisabledVersions) > 0 {
+		fmt.Println("  disabled versions which will be orphaned:")
+		for _, disabled := range plan.OrphanedDisabledVersions {
+			fmt.Printf("    %s %s (%s)\n", disabled.Resource, planVersion(disabled.Version), disabled.Reason)
+		}
+	}
+
+	if len(plan.PassedConstraints) > 0 {
+		fmt.Println("  passed constraints which will change:")
+		for _, change := range plan.PassedConstraints {
+			fmt.Printf("    %s/%s: [%s] -> [%s]\n", change.Job, change.Input, strings.Join(change.Before, ", "), strings.Join(change.After, ", "))
+		}
+	}
+
+	fmt.Println()
+}
+
+func planVersion(version atc.Version) string {
+	payload, err := json.Marshal(version)
+	if err != nil {
+		return fmt.Sprint(version)
+	}
+
+	return string(payload)
+}
+
 func diff(existingConfig atc.Config, newConfig atc.Config) bool {
 	stdout, _ := ui.ForTTY(os.Stdout)
 	return existingConfig.Diff(stdout, newConfig)
@@ -71,16 +71,9 @@ func (yamlTemplate YamlTemplateWithParams) Evaluate(strict bool) ([]byte, error)
 	// second, we take all files. with values in the files specified later on command line taking precedence over the
 	// same values in the files specified earlier on command line
 	for i := len(yamlTemplate.templateVariablesFiles) - 1; i >= 0; i-- {
-		path := yamlTemplate.templateVariablesFiles[i]
-		templateVars, err := os.ReadFile(string(path))
+		staticVars, err := loadVarFile(yamlTemplate.templateVariablesFiles[i])
 		if err != nil {
-			return nil, fmt.Errorf("could not read template variables file (%s): %s", string(path), err.Error())
-		}
-
-		var staticVars vars.StaticVariables
-		err = yaml.Unmarshal(templateVars, &staticVars)
-		if err != nil {
-			return nil, fmt.Errorf("could not unmarshal template variables (%s): %s", string(path), err.Error())
+			return nil, err
 		}
 
 		params = append(params, staticVars)
@@ -93,3 +86,39 @@ func (yamlTemplate YamlTemplateWithParams) Evaluate(strict bool) ([]byte, error)
 
 	return evaluatedConfig, nil
 }
+
+// VarFile is a var file given on the command line, along with its vars.
+type VarFile struct {
+	Path atc.PathFlag
+	Vars vars.StaticVariables
+}
+
+// VarFiles loads the var files in the order they were given.
+func (yamlTemplate YamlTemplateWithParams) VarFiles() ([]VarFile, error) {
+	var varFiles []VarFile
+	for _, path := range yamlTemplate.templateVariablesFiles {
+		staticVars, err := loadVarFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		varFiles = append(varFiles, VarFile{Path: path, Vars: staticVars})
+	}
+
+	return varFiles, nil
+}
+
+func loadVarFile(path atc.PathFlag) (vars.StaticVariables, error) {
+	templateVars, err := os.ReadFile(string(path))
+	if err != nil {
+		return nil, fmt.Errorf("could not read template variables file (%s): %s", string(path), err.Error())
+	}
+
+	var staticVars vars.StaticVariables
+	err = yaml.Unmarshal(templateVars, &staticVars)
+	if err != nil {
+		return nil, fmt.Errorf("could not unmarshal template variables (%s): %s", string(path), err.Error())
+	}
+
+	return staticVars, nil
+}
@@ -32,6 +32,17 @@ func Validate(yamlTemplate templatehelpers.YamlTemplateWithParams, strict bool,
 
 	warnings, errorMessages := configvalidate.Validate(unmarshalledTemplate)
 
+	varFiles, err := yamlTemplate.VarFiles()
+	if err != nil {
+		return err
+	}
+
+	for _, varFile := range varFiles {
+		varFileWarnings, varFileErrors := configvalidate.ValidateVarFileSecrets(string(varFile.Path), varFile.Vars)
+		warnings = append(warnings, varFileWarnings...)
+		errorMessages = append(errorMessages, varFileErrors...)
+	}
+
 	if len(warnings) > 0 {
 		configWarnings := make([]concourse.ConfigWarning, len(warnings))
 		for idx, warning := range warnings {
@@ -2,6 +2,7 @@ package commands
 
 import (
 	"os"
+	"time"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
@@ -54,6 +55,20 @@ func (command *JobsCommand) Execute([]string) error {
 	}
 
 	headers = []string{"name", "paused", "status", "next"}
+
+	// only pipelines with scheduled jobs get the extra column
+	showScheduled := false
+	for _, job := range jobs {
+		if job.NextScheduledTrigger != 0 {
+			showScheduled = true
+			break
+		}
+	}
+
+	if showScheduled {
+		headers = append(headers, "scheduled")
+	}
+
 	table := ui.Table{Headers: ui.TableRow{}}
 	for _, h := range headers {
 		table.Headers = append(table.Headers, ui.TableCell{Contents: h, Color: color.New(color.Bold)})
@@ -89,6 +104,16 @@ func (command *JobsCommand) Execute([]string) error {
 		}
 		row = append(row, nextColumn)
 
+		if showScheduled {
+			var scheduledColumn ui.TableCell
+			if p.NextScheduledTrigger != 0 {
+				scheduledColumn.Contents = time.Unix(p.NextScheduledTrigger, 0).Format(timeDateLayout)
+			} else {
+				scheduledColumn.Contents = "n/a"
+			}
+			row = append(row, scheduledColumn)
+		}
+
 		table.Data = append(table.Data, row)
 	}
 
@@ -0,0 +1,98 @@
+package commands
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+)
+
+type NotificationsCommand struct {
+	Pipeline flaghelpers.PipelineFlag `short:"p" long:"pipeline" required:"true" description:"Get notification deliveries of this pipeline"`
+	All      bool                     `short:"a" long:"all" description:"Show all deliveries instead of only failed ones"`
+	Json     bool                     `long:"json" description:"Print command result as JSON"`
+	Team     flaghelpers.TeamFlag     `long:"team" description:"Name of the team to which the pipeline belongs, if different from the target default"`
+}
+
+func (command *NotificationsCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	team, err := command.Team.LoadTeam(target)
+	if err != nil {
+		return err
+	}
+
+	statuses := []atc.NotificationDeliveryStatus{atc.NotificationDeliveryFailed}
+	if command.All {
+		statuses = nil
+	}
+
+	deliveries, found, err := team.NotificationDeliveries(command.Pipeline.Ref(), statuses)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		displayhelpers.Failf("pipeline not found")
+	}
+
+	if command.Json {
+		return displayhelpers.JsonPrint(deliveries)
+	}
+
+	table := ui.Table{Headers: ui.TableRow{}}
+	for _, h := range []string{"id", "notification", "job", "build", "build status", "status", "attempts", "response", "updated", "error"} {
+		table.Headers = append(table.Headers, ui.TableCell{Contents: h, Color: color.New(color.Bold)})
+	}
+
+	for _, d := range deliveries {
+		var statusCell ui.TableCell
+		switch d.Status {
+		case atc.NotificationDeliverySucceeded:
+			statusCell = ui.TableCell{Contents: string(d.Status), Color: ui.SucceededColor}
+		case atc.NotificationDeliveryFailed:
+			statusCell = ui.TableCell{Contents: string(d.Status), Color: ui.FailedColor}
+		default:
+			statusCell = ui.TableCell{Contents: string(d.Status), Color: ui.PendingColor}
+		}
+
+		responseCell := ui.TableCell{Contents: "n/a", Color: color.New(color.Faint)}
+		if d.ResponseCode != 0 {
+			responseCell = ui.TableCell{Contents: strconv.Itoa(d.ResponseCode)}
+		}
+
+		errorCell := ui.TableCell{Contents: "n/a", Color: color.New(color.Faint)}
+		if d.LastError != "" {
+			errorCell = ui.TableCell{Contents: d.LastError}
+		}
+
+		table.Data = append(table.Data, ui.TableRow{
+			{Contents: strconv.Itoa(d.ID)},
+			{Contents: d.Name},
+			{Contents: d.JobName},
+			{Contents: d.BuildName},
+			ui.BuildStatusCell(d.BuildStatus),
+			statusCell,
+			{Contents: strconv.Itoa(d.Attempts)},
+			responseCell,
+			{Contents: time.Unix(d.UpdatedAt, 0).Format(timeDateLayout)},
+			errorCell,
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
@@ -0,0 +1,139 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+)
+
+type PipelineHistoryCommand struct {
+	Pipeline flaghelpers.PipelineFlag `short:"p" long:"pipeline" required:"true" description:"Get the config history of this pipeline"`
+	Revision int                      `short:"r" long:"revision" description:"Show the changes made by this revision"`
+	Json     bool                     `long:"json" description:"Print command result as JSON"`
+	Team     flaghelpers.TeamFlag     `long:"team" description:"Name of the team to which the pipeline belongs, if different from the target default"`
+}
+
+func (command *PipelineHistoryCommand) Validate() error {
+	_, err := command.Pipeline.Validate()
+	return err
+}
+
+func (command *PipelineHistoryCommand) Execute([]string) error {
+	err := command.Validate()
+	if err != nil {
+		return err
+	}
+
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	team, err := command.Team.LoadTeam(target)
+	if err != nil {
+		return err
+	}
+
+	pipelineRef := command.Pipeline.Ref()
+
+	if command.Revision != 0 {
+		revision, found, err := team.PipelineConfigRevision(pipelineRef, command.Revision)
+		if err != nil {
+			return err
+		}
+
+		if !found {
+			displayhelpers.Failf("revision %d of pipeline '%s' not found", command.Revision, pipelineRef.String())
+		}
+
+		if command.Json {
+			return displayhelpers.JsonPrint(revision)
+		}
+
+		// the first revision, or one whose predecessor has been pruned, is
+		// shown as a change from an empty config
+		previous, found, err := team.PipelineConfigRevision(pipelineRef, command.Revision-1)
+		if err != nil {
+			return err
+		}
+
+		var previousConfig atc.Config
+		if found && previous.Config != nil {
+			previousConfig = *previous.Config
+		}
+
+		var config atc.Config
+		if revision.Config != nil {
+			config = *revision.Config
+		}
+
+		fmt.Printf("revision %d, saved by %s\n\n", revision.Revision, configRevisionOrigin(revision))
+
+		stdout, _ := ui.ForTTY(os.Stdout)
+		if !previousConfig.Diff(stdout, config) {
+			fmt.Println("no changes")
+		}
+
+		return nil
+	}
+
+	revisions, found, err := team.PipelineConfigRevisions(pipelineRef)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		displayhelpers.Failf("pipeline not found")
+	}
+
+	if command.Json {
+		return displayhelpers.JsonPrint(revisions)
+	}
+
+	table := ui.Table{Headers: ui.TableRow{}}
+	for _, h := range []string{"revision", "saved at", "saved by", "config version"} {
+		table.Headers = append(table.Headers, ui.TableCell{Contents: h, Color: color.New(color.Bold)})
+	}
+
+	for _, r := range revisions {
+		table.Data = append(table.Data, ui.TableRow{
+			{Contents: strconv.Itoa(r.Revision)},
+			{Contents: time.Unix(r.CreatedAt, 0).Format(timeDateLayout)},
+			{Contents: configRevisionOrigin(r)},
+			{Contents: strconv.Itoa(r.ConfigVersion)},
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
+
+func configRevisionOrigin(revision atc.PipelineConfigRevision) string {
+	createdBy := revision.CreatedBy
+	if createdBy == "" {
+		createdBy = "unknown"
+	}
+
+	if revision.BuildID == 0 {
+		return createdBy
+	}
+
+	build := revision.BuildName
+	if revision.BuildJobName != "" {
+		build = fmt.Sprintf("%s/%s #%s", revision.BuildPipelineName, revision.BuildJobName, revision.BuildName)
+	}
+
+	return fmt.Sprintf("%s (set_pipeline %s)", createdBy, build)
+}
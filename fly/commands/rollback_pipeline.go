@@ -0,0 +1,100 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/commands/internal/interaction"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+)
+
+type RollbackPipelineCommand struct {
+	Pipeline         flaghelpers.PipelineFlag `short:"p" long:"pipeline" required:"true" description:"Pipeline to roll back"`
+	Revision         int                      `short:"r" long:"revision" required:"true" description:"Revision of the config to restore, as listed by pipeline-history"`
+	SkipInteractive  bool                     `short:"n" long:"non-interactive" description:"Restore the revision without confirmation"`
+	CheckCredentials bool                     `long:"check-creds" description:"Validate credential variables against credential manager"`
+	Team             flaghelpers.TeamFlag     `long:"team" description:"Name of the team to which the pipeline belongs, if different from the target default"`
+}
+
+func (command *RollbackPipelineCommand) Validate() error {
+	_, err := command.Pipeline.Validate()
+	return err
+}
+
+func (command *RollbackPipelineCommand) Execute([]string) error {
+	err := command.Validate()
+	if err != nil {
+		return err
+	}
+
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	team, err := command.Team.LoadTeam(target)
+	if err != nil {
+		return err
+	}
+
+	pipelineRef := command.Pipeline.Ref()
+
+	existingConfig, _, found, err := team.PipelineConfig(pipelineRef)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		displayhelpers.Failf("pipeline '%s' not found", pipelineRef.String())
+	}
+
+	revision, found, err := team.PipelineConfigRevision(pipelineRef, command.Revision)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		displayhelpers.Failf("revision %d of pipeline '%s' not found", command.Revision, pipelineRef.String())
+	}
+
+	var config atc.Config
+	if revision.Config != nil {
+		config = *revision.Config
+	}
+
+	stdout, _ := ui.ForTTY(os.Stdout)
+	if !existingConfig.Diff(stdout, config) {
+		fmt.Println("no changes to apply")
+		return nil
+	}
+
+	if !command.SkipInteractive {
+		confirm, err := interaction.Confirm(fmt.Sprintf("restore revision %d?", command.Revision))
+		if err != nil || !confirm {
+			fmt.Println("bailing out")
+			return err
+		}
+	}
+
+	found, err = team.RestorePipelineConfigRevision(pipelineRef, command.Revision, command.CheckCredentials)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		displayhelpers.Failf("revision %d of pipeline '%s' not found", command.Revision, pipelineRef.String())
+	}
+
+	fmt.Printf("restored revision %d of `%s`\n", command.Revision, pipelineRef.String())
+
+	return nil
+}
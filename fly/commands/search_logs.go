@@ -0,0 +1,142 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/fatih/color"
+)
+
+type SearchLogsCommand struct {
+	Pipeline flaghelpers.PipelineFlag `short:"p" long:"pipeline" description:"Search the logs of this pipeline's builds"`
+	Job      flaghelpers.JobFlag      `short:"j" long:"job" value-name:"PIPELINE/JOB" description:"Search the logs of this job's builds"`
+	Regex    bool                     `short:"e" long:"regex" description:"Interpret the pattern as a regular expression"`
+	Since    string                   `long:"since" description:"Only search builds which started after this time. Expected time format of 'yyyy-mm-dd HH:mm:ss'"`
+	Until    string                   `long:"until" description:"Only search builds which started before this time. Expected time format of 'yyyy-mm-dd HH:mm:ss'"`
+	Count    int                      `short:"c" long:"count" default:"50" description:"Number of matching lines to show"`
+	Json     bool                     `long:"json" description:"Print command result as JSON"`
+	Team     flaghelpers.TeamFlag     `long:"team" description:"Name of the team to which the pipeline belongs, if different from the target default"`
+
+	Args struct {
+		Pattern string `positional-arg-name:"PATTERN" required:"true" description:"Text to search for in the build logs"`
+	} `positional-args:"yes"`
+}
+
+func (command *SearchLogsCommand) Execute([]string) error {
+	if command.Pipeline.Name == "" && command.Job.JobName == "" {
+		return errors.New("either a pipeline or a job must be specified")
+	}
+
+	if command.Pipeline.Name != "" && command.Job.JobName != "" {
+		return errors.New("only one of a pipeline or a job can be specified")
+	}
+
+	search := concourse.BuildLogSearch{
+		Pattern: command.Args.Pattern,
+		Regex:   command.Regex,
+		Limit:   command.Count,
+	}
+
+	pipelineRef := command.Pipeline.Ref()
+	if command.Job.JobName != "" {
+		pipelineRef = command.Job.PipelineRef
+		search.Jobs = []string{command.Job.JobName}
+	}
+
+	if command.Since != "" {
+		since, err := time.ParseInLocation(inputTimeLayout, command.Since, time.Now().Location())
+		if err != nil {
+			return errors.New("Since time should be in the format: " + inputTimeLayout)
+		}
+
+		search.From = since.Unix()
+	}
+
+	if command.Until != "" {
+		until, err := time.ParseInLocation(inputTimeLayout, command.Until, time.Now().Location())
+		if err != nil {
+			return errors.New("Until time should be in the format: " + inputTimeLayout)
+		}
+
+		search.To = until.Unix()
+	}
+
+	if search.From != 0 && search.To != 0 && search.From > search.To {
+		return errors.New("Cannot have --since after --until")
+	}
+
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	team, err := command.Team.LoadTeam(target)
+	if err != nil {
+		return err
+	}
+
+	results, found, err := team.SearchBuildLogs(pipelineRef, search)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		displayhelpers.Failf("pipeline not found")
+	}
+
+	if command.Json {
+		return displayhelpers.JsonPrint(results)
+	}
+
+	table := ui.Table{Headers: ui.TableRow{}}
+	for _, h := range []string{"build", "step", "line", "time", "text"} {
+		table.Headers = append(table.Headers, ui.TableCell{Contents: h, Color: color.New(color.Bold)})
+	}
+
+	for _, match := range results.Matches {
+		table.Data = append(table.Data, ui.TableRow{
+			{Contents: match.JobName + "/" + match.BuildName},
+			stepNameCell(match),
+			{Contents: strconv.Itoa(match.Line)},
+			{Contents: time.Unix(match.Time, 0).Format(timeDateLayout)},
+			{Contents: match.Text},
+		})
+	}
+
+	err = table.Render(os.Stdout, Fly.PrintTableHeaders)
+	if err != nil {
+		return err
+	}
+
+	if results.ArchivedBuilds > 0 {
+		fmt.Fprintf(ui.Stderr, "\n%d builds in range were not searched because their logs have been archived\n", results.ArchivedBuilds)
+	}
+
+	if results.Truncated {
+		fmt.Fprintln(ui.Stderr, "\nmore matches may exist; narrow the search with --job, --since or --until, or raise --count")
+	}
+
+	return nil
+}
+
+func stepNameCell(match atc.BuildLogMatch) ui.TableCell {
+	if match.StepName == "" {
+		return ui.TableCell{Contents: "n/a", Color: color.New(color.Faint)}
+	}
+
+	return ui.TableCell{Contents: match.StepName}
+}
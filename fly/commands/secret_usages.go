@@ -0,0 +1,95 @@
+package commands
+
+import (
+	"os"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+)
+
+type SecretUsagesCommand struct {
+	Var  string `short:"v" long:"var" required:"true" description:"Name of the var, e.g. github.token"`
+	Json bool   `long:"json" description:"Print command result as JSON"`
+}
+
+func (command *SecretUsagesCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	usages, err := target.Client().ListSecretUsages(command.Var)
+	if err != nil {
+		return err
+	}
+
+	if command.Json {
+		return displayhelpers.JsonPrint(usages)
+	}
+
+	table := ui.Table{Headers: ui.TableRow{}}
+	for _, h := range []string{"var", "team", "pipeline", "used by", "last build"} {
+		table.Headers = append(table.Headers, ui.TableCell{Contents: h, Color: color.New(color.Bold)})
+	}
+
+	for _, u := range usages {
+		pipelineCell := ui.TableCell{Contents: "n/a", Color: color.New(color.Faint)}
+		if u.PipelineName != "" {
+			pipelineCell = ui.TableCell{Contents: atc.PipelineRef{Name: u.PipelineName, InstanceVars: u.PipelineInstanceVars}.String()}
+		}
+
+		usedByCell := ui.TableCell{Contents: "n/a", Color: color.New(color.Faint)}
+		if usedBy := secretUsers(u); usedBy != "" {
+			usedByCell = ui.TableCell{Contents: usedBy}
+		}
+
+		lastBuildCell := ui.TableCell{Contents: "n/a", Color: color.New(color.Faint)}
+		if u.LastBuild != nil {
+			lastBuild := "#" + u.LastBuild.Name
+			if u.LastBuild.JobName != "" {
+				lastBuild = u.LastBuild.JobName + "/" + lastBuild
+			}
+
+			lastBuildCell = ui.TableCell{Contents: lastBuild}
+		}
+
+		table.Data = append(table.Data, ui.TableRow{
+			{Contents: u.Var},
+			{Contents: u.TeamName},
+			pipelineCell,
+			usedByCell,
+			lastBuildCell,
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
+
+func secretUsers(usage atc.SecretUsage) string {
+	var users []string
+	for _, kind := range []struct {
+		name  string
+		names []string
+	}{
+		{"job", usage.Jobs},
+		{"resource", usage.Resources},
+		{"resource type", usage.ResourceTypes},
+		{"prototype", usage.Prototypes},
+		{"var source", usage.VarSources},
+	} {
+		for _, name := range kind.names {
+			users = append(users, kind.name+" "+name)
+		}
+	}
+
+	return strings.Join(users, ", ")
+}
@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"os"
+	"time"
+
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+)
+
+type SecretsCommand struct {
+	Pipeline string               `short:"p" long:"pipeline" description:"Only list the secrets of this pipeline"`
+	Json     bool                 `long:"json" description:"Print command result as JSON"`
+	Team     flaghelpers.TeamFlag `long:"team" description:"Name of the team to which the secrets belong, if different from the target default"`
+}
+
+func (command *SecretsCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	team, err := command.Team.LoadTeam(target)
+	if err != nil {
+		return err
+	}
+
+	secrets, err := team.Secrets(command.Pipeline)
+	if err != nil {
+		return err
+	}
+
+	if command.Json {
+		return displayhelpers.JsonPrint(secrets)
+	}
+
+	table := ui.Table{Headers: ui.TableRow{}}
+	for _, h := range []string{"name", "pipeline", "updated by", "updated"} {
+		table.Headers = append(table.Headers, ui.TableCell{Contents: h, Color: color.New(color.Bold)})
+	}
+
+	for _, s := range secrets {
+		pipelineCell := ui.TableCell{Contents: "n/a", Color: color.New(color.Faint)}
+		if s.Pipeline != "" {
+			pipelineCell = ui.TableCell{Contents: s.Pipeline}
+		}
+
+		updatedByCell := ui.TableCell{Contents: "n/a", Color: color.New(color.Faint)}
+		if s.UpdatedBy != "" {
+			updatedByCell = ui.TableCell{Contents: s.UpdatedBy}
+		}
+
+		table.Data = append(table.Data, ui.TableRow{
+			{Contents: s.Name},
+			pipelineCell,
+			updatedByCell,
+			{Contents: time.Unix(s.UpdatedAt, 0).Format(timeDateLayout)},
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
@@ -19,6 +19,7 @@ type SetPipelineCommand struct {
 	SkipInteractive  bool `short:"n"  long:"non-interactive"               description:"Skips interactions, uses default values"`
 	DisableAnsiColor bool `long:"no-color"               description:"Disable color output"`
 	DryRun           bool `short:"d"  long:"dry-run"               description:"Run a set pipeline step but in dry-run mode"`
+	Plan             bool `long:"plan"               description:"Show what applying the configuration would do to the pipeline's existing resources, jobs and versions"`
 
 	CheckCredentials bool `long:"check-creds"  description:"Validate credential variables against credential manager"`
 
@@ -99,6 +100,7 @@ func (command *SetPipelineCommand) Execute(args []string) error {
 		SkipInteraction:  command.SkipInteractive || command.Config.FromStdin(),
 		CheckCredentials: command.CheckCredentials,
 		DryRun:           command.DryRun,
+		ShowPlan:         command.Plan,
 		CommandWarnings:  warnings,
 		GivenTeamName:    string(command.Team),
 	}
@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/commands/internal/interaction"
+	"github.com/concourse/concourse/fly/rc"
+)
+
+type SetSecretCommand struct {
+	Secret        string               `short:"s" long:"secret" required:"true" description:"Name of the secret"`
+	Pipeline      string               `short:"p" long:"pipeline" description:"Only make the secret available to this pipeline, instead of the whole team"`
+	Value         string               `short:"v" long:"value" description:"Value of the secret. If neither this nor --value-from-file is given, the value will be prompted for"`
+	ValueFromFile atc.PathFlag         `long:"value-from-file" description:"Read the value of the secret from a file"`
+	Team          flaghelpers.TeamFlag `long:"team" description:"Name of the team to which the secret belongs, if different from the target default"`
+}
+
+func (command *SetSecretCommand) Validate() error {
+	if strings.Contains(command.Pipeline, "/") {
+		return errors.New("pipeline name cannot contain '/'")
+	}
+
+	if command.Value != "" && command.ValueFromFile != "" {
+		return errors.New("only one of --value and --value-from-file may be given")
+	}
+
+	return nil
+}
+
+func (command *SetSecretCommand) Execute([]string) error {
+	err := command.Validate()
+	if err != nil {
+		return err
+	}
+
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	team, err := command.Team.LoadTeam(target)
+	if err != nil {
+		return err
+	}
+
+	value := command.Value
+	switch {
+	case command.ValueFromFile != "":
+		contents, err := os.ReadFile(string(command.ValueFromFile))
+		if err != nil {
+			return err
+		}
+
+		value = string(contents)
+	case value == "":
+		value, err = interaction.Input("value", true)
+		if err != nil {
+			return err
+		}
+	}
+
+	if value == "" {
+		return errors.New("secret value cannot be empty")
+	}
+
+	err = team.SetSecret(command.Pipeline, command.Secret, value)
+	if err != nil {
+		return err
+	}
+
+	if command.Pipeline != "" {
+		fmt.Printf("secret '%s' set for pipeline '%s'\n", command.Secret, command.Pipeline)
+	} else {
+		fmt.Printf("secret '%s' set for team '%s'\n", command.Secret, team.Name())
+	}
+
+	return nil
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/concourse/concourse/go-concourse/concourse"
 	"github.com/concourse/concourse/skymarshal/skycmd"
 	"github.com/jessevdk/go-flags"
+	"sigs.k8s.io/yaml"
 )
 
 func WireTeamConnectors(command *flags.Command) {
@@ -30,6 +31,7 @@ func WireTeamConnectors(command *flags.Command) {
 type SetTeamCommand struct {
 	Team            flaghelpers.TeamFlag `short:"n" long:"team-name" required:"true" description:"The team to create or modify"`
 	SkipInteractive bool                 `long:"non-interactive" description:"Force apply configuration"`
+	VarSourceConfig atc.PathFlag         `long:"var-source-config" description:"YAML file listing var sources inherited by every pipeline of the team, in the same format as a pipeline's var_sources. Existing var sources are kept if omitted."`
 	AuthFlags       skycmd.AuthTeamFlags `group:"Authentication"`
 }
 
@@ -80,6 +82,11 @@ func (command *SetTeamCommand) Execute([]string) error {
 	}
 	sort.Strings(roles)
 
+	varSources, err := command.loadVarSources()
+	if err != nil {
+		return err
+	}
+
 	teamName := command.Team.Name()
 	fmt.Println("setting team:", ui.Embolden("%s", teamName))
 
@@ -109,6 +116,18 @@ func (command *SetTeamCommand) Execute([]string) error {
 		}
 	}
 
+	if varSources != nil {
+		fmt.Println()
+		fmt.Printf("var sources:\n")
+		if len(*varSources) > 0 {
+			for _, varSource := range *varSources {
+				fmt.Printf("- %s (%s)\n", varSource.Name, varSource.Type)
+			}
+		} else {
+			fmt.Printf("  %s\n", ui.OffColor.Sprint("none"))
+		}
+	}
+
 	if len(warnings) > 0 {
 		displayhelpers.ShowWarnings(warnings)
 	}
@@ -125,7 +144,7 @@ func (command *SetTeamCommand) Execute([]string) error {
 		displayhelpers.Failf("bailing out")
 	}
 
-	team := atc.Team{Auth: authRoles}
+	team := atc.Team{Auth: authRoles, VarSources: varSources}
 
 	_, created, updated, warnings, err := target.Client().Team(teamName).CreateOrUpdate(team)
 	if err != nil {
@@ -144,3 +163,22 @@ func (command *SetTeamCommand) Execute([]string) error {
 
 	return nil
 }
+
+func (command *SetTeamCommand) loadVarSources() (*atc.VarSourceConfigs, error) {
+	if command.VarSourceConfig == "" {
+		return nil, nil
+	}
+
+	content, err := os.ReadFile(string(command.VarSourceConfig))
+	if err != nil {
+		return nil, fmt.Errorf("could not read var source config: %w", err)
+	}
+
+	varSources := atc.VarSourceConfigs{}
+	err = yaml.UnmarshalStrict(content, &varSources)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse var source config: %w", err)
+	}
+
+	return &varSources, nil
+}
@@ -5,6 +5,7 @@ import (
 	"io"
 	"strings"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/event"
 	"github.com/concourse/concourse/fly/ui"
 	"github.com/concourse/concourse/go-concourse/concourse/eventstream"
@@ -56,6 +57,25 @@ func Render(dst io.Writer, src eventstream.EventStream, options RenderOptions) i
 			dstImpl.SetTimestamp(e.Time)
 			fmt.Fprintf(dstImpl, "\x1b[1mwaiting for volume\x1b[0m %s \x1b[1mto be streamed by another step\x1b[0m\n", e.Volume)
 
+		case event.ApprovalPending:
+			dstImpl.SetTimestamp(e.Time)
+			fmt.Fprintf(dstImpl, "\x1b[1mwaiting for approval:\x1b[0m %s\n", e.Name)
+
+		case event.ApprovalDecided:
+			dstImpl.SetTimestamp(e.Time)
+			switch e.Status {
+			case atc.ApprovalApproved:
+				fmt.Fprintf(dstImpl, "\x1b[1mapproved by\x1b[0m %s\n", e.DecidedBy)
+			case atc.ApprovalRejected:
+				fmt.Fprintf(dstImpl, "\x1b[1mrejected by\x1b[0m %s\n", e.DecidedBy)
+			default:
+				fmt.Fprintf(dstImpl, "\x1b[1mapproval timed out\x1b[0m\n")
+			}
+
+		case event.Skipped:
+			dstImpl.SetTimestamp(e.Time)
+			fmt.Fprintf(dstImpl, "\x1b[1mskipped:\x1b[0m %s \x1b[1mis false\x1b[0m\n", e.Condition)
+
 		case event.InitializeCheck:
 			dstImpl.SetTimestamp(e.Time)
 			fmt.Fprintf(dstImpl, "\x1b[1minitializing check:\x1b[0m %s\n", e.Name)
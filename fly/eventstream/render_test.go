@@ -318,6 +318,78 @@ var _ = Describe("V1.0 Renderer", func() {
 		})
 	})
 
+	Context("when an ApprovalPending event is received", func() {
+		BeforeEach(func() {
+			receivedEvents <- event.ApprovalPending{
+				Time: time.Now().Unix(),
+				Name: "ship-it",
+			}
+		})
+
+		It("prints the approval being waited on", func() {
+			Expect(out.Contents()).To(ContainSubstring("\x1b[1mwaiting for approval:\x1b[0m ship-it\n"))
+		})
+	})
+
+	Context("when an ApprovalDecided event is received", func() {
+		Context("when it was approved", func() {
+			BeforeEach(func() {
+				receivedEvents <- event.ApprovalDecided{
+					Time:      time.Now().Unix(),
+					Name:      "ship-it",
+					Status:    atc.ApprovalApproved,
+					DecidedBy: "some-user",
+				}
+			})
+
+			It("prints who approved it", func() {
+				Expect(out.Contents()).To(ContainSubstring("\x1b[1mapproved by\x1b[0m some-user\n"))
+			})
+		})
+
+		Context("when it was rejected", func() {
+			BeforeEach(func() {
+				receivedEvents <- event.ApprovalDecided{
+					Time:      time.Now().Unix(),
+					Name:      "ship-it",
+					Status:    atc.ApprovalRejected,
+					DecidedBy: "some-user",
+				}
+			})
+
+			It("prints who rejected it", func() {
+				Expect(out.Contents()).To(ContainSubstring("\x1b[1mrejected by\x1b[0m some-user\n"))
+			})
+		})
+
+		Context("when it timed out", func() {
+			BeforeEach(func() {
+				receivedEvents <- event.ApprovalDecided{
+					Time:   time.Now().Unix(),
+					Name:   "ship-it",
+					Status: atc.ApprovalTimedOut,
+				}
+			})
+
+			It("prints that it timed out", func() {
+				Expect(out.Contents()).To(ContainSubstring("\x1b[1mapproval timed out\x1b[0m\n"))
+			})
+		})
+	})
+
+	Context("when a Skipped event is received", func() {
+		BeforeEach(func() {
+			receivedEvents <- event.Skipped{
+				Time:      time.Now().Unix(),
+				Condition: "vars.deploy",
+			}
+		})
+
+		It("prints the condition which did not hold", func() {
+			Expect(out.Contents()).To(ContainSubstring("\x1b[1mskipped:\x1b[0m vars.deploy \x1b[1mis false\x1b[0m\n"))
+		})
+	})
+
 	Context("when a SelectedWorker event is received", func() {
 		BeforeEach(func() {
 			receivedEvents <- event.SelectedWorker{
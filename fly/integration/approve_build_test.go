@@ -0,0 +1,144 @@
+package integration_test
+
+import (
+	"net/http"
+	"os/exec"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/concourse/concourse/atc"
+)
+
+var _ = Describe("approve-build", func() {
+	var (
+		expectedBuild = atc.Build{
+			ID:      23,
+			Name:    "42",
+			Status:  "started",
+			JobName: "myjob",
+			APIURL:  "api/v1/builds/23",
+		}
+
+		approvals []atc.BuildApproval
+	)
+
+	BeforeEach(func() {
+		approvals = []atc.BuildApproval{
+			{PlanID: "plan-1", Name: "staging", Status: atc.ApprovalApproved},
+			{PlanID: "plan-2", Name: "production", Status: atc.ApprovalPending},
+		}
+	})
+
+	JustBeforeEach(func() {
+		atcServer.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/api/v1/builds/23"),
+				ghttp.RespondWithJSONEncoded(http.StatusOK, expectedBuild),
+			),
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/api/v1/builds/23/approvals"),
+				ghttp.RespondWithJSONEncoded(http.StatusOK, approvals),
+			),
+		)
+	})
+
+	Context("when a single approve step is waiting", func() {
+		JustBeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/builds/23/approvals/plan-2/approve"),
+					ghttp.RespondWith(http.StatusNoContent, ""),
+				),
+			)
+		})
+
+		It("approves it", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "approve-build", "-b", "23")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(0))
+			Expect(sess.Out).To(gbytes.Say("approved 'production'"))
+		})
+	})
+
+	Context("when more than one approve step is waiting", func() {
+		BeforeEach(func() {
+			approvals[0].Status = atc.ApprovalPending
+		})
+
+		It("asks for the step", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "approve-build", "-b", "23")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(1))
+			Expect(sess.Err).To(gbytes.Say("more than one approve step is waiting, specify one with --step: staging, production"))
+		})
+
+		Context("when the step is specified", func() {
+			JustBeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/builds/23/approvals/plan-1/reject"),
+						ghttp.RespondWith(http.StatusNoContent, ""),
+					),
+				)
+			})
+
+			It("decides that step", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "reject-build", "-b", "23", "--step", "staging")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out).To(gbytes.Say("rejected 'staging'"))
+			})
+		})
+	})
+
+	Context("when no approve step is waiting", func() {
+		BeforeEach(func() {
+			approvals = approvals[:1]
+		})
+
+		It("errors", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "approve-build", "-b", "23")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(1))
+			Expect(sess.Err).To(gbytes.Say("build is not waiting for approval"))
+		})
+	})
+
+	Context("when the approval was decided concurrently", func() {
+		JustBeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/builds/23/approvals/plan-2/approve"),
+					ghttp.RespondWith(http.StatusConflict, ""),
+				),
+			)
+		})
+
+		It("errors", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "approve-build", "-b", "23")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(1))
+			Expect(sess.Err).To(gbytes.Say("approval has already been decided"))
+		})
+	})
+})
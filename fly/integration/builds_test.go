@@ -539,6 +539,83 @@ var _ = Describe("builds", func() {
 					Eventually(session).Should(gexec.Exit(0))
 				})
 			})
+
+			Context("and the job has matrix builds", func() {
+				BeforeEach(func() {
+					returnedBuilds = []atc.Build{
+						{
+							ID:            5,
+							PipelineName:  "some-pipeline",
+							JobName:       "some-job",
+							Name:          "63-2",
+							Status:        "failed",
+							StartTime:     succeededBuildStartTime.Unix(),
+							EndTime:       succeededBuildEndTime.Unix(),
+							ParentBuildID: 3,
+							MatrixValues:  atc.MatrixValues{"go": "1.22", "os": "linux"},
+						},
+						{
+							ID:            4,
+							PipelineName:  "some-pipeline",
+							JobName:       "some-job",
+							Name:          "63-1",
+							Status:        "succeeded",
+							StartTime:     succeededBuildStartTime.Unix(),
+							EndTime:       succeededBuildEndTime.Unix(),
+							ParentBuildID: 3,
+							MatrixValues:  atc.MatrixValues{"go": "1.21", "os": "linux"},
+						},
+						{
+							ID:           3,
+							PipelineName: "some-pipeline",
+							JobName:      "some-job",
+							Name:         "63",
+							Status:       "failed",
+							StartTime:    succeededBuildStartTime.Unix(),
+							EndTime:      succeededBuildEndTime.Unix(),
+						},
+					}
+				})
+
+				It("shows the cells beneath their parent", func() {
+					Eventually(session.Out).Should(PrintTable(ui.Table{
+						Headers: expectedHeaders,
+						Data: []ui.TableRow{
+							{
+								{Contents: "3"},
+								{Contents: "some-pipeline/some-job/63"},
+								{Contents: "failed"},
+								{Contents: succeededBuildStartTime.Local().Format(timeDateLayout)},
+								{Contents: succeededBuildEndTime.Local().Format(timeDateLayout)},
+								{Contents: "1h15m0s"},
+								{Contents: ""},
+								{Contents: "system"},
+							},
+							{
+								{Contents: "4"},
+								{Contents: "  └ 63-1 (go:1.21, os:linux)"},
+								{Contents: "succeeded"},
+								{Contents: succeededBuildStartTime.Local().Format(timeDateLayout)},
+								{Contents: succeededBuildEndTime.Local().Format(timeDateLayout)},
+								{Contents: "1h15m0s"},
+								{Contents: ""},
+								{Contents: "system"},
+							},
+							{
+								{Contents: "5"},
+								{Contents: "  └ 63-2 (go:1.22, os:linux)"},
+								{Contents: "failed"},
+								{Contents: succeededBuildStartTime.Local().Format(timeDateLayout)},
+								{Contents: succeededBuildEndTime.Local().Format(timeDateLayout)},
+								{Contents: "1h15m0s"},
+								{Contents: ""},
+								{Contents: "system"},
+							},
+						},
+					}))
+					Eventually(session).Should(gexec.Exit(0))
+				})
+			})
 		})
 
 		Context("when passing the current-team argument", func() {
@@ -141,6 +141,88 @@ var _ = Describe("check-resource", func() {
 		})
 	})
 
+	Context("when specifying the --preview flag", func() {
+		var streaming chan struct{}
+		var events chan atc.Event
+
+		BeforeEach(func() {
+			streaming = make(chan struct{})
+			events = make(chan atc.Event)
+
+			config := atc.Config{
+				Resources: atc.ResourceConfigs{
+					{Name: "myresource", Type: "git"},
+				},
+				Jobs: atc.JobConfigs{
+					{
+						Name: "release",
+						PlanSequence: []atc.Step{
+							{
+								Config: &atc.GetStep{
+									Name:     "stable",
+									Resource: "myresource",
+									Version: &atc.VersionConfig{
+										Filter: &atc.VersionFilter{Semver: ">= 1.0.0, < 2.0.0"},
+									},
+								},
+							},
+							{
+								Config: &atc.GetStep{
+									Name:     "nightly",
+									Resource: "myresource",
+									Version: &atc.VersionConfig{
+										Filter: &atc.VersionFilter{Match: map[string]string{"ref": "^nightly-"}},
+									},
+								},
+							},
+							{
+								Config: &atc.GetStep{
+									Name:     "myresource",
+									Resource: "myresource",
+								},
+							},
+						},
+					},
+				},
+			}
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", expectedURL, expectedQueryParams),
+					ghttp.VerifyJSON(`{"from":null,"shallow":false}`),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, build),
+				),
+				BuildEventsHandler(123, streaming, events),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/mypipeline/config", expectedQueryParams),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, atc.ConfigResponse{Config: config}, http.Header{atc.ConfigVersionHeader: {"42"}}),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/mypipeline/resources/myresource/versions"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, []atc.ResourceVersion{
+						{ID: 4, Version: atc.Version{"ref": "2.0.0"}, Enabled: true},
+						{ID: 3, Version: atc.Version{"ref": "1.2.0"}, Enabled: false},
+						{ID: 2, Version: atc.Version{"ref": "1.1.0"}, Enabled: true},
+						{ID: 1, Version: atc.Version{"ref": "1.0.0"}, Enabled: true},
+					}),
+				),
+			)
+		})
+
+		It("shows the version each filtered get step would use", func() {
+			flyCmd = exec.Command(flyPath, "-t", targetName, "check-resource", "-r", "mypipeline/branch:master/myresource", "--preview")
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(sess.Out).Should(gbytes.Say("checking mypipeline/branch:master/myresource in build 123"))
+
+			AssertEvents(sess, streaming, events)
+
+			Expect(sess.Out).To(gbytes.Say(`release\s+stable\s+ref:1.1.0`))
+			Expect(sess.Out).To(gbytes.Say(`release\s+nightly\s+none`))
+			Expect(sess.Out).ToNot(gbytes.Say(`release\s+myresource`))
+		})
+	})
+
 	Context("when specifying multiple versions", func() {
 		BeforeEach(func() {
 			atcServer.AppendHandlers(
@@ -0,0 +1,63 @@
+package integration_test
+
+import (
+	"net/http"
+	"os/exec"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("clear-secret-cache", func() {
+	Context("when clearing succeeds", func() {
+		It("sends DELETE /api/v1/secret_cache and prints success", func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("DELETE", "/api/v1/secret_cache", ""),
+					ghttp.RespondWith(http.StatusNoContent, ""),
+				),
+			)
+
+			flyCmd := exec.Command(flyPath, "-t", targetName, "clear-secret-cache")
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(sess).Should(gexec.Exit(0))
+			Expect(sess.Out).To(gbytes.Say("secret cache cleared"))
+		})
+
+		It("only clears the given team's var", func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("DELETE", "/api/v1/secret_cache", "team=main&var=github.token"),
+					ghttp.RespondWith(http.StatusNoContent, ""),
+				),
+			)
+
+			flyCmd := exec.Command(flyPath, "-t", targetName, "clear-secret-cache", "--team", "main", "-v", "github.token")
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(sess).Should(gexec.Exit(0))
+			Expect(sess.Out).To(gbytes.Say("secret cache cleared"))
+		})
+	})
+
+	Context("when the API returns an error", func() {
+		It("exits non-zero and shows an error", func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("DELETE", "/api/v1/secret_cache"),
+					ghttp.RespondWith(http.StatusForbidden, ""),
+				),
+			)
+
+			flyCmd := exec.Command(flyPath, "-t", targetName, "clear-secret-cache")
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(sess).Should(gexec.Exit(1))
+			Expect(sess.Err).To(gbytes.Say("failed to clear secret cache"))
+		})
+	})
+})
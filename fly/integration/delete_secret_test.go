@@ -0,0 +1,85 @@
+package integration_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os/exec"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("delete-secret", func() {
+		var (
+			flyCmd *exec.Cmd
+			stdin  io.Writer
+			sess   *gexec.Session
+		)
+
+		expectedURL := "/api/v1/teams/main/secrets/some-secret"
+
+		BeforeEach(func() {
+			flyCmd = exec.Command(flyPath, "-t", targetName, "delete-secret", "-s", "some-secret", "-p", "some-pipeline")
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			stdin, err = flyCmd.StdinPipe()
+			Expect(err).NotTo(HaveOccurred())
+
+			sess, err = gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the user confirms", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("DELETE", expectedURL, "pipeline=some-pipeline"),
+						ghttp.RespondWith(http.StatusNoContent, ""),
+					),
+				)
+			})
+
+			It("deletes the secret", func() {
+				Eventually(sess).Should(gbytes.Say(`delete secret 'some-secret'\? \[yN\]: `))
+				fmt.Fprintf(stdin, "y\r")
+
+				Eventually(sess).Should(gbytes.Say("secret 'some-secret' deleted"))
+				Eventually(sess).Should(gexec.Exit(0))
+			})
+		})
+
+		Context("when the user declines", func() {
+			It("does not delete the secret", func() {
+				Eventually(sess).Should(gbytes.Say(`delete secret 'some-secret'\? \[yN\]: `))
+				fmt.Fprintf(stdin, "n\r")
+
+				Eventually(sess).Should(gbytes.Say("bailing out"))
+				Eventually(sess).Should(gexec.Exit(0))
+			})
+		})
+
+		Context("when the secret does not exist", func() {
+			BeforeEach(func() {
+				flyCmd.Args = append(flyCmd.Args, "-n")
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("DELETE", expectedURL, "pipeline=some-pipeline"),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("fails", func() {
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("secret 'some-secret' not found"))
+			})
+		})
+	})
+})
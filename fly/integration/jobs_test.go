@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"os/exec"
+	"time"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/fly/ui"
@@ -169,6 +170,49 @@ var _ = Describe("jobs", func() {
 		})
 	})
 
+	Context("when jobs have schedules", func() {
+		var nextTrigger time.Time
+
+		BeforeEach(func() {
+			nextTrigger = time.Date(2024, 3, 6, 2, 0, 0, 0, time.UTC)
+
+			flyCmd = exec.Command(flyPath, "-t", targetName, "jobs", "--pipeline", "pipeline")
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", expectedURL),
+					ghttp.RespondWithJSONEncoded(200, []atc.Job{
+						{
+							ID:                   1,
+							Name:                 "nightly",
+							PipelineName:         "pipeline",
+							TeamName:             teamName,
+							NextScheduledTrigger: nextTrigger.Unix(),
+						},
+						{
+							ID:           2,
+							Name:         "deploy",
+							PipelineName: "pipeline",
+							TeamName:     teamName,
+						},
+					}),
+				),
+			)
+		})
+
+		It("shows when each job is next triggered", func() {
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(sess).Should(gexec.Exit(0))
+
+			Expect(sess.Out).To(PrintTable(ui.Table{
+				Data: []ui.TableRow{
+					{{Contents: "nightly"}, {Contents: "no"}, {Contents: "n/a"}, {Contents: "n/a"}, {Contents: nextTrigger.Local().Format("2006-01-02@15:04:05-0700")}},
+					{{Contents: "deploy"}, {Contents: "no"}, {Contents: "n/a"}, {Contents: "n/a"}, {Contents: "n/a"}},
+				},
+			}))
+		})
+	})
+
 	Context("when the api returns an internal server error", func() {
 		BeforeEach(func() {
 			flyCmd = exec.Command(flyPath, "-t", targetName, "jobs", "-p", "pipeline")
@@ -0,0 +1,182 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"os/exec"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("notifications", func() {
+	var (
+		flyCmd     *exec.Cmd
+		deliveries []atc.NotificationDelivery
+	)
+
+	expectedURL := "/api/v1/teams/main/pipelines/pipeline/notification-deliveries"
+
+	BeforeEach(func() {
+		deliveries = []atc.NotificationDelivery{
+			{
+				ID:           2,
+				Name:         "slack",
+				Status:       atc.NotificationDeliveryFailed,
+				BuildID:      42,
+				BuildName:    "7",
+				JobName:      "some-job",
+				BuildStatus:  atc.StatusFailed,
+				Attempts:     5,
+				ResponseCode: 502,
+				LastError:    "unexpected response: 502 Bad Gateway",
+				UpdatedAt:    1000,
+			},
+			{
+				ID:          1,
+				Name:        "pager",
+				Status:      atc.NotificationDeliveryFailed,
+				BuildID:     41,
+				BuildName:   "6",
+				JobName:     "some-job",
+				BuildStatus: atc.StatusErrored,
+				Attempts:    1,
+				LastError:   "notification is no longer configured",
+				UpdatedAt:   900,
+			},
+		}
+	})
+
+	Context("when not specifying a pipeline name", func() {
+		It("fails and says you should give a pipeline name", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "notifications")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(1))
+
+			Expect(sess.Err).To(gbytes.Say("error: the required flag `" + osFlag("p", "pipeline") + "' was not specified"))
+		})
+	})
+
+	Context("when deliveries are returned from the API", func() {
+		BeforeEach(func() {
+			flyCmd = exec.Command(flyPath, "-t", targetName, "notifications", "-p", "pipeline")
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", expectedURL, "status=failed"),
+					ghttp.RespondWithJSONEncoded(200, deliveries),
+				),
+			)
+		})
+
+		It("shows the failed deliveries", func() {
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(sess).Should(gexec.Exit(0))
+
+			Expect(sess.Out).To(PrintTable(ui.Table{
+				Headers: ui.TableRow{
+					{Contents: "id", Color: color.New(color.Bold)},
+					{Contents: "notification", Color: color.New(color.Bold)},
+					{Contents: "job", Color: color.New(color.Bold)},
+					{Contents: "build", Color: color.New(color.Bold)},
+					{Contents: "build status", Color: color.New(color.Bold)},
+					{Contents: "status", Color: color.New(color.Bold)},
+					{Contents: "attempts", Color: color.New(color.Bold)},
+					{Contents: "response", Color: color.New(color.Bold)},
+					{Contents: "updated", Color: color.New(color.Bold)},
+					{Contents: "error", Color: color.New(color.Bold)},
+				},
+				Data: []ui.TableRow{
+					{
+						{Contents: "2"},
+						{Contents: "slack"},
+						{Contents: "some-job"},
+						{Contents: "7"},
+						{Contents: "failed", Color: color.New(color.FgRed)},
+						{Contents: "failed", Color: color.New(color.FgRed)},
+						{Contents: "5"},
+						{Contents: "502"},
+						{Contents: time.Unix(1000, 0).Format("2006-01-02@15:04:05-0700")},
+						{Contents: "unexpected response: 502 Bad Gateway"},
+					},
+					{
+						{Contents: "1"},
+						{Contents: "pager"},
+						{Contents: "some-job"},
+						{Contents: "6"},
+						{Contents: "errored", Color: color.New(color.FgRed, color.Bold)},
+						{Contents: "failed", Color: color.New(color.FgRed)},
+						{Contents: "1"},
+						{Contents: "n/a", Color: color.New(color.Faint)},
+						{Contents: time.Unix(900, 0).Format("2006-01-02@15:04:05-0700")},
+						{Contents: "notification is no longer configured"},
+					},
+				},
+			}))
+		})
+
+		Context("when --json is given", func() {
+			BeforeEach(func() {
+				flyCmd.Args = append(flyCmd.Args, "--json")
+			})
+
+			It("prints the deliveries as json", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+
+				var printed []atc.NotificationDelivery
+				Expect(json.Unmarshal(sess.Out.Contents(), &printed)).To(Succeed())
+				Expect(printed).To(Equal(deliveries))
+			})
+		})
+	})
+
+	Context("when --all is given", func() {
+		BeforeEach(func() {
+			flyCmd = exec.Command(flyPath, "-t", targetName, "notifications", "-p", "pipeline", "--all")
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", expectedURL, ""),
+					ghttp.RespondWithJSONEncoded(200, deliveries),
+				),
+			)
+		})
+
+		It("does not filter by status", func() {
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(sess).Should(gexec.Exit(0))
+		})
+	})
+
+	Context("when the pipeline does not exist", func() {
+		BeforeEach(func() {
+			flyCmd = exec.Command(flyPath, "-t", targetName, "notifications", "-p", "pipeline")
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", expectedURL),
+					ghttp.RespondWith(404, ""),
+				),
+			)
+		})
+
+		It("writes an error message to stderr", func() {
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(1))
+			Eventually(sess.Err).Should(gbytes.Say("pipeline not found"))
+		})
+	})
+})
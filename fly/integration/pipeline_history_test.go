@@ -0,0 +1,174 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"os/exec"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("pipeline-history", func() {
+	var (
+		flyCmd    *exec.Cmd
+		revisions []atc.PipelineConfigRevision
+	)
+
+	expectedURL := "/api/v1/teams/main/pipelines/pipeline/config/revisions"
+
+	BeforeEach(func() {
+		revisions = []atc.PipelineConfigRevision{
+			{
+				Revision:          2,
+				ConfigVersion:     5,
+				CreatedBy:         "some-user",
+				CreatedAt:         1000,
+				BuildID:           42,
+				BuildName:         "7",
+				BuildJobName:      "reconfigure",
+				BuildPipelineName: "ci",
+			},
+			{
+				Revision:      1,
+				ConfigVersion: 3,
+				CreatedBy:     "some-user",
+				CreatedAt:     900,
+			},
+		}
+	})
+
+	Context("when not specifying a pipeline name", func() {
+		It("fails and says you should give a pipeline name", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "pipeline-history")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(1))
+
+			Expect(sess.Err).To(gbytes.Say("error: the required flag `" + osFlag("p", "pipeline") + "' was not specified"))
+		})
+	})
+
+	Context("when revisions are returned from the API", func() {
+		BeforeEach(func() {
+			flyCmd = exec.Command(flyPath, "-t", targetName, "pipeline-history", "-p", "pipeline")
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", expectedURL),
+					ghttp.RespondWithJSONEncoded(200, revisions),
+				),
+			)
+		})
+
+		It("shows the revisions", func() {
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(sess).Should(gexec.Exit(0))
+
+			Expect(sess.Out).To(PrintTable(ui.Table{
+				Headers: ui.TableRow{
+					{Contents: "revision", Color: color.New(color.Bold)},
+					{Contents: "saved at", Color: color.New(color.Bold)},
+					{Contents: "saved by", Color: color.New(color.Bold)},
+					{Contents: "config version", Color: color.New(color.Bold)},
+				},
+				Data: []ui.TableRow{
+					{
+						{Contents: "2"},
+						{Contents: time.Unix(1000, 0).Format("2006-01-02@15:04:05-0700")},
+						{Contents: "some-user (set_pipeline ci/reconfigure #7)"},
+						{Contents: "5"},
+					},
+					{
+						{Contents: "1"},
+						{Contents: time.Unix(900, 0).Format("2006-01-02@15:04:05-0700")},
+						{Contents: "some-user"},
+						{Contents: "3"},
+					},
+				},
+			}))
+		})
+
+		Context("when --json is given", func() {
+			BeforeEach(func() {
+				flyCmd.Args = append(flyCmd.Args, "--json")
+			})
+
+			It("prints the revisions as json", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+
+				var printed []atc.PipelineConfigRevision
+				Expect(json.Unmarshal(sess.Out.Contents(), &printed)).To(Succeed())
+				Expect(printed).To(Equal(revisions))
+			})
+		})
+	})
+
+	Context("when a revision is given", func() {
+		BeforeEach(func() {
+			flyCmd = exec.Command(flyPath, "-t", targetName, "pipeline-history", "-p", "pipeline", "-r", "2")
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", expectedURL+"/2"),
+					ghttp.RespondWithJSONEncoded(200, atc.PipelineConfigRevision{
+						Revision:  2,
+						CreatedBy: "some-user",
+						Config: &atc.Config{
+							Jobs: atc.JobConfigs{{Name: "some-job"}, {Name: "some-new-job"}},
+						},
+					}),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", expectedURL+"/1"),
+					ghttp.RespondWithJSONEncoded(200, atc.PipelineConfigRevision{
+						Revision:  1,
+						CreatedBy: "some-user",
+						Config: &atc.Config{
+							Jobs: atc.JobConfigs{{Name: "some-job"}},
+						},
+					}),
+				),
+			)
+		})
+
+		It("shows the changes made by the revision", func() {
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(sess).Should(gexec.Exit(0))
+
+			Expect(sess.Out).To(gbytes.Say("revision 2, saved by some-user"))
+			Expect(sess.Out).To(gbytes.Say("job some-new-job has been added"))
+		})
+	})
+
+	Context("when the pipeline does not exist", func() {
+		BeforeEach(func() {
+			flyCmd = exec.Command(flyPath, "-t", targetName, "pipeline-history", "-p", "pipeline")
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", expectedURL),
+					ghttp.RespondWith(404, ""),
+				),
+			)
+		})
+
+		It("writes an error message to stderr", func() {
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(1))
+			Eventually(sess.Err).Should(gbytes.Say("pipeline not found"))
+		})
+	})
+})
@@ -0,0 +1,156 @@
+package integration_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os/exec"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("rollback-pipeline", func() {
+	var (
+		flyCmd *exec.Cmd
+		stdin  io.Writer
+		sess   *gexec.Session
+	)
+
+	configURL := "/api/v1/teams/main/pipelines/pipeline/config"
+	revisionURL := "/api/v1/teams/main/pipelines/pipeline/config/revisions/1"
+
+	BeforeEach(func() {
+		flyCmd = exec.Command(flyPath, "-t", targetName, "rollback-pipeline", "-p", "pipeline", "-r", "1")
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		stdin, err = flyCmd.StdinPipe()
+		Expect(err).NotTo(HaveOccurred())
+
+		sess, err = gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Context("when not specifying a revision", func() {
+		BeforeEach(func() {
+			flyCmd = exec.Command(flyPath, "-t", targetName, "rollback-pipeline", "-p", "pipeline")
+		})
+
+		It("fails and says you should give a revision", func() {
+			Eventually(sess).Should(gexec.Exit(1))
+			Expect(sess.Err).To(gbytes.Say("error: the required flag `" + osFlag("r", "revision") + "' was not specified"))
+		})
+	})
+
+	Context("when the revision exists", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", configURL),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, atc.ConfigResponse{Config: atc.Config{
+						Jobs: atc.JobConfigs{{Name: "some-job"}, {Name: "some-new-job"}},
+					}}, http.Header{atc.ConfigVersionHeader: {"42"}}),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", revisionURL),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, atc.PipelineConfigRevision{
+						Revision: 1,
+						Config: &atc.Config{
+							Jobs: atc.JobConfigs{{Name: "some-job"}},
+						},
+					}),
+				),
+			)
+		})
+
+		Context("when the user confirms", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", revisionURL+"/restore"),
+						ghttp.RespondWith(http.StatusOK, ""),
+					),
+				)
+			})
+
+			It("shows the changes and restores the revision", func() {
+				Eventually(sess).Should(gbytes.Say("job some-new-job has been removed"))
+				Eventually(sess).Should(gbytes.Say(`restore revision 1\? \[yN\]: `))
+				fmt.Fprintf(stdin, "y\r")
+
+				Eventually(sess).Should(gbytes.Say("restored revision 1 of `pipeline`"))
+				Eventually(sess).Should(gexec.Exit(0))
+			})
+		})
+
+		Context("when the user declines", func() {
+			It("does not restore the revision", func() {
+				Eventually(sess).Should(gbytes.Say(`restore revision 1\? \[yN\]: `))
+				fmt.Fprintf(stdin, "n\r")
+
+				Eventually(sess).Should(gbytes.Say("bailing out"))
+				Eventually(sess).Should(gexec.Exit(0))
+			})
+		})
+
+		Context("when running non-interactively", func() {
+			BeforeEach(func() {
+				flyCmd.Args = append(flyCmd.Args, "-n")
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", revisionURL+"/restore"),
+						ghttp.RespondWith(http.StatusOK, ""),
+					),
+				)
+			})
+
+			It("restores the revision without asking", func() {
+				Eventually(sess).Should(gbytes.Say("restored revision 1 of `pipeline`"))
+				Eventually(sess).Should(gexec.Exit(0))
+			})
+		})
+
+		Context("when checking credentials", func() {
+			BeforeEach(func() {
+				flyCmd.Args = append(flyCmd.Args, "-n", "--check-creds")
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", revisionURL+"/restore", "check_creds="),
+						ghttp.RespondWith(http.StatusOK, ""),
+					),
+				)
+			})
+
+			It("asks for them to be checked", func() {
+				Eventually(sess).Should(gbytes.Say("restored revision 1 of `pipeline`"))
+				Eventually(sess).Should(gexec.Exit(0))
+			})
+		})
+	})
+
+	Context("when the revision does not exist", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", configURL),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, atc.ConfigResponse{Config: atc.Config{}}, http.Header{atc.ConfigVersionHeader: {"42"}}),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", revisionURL),
+					ghttp.RespondWith(http.StatusNotFound, ""),
+				),
+			)
+		})
+
+		It("writes an error message to stderr", func() {
+			Eventually(sess).Should(gexec.Exit(1))
+			Eventually(sess.Err).Should(gbytes.Say("revision 1 of pipeline 'pipeline' not found"))
+		})
+	})
+})
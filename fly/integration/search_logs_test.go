@@ -0,0 +1,230 @@
+package integration_test
+
+import (
+	"net/http"
+	"net/url"
+	"os/exec"
+	"strconv"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("search-logs", func() {
+	var (
+		results  atc.BuildLogSearchResults
+		statusOK = http.StatusOK
+	)
+
+	expectedURL := "/api/v1/teams/main/pipelines/pipeline/logs/search"
+
+	BeforeEach(func() {
+		results = atc.BuildLogSearchResults{
+			Matches: []atc.BuildLogMatch{
+				{
+					BuildID:   42,
+					BuildName: "7",
+					JobName:   "some-job",
+					PlanID:    "some-plan",
+					StepName:  "unit",
+					Line:      12,
+					Text:      "FAIL: some-test",
+					Time:      1000,
+				},
+				{
+					BuildID:   43,
+					BuildName: "8",
+					JobName:   "some-job",
+					PlanID:    "other-plan",
+					Line:      3,
+					Text:      "FAIL: other-test",
+					Time:      2000,
+				},
+			},
+		}
+	})
+
+	run := func(args ...string) *gexec.Session {
+		flyCmd := exec.Command(flyPath, append([]string{"-t", targetName, "search-logs"}, args...)...)
+
+		sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).NotTo(HaveOccurred())
+
+		return sess
+	}
+
+	Context("when neither a pipeline nor a job is specified", func() {
+		It("fails", func() {
+			sess := run("FAIL")
+			Eventually(sess).Should(gexec.Exit(1))
+
+			Expect(sess.Err).To(gbytes.Say("either a pipeline or a job must be specified"))
+		})
+	})
+
+	Context("when no pattern is specified", func() {
+		It("fails", func() {
+			sess := run("-p", "pipeline")
+			Eventually(sess).Should(gexec.Exit(1))
+
+			Expect(sess.Err).To(gbytes.Say("the required argument `PATTERN` was not provided"))
+		})
+	})
+
+	Context("when searching a pipeline", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", expectedURL, "pattern=FAIL&limit=50"),
+					ghttp.RespondWithJSONEncodedPtr(&statusOK, &results),
+				),
+			)
+		})
+
+		It("shows the matching lines", func() {
+			sess := run("-p", "pipeline", "FAIL")
+			Eventually(sess).Should(gexec.Exit(0))
+
+			Expect(sess.Out).To(PrintTable(ui.Table{
+				Headers: ui.TableRow{
+					{Contents: "build", Color: color.New(color.Bold)},
+					{Contents: "step", Color: color.New(color.Bold)},
+					{Contents: "line", Color: color.New(color.Bold)},
+					{Contents: "time", Color: color.New(color.Bold)},
+					{Contents: "text", Color: color.New(color.Bold)},
+				},
+				Data: []ui.TableRow{
+					{
+						{Contents: "some-job/7"},
+						{Contents: "unit"},
+						{Contents: "12"},
+						{Contents: time.Unix(1000, 0).Format("2006-01-02@15:04:05-0700")},
+						{Contents: "FAIL: some-test"},
+					},
+					{
+						{Contents: "some-job/8"},
+						{Contents: "n/a", Color: color.New(color.Faint)},
+						{Contents: "3"},
+						{Contents: time.Unix(2000, 0).Format("2006-01-02@15:04:05-0700")},
+						{Contents: "FAIL: other-test"},
+					},
+				},
+			}))
+		})
+
+		Context("when the results are truncated", func() {
+			BeforeEach(func() {
+				results.Truncated = true
+			})
+
+			It("says so", func() {
+				sess := run("-p", "pipeline", "FAIL")
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Err).To(gbytes.Say("more matches may exist"))
+			})
+		})
+
+		Context("when builds in range have had their logs archived", func() {
+			BeforeEach(func() {
+				results.ArchivedBuilds = 2
+			})
+
+			It("says they were not searched", func() {
+				sess := run("-p", "pipeline", "FAIL")
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Err).To(gbytes.Say("2 builds in range were not searched because their logs have been archived"))
+			})
+		})
+
+		Context("when --json is given", func() {
+			It("prints the results as JSON", func() {
+				sess := run("-p", "pipeline", "--json", "FAIL")
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out.Contents()).To(MatchJSON(`{
+					"matches": [
+						{
+							"build_id": 42,
+							"build_name": "7",
+							"job_name": "some-job",
+							"plan_id": "some-plan",
+							"step_name": "unit",
+							"line": 12,
+							"text": "FAIL: some-test",
+							"time": 1000
+						},
+						{
+							"build_id": 43,
+							"build_name": "8",
+							"job_name": "some-job",
+							"plan_id": "other-plan",
+							"line": 3,
+							"text": "FAIL: other-test",
+							"time": 2000
+						}
+					],
+					"truncated": false
+				}`))
+			})
+		})
+	})
+
+	Context("when searching a job with a regex and a time range", func() {
+		BeforeEach(func() {
+			since, err := time.ParseInLocation("2006-01-02 15:04:05", "2020-01-01 00:00:00", time.Now().Location())
+			Expect(err).NotTo(HaveOccurred())
+
+			until, err := time.ParseInLocation("2006-01-02 15:04:05", "2020-01-02 00:00:00", time.Now().Location())
+			Expect(err).NotTo(HaveOccurred())
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", expectedURL),
+					func(w http.ResponseWriter, r *http.Request) {
+						Expect(r.URL.Query()).To(Equal(url.Values{
+							"pattern": {"FAIL.*"},
+							"regex":   {"true"},
+							"job":     {"some-job"},
+							"from":    {strconv.FormatInt(since.Unix(), 10)},
+							"to":      {strconv.FormatInt(until.Unix(), 10)},
+							"limit":   {"10"},
+						}))
+					},
+					ghttp.RespondWithJSONEncoded(200, results),
+				),
+			)
+		})
+
+		It("passes them to the search", func() {
+			sess := run("-j", "pipeline/some-job", "-e", "-c", "10", "--since", "2020-01-01 00:00:00", "--until", "2020-01-02 00:00:00", "FAIL.*")
+			Eventually(sess).Should(gexec.Exit(0))
+		})
+	})
+
+	Context("when the pipeline does not exist", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", expectedURL),
+					ghttp.RespondWith(404, ""),
+				),
+			)
+		})
+
+		It("fails", func() {
+			sess := run("-p", "pipeline", "FAIL")
+			Eventually(sess).Should(gexec.Exit(1))
+
+			Expect(sess.Err).To(gbytes.Say("pipeline not found"))
+		})
+	})
+})
@@ -0,0 +1,136 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"os/exec"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("secret-usages", func() {
+		var (
+			flyCmd *exec.Cmd
+			usages []atc.SecretUsage
+		)
+
+		expectedURL := "/api/v1/secret_usages"
+
+		BeforeEach(func() {
+			usages = []atc.SecretUsage{
+				{
+					Var:      "github.token",
+					TeamName: "main",
+					LastBuild: &atc.SecretUsageBuild{
+						Name:       "3",
+						ResolvedAt: 1000,
+					},
+				},
+				{
+					Var:                  "github",
+					TeamName:             "main",
+					PipelineID:           1,
+					PipelineName:         "some-pipeline",
+					PipelineInstanceVars: atc.InstanceVars{"branch": "main"},
+					Jobs:                 []string{"some-job"},
+					Resources:            []string{"some-repo"},
+					LastBuild: &atc.SecretUsageBuild{
+						ID:         42,
+						Name:       "7",
+						JobName:    "some-job",
+						ResolvedAt: 2000,
+					},
+				},
+				{
+					Var:          "github.token",
+					TeamName:     "other-team",
+					PipelineID:   2,
+					PipelineName: "other-pipeline",
+					VarSources:   []string{"some-vault"},
+				},
+			}
+
+			flyCmd = exec.Command(flyPath, "-t", targetName, "secret-usages", "--var", "github.token")
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", expectedURL, "var=github.token"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, usages),
+				),
+			)
+		})
+
+		It("lists where the var is used", func() {
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(sess).Should(gexec.Exit(0))
+
+			Expect(sess.Out).To(PrintTable(ui.Table{
+				Headers: ui.TableRow{
+					{Contents: "var", Color: color.New(color.Bold)},
+					{Contents: "team", Color: color.New(color.Bold)},
+					{Contents: "pipeline", Color: color.New(color.Bold)},
+					{Contents: "used by", Color: color.New(color.Bold)},
+					{Contents: "last build", Color: color.New(color.Bold)},
+				},
+				Data: []ui.TableRow{
+					{
+						{Contents: "github.token"},
+						{Contents: "main"},
+						{Contents: "n/a", Color: color.New(color.Faint)},
+						{Contents: "n/a", Color: color.New(color.Faint)},
+						{Contents: "#3"},
+					},
+					{
+						{Contents: "github"},
+						{Contents: "main"},
+						{Contents: "some-pipeline/branch:main"},
+						{Contents: "job some-job, resource some-repo"},
+						{Contents: "some-job/#7"},
+					},
+					{
+						{Contents: "github.token"},
+						{Contents: "other-team"},
+						{Contents: "other-pipeline"},
+						{Contents: "var source some-vault"},
+						{Contents: "n/a", Color: color.New(color.Faint)},
+					},
+				},
+			}))
+		})
+
+		Context("when --json is given", func() {
+			BeforeEach(func() {
+				flyCmd.Args = append(flyCmd.Args, "--json")
+			})
+
+			It("prints the usages as json", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(0))
+
+				var printed []atc.SecretUsage
+				Expect(json.Unmarshal(sess.Out.Contents(), &printed)).To(Succeed())
+				Expect(printed).To(Equal(usages))
+			})
+		})
+	})
+
+	Describe("secret-usages without --var", func() {
+		It("asks for the var", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "secret-usages")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(sess).Should(gexec.Exit(1))
+			Expect(sess.Err).To(gbytes.Say("the required flag `-v, --var' was not specified"))
+		})
+	})
+})
@@ -0,0 +1,87 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"os/exec"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("secrets", func() {
+		var (
+			flyCmd  *exec.Cmd
+			secrets []atc.Secret
+		)
+
+		expectedURL := "/api/v1/teams/main/secrets"
+
+		BeforeEach(func() {
+			secrets = []atc.Secret{
+				{Name: "some-secret", UpdatedBy: "some-user", UpdatedAt: 1000},
+				{Name: "some-pipeline-secret", Pipeline: "some-pipeline", UpdatedAt: 2000},
+			}
+
+			flyCmd = exec.Command(flyPath, "-t", targetName, "secrets")
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", expectedURL, ""),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, secrets),
+				),
+			)
+		})
+
+		It("lists the secrets", func() {
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(sess).Should(gexec.Exit(0))
+
+			Expect(sess.Out).To(PrintTable(ui.Table{
+				Headers: ui.TableRow{
+					{Contents: "name", Color: color.New(color.Bold)},
+					{Contents: "pipeline", Color: color.New(color.Bold)},
+					{Contents: "updated by", Color: color.New(color.Bold)},
+					{Contents: "updated", Color: color.New(color.Bold)},
+				},
+				Data: []ui.TableRow{
+					{
+						{Contents: "some-secret"},
+						{Contents: "n/a", Color: color.New(color.Faint)},
+						{Contents: "some-user"},
+						{Contents: time.Unix(1000, 0).Format("2006-01-02@15:04:05-0700")},
+					},
+					{
+						{Contents: "some-pipeline-secret"},
+						{Contents: "some-pipeline"},
+						{Contents: "n/a", Color: color.New(color.Faint)},
+						{Contents: time.Unix(2000, 0).Format("2006-01-02@15:04:05-0700")},
+					},
+				},
+			}))
+		})
+
+		Context("when --json is given", func() {
+			BeforeEach(func() {
+				flyCmd.Args = append(flyCmd.Args, "--json")
+			})
+
+			It("prints the secrets as json", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(0))
+
+				var printed []atc.Secret
+				Expect(json.Unmarshal(sess.Out.Contents(), &printed)).To(Succeed())
+				Expect(printed).To(Equal(secrets))
+			})
+		})
+	})
+})
@@ -1219,6 +1219,71 @@ this is super secure
 			})
 		})
 
+		Context("when the plan is requested", func() {
+			BeforeEach(func() {
+				path, err := atc.Routes.CreatePathForRoute(atc.PlanPipelineConfig, rata.Params{"pipeline_name": "awesome-pipeline", "team_name": "main"})
+				Expect(err).NotTo(HaveOccurred())
+
+				atcServer.RouteToHandler("POST", path, ghttp.CombineHandlers(
+					func(w http.ResponseWriter, r *http.Request) {
+						config := getConfig(r)
+						Expect(config).To(MatchYAML(payload))
+					},
+					ghttp.RespondWithJSONEncoded(http.StatusOK, atc.ConfigPlan{
+						RecheckedResources: []atc.ConfigPlanResource{
+							{Name: "some-resource", Reason: "source changed"},
+						},
+						RemovedJobs: []atc.ConfigPlanJob{
+							{Name: "some-job", Builds: 12},
+						},
+						OrphanedPins: []atc.ConfigPlanVersion{
+							{Resource: "some-resource", Version: atc.Version{"ref": "abc"}, Reason: "source changed"},
+						},
+						PassedConstraints: []atc.ConfigPlanPassedChange{
+							{Job: "some-other-job", Input: "some-input", Before: []string{"some-job"}, After: []string{}},
+						},
+					}),
+				))
+
+				config.Jobs[0].Name = "updated-name"
+			})
+
+			It("prints the plan after the diff", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "set-pipeline", "-p", "awesome-pipeline", "-c", configFile.Name(), "--plan", "-d")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gbytes.Say("plan:"))
+				Eventually(sess).Should(gbytes.Say(`resources which will start over with a new version history:\s+some-resource \(source changed\)`))
+				Eventually(sess).Should(gbytes.Say(`jobs which will lose their build history .*:\s+some-job \(12 builds\)`))
+				Eventually(sess).Should(gbytes.Say(`pinned versions which will be orphaned:\s+some-resource \{"ref":"abc"\} \(source changed\)`))
+				Eventually(sess).Should(gbytes.Say(`passed constraints which will change:\s+some-other-job/some-input: \[some-job\] -> \[\]`))
+				Eventually(sess).Should(gbytes.Say("Dry-run mode was set, exiting."))
+
+				Eventually(sess).Should(gexec.Exit(0))
+			})
+
+			Context("when the config has no effect on existing state", func() {
+				BeforeEach(func() {
+					path, err := atc.Routes.CreatePathForRoute(atc.PlanPipelineConfig, rata.Params{"pipeline_name": "awesome-pipeline", "team_name": "main"})
+					Expect(err).NotTo(HaveOccurred())
+
+					atcServer.RouteToHandler("POST", path, ghttp.RespondWithJSONEncoded(http.StatusOK, atc.ConfigPlan{}))
+				})
+
+				It("says so", func() {
+					flyCmd := exec.Command(flyPath, "-t", targetName, "set-pipeline", "-p", "awesome-pipeline", "-c", configFile.Name(), "--plan", "-d")
+
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess).Should(gbytes.Say("no effect on existing resources, jobs or versions"))
+					Eventually(sess).Should(gexec.Exit(0))
+				})
+			})
+		})
+
 		Context("when the pipeline is paused", func() {
 			AssertSuccessWithPausedPipelineHelp := func(expectCreationMessage bool) {
 				It("succeeds and prints a message to help the user", func() {
@@ -0,0 +1,119 @@
+package integration_test
+
+import (
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("set-secret", func() {
+		expectedURL := "/api/v1/teams/main/secrets/some-secret"
+
+		Context("when not specifying a secret name", func() {
+			It("fails and says you should give a secret name", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "set-secret", "-v", "some-value")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("error: the required flag `" + osFlag("s", "secret") + "' was not specified"))
+			})
+		})
+
+		Context("when a value is given", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", expectedURL, ""),
+						ghttp.VerifyJSONRepresenting(atc.SetSecretRequest{Value: "some-value"}),
+						ghttp.RespondWith(http.StatusNoContent, ""),
+					),
+				)
+			})
+
+			It("sets the secret for the team without printing its value", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "set-secret", "-s", "some-secret", "-v", "some-value")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out).To(gbytes.Say("secret 'some-secret' set for team 'main'"))
+				Expect(sess.Out.Contents()).ToNot(ContainSubstring("some-value"))
+			})
+		})
+
+		Context("when a pipeline is given", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", expectedURL, "pipeline=some-pipeline"),
+						ghttp.VerifyJSONRepresenting(atc.SetSecretRequest{Value: "some-value"}),
+						ghttp.RespondWith(http.StatusNoContent, ""),
+					),
+				)
+			})
+
+			It("sets the secret for the pipeline", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "set-secret", "-s", "some-secret", "-p", "some-pipeline", "-v", "some-value")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out).To(gbytes.Say("secret 'some-secret' set for pipeline 'some-pipeline'"))
+			})
+		})
+
+		Context("when the value is read from a file", func() {
+			var valuePath string
+
+			BeforeEach(func() {
+				valuePath = filepath.Join(GinkgoT().TempDir(), "value")
+				Expect(os.WriteFile(valuePath, []byte("some-file-value"), 0600)).To(Succeed())
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", expectedURL, ""),
+						ghttp.VerifyJSONRepresenting(atc.SetSecretRequest{Value: "some-file-value"}),
+						ghttp.RespondWith(http.StatusNoContent, ""),
+					),
+				)
+			})
+
+			It("sets the secret to the contents of the file", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "set-secret", "-s", "some-secret", "--value-from-file", valuePath)
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+			})
+		})
+
+		Context("when both a value and a file are given", func() {
+			It("fails", func() {
+				valuePath := filepath.Join(GinkgoT().TempDir(), "value")
+				Expect(os.WriteFile(valuePath, []byte("some-file-value"), 0600)).To(Succeed())
+
+				flyCmd := exec.Command(flyPath, "-t", targetName, "set-secret", "-s", "some-secret", "-v", "some-value", "--value-from-file", valuePath)
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("only one of --value and --value-from-file may be given"))
+			})
+		})
+	})
+})
@@ -1,6 +1,7 @@
 package integration_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -412,6 +413,55 @@ var _ = Describe("set-team", func() {
 			})
 		})
 
+		Describe("sending var sources", func() {
+			BeforeEach(func() {
+				cmdParams = []string{"-c", "fixtures/team_config_mixed.yml", "--var-source-config", "fixtures/team_var_sources.yml"}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/teams/venture"),
+						func(w http.ResponseWriter, r *http.Request) {
+							var team atc.Team
+							err := json.NewDecoder(r.Body).Decode(&team)
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(team.VarSources).To(Equal(&atc.VarSourceConfigs{
+								{
+									Name: "team-vault",
+									Type: "vault",
+									Config: map[string]any{
+										"url":          "https://vault.example.com",
+										"client_token": "((vault-token))",
+									},
+								},
+							}))
+						},
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.Team{
+							Name: "venture",
+							ID:   8,
+						}),
+					),
+				)
+			})
+
+			It("shows and sends the var sources", func() {
+				stdin, err := flyCmd.StdinPipe()
+				Expect(err).NotTo(HaveOccurred())
+
+				sess, err := gexec.Start(flyCmd, ginkgo.GinkgoWriter, ginkgo.GinkgoWriter)
+				Expect(err).ToNot(HaveOccurred())
+
+				Eventually(sess).Should(gbytes.Say(`var sources:\n- team-vault \(vault\)`))
+
+				Eventually(sess).Should(gbytes.Say(`apply team configuration\? \[yN\]: `))
+				yes(stdin)
+
+				Eventually(sess.Out).Should(gbytes.Say("team updated"))
+
+				Eventually(sess).Should(gexec.Exit(0))
+			})
+		})
+
 		Describe("handling server response", func() {
 			BeforeEach(func() {
 				cmdParams = []string{"-c", "fixtures/team_config_mixed.yml"}
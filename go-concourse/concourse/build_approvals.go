@@ -0,0 +1,58 @@
+package concourse
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+// ErrApprovalDecided is returned when approving or rejecting an approval which
+// has already been decided, timed out, or whose build has finished.
+var ErrApprovalDecided = errors.New("approval has already been decided")
+
+func (client *client) ListBuildApprovals(buildID string) ([]atc.BuildApproval, error) {
+	params := rata.Params{
+		"build_id": buildID,
+	}
+
+	var approvals []atc.BuildApproval
+	err := client.connection.Send(internal.Request{
+		RequestName: atc.ListBuildApprovals,
+		Params:      params,
+	}, &internal.Response{
+		Result: &approvals,
+	})
+
+	return approvals, err
+}
+
+func (client *client) ApproveBuildStep(buildID string, planID atc.PlanID) error {
+	return client.decideBuildStep(atc.ApproveBuildStep, buildID, planID)
+}
+
+func (client *client) RejectBuildStep(buildID string, planID atc.PlanID) error {
+	return client.decideBuildStep(atc.RejectBuildStep, buildID, planID)
+}
+
+func (client *client) decideBuildStep(requestName string, buildID string, planID atc.PlanID) error {
+	params := rata.Params{
+		"build_id": buildID,
+		"plan_id":  string(planID),
+	}
+
+	err := client.connection.Send(internal.Request{
+		RequestName: requestName,
+		Params:      params,
+	}, nil)
+
+	if unexpectedResponseError, ok := err.(internal.UnexpectedResponseError); ok {
+		if unexpectedResponseError.StatusCode == http.StatusConflict {
+			return ErrApprovalDecided
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,96 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Build Approvals", func() {
+	Describe("ListBuildApprovals", func() {
+		var expectedApprovals []atc.BuildApproval
+
+		BeforeEach(func() {
+			expectedApprovals = []atc.BuildApproval{
+				{
+					PlanID:    "some-plan-id",
+					Name:      "ship-it",
+					Status:    atc.ApprovalPending,
+					Users:     []string{"some-user"},
+					CreatedAt: 100,
+				},
+			}
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/builds/123/approvals"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, expectedApprovals),
+				),
+			)
+		})
+
+		It("returns the build's approvals", func() {
+			approvals, err := client.ListBuildApprovals("123")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(approvals).To(Equal(expectedApprovals))
+		})
+	})
+
+	Describe("ApproveBuildStep", func() {
+		expectedURL := "/api/v1/builds/123/approvals/some-plan-id/approve"
+
+		Context("when the approval is pending", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", expectedURL),
+						ghttp.RespondWith(http.StatusNoContent, ""),
+					),
+				)
+			})
+
+			It("approves it", func() {
+				err := client.ApproveBuildStep("123", "some-plan-id")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(atcServer.ReceivedRequests()).To(HaveLen(1))
+			})
+		})
+
+		Context("when the approval was already decided", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", expectedURL),
+						ghttp.RespondWith(http.StatusConflict, ""),
+					),
+				)
+			})
+
+			It("returns ErrApprovalDecided", func() {
+				err := client.ApproveBuildStep("123", "some-plan-id")
+				Expect(err).To(Equal(concourse.ErrApprovalDecided))
+			})
+		})
+	})
+
+	Describe("RejectBuildStep", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/builds/123/approvals/some-plan-id/reject"),
+					ghttp.RespondWith(http.StatusNoContent, ""),
+				),
+			)
+		})
+
+		It("rejects it", func() {
+			err := client.RejectBuildStep("123", "some-plan-id")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(atcServer.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+})
@@ -0,0 +1,70 @@
+package concourse
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+// BuildLogSearch is a search through the logs of a pipeline's builds. From and
+// To are unix timestamps bounding when the searched builds started, and are
+// ignored if zero, as is Limit.
+type BuildLogSearch struct {
+	Pattern string
+	Regex   bool
+	Jobs    []string
+	From    int64
+	To      int64
+	Limit   int
+}
+
+func (team *team) SearchBuildLogs(pipelineRef atc.PipelineRef, search BuildLogSearch) (atc.BuildLogSearchResults, bool, error) {
+	params := rata.Params{
+		"pipeline_name": pipelineRef.Name,
+		"team_name":     team.Name(),
+	}
+
+	query := url.Values{}
+	query.Set("pattern", search.Pattern)
+
+	if search.Regex {
+		query.Set("regex", "true")
+	}
+
+	for _, job := range search.Jobs {
+		query.Add("job", job)
+	}
+
+	if search.From != 0 {
+		query.Set(atc.PaginationQueryFrom, strconv.FormatInt(search.From, 10))
+	}
+
+	if search.To != 0 {
+		query.Set(atc.PaginationQueryTo, strconv.FormatInt(search.To, 10))
+	}
+
+	if search.Limit != 0 {
+		query.Set(atc.PaginationQueryLimit, strconv.Itoa(search.Limit))
+	}
+
+	var results atc.BuildLogSearchResults
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.SearchPipelineBuildLogs,
+		Params:      params,
+		Query:       merge(query, pipelineRef.QueryParams()),
+	}, &internal.Response{
+		Result: &results,
+	})
+
+	switch err.(type) {
+	case nil:
+		return results, true, nil
+	case internal.ResourceNotFoundError:
+		return atc.BuildLogSearchResults{}, false, nil
+	default:
+		return atc.BuildLogSearchResults{}, false, err
+	}
+}
@@ -0,0 +1,102 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Build Log Search", func() {
+	Describe("SearchBuildLogs", func() {
+		expectedURL := "/api/v1/teams/some-team/pipelines/mypipeline/logs/search"
+		pipelineRef := atc.PipelineRef{Name: "mypipeline", InstanceVars: atc.InstanceVars{"branch": "master"}}
+
+		var (
+			search      concourse.BuildLogSearch
+			queryParams string
+		)
+
+		BeforeEach(func() {
+			search = concourse.BuildLogSearch{Pattern: "FAIL"}
+			queryParams = "pattern=FAIL&vars.branch=%22master%22"
+		})
+
+		Context("when the pipeline exists", func() {
+			var expectedResults atc.BuildLogSearchResults
+
+			BeforeEach(func() {
+				expectedResults = atc.BuildLogSearchResults{
+					Matches: []atc.BuildLogMatch{
+						{
+							BuildID:   42,
+							BuildName: "7",
+							JobName:   "some-job",
+							PlanID:    "some-plan",
+							StepName:  "unit",
+							Line:      12,
+							Text:      "FAIL: some-test",
+							Time:      100,
+						},
+					},
+					Truncated: true,
+				}
+			})
+
+			JustBeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, queryParams),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedResults),
+					),
+				)
+			})
+
+			It("returns the results", func() {
+				results, found, err := team.SearchBuildLogs(pipelineRef, search)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(results).To(Equal(expectedResults))
+			})
+
+			Context("when every option is given", func() {
+				BeforeEach(func() {
+					search = concourse.BuildLogSearch{
+						Pattern: "FAIL.*",
+						Regex:   true,
+						Jobs:    []string{"some-job", "other-job"},
+						From:    10,
+						To:      20,
+						Limit:   5,
+					}
+					queryParams = "from=10&job=some-job&job=other-job&limit=5&pattern=FAIL.%2A&regex=true&to=20&vars.branch=%22master%22"
+				})
+
+				It("sends them as query params", func() {
+					_, _, err := team.SearchBuildLogs(pipelineRef, search)
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+		})
+
+		Context("when the pipeline does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, queryParams),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns not found", func() {
+				_, found, err := team.SearchBuildLogs(pipelineRef, search)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+})
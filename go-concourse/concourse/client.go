@@ -21,6 +21,9 @@ type Client interface {
 	BuildResources(buildID int) (atc.BuildInputsOutputs, bool, error)
 	ListBuildArtifacts(buildID string) ([]atc.WorkerArtifact, error)
 	AbortBuild(buildID string, force bool) error
+	ListBuildApprovals(buildID string) ([]atc.BuildApproval, error)
+	ApproveBuildStep(buildID string, planID atc.PlanID) error
+	RejectBuildStep(buildID string, planID atc.PlanID) error
 	BuildPlan(buildID int) (atc.PublicBuildPlan, bool, error)
 	SaveWorker(atc.Worker, *time.Duration) (*atc.Worker, error)
 	ListWorkers() ([]atc.Worker, error)
@@ -44,6 +47,8 @@ type Client interface {
 	UnpauseComponent(componentName string) error
 	PauseAllComponents() error
 	UnpauseAllComponents() error
+	ListSecretUsages(varName string) ([]atc.SecretUsage, error)
+	ClearSecretCache(teamName string, varName string) error
 }
 
 var _ Client = (*client)(nil)
@@ -24,6 +24,18 @@ type FakeClient struct {
 	abortBuildReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ApproveBuildStepStub        func(string, atc.PlanID) error
+	approveBuildStepMutex       sync.RWMutex
+	approveBuildStepArgsForCall []struct {
+		arg1 string
+		arg2 atc.PlanID
+	}
+	approveBuildStepReturns struct {
+		result1 error
+	}
+	approveBuildStepReturnsOnCall map[int]struct {
+		result1 error
+	}
 	BuildStub        func(string) (atc.Build, bool, error)
 	buildMutex       sync.RWMutex
 	buildArgsForCall []struct {
@@ -97,6 +109,18 @@ type FakeClient struct {
 		result2 concourse.Pagination
 		result3 error
 	}
+	ClearSecretCacheStub        func(string, string) error
+	clearSecretCacheMutex       sync.RWMutex
+	clearSecretCacheArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	clearSecretCacheReturns struct {
+		result1 error
+	}
+	clearSecretCacheReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ClearWallStub        func() error
 	clearWallMutex       sync.RWMutex
 	clearWallArgsForCall []struct {
@@ -219,6 +243,19 @@ type FakeClient struct {
 		result1 []atc.Job
 		result2 error
 	}
+	ListBuildApprovalsStub        func(string) ([]atc.BuildApproval, error)
+	listBuildApprovalsMutex       sync.RWMutex
+	listBuildApprovalsArgsForCall []struct {
+		arg1 string
+	}
+	listBuildApprovalsReturns struct {
+		result1 []atc.BuildApproval
+		result2 error
+	}
+	listBuildApprovalsReturnsOnCall map[int]struct {
+		result1 []atc.BuildApproval
+		result2 error
+	}
 	ListBuildArtifactsStub        func(string) ([]atc.WorkerArtifact, error)
 	listBuildArtifactsMutex       sync.RWMutex
 	listBuildArtifactsArgsForCall []struct {
@@ -256,6 +293,19 @@ type FakeClient struct {
 		result1 []atc.Pipeline
 		result2 error
 	}
+	ListSecretUsagesStub        func(string) ([]atc.SecretUsage, error)
+	listSecretUsagesMutex       sync.RWMutex
+	listSecretUsagesArgsForCall []struct {
+		arg1 string
+	}
+	listSecretUsagesReturns struct {
+		result1 []atc.SecretUsage
+		result2 error
+	}
+	listSecretUsagesReturnsOnCall map[int]struct {
+		result1 []atc.SecretUsage
+		result2 error
+	}
 	ListTeamsStub        func() ([]atc.Team, error)
 	listTeamsMutex       sync.RWMutex
 	listTeamsArgsForCall []struct {
@@ -312,6 +362,18 @@ type FakeClient struct {
 	pruneWorkerReturnsOnCall map[int]struct {
 		result1 error
 	}
+	RejectBuildStepStub        func(string, atc.PlanID) error
+	rejectBuildStepMutex       sync.RWMutex
+	rejectBuildStepArgsForCall []struct {
+		arg1 string
+		arg2 atc.PlanID
+	}
+	rejectBuildStepReturns struct {
+		result1 error
+	}
+	rejectBuildStepReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SaveWorkerStub        func(atc.Worker, *time.Duration) (*atc.Worker, error)
 	saveWorkerMutex       sync.RWMutex
 	saveWorkerArgsForCall []struct {
@@ -457,6 +519,68 @@ func (fake *FakeClient) AbortBuildReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeClient) ApproveBuildStep(arg1 string, arg2 atc.PlanID) error {
+	fake.approveBuildStepMutex.Lock()
+	ret, specificReturn := fake.approveBuildStepReturnsOnCall[len(fake.approveBuildStepArgsForCall)]
+	fake.approveBuildStepArgsForCall = append(fake.approveBuildStepArgsForCall, struct {
+		arg1 string
+		arg2 atc.PlanID
+	}{arg1, arg2})
+	stub := fake.ApproveBuildStepStub
+	fakeReturns := fake.approveBuildStepReturns
+	fake.recordInvocation("ApproveBuildStep", []interface{}{arg1, arg2})
+	fake.approveBuildStepMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeClient) ApproveBuildStepCallCount() int {
+	fake.approveBuildStepMutex.RLock()
+	defer fake.approveBuildStepMutex.RUnlock()
+	return len(fake.approveBuildStepArgsForCall)
+}
+
+func (fake *FakeClient) ApproveBuildStepCalls(stub func(string, atc.PlanID) error) {
+	fake.approveBuildStepMutex.Lock()
+	defer fake.approveBuildStepMutex.Unlock()
+	fake.ApproveBuildStepStub = stub
+}
+
+func (fake *FakeClient) ApproveBuildStepArgsForCall(i int) (string, atc.PlanID) {
+	fake.approveBuildStepMutex.RLock()
+	defer fake.approveBuildStepMutex.RUnlock()
+	argsForCall := fake.approveBuildStepArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClient) ApproveBuildStepReturns(result1 error) {
+	fake.approveBuildStepMutex.Lock()
+	defer fake.approveBuildStepMutex.Unlock()
+	fake.ApproveBuildStepStub = nil
+	fake.approveBuildStepReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) ApproveBuildStepReturnsOnCall(i int, result1 error) {
+	fake.approveBuildStepMutex.Lock()
+	defer fake.approveBuildStepMutex.Unlock()
+	fake.ApproveBuildStepStub = nil
+	if fake.approveBuildStepReturnsOnCall == nil {
+		fake.approveBuildStepReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.approveBuildStepReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeClient) Build(arg1 string) (atc.Build, bool, error) {
 	fake.buildMutex.Lock()
 	ret, specificReturn := fake.buildReturnsOnCall[len(fake.buildArgsForCall)]
@@ -789,6 +913,68 @@ func (fake *FakeClient) BuildsReturnsOnCall(i int, result1 []atc.Build, result2
 	}{result1, result2, result3}
 }
 
+func (fake *FakeClient) ClearSecretCache(arg1 string, arg2 string) error {
+	fake.clearSecretCacheMutex.Lock()
+	ret, specificReturn := fake.clearSecretCacheReturnsOnCall[len(fake.clearSecretCacheArgsForCall)]
+	fake.clearSecretCacheArgsForCall = append(fake.clearSecretCacheArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ClearSecretCacheStub
+	fakeReturns := fake.clearSecretCacheReturns
+	fake.recordInvocation("ClearSecretCache", []interface{}{arg1, arg2})
+	fake.clearSecretCacheMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeClient) ClearSecretCacheCallCount() int {
+	fake.clearSecretCacheMutex.RLock()
+	defer fake.clearSecretCacheMutex.RUnlock()
+	return len(fake.clearSecretCacheArgsForCall)
+}
+
+func (fake *FakeClient) ClearSecretCacheCalls(stub func(string, string) error) {
+	fake.clearSecretCacheMutex.Lock()
+	defer fake.clearSecretCacheMutex.Unlock()
+	fake.ClearSecretCacheStub = stub
+}
+
+func (fake *FakeClient) ClearSecretCacheArgsForCall(i int) (string, string) {
+	fake.clearSecretCacheMutex.RLock()
+	defer fake.clearSecretCacheMutex.RUnlock()
+	argsForCall := fake.clearSecretCacheArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClient) ClearSecretCacheReturns(result1 error) {
+	fake.clearSecretCacheMutex.Lock()
+	defer fake.clearSecretCacheMutex.Unlock()
+	fake.ClearSecretCacheStub = nil
+	fake.clearSecretCacheReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) ClearSecretCacheReturnsOnCall(i int, result1 error) {
+	fake.clearSecretCacheMutex.Lock()
+	defer fake.clearSecretCacheMutex.Unlock()
+	fake.ClearSecretCacheStub = nil
+	if fake.clearSecretCacheReturnsOnCall == nil {
+		fake.clearSecretCacheReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.clearSecretCacheReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeClient) ClearWall() error {
 	fake.clearWallMutex.Lock()
 	ret, specificReturn := fake.clearWallReturnsOnCall[len(fake.clearWallArgsForCall)]
@@ -1377,6 +1563,70 @@ func (fake *FakeClient) ListAllJobsReturnsOnCall(i int, result1 []atc.Job, resul
 	}{result1, result2}
 }
 
+func (fake *FakeClient) ListBuildApprovals(arg1 string) ([]atc.BuildApproval, error) {
+	fake.listBuildApprovalsMutex.Lock()
+	ret, specificReturn := fake.listBuildApprovalsReturnsOnCall[len(fake.listBuildApprovalsArgsForCall)]
+	fake.listBuildApprovalsArgsForCall = append(fake.listBuildApprovalsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ListBuildApprovalsStub
+	fakeReturns := fake.listBuildApprovalsReturns
+	fake.recordInvocation("ListBuildApprovals", []interface{}{arg1})
+	fake.listBuildApprovalsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) ListBuildApprovalsCallCount() int {
+	fake.listBuildApprovalsMutex.RLock()
+	defer fake.listBuildApprovalsMutex.RUnlock()
+	return len(fake.listBuildApprovalsArgsForCall)
+}
+
+func (fake *FakeClient) ListBuildApprovalsCalls(stub func(string) ([]atc.BuildApproval, error)) {
+	fake.listBuildApprovalsMutex.Lock()
+	defer fake.listBuildApprovalsMutex.Unlock()
+	fake.ListBuildApprovalsStub = stub
+}
+
+func (fake *FakeClient) ListBuildApprovalsArgsForCall(i int) string {
+	fake.listBuildApprovalsMutex.RLock()
+	defer fake.listBuildApprovalsMutex.RUnlock()
+	argsForCall := fake.listBuildApprovalsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) ListBuildApprovalsReturns(result1 []atc.BuildApproval, result2 error) {
+	fake.listBuildApprovalsMutex.Lock()
+	defer fake.listBuildApprovalsMutex.Unlock()
+	fake.ListBuildApprovalsStub = nil
+	fake.listBuildApprovalsReturns = struct {
+		result1 []atc.BuildApproval
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) ListBuildApprovalsReturnsOnCall(i int, result1 []atc.BuildApproval, result2 error) {
+	fake.listBuildApprovalsMutex.Lock()
+	defer fake.listBuildApprovalsMutex.Unlock()
+	fake.ListBuildApprovalsStub = nil
+	if fake.listBuildApprovalsReturnsOnCall == nil {
+		fake.listBuildApprovalsReturnsOnCall = make(map[int]struct {
+			result1 []atc.BuildApproval
+			result2 error
+		})
+	}
+	fake.listBuildApprovalsReturnsOnCall[i] = struct {
+		result1 []atc.BuildApproval
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) ListBuildArtifacts(arg1 string) ([]atc.WorkerArtifact, error) {
 	fake.listBuildArtifactsMutex.Lock()
 	ret, specificReturn := fake.listBuildArtifactsReturnsOnCall[len(fake.listBuildArtifactsArgsForCall)]
@@ -1553,6 +1803,70 @@ func (fake *FakeClient) ListPipelinesReturnsOnCall(i int, result1 []atc.Pipeline
 	}{result1, result2}
 }
 
+func (fake *FakeClient) ListSecretUsages(arg1 string) ([]atc.SecretUsage, error) {
+	fake.listSecretUsagesMutex.Lock()
+	ret, specificReturn := fake.listSecretUsagesReturnsOnCall[len(fake.listSecretUsagesArgsForCall)]
+	fake.listSecretUsagesArgsForCall = append(fake.listSecretUsagesArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ListSecretUsagesStub
+	fakeReturns := fake.listSecretUsagesReturns
+	fake.recordInvocation("ListSecretUsages", []interface{}{arg1})
+	fake.listSecretUsagesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) ListSecretUsagesCallCount() int {
+	fake.listSecretUsagesMutex.RLock()
+	defer fake.listSecretUsagesMutex.RUnlock()
+	return len(fake.listSecretUsagesArgsForCall)
+}
+
+func (fake *FakeClient) ListSecretUsagesCalls(stub func(string) ([]atc.SecretUsage, error)) {
+	fake.listSecretUsagesMutex.Lock()
+	defer fake.listSecretUsagesMutex.Unlock()
+	fake.ListSecretUsagesStub = stub
+}
+
+func (fake *FakeClient) ListSecretUsagesArgsForCall(i int) string {
+	fake.listSecretUsagesMutex.RLock()
+	defer fake.listSecretUsagesMutex.RUnlock()
+	argsForCall := fake.listSecretUsagesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) ListSecretUsagesReturns(result1 []atc.SecretUsage, result2 error) {
+	fake.listSecretUsagesMutex.Lock()
+	defer fake.listSecretUsagesMutex.Unlock()
+	fake.ListSecretUsagesStub = nil
+	fake.listSecretUsagesReturns = struct {
+		result1 []atc.SecretUsage
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) ListSecretUsagesReturnsOnCall(i int, result1 []atc.SecretUsage, result2 error) {
+	fake.listSecretUsagesMutex.Lock()
+	defer fake.listSecretUsagesMutex.Unlock()
+	fake.ListSecretUsagesStub = nil
+	if fake.listSecretUsagesReturnsOnCall == nil {
+		fake.listSecretUsagesReturnsOnCall = make(map[int]struct {
+			result1 []atc.SecretUsage
+			result2 error
+		})
+	}
+	fake.listSecretUsagesReturnsOnCall[i] = struct {
+		result1 []atc.SecretUsage
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) ListTeams() ([]atc.Team, error) {
 	fake.listTeamsMutex.Lock()
 	ret, specificReturn := fake.listTeamsReturnsOnCall[len(fake.listTeamsArgsForCall)]
@@ -1840,6 +2154,68 @@ func (fake *FakeClient) PruneWorkerReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeClient) RejectBuildStep(arg1 string, arg2 atc.PlanID) error {
+	fake.rejectBuildStepMutex.Lock()
+	ret, specificReturn := fake.rejectBuildStepReturnsOnCall[len(fake.rejectBuildStepArgsForCall)]
+	fake.rejectBuildStepArgsForCall = append(fake.rejectBuildStepArgsForCall, struct {
+		arg1 string
+		arg2 atc.PlanID
+	}{arg1, arg2})
+	stub := fake.RejectBuildStepStub
+	fakeReturns := fake.rejectBuildStepReturns
+	fake.recordInvocation("RejectBuildStep", []interface{}{arg1, arg2})
+	fake.rejectBuildStepMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeClient) RejectBuildStepCallCount() int {
+	fake.rejectBuildStepMutex.RLock()
+	defer fake.rejectBuildStepMutex.RUnlock()
+	return len(fake.rejectBuildStepArgsForCall)
+}
+
+func (fake *FakeClient) RejectBuildStepCalls(stub func(string, atc.PlanID) error) {
+	fake.rejectBuildStepMutex.Lock()
+	defer fake.rejectBuildStepMutex.Unlock()
+	fake.RejectBuildStepStub = stub
+}
+
+func (fake *FakeClient) RejectBuildStepArgsForCall(i int) (string, atc.PlanID) {
+	fake.rejectBuildStepMutex.RLock()
+	defer fake.rejectBuildStepMutex.RUnlock()
+	argsForCall := fake.rejectBuildStepArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClient) RejectBuildStepReturns(result1 error) {
+	fake.rejectBuildStepMutex.Lock()
+	defer fake.rejectBuildStepMutex.Unlock()
+	fake.RejectBuildStepStub = nil
+	fake.rejectBuildStepReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) RejectBuildStepReturnsOnCall(i int, result1 error) {
+	fake.rejectBuildStepMutex.Lock()
+	defer fake.rejectBuildStepMutex.Unlock()
+	fake.RejectBuildStepStub = nil
+	if fake.rejectBuildStepReturnsOnCall == nil {
+		fake.rejectBuildStepReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.rejectBuildStepReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeClient) SaveWorker(arg1 atc.Worker, arg2 *time.Duration) (*atc.Worker, error) {
 	fake.saveWorkerMutex.Lock()
 	ret, specificReturn := fake.saveWorkerReturnsOnCall[len(fake.saveWorkerArgsForCall)]
@@ -329,6 +329,20 @@ type FakeTeam struct {
 		result1 bool
 		result2 error
 	}
+	DeleteSecretStub        func(string, string) (bool, error)
+	deleteSecretMutex       sync.RWMutex
+	deleteSecretArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	deleteSecretReturns struct {
+		result1 bool
+		result2 error
+	}
+	deleteSecretReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	DestroyTeamStub        func(string) error
 	destroyTeamMutex       sync.RWMutex
 	destroyTeamArgsForCall []struct {
@@ -589,6 +603,22 @@ type FakeTeam struct {
 	nameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	NotificationDeliveriesStub        func(atc.PipelineRef, []atc.NotificationDeliveryStatus) ([]atc.NotificationDelivery, bool, error)
+	notificationDeliveriesMutex       sync.RWMutex
+	notificationDeliveriesArgsForCall []struct {
+		arg1 atc.PipelineRef
+		arg2 []atc.NotificationDeliveryStatus
+	}
+	notificationDeliveriesReturns struct {
+		result1 []atc.NotificationDelivery
+		result2 bool
+		result3 error
+	}
+	notificationDeliveriesReturnsOnCall map[int]struct {
+		result1 []atc.NotificationDelivery
+		result2 bool
+		result3 error
+	}
 	OrderingPipelinesStub        func([]string) error
 	orderingPipelinesMutex       sync.RWMutex
 	orderingPipelinesArgsForCall []struct {
@@ -704,6 +734,51 @@ type FakeTeam struct {
 		result3 bool
 		result4 error
 	}
+	PipelineConfigRevisionStub        func(atc.PipelineRef, int) (atc.PipelineConfigRevision, bool, error)
+	pipelineConfigRevisionMutex       sync.RWMutex
+	pipelineConfigRevisionArgsForCall []struct {
+		arg1 atc.PipelineRef
+		arg2 int
+	}
+	pipelineConfigRevisionReturns struct {
+		result1 atc.PipelineConfigRevision
+		result2 bool
+		result3 error
+	}
+	pipelineConfigRevisionReturnsOnCall map[int]struct {
+		result1 atc.PipelineConfigRevision
+		result2 bool
+		result3 error
+	}
+	PipelineConfigRevisionsStub        func(atc.PipelineRef) ([]atc.PipelineConfigRevision, bool, error)
+	pipelineConfigRevisionsMutex       sync.RWMutex
+	pipelineConfigRevisionsArgsForCall []struct {
+		arg1 atc.PipelineRef
+	}
+	pipelineConfigRevisionsReturns struct {
+		result1 []atc.PipelineConfigRevision
+		result2 bool
+		result3 error
+	}
+	pipelineConfigRevisionsReturnsOnCall map[int]struct {
+		result1 []atc.PipelineConfigRevision
+		result2 bool
+		result3 error
+	}
+	PlanPipelineConfigStub        func(atc.PipelineRef, []byte) (atc.ConfigPlan, error)
+	planPipelineConfigMutex       sync.RWMutex
+	planPipelineConfigArgsForCall []struct {
+		arg1 atc.PipelineRef
+		arg2 []byte
+	}
+	planPipelineConfigReturns struct {
+		result1 atc.ConfigPlan
+		result2 error
+	}
+	planPipelineConfigReturnsOnCall map[int]struct {
+		result1 atc.ConfigPlan
+		result2 error
+	}
 	RenamePipelineStub        func(string, string) (bool, []concourse.ConfigWarning, error)
 	renamePipelineMutex       sync.RWMutex
 	renamePipelineArgsForCall []struct {
@@ -802,6 +877,21 @@ type FakeTeam struct {
 		result3 bool
 		result4 error
 	}
+	RestorePipelineConfigRevisionStub        func(atc.PipelineRef, int, bool) (bool, error)
+	restorePipelineConfigRevisionMutex       sync.RWMutex
+	restorePipelineConfigRevisionArgsForCall []struct {
+		arg1 atc.PipelineRef
+		arg2 int
+		arg3 bool
+	}
+	restorePipelineConfigRevisionReturns struct {
+		result1 bool
+		result2 error
+	}
+	restorePipelineConfigRevisionReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	ScheduleJobStub        func(atc.PipelineRef, string) (bool, error)
 	scheduleJobMutex       sync.RWMutex
 	scheduleJobArgsForCall []struct {
@@ -816,6 +906,35 @@ type FakeTeam struct {
 		result1 bool
 		result2 error
 	}
+	SearchBuildLogsStub        func(atc.PipelineRef, concourse.BuildLogSearch) (atc.BuildLogSearchResults, bool, error)
+	searchBuildLogsMutex       sync.RWMutex
+	searchBuildLogsArgsForCall []struct {
+		arg1 atc.PipelineRef
+		arg2 concourse.BuildLogSearch
+	}
+	searchBuildLogsReturns struct {
+		result1 atc.BuildLogSearchResults
+		result2 bool
+		result3 error
+	}
+	searchBuildLogsReturnsOnCall map[int]struct {
+		result1 atc.BuildLogSearchResults
+		result2 bool
+		result3 error
+	}
+	SecretsStub        func(string) ([]atc.Secret, error)
+	secretsMutex       sync.RWMutex
+	secretsArgsForCall []struct {
+		arg1 string
+	}
+	secretsReturns struct {
+		result1 []atc.Secret
+		result2 error
+	}
+	secretsReturnsOnCall map[int]struct {
+		result1 []atc.Secret
+		result2 error
+	}
 	SetJobBuildCommentStub        func(atc.PipelineRef, string, string, string) (bool, error)
 	setJobBuildCommentMutex       sync.RWMutex
 	setJobBuildCommentArgsForCall []struct {
@@ -847,6 +966,19 @@ type FakeTeam struct {
 		result1 bool
 		result2 error
 	}
+	SetSecretStub        func(string, string, string) error
+	setSecretMutex       sync.RWMutex
+	setSecretArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	setSecretReturns struct {
+		result1 error
+	}
+	setSecretReturnsOnCall map[int]struct {
+		result1 error
+	}
 	UnpauseJobStub        func(atc.PipelineRef, string) (bool, error)
 	unpauseJobMutex       sync.RWMutex
 	unpauseJobArgsForCall []struct {
@@ -2288,6 +2420,71 @@ func (fake *FakeTeam) DeletePipelineReturnsOnCall(i int, result1 bool, result2 e
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) DeleteSecret(arg1 string, arg2 string) (bool, error) {
+	fake.deleteSecretMutex.Lock()
+	ret, specificReturn := fake.deleteSecretReturnsOnCall[len(fake.deleteSecretArgsForCall)]
+	fake.deleteSecretArgsForCall = append(fake.deleteSecretArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.DeleteSecretStub
+	fakeReturns := fake.deleteSecretReturns
+	fake.recordInvocation("DeleteSecret", []interface{}{arg1, arg2})
+	fake.deleteSecretMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) DeleteSecretCallCount() int {
+	fake.deleteSecretMutex.RLock()
+	defer fake.deleteSecretMutex.RUnlock()
+	return len(fake.deleteSecretArgsForCall)
+}
+
+func (fake *FakeTeam) DeleteSecretCalls(stub func(string, string) (bool, error)) {
+	fake.deleteSecretMutex.Lock()
+	defer fake.deleteSecretMutex.Unlock()
+	fake.DeleteSecretStub = stub
+}
+
+func (fake *FakeTeam) DeleteSecretArgsForCall(i int) (string, string) {
+	fake.deleteSecretMutex.RLock()
+	defer fake.deleteSecretMutex.RUnlock()
+	argsForCall := fake.deleteSecretArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) DeleteSecretReturns(result1 bool, result2 error) {
+	fake.deleteSecretMutex.Lock()
+	defer fake.deleteSecretMutex.Unlock()
+	fake.DeleteSecretStub = nil
+	fake.deleteSecretReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) DeleteSecretReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.deleteSecretMutex.Lock()
+	defer fake.deleteSecretMutex.Unlock()
+	fake.DeleteSecretStub = nil
+	if fake.deleteSecretReturnsOnCall == nil {
+		fake.deleteSecretReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.deleteSecretReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) DestroyTeam(arg1 string) error {
 	fake.destroyTeamMutex.Lock()
 	ret, specificReturn := fake.destroyTeamReturnsOnCall[len(fake.destroyTeamArgsForCall)]
@@ -3492,6 +3689,79 @@ func (fake *FakeTeam) NameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeTeam) NotificationDeliveries(arg1 atc.PipelineRef, arg2 []atc.NotificationDeliveryStatus) ([]atc.NotificationDelivery, bool, error) {
+	var arg2Copy []atc.NotificationDeliveryStatus
+	if arg2 != nil {
+		arg2Copy = make([]atc.NotificationDeliveryStatus, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.notificationDeliveriesMutex.Lock()
+	ret, specificReturn := fake.notificationDeliveriesReturnsOnCall[len(fake.notificationDeliveriesArgsForCall)]
+	fake.notificationDeliveriesArgsForCall = append(fake.notificationDeliveriesArgsForCall, struct {
+		arg1 atc.PipelineRef
+		arg2 []atc.NotificationDeliveryStatus
+	}{arg1, arg2Copy})
+	stub := fake.NotificationDeliveriesStub
+	fakeReturns := fake.notificationDeliveriesReturns
+	fake.recordInvocation("NotificationDeliveries", []interface{}{arg1, arg2Copy})
+	fake.notificationDeliveriesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) NotificationDeliveriesCallCount() int {
+	fake.notificationDeliveriesMutex.RLock()
+	defer fake.notificationDeliveriesMutex.RUnlock()
+	return len(fake.notificationDeliveriesArgsForCall)
+}
+
+func (fake *FakeTeam) NotificationDeliveriesCalls(stub func(atc.PipelineRef, []atc.NotificationDeliveryStatus) ([]atc.NotificationDelivery, bool, error)) {
+	fake.notificationDeliveriesMutex.Lock()
+	defer fake.notificationDeliveriesMutex.Unlock()
+	fake.NotificationDeliveriesStub = stub
+}
+
+func (fake *FakeTeam) NotificationDeliveriesArgsForCall(i int) (atc.PipelineRef, []atc.NotificationDeliveryStatus) {
+	fake.notificationDeliveriesMutex.RLock()
+	defer fake.notificationDeliveriesMutex.RUnlock()
+	argsForCall := fake.notificationDeliveriesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) NotificationDeliveriesReturns(result1 []atc.NotificationDelivery, result2 bool, result3 error) {
+	fake.notificationDeliveriesMutex.Lock()
+	defer fake.notificationDeliveriesMutex.Unlock()
+	fake.NotificationDeliveriesStub = nil
+	fake.notificationDeliveriesReturns = struct {
+		result1 []atc.NotificationDelivery
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) NotificationDeliveriesReturnsOnCall(i int, result1 []atc.NotificationDelivery, result2 bool, result3 error) {
+	fake.notificationDeliveriesMutex.Lock()
+	defer fake.notificationDeliveriesMutex.Unlock()
+	fake.NotificationDeliveriesStub = nil
+	if fake.notificationDeliveriesReturnsOnCall == nil {
+		fake.notificationDeliveriesReturnsOnCall = make(map[int]struct {
+			result1 []atc.NotificationDelivery
+			result2 bool
+			result3 error
+		})
+	}
+	fake.notificationDeliveriesReturnsOnCall[i] = struct {
+		result1 []atc.NotificationDelivery
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeTeam) OrderingPipelines(arg1 []string) error {
 	var arg1Copy []string
 	if arg1 != nil {
@@ -4028,6 +4298,211 @@ func (fake *FakeTeam) PipelineConfigReturnsOnCall(i int, result1 atc.Config, res
 	}{result1, result2, result3, result4}
 }
 
+func (fake *FakeTeam) PipelineConfigRevision(arg1 atc.PipelineRef, arg2 int) (atc.PipelineConfigRevision, bool, error) {
+	fake.pipelineConfigRevisionMutex.Lock()
+	ret, specificReturn := fake.pipelineConfigRevisionReturnsOnCall[len(fake.pipelineConfigRevisionArgsForCall)]
+	fake.pipelineConfigRevisionArgsForCall = append(fake.pipelineConfigRevisionArgsForCall, struct {
+		arg1 atc.PipelineRef
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.PipelineConfigRevisionStub
+	fakeReturns := fake.pipelineConfigRevisionReturns
+	fake.recordInvocation("PipelineConfigRevision", []interface{}{arg1, arg2})
+	fake.pipelineConfigRevisionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) PipelineConfigRevisionCallCount() int {
+	fake.pipelineConfigRevisionMutex.RLock()
+	defer fake.pipelineConfigRevisionMutex.RUnlock()
+	return len(fake.pipelineConfigRevisionArgsForCall)
+}
+
+func (fake *FakeTeam) PipelineConfigRevisionCalls(stub func(atc.PipelineRef, int) (atc.PipelineConfigRevision, bool, error)) {
+	fake.pipelineConfigRevisionMutex.Lock()
+	defer fake.pipelineConfigRevisionMutex.Unlock()
+	fake.PipelineConfigRevisionStub = stub
+}
+
+func (fake *FakeTeam) PipelineConfigRevisionArgsForCall(i int) (atc.PipelineRef, int) {
+	fake.pipelineConfigRevisionMutex.RLock()
+	defer fake.pipelineConfigRevisionMutex.RUnlock()
+	argsForCall := fake.pipelineConfigRevisionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) PipelineConfigRevisionReturns(result1 atc.PipelineConfigRevision, result2 bool, result3 error) {
+	fake.pipelineConfigRevisionMutex.Lock()
+	defer fake.pipelineConfigRevisionMutex.Unlock()
+	fake.PipelineConfigRevisionStub = nil
+	fake.pipelineConfigRevisionReturns = struct {
+		result1 atc.PipelineConfigRevision
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) PipelineConfigRevisionReturnsOnCall(i int, result1 atc.PipelineConfigRevision, result2 bool, result3 error) {
+	fake.pipelineConfigRevisionMutex.Lock()
+	defer fake.pipelineConfigRevisionMutex.Unlock()
+	fake.PipelineConfigRevisionStub = nil
+	if fake.pipelineConfigRevisionReturnsOnCall == nil {
+		fake.pipelineConfigRevisionReturnsOnCall = make(map[int]struct {
+			result1 atc.PipelineConfigRevision
+			result2 bool
+			result3 error
+		})
+	}
+	fake.pipelineConfigRevisionReturnsOnCall[i] = struct {
+		result1 atc.PipelineConfigRevision
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) PipelineConfigRevisions(arg1 atc.PipelineRef) ([]atc.PipelineConfigRevision, bool, error) {
+	fake.pipelineConfigRevisionsMutex.Lock()
+	ret, specificReturn := fake.pipelineConfigRevisionsReturnsOnCall[len(fake.pipelineConfigRevisionsArgsForCall)]
+	fake.pipelineConfigRevisionsArgsForCall = append(fake.pipelineConfigRevisionsArgsForCall, struct {
+		arg1 atc.PipelineRef
+	}{arg1})
+	stub := fake.PipelineConfigRevisionsStub
+	fakeReturns := fake.pipelineConfigRevisionsReturns
+	fake.recordInvocation("PipelineConfigRevisions", []interface{}{arg1})
+	fake.pipelineConfigRevisionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) PipelineConfigRevisionsCallCount() int {
+	fake.pipelineConfigRevisionsMutex.RLock()
+	defer fake.pipelineConfigRevisionsMutex.RUnlock()
+	return len(fake.pipelineConfigRevisionsArgsForCall)
+}
+
+func (fake *FakeTeam) PipelineConfigRevisionsCalls(stub func(atc.PipelineRef) ([]atc.PipelineConfigRevision, bool, error)) {
+	fake.pipelineConfigRevisionsMutex.Lock()
+	defer fake.pipelineConfigRevisionsMutex.Unlock()
+	fake.PipelineConfigRevisionsStub = stub
+}
+
+func (fake *FakeTeam) PipelineConfigRevisionsArgsForCall(i int) atc.PipelineRef {
+	fake.pipelineConfigRevisionsMutex.RLock()
+	defer fake.pipelineConfigRevisionsMutex.RUnlock()
+	argsForCall := fake.pipelineConfigRevisionsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) PipelineConfigRevisionsReturns(result1 []atc.PipelineConfigRevision, result2 bool, result3 error) {
+	fake.pipelineConfigRevisionsMutex.Lock()
+	defer fake.pipelineConfigRevisionsMutex.Unlock()
+	fake.PipelineConfigRevisionsStub = nil
+	fake.pipelineConfigRevisionsReturns = struct {
+		result1 []atc.PipelineConfigRevision
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) PipelineConfigRevisionsReturnsOnCall(i int, result1 []atc.PipelineConfigRevision, result2 bool, result3 error) {
+	fake.pipelineConfigRevisionsMutex.Lock()
+	defer fake.pipelineConfigRevisionsMutex.Unlock()
+	fake.PipelineConfigRevisionsStub = nil
+	if fake.pipelineConfigRevisionsReturnsOnCall == nil {
+		fake.pipelineConfigRevisionsReturnsOnCall = make(map[int]struct {
+			result1 []atc.PipelineConfigRevision
+			result2 bool
+			result3 error
+		})
+	}
+	fake.pipelineConfigRevisionsReturnsOnCall[i] = struct {
+		result1 []atc.PipelineConfigRevision
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) PlanPipelineConfig(arg1 atc.PipelineRef, arg2 []byte) (atc.ConfigPlan, error) {
+	var arg2Copy []byte
+	if arg2 != nil {
+		arg2Copy = make([]byte, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.planPipelineConfigMutex.Lock()
+	ret, specificReturn := fake.planPipelineConfigReturnsOnCall[len(fake.planPipelineConfigArgsForCall)]
+	fake.planPipelineConfigArgsForCall = append(fake.planPipelineConfigArgsForCall, struct {
+		arg1 atc.PipelineRef
+		arg2 []byte
+	}{arg1, arg2Copy})
+	stub := fake.PlanPipelineConfigStub
+	fakeReturns := fake.planPipelineConfigReturns
+	fake.recordInvocation("PlanPipelineConfig", []interface{}{arg1, arg2Copy})
+	fake.planPipelineConfigMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) PlanPipelineConfigCallCount() int {
+	fake.planPipelineConfigMutex.RLock()
+	defer fake.planPipelineConfigMutex.RUnlock()
+	return len(fake.planPipelineConfigArgsForCall)
+}
+
+func (fake *FakeTeam) PlanPipelineConfigCalls(stub func(atc.PipelineRef, []byte) (atc.ConfigPlan, error)) {
+	fake.planPipelineConfigMutex.Lock()
+	defer fake.planPipelineConfigMutex.Unlock()
+	fake.PlanPipelineConfigStub = stub
+}
+
+func (fake *FakeTeam) PlanPipelineConfigArgsForCall(i int) (atc.PipelineRef, []byte) {
+	fake.planPipelineConfigMutex.RLock()
+	defer fake.planPipelineConfigMutex.RUnlock()
+	argsForCall := fake.planPipelineConfigArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) PlanPipelineConfigReturns(result1 atc.ConfigPlan, result2 error) {
+	fake.planPipelineConfigMutex.Lock()
+	defer fake.planPipelineConfigMutex.Unlock()
+	fake.PlanPipelineConfigStub = nil
+	fake.planPipelineConfigReturns = struct {
+		result1 atc.ConfigPlan
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) PlanPipelineConfigReturnsOnCall(i int, result1 atc.ConfigPlan, result2 error) {
+	fake.planPipelineConfigMutex.Lock()
+	defer fake.planPipelineConfigMutex.Unlock()
+	fake.PlanPipelineConfigStub = nil
+	if fake.planPipelineConfigReturnsOnCall == nil {
+		fake.planPipelineConfigReturnsOnCall = make(map[int]struct {
+			result1 atc.ConfigPlan
+			result2 error
+		})
+	}
+	fake.planPipelineConfigReturnsOnCall[i] = struct {
+		result1 atc.ConfigPlan
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) RenamePipeline(arg1 string, arg2 string) (bool, []concourse.ConfigWarning, error) {
 	fake.renamePipelineMutex.Lock()
 	ret, specificReturn := fake.renamePipelineReturnsOnCall[len(fake.renamePipelineArgsForCall)]
@@ -4438,6 +4913,72 @@ func (fake *FakeTeam) ResourceVersionsReturnsOnCall(i int, result1 []atc.Resourc
 	}{result1, result2, result3, result4}
 }
 
+func (fake *FakeTeam) RestorePipelineConfigRevision(arg1 atc.PipelineRef, arg2 int, arg3 bool) (bool, error) {
+	fake.restorePipelineConfigRevisionMutex.Lock()
+	ret, specificReturn := fake.restorePipelineConfigRevisionReturnsOnCall[len(fake.restorePipelineConfigRevisionArgsForCall)]
+	fake.restorePipelineConfigRevisionArgsForCall = append(fake.restorePipelineConfigRevisionArgsForCall, struct {
+		arg1 atc.PipelineRef
+		arg2 int
+		arg3 bool
+	}{arg1, arg2, arg3})
+	stub := fake.RestorePipelineConfigRevisionStub
+	fakeReturns := fake.restorePipelineConfigRevisionReturns
+	fake.recordInvocation("RestorePipelineConfigRevision", []interface{}{arg1, arg2, arg3})
+	fake.restorePipelineConfigRevisionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) RestorePipelineConfigRevisionCallCount() int {
+	fake.restorePipelineConfigRevisionMutex.RLock()
+	defer fake.restorePipelineConfigRevisionMutex.RUnlock()
+	return len(fake.restorePipelineConfigRevisionArgsForCall)
+}
+
+func (fake *FakeTeam) RestorePipelineConfigRevisionCalls(stub func(atc.PipelineRef, int, bool) (bool, error)) {
+	fake.restorePipelineConfigRevisionMutex.Lock()
+	defer fake.restorePipelineConfigRevisionMutex.Unlock()
+	fake.RestorePipelineConfigRevisionStub = stub
+}
+
+func (fake *FakeTeam) RestorePipelineConfigRevisionArgsForCall(i int) (atc.PipelineRef, int, bool) {
+	fake.restorePipelineConfigRevisionMutex.RLock()
+	defer fake.restorePipelineConfigRevisionMutex.RUnlock()
+	argsForCall := fake.restorePipelineConfigRevisionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTeam) RestorePipelineConfigRevisionReturns(result1 bool, result2 error) {
+	fake.restorePipelineConfigRevisionMutex.Lock()
+	defer fake.restorePipelineConfigRevisionMutex.Unlock()
+	fake.RestorePipelineConfigRevisionStub = nil
+	fake.restorePipelineConfigRevisionReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) RestorePipelineConfigRevisionReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.restorePipelineConfigRevisionMutex.Lock()
+	defer fake.restorePipelineConfigRevisionMutex.Unlock()
+	fake.RestorePipelineConfigRevisionStub = nil
+	if fake.restorePipelineConfigRevisionReturnsOnCall == nil {
+		fake.restorePipelineConfigRevisionReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.restorePipelineConfigRevisionReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) ScheduleJob(arg1 atc.PipelineRef, arg2 string) (bool, error) {
 	fake.scheduleJobMutex.Lock()
 	ret, specificReturn := fake.scheduleJobReturnsOnCall[len(fake.scheduleJobArgsForCall)]
@@ -4503,6 +5044,138 @@ func (fake *FakeTeam) ScheduleJobReturnsOnCall(i int, result1 bool, result2 erro
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) SearchBuildLogs(arg1 atc.PipelineRef, arg2 concourse.BuildLogSearch) (atc.BuildLogSearchResults, bool, error) {
+	fake.searchBuildLogsMutex.Lock()
+	ret, specificReturn := fake.searchBuildLogsReturnsOnCall[len(fake.searchBuildLogsArgsForCall)]
+	fake.searchBuildLogsArgsForCall = append(fake.searchBuildLogsArgsForCall, struct {
+		arg1 atc.PipelineRef
+		arg2 concourse.BuildLogSearch
+	}{arg1, arg2})
+	stub := fake.SearchBuildLogsStub
+	fakeReturns := fake.searchBuildLogsReturns
+	fake.recordInvocation("SearchBuildLogs", []interface{}{arg1, arg2})
+	fake.searchBuildLogsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) SearchBuildLogsCallCount() int {
+	fake.searchBuildLogsMutex.RLock()
+	defer fake.searchBuildLogsMutex.RUnlock()
+	return len(fake.searchBuildLogsArgsForCall)
+}
+
+func (fake *FakeTeam) SearchBuildLogsCalls(stub func(atc.PipelineRef, concourse.BuildLogSearch) (atc.BuildLogSearchResults, bool, error)) {
+	fake.searchBuildLogsMutex.Lock()
+	defer fake.searchBuildLogsMutex.Unlock()
+	fake.SearchBuildLogsStub = stub
+}
+
+func (fake *FakeTeam) SearchBuildLogsArgsForCall(i int) (atc.PipelineRef, concourse.BuildLogSearch) {
+	fake.searchBuildLogsMutex.RLock()
+	defer fake.searchBuildLogsMutex.RUnlock()
+	argsForCall := fake.searchBuildLogsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) SearchBuildLogsReturns(result1 atc.BuildLogSearchResults, result2 bool, result3 error) {
+	fake.searchBuildLogsMutex.Lock()
+	defer fake.searchBuildLogsMutex.Unlock()
+	fake.SearchBuildLogsStub = nil
+	fake.searchBuildLogsReturns = struct {
+		result1 atc.BuildLogSearchResults
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) SearchBuildLogsReturnsOnCall(i int, result1 atc.BuildLogSearchResults, result2 bool, result3 error) {
+	fake.searchBuildLogsMutex.Lock()
+	defer fake.searchBuildLogsMutex.Unlock()
+	fake.SearchBuildLogsStub = nil
+	if fake.searchBuildLogsReturnsOnCall == nil {
+		fake.searchBuildLogsReturnsOnCall = make(map[int]struct {
+			result1 atc.BuildLogSearchResults
+			result2 bool
+			result3 error
+		})
+	}
+	fake.searchBuildLogsReturnsOnCall[i] = struct {
+		result1 atc.BuildLogSearchResults
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) Secrets(arg1 string) ([]atc.Secret, error) {
+	fake.secretsMutex.Lock()
+	ret, specificReturn := fake.secretsReturnsOnCall[len(fake.secretsArgsForCall)]
+	fake.secretsArgsForCall = append(fake.secretsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.SecretsStub
+	fakeReturns := fake.secretsReturns
+	fake.recordInvocation("Secrets", []interface{}{arg1})
+	fake.secretsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) SecretsCallCount() int {
+	fake.secretsMutex.RLock()
+	defer fake.secretsMutex.RUnlock()
+	return len(fake.secretsArgsForCall)
+}
+
+func (fake *FakeTeam) SecretsCalls(stub func(string) ([]atc.Secret, error)) {
+	fake.secretsMutex.Lock()
+	defer fake.secretsMutex.Unlock()
+	fake.SecretsStub = stub
+}
+
+func (fake *FakeTeam) SecretsArgsForCall(i int) string {
+	fake.secretsMutex.RLock()
+	defer fake.secretsMutex.RUnlock()
+	argsForCall := fake.secretsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) SecretsReturns(result1 []atc.Secret, result2 error) {
+	fake.secretsMutex.Lock()
+	defer fake.secretsMutex.Unlock()
+	fake.SecretsStub = nil
+	fake.secretsReturns = struct {
+		result1 []atc.Secret
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) SecretsReturnsOnCall(i int, result1 []atc.Secret, result2 error) {
+	fake.secretsMutex.Lock()
+	defer fake.secretsMutex.Unlock()
+	fake.SecretsStub = nil
+	if fake.secretsReturnsOnCall == nil {
+		fake.secretsReturnsOnCall = make(map[int]struct {
+			result1 []atc.Secret
+			result2 error
+		})
+	}
+	fake.secretsReturnsOnCall[i] = struct {
+		result1 []atc.Secret
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) SetJobBuildComment(arg1 atc.PipelineRef, arg2 string, arg3 string, arg4 string) (bool, error) {
 	fake.setJobBuildCommentMutex.Lock()
 	ret, specificReturn := fake.setJobBuildCommentReturnsOnCall[len(fake.setJobBuildCommentArgsForCall)]
@@ -4636,6 +5309,69 @@ func (fake *FakeTeam) SetPinCommentReturnsOnCall(i int, result1 bool, result2 er
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) SetSecret(arg1 string, arg2 string, arg3 string) error {
+	fake.setSecretMutex.Lock()
+	ret, specificReturn := fake.setSecretReturnsOnCall[len(fake.setSecretArgsForCall)]
+	fake.setSecretArgsForCall = append(fake.setSecretArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.SetSecretStub
+	fakeReturns := fake.setSecretReturns
+	fake.recordInvocation("SetSecret", []interface{}{arg1, arg2, arg3})
+	fake.setSecretMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) SetSecretCallCount() int {
+	fake.setSecretMutex.RLock()
+	defer fake.setSecretMutex.RUnlock()
+	return len(fake.setSecretArgsForCall)
+}
+
+func (fake *FakeTeam) SetSecretCalls(stub func(string, string, string) error) {
+	fake.setSecretMutex.Lock()
+	defer fake.setSecretMutex.Unlock()
+	fake.SetSecretStub = stub
+}
+
+func (fake *FakeTeam) SetSecretArgsForCall(i int) (string, string, string) {
+	fake.setSecretMutex.RLock()
+	defer fake.setSecretMutex.RUnlock()
+	argsForCall := fake.setSecretArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTeam) SetSecretReturns(result1 error) {
+	fake.setSecretMutex.Lock()
+	defer fake.setSecretMutex.Unlock()
+	fake.SetSecretStub = nil
+	fake.setSecretReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) SetSecretReturnsOnCall(i int, result1 error) {
+	fake.setSecretMutex.Lock()
+	defer fake.setSecretMutex.Unlock()
+	fake.SetSecretStub = nil
+	if fake.setSecretReturnsOnCall == nil {
+		fake.setSecretReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setSecretReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeTeam) UnpauseJob(arg1 atc.PipelineRef, arg2 string) (bool, error) {
 	fake.unpauseJobMutex.Lock()
 	ret, specificReturn := fake.unpauseJobReturnsOnCall[len(fake.unpauseJobArgsForCall)]
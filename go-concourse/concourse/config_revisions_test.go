@@ -0,0 +1,197 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Config Revisions", func() {
+	pipelineRef := atc.PipelineRef{Name: "mypipeline", InstanceVars: atc.InstanceVars{"branch": "master"}}
+	queryParams := "vars.branch=%22master%22"
+
+	Describe("PipelineConfigRevisions", func() {
+		expectedURL := "/api/v1/teams/some-team/pipelines/mypipeline/config/revisions"
+
+		Context("when the pipeline exists", func() {
+			var expectedRevisions []atc.PipelineConfigRevision
+
+			BeforeEach(func() {
+				expectedRevisions = []atc.PipelineConfigRevision{
+					{
+						Revision:          2,
+						ConfigVersion:     5,
+						CreatedBy:         "some-user",
+						CreatedAt:         1234,
+						BuildID:           42,
+						BuildName:         "7",
+						BuildJobName:      "reconfigure",
+						BuildPipelineName: "mypipeline",
+					},
+					{
+						Revision:      1,
+						ConfigVersion: 3,
+						CreatedBy:     "some-user",
+						CreatedAt:     1000,
+					},
+				}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, queryParams),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedRevisions),
+					),
+				)
+			})
+
+			It("returns the revisions", func() {
+				revisions, found, err := team.PipelineConfigRevisions(pipelineRef)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(revisions).To(Equal(expectedRevisions))
+			})
+		})
+
+		Context("when the pipeline does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, queryParams),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns not found", func() {
+				_, found, err := team.PipelineConfigRevisions(pipelineRef)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("PipelineConfigRevision", func() {
+		expectedURL := "/api/v1/teams/some-team/pipelines/mypipeline/config/revisions/2"
+
+		Context("when the revision exists", func() {
+			var expectedRevision atc.PipelineConfigRevision
+
+			BeforeEach(func() {
+				expectedRevision = atc.PipelineConfigRevision{
+					Revision:      2,
+					ConfigVersion: 5,
+					CreatedBy:     "some-user",
+					CreatedAt:     1234,
+					Config: &atc.Config{
+						Jobs: atc.JobConfigs{{Name: "some-job"}},
+					},
+				}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, queryParams),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedRevision),
+					),
+				)
+			})
+
+			It("returns the revision with its config", func() {
+				revision, found, err := team.PipelineConfigRevision(pipelineRef, 2)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(revision).To(Equal(expectedRevision))
+			})
+		})
+
+		Context("when the revision does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, queryParams),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns not found", func() {
+				_, found, err := team.PipelineConfigRevision(pipelineRef, 2)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("RestorePipelineConfigRevision", func() {
+		expectedURL := "/api/v1/teams/some-team/pipelines/mypipeline/config/revisions/2/restore"
+
+		Context("when the revision is restored", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", expectedURL, queryParams),
+						ghttp.RespondWith(http.StatusOK, ""),
+					),
+				)
+			})
+
+			It("returns true", func() {
+				found, err := team.RestorePipelineConfigRevision(pipelineRef, 2, false)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+		})
+
+		Context("when checking credentials", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", expectedURL, "check_creds=&"+queryParams),
+						ghttp.RespondWith(http.StatusOK, ""),
+					),
+				)
+			})
+
+			It("asks for them to be checked", func() {
+				found, err := team.RestorePipelineConfigRevision(pipelineRef, 2, true)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+		})
+
+		Context("when the revision does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", expectedURL, queryParams),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns false", func() {
+				found, err := team.RestorePipelineConfigRevision(pipelineRef, 2, false)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when the pipeline has been configured in the meantime", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", expectedURL, queryParams),
+						ghttp.RespondWith(http.StatusConflict, ""),
+					),
+				)
+			})
+
+			It("returns an error", func() {
+				_, err := team.RestorePipelineConfigRevision(pipelineRef, 2, false)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+})
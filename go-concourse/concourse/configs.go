@@ -135,3 +135,58 @@ func merge(base, extra url.Values) url.Values {
 	}
 	return base
 }
+
+func (team *team) PlanPipelineConfig(pipelineRef atc.PipelineRef, passedConfig []byte) (atc.ConfigPlan, error) {
+	params := rata.Params{
+		"pipeline_name": pipelineRef.Name,
+		"team_name":     team.Name(),
+	}
+
+	response, err := team.httpAgent.Send(internal.Request{
+		ReturnResponseBody: true,
+		RequestName:        atc.PlanPipelineConfig,
+		Params:             params,
+		Query:              pipelineRef.QueryParams(),
+		Body:               bytes.NewBuffer(passedConfig),
+		Header: http.Header{
+			"Content-Type": {"application/x-yaml"},
+		},
+	})
+	if err != nil {
+		return atc.ConfigPlan{}, err
+	}
+
+	defer response.Body.Close()
+	body, _ := io.ReadAll(response.Body)
+
+	switch response.StatusCode {
+	case http.StatusOK:
+		var plan atc.ConfigPlan
+		err = json.Unmarshal(body, &plan)
+		if err != nil {
+			return atc.ConfigPlan{}, fmt.Errorf("parsing JSON: %w", err)
+		}
+		return plan, nil
+	case http.StatusBadRequest:
+		var validationErr atc.SaveConfigResponse
+		err = json.Unmarshal(body, &validationErr)
+		if err != nil {
+			return atc.ConfigPlan{}, internal.UnexpectedResponseError{
+				StatusCode: response.StatusCode,
+				Status:     response.Status,
+				Body:       string(body),
+			}
+		}
+		return atc.ConfigPlan{}, InvalidConfigError{Errors: validationErr.Errors}
+	case http.StatusForbidden:
+		return atc.ConfigPlan{}, internal.ForbiddenError{
+			Reason: string(body),
+		}
+	default:
+		return atc.ConfigPlan{}, internal.UnexpectedResponseError{
+			StatusCode: response.StatusCode,
+			Status:     response.Status,
+			Body:       string(body),
+		}
+	}
+}
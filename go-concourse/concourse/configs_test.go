@@ -404,4 +404,45 @@ var _ = Describe("ATC Handler Configs", func() {
 			})
 		})
 	})
+
+	Describe("PlanPipelineConfig", func() {
+		expectedPath := "/api/v1/teams/some-team/pipelines/mypipeline/config/plan"
+
+		Context("when the plan is returned", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", expectedPath),
+						ghttp.VerifyHeaderKV("Content-Type", "application/x-yaml"),
+						ghttp.VerifyBody([]byte("jobs: []")),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.ConfigPlan{
+							RemovedJobs: []atc.ConfigPlanJob{{Name: "some-job", Builds: 3}},
+						}),
+					),
+				)
+			})
+
+			It("returns the plan", func() {
+				plan, err := team.PlanPipelineConfig(pipelineRef, []byte("jobs: []"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(plan.RemovedJobs).To(Equal([]atc.ConfigPlanJob{{Name: "some-job", Builds: 3}}))
+			})
+		})
+
+		Context("when the config is invalid", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", expectedPath),
+						ghttp.RespondWith(http.StatusBadRequest, `{"errors":["some-error"]}`, http.Header{"Content-Type": {"application/json"}}),
+					),
+				)
+			})
+
+			It("returns the validation errors", func() {
+				_, err := team.PlanPipelineConfig(pipelineRef, []byte("jobs: []"))
+				Expect(err).To(Equal(concourse.InvalidConfigError{Errors: []string{"some-error"}}))
+			})
+		})
+	})
 })
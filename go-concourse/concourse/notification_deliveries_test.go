@@ -0,0 +1,69 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Notification Deliveries", func() {
+	Describe("NotificationDeliveries", func() {
+		expectedURL := "/api/v1/teams/some-team/pipelines/mypipeline/notification-deliveries"
+		queryParams := "status=failed&vars.branch=%22master%22"
+		pipelineRef := atc.PipelineRef{Name: "mypipeline", InstanceVars: atc.InstanceVars{"branch": "master"}}
+
+		Context("when the pipeline exists", func() {
+			var expectedDeliveries []atc.NotificationDelivery
+
+			BeforeEach(func() {
+				expectedDeliveries = []atc.NotificationDelivery{
+					{
+						ID:          1,
+						Name:        "slack",
+						Status:      atc.NotificationDeliveryFailed,
+						BuildID:     42,
+						BuildName:   "7",
+						JobName:     "some-job",
+						BuildStatus: atc.StatusFailed,
+						Attempts:    5,
+						LastError:   "unexpected response: 502 Bad Gateway",
+					},
+				}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, queryParams),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedDeliveries),
+					),
+				)
+			})
+
+			It("returns the deliveries", func() {
+				deliveries, found, err := team.NotificationDeliveries(pipelineRef, []atc.NotificationDeliveryStatus{atc.NotificationDeliveryFailed})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(deliveries).To(Equal(expectedDeliveries))
+			})
+		})
+
+		Context("when the pipeline does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, queryParams),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns not found", func() {
+				_, found, err := team.NotificationDeliveries(pipelineRef, []atc.NotificationDeliveryStatus{atc.NotificationDeliveryFailed})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+})
@@ -0,0 +1,103 @@
+package concourse
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+func (team *team) Secrets(pipelineName string) ([]atc.Secret, error) {
+	var secrets []atc.Secret
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.ListSecrets,
+		Params:      rata.Params{"team_name": team.Name()},
+		Query:       secretQuery(pipelineName),
+	}, &internal.Response{
+		Result: &secrets,
+	})
+
+	return secrets, err
+}
+
+func (team *team) SetSecret(pipelineName string, name string, value string) error {
+	buffer := &bytes.Buffer{}
+	err := json.NewEncoder(buffer).Encode(atc.SetSecretRequest{Value: value})
+	if err != nil {
+		return err
+	}
+
+	return team.connection.Send(internal.Request{
+		RequestName: atc.SetSecret,
+		Params: rata.Params{
+			"team_name":   team.Name(),
+			"secret_name": name,
+		},
+		Query: secretQuery(pipelineName),
+		Body:  buffer,
+		Header: http.Header{
+			"Content-Type": {"application/json"},
+		},
+	}, nil)
+}
+
+func (team *team) DeleteSecret(pipelineName string, name string) (bool, error) {
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.DeleteSecret,
+		Params: rata.Params{
+			"team_name":   team.Name(),
+			"secret_name": name,
+		},
+		Query: secretQuery(pipelineName),
+	}, nil)
+
+	switch err.(type) {
+	case nil:
+		return true, nil
+	case internal.ResourceNotFoundError:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+func (client *client) ListSecretUsages(varName string) ([]atc.SecretUsage, error) {
+	var usages []atc.SecretUsage
+	err := client.connection.Send(internal.Request{
+		RequestName: atc.ListSecretUsages,
+		Query:       url.Values{atc.SecretUsageVarQuery: {varName}},
+	}, &internal.Response{
+		Result: &usages,
+	})
+
+	return usages, err
+}
+
+func (client *client) ClearSecretCache(teamName string, varName string) error {
+	query := url.Values{}
+	if teamName != "" {
+		query.Set(atc.ClearSecretCacheTeamQuery, teamName)
+	}
+
+	if varName != "" {
+		query.Set(atc.ClearSecretCacheVarQuery, varName)
+	}
+
+	return client.connection.Send(internal.Request{
+		RequestName: atc.ClearSecretCache,
+		Query:       query,
+	}, &internal.Response{})
+}
+
+func secretQuery(pipelineName string) url.Values {
+	query := url.Values{}
+	if pipelineName != "" {
+		query.Set(atc.SecretPipelineQuery, pipelineName)
+	}
+
+	return query
+}
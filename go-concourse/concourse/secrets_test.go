@@ -0,0 +1,171 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Secrets", func() {
+	Describe("Secrets", func() {
+		expectedURL := "/api/v1/teams/some-team/secrets"
+
+		var expectedSecrets []atc.Secret
+
+		BeforeEach(func() {
+			expectedSecrets = []atc.Secret{
+				{Name: "some-secret", Pipeline: "some-pipeline", UpdatedBy: "some-user", UpdatedAt: 1000},
+			}
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", expectedURL, "pipeline=some-pipeline"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, expectedSecrets),
+				),
+			)
+		})
+
+		It("returns the secrets", func() {
+			secrets, err := team.Secrets("some-pipeline")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(secrets).To(Equal(expectedSecrets))
+		})
+	})
+
+	Describe("SetSecret", func() {
+		expectedURL := "/api/v1/teams/some-team/secrets/some-secret"
+
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", expectedURL, ""),
+					ghttp.VerifyJSONRepresenting(atc.SetSecretRequest{Value: "some-value"}),
+					ghttp.RespondWith(http.StatusNoContent, ""),
+				),
+			)
+		})
+
+		It("sets a team-scoped secret", func() {
+			err := team.SetSecret("", "some-secret", "some-value")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(atcServer.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+
+	Describe("DeleteSecret", func() {
+		expectedURL := "/api/v1/teams/some-team/secrets/some-secret"
+
+		Context("when the secret exists", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("DELETE", expectedURL, "pipeline=some-pipeline"),
+						ghttp.RespondWith(http.StatusNoContent, ""),
+					),
+				)
+			})
+
+			It("returns true", func() {
+				found, err := team.DeleteSecret("some-pipeline", "some-secret")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+		})
+
+		Context("when the secret does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("DELETE", expectedURL, "pipeline=some-pipeline"),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns false", func() {
+				found, err := team.DeleteSecret("some-pipeline", "some-secret")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("ListSecretUsages", func() {
+		expectedURL := "/api/v1/secret_usages"
+
+		var expectedUsages []atc.SecretUsage
+
+		BeforeEach(func() {
+			expectedUsages = []atc.SecretUsage{
+				{
+					Var:          "github.token",
+					TeamName:     "some-team",
+					PipelineID:   1,
+					PipelineName: "some-pipeline",
+					Jobs:         []string{"some-job"},
+					LastBuild:    &atc.SecretUsageBuild{ID: 42, Name: "7", JobName: "some-job", ResolvedAt: 1000},
+				},
+			}
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", expectedURL, "var=github.token"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, expectedUsages),
+				),
+			)
+		})
+
+		It("returns the usages of the var", func() {
+			usages, err := client.ListSecretUsages("github.token")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(usages).To(Equal(expectedUsages))
+		})
+	})
+
+	Describe("ClearSecretCache", func() {
+		expectedURL := "/api/v1/secret_cache"
+
+		It("clears the cached secrets of the team's var", func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("DELETE", expectedURL, "team=some-team&var=github.token"),
+					ghttp.RespondWith(http.StatusNoContent, ""),
+				),
+			)
+
+			err := client.ClearSecretCache("some-team", "github.token")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("clears every cached secret", func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("DELETE", expectedURL, ""),
+					ghttp.RespondWith(http.StatusNoContent, ""),
+				),
+			)
+
+			err := client.ClearSecretCache("", "")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the user is not an admin", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("DELETE", expectedURL),
+						ghttp.RespondWith(http.StatusForbidden, ""),
+					),
+				)
+			})
+
+			It("returns an error", func() {
+				err := client.ClearSecretCache("", "")
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+})
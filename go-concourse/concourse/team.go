@@ -30,9 +30,21 @@ type Team interface {
 	ListPipelines() ([]atc.Pipeline, error)
 	PipelineConfig(pipelineRef atc.PipelineRef) (atc.Config, string, bool, error)
 	CreateOrUpdatePipelineConfig(pipelineRef atc.PipelineRef, configVersion string, passedConfig []byte, checkCredentials bool) (bool, bool, []ConfigWarning, error)
+	PlanPipelineConfig(pipelineRef atc.PipelineRef, passedConfig []byte) (atc.ConfigPlan, error)
+	PipelineConfigRevisions(pipelineRef atc.PipelineRef) ([]atc.PipelineConfigRevision, bool, error)
+	PipelineConfigRevision(pipelineRef atc.PipelineRef, revision int) (atc.PipelineConfigRevision, bool, error)
+	RestorePipelineConfigRevision(pipelineRef atc.PipelineRef, revision int, checkCredentials bool) (bool, error)
 
 	CreatePipelineBuild(pipelineRef atc.PipelineRef, plan atc.Plan) (atc.Build, error)
 
+	NotificationDeliveries(pipelineRef atc.PipelineRef, statuses []atc.NotificationDeliveryStatus) ([]atc.NotificationDelivery, bool, error)
+
+	SearchBuildLogs(pipelineRef atc.PipelineRef, search BuildLogSearch) (atc.BuildLogSearchResults, bool, error)
+
+	Secrets(pipelineName string) ([]atc.Secret, error)
+	SetSecret(pipelineName string, name string, value string) error
+	DeleteSecret(pipelineName string, name string) (bool, error)
+
 	BuildInputsForJob(pipelineRef atc.PipelineRef, jobName string) ([]atc.BuildInput, bool, error)
 
 	Job(pipelineRef atc.PipelineRef, jobName string) (atc.Job, bool, error)
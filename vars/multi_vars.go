@@ -8,17 +8,22 @@ func NewMultiVars(varss []Variables) MultiVars {
 	return MultiVars{varss}
 }
 
-var _ Variables = MultiVars{}
+var _ ResolvingVariables = MultiVars{}
 
 func (m MultiVars) Get(ref Reference) (any, bool, error) {
+	val, _, found, err := m.Resolve(ref)
+	return val, found, err
+}
+
+func (m MultiVars) Resolve(ref Reference) (any, Resolution, bool, error) {
 	for _, vars := range m.varss {
-		val, found, err := vars.Get(ref)
+		val, resolution, found, err := Resolve(vars, ref)
 		if found || err != nil {
-			return val, found, err
+			return val, resolution, found, err
 		}
 	}
 
-	return nil, false, nil
+	return nil, Resolution{}, false, nil
 }
 
 func (m MultiVars) List() ([]Reference, error) {
@@ -70,6 +70,26 @@ var _ = Describe("MultiVariables", func() {
 		})
 	})
 
+	Describe("Resolve", func() {
+		It("reports the resolution of the source which found the var", func() {
+			vars := NewMultiVars([]Variables{
+				NamedVariables{"s1": StaticVariables{"key1": "val1"}},
+				StaticVariables{"key2": "val2"},
+			})
+
+			_, resolution, found, err := vars.Resolve(Reference{Source: "s1", Path: "key1"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(resolution).To(Equal(Resolution{Manager: "s1"}))
+
+			val, resolution, found, err := vars.Resolve(Reference{Path: "key2"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(val).To(Equal("val2"))
+			Expect(resolution).To(Equal(Resolution{}))
+		})
+	})
+
 	Describe("List", func() {
 		It("returns list of names from multiple vars with duplicates", func() {
 			defs, err := NewMultiVars(nil).List()
@@ -7,15 +7,24 @@ type NamedVariables map[string]Variables
 // the var_source name, and "foo" is the real var name that should be forwarded
 // to the underlying secret manager.
 func (m NamedVariables) Get(ref Reference) (any, bool, error) {
+	val, _, found, err := m.Resolve(ref)
+	return val, found, err
+}
+
+// Resolve is like Get, reporting the var_source as the manager which served
+// the var.
+func (m NamedVariables) Resolve(ref Reference) (any, Resolution, bool, error) {
 	if ref.Source == "" {
-		return nil, false, nil
+		return nil, Resolution{}, false, nil
 	}
 
 	if vars, ok := m[ref.Source]; ok {
-		return vars.Get(ref.WithoutSource())
+		val, resolution, found, err := Resolve(vars, ref.WithoutSource())
+		resolution.Manager = ref.Source
+		return val, resolution, found, err
 	}
 
-	return nil, false, MissingSourceError{Name: ref.String(), Source: ref.Source}
+	return nil, Resolution{}, false, MissingSourceError{Name: ref.String(), Source: ref.Source}
 }
 
 func (m NamedVariables) List() ([]Reference, error) {
@@ -69,6 +69,18 @@ var _ = Describe("NamedVariables", func() {
 		})
 	})
 
+	Describe("Resolve", func() {
+		It("reports the var source as the manager", func() {
+			vars := NamedVariables{"s1": StaticVariables{"key1": "val"}}
+
+			val, resolution, found, err := vars.Resolve(Reference{Source: "s1", Path: "key1"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(val).To(Equal("val"))
+			Expect(resolution).To(Equal(Resolution{Manager: "s1"}))
+		})
+	})
+
 	Describe("List", func() {
 		It("returns list of names from multiple vars with duplicates", func() {
 			defs, err := NamedVariables{}.List()
@@ -0,0 +1,28 @@
+package vars
+
+// Resolution describes where a var was resolved from, without its value.
+type Resolution struct {
+	// Manager is the credential manager or var_source which served the var.
+	Manager string
+
+	// SecretPath is the path of the secret in the manager.
+	SecretPath string
+}
+
+// ResolvingVariables are Variables which can report where a var was resolved
+// from.
+type ResolvingVariables interface {
+	Variables
+	Resolve(Reference) (any, Resolution, bool, error)
+}
+
+// Resolve gets the var, along with where it was resolved from if the
+// variables are able to tell.
+func Resolve(variables Variables, ref Reference) (any, Resolution, bool, error) {
+	if resolving, ok := variables.(ResolvingVariables); ok {
+		return resolving.Resolve(ref)
+	}
+
+	val, found, err := variables.Get(ref)
+	return val, Resolution{}, found, err
+}
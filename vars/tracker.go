@@ -13,11 +13,13 @@ type Tracker struct {
 	// Considering in-parallel steps, a lock is need.
 	lock              sync.RWMutex
 	interpolatedCreds map[string]string
+	references        map[string]Reference
 }
 
 func NewTracker() *Tracker {
 	return &Tracker{
 		interpolatedCreds: map[string]string{},
+		references:        map[string]Reference{},
 	}
 }
 
@@ -25,6 +27,7 @@ func (t *Tracker) Track(varRef Reference, val any) {
 	t.lock.Lock()
 	defer t.lock.Unlock()
 
+	t.references[varRef.String()] = varRef
 	t.track(varRef, val)
 }
 
@@ -61,23 +64,45 @@ func (t *Tracker) IterateInterpolatedCreds(iter TrackedVarsIterator) {
 	}
 }
 
+// References returns the vars which have been tracked, without their values.
+func (t *Tracker) References() []Reference {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	refs := make([]Reference, 0, len(t.references))
+	for _, ref := range t.references {
+		refs = append(refs, ref)
+	}
+
+	return refs
+}
+
 type CredVarsTracker struct {
 	*Tracker
 	CredVars Variables
 }
 
 func (t *CredVarsTracker) Get(ref Reference) (any, bool, error) {
-	val, found, err := t.CredVars.Get(ref)
+	val, _, found, err := t.Resolve(ref)
+	return val, found, err
+}
+
+func (t *CredVarsTracker) Resolve(ref Reference) (any, Resolution, bool, error) {
+	val, resolution, found, err := Resolve(t.CredVars, ref)
 	if found {
 		t.Tracker.Track(ref, val)
 	}
-	return val, found, err
+	return val, resolution, found, err
 }
 
 func (t *CredVarsTracker) List() ([]Reference, error) {
 	return t.CredVars.List()
 }
 
+func (t *CredVarsTracker) ResolvedCredVars() []Reference {
+	return t.Tracker.References()
+}
+
 // TrackedVarsMap is a TrackedVarsIterator which populates interpolated secrets into a map.
 // If there are multiple secrets with the same name, it only keeps the first value.
 type TrackedVarsMap map[string]string
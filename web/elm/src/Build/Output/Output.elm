@@ -180,6 +180,33 @@ handleEvent event ( model, effects ) =
             , effects
             )
 
+        ApprovalPending origin name time ->
+            ( updateStep origin.id (setRunning << appendStepLog ("\u{001B}[1mwaiting for approval: \u{001B}[0m" ++ name ++ "\n") time) model
+            , effects
+            )
+
+        ApprovalDecided origin status decidedBy time ->
+            let
+                message =
+                    case ( status, decidedBy ) of
+                        ( "approved", Just user ) ->
+                            "\u{001B}[1mapproved by \u{001B}[0m" ++ user ++ "\n"
+
+                        ( "rejected", Just user ) ->
+                            "\u{001B}[1mrejected by \u{001B}[0m" ++ user ++ "\n"
+
+                        _ ->
+                            "\u{001B}[1mapproval " ++ status ++ "\u{001B}[0m\n"
+            in
+            ( updateStep origin.id (appendStepLog message time) model
+            , effects
+            )
+
+        Skipped origin condition time ->
+            ( updateStep origin.id (setStepState StepStateSkipped << setStepFinish time << appendStepLog ("\u{001B}[1mskipped: \u{001B}[0m" ++ condition ++ " \u{001B}[1mis false\u{001B}[0m\n") time) model
+            , effects
+            )
+
         Error origin message time ->
             ( updateStep origin.id (setStepError message time) model
             , effects
@@ -285,6 +312,10 @@ handleEvent event ( model, effects ) =
             , effects
             )
 
+        SecretsResolved _ ->
+            -- recorded for auditing only
+            ( model, effects )
+
         End ->
             ( { model | state = StepsComplete, eventStreamUrlPath = Nothing }
             , effects
@@ -52,6 +52,7 @@ type StepTree
     | Put StepID
     | SetPipeline StepID
     | LoadVar StepID
+    | Approve StepID
     | ArtifactInput StepID
     | ArtifactOutput StepID
     | Aggregate (Array StepTree)
@@ -66,6 +67,7 @@ type StepTree
     | Ensure HookedStep
     | Try StepTree
     | Timeout StepTree
+    | If StepID StepTree
 
 
 type alias HookedStep =
@@ -113,6 +115,7 @@ type StepState
     | StepStateSucceeded
     | StepStateFailed
     | StepStateErrored
+    | StepStateSkipped
 
 
 showStepState : StepState -> String
@@ -139,6 +142,9 @@ showStepState state =
         StepStateErrored ->
             "errored"
 
+        StepStateSkipped ->
+            "skipped"
+
 
 stepStateOrdering : Ordering StepState
 stepStateOrdering =
@@ -150,6 +156,7 @@ stepStateOrdering =
         , StepStateRunning
         , StepStatePending
         , StepStateSucceeded
+        , StepStateSkipped
         ]
 
 
@@ -210,6 +217,9 @@ type BuildEvent
     | StartPut Origin Time.Posix
     | FinishPut Origin Int Concourse.Version Concourse.Metadata (Maybe Time.Posix)
     | SetPipelineChanged Origin Bool
+    | ApprovalPending Origin String (Maybe Time.Posix)
+    | ApprovalDecided Origin String (Maybe String) (Maybe Time.Posix)
+    | Skipped Origin String (Maybe Time.Posix)
     | Log Origin String (Maybe Time.Posix)
     | WaitingForWorker Origin (Maybe Time.Posix)
     | SelectedWorker Origin String (Maybe Time.Posix)
@@ -219,6 +229,7 @@ type BuildEvent
     | ImageCheck Origin Concourse.BuildPlan
     | ImageGet Origin Concourse.BuildPlan
     | AcrossSubsteps Origin (List Concourse.AcrossSubstep)
+    | SecretsResolved Origin
     | End
     | Opened
     | NetworkError
@@ -289,6 +300,9 @@ activeStepIds model tree =
         LoadVar stepId ->
             [ stepId ]
 
+        Approve stepId ->
+            [ stepId ]
+
         Aggregate trees ->
             List.concatMap (activeStepIds model) (Array.toList trees)
 
@@ -322,6 +336,14 @@ activeStepIds model tree =
         Timeout subTree ->
             activeStepIds model subTree
 
+        If stepId subTree ->
+            case Dict.get stepId model.steps |> Maybe.map .state of
+                Just StepStateSkipped ->
+                    [ stepId ]
+
+                _ ->
+                    activeStepIds model subTree
+
         Retry _ trees ->
             trees
                 |> Array.toList
@@ -369,6 +391,9 @@ updateTreeNodeAt id fn tree =
         LoadVar stepId ->
             updateSelf stepId
 
+        Approve stepId ->
+            updateSelf stepId
+
         Aggregate trees ->
             Aggregate <| Array.map (updateTreeNodeAt id fn) trees
 
@@ -425,6 +450,9 @@ updateTreeNodeAt id fn tree =
         Timeout subTree ->
             Timeout <| updateTreeNodeAt id fn subTree
 
+        If stepId subTree ->
+            If stepId <| updateTreeNodeAt id fn subTree
+
         Retry stepId trees ->
             let
                 withUpdatedChildren =
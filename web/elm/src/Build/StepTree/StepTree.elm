@@ -108,6 +108,9 @@ init buildId hl resources plan =
         Concourse.BuildStepLoadVar _ ->
             step |> initBottom buildId hl resources plan LoadVar
 
+        Concourse.BuildStepApprove _ ->
+            step |> initBottom buildId hl resources plan Approve
+
         Concourse.BuildStepAggregate plans ->
             initMultiStep buildId hl resources plan.id Aggregate plans Nothing
 
@@ -178,6 +181,10 @@ init buildId hl resources plan =
         Concourse.BuildStepTimeout subPlan ->
             initWrappedStep buildId hl resources Timeout subPlan
 
+        Concourse.BuildStepIf _ subPlan ->
+            initWrappedStep buildId hl resources (If plan.id) subPlan
+                |> (\model -> { model | steps = Dict.insert plan.id step model.steps })
+
 
 setImagePlans : Maybe Concourse.JobBuildIdentifier -> StepID -> Maybe Concourse.ImageBuildPlans -> StepTreeModel -> StepTreeModel
 setImagePlans buildId stepId imagePlans model =
@@ -598,6 +605,9 @@ viewTree session model tree depth =
         LoadVar stepId ->
             viewStep model session depth stepId
 
+        Approve stepId ->
+            viewStep model session depth stepId
+
         Try subTree ->
             viewTree session model subTree depth
 
@@ -663,6 +673,15 @@ viewTree session model tree depth =
         Timeout subTree ->
             viewTree session model subTree depth
 
+        If stepId subTree ->
+            assumeStep model stepId <|
+                \step ->
+                    if step.state == StepStateSkipped then
+                        viewStep model session depth stepId
+
+                    else
+                        viewTree session model subTree depth
+
         Aggregate trees ->
             Html.div [ class "aggregate" ]
                 (Array.toList <| Array.map (viewSeq session model depth) trees)
@@ -1210,6 +1229,16 @@ viewStepState state stepID =
                     ++ attributes
                 )
 
+        StepStateSkipped ->
+            Icon.icon
+                { sizePx = 14
+                , image = Assets.CancelledIcon
+                }
+                (attribute "data-step-state" "skipped"
+                    :: Styles.stepStatusIcon
+                    ++ attributes
+                )
+
         StepStateSucceeded ->
             Icon.icon
                 { sizePx = 14
@@ -1284,6 +1313,9 @@ viewStepHeader step =
         Concourse.BuildStepLoadVar name ->
             simpleHeader "load_var:" Nothing name
 
+        Concourse.BuildStepApprove name ->
+            simpleHeader "approve:" Nothing name
+
         Concourse.BuildStepCheck name _ ->
             simpleHeader "check:" Nothing name
 
@@ -1338,6 +1370,9 @@ viewStepHeader step =
         Concourse.BuildStepTimeout _ ->
             Html.text ""
 
+        Concourse.BuildStepIf condition _ ->
+            simpleHeader "if:" Nothing condition
+
 
 stepName : Concourse.BuildStep -> Maybe String
 stepName header =
@@ -1351,6 +1386,9 @@ stepName header =
         Concourse.BuildStepLoadVar name ->
             Just name
 
+        Concourse.BuildStepApprove name ->
+            Just name
+
         Concourse.BuildStepArtifactInput name ->
             Just name
 
@@ -1405,6 +1443,9 @@ stepName header =
         Concourse.BuildStepTimeout _ ->
             Nothing
 
+        Concourse.BuildStepIf condition _ ->
+            Just condition
+
 
 resourceName : Concourse.BuildStep -> Maybe String
 resourceName step =
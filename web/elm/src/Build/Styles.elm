@@ -243,6 +243,9 @@ stepHeader state =
 
             StepStateSucceeded ->
                 "transparent"
+
+            StepStateSkipped ->
+                "transparent"
     ]
 
 
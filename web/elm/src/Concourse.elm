@@ -401,6 +401,9 @@ mapBuildPlan fn plan =
                 BuildStepLoadVar _ ->
                     []
 
+                BuildStepApprove _ ->
+                    []
+
                 BuildStepArtifactInput _ ->
                     []
 
@@ -470,6 +473,9 @@ mapBuildPlan fn plan =
 
                 BuildStepTimeout step ->
                     mapBuildPlan fn step
+
+                BuildStepIf _ step ->
+                    mapBuildPlan fn step
            )
 
 
@@ -491,6 +497,7 @@ type BuildStep
     = BuildStepTask StepName
     | BuildStepSetPipeline StepName (Maybe TeamName) InstanceVars
     | BuildStepLoadVar StepName
+    | BuildStepApprove StepName
     | BuildStepArtifactInput StepName
     | BuildStepCheck StepName (Maybe ImageBuildPlans)
     | BuildStepGet StepName (Maybe ResourceName) (Maybe Version) (Maybe ImageBuildPlans)
@@ -509,6 +516,7 @@ type BuildStep
     | BuildStepTry BuildPlan
     | BuildStepRetry (Array BuildPlan)
     | BuildStepTimeout BuildPlan
+    | BuildStepIf String BuildPlan
 
 
 type alias HookedPlan =
@@ -704,10 +712,14 @@ decodeBuildPlan =
                     lazy (\_ -> decodeBuildStepRetry)
                 , Json.Decode.field "timeout" <|
                     lazy (\_ -> decodeBuildStepTimeout)
+                , Json.Decode.field "if" <|
+                    lazy (\_ -> decodeBuildStepIf)
                 , Json.Decode.field "set_pipeline" <|
                     lazy (\_ -> decodeBuildSetPipeline)
                 , Json.Decode.field "load_var" <|
                     lazy (\_ -> decodeBuildStepLoadVar)
+                , Json.Decode.field "approve" <|
+                    lazy (\_ -> decodeBuildStepApprove)
                 , Json.Decode.field "across" <|
                     lazy (\_ -> decodeBuildStepAcross)
                 ]
@@ -850,6 +862,13 @@ decodeBuildStepTimeout =
         |> andMap (Json.Decode.field "step" <| lazy (\_ -> decodeBuildPlan))
 
 
+decodeBuildStepIf : Json.Decode.Decoder BuildStep
+decodeBuildStepIf =
+    Json.Decode.succeed BuildStepIf
+        |> andMap (Json.Decode.field "condition" Json.Decode.string)
+        |> andMap (Json.Decode.field "step" <| lazy (\_ -> decodeBuildPlan))
+
+
 decodeBuildSetPipeline : Json.Decode.Decoder BuildStep
 decodeBuildSetPipeline =
     Json.Decode.succeed BuildStepSetPipeline
@@ -875,6 +894,12 @@ decodeBuildStepLoadVar =
         |> andMap (Json.Decode.field "name" Json.Decode.string)
 
 
+decodeBuildStepApprove : Json.Decode.Decoder BuildStep
+decodeBuildStepApprove =
+    Json.Decode.succeed BuildStepApprove
+        |> andMap (Json.Decode.field "name" Json.Decode.string)
+
+
 decodeBuildStepAcross : Json.Decode.Decoder BuildStep
 decodeBuildStepAcross =
     Json.Decode.map BuildStepAcross
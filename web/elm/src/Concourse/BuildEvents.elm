@@ -230,6 +230,34 @@ decodeBuildEvent =
                                 (Json.Decode.field "changed" Json.Decode.bool)
                             )
 
+                    "approval-pending" ->
+                        Json.Decode.field
+                            "data"
+                            (Json.Decode.map3 ApprovalPending
+                                (Json.Decode.field "origin" decodeOrigin)
+                                (Json.Decode.field "name" Json.Decode.string)
+                                (Json.Decode.maybe <| Json.Decode.field "time" <| Json.Decode.map dateFromSeconds Json.Decode.int)
+                            )
+
+                    "approval-decided" ->
+                        Json.Decode.field
+                            "data"
+                            (Json.Decode.map4 ApprovalDecided
+                                (Json.Decode.field "origin" decodeOrigin)
+                                (Json.Decode.field "status" Json.Decode.string)
+                                (Json.Decode.maybe <| Json.Decode.field "decided_by" Json.Decode.string)
+                                (Json.Decode.maybe <| Json.Decode.field "time" <| Json.Decode.map dateFromSeconds Json.Decode.int)
+                            )
+
+                    "skipped" ->
+                        Json.Decode.field
+                            "data"
+                            (Json.Decode.map3 Skipped
+                                (Json.Decode.field "origin" decodeOrigin)
+                                (Json.Decode.field "condition" Json.Decode.string)
+                                (Json.Decode.maybe <| Json.Decode.field "time" <| Json.Decode.map dateFromSeconds Json.Decode.int)
+                            )
+
                     "image-check" ->
                         Json.Decode.field "data"
                             (Json.Decode.map2 ImageCheck
@@ -253,6 +281,12 @@ decodeBuildEvent =
                                 )
                             )
 
+                    "secrets-resolved" ->
+                        Json.Decode.field "data"
+                            (Json.Decode.map SecretsResolved
+                                (Json.Decode.field "origin" decodeOrigin)
+                            )
+
                     unknown ->
                         Json.Decode.fail ("unknown event type: " ++ unknown)
             )
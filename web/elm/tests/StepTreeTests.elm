@@ -4,6 +4,7 @@ module StepTreeTests exposing
     , initAggregateNested
     , initEnsure
     , initGet
+    , initIf
     , initInParallel
     , initInParallelNested
     , initOnFailure
@@ -31,6 +32,7 @@ all =
         [ initTask
         , initSetPipeline
         , initLoadVar
+        , initApprove
         , initCheck
         , initRun
         , initGet
@@ -47,6 +49,7 @@ all =
         , initEnsure
         , initTry
         , initTimeout
+        , initIf
         ]
 
 
@@ -165,6 +168,30 @@ initLoadVar =
         ]
 
 
+initApprove : Test
+initApprove =
+    let
+        step =
+            BuildStepApprove "some-name"
+
+        { tree, steps } =
+            StepTree.init Nothing
+                Routes.HighlightNothing
+                emptyResources
+                { id = "some-id"
+                , step = step
+                }
+    in
+    describe "init with Approve"
+        [ test "the tree" <|
+            \_ ->
+                Expect.equal (Models.Approve "some-id") tree
+        , test "the step" <|
+            \_ ->
+                assertSteps [ someStep "some-id" step Models.StepStatePending ] steps
+        ]
+
+
 initCheck : Test
 initCheck =
     let
@@ -791,6 +818,36 @@ initTimeout =
         ]
 
 
+initIf : Test
+initIf =
+    let
+        { tree, steps } =
+            StepTree.init Nothing
+                Routes.HighlightNothing
+                emptyResources
+                { id = "if-id"
+                , step =
+                    BuildStepIf "vars.deploy" { id = "task-a-id", step = task "a" }
+                }
+    in
+    describe "init with If"
+        [ test "the tree" <|
+            \_ ->
+                Expect.equal
+                    (Models.If "if-id" <|
+                        Models.Task "task-a-id"
+                    )
+                    tree
+        , test "the steps" <|
+            \_ ->
+                assertSteps
+                    [ someStep "if-id" (BuildStepIf "vars.deploy" { id = "task-a-id", step = task "a" }) Models.StepStatePending
+                    , someStep "task-a-id" (task "a") Models.StepStatePending
+                    ]
+                    steps
+        ]
+
+
 assertSteps : List Models.Step -> Dict Routes.StepID Models.Step -> Expectation
 assertSteps expected actual =
     Expect.equalDicts (Dict.fromList (List.map (\s -> ( s.id, s )) expected)) actual
@@ -612,6 +612,20 @@ function createGraph(svg, jobs, resources) {
         for (var p in input.passed) {
           var sourceJobNode = jobNode(input.passed[p]);
 
+          if (isExternalJob(input.passed[p]) && !graph.node(sourceJobNode)) {
+            graph.setNode(
+              sourceJobNode,
+              new GraphNode({
+                id: sourceJobNode,
+                name: input.passed[p],
+                class: "job external",
+                status: "external",
+                url: externalJobURL(job.team_name, input.passed[p]),
+                svg: svg,
+              }),
+            );
+          }
+
           var sourceOutputNode = outputNode(input.passed[p], input.resource);
           var sourceInputNode = inputNode(input.passed[p], input.resource);
 
@@ -754,6 +768,53 @@ function jobNode(name) {
   return "job-" + name;
 }
 
+// jobs in other pipelines are named as <pipeline>/<job>, or as
+// <pipeline>/<instance vars>/<job> for instanced pipelines
+function isExternalJob(name) {
+  return name.includes("/");
+}
+
+function externalJobURL(teamName, name) {
+  var jobIdx = name.lastIndexOf("/");
+  var pipeline = name.substring(0, jobIdx);
+  var job = name.substring(jobIdx + 1);
+
+  var query = "";
+  var varsIdx = pipeline.indexOf("/");
+  if (varsIdx !== -1) {
+    query = "?" + instanceVarsParams(pipeline.substring(varsIdx + 1));
+    pipeline = pipeline.substring(0, varsIdx);
+  }
+
+  return (
+    "/teams/" +
+    teamName +
+    "/pipelines/" +
+    encodeURIComponent(pipeline) +
+    "/jobs/" +
+    encodeURIComponent(job) +
+    query
+  );
+}
+
+// converts instance vars formatted as key:value,key:value into query params
+function instanceVarsParams(instanceVars) {
+  var params = [];
+  var pairs = instanceVars.match(/("[^"]*"|[^,])+/g) || [];
+  for (var pair of pairs) {
+    var colonIdx = pair.indexOf(":");
+    var key = pair.substring(0, colonIdx);
+    var value = pair.substring(colonIdx + 1);
+    try {
+      JSON.parse(value);
+    } catch (e) {
+      value = JSON.stringify(value);
+    }
+    params.push("vars." + key + "=" + encodeURIComponent(value));
+  }
+  return params.join("&");
+}
+
 function gatewayNode(jobNames) {
   return "gateway-" + jobNames.sort().join("-");
 }